@@ -287,6 +287,7 @@ export interface AuthSettings {
 
   disableLogin?: boolean;
   passwordlessEnabled?: boolean;
+  webAuthnEnabled?: boolean;
   basicAuthStrongPasswordPolicy?: boolean;
   disableSignoutMenu?: boolean;
 }
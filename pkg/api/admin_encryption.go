@@ -1,11 +1,13 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/grafana/grafana/pkg/api/response"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/secrets"
 )
 
 func (hs *HTTPServer) AdminRotateDataEncryptionKeys(c *contextmodel.ReqContext) response.Response {
@@ -17,7 +19,18 @@ func (hs *HTTPServer) AdminRotateDataEncryptionKeys(c *contextmodel.ReqContext)
 }
 
 func (hs *HTTPServer) AdminReEncryptEncryptionKeys(c *contextmodel.ReqContext) response.Response {
-	if err := hs.SecretsService.ReEncryptDataKeys(c.Req.Context()); err != nil {
+	var err error
+	// The data keys are re-encrypted with the current provider, unless another one is requested.
+	if provider := c.Query("provider"); provider != "" {
+		err = hs.SecretsService.ReEncryptDataKeysWithProvider(c.Req.Context(), secrets.ProviderID(provider))
+	} else {
+		err = hs.SecretsService.ReEncryptDataKeys(c.Req.Context())
+	}
+
+	if err != nil {
+		if errors.Is(err, secrets.ErrProviderNotConfigured) {
+			return response.Error(http.StatusBadRequest, "Encryption provider is not configured", err)
+		}
 		return response.Error(http.StatusInternalServerError, "Failed to re-encrypt data keys", err)
 	}
 
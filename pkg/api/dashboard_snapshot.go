@@ -7,6 +7,8 @@ import (
 	"strconv"
 	"time"
 
+	claims "github.com/grafana/authlib/types"
+
 	"github.com/grafana/grafana/pkg/api/dtos"
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
@@ -18,6 +20,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/dashboardsnapshots"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/util"
 	"github.com/grafana/grafana/pkg/util/errhttp"
 	"github.com/grafana/grafana/pkg/web"
@@ -27,7 +30,13 @@ import (
 func (hs *HTTPServer) getCreatedSnapshotHandler() web.Handler {
 	if hs.Features.IsEnabledGlobally(featuremgmt.FlagKubernetesSnapshots) {
 		namespaceMapper := request.GetNamespaceMapper(hs.Cfg)
-		return func(w http.ResponseWriter, r *http.Request) {
+		return func(c *contextmodel.ReqContext) {
+			// Published snapshots are not supported by the snapshots API
+			if hs.isPublishedSnapshot(c) {
+				hs.CreateDashboardSnapshot(c)
+				return
+			}
+			w, r := c.Resp, c.Req
 			user, err := identity.GetRequester(r.Context())
 			if err != nil || user == nil {
 				errhttp.Write(r.Context(), fmt.Errorf("no user"), w)
@@ -75,6 +84,11 @@ func (hs *HTTPServer) CreateDashboardSnapshot(c *contextmodel.ReqContext) {
 		return
 	}
 
+	if hs.isPublishedSnapshot(c) {
+		hs.createPublishedSnapshot(c, cmd)
+		return
+	}
+
 	// Do not check permissions when the instance snapshot public mode is enabled
 	if !hs.Cfg.SnapshotPublicMode {
 		evaluator := ac.EvalAll(ac.EvalPermission(dashboards.ActionSnapshotsCreate), ac.EvalPermission(dashboards.ActionDashboardsRead, dashboards.ScopeDashboardsProvider.GetResourceScopeUID(cmd.Dashboard.GetNestedString("uid"))))
@@ -84,6 +98,7 @@ func (hs *HTTPServer) CreateDashboardSnapshot(c *contextmodel.ReqContext) {
 		}
 	}
 
+	cmd.ExternalAPIKey = hs.Cfg.ExternalSnapshotAPIKey
 	dashboardsnapshots.CreateDashboardSnapshot(c, dashboardsnapshot.SnapshotSharingOptions{
 		SnapshotsEnabled:     hs.Cfg.SnapshotEnabled,
 		ExternalEnabled:      hs.Cfg.ExternalEnabled,
@@ -92,6 +107,78 @@ func (hs *HTTPServer) CreateDashboardSnapshot(c *contextmodel.ReqContext) {
 	}, cmd, hs.dashboardsnapshotsService)
 }
 
+// isPublishedSnapshot returns true when the snapshot is published to this instance by another
+// Grafana instance. In external snapshot server mode, other instances authenticate with a
+// service account token.
+func (hs *HTTPServer) isPublishedSnapshot(c *contextmodel.ReqContext) bool {
+	return hs.Cfg.SnapshotExternalServerEnabled && c.SignedInUser != nil &&
+		c.SignedInUser.IsIdentityType(claims.TypeServiceAccount, claims.TypeAPIKey)
+}
+
+// createPublishedSnapshot stores a snapshot published by another Grafana instance. The
+// dashboard of the snapshot belongs to the other instance, so it is stored as is.
+func (hs *HTTPServer) createPublishedSnapshot(c *contextmodel.ReqContext, cmd dashboardsnapshots.CreateDashboardSnapshotCommand) {
+	if !hs.Cfg.SnapshotEnabled {
+		c.JsonApiErr(http.StatusForbidden, "Dashboard Snapshots are disabled", nil)
+		return
+	}
+
+	if canCreate, err := hs.AccessControl.Evaluate(c.Req.Context(), c.SignedInUser, ac.EvalPermission(dashboards.ActionSnapshotsCreate)); err != nil || !canCreate {
+		c.JsonApiErr(http.StatusForbidden, "forbidden", err)
+		return
+	}
+
+	if cmd.Name == "" {
+		cmd.Name = "Unnamed snapshot"
+	}
+
+	cmd.External = false
+	cmd.ExternalURL = ""
+	cmd.ExternalDeleteURL = ""
+	cmd.OrgID = c.SignedInUser.GetOrgID()
+	cmd.UserID, _ = identity.UserIdentifier(c.SignedInUser.GetID())
+	cmd.Expires = publishedSnapshotExpires(cmd.Expires, hs.Cfg.SnapshotExternalServerMaxExpires)
+
+	var err error
+	if cmd.Key == "" {
+		if cmd.Key, err = util.GetRandomString(32); err != nil {
+			c.JsonApiErr(http.StatusInternalServerError, "Could not generate random string", err)
+			return
+		}
+	}
+	if cmd.DeleteKey == "" {
+		if cmd.DeleteKey, err = util.GetRandomString(32); err != nil {
+			c.JsonApiErr(http.StatusInternalServerError, "Could not generate random string", err)
+			return
+		}
+	}
+
+	result, err := hs.dashboardsnapshotsService.CreateDashboardSnapshot(c.Req.Context(), &cmd)
+	if err != nil {
+		c.JsonApiErr(http.StatusInternalServerError, "Failed to create snapshot", err)
+		return
+	}
+
+	metrics.MApiDashboardSnapshotCreate.Inc()
+
+	c.JSON(http.StatusOK, dashboardsnapshot.DashboardCreateResponse{
+		Key:       result.Key,
+		DeleteKey: result.DeleteKey,
+		URL:       setting.ToAbsUrl("dashboard/snapshot/" + result.Key),
+		DeleteURL: setting.ToAbsUrl("api/snapshots-delete/" + result.DeleteKey),
+	})
+}
+
+// publishedSnapshotExpires returns the expiry in seconds of a published snapshot, limited to
+// the maximum lifetime of published snapshots. A maximum of 0 means no limit.
+func publishedSnapshotExpires(expires int64, maxExpires time.Duration) int64 {
+	maxSeconds := int64(maxExpires / time.Second)
+	if maxSeconds > 0 && (expires <= 0 || expires > maxSeconds) {
+		return maxSeconds
+	}
+	return expires
+}
+
 // GET /api/snapshots/:key
 // swagger:route GET /snapshots/{key} snapshots getDashboardSnapshot
 //
@@ -165,7 +252,7 @@ func (hs *HTTPServer) DeleteDashboardSnapshotByDeleteKey(c *contextmodel.ReqCont
 		return response.Error(http.StatusNotFound, "Snapshot not found", nil)
 	}
 
-	err := dashboardsnapshots.DeleteWithKey(c.Req.Context(), key, hs.dashboardsnapshotsService)
+	err := dashboardsnapshots.DeleteWithKey(c.Req.Context(), key, hs.Cfg.ExternalSnapshotAPIKey, hs.dashboardsnapshotsService)
 	if err != nil {
 		if errors.Is(err, dashboardsnapshots.ErrBaseNotFound) {
 			return response.Error(http.StatusNotFound, "Snapshot not found", err)
@@ -213,7 +300,7 @@ func (hs *HTTPServer) DeleteDashboardSnapshot(c *contextmodel.ReqContext) respon
 	}
 
 	if queryResult.External {
-		err := dashboardsnapshots.DeleteExternalDashboardSnapshot(queryResult.ExternalDeleteURL)
+		err := dashboardsnapshots.DeleteExternalDashboardSnapshot(queryResult.ExternalDeleteURL, hs.Cfg.ExternalSnapshotAPIKey)
 		if err != nil {
 			return response.Error(http.StatusInternalServerError, "Failed to delete external dashboard", err)
 		}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -145,6 +146,7 @@ func TestDashboardSnapshotAPIEndpoint_singleSnapshot(t *testing.T) {
 				})
 				d := setUpSnapshotTest(t, 0, ts.URL)
 				hs := buildHttpServer(d, true)
+				hs.Cfg.ExternalSnapshotAPIKey = "external-api-key"
 
 				sc.handlerFunc = hs.DeleteDashboardSnapshotByDeleteKey
 				sc.fakeReqWithParams("GET", sc.url, map[string]string{"deleteKey": "12345"}).exec()
@@ -158,6 +160,7 @@ func TestDashboardSnapshotAPIEndpoint_singleSnapshot(t *testing.T) {
 				assert.Equal(t, http.MethodGet, externalRequest.Method)
 				assert.Equal(t, ts.URL, fmt.Sprintf("http://%s", externalRequest.Host))
 				assert.Equal(t, "/", externalRequest.URL.EscapedPath())
+				assert.Equal(t, "Bearer external-api-key", externalRequest.Header.Get("Authorization"))
 			})
 	})
 
@@ -204,6 +207,22 @@ func TestDashboardSnapshotAPIEndpoint_singleSnapshot(t *testing.T) {
 				assert.Equal(t, 500, sc.resp.Code)
 			}, sqlmock)
 
+		loggedInUserScenarioWithRole(t,
+			"Should gracefully delete local snapshot when remote Grafana snapshot server has already removed it when calling DELETE on",
+			"DELETE", "/api/snapshots/12345", "/api/snapshots/:key", org.RoleEditor, func(sc *scenarioContext) {
+				ts := setupRemoteServer(func(rw http.ResponseWriter, req *http.Request) {
+					rw.WriteHeader(404)
+					_, _ = rw.Write([]byte(`{"message":"Snapshot not found"}`))
+				})
+				d := setUpSnapshotTest(t, testUserID, ts.URL)
+				hs := buildHttpServer(d, true)
+				hs.DashboardService = dashboards.NewFakeDashboardService(t)
+				sc.handlerFunc = hs.DeleteDashboardSnapshot
+				sc.fakeReqWithParams("DELETE", sc.url, map[string]string{"key": "12345"}).exec()
+
+				assert.Equal(t, 200, sc.resp.Code)
+			}, sqlmock)
+
 		loggedInUserScenarioWithRole(t,
 			"Should fail to delete local snapshot when an unexpected remote error occurs when calling DELETE on",
 			"DELETE", "/api/snapshots/12345", "/api/snapshots/:key", org.RoleEditor, func(sc *scenarioContext) {
@@ -237,6 +256,101 @@ func TestDashboardSnapshotAPIEndpoint_singleSnapshot(t *testing.T) {
 	})
 }
 
+func TestHTTPServer_CreatePublishedDashboardSnapshot(t *testing.T) {
+	setup := func(t *testing.T, maxExpires time.Duration) (*webtest.Server, *dashboardsnapshots.MockService) {
+		t.Helper()
+
+		svc := dashboardsnapshots.NewMockService(t)
+		server := SetupAPITestServer(t, func(hs *HTTPServer) {
+			cfg := setting.NewCfg()
+			cfg.SnapshotEnabled = true
+			cfg.SnapshotExternalServerEnabled = true
+			cfg.SnapshotExternalServerMaxExpires = maxExpires
+			hs.Cfg = cfg
+			hs.dashboardsnapshotsService = svc
+		})
+		return server, svc
+	}
+
+	serviceAccount := &user.SignedInUser{
+		UserID:           10,
+		OrgID:            1,
+		IsServiceAccount: true,
+		Permissions:      map[int64]map[string][]string{1: {dashboards.ActionSnapshotsCreate: {}}},
+	}
+	body := `{"name":"published","expires":7200,"dashboard":{"uid":"remote","title":"Remote"},"key":"published-key","deleteKey":"published-delete-key"}`
+	publishRequest := func(server *webtest.Server) *http.Request {
+		req := server.NewPostRequest("/api/snapshots", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("Service account should publish a snapshot of a dashboard that does not exist locally", func(t *testing.T) {
+		server, svc := setup(t, 0)
+		var cmd *dashboardsnapshots.CreateDashboardSnapshotCommand
+		svc.On("CreateDashboardSnapshot", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			cmd = args.Get(1).(*dashboardsnapshots.CreateDashboardSnapshotCommand)
+		}).Return(&dashboardsnapshots.DashboardSnapshot{Key: "published-key", DeleteKey: "published-delete-key"}, nil)
+
+		res, err := server.Send(webtest.RequestWithSignedInUser(
+			publishRequest(server),
+			serviceAccount,
+		))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var resp map[string]string
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, "published-key", resp["key"])
+		assert.True(t, strings.HasSuffix(resp["url"], "dashboard/snapshot/published-key"))
+		assert.True(t, strings.HasSuffix(resp["deleteUrl"], "api/snapshots-delete/published-delete-key"))
+
+		require.NotNil(t, cmd)
+		assert.Equal(t, int64(1), cmd.OrgID)
+		assert.Equal(t, int64(10), cmd.UserID)
+		assert.Equal(t, int64(7200), cmd.Expires)
+		assert.False(t, cmd.External)
+	})
+
+	t.Run("Published snapshots should expire after the maximum lifetime", func(t *testing.T) {
+		server, svc := setup(t, time.Hour)
+		var cmd *dashboardsnapshots.CreateDashboardSnapshotCommand
+		svc.On("CreateDashboardSnapshot", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			cmd = args.Get(1).(*dashboardsnapshots.CreateDashboardSnapshotCommand)
+		}).Return(&dashboardsnapshots.DashboardSnapshot{Key: "published-key", DeleteKey: "published-delete-key"}, nil)
+
+		res, err := server.Send(webtest.RequestWithSignedInUser(
+			publishRequest(server),
+			serviceAccount,
+		))
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, int64(3600), cmd.Expires)
+	})
+
+	t.Run("Service account without permission should not publish a snapshot", func(t *testing.T) {
+		server, _ := setup(t, 0)
+
+		res, err := server.Send(webtest.RequestWithSignedInUser(
+			publishRequest(server),
+			&user.SignedInUser{UserID: 10, OrgID: 1, IsServiceAccount: true},
+		))
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+}
+
+func TestPublishedSnapshotExpires(t *testing.T) {
+	assert.Equal(t, int64(0), publishedSnapshotExpires(0, 0))
+	assert.Equal(t, int64(60), publishedSnapshotExpires(60, 0))
+	assert.Equal(t, int64(3600), publishedSnapshotExpires(0, time.Hour))
+	assert.Equal(t, int64(60), publishedSnapshotExpires(60, time.Hour))
+	assert.Equal(t, int64(3600), publishedSnapshotExpires(7200, time.Hour))
+}
+
 func TestGetDashboardSnapshotNotFound(t *testing.T) {
 	sqlmock := dbtest.NewFakeDB()
 
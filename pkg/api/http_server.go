@@ -426,6 +426,9 @@ func (hs *HTTPServer) Run(ctx context.Context) error {
 			}
 		}
 	default:
+		if hs.Cfg.MTLSAuth.Enabled {
+			hs.log.Warn("mTLS authentication requires the https or h2 protocol and is disabled", "protocol", hs.Cfg.Protocol)
+		}
 	}
 
 	listener, err := hs.getListener()
@@ -922,6 +925,16 @@ func (hs *HTTPServer) configureTLS() error {
 		CipherSuites: tlsCiphers,
 	}
 
+	if hs.Cfg.MTLSAuth.Enabled {
+		clientCAs, err := readClientCAs(hs.Cfg.MTLSAuth.ClientCAFile)
+		if err != nil {
+			return err
+		}
+		// certificates are optional, clients without one use the other auth methods
+		tlsCfg.ClientCAs = clientCAs
+		tlsCfg.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
 	hs.httpSrv.TLSConfig = tlsCfg
 
 	if hs.Cfg.Protocol == setting.HTTP2Scheme {
@@ -935,6 +948,18 @@ func (hs *HTTPServer) configureTLS() error {
 	return nil
 }
 
+func readClientCAs(caFile string) (*x509.CertPool, error) {
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read mTLS client_ca_file %q: %w", caFile, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("mTLS client_ca_file %q does not contain any PEM certificate", caFile)
+	}
+	return pool, nil
+}
+
 func (hs *HTTPServer) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
 	hs.tlsCerts.certLock.RLock()
 	defer hs.tlsCerts.certLock.RUnlock()
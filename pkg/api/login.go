@@ -254,6 +254,20 @@ func (hs *HTTPServer) LoginPasswordless(c *contextmodel.ReqContext) response.Res
 	return authn.HandleLoginResponse(c.Req, c.Resp, hs.Cfg, identity, hs.ValidateRedirectTo, hs.Features)
 }
 
+// LoginWebAuthn signs in a user with the assertion of a passkey or security
+// key, the options of the sign in are returned by /api/login/webauthn/begin.
+func (hs *HTTPServer) LoginWebAuthn(c *contextmodel.ReqContext) response.Response {
+	identity, err := hs.authnService.Login(c.Req.Context(), authn.ClientWebAuthn, &authn.Request{HTTPRequest: c.Req})
+	if err != nil {
+		tokenErr := &auth.CreateTokenErr{}
+		if errors.As(err, &tokenErr) {
+			return response.Error(tokenErr.StatusCode, tokenErr.ExternalErr, tokenErr.InternalErr)
+		}
+		return response.Err(err)
+	}
+	return authn.HandleLoginResponse(c.Req, c.Resp, hs.Cfg, identity, hs.ValidateRedirectTo, hs.Features)
+}
+
 func (hs *HTTPServer) StartPasswordless(c *contextmodel.ReqContext) {
 	redirect, err := hs.authnService.RedirectURL(c.Req.Context(), authn.ClientPasswordless, &authn.Request{HTTPRequest: c.Req})
 	if err != nil {
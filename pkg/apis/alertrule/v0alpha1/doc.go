@@ -0,0 +1,6 @@
+// +k8s:deepcopy-gen=package
+// +k8s:openapi-gen=true
+// +k8s:defaulter-gen=TypeMeta
+// +groupName=rules.alerting.grafana.app
+
+package v0alpha1 // import "github.com/grafana/grafana/pkg/apis/alertrule/v0alpha1"
@@ -0,0 +1,71 @@
+package v0alpha1
+
+import (
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/apimachinery/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	GROUP      = "rules.alerting.grafana.app"
+	VERSION    = "v0alpha1"
+	APIVERSION = GROUP + "/" + VERSION
+)
+
+var AlertRuleResourceInfo = utils.NewResourceInfo(GROUP, VERSION,
+	"alertrules", "alertrule", "AlertRule",
+	func() runtime.Object { return &AlertRule{} },
+	func() runtime.Object { return &AlertRuleList{} },
+	utils.TableColumns{
+		Definition: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string", Format: "name"},
+			{Name: "Title", Type: "string", Format: "string", Description: "The rule title"},
+			{Name: "Group", Type: "string", Format: "string", Description: "The rule group"},
+			{Name: "Paused", Type: "boolean"},
+		},
+		Reader: func(obj any) ([]interface{}, error) {
+			m, ok := obj.(*AlertRule)
+			if !ok {
+				return nil, fmt.Errorf("expected alert rule")
+			}
+			return []interface{}{
+				m.Name,
+				m.Spec.Title,
+				m.Spec.Group,
+				m.Spec.IsPaused,
+			}, nil
+		},
+	}, // default table converter
+)
+
+var (
+	// SchemeGroupVersion is group version used to register these objects
+	SchemeGroupVersion = schema.GroupVersion{Group: GROUP, Version: VERSION}
+
+	// SchemaBuilder is used by standard codegen
+	SchemeBuilder      runtime.SchemeBuilder
+	localSchemeBuilder = &SchemeBuilder
+	AddToScheme        = localSchemeBuilder.AddToScheme
+)
+
+func init() {
+	localSchemeBuilder.Register(addKnownTypes)
+}
+
+// Adds the list of known types to the given scheme.
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&AlertRule{},
+		&AlertRuleList{},
+	)
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+	return nil
+}
+
+// Resource takes an unqualified resource and returns a Group qualified GroupResource
+func Resource(resource string) schema.GroupResource {
+	return SchemeGroupVersion.WithResource(resource).GroupResource()
+}
@@ -0,0 +1,114 @@
+package v0alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	common "github.com/grafana/grafana/pkg/apimachinery/apis/common/v0alpha1"
+)
+
+// AlertRule is a Grafana-managed alert or recording rule. The name is the UID of the rule, and the folder of the
+// rule is set in the grafana.app/folder annotation.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type AlertRule struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AlertRuleSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type AlertRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []AlertRule `json:"items"`
+}
+
+type AlertRuleSpec struct {
+	Title string `json:"title"`
+	// The rule group of the rule. A new rule group is evaluated at the default interval.
+	Group string `json:"group"`
+	// The refId of the query or expression that is the condition of the rule
+	Condition string `json:"condition"`
+	// The queries and expressions of the rule
+	// +listType=atomic
+	Data []AlertQuery `json:"data"`
+	// The state of the alerts when the queries return no data: NoData, Alerting, OK or KeepLast
+	// +optional
+	NoDataState string `json:"noDataState,omitempty"`
+	// The state of the alerts when the evaluation fails: Error, Alerting, OK or KeepLast
+	// +optional
+	ExecErrState string `json:"execErrState,omitempty"`
+	// How long the condition must be met before the alert fires, for example 5m
+	// +optional
+	For string `json:"for,omitempty"`
+	// How long the alert keeps firing after the condition is no longer met, for example 5m
+	// +optional
+	KeepFiringFor string `json:"keepFiringFor,omitempty"`
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// +optional
+	IsPaused bool `json:"isPaused,omitempty"`
+	// Sends the alerts of the rule to a contact point, rather than through the notification policies
+	// +optional
+	NotificationSettings *NotificationSettings `json:"notificationSettings,omitempty"`
+	// Makes the rule a recording rule
+	// +optional
+	Record *Record `json:"record,omitempty"`
+	// The number of evaluations without the alert before it is resolved
+	// +optional
+	MissingSeriesEvalsToResolve *int64 `json:"missingSeriesEvalsToResolve,omitempty"`
+}
+
+type AlertQuery struct {
+	RefId string `json:"refId"`
+	// +optional
+	QueryType string `json:"queryType,omitempty"`
+	// The time range of the query relative to the evaluation time
+	// +optional
+	RelativeTimeRange RelativeTimeRange `json:"relativeTimeRange,omitempty"`
+	// The UID of the data source, or __expr__ for an expression
+	DatasourceUid string `json:"datasourceUid"`
+	// The query or expression
+	Model common.Unstructured `json:"model"`
+}
+
+type RelativeTimeRange struct {
+	// Seconds before the evaluation time the time range starts
+	From int64 `json:"from"`
+	// Seconds before the evaluation time the time range ends
+	To int64 `json:"to"`
+}
+
+type NotificationSettings struct {
+	// The contact point the alerts are sent to
+	Receiver string `json:"receiver"`
+	// +optional
+	// +listType=atomic
+	GroupBy []string `json:"groupBy,omitempty"`
+	// +optional
+	GroupWait string `json:"groupWait,omitempty"`
+	// +optional
+	GroupInterval string `json:"groupInterval,omitempty"`
+	// +optional
+	RepeatInterval string `json:"repeatInterval,omitempty"`
+	// +optional
+	// +listType=atomic
+	MuteTimeIntervals []string `json:"muteTimeIntervals,omitempty"`
+	// +optional
+	// +listType=atomic
+	ActiveTimeIntervals []string `json:"activeTimeIntervals,omitempty"`
+}
+
+type Record struct {
+	// The name of the recorded metric
+	Metric string `json:"metric"`
+	// The refId of the query or expression that is recorded
+	From string `json:"from"`
+	// The UID of the data source the metric is written to
+	// +optional
+	TargetDatasourceUid string `json:"targetDatasourceUid,omitempty"`
+}
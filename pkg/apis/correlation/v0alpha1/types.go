@@ -0,0 +1,63 @@
+package v0alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	common "github.com/grafana/grafana/pkg/apimachinery/apis/common/v0alpha1"
+)
+
+// Correlation links the results of a source data source to a query or an external URL. The name is the UID of the
+// correlation.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type Correlation struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CorrelationSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type CorrelationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Correlation `json:"items"`
+}
+
+type CorrelationSpec struct {
+	// The UID of the data source the correlation originates from
+	SourceUID string `json:"sourceUID"`
+	// The UID of the data source the query is run against, required for correlations of type query
+	// +optional
+	TargetUID *string `json:"targetUID,omitempty"`
+	// The label of the link
+	Label string `json:"label"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// The type of the correlation: query or external
+	Type   string            `json:"type"`
+	Config CorrelationConfig `json:"config"`
+}
+
+type CorrelationConfig struct {
+	// The field the link is attached to
+	Field string `json:"field"`
+	// The query of the target data source, or the URL of an external correlation
+	Target common.Unstructured `json:"target"`
+	// The variables extracted from the values of the source fields
+	// +optional
+	// +listType=atomic
+	Transformations []Transformation `json:"transformations,omitempty"`
+}
+
+type Transformation struct {
+	// regex, logfmt, jsonpath, label or template
+	Type string `json:"type"`
+	// +optional
+	Expression string `json:"expression,omitempty"`
+	// +optional
+	Field string `json:"field,omitempty"`
+	// +optional
+	MapValue string `json:"mapValue,omitempty"`
+}
@@ -43,6 +43,8 @@ var RepositoryResourceInfo = utils.NewResourceInfo(GROUP, VERSION,
 				target = m.Spec.GitHub.URL
 			case GitRepositoryType:
 				target = m.Spec.Git.URL
+			case ObjectStorageRepositoryType:
+				target = m.Spec.ObjectStorage.Endpoint + "/" + m.Spec.ObjectStorage.Bucket
 			}
 
 			return []interface{}{
@@ -77,6 +77,26 @@ type GitRepositoryConfig struct {
 	Path string `json:"path,omitempty"`
 }
 
+type ObjectStorageRepositoryConfig struct {
+	// The endpoint of the S3-compatible service (e.g. `https://s3.us-east-1.amazonaws.com` or `http://minio:9000`).
+	// Objects are addressed with path-style URLs: `<endpoint>/<bucket>/<key>`.
+	Endpoint string `json:"endpoint"`
+	// The bucket holding the Grafana data.
+	Bucket string `json:"bucket"`
+	// The region used to sign requests. Defaults to `us-east-1`.
+	Region string `json:"region,omitempty"`
+	// Access key ID used to sign requests. When empty, requests are sent unsigned (for public buckets).
+	AccessKeyID string `json:"accessKeyID,omitempty"`
+	// Secret access key used to sign requests. If set, it will be encrypted into encryptedSecretAccessKey, then set to an empty string again.
+	SecretAccessKey string `json:"secretAccessKey,omitempty"`
+	// Secret access key, but encrypted. This is not possible to read back to a user decrypted.
+	// +listType=atomic
+	EncryptedSecretAccessKey []byte `json:"encryptedSecretAccessKey,omitempty"`
+	// Path is the key prefix for the Grafana data. If specified, Grafana will ignore any object outside this prefix.
+	// This is usually something like `grafana/`. Trailing and leading slash are not required. They are always added when needed.
+	Path string `json:"path,omitempty"`
+}
+
 // RepositoryType defines the types of Repository
 // +enum
 type RepositoryType string
@@ -86,6 +106,8 @@ const (
 	LocalRepositoryType  RepositoryType = "local"
 	GitHubRepositoryType RepositoryType = "github"
 	GitRepositoryType    RepositoryType = "git"
+
+	ObjectStorageRepositoryType RepositoryType = "objectstorage"
 )
 
 // IsGit returns true if the repository type is git or github
@@ -112,16 +134,20 @@ type RepositorySpec struct {
 	Type RepositoryType `json:"type"`
 
 	// The repository on the local file system.
-	// Mutually exclusive with local | github.
+	// Mutually exclusive with github | git | objectStorage.
 	Local *LocalRepositoryConfig `json:"local,omitempty"`
 
 	// The repository on GitHub.
-	// Mutually exclusive with local | github | git.
+	// Mutually exclusive with local | git | objectStorage.
 	GitHub *GitHubRepositoryConfig `json:"github,omitempty"`
 
 	// The repository on Git.
-	// Mutually exclusive with local | github | git.
+	// Mutually exclusive with local | github | objectStorage.
 	Git *GitRepositoryConfig `json:"git,omitempty"`
+
+	// The repository in an S3-compatible object storage bucket.
+	// Mutually exclusive with local | github | git.
+	ObjectStorage *ObjectStorageRepositoryConfig `json:"objectStorage,omitempty"`
 }
 
 // SyncTargetType defines where we want all values to resolve
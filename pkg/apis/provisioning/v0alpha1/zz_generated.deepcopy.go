@@ -426,6 +426,27 @@ func (in *MigrateJobOptions) DeepCopy() *MigrateJobOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStorageRepositoryConfig) DeepCopyInto(out *ObjectStorageRepositoryConfig) {
+	*out = *in
+	if in.EncryptedSecretAccessKey != nil {
+		in, out := &in.EncryptedSecretAccessKey, &out.EncryptedSecretAccessKey
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageRepositoryConfig.
+func (in *ObjectStorageRepositoryConfig) DeepCopy() *ObjectStorageRepositoryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStorageRepositoryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PullRequestJobOptions) DeepCopyInto(out *PullRequestJobOptions) {
 	*out = *in
@@ -527,6 +548,11 @@ func (in *RepositorySpec) DeepCopyInto(out *RepositorySpec) {
 		*out = new(GitRepositoryConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ObjectStorage != nil {
+		in, out := &in.ObjectStorage, &out.ObjectStorage
+		*out = new(ObjectStorageRepositoryConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
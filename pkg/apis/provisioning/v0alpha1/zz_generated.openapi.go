@@ -14,46 +14,47 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.Author":                 schema_pkg_apis_provisioning_v0alpha1_Author(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ErrorDetails":           schema_pkg_apis_provisioning_v0alpha1_ErrorDetails(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ExportJobOptions":       schema_pkg_apis_provisioning_v0alpha1_ExportJobOptions(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.FileItem":               schema_pkg_apis_provisioning_v0alpha1_FileItem(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.FileList":               schema_pkg_apis_provisioning_v0alpha1_FileList(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.GitHubRepositoryConfig": schema_pkg_apis_provisioning_v0alpha1_GitHubRepositoryConfig(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.GitRepositoryConfig":    schema_pkg_apis_provisioning_v0alpha1_GitRepositoryConfig(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.HealthStatus":           schema_pkg_apis_provisioning_v0alpha1_HealthStatus(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.HistoryItem":            schema_pkg_apis_provisioning_v0alpha1_HistoryItem(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.HistoryList":            schema_pkg_apis_provisioning_v0alpha1_HistoryList(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.Job":                    schema_pkg_apis_provisioning_v0alpha1_Job(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.JobList":                schema_pkg_apis_provisioning_v0alpha1_JobList(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.JobResourceSummary":     schema_pkg_apis_provisioning_v0alpha1_JobResourceSummary(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.JobSpec":                schema_pkg_apis_provisioning_v0alpha1_JobSpec(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.JobStatus":              schema_pkg_apis_provisioning_v0alpha1_JobStatus(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.LocalRepositoryConfig":  schema_pkg_apis_provisioning_v0alpha1_LocalRepositoryConfig(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ManagerStats":           schema_pkg_apis_provisioning_v0alpha1_ManagerStats(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.MigrateJobOptions":      schema_pkg_apis_provisioning_v0alpha1_MigrateJobOptions(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.PullRequestJobOptions":  schema_pkg_apis_provisioning_v0alpha1_PullRequestJobOptions(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.Repository":             schema_pkg_apis_provisioning_v0alpha1_Repository(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.RepositoryList":         schema_pkg_apis_provisioning_v0alpha1_RepositoryList(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.RepositorySpec":         schema_pkg_apis_provisioning_v0alpha1_RepositorySpec(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.RepositoryStatus":       schema_pkg_apis_provisioning_v0alpha1_RepositoryStatus(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.RepositoryView":         schema_pkg_apis_provisioning_v0alpha1_RepositoryView(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.RepositoryViewList":     schema_pkg_apis_provisioning_v0alpha1_RepositoryViewList(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ResourceCount":          schema_pkg_apis_provisioning_v0alpha1_ResourceCount(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ResourceList":           schema_pkg_apis_provisioning_v0alpha1_ResourceList(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ResourceListItem":       schema_pkg_apis_provisioning_v0alpha1_ResourceListItem(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ResourceObjects":        schema_pkg_apis_provisioning_v0alpha1_ResourceObjects(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ResourceRepositoryInfo": schema_pkg_apis_provisioning_v0alpha1_ResourceRepositoryInfo(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ResourceStats":          schema_pkg_apis_provisioning_v0alpha1_ResourceStats(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ResourceType":           schema_pkg_apis_provisioning_v0alpha1_ResourceType(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ResourceURLs":           schema_pkg_apis_provisioning_v0alpha1_ResourceURLs(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ResourceWrapper":        schema_pkg_apis_provisioning_v0alpha1_ResourceWrapper(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.SyncJobOptions":         schema_pkg_apis_provisioning_v0alpha1_SyncJobOptions(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.SyncOptions":            schema_pkg_apis_provisioning_v0alpha1_SyncOptions(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.SyncStatus":             schema_pkg_apis_provisioning_v0alpha1_SyncStatus(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.TestResults":            schema_pkg_apis_provisioning_v0alpha1_TestResults(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.WebhookResponse":        schema_pkg_apis_provisioning_v0alpha1_WebhookResponse(ref),
-		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.WebhookStatus":          schema_pkg_apis_provisioning_v0alpha1_WebhookStatus(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.Author":                        schema_pkg_apis_provisioning_v0alpha1_Author(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ErrorDetails":                  schema_pkg_apis_provisioning_v0alpha1_ErrorDetails(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ExportJobOptions":              schema_pkg_apis_provisioning_v0alpha1_ExportJobOptions(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.FileItem":                      schema_pkg_apis_provisioning_v0alpha1_FileItem(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.FileList":                      schema_pkg_apis_provisioning_v0alpha1_FileList(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.GitHubRepositoryConfig":        schema_pkg_apis_provisioning_v0alpha1_GitHubRepositoryConfig(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.GitRepositoryConfig":           schema_pkg_apis_provisioning_v0alpha1_GitRepositoryConfig(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.HealthStatus":                  schema_pkg_apis_provisioning_v0alpha1_HealthStatus(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.HistoryItem":                   schema_pkg_apis_provisioning_v0alpha1_HistoryItem(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.HistoryList":                   schema_pkg_apis_provisioning_v0alpha1_HistoryList(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.Job":                           schema_pkg_apis_provisioning_v0alpha1_Job(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.JobList":                       schema_pkg_apis_provisioning_v0alpha1_JobList(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.JobResourceSummary":            schema_pkg_apis_provisioning_v0alpha1_JobResourceSummary(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.JobSpec":                       schema_pkg_apis_provisioning_v0alpha1_JobSpec(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.JobStatus":                     schema_pkg_apis_provisioning_v0alpha1_JobStatus(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.LocalRepositoryConfig":         schema_pkg_apis_provisioning_v0alpha1_LocalRepositoryConfig(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ManagerStats":                  schema_pkg_apis_provisioning_v0alpha1_ManagerStats(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.MigrateJobOptions":             schema_pkg_apis_provisioning_v0alpha1_MigrateJobOptions(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ObjectStorageRepositoryConfig": schema_pkg_apis_provisioning_v0alpha1_ObjectStorageRepositoryConfig(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.PullRequestJobOptions":         schema_pkg_apis_provisioning_v0alpha1_PullRequestJobOptions(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.Repository":                    schema_pkg_apis_provisioning_v0alpha1_Repository(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.RepositoryList":                schema_pkg_apis_provisioning_v0alpha1_RepositoryList(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.RepositorySpec":                schema_pkg_apis_provisioning_v0alpha1_RepositorySpec(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.RepositoryStatus":              schema_pkg_apis_provisioning_v0alpha1_RepositoryStatus(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.RepositoryView":                schema_pkg_apis_provisioning_v0alpha1_RepositoryView(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.RepositoryViewList":            schema_pkg_apis_provisioning_v0alpha1_RepositoryViewList(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ResourceCount":                 schema_pkg_apis_provisioning_v0alpha1_ResourceCount(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ResourceList":                  schema_pkg_apis_provisioning_v0alpha1_ResourceList(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ResourceListItem":              schema_pkg_apis_provisioning_v0alpha1_ResourceListItem(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ResourceObjects":               schema_pkg_apis_provisioning_v0alpha1_ResourceObjects(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ResourceRepositoryInfo":        schema_pkg_apis_provisioning_v0alpha1_ResourceRepositoryInfo(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ResourceStats":                 schema_pkg_apis_provisioning_v0alpha1_ResourceStats(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ResourceType":                  schema_pkg_apis_provisioning_v0alpha1_ResourceType(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ResourceURLs":                  schema_pkg_apis_provisioning_v0alpha1_ResourceURLs(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ResourceWrapper":               schema_pkg_apis_provisioning_v0alpha1_ResourceWrapper(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.SyncJobOptions":                schema_pkg_apis_provisioning_v0alpha1_SyncJobOptions(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.SyncOptions":                   schema_pkg_apis_provisioning_v0alpha1_SyncOptions(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.SyncStatus":                    schema_pkg_apis_provisioning_v0alpha1_SyncStatus(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.TestResults":                   schema_pkg_apis_provisioning_v0alpha1_TestResults(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.WebhookResponse":               schema_pkg_apis_provisioning_v0alpha1_WebhookResponse(ref),
+		"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.WebhookStatus":                 schema_pkg_apis_provisioning_v0alpha1_WebhookStatus(ref),
 	}
 }
 
@@ -910,6 +911,75 @@ func schema_pkg_apis_provisioning_v0alpha1_MigrateJobOptions(ref common.Referenc
 	}
 }
 
+func schema_pkg_apis_provisioning_v0alpha1_ObjectStorageRepositoryConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"endpoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The endpoint of the S3-compatible service (e.g. `https://s3.us-east-1.amazonaws.com` or `http://minio:9000`). Objects are addressed with path-style URLs: `<endpoint>/<bucket>/<key>`.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"bucket": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The bucket holding the Grafana data.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"region": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The region used to sign requests. Defaults to `us-east-1`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"accessKeyID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Access key ID used to sign requests. When empty, requests are sent unsigned (for public buckets).",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"secretAccessKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Secret access key used to sign requests. If set, it will be encrypted into encryptedSecretAccessKey, then set to an empty string again.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"encryptedSecretAccessKey": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Secret access key, but encrypted. This is not possible to read back to a user decrypted.",
+							Type:        []string{"string"},
+							Format:      "byte",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the key prefix for the Grafana data. If specified, Grafana will ignore any object outside this prefix. This is usually something like `grafana/`. Trailing and leading slash are not required. They are always added when needed.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"endpoint", "bucket"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_provisioning_v0alpha1_PullRequestJobOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1096,37 +1166,43 @@ func schema_pkg_apis_provisioning_v0alpha1_RepositorySpec(ref common.ReferenceCa
 					},
 					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The repository type.  When selected oneOf the values below should be non-nil\n\nPossible enum values:\n - `\"git\"`\n - `\"github\"`\n - `\"local\"`",
+							Description: "The repository type.  When selected oneOf the values below should be non-nil\n\nPossible enum values:\n - `\"git\"`\n - `\"github\"`\n - `\"local\"`\n - `\"objectstorage\"`",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
-							Enum:        []interface{}{"git", "github", "local"},
+							Enum:        []interface{}{"git", "github", "local", "objectstorage"},
 						},
 					},
 					"local": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The repository on the local file system. Mutually exclusive with local | github.",
+							Description: "The repository on the local file system. Mutually exclusive with github | git | objectStorage.",
 							Ref:         ref("github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.LocalRepositoryConfig"),
 						},
 					},
 					"github": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The repository on GitHub. Mutually exclusive with local | github | git.",
+							Description: "The repository on GitHub. Mutually exclusive with local | git | objectStorage.",
 							Ref:         ref("github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.GitHubRepositoryConfig"),
 						},
 					},
 					"git": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The repository on Git. Mutually exclusive with local | github | git.",
+							Description: "The repository on Git. Mutually exclusive with local | github | objectStorage.",
 							Ref:         ref("github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.GitRepositoryConfig"),
 						},
 					},
+					"objectStorage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The repository in an S3-compatible object storage bucket. Mutually exclusive with local | github | git.",
+							Ref:         ref("github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ObjectStorageRepositoryConfig"),
+						},
+					},
 				},
 				Required: []string{"title", "workflows", "sync", "type"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.GitHubRepositoryConfig", "github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.GitRepositoryConfig", "github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.LocalRepositoryConfig", "github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.SyncOptions"},
+			"github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.GitHubRepositoryConfig", "github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.GitRepositoryConfig", "github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.LocalRepositoryConfig", "github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.ObjectStorageRepositoryConfig", "github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1.SyncOptions"},
 	}
 }
 
@@ -1217,11 +1293,11 @@ func schema_pkg_apis_provisioning_v0alpha1_RepositoryView(ref common.ReferenceCa
 					},
 					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The repository type\n\nPossible enum values:\n - `\"git\"`\n - `\"github\"`\n - `\"local\"`",
+							Description: "The repository type\n\nPossible enum values:\n - `\"git\"`\n - `\"github\"`\n - `\"local\"`\n - `\"objectstorage\"`",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
-							Enum:        []interface{}{"git", "github", "local"},
+							Enum:        []interface{}{"git", "github", "local", "objectstorage"},
 						},
 					},
 					"target": {
@@ -1535,11 +1611,11 @@ func schema_pkg_apis_provisioning_v0alpha1_ResourceRepositoryInfo(ref common.Ref
 				Properties: map[string]spec.Schema{
 					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The repository type\n\nPossible enum values:\n - `\"git\"`\n - `\"github\"`\n - `\"local\"`",
+							Description: "The repository type\n\nPossible enum values:\n - `\"git\"`\n - `\"github\"`\n - `\"local\"`\n - `\"objectstorage\"`",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
-							Enum:        []interface{}{"git", "github", "local"},
+							Enum:        []interface{}{"git", "github", "local", "objectstorage"},
 						},
 					},
 					"title": {
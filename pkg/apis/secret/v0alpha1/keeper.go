@@ -27,6 +27,7 @@ const (
 	AzureKeeperType     KeeperType = "azure"
 	GCPKeeperType       KeeperType = "gcp"
 	HashiCorpKeeperType KeeperType = "hashicorp"
+	FileKeeperType      KeeperType = "file"
 )
 
 func (kt KeeperType) String() string {
@@ -63,6 +64,11 @@ type KeeperSpec struct {
 	// +structType=atomic
 	// +optional
 	HashiCorp *HashiCorpKeeperConfig `json:"hashivault,omitempty"`
+
+	// File Keeper Configuration.
+	// +structType=atomic
+	// +optional
+	File *FileKeeperConfig `json:"file,omitempty"`
 }
 
 func (s *KeeperSpec) GetType() KeeperType {
@@ -78,6 +84,9 @@ func (s *KeeperSpec) GetType() KeeperType {
 	if s.HashiCorp != nil {
 		return HashiCorpKeeperType
 	}
+	if s.File != nil {
+		return FileKeeperType
+	}
 	return ""
 }
 
@@ -122,6 +131,7 @@ type HashiCorpCredentials struct {
 // +union
 type CredentialValue struct {
 	// The name of the secure value that holds the actual value.
+	// The secure value must list `keeper-credentials` in its decrypters.
 	// +optional
 	SecureValueName string `json:"secureValueName,omitempty"`
 
@@ -157,6 +167,18 @@ type GCPKeeperConfig struct {
 
 type HashiCorpKeeperConfig struct {
 	HashiCorpCredentials `json:",inline"`
+
+	// Path where the KV version 2 secrets engine is mounted. Defaults to `secret`.
+	// +optional
+	Mount string `json:"mount,omitempty"`
+}
+
+// Read-only keeper for secrets mounted as files, such as Kubernetes secret volumes.
+type FileKeeperConfig struct {
+	// Directory that contains the secrets, one file per secret.
+	// The `ref` of a secure value is the name of the file relative to this directory.
+	// It must be an absolute path inside one of the directories allowed by `file_keeper_allowed_directories`.
+	Directory string `json:"directory"`
 }
 
 func (s *AWSKeeperConfig) Type() KeeperType {
@@ -174,3 +196,7 @@ func (s *GCPKeeperConfig) Type() KeeperType {
 func (s *HashiCorpKeeperConfig) Type() KeeperType {
 	return HashiCorpKeeperType
 }
+
+func (s *FileKeeperConfig) Type() KeeperType {
+	return FileKeeperType
+}
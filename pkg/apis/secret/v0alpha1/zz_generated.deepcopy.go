@@ -96,6 +96,22 @@ func (in *CredentialValue) DeepCopy() *CredentialValue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileKeeperConfig) DeepCopyInto(out *FileKeeperConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileKeeperConfig.
+func (in *FileKeeperConfig) DeepCopy() *FileKeeperConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FileKeeperConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCPCredentials) DeepCopyInto(out *GCPCredentials) {
 	*out = *in
@@ -246,6 +262,11 @@ func (in *KeeperSpec) DeepCopyInto(out *KeeperSpec) {
 		*out = new(HashiCorpKeeperConfig)
 		**out = **in
 	}
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(FileKeeperConfig)
+		**out = **in
+	}
 	return
 }
 
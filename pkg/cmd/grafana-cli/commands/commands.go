@@ -181,6 +181,12 @@ var adminCommands = []*cli.Command{
 				Name:   "re-encrypt-data-keys",
 				Usage:  "Rotates persisted data encryption keys. Returns ok unless there is an error. Safe to execute multiple times.",
 				Action: runRunnerCommand(secretsmigrations.ReEncryptDEKS),
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "provider",
+						Usage: "Encryption provider to re-encrypt the data keys with, in the format <provider>.<keyName>. Defaults to the current encryption_provider.",
+					},
+				},
 			},
 		},
 	},
@@ -10,11 +10,24 @@ import (
 type reducer string
 
 func (cr reducer) ValidReduceFunc() bool {
-	switch cr {
+	name, param, err := mathexp.ParseReducer(mathexp.ReducerID(cr))
+	if err != nil {
+		return false
+	}
+	if name == mathexp.ReducerPercentile {
+		return param != nil && *param >= 0 && *param <= 1
+	}
+	if param != nil {
+		return false
+	}
+
+	switch name {
 	case "avg", "sum", "min", "max", "count", "last", "median":
 		return true
 	case "diff", "diff_abs", "percent_diff", "percent_diff_abs", "count_non_null":
 		return true
+	case "stddev", "first", "range":
+		return true
 	}
 	return false
 }
@@ -39,6 +52,14 @@ func (cr reducer) Reduce(series mathexp.Series) mathexp.Number {
 	vF := series.Frame.Fields[1]
 	ff := mathexp.Float64Field(*vF)
 
+	name, param, _ := mathexp.ParseReducer(mathexp.ReducerID(cr))
+	if name == mathexp.ReducerPercentile && param != nil {
+		if f := mathexp.Percentile(series, *param); !math.IsNaN(f) {
+			num.SetValue(&f)
+		}
+		return num
+	}
+
 	switch cr {
 	case "avg":
 		validPointsCount := 0
@@ -144,6 +165,48 @@ func (cr reducer) Reduce(series mathexp.Series) mathexp.Number {
 		if value > 0 {
 			allNull = false
 		}
+	case "stddev":
+		var values []float64
+		for i := 0; i < ff.Len(); i++ {
+			f := ff.GetValue(i)
+			if nilOrNaN(f) {
+				continue
+			}
+			allNull = false
+			values = append(values, *f)
+			value += *f
+		}
+		if len(values) >= 1 {
+			mean := value / float64(len(values))
+			squares := float64(0)
+			for _, v := range values {
+				squares += (v - mean) * (v - mean)
+			}
+			value = math.Sqrt(squares / float64(len(values)))
+		}
+	case "first":
+		for i := 0; i < ff.Len(); i++ {
+			f := ff.GetValue(i)
+			if !nilOrNaN(f) {
+				value = *f
+				allNull = false
+				break
+			}
+		}
+	case "range":
+		lowest, highest := math.MaxFloat64, -math.MaxFloat64
+		for i := 0; i < ff.Len(); i++ {
+			f := ff.GetValue(i)
+			if nilOrNaN(f) {
+				continue
+			}
+			allNull = false
+			lowest = math.Min(lowest, *f)
+			highest = math.Max(highest, *f)
+		}
+		if !allNull {
+			value = highest - lowest
+		}
 	}
 
 	if allNull {
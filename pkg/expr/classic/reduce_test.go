@@ -115,6 +115,42 @@ func TestReducer(t *testing.T) {
 			inputSeries:    newSeries(nil, nil),
 			expectedNumber: newNumber(nil),
 		},
+		{
+			name:           "stddev should ignore null values",
+			reducer:        reducer("stddev"),
+			inputSeries:    newSeries(util.Pointer(2.0), nil, util.Pointer(4.0), util.Pointer(4.0), util.Pointer(4.0), util.Pointer(5.0), util.Pointer(5.0), util.Pointer(7.0), util.Pointer(9.0)),
+			expectedNumber: newNumber(util.Pointer(2.0)),
+		},
+		{
+			name:           "first should ignore null values",
+			reducer:        reducer("first"),
+			inputSeries:    newSeries(nil, util.Pointer(math.NaN()), util.Pointer(3.0), util.Pointer(4.0)),
+			expectedNumber: newNumber(util.Pointer(3.0)),
+		},
+		{
+			name:           "range",
+			reducer:        reducer("range"),
+			inputSeries:    newSeries(util.Pointer(3.0), nil, util.Pointer(-1.0), util.Pointer(4.0)),
+			expectedNumber: newNumber(util.Pointer(5.0)),
+		},
+		{
+			name:           "range with only nulls",
+			reducer:        reducer("range"),
+			inputSeries:    newSeries(nil, nil),
+			expectedNumber: newNumber(nil),
+		},
+		{
+			name:           "percentile should ignore null values",
+			reducer:        reducer("percentile(0.95)"),
+			inputSeries:    newSeries(nil, util.Pointer(1.0), util.Pointer(2.0), util.Pointer(3.0), util.Pointer(4.0), util.Pointer(5.0)),
+			expectedNumber: newNumber(util.Pointer(4.8)),
+		},
+		{
+			name:           "percentile with only nulls",
+			reducer:        reducer("percentile(0.5)"),
+			inputSeries:    newSeries(nil, nil),
+			expectedNumber: newNumber(nil),
+		},
 	}
 
 	for _, tt := range tests {
@@ -126,6 +162,12 @@ func TestReducer(t *testing.T) {
 	}
 }
 
+func TestValidReduceFunc(t *testing.T) {
+	for _, r := range []reducer{"percentile", "percentile(95)", "percentile(abc)", "percentile(nan)", "percentile(inf)", "stddev(1)", "foo"} {
+		require.False(t, r.ValidReduceFunc(), r)
+	}
+}
+
 func TestDiffReducer(t *testing.T) {
 	var tests = []struct {
 		name           string
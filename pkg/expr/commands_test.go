@@ -212,7 +212,11 @@ func TestReduceExecute(t *testing.T) {
 
 func randomReduceFunc() mathexp.ReducerID {
 	res := mathexp.GetSupportedReduceFuncs()
-	return res[rand.Intn(len(res))]
+	rFunc := res[rand.Intn(len(res))]
+	if rFunc == mathexp.ReducerPercentile {
+		return mathexp.PercentileReducer(rand.Float64())
+	}
+	return rFunc
 }
 
 func TestResampleCommand_Execute(t *testing.T) {
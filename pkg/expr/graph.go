@@ -287,7 +287,12 @@ func (s *Service) buildGraph(req *Request) (*simple.DirectedGraph, error) {
 		case TypeDatasourceNode:
 			node, err = s.buildDSNode(dp, rn, req)
 		case TypeCMDNode:
-			node, err = buildCMDNode(rn, s.features, s.cfg)
+			var cmdNode *CMDNode
+			cmdNode, err = buildCMDNode(rn, s.features, s.cfg)
+			if err == nil {
+				s.setSQLCatalog(cmdNode, rn, req.User)
+				node = cmdNode
+			}
 		case TypeMLNode:
 			if s.features.IsEnabledGlobally(featuremgmt.FlagMlExpressions) {
 				node, err = s.buildMLNode(dp, rn, req)
@@ -1,7 +1,12 @@
 package mathexp
 
 import (
+	"fmt"
 	"math"
+	"sort"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
 
 	"github.com/grafana/grafana/pkg/expr/mathexp/parse"
 )
@@ -68,6 +73,52 @@ var builtins = map[string]parse.Func{
 		VariantReturn: true,
 		F:             floor,
 	},
+	"sqrt": {
+		Args:          []parse.ReturnType{parse.TypeVariantSet},
+		VariantReturn: true,
+		F:             sqrt,
+	},
+	"exp": {
+		Args:          []parse.ReturnType{parse.TypeVariantSet},
+		VariantReturn: true,
+		F:             exp,
+	},
+	"pow": {
+		Args:          []parse.ReturnType{parse.TypeVariantSet, parse.TypeScalar},
+		VariantReturn: true,
+		F:             pow,
+	},
+	"clamp_min": {
+		Args:          []parse.ReturnType{parse.TypeVariantSet, parse.TypeScalar},
+		VariantReturn: true,
+		F:             clampMin,
+	},
+	"clamp_max": {
+		Args:          []parse.ReturnType{parse.TypeVariantSet, parse.TypeScalar},
+		VariantReturn: true,
+		F:             clampMax,
+	},
+	"rate": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet},
+		Return: parse.TypeSeriesSet,
+		F:      rate,
+	},
+	"delta": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet},
+		Return: parse.TypeSeriesSet,
+		F:      delta,
+	},
+	"timeshift": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet, parse.TypeString},
+		Return: parse.TypeSeriesSet,
+		F:      timeShift,
+		Check:  checkDurationArg(1),
+	},
+	"percentile": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet, parse.TypeScalar},
+		Return: parse.TypeNumberSet,
+		F:      percentile,
+	},
 }
 
 // abs returns the absolute value for each result in NumberSet, SeriesSet, or Scalar
@@ -307,3 +358,227 @@ func floor(e *State, varSet Results) (Results, error) {
 	}
 	return newRes, nil
 }
+
+// sqrt returns the square root of each result in NumberSet, SeriesSet, or Scalar
+func sqrt(e *State, varSet Results) (Results, error) {
+	return perFloatResults(e, varSet, math.Sqrt)
+}
+
+// exp returns e**x for each result in NumberSet, SeriesSet, or Scalar
+func exp(e *State, varSet Results) (Results, error) {
+	return perFloatResults(e, varSet, math.Exp)
+}
+
+// pow raises each result in NumberSet, SeriesSet, or Scalar to the power of the scalar exponent
+func pow(e *State, varSet Results, exponent Results) (Results, error) {
+	y, err := scalarArg("pow", exponent)
+	if err != nil {
+		return Results{}, err
+	}
+	return perFloatResults(e, varSet, func(x float64) float64 {
+		return math.Pow(x, y)
+	})
+}
+
+// clampMin replaces each value in NumberSet, SeriesSet, or Scalar that is lower than the scalar minimum with the minimum.
+// NaN values are kept as they are.
+func clampMin(e *State, varSet Results, minimum Results) (Results, error) {
+	lo, err := scalarArg("clamp_min", minimum)
+	if err != nil {
+		return Results{}, err
+	}
+	return perFloatResults(e, varSet, func(x float64) float64 {
+		if x < lo {
+			return lo
+		}
+		return x
+	})
+}
+
+// clampMax replaces each value in NumberSet, SeriesSet, or Scalar that is greater than the scalar maximum with the maximum.
+// NaN values are kept as they are.
+func clampMax(e *State, varSet Results, maximum Results) (Results, error) {
+	hi, err := scalarArg("clamp_max", maximum)
+	if err != nil {
+		return Results{}, err
+	}
+	return perFloatResults(e, varSet, func(x float64) float64 {
+		if x > hi {
+			return hi
+		}
+		return x
+	})
+}
+
+// rate returns the per-second rate of increase between consecutive points of each series, treating
+// any decrease as a counter reset. The first point of each series has no rate and is dropped.
+func rate(e *State, varSet Results) (Results, error) {
+	return perSeries(e, "rate", varSet, func(s Series) Series {
+		return consecutiveDiff(e.RefID, s, func(prev, cur float64, elapsed time.Duration) float64 {
+			if elapsed <= 0 {
+				return math.NaN()
+			}
+			increase := cur - prev
+			if increase < 0 {
+				// counter reset, the counter restarted from zero
+				increase = cur
+			}
+			return increase / elapsed.Seconds()
+		})
+	})
+}
+
+// delta returns the difference between consecutive points of each series.
+// The first point of each series has no delta and is dropped.
+func delta(e *State, varSet Results) (Results, error) {
+	return perSeries(e, "delta", varSet, func(s Series) Series {
+		return consecutiveDiff(e.RefID, s, func(prev, cur float64, _ time.Duration) float64 {
+			return cur - prev
+		})
+	})
+}
+
+// timeShift moves every point of each series forward in time by the given duration, so that
+// for example timeshift($A, "1w") can be compared with $A to get week-over-week changes.
+func timeShift(e *State, varSet Results, rawDuration string) (Results, error) {
+	d, err := gtime.ParseDuration(rawDuration)
+	if err != nil {
+		return Results{}, fmt.Errorf("timeshift: invalid duration %q: %w", rawDuration, err)
+	}
+	return perSeries(e, "timeshift", varSet, func(s Series) Series {
+		newSeries := NewSeries(e.RefID, s.GetLabels(), s.Len())
+		for i := 0; i < s.Len(); i++ {
+			t, f := s.GetPoint(i)
+			newSeries.SetPoint(i, t.Add(d), f)
+		}
+		return newSeries
+	})
+}
+
+// percentile returns the p-th percentile (0 <= p <= 1) of the values of each series as a Number.
+// Null and NaN values are ignored. A series without values results in NaN.
+func percentile(e *State, varSet Results, rank Results) (Results, error) {
+	p, err := scalarArg("percentile", rank)
+	if err != nil {
+		return Results{}, err
+	}
+	if p < 0 || p > 1 || math.IsNaN(p) {
+		return Results{}, fmt.Errorf("percentile: expected a percentile between 0 and 1, got %v", p)
+	}
+
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		switch v := res.(type) {
+		case Series:
+			n := NewNumber(e.RefID, v.GetLabels())
+			f := Percentile(v, p)
+			n.SetValue(&f)
+			newRes.Values = append(newRes.Values, n)
+		case NoData:
+			newRes.Values = append(newRes.Values, NewNoData())
+		default:
+			return newRes, fmt.Errorf("percentile: expected a %v, got %v", parse.TypeSeriesSet, res.Type())
+		}
+	}
+	return newRes, nil
+}
+
+// Percentile returns the p-th percentile (0 <= p <= 1) of the non-null, non-NaN values of the series,
+// linearly interpolating between the closest ranks. NaN is returned if the series has no such values.
+func Percentile(s Series, p float64) float64 {
+	vals := make([]float64, 0, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		if f := s.GetValue(i); f != nil && !math.IsNaN(*f) {
+			vals = append(vals, *f)
+		}
+	}
+	sort.Float64s(vals)
+	return percentileOfSorted(vals, p)
+}
+
+// perFloatResults applies perFloat to each result in varSet.
+func perFloatResults(e *State, varSet Results, floatF func(x float64) float64) (Results, error) {
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		newVal, err := perFloat(e, res, floatF)
+		if err != nil {
+			return newRes, err
+		}
+		newRes.Values = append(newRes.Values, newVal)
+	}
+	return newRes, nil
+}
+
+// perSeries applies seriesF to each Series in varSet. NoData is passed through, any other type is an error
+// since variables are only known to be series once the expression is executed.
+func perSeries(e *State, name string, varSet Results, seriesF func(s Series) Series) (Results, error) {
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		switch v := res.(type) {
+		case Series:
+			newRes.Values = append(newRes.Values, seriesF(v))
+		case NoData:
+			newRes.Values = append(newRes.Values, NewNoData())
+		default:
+			return newRes, fmt.Errorf("%s: expected a %v, got %v", name, parse.TypeSeriesSet, res.Type())
+		}
+	}
+	return newRes, nil
+}
+
+// consecutiveDiff returns a series with one point per pair of consecutive points of s (ordered by time),
+// placed at the time of the later point. Pairs with a null or NaN value result in a NaN point.
+func consecutiveDiff(refID string, s Series, diffF func(prev, cur float64, elapsed time.Duration) float64) Series {
+	sorted := NewSeries(refID, s.GetLabels(), s.Len())
+	for i := 0; i < s.Len(); i++ {
+		t, f := s.GetPoint(i)
+		sorted.SetPoint(i, t, f)
+	}
+	sorted.SortByTime(false)
+
+	if sorted.Len() < 2 {
+		return NewSeries(refID, s.GetLabels(), 0)
+	}
+
+	newSeries := NewSeries(refID, s.GetLabels(), sorted.Len()-1)
+	for i := 1; i < sorted.Len(); i++ {
+		prevT, prev := sorted.GetPoint(i - 1)
+		t, cur := sorted.GetPoint(i)
+		nF := math.NaN()
+		if prev != nil && cur != nil && !math.IsNaN(*prev) && !math.IsNaN(*cur) {
+			nF = diffF(*prev, *cur, t.Sub(prevT))
+		}
+		newSeries.SetPoint(i-1, t, &nF)
+	}
+	return newSeries
+}
+
+// scalarArg returns the value of a scalar function argument.
+func scalarArg(name string, res Results) (float64, error) {
+	if len(res.Values) != 1 {
+		return 0, fmt.Errorf("%s: expected a single %v argument", name, parse.TypeScalar)
+	}
+	s, ok := res.Values[0].(Scalar)
+	if !ok {
+		return 0, fmt.Errorf("%s: expected a %v argument, got %v", name, parse.TypeScalar, res.Values[0].Type())
+	}
+	f := s.GetFloat64Value()
+	if f == nil {
+		return 0, fmt.Errorf("%s: expected a non-null %v argument", name, parse.TypeScalar)
+	}
+	return *f, nil
+}
+
+// checkDurationArg returns a parse time check that the string argument at argIdx is a valid duration.
+func checkDurationArg(argIdx int) func(*parse.Tree, *parse.FuncNode) error {
+	return func(_ *parse.Tree, f *parse.FuncNode) error {
+		arg, ok := f.Args[argIdx].(*parse.StringNode)
+		if !ok {
+			return fmt.Errorf("parse: expected a duration string for argument %v of %s", argIdx, f.Name)
+		}
+		if _, err := gtime.ParseDuration(arg.Text); err != nil {
+			return fmt.Errorf("parse: invalid duration %q for argument %v of %s", arg.Text, argIdx, f.Name)
+		}
+		return nil
+	}
+}
@@ -140,3 +140,207 @@ func TestIsNumberFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestArithmeticFuncs(t *testing.T) {
+	var tests = []struct {
+		name    string
+		expr    string
+		vars    Vars
+		results Results
+	}{
+		{
+			name:    "sqrt on scalar",
+			expr:    "sqrt(16)",
+			vars:    Vars{},
+			results: resultValuesNoErr(NewScalar("", float64Pointer(4))),
+		},
+		{
+			name: "exp on number",
+			expr: "exp($A)",
+			vars: Vars{
+				"A": resultValuesNoErr(makeNumber("", nil, float64Pointer(0))),
+			},
+			results: resultValuesNoErr(makeNumber("", nil, float64Pointer(1))),
+		},
+		{
+			name: "pow on series",
+			expr: "pow($A, 2)",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil,
+						tp{time.Unix(5, 0), float64Pointer(3)},
+						tp{time.Unix(10, 0), float64Pointer(-4)}),
+				),
+			},
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(5, 0), float64Pointer(9)},
+					tp{time.Unix(10, 0), float64Pointer(16)}),
+			),
+		},
+		{
+			name: "clamp_min and clamp_max on series",
+			expr: "clamp_max(clamp_min($A, 0), 10)",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil,
+						tp{time.Unix(5, 0), float64Pointer(-3)},
+						tp{time.Unix(10, 0), float64Pointer(5)},
+						tp{time.Unix(15, 0), float64Pointer(12)}),
+				),
+			},
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(5, 0), float64Pointer(0)},
+					tp{time.Unix(10, 0), float64Pointer(5)},
+					tp{time.Unix(15, 0), float64Pointer(10)}),
+			),
+		},
+		{
+			name: "clamp_min on number",
+			expr: "clamp_min($A, 1)",
+			vars: Vars{
+				"A": resultValuesNoErr(makeNumber("", nil, float64Pointer(-7))),
+			},
+			results: resultValuesNoErr(makeNumber("", nil, float64Pointer(1))),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := New(tt.expr)
+			require.NoError(t, err)
+			res, err := e.Execute("", tt.vars, tracing.InitializeTracerForTest())
+			require.NoError(t, err)
+			require.Equal(t, tt.results, res)
+		})
+	}
+
+	t.Run("clamp_min with a series as the minimum should error", func(t *testing.T) {
+		_, err := New("clamp_min($A, $B)")
+		require.Error(t, err)
+	})
+}
+
+func TestSeriesFuncs(t *testing.T) {
+	var tests = []struct {
+		name      string
+		expr      string
+		vars      Vars
+		newErrIs  require.ErrorAssertionFunc
+		execErrIs require.ErrorAssertionFunc
+		results   Results
+	}{
+		{
+			name: "rate on series with counter reset",
+			expr: "rate($A)",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil,
+						tp{time.Unix(10, 0), float64Pointer(10)},
+						tp{time.Unix(0, 0), float64Pointer(0)},
+						tp{time.Unix(20, 0), float64Pointer(5)}),
+				),
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(10, 0), float64Pointer(1)},
+					tp{time.Unix(20, 0), float64Pointer(0.5)}),
+			),
+		},
+		{
+			name: "delta on series",
+			expr: "delta($A)",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil,
+						tp{time.Unix(0, 0), float64Pointer(10)},
+						tp{time.Unix(10, 0), float64Pointer(4)},
+						tp{time.Unix(20, 0), float64Pointer(6)}),
+				),
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(10, 0), float64Pointer(-6)},
+					tp{time.Unix(20, 0), float64Pointer(2)}),
+			),
+		},
+		{
+			name: "timeshift on series",
+			expr: `timeshift($A, "1d")`,
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil,
+						tp{time.Unix(0, 0), float64Pointer(1)},
+						tp{time.Unix(60, 0), float64Pointer(2)}),
+				),
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(0, 0).Add(24 * time.Hour), float64Pointer(1)},
+					tp{time.Unix(60, 0).Add(24 * time.Hour), float64Pointer(2)}),
+			),
+		},
+		{
+			name:     "timeshift with invalid duration should error",
+			expr:     `timeshift($A, "yesterday")`,
+			vars:     Vars{},
+			newErrIs: require.Error,
+		},
+		{
+			name: "percentile on series",
+			expr: "percentile($A, 0.5)",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil,
+						tp{time.Unix(0, 0), float64Pointer(4)},
+						tp{time.Unix(10, 0), nil},
+						tp{time.Unix(20, 0), float64Pointer(1)},
+						tp{time.Unix(30, 0), float64Pointer(2)},
+						tp{time.Unix(40, 0), float64Pointer(3)}),
+				),
+			},
+			newErrIs:  require.NoError,
+			execErrIs: require.NoError,
+			results:   resultValuesNoErr(makeNumber("", nil, float64Pointer(2.5))),
+		},
+		{
+			name:      "percentile out of range should error",
+			expr:      "percentile($A, 95)",
+			vars:      Vars{"A": resultValuesNoErr(makeSeries("", nil))},
+			newErrIs:  require.NoError,
+			execErrIs: require.Error,
+		},
+		{
+			name:      "rate on number should error",
+			expr:      "rate($A)",
+			vars:      Vars{"A": resultValuesNoErr(makeNumber("", nil, float64Pointer(1)))},
+			newErrIs:  require.NoError,
+			execErrIs: require.Error,
+		},
+		{
+			name:     "rate on scalar should error",
+			expr:     "rate(1)",
+			vars:     Vars{},
+			newErrIs: require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := New(tt.expr)
+			tt.newErrIs(t, err)
+			if e != nil {
+				res, err := e.Execute("", tt.vars, tracing.InitializeTracerForTest())
+				tt.execErrIs(t, err)
+				if err == nil {
+					require.Equal(t, tt.results, res)
+				}
+			}
+		})
+	}
+}
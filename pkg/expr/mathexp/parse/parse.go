@@ -335,6 +335,14 @@ func (t *Tree) Func() (f *FuncNode) {
 		case itemRightParen:
 			return
 		}
+		switch token = t.next(); token.typ {
+		case itemComma:
+			// continue with the next argument
+		case itemRightParen:
+			return
+		default:
+			t.unexpected(token, "input: "+f.String())
+		}
 	}
 }
 
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 )
@@ -22,11 +24,45 @@ const (
 	ReducerCount  ReducerID = "count"
 	ReducerLast   ReducerID = "last"
 	ReducerMedian ReducerID = "median"
+	ReducerStdDev ReducerID = "stddev"
+	ReducerFirst  ReducerID = "first"
+	ReducerRange  ReducerID = "range"
+	// ReducerCountNonNull counts the values that are neither null nor NaN
+	ReducerCountNonNull ReducerID = "count_non_null"
+	// ReducerPercentile takes the percentile between 0 and 1 as parameter, e.g. "percentile(0.95)"
+	ReducerPercentile ReducerID = "percentile"
 )
 
 // GetSupportedReduceFuncs returns collection of supported function names
 func GetSupportedReduceFuncs() []ReducerID {
-	return []ReducerID{ReducerSum, ReducerMean, ReducerMin, ReducerMax, ReducerCount, ReducerLast, ReducerMedian}
+	return []ReducerID{ReducerSum, ReducerMean, ReducerMin, ReducerMax, ReducerCount, ReducerLast, ReducerMedian,
+		ReducerStdDev, ReducerFirst, ReducerRange, ReducerCountNonNull, ReducerPercentile}
+}
+
+// PercentileReducer returns the ID of the reducer that calculates the p-th percentile (0 <= p <= 1).
+func PercentileReducer(p float64) ReducerID {
+	return ReducerID(fmt.Sprintf("%s(%s)", ReducerPercentile, strconv.FormatFloat(p, 'f', -1, 64)))
+}
+
+// ParseReducer splits a parameterized reducer such as "percentile(0.95)" into its name and parameter.
+// The parameter is nil for reducers without a parameter.
+func ParseReducer(rFunc ReducerID) (ReducerID, *float64, error) {
+	name, rest, found := strings.Cut(string(rFunc), "(")
+	if !found {
+		return rFunc, nil, nil
+	}
+	rawParam, ok := strings.CutSuffix(rest, ")")
+	if !ok {
+		return "", nil, fmt.Errorf("reduction %v is missing a closing parenthesis", rFunc)
+	}
+	param, err := strconv.ParseFloat(strings.TrimSpace(rawParam), 64)
+	if err != nil {
+		return "", nil, fmt.Errorf("reduction %v has an invalid parameter: %w", rFunc, err)
+	}
+	if math.IsNaN(param) || math.IsInf(param, 0) {
+		return "", nil, fmt.Errorf("reduction %v has an invalid parameter: must be a finite number", rFunc)
+	}
+	return ReducerID(strings.TrimSpace(name)), &param, nil
 }
 
 func Sum(fv *Float64Field) *float64 {
@@ -126,8 +162,91 @@ func Median(fv *Float64Field) *float64 {
 	}
 }
 
+// StdDev returns the population standard deviation of the values.
+func StdDev(fv *Float64Field) *float64 {
+	mean := Avg(fv)
+	if fv.Len() == 0 || math.IsNaN(*mean) {
+		nan := math.NaN()
+		return &nan
+	}
+	var squares float64
+	for i := 0; i < fv.Len(); i++ {
+		d := *fv.GetValue(i) - *mean
+		squares += d * d
+	}
+	f := math.Sqrt(squares / float64(fv.Len()))
+	return &f
+}
+
+func First(fv *Float64Field) *float64 {
+	var f float64
+	if fv.Len() == 0 {
+		f = math.NaN()
+		return &f
+	}
+	return fv.GetValue(0)
+}
+
+// Range returns the difference between the maximum and the minimum value.
+func Range(fv *Float64Field) *float64 {
+	f := *Max(fv) - *Min(fv)
+	return &f
+}
+
+// CountNonNull returns the number of values that are neither null nor NaN.
+func CountNonNull(fv *Float64Field) *float64 {
+	var f float64
+	for i := 0; i < fv.Len(); i++ {
+		if v := fv.GetValue(i); v != nil && !math.IsNaN(*v) {
+			f++
+		}
+	}
+	return &f
+}
+
+// PercentileFunc returns a reducer that calculates the p-th percentile (0 <= p <= 1) of the values,
+// interpolating linearly between the closest ranks.
+func PercentileFunc(p float64) ReducerFunc {
+	return func(fv *Float64Field) *float64 {
+		values := make([]float64, 0, fv.Len())
+		for i := 0; i < fv.Len(); i++ {
+			v := fv.GetValue(i)
+			if v == nil || math.IsNaN(*v) {
+				nan := math.NaN()
+				return &nan
+			}
+			values = append(values, *v)
+		}
+		sort.Float64s(values)
+		f := percentileOfSorted(values, p)
+		return &f
+	}
+}
+
+// percentileOfSorted returns the p-th percentile (0 <= p <= 1) of the sorted values, or NaN if there are none.
+func percentileOfSorted(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	rank := p * float64(len(values)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return values[lower]
+	}
+	return values[lower] + (rank-float64(lower))*(values[upper]-values[lower])
+}
+
 func GetReduceFunc(rFunc ReducerID) (ReducerFunc, error) {
-	switch rFunc {
+	name, param, err := ParseReducer(rFunc)
+	if err != nil {
+		return nil, err
+	}
+	if param != nil && name != ReducerPercentile {
+		return nil, fmt.Errorf("reduction %v does not take a parameter", name)
+	}
+
+	switch name {
 	case ReducerSum:
 		return Sum, nil
 	case ReducerMean:
@@ -142,6 +261,22 @@ func GetReduceFunc(rFunc ReducerID) (ReducerFunc, error) {
 		return Last, nil
 	case ReducerMedian:
 		return Median, nil
+	case ReducerStdDev:
+		return StdDev, nil
+	case ReducerFirst:
+		return First, nil
+	case ReducerRange:
+		return Range, nil
+	case ReducerCountNonNull:
+		return CountNonNull, nil
+	case ReducerPercentile:
+		if param == nil {
+			return nil, fmt.Errorf("reduction %v requires a parameter, e.g. %v", name, PercentileReducer(0.95))
+		}
+		if *param < 0 || *param > 1 {
+			return nil, fmt.Errorf("reduction %v expects a percentile between 0 and 1, got %v", name, *param)
+		}
+		return PercentileFunc(*param), nil
 	default:
 		return nil, fmt.Errorf("reduction %v not implemented", rFunc)
 	}
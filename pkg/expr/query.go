@@ -40,7 +40,7 @@ type ReduceQuery struct {
 	// Reference to single query result
 	Expression string `json:"expression" jsonschema:"minLength=1,example=$A"`
 
-	// The reducer
+	// The reducer, e.g. "max" or "percentile(0.95)"
 	Reducer mathexp.ReducerID `json:"reducer"`
 
 	// Reducer Options
@@ -55,7 +55,7 @@ type ResampleQuery struct {
 	// The time duration
 	Window string `json:"window" jsonschema:"minLength=1,example=1d,example=10m"`
 
-	// The downsample function
+	// The downsample function, e.g. "mean" or "percentile(0.95)"
 	Downsampler mathexp.ReducerID `json:"downsampler"`
 
 	// The upsample function
@@ -3,6 +3,8 @@ package expr
 import (
 	"encoding/json"
 	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
 	data "github.com/grafana/grafana-plugin-sdk-go/experimental/apis/data/v0alpha1"
@@ -30,6 +32,14 @@ func TestQueryTypeDefinitions(t *testing.T) {
 			},
 		})
 	require.NoError(t, err)
+
+	// Parameterised reducers such as "percentile(0.95)" can not be listed as enum values,
+	// so reducers are described with a pattern instead.
+	reducerSchema := builder.Reflector().Mapper(reflect.TypeOf(mathexp.ReducerSum))
+	require.NotNil(t, reducerSchema)
+	reducerSchema.Enum = nil
+	reducerSchema.Pattern = reducerPattern()
+
 	err = builder.AddQueries(
 		schemabuilder.QueryTypeInfo{
 			Discriminators: data.NewDiscriminators("type", QueryTypeMath),
@@ -167,6 +177,29 @@ func TestQueryTypeDefinitions(t *testing.T) {
 	_ = builder.UpdateQueryDefinition(t, "./")
 }
 
+func TestReducerPattern(t *testing.T) {
+	pattern := regexp.MustCompile(reducerPattern())
+	for _, r := range []string{"max", "count_non_null", "percentile(0.95)", "percentile(0)", "percentile(1)", "percentile(.5)"} {
+		require.True(t, pattern.MatchString(r), r)
+	}
+	for _, r := range []string{"percentile", "percentile(95)", "percentile(1.5)", "percentile(NaN)", "max(1)", "foo"} {
+		require.False(t, pattern.MatchString(r), r)
+	}
+}
+
+// reducerPattern matches the supported reducers, where the percentile reducer takes a percentile between 0 and 1.
+func reducerPattern() string {
+	names := []string{}
+	for _, r := range mathexp.GetSupportedReduceFuncs() {
+		if r == mathexp.ReducerPercentile {
+			names = append(names, regexp.QuoteMeta(string(r))+`\(\s*(0(\.\d*)?|1(\.0*)?|\.\d+)\s*\)`)
+			continue
+		}
+		names = append(names, regexp.QuoteMeta(string(r)))
+	}
+	return "^(" + strings.Join(names, "|") + ")$"
+}
+
 func toUnstructured(ex string) data.Unstructured {
 	v := data.Unstructured{}
 	_ = json.Unmarshal([]byte(ex), &v.Object)
@@ -67,6 +67,8 @@ type Service struct {
 
 	tracer  tracing.Tracer
 	metrics *metrics.ExprMetrics
+
+	sqlCatalog sqlCatalog
 }
 
 type pluginContextProvider interface {
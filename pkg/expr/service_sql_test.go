@@ -10,8 +10,10 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/services/datasources"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/stretchr/testify/require"
 )
 
@@ -109,4 +111,61 @@ func TestSQLService(t *testing.T) {
 		require.Error(t, rsp.Responses["B"].Error, "should return sql error on parsing")
 		require.ErrorContains(t, rsp.Responses["B"].Error, "limit expression expected to be numeric")
 	})
+
+	catalogQuery := `SELECT A.time FROM A LEFT JOIN grafana.annotations AS an ON an.text = 'maintenance' WHERE an.text IS NULL`
+	maintenance := func(_ context.Context, user identity.Requester, _ backend.TimeRange) (*data.Frame, error) {
+		if user.GetOrgID() != 1 {
+			return data.NewFrame("", data.NewField("text", nil, []string{})), nil
+		}
+		return data.NewFrame("", data.NewField("text", nil, []string{"maintenance"})), nil
+	}
+
+	t.Run("catalog tables are disabled by default", func(t *testing.T) {
+		s, req := newMockQueryService(resp, newABSQLQueries(catalogQuery))
+		s.features = featuremgmt.WithFeatures(featuremgmt.FlagSqlExpressions)
+		s.RegisterSQLCatalogTable("annotations", maintenance)
+
+		pl, err := s.BuildPipeline(req)
+		require.NoError(t, err)
+
+		rsp, err := s.ExecutePipeline(context.Background(), time.Now(), pl)
+		require.NoError(t, err)
+		require.ErrorContains(t, rsp.Responses["B"].Error, "catalog tables, which are not enabled")
+	})
+
+	t.Run("catalog tables are read on behalf of the user of the request", func(t *testing.T) {
+		s, req := newMockQueryService(resp, newABSQLQueries(catalogQuery))
+		s.features = featuremgmt.WithFeatures(featuremgmt.FlagSqlExpressions)
+		s.cfg.SQLExpressionCatalogEnabled = true
+		s.RegisterSQLCatalogTable("annotations", maintenance)
+
+		req.User = &user.SignedInUser{OrgID: 1}
+		pl, err := s.BuildPipeline(req)
+		require.NoError(t, err)
+		rsp, err := s.ExecutePipeline(context.Background(), time.Now(), pl)
+		require.NoError(t, err)
+		require.NoError(t, rsp.Responses["B"].Error)
+		require.Equal(t, 0, rsp.Responses["B"].Frames[0].Rows())
+
+		req.User = &user.SignedInUser{OrgID: 2}
+		pl, err = s.BuildPipeline(req)
+		require.NoError(t, err)
+		rsp, err = s.ExecutePipeline(context.Background(), time.Now(), pl)
+		require.NoError(t, err)
+		require.NoError(t, rsp.Responses["B"].Error)
+		require.Equal(t, 1, rsp.Responses["B"].Frames[0].Rows())
+	})
+
+	t.Run("unknown catalog tables are an error", func(t *testing.T) {
+		s, req := newMockQueryService(resp, newABSQLQueries("SELECT * FROM grafana.dashboards"))
+		s.features = featuremgmt.WithFeatures(featuremgmt.FlagSqlExpressions)
+		s.cfg.SQLExpressionCatalogEnabled = true
+
+		pl, err := s.BuildPipeline(req)
+		require.NoError(t, err)
+
+		rsp, err := s.ExecutePipeline(context.Background(), time.Now(), pl)
+		require.NoError(t, err)
+		require.ErrorContains(t, rsp.Responses["B"].Error, "unknown catalog table grafana.dashboards")
+	})
 }
@@ -60,6 +60,7 @@ type QueryOption func(*QueryOptions)
 type QueryOptions struct {
 	Timeout        time.Duration
 	MaxOutputCells int64
+	CatalogFrames  []*data.Frame
 }
 
 func WithTimeout(d time.Duration) QueryOption {
@@ -74,6 +75,13 @@ func WithMaxOutputCells(n int64) QueryOption {
 	}
 }
 
+// WithCatalogFrames makes the frames available as catalog tables, named after their RefID.
+func WithCatalogFrames(frames []*data.Frame) QueryOption {
+	return func(o *QueryOptions) {
+		o.CatalogFrames = frames
+	}
+}
+
 // QueryFrames runs the sql query query against a database created from frames, and returns the frame.
 // The RefID of each frame becomes a table in the database.
 // It is expected that there is only one frame per RefID.
@@ -100,7 +108,7 @@ func (db *DB) QueryFrames(ctx context.Context, tracer tracing.Tracer, name strin
 	_, span := tracer.Start(ctx, "SSE.ExecuteGMSQuery")
 	defer span.End()
 
-	pro := NewFramesDBProvider(frames)
+	pro := newFramesDBProvider(frames, QueryOptions.CatalogFrames)
 	session := mysql.NewBaseSession()
 
 	// Create a new context with the session and tracer
@@ -286,6 +286,32 @@ func TestQueryFrames_JSONFilter(t *testing.T) {
 	}
 }
 
+func TestQueryFrames_CatalogTables(t *testing.T) {
+	input := data.NewFrame("A",
+		data.NewField("host", nil, []string{"a", "b"}),
+		data.NewField("value", nil, []float64{1, 2}),
+	).SetRefID("A")
+	maintenance := data.NewFrame("annotations",
+		data.NewField("text", nil, []string{"maintenance"}),
+		data.NewField("tags", nil, []string{"b"}),
+	).SetRefID("annotations")
+
+	db := DB{}
+	query := `SELECT A.host, A.value FROM A
+		LEFT JOIN grafana.annotations AS an ON an.tags = A.host AND an.text = 'maintenance'
+		WHERE an.text IS NULL`
+
+	result, err := db.QueryFrames(context.Background(), &testTracer{}, "B", query, data.Frames{input}, WithCatalogFrames(data.Frames{maintenance}))
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Rows())
+	require.Equal(t, "a", result.Fields[0].At(0))
+
+	t.Run("catalog tables are not frames of the request", func(t *testing.T) {
+		_, err := db.QueryFrames(context.Background(), &testTracer{}, "B", "SELECT * FROM annotations", data.Frames{input}, WithCatalogFrames(data.Frames{maintenance}))
+		require.Error(t, err)
+	})
+}
+
 func TestQueryFrames_Limits(t *testing.T) {
 	tests := []struct {
 		name        string
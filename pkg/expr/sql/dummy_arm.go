@@ -31,6 +31,12 @@ func WithMaxOutputCells(_ int64) QueryOption {
 	}
 }
 
+func WithCatalogFrames(_ []*data.Frame) QueryOption {
+	return func(_ *QueryOptions) {
+		// no-op
+	}
+}
+
 type QueryOptions struct{}
 
 type QueryOption func(*QueryOptions)
@@ -3,18 +3,25 @@
 package sql
 
 import (
+	"strings"
+
 	mysql "github.com/dolthub/go-mysql-server/sql"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 )
 
 var dbName = "frames"
 
-// FramesDBProvider is a go-mysql-server DatabaseProvider that provides access to a set of Frames.
+// FramesDBProvider is a go-mysql-server DatabaseProvider that provides access to a set of Frames,
+// and to the catalog tables under the CatalogDatabase name.
 type FramesDBProvider struct {
-	db mysql.Database
+	db      mysql.Database
+	catalog mysql.Database
 }
 
-func (p *FramesDBProvider) Database(_ *mysql.Context, _ string) (mysql.Database, error) {
+func (p *FramesDBProvider) Database(_ *mysql.Context, name string) (mysql.Database, error) {
+	if strings.EqualFold(name, CatalogDatabase) {
+		return p.catalog, nil
+	}
 	return p.db, nil
 }
 
@@ -23,24 +30,37 @@ func (p *FramesDBProvider) HasDatabase(_ *mysql.Context, _ string) bool {
 }
 
 func (p *FramesDBProvider) AllDatabases(_ *mysql.Context) []mysql.Database {
-	return []mysql.Database{p.db}
+	return []mysql.Database{p.db, p.catalog}
 }
 
 // NewFramesDBProvider creates a new FramesDBProvider with the given set of Frames.
 func NewFramesDBProvider(frames data.Frames) mysql.DatabaseProvider {
+	return newFramesDBProvider(frames, nil)
+}
+
+// newFramesDBProvider creates a new FramesDBProvider with the given set of Frames and catalog tables.
+// The RefID of each catalog frame is the name of its table.
+func newFramesDBProvider(frames data.Frames, catalogFrames data.Frames) *FramesDBProvider {
+	return &FramesDBProvider{
+		db:      newFramesDB(dbName, frames),
+		catalog: newFramesDB(CatalogDatabase, catalogFrames),
+	}
+}
+
+func newFramesDB(name string, frames data.Frames) *framesDB {
 	fMap := make(map[string]mysql.Table, len(frames))
 	for _, frame := range frames {
 		fMap[frame.RefID] = &FrameTable{Frame: frame}
 	}
-	return &FramesDBProvider{
-		db: &framesDB{
-			frames: fMap,
-		},
+	return &framesDB{
+		name:   name,
+		frames: fMap,
 	}
 }
 
 // framesDB is a go-mysql-server Database that provides access to a set of Frames.
 type framesDB struct {
+	name   string
 	frames map[string]mysql.Table
 }
 
@@ -61,5 +81,5 @@ func (db *framesDB) GetTableNames(_ *mysql.Context) ([]string, error) {
 }
 
 func (db *framesDB) Name() string {
-	return dbName
+	return db.name
 }
@@ -11,8 +11,17 @@ import (
 
 var logger = log.New("sql_expr")
 
+// CatalogDatabase is the name of the database holding the read-only catalog tables,
+// which are referenced as grafana.<table> in SQL expressions.
+const CatalogDatabase = "grafana"
+
+// isCatalogTable returns true if the table is qualified with the catalog database.
+func isCatalogTable(tableName sqlparser.TableName) bool {
+	return strings.EqualFold(tableName.DbQualifier.String(), CatalogDatabase) && tableName.SchemaQualifier.IsEmpty()
+}
+
 // TablesList returns a list of tables for the sql statement excluding
-// CTEs and the 'dual' table. The list is sorted alphabetically.
+// CTEs, catalog tables and the 'dual' table. The list is sorted alphabetically.
 func TablesList(rawSQL string) ([]string, error) {
 	stmt, err := sqlparser.Parse(rawSQL)
 	if err != nil {
@@ -34,11 +43,13 @@ func TablesList(rawSQL string) ([]string, error) {
 				}
 
 			case *sqlparser.AliasedTableExpr:
-				if tableName, ok := v.Expr.(sqlparser.TableName); ok {
+				if tableName, ok := v.Expr.(sqlparser.TableName); ok && !isCatalogTable(tableName) {
 					tables[tableName.Name.String()] = struct{}{}
 				}
 			case *sqlparser.TableName:
-				tables[v.Name.String()] = struct{}{}
+				if !isCatalogTable(*v) {
+					tables[v.Name.String()] = struct{}{}
+				}
 			}
 			return true, nil
 		}, node)
@@ -76,3 +87,33 @@ func TablesList(rawSQL string) ([]string, error) {
 
 	return result, nil
 }
+
+// CatalogTablesList returns the names of the catalog tables referenced by the sql statement,
+// without the catalog database qualifier. The list is lower cased and sorted alphabetically.
+func CatalogTablesList(rawSQL string) ([]string, error) {
+	stmt, err := sqlparser.Parse(rawSQL)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing sql: %s", err.Error())
+	}
+
+	tables := make(map[string]struct{})
+	err = sqlparser.Walk(func(node sqlparser.SQLNode) (kontinue bool, err error) {
+		if v, ok := node.(*sqlparser.AliasedTableExpr); ok {
+			if tableName, ok := v.Expr.(sqlparser.TableName); ok && isCatalogTable(tableName) {
+				tables[strings.ToLower(tableName.Name.String())] = struct{}{}
+			}
+		}
+		return true, nil
+	}, stmt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SQL expression: %w", err)
+	}
+
+	result := make([]string, 0, len(tables))
+	for table := range tables {
+		result = append(result, table)
+	}
+	sort.Strings(result)
+
+	return result, nil
+}
@@ -147,7 +147,11 @@ func allowedNode(node sqlparser.SQLNode) (b bool) {
 	case *sqlparser.Subquery:
 		return
 
-	case sqlparser.TableName, sqlparser.TableExprs, sqlparser.TableIdent:
+	case sqlparser.TableName:
+		// Tables are either the frames of the request or, when qualified, catalog tables
+		return (v.DbQualifier.IsEmpty() && v.SchemaQualifier.IsEmpty()) || isCatalogTable(v)
+
+	case sqlparser.TableExprs, sqlparser.TableIdent:
 		return
 
 	case *sqlparser.TimestampFuncExpr:
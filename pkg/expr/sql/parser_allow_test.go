@@ -1,6 +1,7 @@
 package sql
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -92,6 +93,21 @@ func TestAllowQuery(t *testing.T) {
 			q:    "SELECT * FROM mockGitHubIssuesDSResponse, JSON_TABLE(labels, '$[*]' COLUMNS(val VARCHAR(255) PATH '$')) AS jt WHERE CAST(jt.val AS CHAR) LIKE 'type%'",
 			err:  nil,
 		},
+		{
+			name: "catalog tables",
+			q:    "SELECT A.value FROM A LEFT JOIN grafana.annotations AS an ON an.text = 'maintenance' WHERE grafana.annotations.id IS NULL",
+			err:  nil,
+		},
+		{
+			name: "tables of other databases are not allowed",
+			q:    "SELECT * FROM mysql.user",
+			err:  errors.New("blocked node sqlparser.TableName - not supported in queries"),
+		},
+		{
+			name: "information schema is not allowed",
+			q:    "SELECT * FROM information_schema.tables",
+			err:  errors.New("blocked node sqlparser.TableName - not supported in queries"),
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -117,6 +117,11 @@ func TestTablesList(t *testing.T) {
 			sql:      "SELECT json_serialize_sql('SELECT 1')",
 			expected: []string{},
 		},
+		{
+			name:     "catalog tables are not frames",
+			sql:      "select * from A join grafana.annotations on A.name = grafana.annotations.text",
+			expected: []string{"A"},
+		},
 	}
 
 	for _, tc := range tests {
@@ -131,3 +136,15 @@ func TestTablesList(t *testing.T) {
 		})
 	}
 }
+
+func TestCatalogTablesList(t *testing.T) {
+	tables, err := CatalogTablesList(`select * from A
+		left join grafana.annotations as an on an.text = A.name
+		where not exists (select 1 from GRAFANA.Silences)`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"annotations", "silences"}, tables)
+
+	tables, err = CatalogTablesList("select * from A")
+	require.NoError(t, err)
+	require.Empty(t, tables)
+}
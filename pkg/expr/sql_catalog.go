@@ -0,0 +1,91 @@
+package expr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/expr/sql"
+)
+
+// SQLCatalogTable returns the rows of a catalog table the user can read. The time range is the
+// one of the SQL expression, tables without a time dimension can ignore it.
+type SQLCatalogTable func(ctx context.Context, user identity.Requester, timeRange backend.TimeRange) (*data.Frame, error)
+
+// sqlCatalog holds the read-only tables SQL expressions can query as grafana.<table>.
+type sqlCatalog struct {
+	mtx    sync.RWMutex
+	tables map[string]SQLCatalogTable
+}
+
+func (c *sqlCatalog) register(name string, table SQLCatalogTable) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.tables == nil {
+		c.tables = make(map[string]SQLCatalogTable)
+	}
+	c.tables[strings.ToLower(name)] = table
+}
+
+func (c *sqlCatalog) table(name string) (SQLCatalogTable, bool) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	table, ok := c.tables[strings.ToLower(name)]
+	return table, ok
+}
+
+// RegisterSQLCatalogTable makes the table available to SQL expressions as grafana.<name>
+// when the catalog is enabled. The table is only read when a SQL expression references it.
+func (s *Service) RegisterSQLCatalogTable(name string, table SQLCatalogTable) {
+	s.sqlCatalog.register(name, table)
+}
+
+// setSQLCatalog gives the SQL expression of the node access to the catalog tables, on behalf of the user of the request.
+func (s *Service) setSQLCatalog(node *CMDNode, rn *rawNode, user identity.Requester) {
+	cmd, ok := node.Command.(*SQLCommand)
+	if !ok || s.cfg == nil || !s.cfg.SQLExpressionCatalogEnabled {
+		return
+	}
+	cmd.catalog = &s.sqlCatalog
+	cmd.user = user
+	cmd.timeRange = rn.TimeRange
+}
+
+// readCatalogTables returns a frame for each catalog table referenced by the SQL expression.
+func (gr *SQLCommand) readCatalogTables(ctx context.Context, now time.Time) ([]*data.Frame, error) {
+	if len(gr.catalogTables) == 0 {
+		return nil, nil
+	}
+	if gr.catalog == nil {
+		return nil, fmt.Errorf("SQL expression for refId %v references catalog tables, which are not enabled", gr.refID)
+	}
+	if gr.user == nil {
+		return nil, fmt.Errorf("SQL expression for refId %v references catalog tables, which require a signed in user", gr.refID)
+	}
+
+	var timeRange backend.TimeRange
+	if gr.timeRange != nil {
+		timeRange = gr.timeRange.AbsoluteTime(now)
+	}
+
+	frames := make([]*data.Frame, 0, len(gr.catalogTables))
+	for _, name := range gr.catalogTables {
+		table, ok := gr.catalog.table(name)
+		if !ok {
+			return nil, fmt.Errorf("SQL expression for refId %v references unknown catalog table %s.%s", gr.refID, sql.CatalogDatabase, name)
+		}
+		frame, err := table(ctx, gr.user, timeRange)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read catalog table %s.%s: %w", sql.CatalogDatabase, name, err)
+		}
+		frame.RefID = name
+		frames = append(frames, frame)
+	}
+	return frames, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
 	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/expr/mathexp"
 	"github.com/grafana/grafana/pkg/expr/metrics"
 	"github.com/grafana/grafana/pkg/expr/sql"
@@ -37,6 +38,13 @@ type SQLCommand struct {
 	inputLimit  int64
 	outputLimit int64
 	timeout     time.Duration
+
+	// catalogTables are the catalog tables referenced by the query. They can only be read
+	// when the catalog is set, on behalf of user and for timeRange.
+	catalogTables []string
+	catalog       *sqlCatalog
+	user          identity.Requester
+	timeRange     TimeRange
 }
 
 // NewSQLCommand creates a new SQLCommand.
@@ -63,15 +71,28 @@ func NewSQLCommand(refID, format, rawSQL string, intputLimit, outputLimit int64,
 	if tables != nil {
 		logger.Debug("REF tables", "tables", tables, "sql", rawSQL)
 	}
+	catalogTables, err := sql.CatalogTablesList(rawSQL)
+	if err != nil {
+		return nil, ErrInvalidSQLQuery.Build(errutil.TemplateData{
+			Error: err,
+			Public: map[string]any{
+				"error": err.Error(),
+			},
+			Private: map[string]any{
+				"query": rawSQL,
+			},
+		})
+	}
 
 	return &SQLCommand{
-		query:       rawSQL,
-		varsToQuery: tables,
-		refID:       refID,
-		inputLimit:  intputLimit,
-		outputLimit: outputLimit,
-		timeout:     timeout,
-		format:      format,
+		query:         rawSQL,
+		varsToQuery:   tables,
+		refID:         refID,
+		inputLimit:    intputLimit,
+		outputLimit:   outputLimit,
+		timeout:       timeout,
+		format:        format,
+		catalogTables: catalogTables,
 	}, nil
 }
 
@@ -138,7 +159,13 @@ func (gr *SQLCommand) Execute(ctx context.Context, now time.Time, vars mathexp.V
 		allFrames = append(allFrames, frames...)
 	}
 
-	tc = totalCells(allFrames)
+	catalogFrames, err := gr.readCatalogTables(ctx, now)
+	if err != nil {
+		rsp.Error = err
+		return rsp, nil
+	}
+
+	tc = totalCells(allFrames) + totalCells(catalogFrames)
 
 	// limit of 0 or less means no limit (following convention)
 	if gr.inputLimit > 0 && tc > gr.inputLimit {
@@ -153,7 +180,7 @@ func (gr *SQLCommand) Execute(ctx context.Context, now time.Time, vars mathexp.V
 	logger.Debug("Executing query", "query", gr.query, "frames", len(allFrames))
 
 	db := sql.DB{}
-	frame, err := db.QueryFrames(ctx, tracer, gr.refID, gr.query, allFrames, sql.WithMaxOutputCells(gr.outputLimit), sql.WithTimeout(gr.timeout))
+	frame, err := db.QueryFrames(ctx, tracer, gr.refID, gr.query, allFrames, sql.WithMaxOutputCells(gr.outputLimit), sql.WithTimeout(gr.timeout), sql.WithCatalogFrames(catalogFrames))
 
 	if err != nil {
 		logger.Error("Failed to query frames", "error", err.Error())
@@ -11,14 +11,15 @@ import (
 // RepositorySpecApplyConfiguration represents a declarative configuration of the RepositorySpec type for use
 // with apply.
 type RepositorySpecApplyConfiguration struct {
-	Title       *string                                   `json:"title,omitempty"`
-	Description *string                                   `json:"description,omitempty"`
-	Workflows   []provisioningv0alpha1.Workflow           `json:"workflows,omitempty"`
-	Sync        *SyncOptionsApplyConfiguration            `json:"sync,omitempty"`
-	Type        *provisioningv0alpha1.RepositoryType      `json:"type,omitempty"`
-	Local       *LocalRepositoryConfigApplyConfiguration  `json:"local,omitempty"`
-	GitHub      *GitHubRepositoryConfigApplyConfiguration `json:"github,omitempty"`
-	Git         *GitRepositoryConfigApplyConfiguration    `json:"git,omitempty"`
+	Title         *string                                          `json:"title,omitempty"`
+	Description   *string                                          `json:"description,omitempty"`
+	Workflows     []provisioningv0alpha1.Workflow                  `json:"workflows,omitempty"`
+	Sync          *SyncOptionsApplyConfiguration                   `json:"sync,omitempty"`
+	Type          *provisioningv0alpha1.RepositoryType             `json:"type,omitempty"`
+	Local         *LocalRepositoryConfigApplyConfiguration         `json:"local,omitempty"`
+	GitHub        *GitHubRepositoryConfigApplyConfiguration        `json:"github,omitempty"`
+	Git           *GitRepositoryConfigApplyConfiguration           `json:"git,omitempty"`
+	ObjectStorage *ObjectStorageRepositoryConfigApplyConfiguration `json:"objectStorage,omitempty"`
 }
 
 // RepositorySpecApplyConfiguration constructs a declarative configuration of the RepositorySpec type for use with
@@ -92,3 +93,11 @@ func (b *RepositorySpecApplyConfiguration) WithGit(value *GitRepositoryConfigApp
 	b.Git = value
 	return b
 }
+
+// WithObjectStorage sets the ObjectStorage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObjectStorage field is set to the value of the last call.
+func (b *RepositorySpecApplyConfiguration) WithObjectStorage(value *ObjectStorageRepositoryConfigApplyConfiguration) *RepositorySpecApplyConfiguration {
+	b.ObjectStorage = value
+	return b
+}
@@ -0,0 +1,214 @@
+package alertrule
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	prommodel "github.com/prometheus/common/model"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/grafana/grafana/pkg/apimachinery/utils"
+	alertrule "github.com/grafana/grafana/pkg/apis/alertrule/v0alpha1"
+	"github.com/grafana/grafana/pkg/services/apiserver/endpoints/request"
+	gapiutil "github.com/grafana/grafana/pkg/services/apiserver/utils"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func convertToK8sResource(rule *ngmodels.AlertRule, namespacer request.NamespaceMapper) (*alertrule.AlertRule, error) {
+	spec := alertrule.AlertRuleSpec{
+		Title:         rule.Title,
+		Group:         rule.RuleGroup,
+		Condition:     rule.Condition,
+		NoDataState:   string(rule.NoDataState),
+		ExecErrState:  string(rule.ExecErrState),
+		For:           formatDuration(rule.For),
+		KeepFiringFor: formatDuration(rule.KeepFiringFor),
+		Annotations:   rule.Annotations,
+		Labels:        rule.Labels,
+		IsPaused:      rule.IsPaused,
+	}
+	if rule.MissingSeriesEvalsToResolve != nil {
+		v := int64(*rule.MissingSeriesEvalsToResolve)
+		spec.MissingSeriesEvalsToResolve = &v
+	}
+	for _, q := range rule.Data {
+		query := alertrule.AlertQuery{
+			RefId:     q.RefID,
+			QueryType: q.QueryType,
+			RelativeTimeRange: alertrule.RelativeTimeRange{
+				From: int64(time.Duration(q.RelativeTimeRange.From).Seconds()),
+				To:   int64(time.Duration(q.RelativeTimeRange.To).Seconds()),
+			},
+			DatasourceUid: q.DatasourceUID,
+		}
+		if len(q.Model) > 0 {
+			if err := json.Unmarshal(q.Model, &query.Model); err != nil {
+				return nil, fmt.Errorf("failed to read the model of query %s: %w", q.RefID, err)
+			}
+		}
+		spec.Data = append(spec.Data, query)
+	}
+	if len(rule.NotificationSettings) > 0 {
+		ns := rule.NotificationSettings[0]
+		spec.NotificationSettings = &alertrule.NotificationSettings{
+			Receiver:            ns.Receiver,
+			GroupBy:             ns.GroupBy,
+			GroupWait:           formatOptionalDuration(ns.GroupWait),
+			GroupInterval:       formatOptionalDuration(ns.GroupInterval),
+			RepeatInterval:      formatOptionalDuration(ns.RepeatInterval),
+			MuteTimeIntervals:   ns.MuteTimeIntervals,
+			ActiveTimeIntervals: ns.ActiveTimeIntervals,
+		}
+	}
+	if rule.Record != nil {
+		spec.Record = &alertrule.Record{
+			Metric:              rule.Record.Metric,
+			From:                rule.Record.From,
+			TargetDatasourceUid: rule.Record.TargetDatasourceUID,
+		}
+	}
+
+	result := &alertrule.AlertRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            rule.UID,
+			Namespace:       namespacer(rule.OrgID),
+			ResourceVersion: strconv.FormatInt(rule.Version, 10),
+		},
+		Spec: spec,
+	}
+	meta, err := utils.MetaAccessor(result)
+	if err != nil {
+		return nil, err
+	}
+	meta.SetFolder(rule.NamespaceUID)
+	meta.SetUpdatedTimestamp(&rule.Updated)
+	result.UID = gapiutil.CalculateClusterWideUID(result)
+	return result, nil
+}
+
+func convertToDomainModel(orgID int64, obj *alertrule.AlertRule) (ngmodels.AlertRule, error) {
+	meta, err := utils.MetaAccessor(obj)
+	if err != nil {
+		return ngmodels.AlertRule{}, err
+	}
+	spec := obj.Spec
+	rule := ngmodels.AlertRule{
+		UID:          obj.Name,
+		OrgID:        orgID,
+		NamespaceUID: meta.GetFolder(),
+		RuleGroup:    spec.Group,
+		Title:        spec.Title,
+		Condition:    spec.Condition,
+		NoDataState:  ngmodels.NoDataState(spec.NoDataState),
+		ExecErrState: ngmodels.ExecutionErrorState(spec.ExecErrState),
+		Annotations:  spec.Annotations,
+		Labels:       spec.Labels,
+		IsPaused:     spec.IsPaused,
+	}
+	if spec.MissingSeriesEvalsToResolve != nil {
+		v := int(*spec.MissingSeriesEvalsToResolve)
+		rule.MissingSeriesEvalsToResolve = &v
+	}
+	if rule.NamespaceUID == "" {
+		return ngmodels.AlertRule{}, errors.NewBadRequest("alert rule must be in a folder")
+	}
+	if rule.For, err = parseDuration("for", spec.For); err != nil {
+		return ngmodels.AlertRule{}, err
+	}
+	if rule.KeepFiringFor, err = parseDuration("keepFiringFor", spec.KeepFiringFor); err != nil {
+		return ngmodels.AlertRule{}, err
+	}
+	for _, q := range spec.Data {
+		model := json.RawMessage("{}")
+		if q.Model.Object != nil {
+			if model, err = json.Marshal(&q.Model); err != nil {
+				return ngmodels.AlertRule{}, err
+			}
+		}
+		rule.Data = append(rule.Data, ngmodels.AlertQuery{
+			RefID:     q.RefId,
+			QueryType: q.QueryType,
+			RelativeTimeRange: ngmodels.RelativeTimeRange{
+				From: ngmodels.Duration(time.Duration(q.RelativeTimeRange.From) * time.Second),
+				To:   ngmodels.Duration(time.Duration(q.RelativeTimeRange.To) * time.Second),
+			},
+			DatasourceUID: q.DatasourceUid,
+			Model:         model,
+		})
+	}
+	if ns := spec.NotificationSettings; ns != nil {
+		settings := ngmodels.NotificationSettings{
+			Receiver:            ns.Receiver,
+			GroupBy:             ns.GroupBy,
+			MuteTimeIntervals:   ns.MuteTimeIntervals,
+			ActiveTimeIntervals: ns.ActiveTimeIntervals,
+		}
+		if settings.GroupWait, err = parseOptionalDuration("notificationSettings.groupWait", ns.GroupWait); err != nil {
+			return ngmodels.AlertRule{}, err
+		}
+		if settings.GroupInterval, err = parseOptionalDuration("notificationSettings.groupInterval", ns.GroupInterval); err != nil {
+			return ngmodels.AlertRule{}, err
+		}
+		if settings.RepeatInterval, err = parseOptionalDuration("notificationSettings.repeatInterval", ns.RepeatInterval); err != nil {
+			return ngmodels.AlertRule{}, err
+		}
+		rule.NotificationSettings = []ngmodels.NotificationSettings{settings}
+	}
+	if spec.Record != nil {
+		rule.Record = &ngmodels.Record{
+			Metric:              spec.Record.Metric,
+			From:                spec.Record.From,
+			TargetDatasourceUID: spec.Record.TargetDatasourceUid,
+		}
+		ngmodels.ClearRecordingRuleIgnoredFields(&rule)
+	} else {
+		// Use the same defaults as the alert rule form
+		if rule.NoDataState == "" {
+			rule.NoDataState = ngmodels.NoData
+		}
+		if rule.ExecErrState == "" {
+			rule.ExecErrState = ngmodels.ErrorErrState
+		}
+	}
+	return rule, nil
+}
+
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return prommodel.Duration(d).String()
+}
+
+func formatOptionalDuration(d *prommodel.Duration) string {
+	if d == nil {
+		return ""
+	}
+	return d.String()
+}
+
+func parseDuration(field string, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := prommodel.ParseDuration(value)
+	if err != nil {
+		return 0, errors.NewBadRequest(fmt.Sprintf("invalid %s: %s", field, err))
+	}
+	return time.Duration(d), nil
+}
+
+func parseOptionalDuration(field string, value string) (*prommodel.Duration, error) {
+	if value == "" {
+		return nil, nil
+	}
+	d, err := parseDuration(field, value)
+	if err != nil {
+		return nil, err
+	}
+	result := prommodel.Duration(d)
+	return &result, nil
+}
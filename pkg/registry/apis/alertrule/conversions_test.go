@@ -0,0 +1,130 @@
+package alertrule
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	prommodel "github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/apimachinery/utils"
+	"github.com/grafana/grafana/pkg/services/apiserver/endpoints/request"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestConversions(t *testing.T) {
+	namespacer := request.GetNamespaceMapper(nil)
+	groupWait := prommodel.Duration(30 * time.Second)
+	evals := 3
+
+	t.Run("alert rule round trip", func(t *testing.T) {
+		rule := ngmodels.AlertRule{
+			UID:          "rule-uid",
+			OrgID:        1,
+			NamespaceUID: "folder-uid",
+			RuleGroup:    "group",
+			Title:        "High CPU",
+			Condition:    "B",
+			Data: []ngmodels.AlertQuery{{
+				RefID:             "A",
+				RelativeTimeRange: ngmodels.RelativeTimeRange{From: ngmodels.Duration(10 * time.Minute)},
+				DatasourceUID:     "prom",
+				Model:             json.RawMessage(`{"expr":"cpu > 0.9","refId":"A"}`),
+			}, {
+				RefID:         "B",
+				DatasourceUID: "__expr__",
+				Model:         json.RawMessage(`{"type":"threshold"}`),
+			}},
+			NoDataState:                 ngmodels.OK,
+			ExecErrState:                ngmodels.AlertingErrState,
+			For:                         5 * time.Minute,
+			KeepFiringFor:               90 * time.Second,
+			Annotations:                 map[string]string{"summary": "CPU is high"},
+			Labels:                      map[string]string{"team": "infra"},
+			IsPaused:                    true,
+			MissingSeriesEvalsToResolve: &evals,
+			NotificationSettings: []ngmodels.NotificationSettings{{
+				Receiver:  "slack",
+				GroupBy:   []string{"alertname"},
+				GroupWait: &groupWait,
+			}},
+			Version: 4,
+		}
+
+		obj, err := convertToK8sResource(&rule, namespacer)
+		require.NoError(t, err)
+		require.Equal(t, "rule-uid", obj.Name)
+		require.Equal(t, "default", obj.Namespace)
+		require.Equal(t, "4", obj.ResourceVersion)
+		require.Equal(t, "5m", obj.Spec.For)
+		require.Equal(t, "1m30s", obj.Spec.KeepFiringFor)
+		require.Equal(t, int64(600), obj.Spec.Data[0].RelativeTimeRange.From)
+		require.Equal(t, "30s", obj.Spec.NotificationSettings.GroupWait)
+		meta, err := utils.MetaAccessor(obj)
+		require.NoError(t, err)
+		require.Equal(t, "folder-uid", meta.GetFolder())
+
+		back, err := convertToDomainModel(1, obj)
+		require.NoError(t, err)
+		require.Equal(t, rule.UID, back.UID)
+		require.Equal(t, rule.NamespaceUID, back.NamespaceUID)
+		require.Equal(t, rule.For, back.For)
+		require.Equal(t, rule.KeepFiringFor, back.KeepFiringFor)
+		require.Equal(t, rule.NoDataState, back.NoDataState)
+		require.Equal(t, rule.ExecErrState, back.ExecErrState)
+		require.Equal(t, rule.NotificationSettings, back.NotificationSettings)
+		require.Equal(t, rule.MissingSeriesEvalsToResolve, back.MissingSeriesEvalsToResolve)
+		require.Len(t, back.Data, 2)
+		require.Equal(t, rule.Data[0].RelativeTimeRange, back.Data[0].RelativeTimeRange)
+		require.JSONEq(t, string(rule.Data[0].Model), string(back.Data[0].Model))
+	})
+
+	t.Run("recording rule round trip", func(t *testing.T) {
+		rule := ngmodels.AlertRule{
+			UID:          "recording-uid",
+			OrgID:        1,
+			NamespaceUID: "folder-uid",
+			RuleGroup:    "group",
+			Title:        "CPU",
+			Data: []ngmodels.AlertQuery{{
+				RefID:         "A",
+				DatasourceUID: "prom",
+				Model:         json.RawMessage(`{"expr":"cpu"}`),
+			}},
+			Record: &ngmodels.Record{Metric: "cpu:recorded", From: "A", TargetDatasourceUID: "prom"},
+		}
+
+		obj, err := convertToK8sResource(&rule, namespacer)
+		require.NoError(t, err)
+		back, err := convertToDomainModel(1, obj)
+		require.NoError(t, err)
+		require.Equal(t, rule.Record, back.Record)
+		require.Empty(t, back.NoDataState)
+		require.Empty(t, back.ExecErrState)
+	})
+
+	t.Run("defaults the states of alert rules", func(t *testing.T) {
+		obj, err := convertToK8sResource(&ngmodels.AlertRule{UID: "uid", NamespaceUID: "folder-uid"}, namespacer)
+		require.NoError(t, err)
+		back, err := convertToDomainModel(1, obj)
+		require.NoError(t, err)
+		require.Equal(t, ngmodels.NoData, back.NoDataState)
+		require.Equal(t, ngmodels.ErrorErrState, back.ExecErrState)
+	})
+
+	t.Run("requires a folder", func(t *testing.T) {
+		obj, err := convertToK8sResource(&ngmodels.AlertRule{UID: "uid"}, namespacer)
+		require.NoError(t, err)
+		_, err = convertToDomainModel(1, obj)
+		require.Error(t, err)
+	})
+
+	t.Run("rejects invalid durations", func(t *testing.T) {
+		obj, err := convertToK8sResource(&ngmodels.AlertRule{UID: "uid", NamespaceUID: "folder-uid"}, namespacer)
+		require.NoError(t, err)
+		obj.Spec.For = "five minutes"
+		_, err = convertToDomainModel(1, obj)
+		require.ErrorContains(t, err, "invalid for")
+	})
+}
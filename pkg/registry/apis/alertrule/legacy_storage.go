@@ -0,0 +1,213 @@
+package alertrule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	alertrule "github.com/grafana/grafana/pkg/apis/alertrule/v0alpha1"
+	grafanarest "github.com/grafana/grafana/pkg/apiserver/rest"
+	"github.com/grafana/grafana/pkg/services/apiserver/endpoints/request"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+var (
+	_ grafanarest.Storage = (*legacyStorage)(nil)
+)
+
+type AlertRuleService interface {
+	GetAlertRules(ctx context.Context, user identity.Requester) ([]*ngmodels.AlertRule, map[string]ngmodels.Provenance, error)
+	GetAlertRule(ctx context.Context, user identity.Requester, ruleUID string) (ngmodels.AlertRule, ngmodels.Provenance, error)
+	CreateAlertRule(ctx context.Context, user identity.Requester, rule ngmodels.AlertRule, provenance ngmodels.Provenance) (ngmodels.AlertRule, error)
+	UpdateAlertRule(ctx context.Context, user identity.Requester, rule ngmodels.AlertRule, provenance ngmodels.Provenance) (ngmodels.AlertRule, error)
+	DeleteAlertRule(ctx context.Context, user identity.Requester, ruleUID string, provenance ngmodels.Provenance) error
+}
+
+type legacyStorage struct {
+	service        AlertRuleService
+	namespacer     request.NamespaceMapper
+	tableConverter rest.TableConvertor
+}
+
+func (s *legacyStorage) New() runtime.Object {
+	return resourceInfo.NewFunc()
+}
+
+func (s *legacyStorage) Destroy() {}
+
+func (s *legacyStorage) NamespaceScoped() bool {
+	return true // namespace == org
+}
+
+func (s *legacyStorage) GetSingularName() string {
+	return resourceInfo.GetSingularName()
+}
+
+func (s *legacyStorage) NewList() runtime.Object {
+	return resourceInfo.NewListFunc()
+}
+
+func (s *legacyStorage) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	return s.tableConverter.ConvertToTable(ctx, object, tableOptions)
+}
+
+func (s *legacyStorage) List(ctx context.Context, _ *internalversion.ListOptions) (runtime.Object, error) {
+	user, err := identity.GetRequester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, _, err := s.service.GetAlertRules(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &alertrule.AlertRuleList{}
+	for _, rule := range rules {
+		item, err := convertToK8sResource(rule, s.namespacer)
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, *item)
+	}
+	return list, nil
+}
+
+func (s *legacyStorage) Get(ctx context.Context, uid string, _ *metav1.GetOptions) (runtime.Object, error) {
+	user, err := identity.GetRequester(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, _, err := s.service.GetAlertRule(ctx, user, uid)
+	if err != nil {
+		if errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
+			return nil, apierrors.NewNotFound(resourceInfo.GroupResource(), uid)
+		}
+		return nil, err
+	}
+	return convertToK8sResource(&rule, s.namespacer)
+}
+
+func (s *legacyStorage) Create(ctx context.Context,
+	obj runtime.Object,
+	createValidation rest.ValidateObjectFunc,
+	_ *metav1.CreateOptions,
+) (runtime.Object, error) {
+	info, err := request.NamespaceInfoFrom(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	user, err := identity.GetRequester(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+	p, ok := obj.(*alertrule.AlertRule)
+	if !ok {
+		return nil, fmt.Errorf("expected alert rule but got %s", obj.GetObjectKind().GroupVersionKind())
+	}
+	rule, err := convertToDomainModel(info.OrgID, p)
+	if err != nil {
+		return nil, err
+	}
+	created, err := s.service.CreateAlertRule(ctx, user, rule, ngmodels.ProvenanceNone)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	return convertToK8sResource(&created, s.namespacer)
+}
+
+func (s *legacyStorage) Update(ctx context.Context,
+	uid string,
+	objInfo rest.UpdatedObjectInfo,
+	createValidation rest.ValidateObjectFunc,
+	updateValidation rest.ValidateObjectUpdateFunc,
+	_ bool,
+	_ *metav1.UpdateOptions,
+) (runtime.Object, bool, error) {
+	info, err := request.NamespaceInfoFrom(ctx, true)
+	if err != nil {
+		return nil, false, err
+	}
+	user, err := identity.GetRequester(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	old, err := s.Get(ctx, uid, nil)
+	if err != nil {
+		return old, false, err
+	}
+	obj, err := objInfo.UpdatedObject(ctx, old)
+	if err != nil {
+		return old, false, err
+	}
+	if updateValidation != nil {
+		if err := updateValidation(ctx, obj, old); err != nil {
+			return nil, false, err
+		}
+	}
+	p, ok := obj.(*alertrule.AlertRule)
+	if !ok {
+		return nil, false, fmt.Errorf("expected alert rule but got %s", obj.GetObjectKind().GroupVersionKind())
+	}
+	if p.ResourceVersion != "" && p.ResourceVersion != old.(*alertrule.AlertRule).ResourceVersion {
+		return nil, false, apierrors.NewConflict(resourceInfo.GroupResource(), uid, errors.New("the alert rule has been modified"))
+	}
+	rule, err := convertToDomainModel(info.OrgID, p)
+	if err != nil {
+		return old, false, err
+	}
+	rule.UID = uid
+
+	updated, err := s.service.UpdateAlertRule(ctx, user, rule, ngmodels.ProvenanceNone)
+	if err != nil {
+		return nil, false, convertError(err)
+	}
+	// the service does not return the stored rule, read it back to get the new version
+	r, err := s.Get(ctx, updated.UID, nil)
+	return r, false, err
+}
+
+// GracefulDeleter
+func (s *legacyStorage) Delete(ctx context.Context, uid string, deleteValidation rest.ValidateObjectFunc, _ *metav1.DeleteOptions) (runtime.Object, bool, error) {
+	user, err := identity.GetRequester(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	old, err := s.Get(ctx, uid, nil)
+	if err != nil {
+		return old, false, err
+	}
+	if deleteValidation != nil {
+		if err = deleteValidation(ctx, old); err != nil {
+			return nil, false, err
+		}
+	}
+
+	err = s.service.DeleteAlertRule(ctx, user, uid, ngmodels.ProvenanceNone) // TODO add support for dry-run option
+	return old, false, err                                                   // false - will be deleted async
+}
+
+func (s *legacyStorage) DeleteCollection(context.Context, rest.ValidateObjectFunc, *metav1.DeleteOptions, *internalversion.ListOptions) (runtime.Object, error) {
+	return nil, apierrors.NewMethodNotSupported(resourceInfo.GroupResource(), "deleteCollection")
+}
+
+func convertError(err error) error {
+	if errors.Is(err, ngmodels.ErrAlertRuleFailedValidation) {
+		return apierrors.NewBadRequest(err.Error())
+	}
+	return err
+}
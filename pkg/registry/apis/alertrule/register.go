@@ -0,0 +1,142 @@
+package alertrule
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/registry/rest"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/kube-openapi/pkg/common"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	alertrule "github.com/grafana/grafana/pkg/apis/alertrule/v0alpha1"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/apiserver/builder"
+	"github.com/grafana/grafana/pkg/services/apiserver/endpoints/request"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/ngalert"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+var _ builder.APIGroupBuilder = (*AlertRuleAPIBuilder)(nil)
+
+var resourceInfo = alertrule.AlertRuleResourceInfo
+
+// AlertRuleAPIBuilder exposes the Grafana-managed alert rules, so they can be synced by provisioning
+type AlertRuleAPIBuilder struct {
+	service    AlertRuleService
+	ac         accesscontrol.AccessControl
+	namespacer request.NamespaceMapper
+}
+
+func RegisterAPIService(
+	cfg *setting.Cfg,
+	features featuremgmt.FeatureToggles,
+	apiregistration builder.APIRegistrar,
+	ng *ngalert.AlertNG,
+) *AlertRuleAPIBuilder {
+	if !features.IsEnabledGlobally(featuremgmt.FlagProvisioning) {
+		return nil // skip registration unless opting into provisioning
+	}
+	if ng.IsDisabled() {
+		return nil
+	}
+	builder := &AlertRuleAPIBuilder{
+		service:    ng.Api.AlertRules,
+		ac:         ng.Api.AccessControl,
+		namespacer: request.GetNamespaceMapper(cfg),
+	}
+	apiregistration.RegisterAPI(builder)
+	return builder
+}
+
+func (b *AlertRuleAPIBuilder) GetAuthorizer() authorizer.Authorizer {
+	return authorizer.AuthorizerFunc(
+		func(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+			if !attr.IsResourceRequest() {
+				return authorizer.DecisionNoOpinion, "", nil
+			}
+			user, err := identity.GetRequester(ctx)
+			if err != nil {
+				return authorizer.DecisionDeny, "valid user is required", err
+			}
+
+			// The rules are authorized against their folders by the service, this only checks the user can manage
+			// any rule at all.
+			var action accesscontrol.Evaluator
+			switch attr.GetVerb() {
+			case "create":
+				action = accesscontrol.EvalPermission(accesscontrol.ActionAlertingRuleCreate)
+			case "patch":
+				fallthrough
+			case "update":
+				action = accesscontrol.EvalPermission(accesscontrol.ActionAlertingRuleUpdate)
+			case "deletecollection":
+				fallthrough
+			case "delete":
+				action = accesscontrol.EvalPermission(accesscontrol.ActionAlertingRuleDelete)
+			}
+
+			eval := accesscontrol.EvalPermission(accesscontrol.ActionAlertingRuleRead)
+			if action != nil {
+				eval = accesscontrol.EvalAll(eval, action)
+			}
+
+			ok, err := b.ac.Evaluate(ctx, user, eval)
+			if ok {
+				return authorizer.DecisionAllow, "", nil
+			}
+			return authorizer.DecisionDeny, "", err
+		})
+}
+
+func (b *AlertRuleAPIBuilder) GetGroupVersion() schema.GroupVersion {
+	return resourceInfo.GroupVersion()
+}
+
+func addKnownTypes(scheme *runtime.Scheme, gv schema.GroupVersion) {
+	scheme.AddKnownTypes(gv,
+		&alertrule.AlertRule{},
+		&alertrule.AlertRuleList{},
+	)
+}
+
+func (b *AlertRuleAPIBuilder) InstallSchema(scheme *runtime.Scheme) error {
+	gv := resourceInfo.GroupVersion()
+	err := alertrule.AddToScheme(scheme)
+	if err != nil {
+		return err
+	}
+
+	// Link this version to the internal representation.
+	// This is used for server-side-apply (PATCH), and avoids the error:
+	//   "no kind is registered for the type"
+	addKnownTypes(scheme, schema.GroupVersion{
+		Group:   gv.Group,
+		Version: runtime.APIVersionInternal,
+	})
+	metav1.AddToGroupVersion(scheme, gv)
+	return scheme.SetVersionPriority(gv)
+}
+
+func (b *AlertRuleAPIBuilder) AllowedV0Alpha1Resources() []string {
+	return []string{builder.AllResourcesAllowed}
+}
+
+func (b *AlertRuleAPIBuilder) UpdateAPIGroupInfo(apiGroupInfo *genericapiserver.APIGroupInfo, _ builder.APIGroupOptions) error {
+	storage := map[string]rest.Storage{}
+	storage[resourceInfo.StoragePath()] = &legacyStorage{
+		service:        b.service,
+		namespacer:     b.namespacer,
+		tableConverter: resourceInfo.TableConverter(),
+	}
+	apiGroupInfo.VersionedResourcesStorageMap[alertrule.VERSION] = storage
+	return nil
+}
+
+func (b *AlertRuleAPIBuilder) GetOpenAPIDefinitions() common.GetOpenAPIDefinitions {
+	return alertrule.GetOpenAPIDefinitions
+}
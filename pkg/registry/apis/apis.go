@@ -1,6 +1,8 @@
 package apiregistry
 
 import (
+	"github.com/grafana/grafana/pkg/registry/apis/alertrule"
+	"github.com/grafana/grafana/pkg/registry/apis/correlations"
 	dashboardinternal "github.com/grafana/grafana/pkg/registry/apis/dashboard"
 	"github.com/grafana/grafana/pkg/registry/apis/dashboardsnapshot"
 	"github.com/grafana/grafana/pkg/registry/apis/datasource"
@@ -29,6 +31,8 @@ func ProvideRegistryServiceSink(
 	_ *userstorage.UserStorageAPIBuilder,
 	_ *provisioning.APIBuilder,
 	_ *ofrep.APIBuilder,
+	_ *alertrule.AlertRuleAPIBuilder,
+	_ *correlations.CorrelationsAPIBuilder,
 	_ *secret.DependencyRegisterer,
 ) *Service {
 	return &Service{}
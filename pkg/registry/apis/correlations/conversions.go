@@ -0,0 +1,101 @@
+package correlations
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	common "github.com/grafana/grafana/pkg/apimachinery/apis/common/v0alpha1"
+	correlation "github.com/grafana/grafana/pkg/apis/correlation/v0alpha1"
+	"github.com/grafana/grafana/pkg/services/apiserver/endpoints/request"
+	gapiutil "github.com/grafana/grafana/pkg/services/apiserver/utils"
+	"github.com/grafana/grafana/pkg/services/correlations"
+)
+
+func convertToK8sResource(c correlations.Correlation, namespacer request.NamespaceMapper) *correlation.Correlation {
+	spec := correlation.CorrelationSpec{
+		SourceUID:   c.SourceUID,
+		TargetUID:   c.TargetUID,
+		Label:       c.Label,
+		Description: c.Description,
+		Type:        string(c.Type),
+		Config: correlation.CorrelationConfig{
+			Field:  c.Config.Field,
+			Target: common.Unstructured{Object: c.Config.Target},
+		},
+	}
+	for _, t := range c.Config.Transformations {
+		spec.Config.Transformations = append(spec.Config.Transformations, correlation.Transformation{
+			Type:       t.Type,
+			Expression: t.Expression,
+			Field:      t.Field,
+			MapValue:   t.MapValue,
+		})
+	}
+
+	result := &correlation.Correlation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.UID,
+			Namespace: namespacer(c.OrgID),
+		},
+		Spec: spec,
+	}
+	result.UID = gapiutil.CalculateClusterWideUID(result)
+	return result
+}
+
+func convertToCreateCommand(orgID int64, obj *correlation.Correlation) correlations.CreateCorrelationCommand {
+	return correlations.CreateCorrelationCommand{
+		UID:         obj.Name,
+		SourceUID:   obj.Spec.SourceUID,
+		OrgId:       orgID,
+		TargetUID:   obj.Spec.TargetUID,
+		Label:       obj.Spec.Label,
+		Description: obj.Spec.Description,
+		Config: correlations.CorrelationConfig{
+			Field:           obj.Spec.Config.Field,
+			Target:          targetOrEmpty(obj.Spec.Config.Target),
+			Transformations: convertTransformations(obj.Spec.Config.Transformations),
+		},
+		Type: correlations.CorrelationType(obj.Spec.Type),
+	}
+}
+
+func convertToUpdateCommand(orgID int64, obj *correlation.Correlation) correlations.UpdateCorrelationCommand {
+	spec := obj.Spec
+	target := targetOrEmpty(spec.Config.Target)
+	correlationType := correlations.CorrelationType(spec.Type)
+	return correlations.UpdateCorrelationCommand{
+		UID:         obj.Name,
+		SourceUID:   spec.SourceUID,
+		OrgId:       orgID,
+		TargetUID:   spec.TargetUID,
+		Label:       &spec.Label,
+		Description: &spec.Description,
+		Config: &correlations.CorrelationConfigUpdateDTO{
+			Field:  &spec.Config.Field,
+			Target: &target,
+			// never nil, so removed transformations are cleared
+			Transformations: append([]correlations.Transformation{}, convertTransformations(spec.Config.Transformations)...),
+		},
+		Type: &correlationType,
+	}
+}
+
+func convertTransformations(transformations []correlation.Transformation) correlations.Transformations {
+	var result correlations.Transformations
+	for _, t := range transformations {
+		result = append(result, correlations.Transformation{
+			Type:       t.Type,
+			Expression: t.Expression,
+			Field:      t.Field,
+			MapValue:   t.MapValue,
+		})
+	}
+	return result
+}
+
+func targetOrEmpty(target common.Unstructured) map[string]any {
+	if target.Object == nil {
+		return map[string]any{}
+	}
+	return target.Object
+}
@@ -0,0 +1,284 @@
+package correlations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	correlation "github.com/grafana/grafana/pkg/apis/correlation/v0alpha1"
+	grafanarest "github.com/grafana/grafana/pkg/apiserver/rest"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/apiserver/endpoints/request"
+	"github.com/grafana/grafana/pkg/services/correlations"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+var (
+	_ grafanarest.Storage = (*legacyStorage)(nil)
+)
+
+// the number of correlations read at once when listing
+const listPageSize = 1000
+
+type CorrelationsService interface {
+	GetCorrelations(ctx context.Context, cmd correlations.GetCorrelationsQuery) (correlations.GetCorrelationsResponseBody, error)
+	CreateCorrelation(ctx context.Context, cmd correlations.CreateCorrelationCommand) (correlations.Correlation, error)
+	UpdateCorrelation(ctx context.Context, cmd correlations.UpdateCorrelationCommand) (correlations.Correlation, error)
+	DeleteCorrelation(ctx context.Context, cmd correlations.DeleteCorrelationCommand) error
+}
+
+type legacyStorage struct {
+	service        CorrelationsService
+	ac             accesscontrol.AccessControl
+	namespacer     request.NamespaceMapper
+	tableConverter rest.TableConvertor
+}
+
+func (s *legacyStorage) New() runtime.Object {
+	return resourceInfo.NewFunc()
+}
+
+func (s *legacyStorage) Destroy() {}
+
+func (s *legacyStorage) NamespaceScoped() bool {
+	return true // namespace == org
+}
+
+func (s *legacyStorage) GetSingularName() string {
+	return resourceInfo.GetSingularName()
+}
+
+func (s *legacyStorage) NewList() runtime.Object {
+	return resourceInfo.NewListFunc()
+}
+
+func (s *legacyStorage) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	return s.tableConverter.ConvertToTable(ctx, object, tableOptions)
+}
+
+func (s *legacyStorage) List(ctx context.Context, _ *internalversion.ListOptions) (runtime.Object, error) {
+	orgId, err := request.OrgIDForList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := s.listAll(ctx, orgId)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &correlation.CorrelationList{}
+	for _, c := range all {
+		list.Items = append(list.Items, *convertToK8sResource(c, s.namespacer))
+	}
+	return list, nil
+}
+
+func (s *legacyStorage) Get(ctx context.Context, uid string, _ *metav1.GetOptions) (runtime.Object, error) {
+	info, err := request.NamespaceInfoFrom(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := s.get(ctx, info.OrgID, uid)
+	if err != nil {
+		return nil, err
+	}
+	return convertToK8sResource(c, s.namespacer), nil
+}
+
+func (s *legacyStorage) Create(ctx context.Context,
+	obj runtime.Object,
+	createValidation rest.ValidateObjectFunc,
+	_ *metav1.CreateOptions,
+) (runtime.Object, error) {
+	info, err := request.NamespaceInfoFrom(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+	p, ok := obj.(*correlation.Correlation)
+	if !ok {
+		return nil, fmt.Errorf("expected correlation but got %s", obj.GetObjectKind().GroupVersionKind())
+	}
+	if err := s.authorizeWrite(ctx, p.Spec.SourceUID); err != nil {
+		return nil, err
+	}
+	if p.Name != "" {
+		if err := util.ValidateUID(p.Name); err != nil {
+			return nil, apierrors.NewBadRequest(err.Error())
+		}
+	}
+	cmd := convertToCreateCommand(info.OrgID, p)
+	if err := cmd.Validate(); err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	created, err := s.service.CreateCorrelation(ctx, cmd)
+	if err != nil {
+		return nil, convertError(err, p.Name)
+	}
+	return convertToK8sResource(created, s.namespacer), nil
+}
+
+func (s *legacyStorage) Update(ctx context.Context,
+	uid string,
+	objInfo rest.UpdatedObjectInfo,
+	createValidation rest.ValidateObjectFunc,
+	updateValidation rest.ValidateObjectUpdateFunc,
+	_ bool,
+	_ *metav1.UpdateOptions,
+) (runtime.Object, bool, error) {
+	info, err := request.NamespaceInfoFrom(ctx, true)
+	if err != nil {
+		return nil, false, err
+	}
+
+	old, err := s.Get(ctx, uid, nil)
+	if err != nil {
+		return old, false, err
+	}
+	obj, err := objInfo.UpdatedObject(ctx, old)
+	if err != nil {
+		return old, false, err
+	}
+	if updateValidation != nil {
+		if err := updateValidation(ctx, obj, old); err != nil {
+			return nil, false, err
+		}
+	}
+	p, ok := obj.(*correlation.Correlation)
+	if !ok {
+		return nil, false, fmt.Errorf("expected correlation but got %s", obj.GetObjectKind().GroupVersionKind())
+	}
+	if p.Spec.SourceUID != old.(*correlation.Correlation).Spec.SourceUID {
+		return nil, false, apierrors.NewBadRequest("source data source of a correlation cannot be changed. Consider creating a new resource.")
+	}
+	if err := s.authorizeWrite(ctx, p.Spec.SourceUID); err != nil {
+		return nil, false, err
+	}
+	p.Name = uid
+	cmd := convertToUpdateCommand(info.OrgID, p)
+	if err := cmd.Validate(); err != nil {
+		return nil, false, apierrors.NewBadRequest(err.Error())
+	}
+	if err := correlations.CorrelationType(p.Spec.Type).Validate(); err != nil {
+		return nil, false, apierrors.NewBadRequest(err.Error())
+	}
+
+	updated, err := s.service.UpdateCorrelation(ctx, cmd)
+	if err != nil {
+		return nil, false, convertError(err, uid)
+	}
+	return convertToK8sResource(updated, s.namespacer), false, nil
+}
+
+// GracefulDeleter
+func (s *legacyStorage) Delete(ctx context.Context, uid string, deleteValidation rest.ValidateObjectFunc, _ *metav1.DeleteOptions) (runtime.Object, bool, error) {
+	info, err := request.NamespaceInfoFrom(ctx, true)
+	if err != nil {
+		return nil, false, err
+	}
+	old, err := s.Get(ctx, uid, nil)
+	if err != nil {
+		return old, false, err
+	}
+	if deleteValidation != nil {
+		if err = deleteValidation(ctx, old); err != nil {
+			return nil, false, err
+		}
+	}
+	sourceUID := old.(*correlation.Correlation).Spec.SourceUID
+	if err := s.authorizeWrite(ctx, sourceUID); err != nil {
+		return nil, false, err
+	}
+
+	err = s.service.DeleteCorrelation(ctx, correlations.DeleteCorrelationCommand{
+		UID:       uid,
+		SourceUID: sourceUID,
+		OrgId:     info.OrgID,
+	})
+	if err != nil {
+		return nil, false, convertError(err, uid)
+	}
+	return old, false, nil // false - will be deleted async
+}
+
+func (s *legacyStorage) DeleteCollection(context.Context, rest.ValidateObjectFunc, *metav1.DeleteOptions, *internalversion.ListOptions) (runtime.Object, error) {
+	return nil, apierrors.NewMethodNotSupported(resourceInfo.GroupResource(), "deleteCollection")
+}
+
+// get finds the correlation by UID, which is unique in the organization
+func (s *legacyStorage) get(ctx context.Context, orgID int64, uid string) (correlations.Correlation, error) {
+	all, err := s.listAll(ctx, orgID)
+	if err != nil {
+		return correlations.Correlation{}, err
+	}
+	for _, c := range all {
+		if c.UID == uid {
+			return c, nil
+		}
+	}
+	return correlations.Correlation{}, apierrors.NewNotFound(resourceInfo.GroupResource(), uid)
+}
+
+func (s *legacyStorage) listAll(ctx context.Context, orgID int64) ([]correlations.Correlation, error) {
+	var result []correlations.Correlation
+	for page := int64(1); ; page++ {
+		res, err := s.service.GetCorrelations(ctx, correlations.GetCorrelationsQuery{
+			OrgId: orgID,
+			Limit: listPageSize,
+			Page:  page,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, res.Correlations...)
+		if len(res.Correlations) < listPageSize {
+			return result, nil
+		}
+	}
+}
+
+// authorizeWrite checks the user can write the source data source, like the correlations HTTP API
+func (s *legacyStorage) authorizeWrite(ctx context.Context, sourceUID string) error {
+	user, err := identity.GetRequester(ctx)
+	if err != nil {
+		return err
+	}
+	ok, err := s.ac.Evaluate(ctx, user, accesscontrol.EvalPermission(datasources.ActionWrite, datasources.ScopeProvider.GetResourceScopeUID(sourceUID)))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return apierrors.NewForbidden(resourceInfo.GroupResource(), sourceUID, errors.New("user cannot write the source data source"))
+	}
+	return nil
+}
+
+func convertError(err error, name string) error {
+	switch {
+	case errors.Is(err, correlations.ErrCorrelationNotFound):
+		return apierrors.NewNotFound(resourceInfo.GroupResource(), name)
+	case errors.Is(err, correlations.ErrSourceDataSourceDoesNotExists),
+		errors.Is(err, correlations.ErrTargetDataSourceDoesNotExists),
+		errors.Is(err, correlations.ErrInvalidConfigType),
+		errors.Is(err, correlations.ErrUpdateCorrelationEmptyParams):
+		return apierrors.NewBadRequest(err.Error())
+	case errors.Is(err, correlations.ErrCorrelationReadOnly),
+		errors.Is(err, correlations.ErrCorrelationsQuotaReached):
+		return apierrors.NewForbidden(resourceInfo.GroupResource(), name, err)
+	}
+	return err
+}
@@ -0,0 +1,138 @@
+package correlations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	k8srequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	correlation "github.com/grafana/grafana/pkg/apis/correlation/v0alpha1"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/apiserver/endpoints/request"
+	"github.com/grafana/grafana/pkg/services/correlations"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+type fakeCorrelationsService struct {
+	correlations []correlations.Correlation
+	created      []correlations.CreateCorrelationCommand
+	updated      []correlations.UpdateCorrelationCommand
+	deleted      []correlations.DeleteCorrelationCommand
+}
+
+func (f *fakeCorrelationsService) GetCorrelations(_ context.Context, cmd correlations.GetCorrelationsQuery) (correlations.GetCorrelationsResponseBody, error) {
+	res := correlations.GetCorrelationsResponseBody{Correlations: []correlations.Correlation{}}
+	start := int((cmd.Page - 1) * cmd.Limit)
+	for i := start; i < len(f.correlations) && i < start+int(cmd.Limit); i++ {
+		res.Correlations = append(res.Correlations, f.correlations[i])
+	}
+	return res, nil
+}
+
+func (f *fakeCorrelationsService) CreateCorrelation(_ context.Context, cmd correlations.CreateCorrelationCommand) (correlations.Correlation, error) {
+	f.created = append(f.created, cmd)
+	return correlations.Correlation{UID: cmd.UID, OrgID: cmd.OrgId, SourceUID: cmd.SourceUID, Type: cmd.Type, Config: cmd.Config}, nil
+}
+
+func (f *fakeCorrelationsService) UpdateCorrelation(_ context.Context, cmd correlations.UpdateCorrelationCommand) (correlations.Correlation, error) {
+	f.updated = append(f.updated, cmd)
+	return correlations.Correlation{UID: cmd.UID, OrgID: cmd.OrgId, SourceUID: cmd.SourceUID, Type: *cmd.Type}, nil
+}
+
+func (f *fakeCorrelationsService) DeleteCorrelation(_ context.Context, cmd correlations.DeleteCorrelationCommand) error {
+	f.deleted = append(f.deleted, cmd)
+	return nil
+}
+
+func TestLegacyStorage(t *testing.T) {
+	newStorage := func(svc *fakeCorrelationsService) *legacyStorage {
+		return &legacyStorage{
+			service:        svc,
+			ac:             acimpl.ProvideAccessControlTest(),
+			namespacer:     request.GetNamespaceMapper(nil),
+			tableConverter: resourceInfo.TableConverter(),
+		}
+	}
+	newContext := func(permissions map[string][]string) context.Context {
+		usr := &user.SignedInUser{UserID: 1, OrgID: 1, Permissions: map[int64]map[string][]string{1: permissions}}
+		return k8srequest.WithNamespace(identity.WithRequester(context.Background(), usr), "default")
+	}
+	canWrite := newContext(map[string][]string{
+		datasources.ActionRead:  {"datasources:*"},
+		datasources.ActionWrite: {"datasources:uid:loki"},
+	})
+	newCorrelation := func(name, source string) *correlation.Correlation {
+		c := &correlation.Correlation{Spec: correlation.CorrelationSpec{
+			SourceUID: source,
+			Label:     "Logs to traces",
+			Type:      "external",
+			Config:    correlation.CorrelationConfig{Field: "traceID"},
+		}}
+		c.Name = name
+		return c
+	}
+
+	t.Run("get finds the correlation on any page", func(t *testing.T) {
+		svc := &fakeCorrelationsService{}
+		for i := 0; i < listPageSize+1; i++ {
+			svc.correlations = append(svc.correlations, correlations.Correlation{UID: "other", OrgID: 1, SourceUID: "loki"})
+		}
+		svc.correlations[listPageSize].UID = "last"
+		s := newStorage(svc)
+
+		obj, err := s.Get(canWrite, "last", nil)
+		require.NoError(t, err)
+		require.Equal(t, "last", obj.(*correlation.Correlation).Name)
+
+		_, err = s.Get(canWrite, "missing", nil)
+		require.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("create uses the name as the UID", func(t *testing.T) {
+		svc := &fakeCorrelationsService{}
+		s := newStorage(svc)
+
+		_, err := s.Create(canWrite, newCorrelation("logs-to-traces", "loki"), nil, nil)
+		require.NoError(t, err)
+		require.Len(t, svc.created, 1)
+		require.Equal(t, "logs-to-traces", svc.created[0].UID)
+		require.Equal(t, int64(1), svc.created[0].OrgId)
+	})
+
+	t.Run("create requires write access to the source data source", func(t *testing.T) {
+		svc := &fakeCorrelationsService{}
+		s := newStorage(svc)
+
+		_, err := s.Create(canWrite, newCorrelation("logs-to-traces", "tempo"), nil, nil)
+		require.True(t, apierrors.IsForbidden(err))
+		require.Empty(t, svc.created)
+	})
+
+	t.Run("update cannot change the source data source", func(t *testing.T) {
+		svc := &fakeCorrelationsService{correlations: []correlations.Correlation{{UID: "uid", OrgID: 1, SourceUID: "loki", Type: "external"}}}
+		s := newStorage(svc)
+
+		_, _, err := s.Update(canWrite, "uid", rest.DefaultUpdatedObjectInfo(newCorrelation("uid", "tempo")), nil, nil, false, nil)
+		require.True(t, apierrors.IsBadRequest(err))
+
+		_, _, err = s.Update(canWrite, "uid", rest.DefaultUpdatedObjectInfo(newCorrelation("uid", "loki")), nil, nil, false, nil)
+		require.NoError(t, err)
+		require.Len(t, svc.updated, 1)
+		require.Equal(t, "Logs to traces", *svc.updated[0].Label)
+		require.NotNil(t, svc.updated[0].Config.Transformations)
+	})
+
+	t.Run("delete requires write access to the source data source", func(t *testing.T) {
+		svc := &fakeCorrelationsService{correlations: []correlations.Correlation{{UID: "uid", OrgID: 1, SourceUID: "tempo"}}}
+		s := newStorage(svc)
+
+		_, _, err := s.Delete(canWrite, "uid", nil, nil)
+		require.True(t, apierrors.IsForbidden(err))
+		require.Empty(t, svc.deleted)
+	})
+}
@@ -0,0 +1,130 @@
+package correlations
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/registry/rest"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/kube-openapi/pkg/common"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	correlation "github.com/grafana/grafana/pkg/apis/correlation/v0alpha1"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/apiserver/builder"
+	"github.com/grafana/grafana/pkg/services/apiserver/endpoints/request"
+	"github.com/grafana/grafana/pkg/services/correlations"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+var _ builder.APIGroupBuilder = (*CorrelationsAPIBuilder)(nil)
+
+var resourceInfo = correlation.CorrelationResourceInfo
+
+// CorrelationsAPIBuilder exposes the correlations, so they can be synced by provisioning
+type CorrelationsAPIBuilder struct {
+	service    CorrelationsService
+	ac         accesscontrol.AccessControl
+	namespacer request.NamespaceMapper
+}
+
+func RegisterAPIService(
+	cfg *setting.Cfg,
+	features featuremgmt.FeatureToggles,
+	apiregistration builder.APIRegistrar,
+	service *correlations.CorrelationsService,
+	ac accesscontrol.AccessControl,
+) *CorrelationsAPIBuilder {
+	if !features.IsEnabledGlobally(featuremgmt.FlagProvisioning) {
+		return nil // skip registration unless opting into provisioning
+	}
+	builder := &CorrelationsAPIBuilder{
+		service:    service,
+		ac:         ac,
+		namespacer: request.GetNamespaceMapper(cfg),
+	}
+	apiregistration.RegisterAPI(builder)
+	return builder
+}
+
+func (b *CorrelationsAPIBuilder) GetAuthorizer() authorizer.Authorizer {
+	return authorizer.AuthorizerFunc(
+		func(ctx context.Context, attr authorizer.Attributes) (authorizer.Decision, string, error) {
+			if !attr.IsResourceRequest() {
+				return authorizer.DecisionNoOpinion, "", nil
+			}
+			user, err := identity.GetRequester(ctx)
+			if err != nil {
+				return authorizer.DecisionDeny, "valid user is required", err
+			}
+
+			// Writes are authorized against the source data source by the storage, this only checks the user can
+			// write any data source at all.
+			eval := accesscontrol.EvalPermission(datasources.ActionRead)
+			switch attr.GetVerb() {
+			case "get", "list", "watch":
+			default:
+				eval = accesscontrol.EvalAll(eval, accesscontrol.EvalPermission(datasources.ActionWrite))
+			}
+
+			ok, err := b.ac.Evaluate(ctx, user, eval)
+			if ok {
+				return authorizer.DecisionAllow, "", nil
+			}
+			return authorizer.DecisionDeny, "", err
+		})
+}
+
+func (b *CorrelationsAPIBuilder) GetGroupVersion() schema.GroupVersion {
+	return resourceInfo.GroupVersion()
+}
+
+func addKnownTypes(scheme *runtime.Scheme, gv schema.GroupVersion) {
+	scheme.AddKnownTypes(gv,
+		&correlation.Correlation{},
+		&correlation.CorrelationList{},
+	)
+}
+
+func (b *CorrelationsAPIBuilder) InstallSchema(scheme *runtime.Scheme) error {
+	gv := resourceInfo.GroupVersion()
+	err := correlation.AddToScheme(scheme)
+	if err != nil {
+		return err
+	}
+
+	// Link this version to the internal representation.
+	// This is used for server-side-apply (PATCH), and avoids the error:
+	//   "no kind is registered for the type"
+	addKnownTypes(scheme, schema.GroupVersion{
+		Group:   gv.Group,
+		Version: runtime.APIVersionInternal,
+	})
+	metav1.AddToGroupVersion(scheme, gv)
+	return scheme.SetVersionPriority(gv)
+}
+
+func (b *CorrelationsAPIBuilder) AllowedV0Alpha1Resources() []string {
+	return []string{builder.AllResourcesAllowed}
+}
+
+func (b *CorrelationsAPIBuilder) UpdateAPIGroupInfo(apiGroupInfo *genericapiserver.APIGroupInfo, _ builder.APIGroupOptions) error {
+	storage := map[string]rest.Storage{}
+	storage[resourceInfo.StoragePath()] = &legacyStorage{
+		service:        b.service,
+		ac:             b.ac,
+		namespacer:     b.namespacer,
+		tableConverter: resourceInfo.TableConverter(),
+	}
+	apiGroupInfo.VersionedResourcesStorageMap[correlation.VERSION] = storage
+	return nil
+}
+
+func (b *CorrelationsAPIBuilder) GetOpenAPIDefinitions() common.GetOpenAPIDefinitions {
+	return correlation.GetOpenAPIDefinitions
+}
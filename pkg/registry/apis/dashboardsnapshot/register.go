@@ -44,11 +44,12 @@ var resourceInfo = dashboardsnapshot.DashboardSnapshotResourceInfo
 
 // This is used just so wire has something unique to return
 type SnapshotsAPIBuilder struct {
-	service    dashboardsnapshots.Service
-	namespacer request.NamespaceMapper
-	options    sharingOptionsGetter
-	exporter   *dashExporter
-	logger     log.Logger
+	service        dashboardsnapshots.Service
+	namespacer     request.NamespaceMapper
+	options        sharingOptionsGetter
+	externalAPIKey string
+	exporter       *dashExporter
+	logger         log.Logger
 }
 
 func NewSnapshotsAPIBuilder(
@@ -57,11 +58,12 @@ func NewSnapshotsAPIBuilder(
 	exporter *dashExporter,
 ) *SnapshotsAPIBuilder {
 	return &SnapshotsAPIBuilder{
-		service:    p,
-		options:    newSharingOptionsGetter(cfg),
-		namespacer: request.GetNamespaceMapper(cfg),
-		exporter:   exporter,
-		logger:     log.New("snapshots::RawHandlers"),
+		service:        p,
+		options:        newSharingOptionsGetter(cfg),
+		namespacer:     request.GetNamespaceMapper(cfg),
+		externalAPIKey: cfg.ExternalSnapshotAPIKey,
+		exporter:       exporter,
+		logger:         log.New("snapshots::RawHandlers"),
 	}
 }
 
@@ -128,9 +130,10 @@ func (b *SnapshotsAPIBuilder) UpdateAPIGroupInfo(apiGroupInfo *genericapiserver.
 	storage := map[string]rest.Storage{}
 
 	legacyStore := &legacyStorage{
-		service:    b.service,
-		namespacer: b.namespacer,
-		options:    b.options,
+		service:        b.service,
+		namespacer:     b.namespacer,
+		options:        b.options,
+		externalAPIKey: b.externalAPIKey,
 	}
 	legacyStore.tableConverter = resourceInfo.TableConverter()
 	storage[resourceInfo.StoragePath()] = legacyStore
@@ -265,6 +268,7 @@ func (b *SnapshotsAPIBuilder) GetAPIRoutes(gv schema.GroupVersion) *builder.APIR
 					}
 
 					// Use the existing snapshot service
+					cmd.ExternalAPIKey = b.externalAPIKey
 					dashboardsnapshots.CreateDashboardSnapshot(wrap, opts.Spec, cmd, b.service)
 				},
 			},
@@ -295,7 +299,7 @@ func (b *SnapshotsAPIBuilder) GetAPIRoutes(gv schema.GroupVersion) *builder.APIR
 					vars := mux.Vars(r)
 					key := vars["deleteKey"]
 
-					err := dashboardsnapshots.DeleteWithKey(ctx, key, b.service)
+					err := dashboardsnapshots.DeleteWithKey(ctx, key, b.externalAPIKey, b.service)
 					if err != nil {
 						errhttp.Write(ctx, fmt.Errorf("failed to delete external dashboard (%w)", err), w)
 						return
@@ -29,6 +29,7 @@ type legacyStorage struct {
 	namespacer     request.NamespaceMapper
 	tableConverter rest.TableConvertor
 	options        sharingOptionsGetter
+	externalAPIKey string
 }
 
 func (s *legacyStorage) New() runtime.Object {
@@ -131,7 +132,7 @@ func (s *legacyStorage) Delete(ctx context.Context, name string, deleteValidatio
 
 	// Delete the external one first
 	if snap.ExternalDeleteURL != "" {
-		err := dashboardsnapshots.DeleteExternalDashboardSnapshot(snap.ExternalDeleteURL)
+		err := dashboardsnapshots.DeleteExternalDashboardSnapshot(snap.ExternalDeleteURL, s.externalAPIKey)
 		if err != nil {
 			return nil, false, err
 		}
@@ -24,7 +24,7 @@ type conversionShim = func(ctx context.Context, item *unstructured.Unstructured)
 func ExportResources(ctx context.Context, options provisioning.ExportJobOptions, clients resources.ResourceClients, repositoryResources resources.RepositoryResources, progress jobs.JobProgressRecorder) error {
 	progress.SetMessage(ctx, "start resource export")
 	for _, kind := range resources.SupportedProvisioningResources {
-		// skip from folders as we do them first... so only dashboards
+		// skip from folders as we do them first
 		if kind == resources.FolderResource {
 			continue
 		}
@@ -32,6 +32,11 @@ func ExportResources(ctx context.Context, options provisioning.ExportJobOptions,
 		progress.SetMessage(ctx, fmt.Sprintf("export %s", kind.Resource))
 		client, _, err := clients.ForResource(kind)
 		if err != nil {
+			// The API for this kind is not enabled, so there is nothing to export
+			if resources.IsOptionalResource(kind) {
+				progress.SetMessage(ctx, fmt.Sprintf("skip %s: %s", kind.Resource, err))
+				continue
+			}
 			return fmt.Errorf("get client for %s: %w", kind.Resource, err)
 		}
 
@@ -419,6 +419,7 @@ func TestExportResources_Dashboards(t *testing.T) {
 			resourceClients := resources.NewMockResourceClients(t)
 			mockProgress := jobs.NewMockJobProgressRecorder(t)
 			tt.setupProgress(mockProgress)
+			skipOptionalResources(resourceClients, mockProgress)
 
 			repoResources := resources.NewMockRepositoryResources(t)
 			tt.setupResources(repoResources, resourceClients, mockClient, schema.GroupVersionKind{
@@ -445,3 +446,65 @@ func TestExportResources_Dashboards(t *testing.T) {
 		})
 	}
 }
+
+func TestExportResources_OptionalResources(t *testing.T) {
+	playlistClient := &mockDynamicInterface{
+		items: []unstructured.Unstructured{
+			{
+				Object: map[string]interface{}{
+					"apiVersion": resources.PlaylistResource.GroupVersion().String(),
+					"kind":       "Playlist",
+					"metadata": map[string]interface{}{
+						"name": "playlist-1",
+					},
+				},
+			},
+		},
+	}
+
+	resourceClients := resources.NewMockResourceClients(t)
+	resourceClients.On("ForResource", resources.DashboardResource).Return(&mockDynamicInterface{}, schema.GroupVersionKind{}, nil)
+	resourceClients.On("ForResource", resources.LibraryPanelResource).Return(nil, schema.GroupVersionKind{}, fmt.Errorf("resource not found"))
+	resourceClients.On("ForResource", resources.PlaylistResource).Return(playlistClient, schema.GroupVersionKind{}, nil)
+	resourceClients.On("ForResource", resources.ContactPointResource).Return(nil, schema.GroupVersionKind{}, fmt.Errorf("resource not found"))
+	resourceClients.On("ForResource", resources.AlertRuleResource).Return(nil, schema.GroupVersionKind{}, fmt.Errorf("resource not found"))
+	resourceClients.On("ForResource", resources.CorrelationResource).Return(nil, schema.GroupVersionKind{}, fmt.Errorf("resource not found"))
+
+	progress := jobs.NewMockJobProgressRecorder(t)
+	progress.On("SetMessage", mock.Anything, "start resource export").Return()
+	progress.On("SetMessage", mock.Anything, "export dashboards").Return()
+	progress.On("SetMessage", mock.Anything, "export librarypanels").Return()
+	progress.On("SetMessage", mock.Anything, "skip librarypanels: resource not found").Return()
+	progress.On("SetMessage", mock.Anything, "export playlists").Return()
+	progress.On("SetMessage", mock.Anything, "export receivers").Return()
+	progress.On("SetMessage", mock.Anything, "skip receivers: resource not found").Return()
+	progress.On("SetMessage", mock.Anything, "export alertrules").Return()
+	progress.On("SetMessage", mock.Anything, "skip alertrules: resource not found").Return()
+	progress.On("SetMessage", mock.Anything, "export correlations").Return()
+	progress.On("SetMessage", mock.Anything, "skip correlations: resource not found").Return()
+	progress.On("Record", mock.Anything, mock.MatchedBy(func(result jobs.JobResourceResult) bool {
+		return result.Name == "playlist-1" && result.Resource == "Playlist" && result.Path == "playlist-1.playlist.json" && result.Action == repository.FileActionCreated
+	})).Return()
+	progress.On("TooManyErrors").Return(nil)
+
+	repoResources := resources.NewMockRepositoryResources(t)
+	repoResources.On("WriteResourceFileFromObject", mock.Anything, mock.MatchedBy(func(obj *unstructured.Unstructured) bool {
+		return obj.GetName() == "playlist-1"
+	}), resources.WriteOptions{Path: "grafana"}).Return("playlist-1.playlist.json", nil)
+
+	err := ExportResources(context.Background(), provisioningV0.ExportJobOptions{Path: "grafana"}, resourceClients, repoResources, progress)
+	require.NoError(t, err)
+
+	progress.AssertExpectations(t)
+	repoResources.AssertExpectations(t)
+	resourceClients.AssertExpectations(t)
+}
+
+// skipOptionalResources sets up the clients as if the APIs of the optional resources were not enabled
+func skipOptionalResources(resourceClients *resources.MockResourceClients, progress *jobs.MockJobProgressRecorder) {
+	for _, kind := range resources.OptionalProvisioningResources {
+		resourceClients.On("ForResource", kind).Return(nil, schema.GroupVersionKind{}, fmt.Errorf("resource not found")).Maybe()
+		progress.On("SetMessage", mock.Anything, "export "+kind.Resource).Return().Maybe()
+		progress.On("SetMessage", mock.Anything, "skip "+kind.Resource+": resource not found").Return().Maybe()
+	}
+}
@@ -34,6 +34,9 @@ func (c *namespaceCleaner) Clean(ctx context.Context, namespace string, progress
 		progress.SetMessage(ctx, fmt.Sprintf("remove unprovisioned %s", kind.Resource))
 		client, _, err := clients.ForResource(kind)
 		if err != nil {
+			if resources.IsOptionalResource(kind) {
+				continue // the API for this kind is not enabled
+			}
 			return fmt.Errorf("get resource client: %w", err)
 		}
 
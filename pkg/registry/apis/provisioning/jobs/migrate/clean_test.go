@@ -174,8 +174,9 @@ func TestNamespaceCleaner_Clean(t *testing.T) {
 
 		cleaner := NewNamespaceCleaner(mockClientFactory)
 		progress := jobs.NewMockJobProgressRecorder(t)
-		progress.On("SetMessage", mock.Anything, "remove unprovisioned folders").Return()
-		progress.On("SetMessage", mock.Anything, "remove unprovisioned dashboards").Return()
+		for _, kind := range resources.SupportedProvisioningResources {
+			progress.On("SetMessage", mock.Anything, "remove unprovisioned "+kind.Resource).Return()
+		}
 
 		// Expect two successful deletions
 		progress.On("Record", mock.Anything, mock.MatchedBy(func(result jobs.JobResourceResult) bool {
@@ -87,7 +87,7 @@ func (m *legacyResourcesMigrator) Migrate(ctx context.Context, rw repository.Rea
 	}
 
 	progress.SetMessage(ctx, "migrate resources from SQL")
-	for _, kind := range resources.SupportedProvisioningResources {
+	for _, kind := range resources.LegacyMigrationResources {
 		if kind == resources.FolderResource {
 			continue // folders have special handling
 		}
@@ -44,7 +44,7 @@ func NewStorageSwapper(bulk BulkStoreClient, dual dualwrite.Service) StorageSwap
 func (s *storageSwapper) StopReadingUnifiedStorage(ctx context.Context) error {
 	// FIXME: dual writer is not namespaced which means that we would consider all namespaces migrated
 	// after one migrates
-	for _, gr := range resources.SupportedProvisioningResources {
+	for _, gr := range resources.LegacyMigrationResources {
 		status, _ := s.dual.Status(ctx, gr.GroupResource())
 		status.ReadUnified = false
 		status.Migrated = 0
@@ -59,7 +59,7 @@ func (s *storageSwapper) StopReadingUnifiedStorage(ctx context.Context) error {
 }
 
 func (s *storageSwapper) WipeUnifiedAndSetMigratedFlag(ctx context.Context, namespace string) error {
-	for _, gr := range resources.SupportedProvisioningResources {
+	for _, gr := range resources.LegacyMigrationResources {
 		status, _ := s.dual.Status(ctx, gr.GroupResource())
 		if status.ReadUnified {
 			return fmt.Errorf("unexpected state - already using unified storage for: %s", gr)
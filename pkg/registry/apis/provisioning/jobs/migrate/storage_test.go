@@ -26,7 +26,7 @@ func TestStorageSwapper_StopReadingUnifiedStorage(t *testing.T) {
 		{
 			name: "should update status for all resources",
 			setupMocks: func(bulk *MockBulkStoreClient, dual *dualwrite.MockService) {
-				for _, gr := range resources.SupportedProvisioningResources {
+				for _, gr := range resources.LegacyMigrationResources {
 					status := dualwrite.StorageStatus{
 						ReadUnified: true,
 						Migrated:    123,
@@ -42,7 +42,7 @@ func TestStorageSwapper_StopReadingUnifiedStorage(t *testing.T) {
 		{
 			name: "should fail if status update fails",
 			setupMocks: func(bulk *MockBulkStoreClient, dual *dualwrite.MockService) {
-				gr := resources.SupportedProvisioningResources[0]
+				gr := resources.LegacyMigrationResources[0]
 				dual.On("Status", mock.Anything, gr.GroupResource()).Return(dualwrite.StorageStatus{}, nil)
 				dual.On("Update", mock.Anything, mock.Anything).Return(dualwrite.StorageStatus{}, errors.New("update failed"))
 			},
@@ -81,7 +81,7 @@ func TestStorageSwapper_WipeUnifiedAndSetMigratedFlag(t *testing.T) {
 		{
 			name: "should fail if already using unified storage",
 			setupMocks: func(bulk *MockBulkStoreClient, dual *dualwrite.MockService) {
-				gr := resources.SupportedProvisioningResources[0]
+				gr := resources.LegacyMigrationResources[0]
 				status := dualwrite.StorageStatus{
 					ReadUnified: true,
 				}
@@ -92,7 +92,7 @@ func TestStorageSwapper_WipeUnifiedAndSetMigratedFlag(t *testing.T) {
 		{
 			name: "should fail if migration is in progress",
 			setupMocks: func(bulk *MockBulkStoreClient, dual *dualwrite.MockService) {
-				gr := resources.SupportedProvisioningResources[0]
+				gr := resources.LegacyMigrationResources[0]
 				status := dualwrite.StorageStatus{
 					ReadUnified: false,
 					Migrating:   time.Now().UnixMilli(),
@@ -104,7 +104,7 @@ func TestStorageSwapper_WipeUnifiedAndSetMigratedFlag(t *testing.T) {
 		{
 			name: "should fail if bulk process fails",
 			setupMocks: func(bulk *MockBulkStoreClient, dual *dualwrite.MockService) {
-				gr := resources.SupportedProvisioningResources[0]
+				gr := resources.LegacyMigrationResources[0]
 				dual.On("Status", mock.Anything, gr.GroupResource()).Return(dualwrite.StorageStatus{}, nil)
 				bulk.On("BulkProcess", mock.Anything, mock.Anything).Return(nil, errors.New("bulk process failed"))
 			},
@@ -113,7 +113,7 @@ func TestStorageSwapper_WipeUnifiedAndSetMigratedFlag(t *testing.T) {
 		{
 			name: "should fail if status update fails after bulk process",
 			setupMocks: func(bulk *MockBulkStoreClient, dual *dualwrite.MockService) {
-				gr := resources.SupportedProvisioningResources[0]
+				gr := resources.LegacyMigrationResources[0]
 				dual.On("Status", mock.Anything, gr.GroupResource()).Return(dualwrite.StorageStatus{}, nil)
 
 				mockStream := NewBulkStore_BulkProcessClient(t)
@@ -129,7 +129,7 @@ func TestStorageSwapper_WipeUnifiedAndSetMigratedFlag(t *testing.T) {
 		{
 			name: "should fail if bulk process stream close fails",
 			setupMocks: func(bulk *MockBulkStoreClient, dual *dualwrite.MockService) {
-				gr := resources.SupportedProvisioningResources[0]
+				gr := resources.LegacyMigrationResources[0]
 				dual.On("Status", mock.Anything, gr.GroupResource()).Return(dualwrite.StorageStatus{}, nil)
 
 				mockStream := NewBulkStore_BulkProcessClient(t)
@@ -141,7 +141,7 @@ func TestStorageSwapper_WipeUnifiedAndSetMigratedFlag(t *testing.T) {
 		{
 			name: "should succeed with complete workflow",
 			setupMocks: func(bulk *MockBulkStoreClient, dual *dualwrite.MockService) {
-				for _, gr := range resources.SupportedProvisioningResources {
+				for _, gr := range resources.LegacyMigrationResources {
 					dual.On("Status", mock.Anything, gr.GroupResource()).Return(dualwrite.StorageStatus{}, nil)
 
 					mockStream := NewBulkStore_BulkProcessClient(t)
@@ -37,6 +37,7 @@ import (
 	client "github.com/grafana/grafana/pkg/generated/clientset/versioned/typed/provisioning/v0alpha1"
 	informers "github.com/grafana/grafana/pkg/generated/informers/externalversions"
 	listers "github.com/grafana/grafana/pkg/generated/listers/provisioning/v0alpha1"
+	"github.com/grafana/grafana/pkg/infra/tracing"
 	"github.com/grafana/grafana/pkg/infra/usagestats"
 	"github.com/grafana/grafana/pkg/registry/apis/dashboard/legacy"
 	"github.com/grafana/grafana/pkg/registry/apis/provisioning/controller"
@@ -48,12 +49,14 @@ import (
 	"github.com/grafana/grafana/pkg/registry/apis/provisioning/repository/github"
 	gogit "github.com/grafana/grafana/pkg/registry/apis/provisioning/repository/go-git"
 	"github.com/grafana/grafana/pkg/registry/apis/provisioning/repository/nanogit"
+	"github.com/grafana/grafana/pkg/registry/apis/provisioning/repository/objectstorage"
 	"github.com/grafana/grafana/pkg/registry/apis/provisioning/resources"
 	"github.com/grafana/grafana/pkg/registry/apis/provisioning/resources/signature"
 	"github.com/grafana/grafana/pkg/registry/apis/provisioning/safepath"
 	"github.com/grafana/grafana/pkg/registry/apis/provisioning/secrets"
 	"github.com/grafana/grafana/pkg/services/apiserver"
 	"github.com/grafana/grafana/pkg/services/apiserver/builder"
+	objstore "github.com/grafana/grafana/pkg/services/cloudmigration/objectstorage"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	grafanasecrets "github.com/grafana/grafana/pkg/services/secrets"
 	"github.com/grafana/grafana/pkg/setting"
@@ -81,6 +84,7 @@ type APIBuilder struct {
 	repositoryResources resources.RepositoryResourcesFactory
 	clients             resources.ClientFactory
 	ghFactory           *github.Factory
+	objectStorage       *objstore.S3
 	clonedir            string // where repo clones are managed
 	jobs                interface {
 		jobs.Queue
@@ -125,6 +129,7 @@ func NewAPIBuilder(
 		localFileResolver:   local,
 		features:            features,
 		ghFactory:           ghFactory,
+		objectStorage:       objstore.NewS3(&http.Client{Timeout: 30 * time.Second}, tracing.NewNoopTracerService()),
 		clients:             clients,
 		parsers:             parsers,
 		repositoryResources: resources.NewRepositoryResourcesFactory(parsers, clients, resourceLister),
@@ -441,15 +446,19 @@ func (b *APIBuilder) Mutate(ctx context.Context, a admission.Attributes, o admis
 		}
 	}
 
-	if r.Spec.Workflows == nil {
-		r.Spec.Workflows = []provisioning.Workflow{}
+	if r.Spec.Type == provisioning.ObjectStorageRepositoryType {
+		if r.Spec.ObjectStorage == nil {
+			return fmt.Errorf("object storage configuration is required")
+		}
+
+		r.Spec.ObjectStorage.Endpoint = strings.TrimSuffix(r.Spec.ObjectStorage.Endpoint, "/")
 	}
 
-	if err := b.encryptGithubToken(ctx, r); err != nil {
-		return fmt.Errorf("failed to encrypt secrets: %w", err)
+	if r.Spec.Workflows == nil {
+		r.Spec.Workflows = []provisioning.Workflow{}
 	}
 
-	if err := b.encryptGitToken(ctx, r); err != nil {
+	if err := b.encryptSecrets(ctx, r); err != nil {
 		return fmt.Errorf("failed to encrypt secrets: %w", err)
 	}
 
@@ -464,30 +473,35 @@ func (b *APIBuilder) Mutate(ctx context.Context, a admission.Attributes, o admis
 }
 
 // TODO: move this to a more appropriate place
-func (b *APIBuilder) encryptGithubToken(ctx context.Context, repo *provisioning.Repository) error {
-	var err error
-	if repo.Spec.GitHub != nil &&
-		repo.Spec.GitHub.Token != "" {
-		repo.Spec.GitHub.EncryptedToken, err = b.secrets.Encrypt(ctx, []byte(repo.Spec.GitHub.Token))
-		if err != nil {
-			return err
-		}
-		repo.Spec.GitHub.Token = ""
+// encryptSecrets encrypts the secrets set in the repository configuration into their encrypted
+// counterparts, then sets them to an empty string again.
+func (b *APIBuilder) encryptSecrets(ctx context.Context, repo *provisioning.Repository) error {
+	type secretField struct {
+		value     *string
+		encrypted *[]byte
 	}
 
-	return nil
-}
+	var fields []secretField
+	if repo.Spec.GitHub != nil {
+		fields = append(fields, secretField{&repo.Spec.GitHub.Token, &repo.Spec.GitHub.EncryptedToken})
+	}
+	if repo.Spec.Git != nil {
+		fields = append(fields, secretField{&repo.Spec.Git.Token, &repo.Spec.Git.EncryptedToken})
+	}
+	if repo.Spec.ObjectStorage != nil {
+		fields = append(fields, secretField{&repo.Spec.ObjectStorage.SecretAccessKey, &repo.Spec.ObjectStorage.EncryptedSecretAccessKey})
+	}
 
-// TODO: move this to a more appropriate place
-func (b *APIBuilder) encryptGitToken(ctx context.Context, repo *provisioning.Repository) error {
-	var err error
-	if repo.Spec.Git != nil &&
-		repo.Spec.Git.Token != "" {
-		repo.Spec.Git.EncryptedToken, err = b.secrets.Encrypt(ctx, []byte(repo.Spec.Git.Token))
+	for _, field := range fields {
+		if *field.value == "" {
+			continue
+		}
+		encrypted, err := b.secrets.Encrypt(ctx, []byte(*field.value))
 		if err != nil {
 			return err
 		}
-		repo.Spec.Git.Token = ""
+		*field.encrypted = encrypted
+		*field.value = ""
 	}
 
 	return nil
@@ -1216,6 +1230,8 @@ func (b *APIBuilder) AsRepository(ctx context.Context, r *provisioning.Repositor
 		}
 
 		return nanogit.NewGithubRepository(apiRepo, nanogitRepo), nil
+	case provisioning.ObjectStorageRepositoryType:
+		return objectstorage.NewRepository(ctx, r, b.objectStorage, b.secrets)
 	default:
 		return nil, fmt.Errorf("unknown repository type (%s)", r.Spec.Type)
 	}
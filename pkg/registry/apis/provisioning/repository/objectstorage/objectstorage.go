@@ -0,0 +1,320 @@
+package objectstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	provisioning "github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1"
+	"github.com/grafana/grafana/pkg/registry/apis/provisioning/repository"
+	"github.com/grafana/grafana/pkg/registry/apis/provisioning/safepath"
+	"github.com/grafana/grafana/pkg/registry/apis/provisioning/secrets"
+	objstore "github.com/grafana/grafana/pkg/services/cloudmigration/objectstorage"
+)
+
+var (
+	_ repository.Repository = (*objectStorageRepository)(nil)
+	_ repository.Writer     = (*objectStorageRepository)(nil)
+	_ repository.Reader     = (*objectStorageRepository)(nil)
+)
+
+// objectStorageRepository reads and writes resources in a bucket of an S3-compatible service.
+// Buckets have no history, so the ETag of each object is used as its hash: a full sync picks up
+// every object whose ETag changed since the last one.
+type objectStorageRepository struct {
+	config *provisioning.Repository
+	client *objstore.S3
+	bucket objstore.BucketConfig
+
+	// key prefix of the repository root, empty or with a trailing slash
+	prefix string
+}
+
+func NewRepository(
+	ctx context.Context,
+	config *provisioning.Repository,
+	client *objstore.S3,
+	secrets secrets.Service,
+) (*objectStorageRepository, error) {
+	r := &objectStorageRepository{
+		config: config,
+		client: client,
+	}
+
+	cfg := config.Spec.ObjectStorage
+	if cfg == nil {
+		return r, nil
+	}
+
+	r.bucket = objstore.BucketConfig{
+		Endpoint:        cfg.Endpoint,
+		Bucket:          cfg.Bucket,
+		Region:          cfg.Region,
+		AccessKeyID:     cfg.AccessKeyID,
+		SecretAccessKey: cfg.SecretAccessKey,
+	}
+	if r.bucket.SecretAccessKey == "" && len(cfg.EncryptedSecretAccessKey) > 0 {
+		decrypted, err := secrets.Decrypt(ctx, cfg.EncryptedSecretAccessKey)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt secret access key: %w", err)
+		}
+		r.bucket.SecretAccessKey = string(decrypted)
+	}
+
+	r.prefix = strings.Trim(cfg.Path, "/")
+	if r.prefix != "" {
+		r.prefix += "/"
+	}
+
+	return r, nil
+}
+
+func (r *objectStorageRepository) Config() *provisioning.Repository {
+	return r.config
+}
+
+// Validate implements provisioning.Repository.
+func (r *objectStorageRepository) Validate() (list field.ErrorList) {
+	cfg := r.config.Spec.ObjectStorage
+	if cfg == nil {
+		return field.ErrorList{field.Required(field.NewPath("spec", "objectStorage"), "an object storage config is required")}
+	}
+
+	if cfg.Endpoint == "" {
+		list = append(list, field.Required(field.NewPath("spec", "objectStorage", "endpoint"), "an endpoint is required"))
+	} else if u, err := url.Parse(cfg.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		list = append(list, field.Invalid(field.NewPath("spec", "objectStorage", "endpoint"), cfg.Endpoint, "endpoint must be an http or https URL"))
+	}
+
+	if cfg.Bucket == "" {
+		list = append(list, field.Required(field.NewPath("spec", "objectStorage", "bucket"), "a bucket is required"))
+	} else if strings.Contains(cfg.Bucket, "/") {
+		list = append(list, field.Invalid(field.NewPath("spec", "objectStorage", "bucket"), cfg.Bucket, "invalid bucket name"))
+	}
+
+	if cfg.AccessKeyID != "" && cfg.SecretAccessKey == "" && len(cfg.EncryptedSecretAccessKey) == 0 {
+		list = append(list, field.Required(field.NewPath("spec", "objectStorage", "secretAccessKey"), "a secret access key is required with an access key ID"))
+	}
+
+	if err := safepath.IsSafe(cfg.Path); err != nil {
+		list = append(list, field.Invalid(field.NewPath("spec", "objectStorage", "path"), cfg.Path, err.Error()))
+	}
+
+	if safepath.IsAbs(cfg.Path) {
+		list = append(list, field.Invalid(field.NewPath("spec", "objectStorage", "path"), cfg.Path, "path must be relative"))
+	}
+
+	return list
+}
+
+// Test implements provisioning.Repository.
+func (r *objectStorageRepository) Test(ctx context.Context) (*provisioning.TestResults, error) {
+	if _, err := r.client.ListObjects(ctx, r.bucket, r.prefix); err != nil {
+		return &provisioning.TestResults{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Errors: []provisioning.ErrorDetails{{
+				Type:   metav1.CauseTypeFieldValueInvalid,
+				Field:  field.NewPath("spec", "objectStorage", "bucket").String(),
+				Detail: fmt.Sprintf("failed to list bucket: %v", err),
+			}},
+		}, nil
+	}
+
+	return &provisioning.TestResults{
+		Code:    http.StatusOK,
+		Success: true,
+	}, nil
+}
+
+func (r *objectStorageRepository) validateRequest(ref string) error {
+	if ref != "" {
+		return apierrors.NewBadRequest("object storage repository does not support ref")
+	}
+
+	return nil
+}
+
+func (r *objectStorageRepository) key(filePath string) string {
+	return r.prefix + strings.TrimPrefix(filePath, "/")
+}
+
+// Read implements provisioning.Repository.
+func (r *objectStorageRepository) Read(ctx context.Context, filePath, ref string) (*repository.FileInfo, error) {
+	if err := r.validateRequest(ref); err != nil {
+		return nil, err
+	}
+
+	if safepath.IsDir(filePath) {
+		objects, err := r.client.ListObjects(ctx, r.bucket, r.key(filePath))
+		if err != nil {
+			return nil, fmt.Errorf("list objects: %w", err)
+		}
+		if len(objects) == 0 {
+			return nil, repository.ErrFileNotFound
+		}
+
+		return &repository.FileInfo{Path: filePath}, nil
+	}
+
+	data, info, err := r.client.GetObject(ctx, r.bucket, r.key(filePath))
+	if errors.Is(err, objstore.ErrObjectNotFound) {
+		return nil, repository.ErrFileNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("get object: %w", err)
+	}
+
+	file := &repository.FileInfo{
+		Path: filePath,
+		Data: data,
+		Hash: info.ETag,
+	}
+	if !info.LastModified.IsZero() {
+		file.Modified = &metav1.Time{Time: info.LastModified}
+	}
+
+	return file, nil
+}
+
+// ReadTree implements provisioning.Repository.
+// Buckets have no directories: they are derived from the object keys.
+func (r *objectStorageRepository) ReadTree(ctx context.Context, ref string) ([]repository.FileTreeEntry, error) {
+	if err := r.validateRequest(ref); err != nil {
+		return nil, err
+	}
+
+	objects, err := r.client.ListObjects(ctx, r.bucket, r.prefix)
+	if err != nil {
+		return nil, fmt.Errorf("list objects: %w", err)
+	}
+
+	dirs := map[string]bool{}
+	entries := make([]repository.FileTreeEntry, 0, len(objects))
+	for _, obj := range objects {
+		p := strings.TrimPrefix(obj.Key, r.prefix)
+		if p == "" {
+			continue
+		}
+
+		if !safepath.IsDir(p) {
+			entries = append(entries, repository.FileTreeEntry{
+				Path: p,
+				Hash: obj.ETag,
+				Size: obj.Size,
+				Blob: true,
+			})
+		} else if !dirs[p] {
+			dirs[p] = true
+			entries = append(entries, repository.FileTreeEntry{Path: p})
+		}
+
+		for dir := safepath.Dir(p); dir != "" && !dirs[dir]; dir = safepath.Dir(dir) {
+			dirs[dir] = true
+			entries = append(entries, repository.FileTreeEntry{Path: dir})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Path < entries[j].Path
+	})
+
+	return entries, nil
+}
+
+func (r *objectStorageRepository) Create(ctx context.Context, filePath, ref string, data []byte, comment string) error {
+	if err := r.validateRequest(ref); err != nil {
+		return err
+	}
+
+	// Directories only exist through the keys they contain
+	if safepath.IsDir(filePath) {
+		if data != nil {
+			return apierrors.NewBadRequest("data cannot be provided for a directory")
+		}
+
+		filePath = safepath.Join(filePath, ".keep")
+		data = []byte{}
+	}
+
+	_, _, err := r.client.GetObject(ctx, r.bucket, r.key(filePath))
+	if err == nil {
+		return repository.ErrFileAlreadyExists
+	} else if !errors.Is(err, objstore.ErrObjectNotFound) {
+		return fmt.Errorf("check if object exists: %w", err)
+	}
+
+	return r.client.PutObject(ctx, r.bucket, r.key(filePath), data)
+}
+
+func (r *objectStorageRepository) Update(ctx context.Context, filePath, ref string, data []byte, comment string) error {
+	if err := r.validateRequest(ref); err != nil {
+		return err
+	}
+
+	if safepath.IsDir(filePath) {
+		return apierrors.NewBadRequest("cannot update a directory")
+	}
+
+	_, _, err := r.client.GetObject(ctx, r.bucket, r.key(filePath))
+	if errors.Is(err, objstore.ErrObjectNotFound) {
+		return repository.ErrFileNotFound
+	} else if err != nil {
+		return fmt.Errorf("check if object exists: %w", err)
+	}
+
+	return r.client.PutObject(ctx, r.bucket, r.key(filePath), data)
+}
+
+func (r *objectStorageRepository) Write(ctx context.Context, filePath, ref string, data []byte, comment string) error {
+	if err := r.validateRequest(ref); err != nil {
+		return err
+	}
+
+	if safepath.IsDir(filePath) {
+		return r.client.PutObject(ctx, r.bucket, r.key(safepath.Join(filePath, ".keep")), []byte{})
+	}
+
+	return r.client.PutObject(ctx, r.bucket, r.key(filePath), data)
+}
+
+func (r *objectStorageRepository) Delete(ctx context.Context, filePath, ref, comment string) error {
+	if err := r.validateRequest(ref); err != nil {
+		return err
+	}
+
+	if safepath.IsDir(filePath) {
+		objects, err := r.client.ListObjects(ctx, r.bucket, r.key(filePath))
+		if err != nil {
+			return fmt.Errorf("list objects: %w", err)
+		}
+		if len(objects) == 0 {
+			return repository.ErrFileNotFound
+		}
+
+		for _, obj := range objects {
+			if err := r.client.DeleteObject(ctx, r.bucket, obj.Key); err != nil {
+				return fmt.Errorf("delete object %s: %w", obj.Key, err)
+			}
+		}
+
+		return nil
+	}
+
+	// Deleting a missing key succeeds in S3, so check it exists first
+	_, _, err := r.client.GetObject(ctx, r.bucket, r.key(filePath))
+	if errors.Is(err, objstore.ErrObjectNotFound) {
+		return repository.ErrFileNotFound
+	} else if err != nil {
+		return fmt.Errorf("check if object exists: %w", err)
+	}
+
+	return r.client.DeleteObject(ctx, r.bucket, r.key(filePath))
+}
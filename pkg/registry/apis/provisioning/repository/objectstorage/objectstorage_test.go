@@ -0,0 +1,225 @@
+package objectstorage
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	provisioning "github.com/grafana/grafana/pkg/apis/provisioning/v0alpha1"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/registry/apis/provisioning/repository"
+	"github.com/grafana/grafana/pkg/registry/apis/provisioning/secrets"
+	objstore "github.com/grafana/grafana/pkg/services/cloudmigration/objectstorage"
+	"github.com/grafana/grafana/pkg/services/cloudmigration/objectstorage/objectstoragetest"
+)
+
+func newTestObjectStorage(t *testing.T, cfg *provisioning.ObjectStorageRepositoryConfig) *objectStorageRepository {
+	t.Helper()
+
+	repo, err := NewRepository(context.Background(), &provisioning.Repository{
+		Spec: provisioning.RepositorySpec{
+			Type:          provisioning.ObjectStorageRepositoryType,
+			ObjectStorage: cfg,
+		},
+	}, objstore.NewS3(http.DefaultClient, tracing.NewNoopTracerService()), secrets.NewMockService(t))
+	require.NoError(t, err)
+	return repo
+}
+
+func TestObjectStorageRepository_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *provisioning.ObjectStorageRepositoryConfig
+		expected field.ErrorList
+	}{
+		{
+			name:   "missing config",
+			config: nil,
+			expected: field.ErrorList{
+				field.Required(field.NewPath("spec", "objectStorage"), "an object storage config is required"),
+			},
+		},
+		{
+			name:   "valid config",
+			config: &provisioning.ObjectStorageRepositoryConfig{Endpoint: "http://minio:9000", Bucket: "dashboards", Path: "grafana/"},
+		},
+		{
+			name:   "missing endpoint and bucket",
+			config: &provisioning.ObjectStorageRepositoryConfig{},
+			expected: field.ErrorList{
+				field.Required(field.NewPath("spec", "objectStorage", "endpoint"), "an endpoint is required"),
+				field.Required(field.NewPath("spec", "objectStorage", "bucket"), "a bucket is required"),
+			},
+		},
+		{
+			name:   "invalid endpoint",
+			config: &provisioning.ObjectStorageRepositoryConfig{Endpoint: "minio:9000", Bucket: "dashboards"},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "objectStorage", "endpoint"), "minio:9000", "endpoint must be an http or https URL"),
+			},
+		},
+		{
+			name:   "access key without secret",
+			config: &provisioning.ObjectStorageRepositoryConfig{Endpoint: "http://minio:9000", Bucket: "dashboards", AccessKeyID: "key"},
+			expected: field.ErrorList{
+				field.Required(field.NewPath("spec", "objectStorage", "secretAccessKey"), "a secret access key is required with an access key ID"),
+			},
+		},
+		{
+			name:   "absolute path",
+			config: &provisioning.ObjectStorageRepositoryConfig{Endpoint: "http://minio:9000", Bucket: "dashboards", Path: "/grafana"},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "objectStorage", "path"), "/grafana", "path must be relative"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestObjectStorage(t, tt.config)
+			require.Equal(t, tt.expected, repo.Validate())
+		})
+	}
+}
+
+func TestObjectStorageRepository_DecryptsSecretAccessKey(t *testing.T) {
+	secretsSvc := secrets.NewMockService(t)
+	secretsSvc.EXPECT().Decrypt(context.Background(), []byte("encrypted")).Return([]byte("secret"), nil)
+
+	repo, err := NewRepository(context.Background(), &provisioning.Repository{
+		Spec: provisioning.RepositorySpec{
+			Type: provisioning.ObjectStorageRepositoryType,
+			ObjectStorage: &provisioning.ObjectStorageRepositoryConfig{
+				Endpoint:                 "http://minio:9000",
+				Bucket:                   "dashboards",
+				AccessKeyID:              "key",
+				EncryptedSecretAccessKey: []byte("encrypted"),
+			},
+		},
+	}, nil, secretsSvc)
+	require.NoError(t, err)
+	require.Equal(t, "secret", repo.bucket.SecretAccessKey)
+}
+
+func TestObjectStorageRepository_ReadWrite(t *testing.T) {
+	ctx := context.Background()
+
+	server := objectstoragetest.NewServer("dashboards")
+	t.Cleanup(server.Close)
+	server.Put("dashboards", "grafana/root.json", []byte(`{"root":true}`))
+	server.Put("dashboards", "grafana/team/a/dash.json", []byte(`{"a":true}`))
+	server.Put("dashboards", "outside.json", []byte(`{}`))
+
+	repo := newTestObjectStorage(t, &provisioning.ObjectStorageRepositoryConfig{
+		Endpoint: server.URL,
+		Bucket:   "dashboards",
+		Path:     "grafana",
+	})
+
+	results, err := repo.Test(ctx)
+	require.NoError(t, err)
+	require.True(t, results.Success)
+
+	t.Run("read tree", func(t *testing.T) {
+		tree, err := repo.ReadTree(ctx, "")
+		require.NoError(t, err)
+
+		paths := make([]string, 0, len(tree))
+		for _, entry := range tree {
+			paths = append(paths, entry.Path)
+			require.Equal(t, !strings.HasSuffix(entry.Path, "/"), entry.Blob)
+			if entry.Blob {
+				require.NotEmpty(t, entry.Hash)
+			}
+		}
+		require.Equal(t, []string{"root.json", "team/", "team/a/", "team/a/dash.json"}, paths)
+	})
+
+	t.Run("read", func(t *testing.T) {
+		info, err := repo.Read(ctx, "team/a/dash.json", "")
+		require.NoError(t, err)
+		require.Equal(t, `{"a":true}`, string(info.Data))
+		require.NotEmpty(t, info.Hash)
+		require.NotNil(t, info.Modified)
+
+		info, err = repo.Read(ctx, "team/", "")
+		require.NoError(t, err)
+		require.Equal(t, "team/", info.Path)
+
+		_, err = repo.Read(ctx, "missing.json", "")
+		require.ErrorIs(t, err, repository.ErrFileNotFound)
+
+		_, err = repo.Read(ctx, "root.json", "main")
+		require.Error(t, err)
+	})
+
+	t.Run("hash changes with the content", func(t *testing.T) {
+		before, err := repo.Read(ctx, "root.json", "")
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Update(ctx, "root.json", "", []byte(`{"root":false}`), "update"))
+
+		after, err := repo.Read(ctx, "root.json", "")
+		require.NoError(t, err)
+		require.NotEqual(t, before.Hash, after.Hash)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		require.NoError(t, repo.Create(ctx, "new.json", "", []byte(`{}`), "create"))
+		data, ok := server.Get("dashboards", "grafana/new.json")
+		require.True(t, ok)
+		require.Equal(t, `{}`, string(data))
+
+		require.ErrorIs(t, repo.Create(ctx, "new.json", "", []byte(`{}`), "create"), repository.ErrFileAlreadyExists)
+
+		require.NoError(t, repo.Create(ctx, "empty/", "", nil, "create folder"))
+		_, ok = server.Get("dashboards", "grafana/empty/.keep")
+		require.True(t, ok)
+	})
+
+	t.Run("update missing file", func(t *testing.T) {
+		require.ErrorIs(t, repo.Update(ctx, "missing.json", "", []byte(`{}`), "update"), repository.ErrFileNotFound)
+	})
+
+	t.Run("write", func(t *testing.T) {
+		require.NoError(t, repo.Write(ctx, "written.json", "", []byte(`{"v":1}`), "write"))
+		require.NoError(t, repo.Write(ctx, "written.json", "", []byte(`{"v":2}`), "write"))
+		data, ok := server.Get("dashboards", "grafana/written.json")
+		require.True(t, ok)
+		require.Equal(t, `{"v":2}`, string(data))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, "new.json", "", "delete"))
+		_, ok := server.Get("dashboards", "grafana/new.json")
+		require.False(t, ok)
+
+		require.ErrorIs(t, repo.Delete(ctx, "new.json", "", "delete"), repository.ErrFileNotFound)
+
+		require.NoError(t, repo.Delete(ctx, "team/", "", "delete folder"))
+		_, ok = server.Get("dashboards", "grafana/team/a/dash.json")
+		require.False(t, ok)
+
+		_, ok = server.Get("dashboards", "outside.json")
+		require.True(t, ok, "objects outside of the path should not be touched")
+	})
+}
+
+func TestObjectStorageRepository_TestMissingBucket(t *testing.T) {
+	server := objectstoragetest.NewServer()
+	t.Cleanup(server.Close)
+
+	repo := newTestObjectStorage(t, &provisioning.ObjectStorageRepositoryConfig{
+		Endpoint: server.URL,
+		Bucket:   "missing",
+	})
+
+	results, err := repo.Test(context.Background())
+	require.NoError(t, err)
+	require.False(t, results.Success)
+	require.Equal(t, "spec.objectStorage.bucket", results.Errors[0].Field)
+}
@@ -81,6 +81,11 @@ func ValidateRepository(repo Repository) field.ErrorList {
 			cfg.Spec.Git, "Git config only valid when type is git"))
 	}
 
+	if cfg.Spec.Type != provisioning.ObjectStorageRepositoryType && cfg.Spec.ObjectStorage != nil {
+		list = append(list, field.Invalid(field.NewPath("spec", "objectStorage"),
+			cfg.Spec.ObjectStorage, "Object storage config only valid when type is objectstorage"))
+	}
+
 	for _, w := range cfg.Spec.Workflows {
 		switch w {
 		case provisioning.WriteWorkflow: // valid; no fall thru
@@ -170,6 +170,25 @@ func TestValidateRepository(t *testing.T) {
 				require.Contains(t, errors.ToAggregate().Error(), "spec.git: Invalid value")
 			},
 		},
+		{
+			name: "mismatched object storage config",
+			repository: func() *MockRepository {
+				m := NewMockRepository(t)
+				m.On("Config").Return(&provisioning.Repository{
+					Spec: provisioning.RepositorySpec{
+						Title:         "Test Repo",
+						Type:          provisioning.LocalRepositoryType,
+						ObjectStorage: &provisioning.ObjectStorageRepositoryConfig{},
+					},
+				})
+				m.On("Validate").Return(field.ErrorList{})
+				return m
+			}(),
+			expectedErrs: 1,
+			validateError: func(t *testing.T, errors field.ErrorList) {
+				require.Contains(t, errors.ToAggregate().Error(), "spec.objectStorage: Invalid value")
+			},
+		},
 		{
 			name: "multiple validation errors",
 			repository: func() *MockRepository {
@@ -3,6 +3,7 @@ package resources
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -10,25 +11,69 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 
+	alerting "github.com/grafana/grafana/apps/alerting/notifications/pkg/apis/alerting/v0alpha1"
+	dashboardV0 "github.com/grafana/grafana/apps/dashboard/pkg/apis/dashboard/v0alpha1"
 	dashboardV1 "github.com/grafana/grafana/apps/dashboard/pkg/apis/dashboard/v1beta1"
 	dashboardV2 "github.com/grafana/grafana/apps/dashboard/pkg/apis/dashboard/v2alpha1"
 	folders "github.com/grafana/grafana/apps/folder/pkg/apis/folder/v1beta1"
+	playlist "github.com/grafana/grafana/apps/playlist/pkg/apis/playlist/v0alpha1"
+	alertrule "github.com/grafana/grafana/pkg/apis/alertrule/v0alpha1"
+	correlation "github.com/grafana/grafana/pkg/apis/correlation/v0alpha1"
 	iam "github.com/grafana/grafana/pkg/apis/iam/v0alpha1"
 	"github.com/grafana/grafana/pkg/services/apiserver"
 	"github.com/grafana/grafana/pkg/services/apiserver/client"
 )
 
 var (
-	UserResource        = iam.UserResourceInfo.GroupVersionResource()
-	FolderResource      = folders.FolderResourceInfo.GroupVersionResource()
-	DashboardResource   = dashboardV1.DashboardResourceInfo.GroupVersionResource()
-	DashboardResourceV2 = dashboardV2.DashboardResourceInfo.GroupVersionResource()
+	UserResource         = iam.UserResourceInfo.GroupVersionResource()
+	FolderResource       = folders.FolderResourceInfo.GroupVersionResource()
+	DashboardResource    = dashboardV1.DashboardResourceInfo.GroupVersionResource()
+	DashboardResourceV2  = dashboardV2.DashboardResourceInfo.GroupVersionResource()
+	LibraryPanelResource = dashboardV0.LibraryPanelResourceInfo.GroupVersionResource()
+	PlaylistResource     = schema.GroupVersionResource{
+		Group:    playlist.PlaylistKind().Group(),
+		Version:  playlist.PlaylistKind().Version(),
+		Resource: playlist.PlaylistKind().Plural(),
+	}
+	ContactPointResource = schema.GroupVersionResource{
+		Group:    alerting.ReceiverKind().Group(),
+		Version:  alerting.ReceiverKind().Version(),
+		Resource: alerting.ReceiverKind().Plural(),
+	}
+	AlertRuleResource   = alertrule.AlertRuleResourceInfo.GroupVersionResource()
+	CorrelationResource = correlation.CorrelationResourceInfo.GroupVersionResource()
 
 	// SupportedProvisioningResources is the list of resources that can fully managed from the UI
-	SupportedProvisioningResources = []schema.GroupVersionResource{FolderResource, DashboardResource}
+	SupportedProvisioningResources = []schema.GroupVersionResource{
+		FolderResource,
+		DashboardResource,
+		LibraryPanelResource,
+		PlaylistResource,
+		ContactPointResource,
+		AlertRuleResource,
+		CorrelationResource,
+	}
+
+	// OptionalProvisioningResources is the list of supported resources whose API may not be enabled in the instance.
+	// They are skipped, rather than failing the job, when no client can be found for them.
+	OptionalProvisioningResources = []schema.GroupVersionResource{
+		LibraryPanelResource,
+		PlaylistResource,
+		ContactPointResource,
+		AlertRuleResource,
+		CorrelationResource,
+	}
+
+	// LegacyMigrationResources is the list of resources that can be migrated from the legacy SQL storage
+	LegacyMigrationResources = []schema.GroupVersionResource{FolderResource, DashboardResource}
 
 	// SupportsFolderAnnotation is the list of resources that can be saved in a folder
-	SupportsFolderAnnotation = []schema.GroupResource{FolderResource.GroupResource(), DashboardResource.GroupResource()}
+	SupportsFolderAnnotation = []schema.GroupResource{
+		FolderResource.GroupResource(),
+		DashboardResource.GroupResource(),
+		LibraryPanelResource.GroupResource(),
+		AlertRuleResource.GroupResource(),
+	}
 )
 
 // ClientFactory is a factory for creating clients for a given namespace
@@ -187,6 +232,11 @@ func (c *resourceClients) User() (dynamic.ResourceInterface, error) {
 	return v, err
 }
 
+// IsOptionalResource returns true if the resource is supported, but its API may not be enabled in the instance
+func IsOptionalResource(gvr schema.GroupVersionResource) bool {
+	return slices.Contains(OptionalProvisioningResources, gvr)
+}
+
 // ForEach applies the function to each resource returned from the list operation
 func ForEach(ctx context.Context, client dynamic.ResourceInterface, fn func(item *unstructured.Unstructured) error) error {
 	var continueToken string
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path"
+	"slices"
 
 	"gopkg.in/yaml.v3"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -187,13 +188,6 @@ func (r *parser) Parse(ctx context.Context, info *repository.FileInfo) (parsed *
 		obj.SetName(obj.GetGenerateName() + util.GenerateShortUID())
 	}
 
-	// Calculate folder identifier from the file path
-	if info.Path != "" {
-		dirPath := safepath.Dir(info.Path)
-		if dirPath != "" {
-			parsed.Meta.SetFolder(ParseFolder(dirPath, r.repo.Name).ID)
-		}
-	}
 	obj.SetUID("")             // clear identifiers
 	obj.SetResourceVersion("") // clear identifiers
 
@@ -208,6 +202,15 @@ func (r *parser) Parse(ctx context.Context, info *repository.FileInfo) (parsed *
 		return nil, fmt.Errorf("get client for kind: %w", err)
 	}
 
+	// Calculate folder identifier from the file path
+	// Resources that can not be saved in a folder (playlists, contact points) ignore the directory they are in
+	if info.Path != "" && slices.Contains(SupportsFolderAnnotation, parsed.GVR.GroupResource()) {
+		dirPath := safepath.Dir(info.Path)
+		if dirPath != "" {
+			parsed.Meta.SetFolder(ParseFolder(dirPath, r.repo.Name).ID)
+		}
+	}
+
 	return parsed, nil
 }
 
@@ -19,6 +19,10 @@ func TestParser(t *testing.T) {
 		Return(nil, dashboardV0.DashboardResourceInfo.GroupVersionResource(), nil).Maybe()
 	clients.On("ForKind", dashboardV1.DashboardResourceInfo.GroupVersionKind()).
 		Return(nil, dashboardV1.DashboardResourceInfo.GroupVersionResource(), nil).Maybe()
+	clients.On("ForKind", dashboardV0.LibraryPanelResourceInfo.GroupVersionKind()).
+		Return(nil, LibraryPanelResource, nil).Maybe()
+	clients.On("ForKind", PlaylistResource.GroupVersion().WithKind("Playlist")).
+		Return(nil, PlaylistResource, nil).Maybe()
 
 	parser := &parser{
 		repo: provisioning.ResourceRepositoryInfo{
@@ -93,4 +97,39 @@ spec:
 		require.Equal(t, "dashboard.grafana.app", dash.GVR.Group)
 		require.Equal(t, "v0alpha1", dash.GVR.Version)
 	})
+	t.Run("folder is only set on resources that support folders", func(t *testing.T) {
+		panel, err := parser.Parse(context.Background(), &repository.FileInfo{
+			Path: "team/panel.librarypanel.yaml",
+			Data: []byte(`apiVersion: dashboard.grafana.app/v0alpha1
+kind: LibraryPanel
+metadata:
+  name: panel
+spec:
+  title: Test panel
+`),
+		})
+		require.NoError(t, err)
+		require.Equal(t, LibraryPanelResource, panel.GVR)
+		require.Equal(t, ParseFolder("team/", "repo").ID, panel.Meta.GetFolder())
+
+		playlist, err := parser.Parse(context.Background(), &repository.FileInfo{
+			Path: "team/morning.playlist.yaml",
+			Data: []byte(`apiVersion: playlist.grafana.app/v0alpha1
+kind: Playlist
+metadata:
+  name: morning
+spec:
+  title: Morning
+`),
+		})
+		require.NoError(t, err)
+		require.Equal(t, PlaylistResource, playlist.GVR)
+		require.Empty(t, playlist.Meta.GetFolder())
+	})
+}
+
+func TestFileSuffix(t *testing.T) {
+	require.Equal(t, ".json", fileSuffix(dashboardV1.DashboardResourceInfo.GroupVersionKind()))
+	require.Equal(t, ".librarypanel.json", fileSuffix(dashboardV0.LibraryPanelResourceInfo.GroupVersionKind()))
+	require.Equal(t, ".playlist.json", fileSuffix(PlaylistResource.GroupVersion().WithKind("Playlist")))
 }
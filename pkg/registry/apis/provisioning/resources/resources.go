@@ -6,12 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	dashboardV1 "github.com/grafana/grafana/apps/dashboard/pkg/apis/dashboard/v1beta1"
 	"github.com/grafana/grafana/pkg/apimachinery/utils"
 	"github.com/grafana/grafana/pkg/infra/slugify"
 	"github.com/grafana/grafana/pkg/registry/apis/provisioning/repository"
@@ -99,7 +101,7 @@ func (r *ResourcesManager) WriteResourceFileFromObject(ctx context.Context, obj
 		return "", fmt.Errorf("folder not found in tree: %s", folder)
 	}
 
-	fileName := slugify.Slugify(title) + ".json"
+	fileName := slugify.Slugify(title) + fileSuffix(obj.GroupVersionKind())
 	if fid.Path != "" {
 		fileName = safepath.Join(fid.Path, fileName)
 	}
@@ -211,3 +213,12 @@ func (r *ResourcesManager) RemoveResourceFromFile(ctx context.Context, path stri
 
 	return objName, schema.GroupVersionKind{}, nil
 }
+
+// fileSuffix returns the suffix of the file an object is written to.
+// Other kinds get their kind in the suffix, so they do not collide with a dashboard of the same title.
+func fileSuffix(gvk schema.GroupVersionKind) string {
+	if gvk.Kind == "" || gvk.GroupKind() == dashboardV1.DashboardResourceInfo.GroupVersionKind().GroupKind() {
+		return ".json"
+	}
+	return "." + strings.ToLower(gvk.Kind) + ".json"
+}
@@ -165,11 +165,30 @@ func (e *evaluator) evaluateFile(ctx context.Context, repo repository.Reader, ba
 				}
 			}
 		}
+	} else if info.Parsed.Existing != nil {
+		info.GrafanaURL = resourceURL(baseURL, info.Parsed)
 	}
 
 	return info
 }
 
+// resourceURL returns the page where an existing resource, other than a dashboard, can be seen
+func resourceURL(baseURL string, parsed *resources.ParsedResource) string {
+	name := parsed.Obj.GetName()
+	switch parsed.GVR.GroupResource() {
+	case resources.PlaylistResource.GroupResource():
+		return fmt.Sprintf("%splaylists/edit/%s", baseURL, url.PathEscape(name))
+	case resources.ContactPointResource.GroupResource():
+		return fmt.Sprintf("%salerting/notifications/receivers/%s/edit", baseURL, url.PathEscape(name))
+	case resources.AlertRuleResource.GroupResource():
+		return fmt.Sprintf("%salerting/grafana/%s/view", baseURL, url.PathEscape(name))
+	case resources.CorrelationResource.GroupResource():
+		return fmt.Sprintf("%sdatasources/correlations", baseURL)
+	default:
+		return ""
+	}
+}
+
 func renderScreenshotFromGrafanaURL(ctx context.Context,
 	baseURL string,
 	renderer ScreenshotRenderer,
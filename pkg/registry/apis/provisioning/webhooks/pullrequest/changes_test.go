@@ -562,6 +562,138 @@ func TestCalculateChanges(t *testing.T) {
 				}},
 			},
 		},
+		{
+			name: "existing playlist",
+			setupMocks: func(parser *resources.MockParser, reader *repository.MockReader, progress *jobs.MockJobProgressRecorder, renderer *MockScreenshotRenderer, parserFactory *resources.MockParserFactory) {
+				finfo := &repository.FileInfo{
+					Path: "morning.playlist.json",
+					Ref:  "ref",
+					Data: []byte("xxxx"),
+				}
+				obj := &unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"apiVersion": resources.PlaylistResource.GroupVersion().String(),
+						"kind":       "Playlist",
+						"metadata": map[string]interface{}{
+							"name": "morning",
+						},
+						"spec": map[string]interface{}{
+							"title": "Morning",
+						},
+					},
+				}
+				meta, _ := utils.MetaAccessor(obj)
+				renderer.On("IsAvailable", mock.Anything, mock.Anything).Return(false)
+				progress.On("SetMessage", mock.Anything, "process morning.playlist.json").Return()
+				reader.On("Read", mock.Anything, "morning.playlist.json", "ref").Return(finfo, nil)
+				reader.On("Config").Return(&provisioning.Repository{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-repo",
+						Namespace: "x",
+					},
+				})
+				parser.On("Parse", mock.Anything, finfo).Return(&resources.ParsedResource{
+					Info: finfo,
+					Repo: provisioning.ResourceRepositoryInfo{
+						Namespace: "x",
+						Name:      "y",
+					},
+					GVK: schema.GroupVersionKind{
+						Group:   resources.PlaylistResource.Group,
+						Version: resources.PlaylistResource.Version,
+						Kind:    "Playlist",
+					},
+					GVR:            resources.PlaylistResource,
+					Obj:            obj,
+					Existing:       obj,
+					Meta:           meta,
+					DryRunResponse: obj,
+				}, nil)
+				parserFactory.On("GetParser", mock.Anything, mock.Anything).Return(parser, nil)
+			},
+			changes: []repository.VersionedFileChange{{
+				Action: repository.FileActionUpdated,
+				Path:   "morning.playlist.json",
+				Ref:    "ref",
+			}},
+			expectedInfo: changeInfo{
+				Changes: []fileChangeInfo{{
+					Change: repository.VersionedFileChange{
+						Action: repository.FileActionUpdated,
+						Path:   "morning.playlist.json",
+						Ref:    "ref",
+					},
+					Title:      "Morning",
+					GrafanaURL: "http://host/playlists/edit/morning",
+				}},
+			},
+		},
+		{
+			name: "existing alert rule",
+			setupMocks: func(parser *resources.MockParser, reader *repository.MockReader, progress *jobs.MockJobProgressRecorder, renderer *MockScreenshotRenderer, parserFactory *resources.MockParserFactory) {
+				finfo := &repository.FileInfo{
+					Path: "cpu/high-cpu.alertrule.json",
+					Ref:  "ref",
+					Data: []byte("xxxx"),
+				}
+				obj := &unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"apiVersion": resources.AlertRuleResource.GroupVersion().String(),
+						"kind":       "AlertRule",
+						"metadata": map[string]interface{}{
+							"name": "high-cpu",
+						},
+						"spec": map[string]interface{}{
+							"title": "High CPU",
+						},
+					},
+				}
+				meta, _ := utils.MetaAccessor(obj)
+				renderer.On("IsAvailable", mock.Anything, mock.Anything).Return(false)
+				progress.On("SetMessage", mock.Anything, "process cpu/high-cpu.alertrule.json").Return()
+				reader.On("Read", mock.Anything, "cpu/high-cpu.alertrule.json", "ref").Return(finfo, nil)
+				reader.On("Config").Return(&provisioning.Repository{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-repo",
+						Namespace: "x",
+					},
+				})
+				parser.On("Parse", mock.Anything, finfo).Return(&resources.ParsedResource{
+					Info: finfo,
+					Repo: provisioning.ResourceRepositoryInfo{
+						Namespace: "x",
+						Name:      "y",
+					},
+					GVK: schema.GroupVersionKind{
+						Group:   resources.AlertRuleResource.Group,
+						Version: resources.AlertRuleResource.Version,
+						Kind:    "AlertRule",
+					},
+					GVR:            resources.AlertRuleResource,
+					Obj:            obj,
+					Existing:       obj,
+					Meta:           meta,
+					DryRunResponse: obj,
+				}, nil)
+				parserFactory.On("GetParser", mock.Anything, mock.Anything).Return(parser, nil)
+			},
+			changes: []repository.VersionedFileChange{{
+				Action: repository.FileActionUpdated,
+				Path:   "cpu/high-cpu.alertrule.json",
+				Ref:    "ref",
+			}},
+			expectedInfo: changeInfo{
+				Changes: []fileChangeInfo{{
+					Change: repository.VersionedFileChange{
+						Action: repository.FileActionUpdated,
+						Path:   "cpu/high-cpu.alertrule.json",
+						Ref:    "ref",
+					},
+					Title:      "High CPU",
+					GrafanaURL: "http://host/alerting/grafana/high-cpu/view",
+				}},
+			},
+		},
 		{
 			name: "deleted file",
 			setupMocks: func(parser *resources.MockParser, reader *repository.MockReader, progress *jobs.MockJobProgressRecorder, renderer *MockScreenshotRenderer, parserFactory *resources.MockParserFactory) {
@@ -126,6 +126,22 @@ func TestGenerateComment(t *testing.T) {
 				},
 			},
 		}},
+		{"update contact point", changeInfo{
+			GrafanaBaseURL: "http://host/",
+			Changes: []fileChangeInfo{
+				{
+					Parsed: &resources.ParsedResource{
+						Info: &repository.FileInfo{
+							Path: "oncall.receiver.json",
+						},
+						Action: v0alpha1.ResourceActionUpdate,
+						GVK:    schema.GroupVersionKind{Kind: "Receiver"},
+					},
+					Title:      "On-call",
+					GrafanaURL: "http://grafana/alerting/notifications/receivers/b25jYWxs/edit",
+				},
+			},
+		}},
 	} {
 		t.Run(tc.Name, func(t *testing.T) {
 			repo := NewMockPullRequestRepo(t)
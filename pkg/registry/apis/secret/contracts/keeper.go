@@ -13,6 +13,10 @@ var (
 	ErrKeeperNotFound = errors.New("keeper not found")
 )
 
+// KeeperCredentialsDecrypter is the decrypter that a SecureValue must list to be used as a Keeper credential.
+// Keepers send their credentials to an address set by the user, so only values created for that purpose are allowed.
+const KeeperCredentialsDecrypter = "keeper-credentials"
+
 // KeeperMetadataStorage is the interface for wiring and dependency injection.
 type KeeperMetadataStorage interface {
 	Create(ctx context.Context, keeper *secretv0alpha1.Keeper, actorUID string) (*secretv0alpha1.Keeper, error)
@@ -84,6 +88,37 @@ func (e *ErrKeeperInvalidSecureValuesReference) ErrorList() field.ErrorList {
 	return errs
 }
 
+// ErrKeeperSecureValuesNotDecryptable is returned when a Keeper references SecureValues that do not list
+// the KeeperCredentialsDecrypter in their decrypters.
+type ErrKeeperSecureValuesNotDecryptable struct {
+	secureValues map[string]struct{}
+}
+
+var _ xkube.ErrorLister = (*ErrKeeperSecureValuesNotDecryptable)(nil)
+
+func NewErrKeeperSecureValuesNotDecryptable(secureValues map[string]struct{}) *ErrKeeperSecureValuesNotDecryptable {
+	return &ErrKeeperSecureValuesNotDecryptable{secureValues: secureValues}
+}
+
+func (e *ErrKeeperSecureValuesNotDecryptable) Error() string {
+	return e.ErrorList().ToAggregate().Error()
+}
+
+func (e *ErrKeeperSecureValuesNotDecryptable) ErrorList() field.ErrorList {
+	errs := make(field.ErrorList, 0, len(e.secureValues))
+
+	path := field.NewPath("secureValueName")
+
+	for sv := range e.secureValues {
+		errs = append(
+			errs,
+			field.Forbidden(path, `secure value "`+sv+`" must list "`+KeeperCredentialsDecrypter+`" in its decrypters`),
+		)
+	}
+
+	return errs
+}
+
 // ExternalID represents either the secure value's GUID or ref (in case of external secret references).
 // This is saved in the secure_value metadata storage as `external_id`.
 // TODO: this does not belong in the k8s spec, but it is used by us internally. Place it somewhere appropriate.
@@ -0,0 +1,56 @@
+package secretkeeper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	secretv0alpha1 "github.com/grafana/grafana/pkg/apis/secret/v0alpha1"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/contracts"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/xkube"
+)
+
+var (
+	ErrCredentialNotFound         = errors.New("keeper credential not found")
+	ErrCredentialSourceNotAllowed = errors.New("keeper credentials can only be read from secure values")
+	ErrCredentialNotAllowed       = errors.New("secure value cannot be used as a keeper credential")
+)
+
+// credentialResolver resolves the credentials of third-party keepers.
+type credentialResolver struct {
+	secureValueMetadataStorage contracts.SecureValueMetadataStorage
+	systemKeeper               contracts.Keeper
+}
+
+// Resolve returns the value of a credential, which is read from a secure value stored in the system keeper.
+// Keepers are created by users and their credentials are sent to the address of the keeper, so values from
+// the environment or the Grafana configuration are refused: they would let users send server secrets to any host.
+// For the same reason, the secure value must list contracts.KeeperCredentialsDecrypter in its decrypters.
+func (r *credentialResolver) Resolve(ctx context.Context, namespace string, value secretv0alpha1.CredentialValue) (string, error) {
+	switch {
+	case value.ValueFromEnv != "" || value.ValueFromConfig != "":
+		return "", ErrCredentialSourceNotAllowed
+
+	case value.SecureValueName != "":
+		sv, err := r.secureValueMetadataStorage.ReadForDecrypt(ctx, xkube.Namespace(namespace), value.SecureValueName)
+		if err != nil {
+			return "", fmt.Errorf("reading secure value %q: %w", value.SecureValueName, err)
+		}
+		// Third-party keepers can only reference secure values from the system keeper that are meant to be used as
+		// credentials, this is validated on creation and checked again here as the secure value may have changed since.
+		if sv.Keeper != nil {
+			return "", fmt.Errorf("secure value %q is not stored in the system keeper", value.SecureValueName)
+		}
+		if !slices.Contains(sv.Decrypters, contracts.KeeperCredentialsDecrypter) {
+			return "", fmt.Errorf("%w: secure value %q does not list %q in its decrypters", ErrCredentialNotAllowed, value.SecureValueName, contracts.KeeperCredentialsDecrypter)
+		}
+		exposed, err := r.systemKeeper.Expose(ctx, &secretv0alpha1.SystemKeeperConfig{}, namespace, contracts.ExternalID(sv.ExternalID))
+		if err != nil {
+			return "", fmt.Errorf("exposing secure value %q: %w", value.SecureValueName, err)
+		}
+		return exposed.DangerouslyExposeAndConsumeValue(), nil
+	}
+
+	return "", fmt.Errorf("%w: no source configured", ErrCredentialNotFound)
+}
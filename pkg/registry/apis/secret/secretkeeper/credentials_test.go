@@ -0,0 +1,93 @@
+package secretkeeper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	secretv0alpha1 "github.com/grafana/grafana/pkg/apis/secret/v0alpha1"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/contracts"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper/fakes"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/xkube"
+)
+
+func Test_CredentialResolver(t *testing.T) {
+	ctx := context.Background()
+	resolver := &credentialResolver{}
+
+	t.Run("refuses values from the environment", func(t *testing.T) {
+		t.Setenv("GF_TEST_VAULT_TOKEN", "from-env")
+
+		for _, name := range []string{"GF_TEST_VAULT_TOKEN", "GF_SECURITY_SECRET_KEY", "GF_DATABASE_PASSWORD"} {
+			v, err := resolver.Resolve(ctx, "default", secretv0alpha1.CredentialValue{ValueFromEnv: name})
+			require.ErrorIs(t, err, ErrCredentialSourceNotAllowed, name)
+			require.Empty(t, v)
+		}
+	})
+
+	t.Run("refuses values from the config", func(t *testing.T) {
+		for _, key := range []string{"security.secret_key", "database.password", "secrets.vault.token"} {
+			v, err := resolver.Resolve(ctx, "default", secretv0alpha1.CredentialValue{ValueFromConfig: key})
+			require.ErrorIs(t, err, ErrCredentialSourceNotAllowed, key)
+			require.Empty(t, v)
+		}
+	})
+
+	t.Run("refuses values from the environment even with a secure value", func(t *testing.T) {
+		_, err := resolver.Resolve(ctx, "default", secretv0alpha1.CredentialValue{SecureValueName: "token", ValueFromEnv: "GF_TEST_VAULT_TOKEN"})
+		require.ErrorIs(t, err, ErrCredentialSourceNotAllowed)
+	})
+
+	t.Run("resolves secure values meant to be keeper credentials", func(t *testing.T) {
+		resolver := newTestCredentialResolver(t, []string{contracts.KeeperCredentialsDecrypter})
+
+		v, err := resolver.Resolve(ctx, "default", secretv0alpha1.CredentialValue{SecureValueName: "token"})
+		require.NoError(t, err)
+		require.Equal(t, "s3cr3t", v)
+	})
+
+	t.Run("refuses unrelated secure values", func(t *testing.T) {
+		resolver := newTestCredentialResolver(t, []string{"some-app"})
+
+		v, err := resolver.Resolve(ctx, "default", secretv0alpha1.CredentialValue{SecureValueName: "token"})
+		require.ErrorIs(t, err, ErrCredentialNotAllowed)
+		require.Empty(t, v)
+	})
+
+	t.Run("fails without a source", func(t *testing.T) {
+		_, err := resolver.Resolve(ctx, "default", secretv0alpha1.CredentialValue{})
+		require.ErrorIs(t, err, ErrCredentialNotFound)
+	})
+}
+
+type fakeSecureValueMetadataStorage struct {
+	contracts.SecureValueMetadataStorage
+	values map[string]*contracts.DecryptSecureValue
+}
+
+func (s *fakeSecureValueMetadataStorage) ReadForDecrypt(_ context.Context, _ xkube.Namespace, name string) (*contracts.DecryptSecureValue, error) {
+	sv, ok := s.values[name]
+	if !ok {
+		return nil, contracts.ErrSecureValueNotFound
+	}
+	return sv, nil
+}
+
+// newTestCredentialResolver returns a resolver with a secure value named "token" in the system keeper.
+func newTestCredentialResolver(t *testing.T, decrypters []string) *credentialResolver {
+	t.Helper()
+
+	systemKeeper := fakes.NewFakeKeeper()
+	externalID, err := systemKeeper.Store(context.Background(), &secretv0alpha1.SystemKeeperConfig{}, "default", "s3cr3t")
+	require.NoError(t, err)
+
+	return &credentialResolver{
+		secureValueMetadataStorage: &fakeSecureValueMetadataStorage{
+			values: map[string]*contracts.DecryptSecureValue{
+				"token": {ExternalID: externalID.String(), Decrypters: decrypters},
+			},
+		},
+		systemKeeper: systemKeeper,
+	}
+}
@@ -0,0 +1,192 @@
+package filekeeper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	secretv0alpha1 "github.com/grafana/grafana/pkg/apis/secret/v0alpha1"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/contracts"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper/metrics"
+)
+
+var (
+	ErrInvalidConfig  = errors.New("invalid file keeper config")
+	ErrInvalidRef     = errors.New("invalid file keeper ref")
+	ErrSecretNotFound = errors.New("secret file not found")
+	ErrReadOnly       = errors.New("file keeper is read-only")
+	// ErrDirectoryNotAllowed is returned when the directory of a keeper is not inside one of the directories allowed by the server config.
+	ErrDirectoryNotAllowed = errors.New("file keeper directory is not allowed")
+)
+
+// FileKeeper is a read-only keeper for secrets mounted as files, such as Kubernetes secret volumes.
+// Secure values reference a file relative to the configured directory, and the reference is used as external ID.
+// Grafana never writes nor deletes the files.
+// Keepers are created by users, so their directory must be inside one of the directories allowed by the server config.
+type FileKeeper struct {
+	tracer             trace.Tracer
+	metrics            *metrics.KeeperMetrics
+	allowedDirectories []string
+}
+
+var _ contracts.Keeper = (*FileKeeper)(nil)
+
+func NewFileKeeper(tracer trace.Tracer, keeperMetrics *metrics.KeeperMetrics, allowedDirectories []string) *FileKeeper {
+	return &FileKeeper{
+		tracer:             tracer,
+		metrics:            keeperMetrics,
+		allowedDirectories: allowedDirectories,
+	}
+}
+
+// Store checks that the referenced file exists and returns the reference as external ID.
+func (k *FileKeeper) Store(ctx context.Context, cfg secretv0alpha1.KeeperConfig, namespace string, exposedValueOrRef string) (contracts.ExternalID, error) {
+	_, span := k.tracer.Start(ctx, "FileKeeper.Store", trace.WithAttributes(attribute.String("namespace", namespace)))
+	defer span.End()
+
+	start := time.Now()
+	if _, err := k.read(cfg, exposedValueOrRef); err != nil {
+		return "", fmt.Errorf("unable to store value: %w", err)
+	}
+
+	k.metrics.StoreDuration.WithLabelValues(string(cfg.Type())).Observe(time.Since(start).Seconds())
+	externalID := contracts.ExternalID(exposedValueOrRef)
+	span.SetAttributes(attribute.String("externalID", externalID.String()))
+
+	return externalID, nil
+}
+
+// Update only succeeds when the reference does not change, since the external ID of a secure value is immutable.
+func (k *FileKeeper) Update(ctx context.Context, cfg secretv0alpha1.KeeperConfig, namespace string, externalID contracts.ExternalID, exposedValueOrRef string) error {
+	_, span := k.tracer.Start(ctx, "FileKeeper.Update", trace.WithAttributes(
+		attribute.String("namespace", namespace),
+		attribute.String("externalID", externalID.String()),
+	))
+	defer span.End()
+
+	start := time.Now()
+	if exposedValueOrRef != externalID.String() {
+		return fmt.Errorf("%w: the ref of a secure value cannot be changed", ErrReadOnly)
+	}
+	if _, err := k.read(cfg, exposedValueOrRef); err != nil {
+		return fmt.Errorf("unable to update value: %w", err)
+	}
+
+	k.metrics.UpdateDuration.WithLabelValues(string(cfg.Type())).Observe(time.Since(start).Seconds())
+
+	return nil
+}
+
+func (k *FileKeeper) Expose(ctx context.Context, cfg secretv0alpha1.KeeperConfig, namespace string, externalID contracts.ExternalID) (secretv0alpha1.ExposedSecureValue, error) {
+	_, span := k.tracer.Start(ctx, "FileKeeper.Expose", trace.WithAttributes(
+		attribute.String("namespace", namespace),
+		attribute.String("externalID", externalID.String()),
+	))
+	defer span.End()
+
+	start := time.Now()
+	value, err := k.read(cfg, externalID.String())
+	if err != nil {
+		return "", fmt.Errorf("unable to read value: %w", err)
+	}
+
+	exposedValue := secretv0alpha1.NewExposedSecureValue(string(value))
+	k.metrics.ExposeDuration.WithLabelValues(string(cfg.Type())).Observe(time.Since(start).Seconds())
+
+	return exposedValue, nil
+}
+
+// Delete is a no-op, the files are owned by whoever mounted them.
+func (k *FileKeeper) Delete(ctx context.Context, cfg secretv0alpha1.KeeperConfig, namespace string, externalID contracts.ExternalID) error {
+	_, span := k.tracer.Start(ctx, "FileKeeper.Delete", trace.WithAttributes(
+		attribute.String("namespace", namespace),
+		attribute.String("externalID", externalID.String()),
+	))
+	defer span.End()
+
+	k.metrics.DeleteDuration.WithLabelValues(string(cfg.Type())).Observe(0)
+
+	return nil
+}
+
+// read returns the content of the referenced file. The file must be inside the configured directory,
+// symbolic links are followed as long as they do not escape it.
+func (k *FileKeeper) read(cfg secretv0alpha1.KeeperConfig, ref string) ([]byte, error) {
+	fileCfg, ok := cfg.(*secretv0alpha1.FileKeeperConfig)
+	if !ok || fileCfg == nil {
+		return nil, fmt.Errorf("%w: expected %s keeper config", ErrInvalidConfig, secretv0alpha1.FileKeeperType)
+	}
+	if fileCfg.Directory == "" {
+		return nil, fmt.Errorf("%w: directory is required", ErrInvalidConfig)
+	}
+	if ref == "" || !filepath.IsLocal(ref) {
+		return nil, fmt.Errorf("%w: %q must be a path relative to the keeper directory", ErrInvalidRef, ref)
+	}
+
+	dir, err := k.resolveDirectory(fileCfg.Directory)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	defer func() { _ = root.Close() }()
+
+	f, err := root.Open(ref)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrSecretNotFound, ref)
+		}
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%w: %q is a directory", ErrInvalidRef, ref)
+	}
+	if info.Size() > contracts.SECURE_VALUE_RAW_INPUT_MAX_SIZE_BYTES {
+		return nil, fmt.Errorf("%w: %q is larger than %d bytes", ErrInvalidRef, ref, contracts.SECURE_VALUE_RAW_INPUT_MAX_SIZE_BYTES)
+	}
+
+	return io.ReadAll(f)
+}
+
+// resolveDirectory returns the directory of a keeper with symbolic links resolved.
+// It fails unless the resolved directory is one of the allowed directories or inside one of them.
+func (k *FileKeeper) resolveDirectory(dir string) (string, error) {
+	if !filepath.IsAbs(dir) {
+		return "", fmt.Errorf("%w: %q must be an absolute path", ErrInvalidConfig, dir)
+	}
+
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	for _, allowed := range k.allowedDirectories {
+		allowed, err := filepath.EvalSymlinks(allowed)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(allowed, resolved)
+		if err == nil && filepath.IsLocal(rel) {
+			return resolved, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrDirectoryNotAllowed, dir)
+}
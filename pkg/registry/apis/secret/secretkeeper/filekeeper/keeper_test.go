@@ -0,0 +1,133 @@
+package filekeeper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	secretv0alpha1 "github.com/grafana/grafana/pkg/apis/secret/v0alpha1"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/contracts"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper/metrics"
+)
+
+func Test_FileKeeper(t *testing.T) {
+	ctx := context.Background()
+
+	// Mimic the layout of a Kubernetes secret volume, where the files are symbolic links to a hidden directory.
+	allowed := t.TempDir()
+	dir := filepath.Join(allowed, "secrets")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "..data", "nested"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "..data", "db-password"), []byte("s3cr3t"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "..data", "nested", "api-key"), []byte("k3y"), 0o600))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "db-password"), filepath.Join(dir, "db-password")))
+	require.NoError(t, os.Symlink("..data/nested", filepath.Join(dir, "nested")))
+
+	outside := filepath.Join(t.TempDir(), "outside")
+	require.NoError(t, os.WriteFile(outside, []byte("not yours"), 0o600))
+	require.NoError(t, os.Symlink(outside, filepath.Join(dir, "escape")))
+
+	keeper := NewFileKeeper(noop.NewTracerProvider().Tracer("test"), metrics.NewTestMetrics(), []string{allowed})
+	cfg := &secretv0alpha1.FileKeeperConfig{Directory: dir}
+
+	t.Run("store returns the ref as external id and expose reads the file", func(t *testing.T) {
+		externalID, err := keeper.Store(ctx, cfg, "default", "db-password")
+		require.NoError(t, err)
+		require.Equal(t, contracts.ExternalID("db-password"), externalID)
+
+		exposed, err := keeper.Expose(ctx, cfg, "default", externalID)
+		require.NoError(t, err)
+		require.Equal(t, "s3cr3t", exposed.DangerouslyExposeAndConsumeValue())
+
+		externalID, err = keeper.Store(ctx, cfg, "default", "nested/api-key")
+		require.NoError(t, err)
+
+		exposed, err = keeper.Expose(ctx, cfg, "default", externalID)
+		require.NoError(t, err)
+		require.Equal(t, "k3y", exposed.DangerouslyExposeAndConsumeValue())
+	})
+
+	t.Run("expose reads the latest content of the file", func(t *testing.T) {
+		path := filepath.Join(dir, "rotated")
+		require.NoError(t, os.WriteFile(path, []byte("v1"), 0o600))
+
+		externalID, err := keeper.Store(ctx, cfg, "default", "rotated")
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(path, []byte("v2"), 0o600))
+		exposed, err := keeper.Expose(ctx, cfg, "default", externalID)
+		require.NoError(t, err)
+		require.Equal(t, "v2", exposed.DangerouslyExposeAndConsumeValue())
+	})
+
+	t.Run("store fails when the file does not exist", func(t *testing.T) {
+		_, err := keeper.Store(ctx, cfg, "default", "missing")
+		require.ErrorIs(t, err, ErrSecretNotFound)
+	})
+
+	t.Run("refs cannot escape the directory", func(t *testing.T) {
+		for _, ref := range []string{"", "../outside", "/etc/passwd", "nested/../../outside"} {
+			_, err := keeper.Store(ctx, cfg, "default", ref)
+			require.ErrorIs(t, err, ErrInvalidRef, ref)
+		}
+
+		_, err := keeper.Store(ctx, cfg, "default", "escape")
+		require.Error(t, err)
+	})
+
+	t.Run("directories cannot be referenced", func(t *testing.T) {
+		_, err := keeper.Store(ctx, cfg, "default", "nested")
+		require.ErrorIs(t, err, ErrInvalidRef)
+	})
+
+	t.Run("the ref cannot be updated", func(t *testing.T) {
+		require.NoError(t, keeper.Update(ctx, cfg, "default", "db-password", "db-password"))
+		require.ErrorIs(t, keeper.Update(ctx, cfg, "default", "db-password", "nested/api-key"), ErrReadOnly)
+	})
+
+	t.Run("delete does not remove the file", func(t *testing.T) {
+		require.NoError(t, keeper.Delete(ctx, cfg, "default", "db-password"))
+		require.FileExists(t, filepath.Join(dir, "db-password"))
+	})
+
+	t.Run("directories outside of the allowed directories are rejected", func(t *testing.T) {
+		outsideDir := filepath.Dir(outside)
+		require.NoError(t, os.Symlink(outsideDir, filepath.Join(allowed, "link")))
+
+		for _, directory := range []string{
+			"/etc",
+			outsideDir,
+			filepath.Join(allowed, ".."),
+			filepath.Join(dir, "..", ".."),
+			filepath.Join(allowed, "link"),
+		} {
+			_, err := keeper.Store(ctx, &secretv0alpha1.FileKeeperConfig{Directory: directory}, "default", "outside")
+			require.ErrorIs(t, err, ErrDirectoryNotAllowed, directory)
+		}
+
+		_, err := keeper.Store(ctx, &secretv0alpha1.FileKeeperConfig{Directory: "secrets"}, "default", "db-password")
+		require.ErrorIs(t, err, ErrInvalidConfig)
+	})
+
+	t.Run("directories are rejected when none is allowed", func(t *testing.T) {
+		disabled := NewFileKeeper(noop.NewTracerProvider().Tracer("test"), metrics.NewTestMetrics(), nil)
+		_, err := disabled.Store(ctx, cfg, "default", "db-password")
+		require.ErrorIs(t, err, ErrDirectoryNotAllowed)
+	})
+
+	t.Run("the allowed directory itself can be used", func(t *testing.T) {
+		_, err := keeper.Store(ctx, &secretv0alpha1.FileKeeperConfig{Directory: allowed}, "default", "secrets/db-password")
+		require.NoError(t, err)
+	})
+
+	t.Run("fails with an invalid config", func(t *testing.T) {
+		_, err := keeper.Store(ctx, &secretv0alpha1.FileKeeperConfig{}, "default", "db-password")
+		require.ErrorIs(t, err, ErrInvalidConfig)
+
+		_, err = keeper.Store(ctx, &secretv0alpha1.SystemKeeperConfig{}, "default", "db-password")
+		require.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
@@ -1,35 +1,64 @@
 package secretkeeper
 
 import (
+	"net/http"
+	"time"
+
 	"go.opentelemetry.io/otel/trace"
 
 	secretv0alpha1 "github.com/grafana/grafana/pkg/apis/secret/v0alpha1"
 	"github.com/grafana/grafana/pkg/registry/apis/secret/contracts"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper/filekeeper"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper/metrics"
 	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper/sqlkeeper"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper/vaultkeeper"
+	"github.com/grafana/grafana/pkg/setting"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 // OSSKeeperService is the OSS implementation of the Service interface.
 type OSSKeeperService struct {
 	systemKeeper *sqlkeeper.SQLKeeper
+	vaultKeeper  *vaultkeeper.VaultKeeper
+	fileKeeper   *filekeeper.FileKeeper
 }
 
 var _ contracts.KeeperService = (*OSSKeeperService)(nil)
 
 func ProvideService(
 	tracer trace.Tracer,
+	cfg *setting.Cfg,
 	store contracts.EncryptedValueStorage,
+	secureValueMetadataStorage contracts.SecureValueMetadataStorage,
 	encryptionManager contracts.EncryptionManager,
 	reg prometheus.Registerer,
 ) (*OSSKeeperService, error) {
+	keeperMetrics := metrics.NewKeeperMetrics(reg)
+
+	// TODO: rename to system keeper or something like that
+	systemKeeper := sqlkeeper.NewSQLKeeper(tracer, encryptionManager, store, keeperMetrics)
+
+	credentials := &credentialResolver{
+		secureValueMetadataStorage: secureValueMetadataStorage,
+		systemKeeper:               systemKeeper,
+	}
+
 	return &OSSKeeperService{
-		// TODO: rename to system keeper or something like that
-		systemKeeper: sqlkeeper.NewSQLKeeper(tracer, encryptionManager, store, reg),
+		systemKeeper: systemKeeper,
+		vaultKeeper:  vaultkeeper.NewVaultKeeper(tracer, &http.Client{Timeout: 30 * time.Second}, credentials, keeperMetrics),
+		fileKeeper:   filekeeper.NewFileKeeper(tracer, keeperMetrics, cfg.SecretsManagement.FileKeeperDirectories),
 	}, nil
 }
 
-// Ignore the config, but we could use it to get the keeper type and then return the correct keeper.
-// Instantiation only happens on ProvideService ONCE.
-func (k *OSSKeeperService) KeeperForConfig(secretv0alpha1.KeeperConfig) (contracts.Keeper, error) {
-	return k.systemKeeper, nil
+// KeeperForConfig returns the keeper for the type of the config.
+// Instantiation only happens on ProvideService ONCE, keepers are stateless and receive the config on every call.
+func (k *OSSKeeperService) KeeperForConfig(cfg secretv0alpha1.KeeperConfig) (contracts.Keeper, error) {
+	switch cfg.(type) {
+	case *secretv0alpha1.HashiCorpKeeperConfig:
+		return k.vaultKeeper, nil
+	case *secretv0alpha1.FileKeeperConfig:
+		return k.fileKeeper, nil
+	default:
+		return k.systemKeeper, nil
+	}
 }
@@ -7,14 +7,18 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/trace/noop"
 
+	secretv0alpha1 "github.com/grafana/grafana/pkg/apis/secret/v0alpha1"
 	"github.com/grafana/grafana/pkg/infra/usagestats"
 	"github.com/grafana/grafana/pkg/registry/apis/secret/encryption/manager"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper/filekeeper"
 	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper/sqlkeeper"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper/vaultkeeper"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/sqlstore"
 	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/storage/secret/database"
 	encryptionstorage "github.com/grafana/grafana/pkg/storage/secret/encryption"
+	"github.com/grafana/grafana/pkg/storage/secret/metadata"
 	"github.com/grafana/grafana/pkg/storage/secret/migrator"
 	"github.com/grafana/grafana/pkg/tests/testsuite"
 )
@@ -40,6 +44,18 @@ func Test_OSSKeeperService(t *testing.T) {
 		assert.NotNil(t, keeper)
 		assert.IsType(t, &sqlkeeper.SQLKeeper{}, keeper)
 	})
+
+	t.Run("KeeperForConfig should return the vault keeper for hashicorp configs", func(t *testing.T) {
+		keeper, err := keeperService.KeeperForConfig(&secretv0alpha1.HashiCorpKeeperConfig{})
+		require.NoError(t, err)
+		assert.IsType(t, &vaultkeeper.VaultKeeper{}, keeper)
+	})
+
+	t.Run("KeeperForConfig should return the file keeper for file configs", func(t *testing.T) {
+		keeper, err := keeperService.KeeperForConfig(&secretv0alpha1.FileKeeperConfig{})
+		require.NoError(t, err)
+		assert.IsType(t, &filekeeper.FileKeeper{}, keeper)
+	})
 }
 
 func setupTestService(t *testing.T, cfg *setting.Cfg) (*OSSKeeperService, error) {
@@ -59,7 +75,10 @@ func setupTestService(t *testing.T, cfg *setting.Cfg) (*OSSKeeperService, error)
 	require.NoError(t, err)
 
 	// Initialize the keeper service
-	keeperService, err := ProvideService(tracer, encValueStore, encryptionManager, nil)
+	secureValueMetadataStorage, err := metadata.ProvideSecureValueMetadataStorage(database, tracer, features, nil)
+	require.NoError(t, err)
+
+	keeperService, err := ProvideService(tracer, cfg, encValueStore, secureValueMetadataStorage, encryptionManager, nil)
 
 	return keeperService, err
 }
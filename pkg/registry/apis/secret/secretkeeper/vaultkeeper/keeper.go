@@ -0,0 +1,226 @@
+package vaultkeeper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	secretv0alpha1 "github.com/grafana/grafana/pkg/apis/secret/v0alpha1"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/contracts"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper/metrics"
+)
+
+const (
+	// defaultMount is the path where Vault mounts the KV version 2 secrets engine by default.
+	defaultMount = "secret"
+	// pathPrefix is prepended to the path of every secret written by Grafana.
+	pathPrefix = "grafana"
+	// valueKey is the key of the secret data that holds the secure value.
+	valueKey = "value"
+)
+
+var (
+	ErrInvalidConfig  = errors.New("invalid hashicorp vault keeper config")
+	ErrSecretNotFound = errors.New("secret not found in hashicorp vault")
+)
+
+// CredentialResolver resolves the credentials referenced by a keeper config.
+type CredentialResolver interface {
+	Resolve(ctx context.Context, namespace string, value secretv0alpha1.CredentialValue) (string, error)
+}
+
+// VaultKeeper stores secure values in the KV version 2 secrets engine of HashiCorp Vault, using its HTTP API.
+// Secrets are written to `<mount>/data/grafana/<namespace>/<uid>`, where the uid is used as external ID.
+type VaultKeeper struct {
+	tracer      trace.Tracer
+	client      *http.Client
+	credentials CredentialResolver
+	metrics     *metrics.KeeperMetrics
+}
+
+var _ contracts.Keeper = (*VaultKeeper)(nil)
+
+func NewVaultKeeper(
+	tracer trace.Tracer,
+	client *http.Client,
+	credentials CredentialResolver,
+	keeperMetrics *metrics.KeeperMetrics,
+) *VaultKeeper {
+	return &VaultKeeper{
+		tracer:      tracer,
+		client:      client,
+		credentials: credentials,
+		metrics:     keeperMetrics,
+	}
+}
+
+func (k *VaultKeeper) Store(ctx context.Context, cfg secretv0alpha1.KeeperConfig, namespace string, exposedValueOrRef string) (contracts.ExternalID, error) {
+	ctx, span := k.tracer.Start(ctx, "VaultKeeper.Store", trace.WithAttributes(attribute.String("namespace", namespace)))
+	defer span.End()
+
+	start := time.Now()
+	externalID := contracts.ExternalID(uuid.NewString())
+	if err := k.write(ctx, cfg, namespace, externalID, exposedValueOrRef); err != nil {
+		return "", fmt.Errorf("unable to store value: %w", err)
+	}
+
+	k.metrics.StoreDuration.WithLabelValues(string(cfg.Type())).Observe(time.Since(start).Seconds())
+	span.SetAttributes(attribute.String("externalID", externalID.String()))
+
+	return externalID, nil
+}
+
+func (k *VaultKeeper) Update(ctx context.Context, cfg secretv0alpha1.KeeperConfig, namespace string, externalID contracts.ExternalID, exposedValueOrRef string) error {
+	ctx, span := k.tracer.Start(ctx, "VaultKeeper.Update", trace.WithAttributes(
+		attribute.String("namespace", namespace),
+		attribute.String("externalID", externalID.String()),
+	))
+	defer span.End()
+
+	start := time.Now()
+	// Writing to an existing path creates a new version of the secret.
+	if err := k.write(ctx, cfg, namespace, externalID, exposedValueOrRef); err != nil {
+		return fmt.Errorf("unable to update value: %w", err)
+	}
+
+	k.metrics.UpdateDuration.WithLabelValues(string(cfg.Type())).Observe(time.Since(start).Seconds())
+
+	return nil
+}
+
+func (k *VaultKeeper) Expose(ctx context.Context, cfg secretv0alpha1.KeeperConfig, namespace string, externalID contracts.ExternalID) (secretv0alpha1.ExposedSecureValue, error) {
+	ctx, span := k.tracer.Start(ctx, "VaultKeeper.Expose", trace.WithAttributes(
+		attribute.String("namespace", namespace),
+		attribute.String("externalID", externalID.String()),
+	))
+	defer span.End()
+
+	start := time.Now()
+	var body struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	if err := k.do(ctx, cfg, namespace, http.MethodGet, "data", externalID, nil, &body); err != nil {
+		return "", fmt.Errorf("unable to read value: %w", err)
+	}
+
+	value, ok := body.Data.Data[valueKey]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no %q key: %w", externalID, valueKey, ErrSecretNotFound)
+	}
+
+	k.metrics.ExposeDuration.WithLabelValues(string(cfg.Type())).Observe(time.Since(start).Seconds())
+
+	return secretv0alpha1.NewExposedSecureValue(value), nil
+}
+
+func (k *VaultKeeper) Delete(ctx context.Context, cfg secretv0alpha1.KeeperConfig, namespace string, externalID contracts.ExternalID) error {
+	ctx, span := k.tracer.Start(ctx, "VaultKeeper.Delete", trace.WithAttributes(
+		attribute.String("namespace", namespace),
+		attribute.String("externalID", externalID.String()),
+	))
+	defer span.End()
+
+	start := time.Now()
+	// Deleting the metadata removes all versions of the secret.
+	err := k.do(ctx, cfg, namespace, http.MethodDelete, "metadata", externalID, nil, nil)
+	if err != nil && !errors.Is(err, ErrSecretNotFound) {
+		return fmt.Errorf("failed to delete value: %w", err)
+	}
+
+	k.metrics.DeleteDuration.WithLabelValues(string(cfg.Type())).Observe(time.Since(start).Seconds())
+
+	return nil
+}
+
+func (k *VaultKeeper) write(ctx context.Context, cfg secretv0alpha1.KeeperConfig, namespace string, externalID contracts.ExternalID, value string) error {
+	body := map[string]any{
+		"data": map[string]string{valueKey: value},
+	}
+	return k.do(ctx, cfg, namespace, http.MethodPost, "data", externalID, body, nil)
+}
+
+// do sends a request to the KV version 2 API of Vault. The kind is either `data` or `metadata`.
+func (k *VaultKeeper) do(ctx context.Context, cfg secretv0alpha1.KeeperConfig, namespace string, method string, kind string, externalID contracts.ExternalID, in any, out any) error {
+	vaultCfg, ok := cfg.(*secretv0alpha1.HashiCorpKeeperConfig)
+	if !ok || vaultCfg == nil {
+		return fmt.Errorf("%w: expected %s keeper config", ErrInvalidConfig, secretv0alpha1.HashiCorpKeeperType)
+	}
+	if vaultCfg.Address == "" {
+		return fmt.Errorf("%w: address is required", ErrInvalidConfig)
+	}
+
+	mount := strings.Trim(vaultCfg.Mount, "/")
+	if mount == "" {
+		mount = defaultMount
+	}
+
+	endpoint, err := url.JoinPath(vaultCfg.Address, "v1", mount, kind, pathPrefix, namespace, externalID.String())
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	token, err := k.credentials.Resolve(ctx, namespace, vaultCfg.Token)
+	if err != nil {
+		return fmt.Errorf("resolving token: %w", err)
+	}
+
+	var reqBody io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrSecretNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code from hashicorp vault: %d: %s", resp.StatusCode, readErrors(resp.Body))
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// readErrors returns the errors reported by Vault in the response body, if any.
+func readErrors(r io.Reader) string {
+	var body struct {
+		Errors []string `json:"errors"`
+	}
+	if err := json.NewDecoder(io.LimitReader(r, 64*1024)).Decode(&body); err != nil {
+		return ""
+	}
+	return strings.Join(body.Errors, "; ")
+}
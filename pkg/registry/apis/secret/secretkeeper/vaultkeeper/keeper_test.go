@@ -0,0 +1,179 @@
+package vaultkeeper
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	secretv0alpha1 "github.com/grafana/grafana/pkg/apis/secret/v0alpha1"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/contracts"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper/metrics"
+)
+
+// fakeVault is a minimal stand-in for the KV version 2 secrets engine of HashiCorp Vault.
+type fakeVault struct {
+	mtx      sync.Mutex
+	token    string
+	mount    string
+	versions map[string][]map[string]string
+}
+
+func newFakeVault(token, mount string) *fakeVault {
+	return &fakeVault{token: token, mount: mount, versions: make(map[string][]map[string]string)}
+}
+
+func (v *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	if r.Header.Get("X-Vault-Token") != v.token {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+		return
+	}
+
+	path, ok := strings.CutPrefix(r.URL.Path, "/v1/"+v.mount+"/")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	kind, path, _ := strings.Cut(path, "/")
+
+	switch {
+	case kind == "data" && r.Method == http.MethodPost:
+		var body struct {
+			Data map[string]string `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		v.versions[path] = append(v.versions[path], body.Data)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"version": len(v.versions[path])},
+		})
+
+	case kind == "data" && r.Method == http.MethodGet:
+		versions, ok := v.versions[path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{
+				"data":     versions[len(versions)-1],
+				"metadata": map[string]any{"version": len(versions)},
+			},
+		})
+
+	case kind == "metadata" && r.Method == http.MethodDelete:
+		if _, ok := v.versions[path]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(v.versions, path)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+type staticCredentials map[string]string
+
+func (c staticCredentials) Resolve(_ context.Context, _ string, value secretv0alpha1.CredentialValue) (string, error) {
+	return c[value.SecureValueName], nil
+}
+
+func Test_VaultKeeper(t *testing.T) {
+	ctx := context.Background()
+	vault := newFakeVault("root-token", "kv")
+	server := httptest.NewServer(vault)
+	t.Cleanup(server.Close)
+
+	keeper := NewVaultKeeper(noop.NewTracerProvider().Tracer("test"), server.Client(), staticCredentials{
+		"VAULT_TOKEN": "root-token",
+		"WRONG_TOKEN": "wrong",
+	}, metrics.NewTestMetrics())
+
+	cfg := &secretv0alpha1.HashiCorpKeeperConfig{
+		HashiCorpCredentials: secretv0alpha1.HashiCorpCredentials{
+			Address: server.URL,
+			Token:   secretv0alpha1.CredentialValue{SecureValueName: "VAULT_TOKEN"},
+		},
+		Mount: "kv",
+	}
+
+	t.Run("store, update, expose and delete a secret", func(t *testing.T) {
+		externalID, err := keeper.Store(ctx, cfg, "default", "s3cr3t")
+		require.NoError(t, err)
+		require.NotEmpty(t, externalID)
+		require.Contains(t, vault.versions, "grafana/default/"+externalID.String())
+
+		exposed, err := keeper.Expose(ctx, cfg, "default", externalID)
+		require.NoError(t, err)
+		require.Equal(t, "s3cr3t", exposed.DangerouslyExposeAndConsumeValue())
+
+		require.NoError(t, keeper.Update(ctx, cfg, "default", externalID, "n3w-s3cr3t"))
+		require.Len(t, vault.versions["grafana/default/"+externalID.String()], 2)
+
+		exposed, err = keeper.Expose(ctx, cfg, "default", externalID)
+		require.NoError(t, err)
+		require.Equal(t, "n3w-s3cr3t", exposed.DangerouslyExposeAndConsumeValue())
+
+		require.NoError(t, keeper.Delete(ctx, cfg, "default", externalID))
+		require.NotContains(t, vault.versions, "grafana/default/"+externalID.String())
+
+		_, err = keeper.Expose(ctx, cfg, "default", externalID)
+		require.ErrorIs(t, err, ErrSecretNotFound)
+
+		// Deleting twice is not an error, so that the deletion can be retried.
+		require.NoError(t, keeper.Delete(ctx, cfg, "default", externalID))
+	})
+
+	t.Run("secrets are isolated by namespace", func(t *testing.T) {
+		externalID, err := keeper.Store(ctx, cfg, "org-1", "s3cr3t")
+		require.NoError(t, err)
+
+		_, err = keeper.Expose(ctx, cfg, "org-2", externalID)
+		require.ErrorIs(t, err, ErrSecretNotFound)
+	})
+
+	t.Run("returns the errors reported by vault", func(t *testing.T) {
+		wrongCfg := *cfg
+		wrongCfg.Token = secretv0alpha1.CredentialValue{SecureValueName: "WRONG_TOKEN"}
+
+		_, err := keeper.Store(ctx, &wrongCfg, "default", "s3cr3t")
+		require.ErrorContains(t, err, "403: permission denied")
+	})
+
+	t.Run("defaults to the secret mount", func(t *testing.T) {
+		defaultVault := newFakeVault("root-token", "secret")
+		defaultServer := httptest.NewServer(defaultVault)
+		t.Cleanup(defaultServer.Close)
+
+		defaultCfg := *cfg
+		defaultCfg.Address = defaultServer.URL
+		defaultCfg.Mount = ""
+
+		externalID, err := keeper.Store(ctx, &defaultCfg, "default", "s3cr3t")
+		require.NoError(t, err)
+		require.Contains(t, defaultVault.versions, "grafana/default/"+externalID.String())
+	})
+
+	t.Run("fails with an invalid config", func(t *testing.T) {
+		_, err := keeper.Store(ctx, &secretv0alpha1.FileKeeperConfig{Directory: "/tmp"}, "default", "s3cr3t")
+		require.ErrorIs(t, err, ErrInvalidConfig)
+
+		_, err = keeper.Expose(ctx, &secretv0alpha1.HashiCorpKeeperConfig{}, "default", contracts.ExternalID("id"))
+		require.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
@@ -53,7 +53,7 @@ func (s *SecureValueService) Create(ctx context.Context, sv *secretv0alpha1.Secu
 
 	var out *secretv0alpha1.SecureValue
 
-	encryptedSecret, err := s.encryptionManager.Encrypt(ctx, sv.Namespace, []byte(sv.Spec.Value.DangerouslyExposeAndConsumeValue()))
+	encryptedSecret, err := s.encryptionManager.Encrypt(ctx, sv.Namespace, []byte(valueOrRef(sv)))
 	if err != nil {
 		return nil, fmt.Errorf("encrypting secure value secret: %w", err)
 	}
@@ -156,8 +156,8 @@ func (s *SecureValueService) Update(ctx context.Context, newSecureValue *secretv
 		encryptedSecret string
 	)
 
-	if newSecureValue.Spec.Value != "" {
-		buffer, err := s.encryptionManager.Encrypt(ctx, newSecureValue.Namespace, []byte(newSecureValue.Spec.Value.DangerouslyExposeAndConsumeValue()))
+	if newSecureValue.Spec.Value != "" || newSecureValue.Spec.Ref != nil {
+		buffer, err := s.encryptionManager.Encrypt(ctx, newSecureValue.Namespace, []byte(valueOrRef(newSecureValue)))
 		if err != nil {
 			return nil, false, fmt.Errorf("encrypting secure value secret: %w", err)
 		}
@@ -266,3 +266,12 @@ func (s *SecureValueService) Delete(ctx context.Context, namespace xkube.Namespa
 
 	return out, nil
 }
+
+// valueOrRef returns what is sent to the keeper: the raw value or, for values that already exist
+// in the storage of a third-party keeper, the reference to them.
+func valueOrRef(sv *secretv0alpha1.SecureValue) string {
+	if sv.Spec.Ref != nil {
+		return *sv.Spec.Ref
+	}
+	return sv.Spec.Value.DangerouslyExposeAndConsumeValue()
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/grafana/grafana/pkg/registry/apis/secret/contracts"
 	"github.com/grafana/grafana/pkg/registry/apis/secret/encryption"
 	"github.com/grafana/grafana/pkg/registry/apis/secret/encryption/manager"
+	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper/metrics"
 	"github.com/grafana/grafana/pkg/registry/apis/secret/secretkeeper/sqlkeeper"
 	"github.com/grafana/grafana/pkg/registry/apis/secret/service"
 	"github.com/grafana/grafana/pkg/registry/apis/secret/worker"
@@ -107,7 +108,7 @@ func Setup(t *testing.T, opts ...func(*setupConfig)) Sut {
 	encValueStore, err := encryptionstorage.ProvideEncryptedValueStorage(database, tracer, features)
 	require.NoError(t, err)
 
-	sqlKeeper := sqlkeeper.NewSQLKeeper(tracer, encryptionManager, encValueStore, nil)
+	sqlKeeper := sqlkeeper.NewSQLKeeper(tracer, encryptionManager, encValueStore, metrics.NewTestMetrics())
 
 	var keeperService contracts.KeeperService = newKeeperServiceWrapper(sqlKeeper)
 
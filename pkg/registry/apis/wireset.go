@@ -3,6 +3,8 @@ package apiregistry
 import (
 	"github.com/google/wire"
 
+	"github.com/grafana/grafana/pkg/registry/apis/alertrule"
+	"github.com/grafana/grafana/pkg/registry/apis/correlations"
 	dashboardinternal "github.com/grafana/grafana/pkg/registry/apis/dashboard"
 	"github.com/grafana/grafana/pkg/registry/apis/dashboardsnapshot"
 	"github.com/grafana/grafana/pkg/registry/apis/datasource"
@@ -63,4 +65,6 @@ var WireSet = wire.NewSet(
 	query.RegisterAPIService,
 	userstorage.RegisterAPIService,
 	ofrep.RegisterAPIService,
+	alertrule.RegisterAPIService,
+	correlations.RegisterAPIService,
 )
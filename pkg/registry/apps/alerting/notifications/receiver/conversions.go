@@ -3,7 +3,9 @@ package receiver
 import (
 	"fmt"
 	"maps"
+	"strings"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/types"
@@ -11,6 +13,7 @@ import (
 	model "github.com/grafana/grafana/apps/alerting/notifications/pkg/apis/alerting/v0alpha1"
 	"github.com/grafana/grafana/pkg/services/apiserver/endpoints/request"
 	gapiutil "github.com/grafana/grafana/pkg/services/apiserver/utils"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
 )
 
@@ -142,17 +145,48 @@ func convertToDomainModel(receiver *model.Receiver) (*ngmodels.Receiver, map[str
 
 		domain.Integrations = append(domain.Integrations, &grafanaIntegration)
 
+		// Secure settings are redacted when contact points are exported. Saving the placeholder would overwrite the
+		// real value, so the stored value is kept instead.
+		var redacted []string
+		for _, path := range config.GetSecretFields() {
+			if removeRedactedField(grafanaIntegration.Settings, path) {
+				redacted = append(redacted, path.String())
+			}
+		}
+
 		if grafanaIntegration.UID != "" {
 			// This is an existing integration, so we track the secure fields being requested to copy over from existing values.
-			secureFields := make([]string, 0, len(integration.SecureFields))
+			secureFields := make([]string, 0, len(integration.SecureFields)+len(redacted))
 			for k, isSecure := range integration.SecureFields {
 				if isSecure {
 					secureFields = append(secureFields, k)
 				}
 			}
-			storedSecureFields[grafanaIntegration.UID] = secureFields
+			storedSecureFields[grafanaIntegration.UID] = append(secureFields, redacted...)
+		} else if len(redacted) > 0 {
+			return nil, nil, apierrors.NewBadRequest(fmt.Sprintf("integration of type %s has redacted secure settings %s, but no stored values to keep", integration.Type, strings.Join(redacted, ", ")))
 		}
 	}
 
 	return domain, storedSecureFields, nil
 }
+
+// removeRedactedField removes the setting at the path if its value is the redaction placeholder.
+func removeRedactedField(settings map[string]any, path ngmodels.IntegrationFieldPath) bool {
+	val, ok := settings[path.Head()]
+	if !ok {
+		return false
+	}
+	if path.IsLeaf() {
+		if val != definitions.RedactedValue {
+			return false
+		}
+		delete(settings, path.Head())
+		return true
+	}
+	sub, ok := val.(map[string]any)
+	if !ok {
+		return false
+	}
+	return removeRedactedField(sub, path.Tail())
+}
@@ -178,8 +178,9 @@ func (s *legacyStorage) Create(ctx context.Context,
 	if !ok {
 		return nil, fmt.Errorf("expected receiver but got %s", obj.GetObjectKind().GroupVersionKind())
 	}
-	if p.Name != "" { // TODO remove when metadata.name can be defined by user
-		return nil, apierrors.NewBadRequest("object's metadata.name should be empty")
+	// The name is derived from the title. It is accepted when it matches, so exported receivers can be created again.
+	if p.Name != "" && p.Name != legacy_storage.NameToUid(p.Spec.Title) { // TODO remove when metadata.name can be defined by user
+		return nil, apierrors.NewBadRequest("object's metadata.name should be empty or match the title")
 	}
 	model, _, err := convertToDomainModel(p)
 	if err != nil {
@@ -139,6 +139,10 @@ type ServiceAccountPermissionsService interface {
 	PermissionsService
 }
 
+type ScheduledExportPermissionsService interface {
+	PermissionsService
+}
+
 type ReceiverPermissionsService interface {
 	PermissionsService
 	SetDefaultPermissions(ctx context.Context, orgID int64, user identity.Requester, uid string)
@@ -10,16 +10,17 @@ import (
 )
 
 var sqlIDAcceptList = map[string]struct{}{
-	"id":               {},
-	"org_user.user_id": {},
-	"role.uid":         {},
-	"t.id":             {},
-	"team.id":          {},
-	"u.id":             {},
-	"\"user\".\"id\"":  {}, // For Postgres
-	"`user`.`id`":      {}, // For MySQL and SQLite
-	"dashboard.uid":    {},
-	"report.id":        {},
+	"id":                   {},
+	"org_user.user_id":     {},
+	"role.uid":             {},
+	"t.id":                 {},
+	"team.id":              {},
+	"u.id":                 {},
+	"\"user\".\"id\"":      {}, // For Postgres
+	"`user`.`id`":          {}, // For MySQL and SQLite
+	"dashboard.uid":        {},
+	"report.id":            {},
+	"scheduled_export.uid": {},
 }
 
 var (
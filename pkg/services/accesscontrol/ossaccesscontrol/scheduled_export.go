@@ -0,0 +1,71 @@
+package ossaccesscontrol
+
+import (
+	"context"
+
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/resourcepermissions"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/licensing"
+	"github.com/grafana/grafana/pkg/services/scheduledexports"
+	"github.com/grafana/grafana/pkg/services/team"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+var (
+	ScheduledExportViewActions  = []string{scheduledexports.ActionRead}
+	ScheduledExportEditActions  = append(ScheduledExportViewActions, scheduledexports.ActionWrite, scheduledexports.ActionDelete)
+	ScheduledExportAdminActions = append(ScheduledExportEditActions, scheduledexports.ActionPermissionsRead, scheduledexports.ActionPermissionsWrite)
+)
+
+type ScheduledExportPermissionsService struct {
+	*resourcepermissions.Service
+}
+
+var _ accesscontrol.ScheduledExportPermissionsService = new(ScheduledExportPermissionsService)
+
+func ProvideScheduledExportPermissions(
+	cfg *setting.Cfg, features featuremgmt.FeatureToggles, router routing.RouteRegister, sql db.DB, ac accesscontrol.AccessControl,
+	license licensing.Licensing, service accesscontrol.Service,
+	teamService team.Service, userService user.Service, actionSetService resourcepermissions.ActionSetService,
+) (*ScheduledExportPermissionsService, error) {
+	options := resourcepermissions.Options{
+		Resource:          "scheduledexports",
+		ResourceAttribute: "uid",
+		ResourceValidator: func(ctx context.Context, orgID int64, resourceID string) error {
+			return sql.WithDbSession(ctx, func(sess *db.Session) error {
+				ok, err := sess.Where("org_id = ? AND uid = ?", orgID, resourceID).Exist(&scheduledexports.ScheduledExport{})
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return scheduledexports.ErrNotFound.Errorf("scheduled export %s not found", resourceID)
+				}
+				return nil
+			})
+		},
+		Assignments: resourcepermissions.Assignments{
+			Users:           true,
+			Teams:           true,
+			BuiltInRoles:    true,
+			ServiceAccounts: true,
+		},
+		PermissionsToActions: map[string][]string{
+			"View":  ScheduledExportViewActions,
+			"Edit":  ScheduledExportEditActions,
+			"Admin": ScheduledExportAdminActions,
+		},
+		ReaderRoleName: "Scheduled export permission reader",
+		WriterRoleName: "Scheduled export permission writer",
+		RoleGroup:      "Scheduled exports",
+	}
+
+	srv, err := resourcepermissions.New(cfg, options, features, router, license, ac, service, sql, teamService, userService, actionSetService)
+	if err != nil {
+		return nil, err
+	}
+	return &ScheduledExportPermissionsService{srv}, nil
+}
@@ -30,6 +30,8 @@ const (
 	ClientProxy        = "auth.client.proxy"
 	ClientSAML         = "auth.client.saml"
 	ClientPasswordless = "auth.client.passwordless"
+	ClientWebAuthn     = "auth.client.webauthn"
+	ClientMTLS         = "auth.client.mtls"
 	ClientLDAP         = "ldap"
 	ClientProvisioning = "auth.client.apiserver.provisioning"
 )
@@ -38,6 +40,8 @@ const (
 	MetaKeyUsername            = "username"
 	MetaKeyAuthModule          = "authModule"
 	MetaKeyIsLogin             = "isLogin"
+	MetaKeyPasswordAuth        = "passwordAuth"
+	MetaKeyTwoFactorCode       = "twoFactorCode"
 	defaultRedirectToCookieKey = "redirect_to"
 )
 
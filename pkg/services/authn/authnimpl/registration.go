@@ -25,8 +25,10 @@ import (
 	"github.com/grafana/grafana/pkg/services/org"
 	"github.com/grafana/grafana/pkg/services/quota"
 	"github.com/grafana/grafana/pkg/services/rendering"
+	"github.com/grafana/grafana/pkg/services/serviceaccounts"
 	tempuser "github.com/grafana/grafana/pkg/services/temp_user"
 	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/services/webauthn"
 	"github.com/grafana/grafana/pkg/setting"
 )
 
@@ -44,6 +46,7 @@ func ProvideRegistration(
 	socialService social.Service, cache *remotecache.RemoteCache,
 	ldapService service.LDAP, settingsProviderService setting.Provider,
 	tracer tracing.Tracer, tempUserService tempuser.Service, notificationService notifications.Service,
+	webauthnService webauthn.Service, serviceAccountService serviceaccounts.Service,
 ) Registration {
 	logger := log.New("authn.registration")
 
@@ -102,6 +105,10 @@ func ProvideRegistration(
 		}
 	}
 
+	if cfg.WebAuthn.Enabled {
+		authnSvc.RegisterClient(clients.ProvideWebAuthn(loginAttempts, webauthnService))
+	}
+
 	if cfg.AuthProxy.Enabled && len(proxyClients) > 0 {
 		proxy, err := clients.ProvideProxy(cfg, cache, proxyClients...)
 		if err != nil {
@@ -111,11 +118,15 @@ func ProvideRegistration(
 		}
 	}
 
+	orgRoleMapper := connectors.ProvideOrgRoleMapper(cfg, orgService)
 	if cfg.JWTAuth.Enabled {
-		orgRoleMapper := connectors.ProvideOrgRoleMapper(cfg, orgService)
 		authnSvc.RegisterClient(clients.ProvideJWT(jwtService, orgRoleMapper, cfg))
 	}
 
+	if cfg.MTLSAuth.Enabled {
+		authnSvc.RegisterClient(clients.ProvideMTLS(cfg, orgRoleMapper, serviceAccountService))
+	}
+
 	if cfg.ExtJWTAuth.Enabled {
 		authnSvc.RegisterClient(clients.ProvideExtendedJWT(cfg))
 	}
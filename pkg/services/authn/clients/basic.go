@@ -9,6 +9,9 @@ import (
 
 var errDecodingBasicAuthHeader = errutil.BadRequest("basic-auth.invalid-header", errutil.WithPublicMessage("Invalid Basic Auth Header"))
 
+// twoFactorCodeHeader holds the TOTP or recovery code of users with two-factor authentication.
+const twoFactorCodeHeader = "X-Grafana-OTP"
+
 var _ authn.ContextAwareClient = new(Basic)
 
 func ProvideBasic(client authn.PasswordClient) *Basic {
@@ -33,6 +36,7 @@ func (c *Basic) Authenticate(ctx context.Context, r *authn.Request) (*authn.Iden
 		return nil, errDecodingBasicAuthHeader.Errorf("failed to decode basic auth header")
 	}
 
+	r.SetMeta(authn.MetaKeyTwoFactorCode, r.HTTPRequest.Header.Get(twoFactorCodeHeader))
 	return c.client.AuthenticatePassword(ctx, r, username, password)
 }
 
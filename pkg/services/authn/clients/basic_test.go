@@ -20,6 +20,7 @@ func TestBasic_Authenticate(t *testing.T) {
 		client           authn.PasswordClient
 		expectedErr      error
 		expectedIdentity *authn.Identity
+		expectedCode     string
 	}
 
 	tests := []TestCase{
@@ -29,6 +30,16 @@ func TestBasic_Authenticate(t *testing.T) {
 			client:           authntest.FakePasswordClient{ExpectedIdentity: &authn.Identity{ID: "1", Type: claims.TypeUser}},
 			expectedIdentity: &authn.Identity{ID: "1", Type: claims.TypeUser},
 		},
+		{
+			desc: "should pass two-factor code from header",
+			req: &authn.Request{HTTPRequest: &http.Request{Header: http.Header{
+				http.CanonicalHeaderKey(authorizationHeaderName): {encodeBasicAuth("user", "password")},
+				http.CanonicalHeaderKey(twoFactorCodeHeader):     {"123456"},
+			}}},
+			client:           authntest.FakePasswordClient{ExpectedIdentity: &authn.Identity{ID: "1", Type: claims.TypeUser}},
+			expectedIdentity: &authn.Identity{ID: "1", Type: claims.TypeUser},
+			expectedCode:     "123456",
+		},
 		{
 			desc:        "should fail when basic auth header could not be decoded",
 			req:         &authn.Request{HTTPRequest: &http.Request{Header: map[string][]string{authorizationHeaderName: {}}}},
@@ -47,6 +58,7 @@ func TestBasic_Authenticate(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.EqualValues(t, *tt.expectedIdentity, *identity)
+				assert.Equal(t, tt.expectedCode, tt.req.GetMeta(authn.MetaKeyTwoFactorCode))
 			}
 		})
 	}
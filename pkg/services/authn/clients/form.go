@@ -23,6 +23,8 @@ type Form struct {
 type loginForm struct {
 	Username string `json:"user" binding:"Required"`
 	Password string `json:"password" binding:"Required"`
+	// Code is the TOTP or recovery code of users with two-factor authentication.
+	Code string `json:"code"`
 }
 
 func (c *Form) Name() string {
@@ -34,6 +36,7 @@ func (c *Form) Authenticate(ctx context.Context, r *authn.Request) (*authn.Ident
 	if err := web.Bind(r.HTTPRequest, &form); err != nil {
 		return nil, errBadForm.Errorf("failed to parse request: %w", err)
 	}
+	r.SetMeta(authn.MetaKeyTwoFactorCode, form.Code)
 	return c.client.AuthenticatePassword(ctx, r, form.Username, form.Password)
 }
 
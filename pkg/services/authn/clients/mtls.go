@@ -0,0 +1,212 @@
+package clients
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strconv"
+
+	claims "github.com/grafana/authlib/types"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/login/social/connectors"
+	"github.com/grafana/grafana/pkg/services/authn"
+	"github.com/grafana/grafana/pkg/services/login"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/serviceaccounts"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+var _ authn.ContextAwareClient = new(MTLS)
+
+var (
+	errMTLSCertificateNotAllowed = errutil.Unauthorized(
+		"mtls.not-allowed", errutil.WithPublicMessage("Client certificate is not allowed"))
+	errMTLSNoMatchingRule = errutil.Unauthorized(
+		"mtls.no-matching-rule", errutil.WithPublicMessage("Client certificate does not match any identity"))
+	errMTLSServiceAccountNotFound = errutil.Unauthorized(
+		"mtls.service-account-not-found", errutil.WithPublicMessage("Service account of the client certificate not found"))
+	errMTLSInvalidRole = errutil.Forbidden(
+		"mtls.invalid-role", errutil.WithPublicMessage("Invalid role for the client certificate"))
+)
+
+func ProvideMTLS(cfg *setting.Cfg, orgRoleMapper *connectors.OrgRoleMapper, serviceAccountService serviceaccounts.Service) *MTLS {
+	return &MTLS{
+		cfg:                   cfg,
+		log:                   log.New(authn.ClientMTLS),
+		orgRoleMapper:         orgRoleMapper,
+		orgMappingCfg:         orgRoleMapper.ParseOrgMappingSettings(context.Background(), cfg.MTLSAuth.OrgMapping, cfg.MTLSAuth.RoleAttributeStrict),
+		serviceAccountService: serviceAccountService,
+	}
+}
+
+// MTLS authenticates clients with the certificate they presented during the
+// TLS handshake. The certificate is verified against the client CAs by the
+// HTTP server, the client maps it to a user or a service account with the
+// first rule that matches it.
+type MTLS struct {
+	cfg                   *setting.Cfg
+	log                   log.Logger
+	orgRoleMapper         *connectors.OrgRoleMapper
+	orgMappingCfg         connectors.MappingConfiguration
+	serviceAccountService serviceaccounts.Service
+}
+
+func (c *MTLS) Name() string {
+	return authn.ClientMTLS
+}
+
+func (c *MTLS) Authenticate(ctx context.Context, r *authn.Request) (*authn.Identity, error) {
+	cert := clientCertificate(r)
+	if cert == nil {
+		return nil, errMTLSNoMatchingRule.Errorf("request has no verified client certificate")
+	}
+
+	fingerprint := certificateFingerprint(cert)
+	if len(c.cfg.MTLSAuth.AllowedFingerprints) > 0 && !slices.Contains(c.cfg.MTLSAuth.AllowedFingerprints, fingerprint) {
+		return nil, errMTLSCertificateNotAllowed.Errorf("certificate %s of %q is not in the allowed fingerprints", fingerprint, cert.Subject)
+	}
+
+	for _, rule := range c.cfg.MTLSAuth.Rules {
+		value, match := matchCertificate(&rule, cert)
+		if match == nil {
+			continue
+		}
+		userLogin := string(rule.Pattern.ExpandString(nil, rule.Login, value, match))
+		c.log.FromContext(ctx).Debug("Client certificate matched rule", "rule", rule.Name, "fingerprint", fingerprint, "login", userLogin)
+
+		if rule.Identity == setting.MTLSIdentityServiceAccount {
+			return c.serviceAccountIdentity(ctx, &rule, userLogin)
+		}
+		email := string(rule.Pattern.ExpandString(nil, rule.Email, value, match))
+		return c.userIdentity(&rule, cert, value, userLogin, email)
+	}
+
+	return nil, errMTLSNoMatchingRule.Errorf("certificate %s of %q does not match any rule", fingerprint, cert.Subject)
+}
+
+func (c *MTLS) userIdentity(rule *setting.MTLSRule, cert *x509.Certificate, authID, userLogin, email string) (*authn.Identity, error) {
+	id := &authn.Identity{
+		AuthenticatedBy: login.MTLSModule,
+		AuthID:          authID,
+		Login:           userLogin,
+		Email:           email,
+		Name:            cert.Subject.CommonName,
+		OrgRoles:        map[int64]org.RoleType{},
+		ClientParams: authn.ClientParams{
+			SyncUser:        true,
+			FetchSyncedUser: true,
+			SyncPermissions: true,
+			SyncOrgRoles:    !c.cfg.MTLSAuth.SkipOrgRoleSync,
+			AllowSignUp:     c.cfg.MTLSAuth.AutoSignUp,
+		},
+	}
+	id.ClientParams.LookUpParams.Login = &id.Login
+	if email != "" {
+		id.ClientParams.LookUpParams.Email = &id.Email
+	}
+
+	if !c.cfg.MTLSAuth.SkipOrgRoleSync {
+		if c.cfg.MTLSAuth.AllowAssignGrafanaAdmin {
+			grafanaAdmin := rule.GrafanaAdmin
+			id.IsGrafanaAdmin = &grafanaAdmin
+		}
+		// the organizational units of the subject are the external orgs of the org mapping
+		id.OrgRoles = c.orgRoleMapper.MapOrgRoles(c.orgMappingCfg, cert.Subject.OrganizationalUnit, org.RoleType(rule.Role))
+		if c.cfg.MTLSAuth.RoleAttributeStrict && len(id.OrgRoles) == 0 {
+			return nil, errMTLSInvalidRole.Errorf("could not evaluate any valid roles for rule %s", rule.Name)
+		}
+	}
+
+	return id, nil
+}
+
+func (c *MTLS) serviceAccountIdentity(ctx context.Context, rule *setting.MTLSRule, name string) (*authn.Identity, error) {
+	serviceAccountID, err := c.serviceAccountService.RetrieveServiceAccountIdByName(ctx, rule.OrgID, name)
+	if err != nil {
+		if errors.Is(err, serviceaccounts.ErrServiceAccountNotFound) {
+			return nil, errMTLSServiceAccountNotFound.Errorf("rule %s: %w", rule.Name, err)
+		}
+		return nil, err
+	}
+
+	return &authn.Identity{
+		ID:              strconv.FormatInt(serviceAccountID, 10),
+		Type:            claims.TypeServiceAccount,
+		OrgID:           rule.OrgID,
+		AuthenticatedBy: login.MTLSModule,
+		ClientParams:    authn.ClientParams{FetchSyncedUser: true, SyncPermissions: true},
+	}, nil
+}
+
+func (c *MTLS) IsEnabled() bool {
+	return c.cfg.MTLSAuth.Enabled
+}
+
+func (c *MTLS) Test(ctx context.Context, r *authn.Request) bool {
+	return clientCertificate(r) != nil
+}
+
+// Priority lets credentials sent with the request, such as tokens, basic
+// auth, auth proxy headers and session cookies, take precedence over the
+// certificate of the connection. Otherwise a signed in user whose browser
+// also presents a certificate would be re-identified as the certificate.
+func (c *MTLS) Priority() uint {
+	return 70
+}
+
+// clientCertificate returns the leaf of the verified client certificate chain,
+// or nil when the client did not present a certificate.
+func clientCertificate(r *authn.Request) *x509.Certificate {
+	if r.HTTPRequest == nil || r.HTTPRequest.TLS == nil {
+		return nil
+	}
+	chains := r.HTTPRequest.TLS.VerifiedChains
+	if len(chains) == 0 || len(chains[0]) == 0 {
+		return nil
+	}
+	return chains[0][0]
+}
+
+func certificateFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// matchCertificate returns the first value of the attribute of the rule that
+// matches its pattern, with the indexes of the submatches.
+func matchCertificate(rule *setting.MTLSRule, cert *x509.Certificate) (string, []int) {
+	for _, value := range certificateValues(rule.Match, cert) {
+		if match := rule.Pattern.FindStringSubmatchIndex(value); match != nil {
+			return value, match
+		}
+	}
+	return "", nil
+}
+
+func certificateValues(attribute string, cert *x509.Certificate) []string {
+	switch attribute {
+	case setting.MTLSMatchSubject:
+		return []string{cert.Subject.String()}
+	case setting.MTLSMatchCommonName:
+		return []string{cert.Subject.CommonName}
+	case setting.MTLSMatchDNSSAN:
+		return cert.DNSNames
+	case setting.MTLSMatchEmailSAN:
+		return cert.EmailAddresses
+	case setting.MTLSMatchURISAN, setting.MTLSMatchSPIFFEID:
+		values := make([]string, 0, len(cert.URIs))
+		for _, uri := range cert.URIs {
+			if attribute == setting.MTLSMatchSPIFFEID && uri.Scheme != "spiffe" {
+				continue
+			}
+			values = append(values, uri.String())
+		}
+		return values
+	}
+	return nil
+}
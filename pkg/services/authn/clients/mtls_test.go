@@ -0,0 +1,243 @@
+package clients
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	claims "github.com/grafana/authlib/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/login/social/connectors"
+	"github.com/grafana/grafana/pkg/services/auth/authtest"
+	"github.com/grafana/grafana/pkg/services/authn"
+	"github.com/grafana/grafana/pkg/services/login"
+	"github.com/grafana/grafana/pkg/services/login/authinfotest"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/org/orgtest"
+	"github.com/grafana/grafana/pkg/services/serviceaccounts"
+	satests "github.com/grafana/grafana/pkg/services/serviceaccounts/tests"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestMTLS_Test(t *testing.T) {
+	cert := newTestClientCertificate(t, pkix.Name{CommonName: "ci"}, nil)
+	client := ProvideMTLS(setting.NewCfg(), connectors.ProvideOrgRoleMapper(setting.NewCfg(), &orgtest.FakeOrgService{}), &satests.FakeServiceAccountService{})
+
+	assert.False(t, client.Test(context.Background(), &authn.Request{HTTPRequest: &http.Request{}}))
+	assert.False(t, client.Test(context.Background(), &authn.Request{HTTPRequest: &http.Request{TLS: &tls.ConnectionState{}}}))
+	assert.True(t, client.Test(context.Background(), newMTLSRequest(cert)))
+}
+
+func TestMTLS_SessionTakesPrecedence(t *testing.T) {
+	cfg := setting.NewCfg()
+	cfg.LoginCookieName = "grafana_session"
+	session := ProvideSession(cfg, &authtest.FakeUserAuthTokenService{}, &authinfotest.FakeService{})
+	client := ProvideMTLS(cfg, connectors.ProvideOrgRoleMapper(cfg, &orgtest.FakeOrgService{}), &satests.FakeServiceAccountService{})
+
+	r := newMTLSRequest(newTestClientCertificate(t, pkix.Name{CommonName: "ci"}, nil))
+	r.HTTPRequest.Header = http.Header{}
+	r.HTTPRequest.AddCookie(&http.Cookie{Name: cfg.LoginCookieName, Value: "bob-the-high-entropy-token"})
+
+	// Both clients accept the request, the session of a signed in user must win.
+	require.True(t, session.Test(context.Background(), r))
+	require.True(t, client.Test(context.Background(), r))
+	assert.Less(t, session.Priority(), client.Priority())
+}
+
+func TestMTLS_Authenticate(t *testing.T) {
+	spiffeID, err := url.Parse("spiffe://mesh.example.com/ns/automation/sa/deployer")
+	require.NoError(t, err)
+
+	userCert := newTestClientCertificate(t, pkix.Name{CommonName: "Jane Doe", OrganizationalUnit: []string{"platform"}}, func(cert *x509.Certificate) {
+		cert.EmailAddresses = []string{"jane@example.com"}
+	})
+	workloadCert := newTestClientCertificate(t, pkix.Name{CommonName: "deployer"}, func(cert *x509.Certificate) {
+		cert.URIs = []*url.URL{spiffeID}
+	})
+
+	userRule := setting.MTLSRule{
+		Name:     "employees",
+		Match:    setting.MTLSMatchEmailSAN,
+		Pattern:  regexp.MustCompile(`^(?:(?P<user>[^@]+)@example\.com)$`),
+		Identity: setting.MTLSIdentityUser,
+		Login:    "$user",
+		Email:    "$0",
+		Role:     "Viewer",
+	}
+	workloadRule := setting.MTLSRule{
+		Name:     "automation",
+		Match:    setting.MTLSMatchSPIFFEID,
+		Pattern:  regexp.MustCompile(`^(?:spiffe://mesh\.example\.com/ns/automation/sa/(.+))$`),
+		Identity: setting.MTLSIdentityServiceAccount,
+		Login:    "$1",
+		OrgID:    2,
+	}
+
+	type testCase struct {
+		desc             string
+		settings         setting.MTLSSettings
+		cert             *x509.Certificate
+		serviceAccount   *satests.FakeServiceAccountService
+		expectedIdentity *authn.Identity
+		expectedErr      error
+	}
+
+	tests := []testCase{
+		{
+			desc:     "should map certificate to user",
+			settings: setting.MTLSSettings{Enabled: true, AutoSignUp: true, Rules: []setting.MTLSRule{workloadRule, userRule}},
+			cert:     userCert,
+			expectedIdentity: &authn.Identity{
+				AuthenticatedBy: login.MTLSModule,
+				AuthID:          "jane@example.com",
+				Login:           "jane",
+				Email:           "jane@example.com",
+				Name:            "Jane Doe",
+				OrgRoles:        map[int64]org.RoleType{1: org.RoleViewer},
+				ClientParams: authn.ClientParams{
+					SyncUser:        true,
+					FetchSyncedUser: true,
+					SyncPermissions: true,
+					SyncOrgRoles:    true,
+					AllowSignUp:     true,
+					LookUpParams: login.UserLookupParams{
+						Login: stringPtr("jane"),
+						Email: stringPtr("jane@example.com"),
+					},
+				},
+			},
+		},
+		{
+			desc: "should map organizational units with org mapping",
+			settings: setting.MTLSSettings{
+				Enabled:                 true,
+				OrgMapping:              []string{"platform:2:Editor"},
+				AllowAssignGrafanaAdmin: true,
+				Rules:                   []setting.MTLSRule{userRule},
+			},
+			cert: userCert,
+			expectedIdentity: &authn.Identity{
+				AuthenticatedBy: login.MTLSModule,
+				AuthID:          "jane@example.com",
+				Login:           "jane",
+				Email:           "jane@example.com",
+				Name:            "Jane Doe",
+				IsGrafanaAdmin:  boolPtr(false),
+				OrgRoles:        map[int64]org.RoleType{2: org.RoleEditor},
+				ClientParams: authn.ClientParams{
+					SyncUser:        true,
+					FetchSyncedUser: true,
+					SyncPermissions: true,
+					SyncOrgRoles:    true,
+					LookUpParams: login.UserLookupParams{
+						Login: stringPtr("jane"),
+						Email: stringPtr("jane@example.com"),
+					},
+				},
+			},
+		},
+		{
+			desc:           "should map SPIFFE ID to service account",
+			settings:       setting.MTLSSettings{Enabled: true, Rules: []setting.MTLSRule{userRule, workloadRule}},
+			cert:           workloadCert,
+			serviceAccount: &satests.FakeServiceAccountService{ExpectedServiceAccountID: 7},
+			expectedIdentity: &authn.Identity{
+				ID:              "7",
+				Type:            claims.TypeServiceAccount,
+				OrgID:           2,
+				AuthenticatedBy: login.MTLSModule,
+				ClientParams:    authn.ClientParams{FetchSyncedUser: true, SyncPermissions: true},
+			},
+		},
+		{
+			desc:           "should fail when service account does not exist",
+			settings:       setting.MTLSSettings{Enabled: true, Rules: []setting.MTLSRule{workloadRule}},
+			cert:           workloadCert,
+			serviceAccount: &satests.FakeServiceAccountService{ExpectedErr: serviceaccounts.ErrServiceAccountNotFound.Errorf("not found")},
+			expectedErr:    errMTLSServiceAccountNotFound,
+		},
+		{
+			desc:        "should fail when no rule matches",
+			settings:    setting.MTLSSettings{Enabled: true, Rules: []setting.MTLSRule{workloadRule}},
+			cert:        userCert,
+			expectedErr: errMTLSNoMatchingRule,
+		},
+		{
+			desc:        "should fail when certificate is not in the allowed fingerprints",
+			settings:    setting.MTLSSettings{Enabled: true, AllowedFingerprints: []string{certificateFingerprint(workloadCert)}, Rules: []setting.MTLSRule{userRule}},
+			cert:        userCert,
+			expectedErr: errMTLSCertificateNotAllowed,
+		},
+		{
+			desc:        "should fail with strict role mapping and no valid role",
+			settings:    setting.MTLSSettings{Enabled: true, RoleAttributeStrict: true, Rules: []setting.MTLSRule{{Name: "cn", Match: setting.MTLSMatchCommonName, Pattern: regexp.MustCompile(`^(?:.+)$`), Identity: setting.MTLSIdentityUser, Login: "$0"}}},
+			cert:        userCert,
+			expectedErr: errMTLSInvalidRole,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			cfg := setting.NewCfg()
+			cfg.MTLSAuth = tt.settings
+			serviceAccount := tt.serviceAccount
+			if serviceAccount == nil {
+				serviceAccount = &satests.FakeServiceAccountService{}
+			}
+			orgRoleMapper := connectors.ProvideOrgRoleMapper(cfg, &orgtest.FakeOrgService{ExpectedOrgs: []*org.OrgDTO{{ID: 2, Name: "Org2"}}})
+			client := ProvideMTLS(cfg, orgRoleMapper, serviceAccount)
+
+			identity, err := client.Authenticate(context.Background(), newMTLSRequest(tt.cert))
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, identity)
+				return
+			}
+			require.NoError(t, err)
+			assert.EqualValues(t, tt.expectedIdentity, identity)
+		})
+	}
+}
+
+func newMTLSRequest(cert *x509.Certificate) *authn.Request {
+	return &authn.Request{HTTPRequest: &http.Request{
+		TLS: &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}},
+	}}
+}
+
+func newTestClientCertificate(t *testing.T, subject pkix.Name, modify func(*x509.Certificate)) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      subject,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if modify != nil {
+		modify(template)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
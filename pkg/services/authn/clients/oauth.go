@@ -197,6 +197,7 @@ func (c *OAuth) Authenticate(ctx context.Context, r *authn.Request) (*authn.Iden
 		IsGrafanaAdmin:  userInfo.IsGrafanaAdmin,
 		AuthenticatedBy: c.moduleName,
 		AuthID:          userInfo.Id,
+		ExternalUID:     userInfo.Id,
 		Groups:          userInfo.Groups,
 		OAuthToken:      token,
 		OrgRoles:        userInfo.OrgRoles,
@@ -61,6 +61,7 @@ func (c *Password) AuthenticatePassword(ctx context.Context, r *authn.Request, u
 			continue
 		}
 
+		r.SetMeta(authn.MetaKeyPasswordAuth, "true")
 		return identity, nil
 	}
 
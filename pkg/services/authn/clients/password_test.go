@@ -85,9 +85,11 @@ func TestPassword_AuthenticatePassword(t *testing.T) {
 			if tt.expectedErr != nil {
 				assert.ErrorIs(t, err, tt.expectedErr)
 				assert.Nil(t, identity)
+				assert.Empty(t, r.GetMeta(authn.MetaKeyPasswordAuth))
 			} else {
 				assert.NoError(t, err)
 				assert.EqualValues(t, *tt.expectedIdentity, *identity)
+				assert.Equal(t, "true", r.GetMeta(authn.MetaKeyPasswordAuth))
 			}
 		})
 	}
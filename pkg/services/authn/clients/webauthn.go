@@ -0,0 +1,78 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	claims "github.com/grafana/authlib/types"
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+	"github.com/grafana/grafana/pkg/services/authn"
+	"github.com/grafana/grafana/pkg/services/login"
+	"github.com/grafana/grafana/pkg/services/loginattempt"
+	"github.com/grafana/grafana/pkg/services/webauthn"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+var (
+	errWebAuthnClientTooManyLoginAttempts = errutil.Unauthorized("webauthn.invalid.login-attempt", errutil.WithPublicMessage("Login temporarily blocked"))
+)
+
+var _ authn.Client = new(WebAuthn)
+
+func ProvideWebAuthn(loginAttempts loginattempt.Service, webauthnService webauthn.Service) *WebAuthn {
+	return &WebAuthn{loginAttempts, webauthnService}
+}
+
+// WebAuthn signs in users with a passkey or security key. The browser gets
+// the options of the sign in from /api/login/webauthn/begin, and posts the
+// assertion of the authenticator to the login endpoint of the client.
+type WebAuthn struct {
+	loginAttempts   loginattempt.Service
+	webauthnService webauthn.Service
+}
+
+// Authenticate implements authn.Client.
+func (c *WebAuthn) Authenticate(ctx context.Context, r *authn.Request) (*authn.Identity, error) {
+	var credential webauthn.AuthenticationResponse
+	if err := web.Bind(r.HTTPRequest, &credential); err != nil {
+		return nil, err
+	}
+
+	ipAddress := web.RemoteAddr(r.HTTPRequest)
+	ok, err := c.loginAttempts.ValidateIPAddress(ctx, ipAddress)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errWebAuthnClientTooManyLoginAttempts.Errorf("too many consecutive incorrect login attempts for IP address - login for IP address temporarily blocked")
+	}
+
+	userID, err := c.webauthnService.FinishLogin(ctx, &credential)
+	if err != nil {
+		if errors.Is(err, webauthn.ErrInvalidCredential) {
+			if err := c.loginAttempts.Add(ctx, "", ipAddress); err != nil {
+				return nil, err
+			}
+		}
+		return nil, err
+	}
+
+	r.SetMeta(authn.MetaKeyAuthModule, login.WebAuthnAuthModule)
+
+	return &authn.Identity{
+		ID:              strconv.FormatInt(userID, 10),
+		Type:            claims.TypeUser,
+		OrgID:           r.OrgID,
+		ClientParams:    authn.ClientParams{FetchSyncedUser: true, SyncPermissions: true},
+		AuthenticatedBy: login.WebAuthnAuthModule,
+	}, nil
+}
+
+func (c *WebAuthn) IsEnabled() bool {
+	return true
+}
+
+func (c *WebAuthn) Name() string {
+	return authn.ClientWebAuthn
+}
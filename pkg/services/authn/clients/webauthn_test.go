@@ -0,0 +1,80 @@
+package clients
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	claims "github.com/grafana/authlib/types"
+	"github.com/grafana/grafana/pkg/services/authn"
+	"github.com/grafana/grafana/pkg/services/login"
+	"github.com/grafana/grafana/pkg/services/loginattempt/loginattempttest"
+	"github.com/grafana/grafana/pkg/services/webauthn"
+	"github.com/grafana/grafana/pkg/services/webauthn/webauthntest"
+)
+
+func TestWebAuthn_Authenticate(t *testing.T) {
+	type testCase struct {
+		desc             string
+		blockLogin       bool
+		expectedUserID   int64
+		expectedErr      error
+		expectedIdentity *authn.Identity
+		expectAttempt    bool
+	}
+
+	tests := []testCase{
+		{
+			desc:           "should return identity of the owner of the credential",
+			expectedUserID: 1,
+			expectedIdentity: &authn.Identity{
+				ID:              "1",
+				Type:            claims.TypeUser,
+				OrgID:           1,
+				ClientParams:    authn.ClientParams{FetchSyncedUser: true, SyncPermissions: true},
+				AuthenticatedBy: login.WebAuthnAuthModule,
+			},
+		},
+		{
+			desc:          "should record login attempt for invalid assertion",
+			expectedErr:   webauthn.ErrInvalidCredential.Errorf("invalid signature"),
+			expectAttempt: true,
+		},
+		{
+			desc:        "should not record login attempt for expired challenge",
+			expectedErr: webauthn.ErrChallengeExpired.Errorf("no ceremony in progress"),
+		},
+		{
+			desc:        "should fail if login is blocked for the IP address",
+			blockLogin:  true,
+			expectedErr: errWebAuthnClientTooManyLoginAttempts,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			loginAttempts := &loginattempttest.MockLoginAttemptService{ExpectedValid: !tt.blockLogin}
+			c := ProvideWebAuthn(loginAttempts, &webauthntest.FakeService{ExpectedUserID: tt.expectedUserID, ExpectedErr: tt.expectedErr})
+
+			r := &authn.Request{
+				OrgID: 1,
+				HTTPRequest: &http.Request{
+					Header: map[string][]string{"Content-Type": {"application/json"}},
+					Body:   io.NopCloser(strings.NewReader(`{"id": "AQID", "rawId": "AQID", "type": "public-key", "response": {}}`)),
+				},
+			}
+			identity, err := c.Authenticate(context.Background(), r)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedIdentity, identity)
+			assert.Equal(t, tt.expectAttempt, loginAttempts.AddCalled)
+			if tt.expectedIdentity != nil {
+				require.Equal(t, login.WebAuthnAuthModule, r.GetMeta(authn.MetaKeyAuthModule))
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package caching
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+)
+
+const (
+	queryKeyPrefix    = "query-cache:"
+	resourceKeyPrefix = "resource-cache:"
+)
+
+// volatileQueryFields are removed from the query model before hashing since they differ
+// between otherwise identical requests.
+var volatileQueryFields = []string{"requestId", "queryCachingTTL"}
+
+type normalizedQuery struct {
+	RefID         string         `json:"refId"`
+	QueryType     string         `json:"queryType"`
+	MaxDataPoints int64          `json:"maxDataPoints"`
+	Interval      time.Duration  `json:"interval"`
+	From          int64          `json:"from"`
+	To            int64          `json:"to"`
+	Model         map[string]any `json:"model"`
+}
+
+type normalizedQueryRequest struct {
+	OrgID             int64             `json:"orgId"`
+	PluginID          string            `json:"pluginId"`
+	DataSourceUID     string            `json:"datasourceUid"`
+	DataSourceUpdated int64             `json:"datasourceUpdated"`
+	Queries           []normalizedQuery `json:"queries"`
+}
+
+type normalizedResourceRequest struct {
+	OrgID             int64  `json:"orgId"`
+	PluginID          string `json:"pluginId"`
+	DataSourceUID     string `json:"datasourceUid"`
+	DataSourceUpdated int64  `json:"datasourceUpdated"`
+	Method            string `json:"method"`
+	Path              string `json:"path"`
+	URL               string `json:"url"`
+	Body              []byte `json:"body"`
+}
+
+// queryCacheKey returns a stable key for a QueryDataRequest. The time range of every query is
+// aligned to the query interval (or the TTL when no interval is set), so that relative time
+// ranges such as "now-1h" resolve to the same key for requests issued within the same interval.
+func queryCacheKey(req *backend.QueryDataRequest, ttl time.Duration) (string, error) {
+	normalized := normalizedQueryRequest{
+		OrgID:    req.PluginContext.OrgID,
+		PluginID: req.PluginContext.PluginID,
+		Queries:  make([]normalizedQuery, 0, len(req.Queries)),
+	}
+	if ds := req.PluginContext.DataSourceInstanceSettings; ds != nil {
+		normalized.DataSourceUID = ds.UID
+		normalized.DataSourceUpdated = ds.Updated.UnixMilli()
+	}
+
+	for _, q := range req.Queries {
+		model := map[string]any{}
+		if len(q.JSON) > 0 {
+			if err := json.Unmarshal(q.JSON, &model); err != nil {
+				return "", err
+			}
+		}
+		for _, field := range volatileQueryFields {
+			delete(model, field)
+		}
+
+		alignment := q.Interval
+		if alignment <= 0 {
+			alignment = ttl
+		}
+		if alignment <= 0 {
+			alignment = time.Second
+		}
+
+		normalized.Queries = append(normalized.Queries, normalizedQuery{
+			RefID:         q.RefID,
+			QueryType:     q.QueryType,
+			MaxDataPoints: q.MaxDataPoints,
+			Interval:      q.Interval,
+			From:          q.TimeRange.From.Truncate(alignment).UnixMilli(),
+			To:            q.TimeRange.To.Truncate(alignment).UnixMilli(),
+			Model:         model,
+		})
+	}
+
+	return hashKey(queryKeyPrefix, normalized)
+}
+
+// resourceCacheKey returns a stable key for a CallResourceRequest.
+func resourceCacheKey(req *backend.CallResourceRequest) (string, error) {
+	normalized := normalizedResourceRequest{
+		OrgID:    req.PluginContext.OrgID,
+		PluginID: req.PluginContext.PluginID,
+		Method:   req.Method,
+		Path:     req.Path,
+		URL:      req.URL,
+		Body:     req.Body,
+	}
+	if ds := req.PluginContext.DataSourceInstanceSettings; ds != nil {
+		normalized.DataSourceUID = ds.UID
+		normalized.DataSourceUpdated = ds.Updated.UnixMilli()
+	}
+
+	return hashKey(resourceKeyPrefix, normalized)
+}
+
+func hashKey(prefix string, v any) (string, error) {
+	// encoding/json sorts map keys, which makes the encoding of the query model deterministic
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return prefix + hex.EncodeToString(sum[:]), nil
+}
@@ -2,8 +2,18 @@ package caching
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/remotecache"
+	"github.com/grafana/grafana/pkg/services/contexthandler"
+	"github.com/grafana/grafana/pkg/setting"
 )
 
 const (
@@ -35,8 +45,27 @@ type CachedResourceDataResponse struct {
 	UpdateCacheFn CacheResourceResponseFn
 }
 
-func ProvideCachingService() *OSSCachingService {
-	return &OSSCachingService{}
+func ProvideCachingService(cfg *setting.Cfg, remoteCache remotecache.CacheStorage) (*OSSCachingService, error) {
+	s := &OSSCachingService{
+		settings: cfg.QueryCaching,
+		log:      log.New("caching"),
+	}
+	if !s.settings.Enabled {
+		return s, nil
+	}
+
+	switch s.settings.Backend {
+	case setting.QueryCachingBackendRemote:
+		s.store = remoteCache
+	default:
+		store, err := newMemoryStorage(s.settings.MaxItems)
+		if err != nil {
+			return nil, err
+		}
+		s.store = store
+	}
+
+	return s, nil
 }
 
 type CachingService interface {
@@ -49,16 +78,207 @@ type CachingService interface {
 	HandleResourceRequest(context.Context, *backend.CallResourceRequest) (bool, CachedResourceDataResponse)
 }
 
-// Implementation of interface - does nothing
+// OSSCachingService caches query and resource responses in memory or in the remote cache.
+// When caching is disabled in the configuration it does nothing.
 type OSSCachingService struct {
+	settings setting.QueryCachingSettings
+	store    remotecache.CacheStorage
+	log      log.Logger
 }
 
 func (s *OSSCachingService) HandleQueryRequest(ctx context.Context, req *backend.QueryDataRequest) (bool, CachedQueryDataResponse) {
-	return false, CachedQueryDataResponse{}
+	if s.store == nil || req == nil || req.PluginContext.DataSourceInstanceSettings == nil {
+		return false, CachedQueryDataResponse{}
+	}
+
+	ds := req.PluginContext.DataSourceInstanceSettings
+	if skipCache(ctx) || forwardsIdentity(ds) {
+		setCacheStatus(ctx, StatusBypass)
+		return false, CachedQueryDataResponse{}
+	}
+
+	ttl := s.queryTTL(req)
+	if ttl <= 0 {
+		setCacheStatus(ctx, StatusDisabled)
+		return false, CachedQueryDataResponse{}
+	}
+
+	key, err := queryCacheKey(req, ttl)
+	if err != nil {
+		s.log.FromContext(ctx).Warn("Failed to compute query cache key", "datasource", ds.UID, "error", err)
+		setCacheStatus(ctx, StatusError)
+		return false, CachedQueryDataResponse{}
+	}
+
+	data, err := s.store.Get(ctx, key)
+	switch {
+	case err == nil:
+		resp := &backend.QueryDataResponse{}
+		if err := json.Unmarshal(data, resp); err == nil {
+			setCacheStatus(ctx, StatusHit)
+			return true, CachedQueryDataResponse{Response: resp}
+		}
+		s.log.FromContext(ctx).Warn("Failed to decode cached query response", "datasource", ds.UID, "error", err)
+	case !errors.Is(err, remotecache.ErrCacheItemNotFound):
+		s.log.FromContext(ctx).Warn("Failed to read query response from cache", "datasource", ds.UID, "error", err)
+		setCacheStatus(ctx, StatusError)
+		return false, CachedQueryDataResponse{}
+	}
+
+	setCacheStatus(ctx, StatusMiss)
+	return false, CachedQueryDataResponse{
+		UpdateCacheFn: func(ctx context.Context, resp *backend.QueryDataResponse) {
+			if resp == nil || hasErrors(resp) {
+				return
+			}
+			data, err := json.Marshal(resp)
+			if err != nil {
+				s.log.FromContext(ctx).Warn("Failed to encode query response", "datasource", ds.UID, "error", err)
+				return
+			}
+			if err := s.store.Set(ctx, key, data, ttl); err != nil {
+				s.log.FromContext(ctx).Warn("Failed to write query response to cache", "datasource", ds.UID, "error", err)
+			}
+		},
+	}
 }
 
 func (s *OSSCachingService) HandleResourceRequest(ctx context.Context, req *backend.CallResourceRequest) (bool, CachedResourceDataResponse) {
-	return false, CachedResourceDataResponse{}
+	// Only data source resources are cached. The cache key has no user identity, and app plugins
+	// often return per-user data from their resources.
+	if s.store == nil || req == nil || req.PluginContext.DataSourceInstanceSettings == nil {
+		return false, CachedResourceDataResponse{}
+	}
+
+	ds := req.PluginContext.DataSourceInstanceSettings
+	if skipCache(ctx) || req.Method != http.MethodGet || forwardsIdentity(ds) {
+		setCacheStatus(ctx, StatusBypass)
+		return false, CachedResourceDataResponse{}
+	}
+
+	ttl := s.settings.ResourceTTL
+	if dsTTL, ok := s.settings.DataSourceTTLs[ds.UID]; ok && dsTTL <= 0 {
+		ttl = 0
+	}
+	if ttl <= 0 {
+		setCacheStatus(ctx, StatusDisabled)
+		return false, CachedResourceDataResponse{}
+	}
+
+	key, err := resourceCacheKey(req)
+	if err != nil {
+		s.log.FromContext(ctx).Warn("Failed to compute resource cache key", "plugin", req.PluginContext.PluginID, "error", err)
+		setCacheStatus(ctx, StatusError)
+		return false, CachedResourceDataResponse{}
+	}
+
+	data, err := s.store.Get(ctx, key)
+	switch {
+	case err == nil:
+		resp := &backend.CallResourceResponse{}
+		if err := json.Unmarshal(data, resp); err == nil {
+			setCacheStatus(ctx, StatusHit)
+			return true, CachedResourceDataResponse{Response: resp}
+		}
+		s.log.FromContext(ctx).Warn("Failed to decode cached resource response", "plugin", req.PluginContext.PluginID, "error", err)
+	case !errors.Is(err, remotecache.ErrCacheItemNotFound):
+		s.log.FromContext(ctx).Warn("Failed to read resource response from cache", "plugin", req.PluginContext.PluginID, "error", err)
+		setCacheStatus(ctx, StatusError)
+		return false, CachedResourceDataResponse{}
+	}
+
+	setCacheStatus(ctx, StatusMiss)
+
+	// Streamed responses consist of several chunks; only single chunk responses are cached.
+	var (
+		mu     sync.Mutex
+		chunks int
+	)
+	return false, CachedResourceDataResponse{
+		UpdateCacheFn: func(ctx context.Context, resp *backend.CallResourceResponse) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			chunks++
+			if chunks > 1 {
+				if err := s.store.Delete(ctx, key); err != nil {
+					s.log.FromContext(ctx).Warn("Failed to delete streamed resource response from cache", "plugin", req.PluginContext.PluginID, "error", err)
+				}
+				return
+			}
+			if resp == nil || resp.Status < http.StatusOK || resp.Status >= http.StatusMultipleChoices {
+				return
+			}
+			data, err := json.Marshal(resp)
+			if err != nil {
+				s.log.FromContext(ctx).Warn("Failed to encode resource response", "plugin", req.PluginContext.PluginID, "error", err)
+				return
+			}
+			if err := s.store.Set(ctx, key, data, ttl); err != nil {
+				s.log.FromContext(ctx).Warn("Failed to write resource response to cache", "plugin", req.PluginContext.PluginID, "error", err)
+			}
+		},
+	}
+}
+
+// queryTTL resolves the TTL of a query request. The configured default can be overridden per
+// data source, and panels can lower it further by setting queryCachingTTL (in milliseconds).
+func (s *OSSCachingService) queryTTL(req *backend.QueryDataRequest) time.Duration {
+	ttl := s.settings.TTL
+	if dsTTL, ok := s.settings.DataSourceTTLs[req.PluginContext.DataSourceInstanceSettings.UID]; ok {
+		ttl = dsTTL
+	}
+	if ttl <= 0 {
+		return 0
+	}
+
+	for _, q := range req.Queries {
+		var model struct {
+			QueryCachingTTL int64 `json:"queryCachingTTL"`
+		}
+		if err := json.Unmarshal(q.JSON, &model); err != nil || model.QueryCachingTTL <= 0 {
+			continue
+		}
+		if panelTTL := time.Duration(model.QueryCachingTTL) * time.Millisecond; panelTTL < ttl {
+			ttl = panelTTL
+		}
+	}
+
+	return ttl
+}
+
+func hasErrors(resp *backend.QueryDataResponse) bool {
+	for _, r := range resp.Responses {
+		if r.Error != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardsIdentity reports whether the data source forwards the user's credentials,
+// in which case responses are user specific and must not be shared.
+func forwardsIdentity(ds *backend.DataSourceInstanceSettings) bool {
+	var jsonData struct {
+		OAuthPassThru bool `json:"oauthPassThru"`
+	}
+	if err := json.Unmarshal(ds.JSONData, &jsonData); err != nil {
+		return false
+	}
+	return jsonData.OAuthPassThru
+}
+
+func skipCache(ctx context.Context) bool {
+	reqCtx := contexthandler.FromContext(ctx)
+	return reqCtx != nil && reqCtx.SkipQueryCache
+}
+
+func setCacheStatus(ctx context.Context, status string) {
+	reqCtx := contexthandler.FromContext(ctx)
+	if reqCtx == nil || reqCtx.Resp == nil {
+		return
+	}
+	reqCtx.Resp.Header().Set(XCacheHeader, status)
 }
 
 var _ CachingService = &OSSCachingService{}
@@ -0,0 +1,292 @@
+package caching
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/contexthandler"
+	"github.com/grafana/grafana/pkg/services/contexthandler/ctxkey"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func TestOSSCachingService_HandleQueryRequest(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 30, 0, time.UTC)
+
+	newRequest := func(uid string, from time.Time, model string) *backend.QueryDataRequest {
+		return &backend.QueryDataRequest{
+			PluginContext: backend.PluginContext{
+				OrgID:    1,
+				PluginID: "prometheus",
+				DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{
+					UID:      uid,
+					JSONData: []byte(`{}`),
+				},
+			},
+			Queries: []backend.DataQuery{{
+				RefID:     "A",
+				Interval:  time.Minute,
+				TimeRange: backend.TimeRange{From: from.Add(-time.Hour), To: from},
+				JSON:      []byte(model),
+			}},
+		}
+	}
+
+	response := &backend.QueryDataResponse{Responses: backend.Responses{
+		"A": {Frames: data.Frames{data.NewFrame("A", data.NewField("value", nil, []float64{1, 2, 3}))}},
+	}}
+
+	t.Run("returns a miss and then a hit for an equivalent request", func(t *testing.T) {
+		s := newTestService(t, setting.QueryCachingSettings{})
+
+		ctx, rec := newReqContext(t)
+		hit, cr := s.HandleQueryRequest(ctx, newRequest("ds1", now, `{"expr":"up","requestId":"1"}`))
+		require.False(t, hit)
+		require.NotNil(t, cr.UpdateCacheFn)
+		assert.Equal(t, StatusMiss, rec.Header().Get(XCacheHeader))
+		cr.UpdateCacheFn(ctx, response)
+
+		// Different request id and a time range within the same interval resolves to the same key
+		ctx, rec = newReqContext(t)
+		hit, cr = s.HandleQueryRequest(ctx, newRequest("ds1", now.Add(10*time.Second), `{"requestId":"2","expr":"up"}`))
+		require.True(t, hit)
+		assert.Equal(t, StatusHit, rec.Header().Get(XCacheHeader))
+		require.Contains(t, cr.Response.Responses, "A")
+		assert.Equal(t, 3, cr.Response.Responses["A"].Frames[0].Rows())
+	})
+
+	t.Run("a different query or interval is a miss", func(t *testing.T) {
+		s := newTestService(t, setting.QueryCachingSettings{})
+
+		ctx, _ := newReqContext(t)
+		_, cr := s.HandleQueryRequest(ctx, newRequest("ds1", now, `{"expr":"up"}`))
+		cr.UpdateCacheFn(ctx, response)
+
+		hit, _ := s.HandleQueryRequest(ctx, newRequest("ds1", now, `{"expr":"down"}`))
+		assert.False(t, hit)
+		hit, _ = s.HandleQueryRequest(ctx, newRequest("ds1", now.Add(time.Minute), `{"expr":"up"}`))
+		assert.False(t, hit)
+		hit, _ = s.HandleQueryRequest(ctx, newRequest("ds2", now, `{"expr":"up"}`))
+		assert.False(t, hit)
+	})
+
+	t.Run("responses with errors are not cached", func(t *testing.T) {
+		s := newTestService(t, setting.QueryCachingSettings{})
+
+		ctx, _ := newReqContext(t)
+		_, cr := s.HandleQueryRequest(ctx, newRequest("ds1", now, `{"expr":"up"}`))
+		cr.UpdateCacheFn(ctx, &backend.QueryDataResponse{Responses: backend.Responses{
+			"A": backend.ErrDataResponse(backend.StatusBadRequest, "bad query"),
+		}})
+
+		hit, _ := s.HandleQueryRequest(ctx, newRequest("ds1", now, `{"expr":"up"}`))
+		assert.False(t, hit)
+	})
+
+	t.Run("entries expire after the data source TTL", func(t *testing.T) {
+		s := newTestService(t, setting.QueryCachingSettings{
+			DataSourceTTLs: map[string]time.Duration{"ds1": 10 * time.Second},
+		})
+		clock := now
+		s.store.(*memoryStorage).now = func() time.Time { return clock }
+
+		ctx, _ := newReqContext(t)
+		_, cr := s.HandleQueryRequest(ctx, newRequest("ds1", now, `{"expr":"up"}`))
+		cr.UpdateCacheFn(ctx, response)
+
+		clock = clock.Add(5 * time.Second)
+		hit, _ := s.HandleQueryRequest(ctx, newRequest("ds1", now, `{"expr":"up"}`))
+		assert.True(t, hit)
+
+		clock = clock.Add(10 * time.Second)
+		hit, _ = s.HandleQueryRequest(ctx, newRequest("ds1", now, `{"expr":"up"}`))
+		assert.False(t, hit)
+	})
+
+	t.Run("a zero data source TTL disables caching", func(t *testing.T) {
+		s := newTestService(t, setting.QueryCachingSettings{
+			DataSourceTTLs: map[string]time.Duration{"ds1": 0},
+		})
+
+		ctx, rec := newReqContext(t)
+		hit, cr := s.HandleQueryRequest(ctx, newRequest("ds1", now, `{"expr":"up"}`))
+		assert.False(t, hit)
+		assert.Nil(t, cr.UpdateCacheFn)
+		assert.Equal(t, StatusDisabled, rec.Header().Get(XCacheHeader))
+	})
+
+	t.Run("bypasses the cache when requested or when the data source forwards identity", func(t *testing.T) {
+		s := newTestService(t, setting.QueryCachingSettings{})
+
+		ctx, rec := newReqContext(t)
+		contexthandler.FromContext(ctx).SkipQueryCache = true
+		hit, cr := s.HandleQueryRequest(ctx, newRequest("ds1", now, `{"expr":"up"}`))
+		assert.False(t, hit)
+		assert.Nil(t, cr.UpdateCacheFn)
+		assert.Equal(t, StatusBypass, rec.Header().Get(XCacheHeader))
+
+		ctx, rec = newReqContext(t)
+		req := newRequest("ds1", now, `{"expr":"up"}`)
+		req.PluginContext.DataSourceInstanceSettings.JSONData = []byte(`{"oauthPassThru":true}`)
+		hit, cr = s.HandleQueryRequest(ctx, req)
+		assert.False(t, hit)
+		assert.Nil(t, cr.UpdateCacheFn)
+		assert.Equal(t, StatusBypass, rec.Header().Get(XCacheHeader))
+	})
+
+	t.Run("does nothing when disabled", func(t *testing.T) {
+		s, err := ProvideCachingService(&setting.Cfg{}, nil)
+		require.NoError(t, err)
+
+		ctx, rec := newReqContext(t)
+		hit, cr := s.HandleQueryRequest(ctx, newRequest("ds1", now, `{"expr":"up"}`))
+		assert.False(t, hit)
+		assert.Nil(t, cr.UpdateCacheFn)
+		assert.Empty(t, rec.Header().Get(XCacheHeader))
+	})
+}
+
+func TestOSSCachingService_HandleResourceRequest(t *testing.T) {
+	newRequest := func(method string) *backend.CallResourceRequest {
+		return &backend.CallResourceRequest{
+			PluginContext: backend.PluginContext{
+				OrgID:                      1,
+				PluginID:                   "prometheus",
+				DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{UID: "ds1", JSONData: []byte(`{}`)},
+			},
+			Method: method,
+			Path:   "api/v1/labels",
+			URL:    "api/v1/labels?match[]=up",
+		}
+	}
+
+	t.Run("caches single chunk GET responses", func(t *testing.T) {
+		s := newTestService(t, setting.QueryCachingSettings{})
+
+		ctx, _ := newReqContext(t)
+		hit, cr := s.HandleResourceRequest(ctx, newRequest(http.MethodGet))
+		require.False(t, hit)
+		require.NotNil(t, cr.UpdateCacheFn)
+		cr.UpdateCacheFn(ctx, &backend.CallResourceResponse{Status: http.StatusOK, Body: []byte(`["job"]`)})
+
+		ctx, rec := newReqContext(t)
+		hit, cr = s.HandleResourceRequest(ctx, newRequest(http.MethodGet))
+		require.True(t, hit)
+		assert.Equal(t, StatusHit, rec.Header().Get(XCacheHeader))
+		assert.Equal(t, []byte(`["job"]`), cr.Response.Body)
+	})
+
+	t.Run("does not cache streamed or failed responses", func(t *testing.T) {
+		s := newTestService(t, setting.QueryCachingSettings{})
+
+		ctx, _ := newReqContext(t)
+		_, cr := s.HandleResourceRequest(ctx, newRequest(http.MethodGet))
+		cr.UpdateCacheFn(ctx, &backend.CallResourceResponse{Status: http.StatusOK, Body: []byte(`a`)})
+		cr.UpdateCacheFn(ctx, &backend.CallResourceResponse{Status: http.StatusOK, Body: []byte(`b`)})
+		hit, cr := s.HandleResourceRequest(ctx, newRequest(http.MethodGet))
+		assert.False(t, hit)
+
+		cr.UpdateCacheFn(ctx, &backend.CallResourceResponse{Status: http.StatusInternalServerError})
+		hit, _ = s.HandleResourceRequest(ctx, newRequest(http.MethodGet))
+		assert.False(t, hit)
+	})
+
+	t.Run("does not cache app plugin resources", func(t *testing.T) {
+		s := newTestService(t, setting.QueryCachingSettings{})
+
+		req := newRequest(http.MethodGet)
+		req.PluginContext.DataSourceInstanceSettings = nil
+		req.PluginContext.AppInstanceSettings = &backend.AppInstanceSettings{}
+		ctx, rec := newReqContext(t)
+		hit, cr := s.HandleResourceRequest(ctx, req)
+		assert.False(t, hit)
+		assert.Nil(t, cr.UpdateCacheFn)
+		assert.Empty(t, rec.Header().Get(XCacheHeader))
+	})
+
+	t.Run("updating the data source is a miss", func(t *testing.T) {
+		s := newTestService(t, setting.QueryCachingSettings{})
+
+		ctx, _ := newReqContext(t)
+		_, cr := s.HandleResourceRequest(ctx, newRequest(http.MethodGet))
+		cr.UpdateCacheFn(ctx, &backend.CallResourceResponse{Status: http.StatusOK, Body: []byte(`["job"]`)})
+
+		req := newRequest(http.MethodGet)
+		req.PluginContext.DataSourceInstanceSettings.Updated = time.Now()
+		hit, _ := s.HandleResourceRequest(ctx, req)
+		assert.False(t, hit)
+	})
+
+	t.Run("bypasses non GET requests", func(t *testing.T) {
+		s := newTestService(t, setting.QueryCachingSettings{})
+
+		ctx, rec := newReqContext(t)
+		hit, cr := s.HandleResourceRequest(ctx, newRequest(http.MethodPost))
+		assert.False(t, hit)
+		assert.Nil(t, cr.UpdateCacheFn)
+		assert.Equal(t, StatusBypass, rec.Header().Get(XCacheHeader))
+	})
+}
+
+func TestQueryCacheKey(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	req := func(model string) *backend.QueryDataRequest {
+		return &backend.QueryDataRequest{
+			PluginContext: backend.PluginContext{DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{UID: "ds1"}},
+			Queries:       []backend.DataQuery{{RefID: "A", TimeRange: backend.TimeRange{From: now.Add(-time.Hour), To: now}, JSON: []byte(model)}},
+		}
+	}
+
+	a, err := queryCacheKey(req(`{"expr":"up","step":"15s"}`), time.Minute)
+	require.NoError(t, err)
+	b, err := queryCacheKey(req(`{"step":"15s","expr":"up","queryCachingTTL":1000}`), time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	_, err = queryCacheKey(req(`not json`), time.Minute)
+	assert.Error(t, err)
+}
+
+func newTestService(t *testing.T, settings setting.QueryCachingSettings) *OSSCachingService {
+	t.Helper()
+
+	settings.Enabled = true
+	settings.Backend = setting.QueryCachingBackendMemory
+	if settings.MaxItems == 0 {
+		settings.MaxItems = 100
+	}
+	if settings.TTL == 0 {
+		settings.TTL = time.Minute
+	}
+	if settings.ResourceTTL == 0 {
+		settings.ResourceTTL = time.Minute
+	}
+
+	s, err := ProvideCachingService(&setting.Cfg{QueryCaching: settings}, nil)
+	require.NoError(t, err)
+	return s
+}
+
+func newReqContext(t *testing.T) (context.Context, *httptest.ResponseRecorder) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, "/api/ds/query", nil)
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	reqContext := &contextmodel.ReqContext{
+		Context: &web.Context{
+			Req:  req,
+			Resp: web.NewResponseWriter(req.Method, rec),
+		},
+	}
+	return ctxkey.Set(context.Background(), reqContext), rec
+}
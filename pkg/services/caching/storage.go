@@ -0,0 +1,53 @@
+package caching
+
+import (
+	"context"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/grafana/grafana/pkg/infra/remotecache"
+)
+
+type memoryEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// memoryStorage is an in-process, size bounded LRU cache that honours per-item expiry.
+type memoryStorage struct {
+	cache *lru.Cache[string, memoryEntry]
+	now   func() time.Time
+}
+
+func newMemoryStorage(maxItems int) (*memoryStorage, error) {
+	cache, err := lru.New[string, memoryEntry](maxItems)
+	if err != nil {
+		return nil, err
+	}
+	return &memoryStorage{cache: cache, now: time.Now}, nil
+}
+
+func (s *memoryStorage) Get(_ context.Context, key string) ([]byte, error) {
+	entry, ok := s.cache.Get(key)
+	if !ok {
+		return nil, remotecache.ErrCacheItemNotFound
+	}
+	if !s.now().Before(entry.expires) {
+		s.cache.Remove(key)
+		return nil, remotecache.ErrCacheItemNotFound
+	}
+	return entry.value, nil
+}
+
+func (s *memoryStorage) Set(_ context.Context, key string, value []byte, expire time.Duration) error {
+	s.cache.Add(key, memoryEntry{value: value, expires: s.now().Add(expire)})
+	return nil
+}
+
+func (s *memoryStorage) Delete(_ context.Context, key string) error {
+	s.cache.Remove(key)
+	return nil
+}
+
+var _ remotecache.CacheStorage = &memoryStorage{}
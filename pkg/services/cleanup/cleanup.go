@@ -31,6 +31,14 @@ type AlertRuleService interface {
 	CleanUpDeletedAlertRules(ctx context.Context) (int64, error)
 }
 
+type AlertNotificationHistoryService interface {
+	CleanUpNotificationHistory(ctx context.Context) (int64, error)
+}
+
+type AlertStateHistoryService interface {
+	CleanUpStateHistory(ctx context.Context) (int64, error)
+}
+
 type CleanUpService struct {
 	log                       log.Logger
 	tracer                    tracing.Tracer
@@ -46,12 +54,15 @@ type CleanUpService struct {
 	annotationCleaner         annotations.Cleaner
 	dashboardService          dashboards.DashboardService
 	alertRuleService          AlertRuleService
+	notificationHistory       AlertNotificationHistoryService
+	stateHistory              AlertStateHistoryService
 }
 
 func ProvideService(cfg *setting.Cfg, serverLockService *serverlock.ServerLockService,
 	shortURLService shorturls.Service, sqlstore db.DB, queryHistoryService queryhistory.Service,
 	dashboardVersionService dashver.Service, dashSnapSvc dashboardsnapshots.Service, deleteExpiredImageService *image.DeleteExpiredService,
-	tempUserService tempuser.Service, tracer tracing.Tracer, annotationCleaner annotations.Cleaner, dashboardService dashboards.DashboardService, service AlertRuleService) *CleanUpService {
+	tempUserService tempuser.Service, tracer tracing.Tracer, annotationCleaner annotations.Cleaner, dashboardService dashboards.DashboardService, service AlertRuleService,
+	notificationHistory AlertNotificationHistoryService, stateHistory AlertStateHistoryService) *CleanUpService {
 	s := &CleanUpService{
 		Cfg:                       cfg,
 		ServerLockService:         serverLockService,
@@ -67,6 +78,8 @@ func ProvideService(cfg *setting.Cfg, serverLockService *serverlock.ServerLockSe
 		annotationCleaner:         annotationCleaner,
 		dashboardService:          dashboardService,
 		alertRuleService:          service,
+		notificationHistory:       notificationHistory,
+		stateHistory:              stateHistory,
 	}
 	return s
 }
@@ -121,6 +134,14 @@ func (srv *CleanUpService) clean(ctx context.Context) {
 		cleanupJobs = append(cleanupJobs, cleanUpJob{"cleanup trash alert rules", srv.cleanUpTrashAlertRules})
 	}
 
+	if srv.Cfg.UnifiedAlerting.NotificationHistory.Enabled && srv.Cfg.UnifiedAlerting.NotificationHistory.Retention > 0 {
+		cleanupJobs = append(cleanupJobs, cleanUpJob{"cleanup alert notification history", srv.cleanUpNotificationHistory})
+	}
+
+	if srv.Cfg.UnifiedAlerting.StateHistory.Enabled && srv.Cfg.UnifiedAlerting.StateHistory.SQLRetention > 0 {
+		cleanupJobs = append(cleanupJobs, cleanUpJob{"cleanup alert state history", srv.cleanUpStateHistory})
+	}
+
 	logger := srv.log.FromContext(ctx)
 	logger.Debug("Starting cleanup jobs", "jobs", fmt.Sprintf("%v", cleanupJobs))
 
@@ -322,3 +343,23 @@ func (srv *CleanUpService) cleanUpTrashAlertRules(ctx context.Context) {
 		logger.Debug("Cleaned up deleted alert rules", "rows affected", affected)
 	}
 }
+
+func (srv *CleanUpService) cleanUpNotificationHistory(ctx context.Context) {
+	logger := srv.log.FromContext(ctx)
+	affected, err := srv.notificationHistory.CleanUpNotificationHistory(ctx)
+	if err != nil {
+		logger.Error("Problem cleaning up alert notification history", "error", err)
+	} else {
+		logger.Debug("Cleaned up alert notification history", "rows affected", affected)
+	}
+}
+
+func (srv *CleanUpService) cleanUpStateHistory(ctx context.Context) {
+	logger := srv.log.FromContext(ctx)
+	affected, err := srv.stateHistory.CleanUpStateHistory(ctx)
+	if err != nil {
+		logger.Error("Problem cleaning up alert state history", "error", err)
+	} else {
+		logger.Debug("Cleaned up alert state history", "rows affected", affected)
+	}
+}
@@ -0,0 +1,254 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const defaultRegion = "us-east-1"
+
+// MaxObjectSize is the largest object GetObject reads, the same as the maximum size of provisioned files.
+const MaxObjectSize = 10 * 1024 * 1024 // 10MB
+
+var (
+	// ErrObjectNotFound is returned when the requested key does not exist in the bucket.
+	ErrObjectNotFound = errors.New("object not found")
+	// ErrObjectTooLarge is returned when the requested object is larger than MaxObjectSize.
+	ErrObjectTooLarge = errors.New("object exceeds maximum allowed size")
+)
+
+// BucketConfig identifies a bucket of an S3-compatible service and the credentials used to access it.
+// Objects are addressed with path-style URLs: <endpoint>/<bucket>/<key>.
+type BucketConfig struct {
+	Endpoint string
+	Bucket   string
+	// Region used to sign requests. Defaults to us-east-1.
+	Region string
+	// When AccessKeyID is empty, requests are sent unsigned.
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// ObjectInfo describes an object stored in a bucket.
+type ObjectInfo struct {
+	Key string
+	// ETag of the object, without the surrounding quotes.
+	ETag         string
+	Size         int64
+	LastModified time.Time
+}
+
+// GetObject returns the content of the object stored at key.
+func (s3 *S3) GetObject(ctx context.Context, cfg BucketConfig, key string) (_ []byte, _ ObjectInfo, err error) {
+	ctx, span := s3.tracer.Start(ctx, "objectstorage.S3.GetObject")
+	span.SetAttributes(attribute.String("bucket", cfg.Bucket), attribute.String("key", key))
+	defer span.End()
+
+	response, err := s3.do(ctx, cfg, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	defer func() {
+		if closeErr := response.Body.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("closing response body: %w", closeErr))
+		}
+	}()
+
+	// read one byte past the limit to tell a large object from one of exactly MaxObjectSize
+	data, err := io.ReadAll(io.LimitReader(response.Body, MaxObjectSize+1))
+	if err != nil {
+		return nil, ObjectInfo{}, fmt.Errorf("reading object: %w", err)
+	}
+	if len(data) > MaxObjectSize {
+		return nil, ObjectInfo{}, ErrObjectTooLarge
+	}
+
+	info := ObjectInfo{
+		Key:  key,
+		ETag: trimETag(response.Header.Get("ETag")),
+		Size: int64(len(data)),
+	}
+	if modified, err := http.ParseTime(response.Header.Get("Last-Modified")); err == nil {
+		info.LastModified = modified
+	}
+
+	return data, info, nil
+}
+
+// PutObject creates or replaces the object stored at key.
+func (s3 *S3) PutObject(ctx context.Context, cfg BucketConfig, key string, data []byte) error {
+	ctx, span := s3.tracer.Start(ctx, "objectstorage.S3.PutObject")
+	span.SetAttributes(attribute.String("bucket", cfg.Bucket), attribute.String("key", key))
+	defer span.End()
+
+	response, err := s3.do(ctx, cfg, http.MethodPut, key, nil, data)
+	if err != nil {
+		return err
+	}
+
+	return response.Body.Close()
+}
+
+// DeleteObject removes the object stored at key. Deleting a missing key is not an error.
+func (s3 *S3) DeleteObject(ctx context.Context, cfg BucketConfig, key string) error {
+	ctx, span := s3.tracer.Start(ctx, "objectstorage.S3.DeleteObject")
+	span.SetAttributes(attribute.String("bucket", cfg.Bucket), attribute.String("key", key))
+	defer span.End()
+
+	response, err := s3.do(ctx, cfg, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return response.Body.Close()
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		ETag         string    `xml:"ETag"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// ListObjects returns every object whose key starts with prefix, following continuation tokens.
+func (s3 *S3) ListObjects(ctx context.Context, cfg BucketConfig, prefix string) ([]ObjectInfo, error) {
+	ctx, span := s3.tracer.Start(ctx, "objectstorage.S3.ListObjects")
+	span.SetAttributes(attribute.String("bucket", cfg.Bucket), attribute.String("prefix", prefix))
+	defer span.End()
+
+	objects := []ObjectInfo{}
+	token := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		if prefix != "" {
+			query.Set("prefix", prefix)
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		page, err := s3.listPage(ctx, cfg, query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range page.Contents {
+			objects = append(objects, ObjectInfo{
+				Key:          c.Key,
+				ETag:         trimETag(c.ETag),
+				Size:         c.Size,
+				LastModified: c.LastModified,
+			})
+		}
+
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+func (s3 *S3) listPage(ctx context.Context, cfg BucketConfig, query url.Values) (_ *listBucketResult, err error) {
+	response, err := s3.do(ctx, cfg, http.MethodGet, "", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := response.Body.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("closing response body: %w", closeErr))
+		}
+	}()
+
+	result := &listBucketResult{}
+	if err := xml.NewDecoder(response.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("decoding list response: %w", err)
+	}
+
+	return result, nil
+}
+
+// do sends the request and returns the response when its status is successful.
+// The caller is responsible for closing the response body.
+func (s3 *S3) do(ctx context.Context, cfg BucketConfig, method, key string, query url.Values, body []byte) (*http.Response, error) {
+	endpoint, err := url.JoinPath(cfg.Endpoint, cfg.Bucket, key)
+	if err != nil {
+		return nil, fmt.Errorf("building object url: %w", err)
+	}
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating http request: %w", err)
+	}
+	request.ContentLength = int64(len(body))
+
+	if err := sign(ctx, cfg, request, body); err != nil {
+		return nil, fmt.Errorf("signing http request: %w", err)
+	}
+
+	response, err := s3.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("sending http request: %w", err)
+	}
+
+	if response.StatusCode == http.StatusNotFound && key != "" {
+		_ = response.Body.Close()
+		return nil, ErrObjectNotFound
+	}
+
+	if response.StatusCode >= 400 {
+		responseBody, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		_ = response.Body.Close()
+		return nil, fmt.Errorf("unexpected response: status=%d body=%s", response.StatusCode, string(responseBody))
+	}
+
+	return response, nil
+}
+
+// sign adds an AWS Signature Version 4 to the request, unless no access key is configured.
+func sign(ctx context.Context, cfg BucketConfig, request *http.Request, body []byte) error {
+	if cfg.AccessKeyID == "" {
+		return nil
+	}
+
+	region := cfg.Region
+	if region == "" {
+		region = defaultRegion
+	}
+
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	credentials := aws.Credentials{
+		AccessKeyID:     cfg.AccessKeyID,
+		SecretAccessKey: cfg.SecretAccessKey,
+	}
+
+	return v4.NewSigner().SignHTTP(ctx, credentials, request, payloadHash, "s3", region, time.Now())
+}
+
+func trimETag(etag string) string {
+	return strings.Trim(etag, `"`)
+}
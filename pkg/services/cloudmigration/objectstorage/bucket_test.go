@@ -0,0 +1,99 @@
+package objectstorage
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/cloudmigration/objectstorage/objectstoragetest"
+)
+
+func TestBucketOperations(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	server := objectstoragetest.NewServer("dashboards")
+	t.Cleanup(server.Close)
+
+	s3 := NewS3(http.DefaultClient, tracing.NewNoopTracerService())
+	cfg := BucketConfig{Endpoint: server.URL, Bucket: "dashboards"}
+
+	require.NoError(t, s3.PutObject(ctx, cfg, "grafana/a.json", []byte(`{"a":1}`)))
+	require.NoError(t, s3.PutObject(ctx, cfg, "grafana/nested/b.json", []byte(`{"b":2}`)))
+	require.NoError(t, s3.PutObject(ctx, cfg, "other/c.json", []byte(`{"c":3}`)))
+
+	data, info, err := s3.GetObject(ctx, cfg, "grafana/a.json")
+	require.NoError(t, err)
+	require.Equal(t, `{"a":1}`, string(data))
+	require.NotEmpty(t, info.ETag)
+	require.NotContains(t, info.ETag, `"`)
+	require.False(t, info.LastModified.IsZero())
+
+	objects, err := s3.ListObjects(ctx, cfg, "grafana/")
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+	require.Equal(t, "grafana/a.json", objects[0].Key)
+	require.Equal(t, info.ETag, objects[0].ETag)
+	require.Equal(t, "grafana/nested/b.json", objects[1].Key)
+
+	require.NoError(t, s3.PutObject(ctx, cfg, "grafana/a.json", []byte(`{"a":2}`)))
+	_, updated, err := s3.GetObject(ctx, cfg, "grafana/a.json")
+	require.NoError(t, err)
+	require.NotEqual(t, info.ETag, updated.ETag, "the etag should change with the content")
+
+	require.NoError(t, s3.DeleteObject(ctx, cfg, "grafana/a.json"))
+	_, _, err = s3.GetObject(ctx, cfg, "grafana/a.json")
+	require.ErrorIs(t, err, ErrObjectNotFound)
+
+	require.NoError(t, s3.PutObject(ctx, cfg, "grafana/large.json", make([]byte, MaxObjectSize+1)))
+	_, _, err = s3.GetObject(ctx, cfg, "grafana/large.json")
+	require.ErrorIs(t, err, ErrObjectTooLarge)
+
+	for _, auth := range server.Requests {
+		require.Empty(t, auth, "requests without an access key should be unsigned")
+	}
+}
+
+func TestListObjectsPagination(t *testing.T) {
+	t.Parallel()
+
+	server := objectstoragetest.NewServer("bucket")
+	t.Cleanup(server.Close)
+	server.PageSize = 2
+	for _, key := range []string{"a.json", "b.json", "c.json", "d.json", "e.json"} {
+		server.Put("bucket", key, []byte(key))
+	}
+
+	s3 := NewS3(http.DefaultClient, tracing.NewNoopTracerService())
+	objects, err := s3.ListObjects(context.Background(), BucketConfig{Endpoint: server.URL, Bucket: "bucket"}, "")
+	require.NoError(t, err)
+	require.Len(t, objects, 5)
+	require.Equal(t, "e.json", objects[4].Key)
+	require.Len(t, server.Requests, 3)
+}
+
+func TestSignedRequests(t *testing.T) {
+	t.Parallel()
+
+	server := objectstoragetest.NewServer("bucket")
+	t.Cleanup(server.Close)
+
+	s3 := NewS3(http.DefaultClient, tracing.NewNoopTracerService())
+	cfg := BucketConfig{
+		Endpoint:        server.URL,
+		Bucket:          "bucket",
+		Region:          "eu-west-1",
+		AccessKeyID:     "access-key",
+		SecretAccessKey: "secret-key",
+	}
+
+	require.NoError(t, s3.PutObject(context.Background(), cfg, "a.json", []byte("{}")))
+	require.Len(t, server.Requests, 1)
+	require.True(t, strings.HasPrefix(server.Requests[0], "AWS4-HMAC-SHA256 Credential=access-key/"))
+	require.Contains(t, server.Requests[0], "/eu-west-1/s3/aws4_request")
+}
@@ -0,0 +1,169 @@
+// Package objectstoragetest provides an in-memory, MinIO-style fake of the S3 API for tests.
+package objectstoragetest
+
+import (
+	"crypto/md5" //nolint:gosec // ETags of S3 single-part uploads are MD5 digests
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type object struct {
+	data     []byte
+	etag     string
+	modified time.Time
+}
+
+// Server is a fake S3-compatible service supporting path-style GetObject, PutObject, DeleteObject and ListObjectsV2.
+type Server struct {
+	*httptest.Server
+
+	// PageSize limits the number of keys returned by each ListObjectsV2 call. Defaults to 1000.
+	PageSize int
+	// Requests holds the Authorization header of every request received, in order.
+	Requests []string
+
+	mu      sync.Mutex
+	buckets map[string]map[string]object
+}
+
+// NewServer starts a fake with the given buckets already created.
+// The caller must call Close when finished.
+func NewServer(buckets ...string) *Server {
+	s := &Server{
+		PageSize: 1000,
+		buckets:  map[string]map[string]object{},
+	}
+	for _, b := range buckets {
+		s.buckets[b] = map[string]object{}
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Put stores an object directly, bypassing the HTTP API.
+func (s *Server) Put(bucket, key string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.put(bucket, key, data)
+}
+
+// Get returns the object stored at key, and whether it exists.
+func (s *Server) Get(bucket, key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.buckets[bucket][key]
+	return obj.data, ok
+}
+
+func (s *Server) put(bucket, key string, data []byte) {
+	sum := md5.Sum(data) //nolint:gosec
+	s.buckets[bucket][key] = object{
+		data:     data,
+		etag:     hex.EncodeToString(sum[:]),
+		modified: time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Requests = append(s.Requests, r.Header.Get("Authorization"))
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	objects, ok := s.buckets[bucket]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	switch {
+	case key == "" && r.Method == http.MethodGet:
+		s.list(w, r, objects)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		obj, ok := objects[key]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("ETag", `"`+obj.etag+`"`)
+		w.Header().Set("Last-Modified", obj.modified.Format(http.TimeFormat))
+		w.Header().Set("Content-Length", strconv.Itoa(len(obj.data)))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(obj.data)
+		}
+	case r.Method == http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		s.put(bucket, key, data)
+		w.Header().Set("ETag", `"`+objects[key].etag+`"`)
+	case r.Method == http.MethodDelete:
+		delete(objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+type listContents struct {
+	Key          string `xml:"Key"`
+	ETag         string `xml:"ETag"`
+	Size         int    `xml:"Size"`
+	LastModified string `xml:"LastModified"`
+}
+
+type listResult struct {
+	XMLName               xml.Name       `xml:"ListBucketResult"`
+	Contents              []listContents `xml:"Contents"`
+	IsTruncated           bool           `xml:"IsTruncated"`
+	NextContinuationToken string         `xml:"NextContinuationToken,omitempty"`
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request, objects map[string]object) {
+	prefix := r.URL.Query().Get("prefix")
+	token := r.URL.Query().Get("continuation-token")
+
+	keys := make([]string, 0, len(objects))
+	for k := range objects {
+		if strings.HasPrefix(k, prefix) && k > token {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	result := listResult{}
+	if len(keys) > s.PageSize {
+		keys = keys[:s.PageSize]
+		result.IsTruncated = true
+		result.NextContinuationToken = keys[len(keys)-1]
+	}
+	for _, k := range keys {
+		obj := objects[k]
+		result.Contents = append(result.Contents, listContents{
+			Key:          k,
+			ETag:         `"` + obj.etag + `"`,
+			Size:         len(obj.data),
+			LastModified: obj.modified.Format(time.RFC3339),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte("<Error><Code>" + code + "</Code></Error>"))
+}
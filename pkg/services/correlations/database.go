@@ -15,8 +15,15 @@ const VALID_TYPE_FILTER = "(correlation.type = 'external' OR (correlation.type =
 
 // createCorrelation adds a correlation
 func (s CorrelationsService) createCorrelation(ctx context.Context, cmd CreateCorrelationCommand) (Correlation, error) {
+	uid := cmd.UID
+	if uid == "" {
+		uid = util.GenerateShortUID()
+	} else if err := util.ValidateUID(uid); err != nil {
+		return Correlation{}, err
+	}
+
 	correlation := Correlation{
-		UID:         util.GenerateShortUID(),
+		UID:         uid,
 		OrgID:       cmd.OrgId,
 		SourceUID:   cmd.SourceUID,
 		TargetUID:   cmd.TargetUID,
@@ -143,6 +150,16 @@ func (s CorrelationsService) updateCorrelation(ctx context.Context, cmd UpdateCo
 		if cmd.Type != nil {
 			correlation.Type = *cmd.Type
 		}
+		if cmd.TargetUID != nil {
+			if _, err = s.DataSourceService.GetDataSource(ctx, &datasources.GetDataSourceQuery{
+				OrgID: cmd.OrgId,
+				UID:   *cmd.TargetUID,
+			}); err != nil {
+				return ErrTargetDataSourceDoesNotExists
+			}
+			correlation.TargetUID = cmd.TargetUID
+			session.MustCols("target_uid")
+		}
 		if cmd.Config != nil {
 			session.MustCols("config")
 			if cmd.Config.Field != nil {
@@ -18,6 +18,12 @@ var (
 	ErrInvalidTransformationType     = errors.New("invalid transformation type")
 	ErrTransformationNotNested       = errors.New("transformations must be nested under config")
 	ErrTransformationRegexReqExp     = errors.New("regex transformations require expression")
+	ErrTransformationJSONPathReqExp  = errors.New("jsonpath transformations require expression")
+	ErrTransformationInvalidJSONPath = errors.New("invalid jsonpath expression")
+	ErrTransformationLabelReqExp     = errors.New("label transformations require expression")
+	ErrTransformationTemplateReqExp  = errors.New("template transformations require expression")
+	ErrTransformationTemplateReqMap  = errors.New("template transformations require mapValue")
+	ErrTransformationInvalidTemplate = errors.New("invalid template expression")
 	ErrCorrelationsQuotaFailed       = errors.New("error getting correlations quota")
 	ErrCorrelationsQuotaReached      = errors.New("correlations quota reached")
 	ErrInvalidConfigType             = errors.New("correlation contains non default value in config.type")
@@ -37,8 +43,19 @@ const (
 	external CorrelationType = "external"
 )
 
+// Transformation extracts variables from the value of a source field, which are used in the target query.
+//
+//   - regex: matches Expression against the field value. The first capture group is stored in the
+//     variable named MapValue, or named after the field. Named capture groups are stored by name.
+//   - logfmt: parses the field value as logfmt and stores every key/value pair.
+//   - jsonpath: parses the field value as JSON and stores the value at the JSONPath Expression,
+//     for example $.resource.service, in the variable named MapValue, or named after the field.
+//   - label: stores the value of the field label named Expression, for example service.name,
+//     in the variable named MapValue, or named after the label.
+//   - template: interpolates the variables referenced as ${name} in Expression, which were
+//     extracted by the preceding transformations, and stores the result in the variable named MapValue.
 type Transformation struct {
-	//Enum: regex,logfmt
+	//Enum: regex,logfmt,jsonpath,label,template
 	Type       string `json:"type"`
 	Expression string `json:"expression,omitempty"`
 	Field      string `json:"field,omitempty"`
@@ -54,10 +71,35 @@ func (t CorrelationType) Validate() error {
 
 func (t Transformations) Validate() error {
 	for _, v := range t {
-		if v.Type != "regex" && v.Type != "logfmt" {
+		switch v.Type {
+		case "logfmt":
+		case "regex":
+			if len(v.Expression) == 0 {
+				return fmt.Errorf("%s: \"%s\"", ErrTransformationRegexReqExp, t)
+			}
+		case "jsonpath":
+			if len(v.Expression) == 0 {
+				return fmt.Errorf("%s: \"%s\"", ErrTransformationJSONPathReqExp, t)
+			}
+			if !validJSONPath(v.Expression) {
+				return fmt.Errorf("%s: \"%s\"", ErrTransformationInvalidJSONPath, v.Expression)
+			}
+		case "label":
+			if len(v.Expression) == 0 {
+				return fmt.Errorf("%s: \"%s\"", ErrTransformationLabelReqExp, t)
+			}
+		case "template":
+			if len(v.Expression) == 0 {
+				return fmt.Errorf("%s: \"%s\"", ErrTransformationTemplateReqExp, t)
+			}
+			if len(v.MapValue) == 0 {
+				return fmt.Errorf("%s: \"%s\"", ErrTransformationTemplateReqMap, t)
+			}
+			if !validTemplate(v.Expression) {
+				return fmt.Errorf("%s: \"%s\"", ErrTransformationInvalidTemplate, v.Expression)
+			}
+		default:
 			return fmt.Errorf("%s: \"%s\"", ErrInvalidTransformationType, t)
-		} else if v.Type == "regex" && len(v.Expression) == 0 {
-			return fmt.Errorf("%s: \"%s\"", ErrTransformationRegexReqExp, t)
 		}
 	}
 	return nil
@@ -149,6 +191,9 @@ type CreateCorrelationResponseBody struct {
 // CreateCorrelationCommand is the command for creating a correlation
 // swagger:model
 type CreateCorrelationCommand struct {
+	// UID of the correlation, generated when empty. It is set by the correlations API, which names the
+	// correlations itself.
+	UID string `json:"-"`
 	// UID of the data source for which correlation is created.
 	SourceUID string `json:"-"`
 	OrgId     int64  `json:"-"`
@@ -224,6 +269,8 @@ type UpdateCorrelationCommand struct {
 	UID       string `json:"-"`
 	SourceUID string `json:"-"`
 	OrgId     int64  `json:"-"`
+	// Target data source UID, only updated by the correlations API
+	TargetUID *string `json:"-"`
 
 	// Optional label identifying the correlation
 	// example: My label
@@ -238,10 +285,16 @@ type UpdateCorrelationCommand struct {
 }
 
 func (c UpdateCorrelationCommand) Validate() error {
-	if c.Label == nil && c.Description == nil && c.Type == nil && (c.Config == nil || (c.Config.Field == nil && c.Config.Target == nil)) {
+	if c.Label == nil && c.Description == nil && c.Type == nil && c.TargetUID == nil && (c.Config == nil || (c.Config.Field == nil && c.Config.Target == nil)) {
 		return ErrUpdateCorrelationEmptyParams
 	}
 
+	if c.Config != nil {
+		if err := Transformations(c.Config.Transformations).Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -75,6 +75,54 @@ func TestCorrelationModels(t *testing.T) {
 		})
 	})
 
+	t.Run("Transformations Validate", func(t *testing.T) {
+		type test struct {
+			name      string
+			input     Transformation
+			assertion require.ErrorAssertionFunc
+		}
+
+		tests := []test{
+			{name: "logfmt", input: Transformation{Type: "logfmt"}, assertion: require.NoError},
+			{name: "regex", input: Transformation{Type: "regex", Expression: `id=(\w+)`}, assertion: require.NoError},
+			{name: "regex without expression", input: Transformation{Type: "regex"}, assertion: require.Error},
+			{name: "jsonpath", input: Transformation{Type: "jsonpath", Expression: "$.resource['service.name']"}, assertion: require.NoError},
+			{name: "jsonpath with index", input: Transformation{Type: "jsonpath", Expression: "$.spans[0].traceId"}, assertion: require.NoError},
+			{name: "jsonpath without root", input: Transformation{Type: "jsonpath", Expression: "resource.service"}, assertion: require.Error},
+			{name: "jsonpath without expression", input: Transformation{Type: "jsonpath"}, assertion: require.Error},
+			{name: "label", input: Transformation{Type: "label", Expression: "trace_id"}, assertion: require.NoError},
+			{name: "label without expression", input: Transformation{Type: "label"}, assertion: require.Error},
+			{name: "template", input: Transformation{Type: "template", Expression: "${service}-${env}", MapValue: "name"}, assertion: require.NoError},
+			{name: "template without mapValue", input: Transformation{Type: "template", Expression: "${service}"}, assertion: require.Error},
+			{name: "template without variables", input: Transformation{Type: "template", Expression: "service", MapValue: "name"}, assertion: require.Error},
+			{name: "template with unclosed variable", input: Transformation{Type: "template", Expression: "${service", MapValue: "name"}, assertion: require.Error},
+			{name: "unknown type", input: Transformation{Type: "xpath", Expression: "/a"}, assertion: require.Error},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				tc.assertion(t, Transformations{tc.input}.Validate())
+			})
+		}
+
+		t.Run("Validates transformations on update", func(t *testing.T) {
+			label := "label"
+			cmd := &UpdateCorrelationCommand{
+				UID:       "some-uid",
+				SourceUID: "some-uid",
+				OrgId:     1,
+				Label:     &label,
+				Config: &CorrelationConfigUpdateDTO{
+					Transformations: []Transformation{{Type: "jsonpath", Expression: "body"}},
+				},
+			}
+			require.Error(t, cmd.Validate())
+
+			cmd.Config.Transformations = []Transformation{{Type: "jsonpath", Expression: "$.body"}}
+			require.NoError(t, cmd.Validate())
+		})
+	})
+
 	t.Run("CorrelationConfig JSON Marshaling", func(t *testing.T) {
 		t.Run("Applies a default empty object if target is not defined", func(t *testing.T) {
 			config := CorrelationConfig{
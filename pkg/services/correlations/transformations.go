@@ -0,0 +1,35 @@
+package correlations
+
+import (
+	"regexp"
+	"strings"
+)
+
+// jsonPathRegex matches the JSONPath subset supported by jsonpath transformations: the root `$`
+// followed by child names (`.name`, `['name']`), array indexes (`[0]`) and wildcards (`.*`, `[*]`).
+var jsonPathRegex = regexp.MustCompile(`^\$(\.[A-Za-z_@][\w@-]*|\.\*|\[\d+\]|\[\*\]|\['[^']+'\]|\["[^"]+"\])*$`)
+
+// templateVariableRegex matches a variable reference in a template transformation.
+var templateVariableRegex = regexp.MustCompile(`\$\{([^${}]*)\}`)
+
+var templateVariableNameRegex = regexp.MustCompile(`^[A-Za-z_][\w.-]*$`)
+
+func validJSONPath(expression string) bool {
+	return jsonPathRegex.MatchString(expression)
+}
+
+// validTemplate returns true when the template references at least one variable,
+// and all variable references are well-formed.
+func validTemplate(expression string) bool {
+	matches := templateVariableRegex.FindAllStringSubmatch(expression, -1)
+	if len(matches) == 0 {
+		return false
+	}
+	for _, match := range matches {
+		if !templateVariableNameRegex.MatchString(match[1]) {
+			return false
+		}
+	}
+	// Any remaining "${" is an unterminated variable reference.
+	return !strings.Contains(templateVariableRegex.ReplaceAllString(expression, ""), "${")
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/services/dashboardsnapshots"
 	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/quota"
 	"github.com/grafana/grafana/pkg/setting"
 )
 
@@ -151,3 +152,41 @@ func (d *DashboardSnapshotStore) SearchDashboardSnapshots(ctx context.Context, q
 	}
 	return queryResult, nil
 }
+
+func (d *DashboardSnapshotStore) Count(ctx context.Context, scopeParams *quota.ScopeParameters) (*quota.Map, error) {
+	u := &quota.Map{}
+	type result struct {
+		Count int64
+	}
+
+	r := result{}
+	if err := d.store.WithDbSession(ctx, func(sess *db.Session) error {
+		rawSQL := "SELECT COUNT(*) AS count FROM dashboard_snapshot"
+		_, err := sess.SQL(rawSQL).Get(&r)
+		return err
+	}); err != nil {
+		return u, err
+	}
+	tag, err := quota.NewTag(dashboardsnapshots.QuotaTargetSrv, dashboardsnapshots.QuotaTarget, quota.GlobalScope)
+	if err != nil {
+		return u, err
+	}
+	u.Set(tag, r.Count)
+
+	if scopeParams != nil && scopeParams.OrgID != 0 {
+		if err := d.store.WithDbSession(ctx, func(sess *db.Session) error {
+			rawSQL := "SELECT COUNT(*) AS count FROM dashboard_snapshot WHERE org_id = ?"
+			_, err := sess.SQL(rawSQL, scopeParams.OrgID).Get(&r)
+			return err
+		}); err != nil {
+			return u, err
+		}
+		tag, err := quota.NewTag(dashboardsnapshots.QuotaTargetSrv, dashboardsnapshots.QuotaTarget, quota.OrgScope)
+		if err != nil {
+			return u, err
+		}
+		u.Set(tag, r.Count)
+	}
+
+	return u, nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/services/dashboardsnapshots"
 	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/quota"
 	"github.com/grafana/grafana/pkg/services/secrets"
 	"github.com/grafana/grafana/pkg/services/secrets/fakes"
 	"github.com/grafana/grafana/pkg/services/user"
@@ -156,6 +157,28 @@ func TestIntegrationDashboardSnapshotDBAccess(t *testing.T) {
 
 			require.Equal(t, decryptedDashboard, rawDashboard)
 		})
+
+		t.Run("Should count snapshots for quota", func(t *testing.T) {
+			globalTag, err := quota.NewTag(dashboardsnapshots.QuotaTargetSrv, dashboardsnapshots.QuotaTarget, quota.GlobalScope)
+			require.NoError(t, err)
+			orgTag, err := quota.NewTag(dashboardsnapshots.QuotaTargetSrv, dashboardsnapshots.QuotaTarget, quota.OrgScope)
+			require.NoError(t, err)
+
+			usage, err := dashStore.Count(context.Background(), &quota.ScopeParameters{OrgID: 1})
+			require.NoError(t, err)
+			globalCount, ok := usage.Get(globalTag)
+			require.True(t, ok)
+			assert.Greater(t, globalCount, int64(0))
+			orgCount, ok := usage.Get(orgTag)
+			require.True(t, ok)
+			assert.Equal(t, globalCount, orgCount)
+
+			usage, err = dashStore.Count(context.Background(), &quota.ScopeParameters{OrgID: 2})
+			require.NoError(t, err)
+			orgCount, ok = usage.Get(orgTag)
+			require.True(t, ok)
+			assert.Equal(t, int64(0), orgCount)
+		})
 	})
 }
 
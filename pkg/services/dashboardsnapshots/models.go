@@ -6,6 +6,12 @@ import (
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	dashboardsnapshot "github.com/grafana/grafana/pkg/apis/dashboardsnapshot/v0alpha1"
 	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/quota"
+)
+
+const (
+	QuotaTargetSrv quota.TargetSrv = "dashboard_snapshot"
+	QuotaTarget    quota.Target    = "dashboard_snapshot"
 )
 
 // DashboardSnapshot model
@@ -54,6 +60,8 @@ type CreateDashboardSnapshotCommand struct {
 
 	ExternalURL       string `json:"-"`
 	ExternalDeleteURL string `json:"-"`
+	// API key sent to the external snapshot server when External is true.
+	ExternalAPIKey string `json:"-"`
 
 	// Define the unique key. Required if `external` is `true`.
 	// required:false
@@ -135,8 +135,16 @@ func CreateDashboardSnapshot(c *contextmodel.ReqContext, cfg dashboardsnapshot.S
 
 var plog = log.New("external-snapshot")
 
-func DeleteExternalDashboardSnapshot(externalUrl string) error {
-	resp, err := client.Get(externalUrl)
+// DeleteExternalDashboardSnapshot deletes a snapshot from the external snapshot server.
+// The API key is sent when set.
+func DeleteExternalDashboardSnapshot(externalUrl string, apiKey string) error {
+	req, err := http.NewRequest(http.MethodGet, externalUrl, nil)
+	if err != nil {
+		return err
+	}
+	setExternalAPIKey(req, apiKey)
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return err
 	}
@@ -164,6 +172,15 @@ func DeleteExternalDashboardSnapshot(externalUrl string) error {
 		}
 	}
 
+	// Grafana instances acting as external snapshot server respond with
+	// "Snapshot not found" instead.
+	if resp.StatusCode == http.StatusNotFound {
+		var respJson map[string]any
+		if err := json.NewDecoder(resp.Body).Decode(&respJson); err == nil && respJson["message"] == "Snapshot not found" {
+			return nil
+		}
+	}
+
 	return fmt.Errorf("unexpected response when deleting external snapshot, status code: %d", resp.StatusCode)
 }
 
@@ -182,7 +199,14 @@ func createExternalDashboardSnapshot(cmd CreateDashboardSnapshotCommand, externa
 		return nil, err
 	}
 
-	resp, err := client.Post(externalSnapshotUrl+"/api/snapshots", "application/json", bytes.NewBuffer(messageBytes))
+	req, err := http.NewRequest(http.MethodPost, externalSnapshotUrl+"/api/snapshots", bytes.NewBuffer(messageBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	setExternalAPIKey(req, cmd.ExternalAPIKey)
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -203,6 +227,12 @@ func createExternalDashboardSnapshot(cmd CreateDashboardSnapshotCommand, externa
 	return &createSnapshotResponse, nil
 }
 
+func setExternalAPIKey(req *http.Request, apiKey string) {
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+}
+
 func createOriginalDashboardURL(cmd *CreateDashboardSnapshotCommand) (string, error) {
 	dashUID := cmd.Dashboard.GetNestedString("uid")
 	if ok := util.IsValidShortUID(dashUID); !ok {
@@ -212,7 +242,7 @@ func createOriginalDashboardURL(cmd *CreateDashboardSnapshotCommand) (string, er
 	return fmt.Sprintf("/d/%v", dashUID), nil
 }
 
-func DeleteWithKey(ctx context.Context, key string, svc Service) error {
+func DeleteWithKey(ctx context.Context, key string, externalAPIKey string, svc Service) error {
 	query := &GetDashboardSnapshotQuery{DeleteKey: key}
 	queryResult, err := svc.GetDashboardSnapshot(ctx, query)
 	if err != nil {
@@ -220,7 +250,7 @@ func DeleteWithKey(ctx context.Context, key string, svc Service) error {
 	}
 
 	if queryResult.External {
-		err := DeleteExternalDashboardSnapshot(queryResult.ExternalDeleteURL)
+		err := DeleteExternalDashboardSnapshot(queryResult.ExternalDeleteURL, externalAPIKey)
 		if err != nil {
 			return err
 		}
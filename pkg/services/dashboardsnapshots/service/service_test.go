@@ -44,7 +44,8 @@ func TestIntegrationDashboardSnapshotsService(t *testing.T) {
 	dsStore := dashsnapdb.ProvideStore(sqlStore, cfg)
 	fakeDashboardService := &dashboards.FakeDashboardService{}
 	secretsService := secretsManager.SetupTestService(t, database.ProvideSecretsStore(sqlStore))
-	s := ProvideService(dsStore, secretsService, fakeDashboardService)
+	s, err := ProvideService(dsStore, secretsService, fakeDashboardService, quotatest.New(false, nil), cfg)
+	require.NoError(t, err)
 
 	origSecret := cfg.SecretKey
 	cfg.SecretKey = "dashboard_snapshot_service_test"
@@ -56,7 +57,7 @@ func TestIntegrationDashboardSnapshotsService(t *testing.T) {
 
 	dashboard := &common.Unstructured{}
 	rawDashboard := []byte(`{"id":123}`)
-	err := json.Unmarshal(rawDashboard, dashboard)
+	err = json.Unmarshal(rawDashboard, dashboard)
 	require.NoError(t, err)
 
 	t.Run("create dashboard snapshot should encrypt the dashboard", func(t *testing.T) {
@@ -127,7 +128,8 @@ func TestIntegrationValidateDashboardExists(t *testing.T) {
 		kvstore.NewFakeKVStore(),
 	)
 	require.NoError(t, err)
-	s := ProvideService(dsStore, secretsService, dashSvc)
+	s, err := ProvideService(dsStore, secretsService, dashSvc, quotatest.New(false, nil), cfg)
+	require.NoError(t, err)
 	ctx := context.Background()
 
 	t.Run("returns false when dashboard does not exist", func(t *testing.T) {
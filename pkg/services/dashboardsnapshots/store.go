@@ -2,6 +2,8 @@ package dashboardsnapshots
 
 import (
 	"context"
+
+	"github.com/grafana/grafana/pkg/services/quota"
 )
 
 type Store interface {
@@ -10,4 +12,5 @@ type Store interface {
 	DeleteExpiredSnapshots(context.Context, *DeleteExpiredSnapshotsCommand) error
 	GetDashboardSnapshot(context.Context, *GetDashboardSnapshotQuery) (*DashboardSnapshot, error)
 	SearchDashboardSnapshots(context.Context, *GetDashboardSnapshotsQuery) (DashboardSnapshotsList, error)
+	Count(context.Context, *quota.ScopeParameters) (*quota.Map, error)
 }
@@ -0,0 +1,90 @@
+package ageprovider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+
+	"github.com/grafana/grafana/pkg/services/secrets"
+)
+
+// Kind is the kind of the provider, used as prefix of the provider identifier: age.<keyName>.
+const Kind = "age"
+
+// ageProvider wraps data keys with age, using X25519 keys.
+type ageProvider struct {
+	identities []age.Identity
+	recipients []age.Recipient
+}
+
+// New reads the X25519 identities from the file at identityFile. Data keys are encrypted to the recipients of
+// those identities and to the additional recipients, so that they can be decrypted with any of their identities.
+func New(identityFile string, additionalRecipients []string) (secrets.Provider, error) {
+	if identityFile == "" {
+		return nil, errors.New("identity_file is required")
+	}
+
+	content, err := os.ReadFile(identityFile) // #nosec G304 -- the path comes from the Grafana configuration
+	if err != nil {
+		return nil, fmt.Errorf("failed to read identity file: %w", err)
+	}
+
+	identities, err := age.ParseIdentities(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("invalid identity file %s: %w", identityFile, err)
+	}
+
+	recipients := make([]age.Recipient, 0, len(identities)+len(additionalRecipients))
+	for _, identity := range identities {
+		x25519, ok := identity.(*age.X25519Identity)
+		if !ok {
+			return nil, fmt.Errorf("invalid identity file %s: only X25519 identities are supported", identityFile)
+		}
+		recipients = append(recipients, x25519.Recipient())
+	}
+
+	for _, r := range additionalRecipients {
+		recipient, err := age.ParseX25519Recipient(strings.TrimSpace(r))
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient %q: %w", r, err)
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	return ageProvider{
+		identities: identities,
+		recipients: recipients,
+	}, nil
+}
+
+func (p ageProvider) Encrypt(_ context.Context, blob []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w, err := age.Encrypt(&buf, p.recipients...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(blob); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (p ageProvider) Decrypt(_ context.Context, blob []byte) ([]byte, error) {
+	r, err := age.Decrypt(bytes.NewReader(blob), p.identities...)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(r)
+}
@@ -0,0 +1,73 @@
+package ageprovider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgeProvider(t *testing.T) {
+	ctx := context.Background()
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	backup, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	identityFile := filepath.Join(t.TempDir(), "kek.age")
+	require.NoError(t, os.WriteFile(identityFile, []byte("# created: 2025-01-01\n"+identity.String()+"\n"), 0o600))
+
+	provider, err := New(identityFile, []string{backup.Recipient().String()})
+	require.NoError(t, err)
+
+	ciphertext, err := provider.Encrypt(ctx, []byte("data key"))
+	require.NoError(t, err)
+
+	t.Run("decrypts with the identity", func(t *testing.T) {
+		plaintext, err := provider.Decrypt(ctx, ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("data key"), plaintext)
+	})
+
+	t.Run("additional recipients can decrypt", func(t *testing.T) {
+		r, err := age.Decrypt(bytes.NewReader(ciphertext), backup)
+		require.NoError(t, err)
+		plaintext, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("data key"), plaintext)
+	})
+
+	t.Run("other identities cannot decrypt", func(t *testing.T) {
+		other, err := age.GenerateX25519Identity()
+		require.NoError(t, err)
+
+		otherFile := filepath.Join(t.TempDir(), "other.age")
+		require.NoError(t, os.WriteFile(otherFile, []byte(other.String()), 0o600))
+
+		otherProvider, err := New(otherFile, nil)
+		require.NoError(t, err)
+
+		_, err = otherProvider.Decrypt(ctx, ciphertext)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := New("", nil)
+		require.Error(t, err)
+
+		_, err = New(identityFile, []string{"not-a-recipient"})
+		require.Error(t, err)
+
+		invalidFile := filepath.Join(t.TempDir(), "invalid.age")
+		require.NoError(t, os.WriteFile(invalidFile, []byte("AGE-SECRET-KEY-INVALID"), 0o600))
+		_, err = New(invalidFile, nil)
+		require.Error(t, err)
+	})
+}
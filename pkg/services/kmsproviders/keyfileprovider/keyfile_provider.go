@@ -0,0 +1,86 @@
+package keyfileprovider
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/grafana/grafana/pkg/services/secrets"
+)
+
+// Kind is the kind of the provider, used as prefix of the provider identifier: keyfile.<keyName>.
+const Kind = "keyfile"
+
+const keySize = 32
+
+// keyFileProvider wraps data keys with an AES-256 key read from a local file,
+// in the same way a key stored in a hardware security module is referenced by a PKCS#11 key file.
+type keyFileProvider struct {
+	aead cipher.AEAD
+}
+
+// New reads the key from the file at path. The file must contain a 256-bit key, either hex or base64 encoded.
+func New(path string) (secrets.Provider, error) {
+	if path == "" {
+		return nil, errors.New("key_file is required")
+	}
+
+	content, err := os.ReadFile(path) // #nosec G304 -- the path comes from the Grafana configuration
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	key, err := parseKey(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key file %s: %w", path, err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return keyFileProvider{aead: aead}, nil
+}
+
+func parseKey(content []byte) ([]byte, error) {
+	trimmed := string(bytes.TrimSpace(content))
+	if key, err := hex.DecodeString(trimmed); err == nil && len(key) == keySize {
+		return key, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(trimmed); err == nil && len(key) == keySize {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("expected a hex or base64 encoded %d-byte key", keySize)
+}
+
+func (p keyFileProvider) Encrypt(_ context.Context, blob []byte) ([]byte, error) {
+	nonce := make([]byte, p.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return p.aead.Seal(nonce, nonce, blob, nil), nil
+}
+
+func (p keyFileProvider) Decrypt(_ context.Context, blob []byte) ([]byte, error) {
+	if len(blob) < p.aead.NonceSize() {
+		return nil, errors.New("unable to decrypt: payload too short")
+	}
+
+	nonce, ciphertext := blob[:p.aead.NonceSize()], blob[p.aead.NonceSize():]
+	return p.aead.Open(nil, nonce, ciphertext, nil)
+}
@@ -0,0 +1,90 @@
+package keyfileprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyFileProvider(t *testing.T) {
+	ctx := context.Background()
+	key := bytes.Repeat([]byte{0x42}, keySize)
+
+	writeKey := func(t *testing.T, content []byte) string {
+		path := filepath.Join(t.TempDir(), "kek.key")
+		require.NoError(t, os.WriteFile(path, content, 0o600))
+		return path
+	}
+
+	t.Run("supports hex and base64 encoded keys", func(t *testing.T) {
+		var ciphertexts [][]byte
+		for _, content := range [][]byte{
+			[]byte(hex.EncodeToString(key) + "\n"),
+			[]byte(base64.StdEncoding.EncodeToString(key) + "\n"),
+		} {
+			provider, err := New(writeKey(t, content))
+			require.NoError(t, err)
+
+			ciphertext, err := provider.Encrypt(ctx, []byte("data key"))
+			require.NoError(t, err)
+			ciphertexts = append(ciphertexts, ciphertext)
+		}
+
+		// All of them are the same key, so any of them decrypts the data keys encrypted by the others.
+		provider, err := New(writeKey(t, []byte(hex.EncodeToString(key))))
+		require.NoError(t, err)
+		for _, ciphertext := range ciphertexts {
+			plaintext, err := provider.Decrypt(ctx, ciphertext)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("data key"), plaintext)
+		}
+	})
+
+	t.Run("encrypting twice gives different ciphertexts", func(t *testing.T) {
+		provider, err := New(writeKey(t, []byte(hex.EncodeToString(key))))
+		require.NoError(t, err)
+
+		c1, err := provider.Encrypt(ctx, []byte("data key"))
+		require.NoError(t, err)
+		c2, err := provider.Encrypt(ctx, []byte("data key"))
+		require.NoError(t, err)
+		assert.NotEqual(t, c1, c2)
+	})
+
+	t.Run("decrypting with another key fails", func(t *testing.T) {
+		provider, err := New(writeKey(t, []byte(hex.EncodeToString(key))))
+		require.NoError(t, err)
+		other, err := New(writeKey(t, []byte(hex.EncodeToString(bytes.Repeat([]byte{0x43}, keySize)))))
+		require.NoError(t, err)
+
+		ciphertext, err := provider.Encrypt(ctx, []byte("data key"))
+		require.NoError(t, err)
+
+		_, err = other.Decrypt(ctx, ciphertext)
+		require.Error(t, err)
+
+		_, err = provider.Decrypt(ctx, []byte("short"))
+		require.Error(t, err)
+	})
+
+	t.Run("invalid key files", func(t *testing.T) {
+		_, err := New("")
+		require.Error(t, err)
+
+		_, err = New(filepath.Join(t.TempDir(), "missing"))
+		require.Error(t, err)
+
+		_, err = New(writeKey(t, []byte(hex.EncodeToString(key[:16]))))
+		require.ErrorContains(t, err, "expected a hex or base64 encoded 32-byte key")
+
+		_, err = New(writeKey(t, key))
+		require.Error(t, err)
+	})
+}
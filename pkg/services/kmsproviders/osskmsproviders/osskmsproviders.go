@@ -1,10 +1,16 @@
 package osskmsproviders
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/grafana/grafana/pkg/services/encryption"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/kmsproviders"
+	"github.com/grafana/grafana/pkg/services/kmsproviders/ageprovider"
 	grafana "github.com/grafana/grafana/pkg/services/kmsproviders/defaultprovider"
+	"github.com/grafana/grafana/pkg/services/kmsproviders/keyfileprovider"
+	"github.com/grafana/grafana/pkg/services/kmsproviders/vaulttransitprovider"
 	"github.com/grafana/grafana/pkg/services/secrets"
 	"github.com/grafana/grafana/pkg/setting"
 )
@@ -23,8 +29,71 @@ func ProvideService(enc encryption.Internal, cfg *setting.Cfg, features featurem
 	}
 }
 
+// Provide returns the default provider, plus the providers listed in available_encryption_providers
+// and the current encryption_provider whose kind is supported in OSS. Each of them is configured
+// in its own [security.encryption.<kind>.<keyName>] section.
 func (s Service) Provide() (map[secrets.ProviderID]secrets.Provider, error) {
-	return map[secrets.ProviderID]secrets.Provider{
+	providers := map[secrets.ProviderID]secrets.Provider{
 		kmsproviders.Default: grafana.New(s.cfg, s.enc),
-	}, nil
+	}
+
+	for _, id := range s.configuredProviders() {
+		if _, ok := providers[id]; ok {
+			continue
+		}
+
+		kind, err := id.Kind()
+		if err != nil {
+			return nil, err
+		}
+
+		provider, err := s.newProvider(id, kind)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize encryption provider %s: %w", id, err)
+		}
+		// Kinds not supported in OSS are left for other implementations of kmsproviders.Service.
+		if provider == nil {
+			continue
+		}
+
+		providers[id] = provider
+	}
+
+	return providers, nil
+}
+
+func (s Service) configuredProviders() []secrets.ProviderID {
+	sec := s.cfg.SectionWithEnvOverrides("security")
+
+	ids := []secrets.ProviderID{
+		kmsproviders.NormalizeProviderID(secrets.ProviderID(sec.Key("encryption_provider").MustString(kmsproviders.Default))),
+	}
+	for _, id := range strings.Fields(sec.Key("available_encryption_providers").MustString("")) {
+		ids = append(ids, kmsproviders.NormalizeProviderID(secrets.ProviderID(id)))
+	}
+
+	return ids
+}
+
+func (s Service) newProvider(id secrets.ProviderID, kind string) (secrets.Provider, error) {
+	sec := s.cfg.SectionWithEnvOverrides("security.encryption." + string(id))
+
+	switch kind {
+	case keyfileprovider.Kind:
+		return keyfileprovider.New(sec.Key("key_file").MustString(""))
+	case ageprovider.Kind:
+		return ageprovider.New(
+			sec.Key("identity_file").MustString(""),
+			strings.Fields(sec.Key("recipients").MustString("")),
+		)
+	case vaulttransitprovider.Kind:
+		return vaulttransitprovider.New(vaulttransitprovider.Settings{
+			URL:               sec.Key("url").MustString(""),
+			Token:             sec.Key("token").MustString(""),
+			TransitEnginePath: sec.Key("transit_engine_path").MustString(""),
+			KeyRing:           sec.Key("key_ring").MustString(""),
+		}, nil)
+	default:
+		return nil, nil
+	}
 }
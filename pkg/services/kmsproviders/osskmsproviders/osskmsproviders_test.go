@@ -0,0 +1,99 @@
+package osskmsproviders
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ini.v1"
+
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/infra/usagestats"
+	encryptionprovider "github.com/grafana/grafana/pkg/services/encryption/provider"
+	encryptionservice "github.com/grafana/grafana/pkg/services/encryption/service"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/kmsproviders"
+	"github.com/grafana/grafana/pkg/services/secrets"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestService_Provide(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "kek.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(strings.Repeat("ab", 32)), 0o600))
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	identityFile := filepath.Join(dir, "kek.age")
+	require.NoError(t, os.WriteFile(identityFile, []byte(identity.String()), 0o600))
+
+	setup := func(t *testing.T, rawCfg string) Service {
+		raw, err := ini.Load([]byte(rawCfg))
+		require.NoError(t, err)
+		cfg := &setting.Cfg{Raw: raw}
+
+		enc, err := encryptionservice.ProvideEncryptionService(tracing.InitializeTracerForTest(), encryptionprovider.Provider{}, &usagestats.UsageStatsMock{}, cfg)
+		require.NoError(t, err)
+
+		return ProvideService(enc, cfg, featuremgmt.WithFeatures())
+	}
+
+	t.Run("provides only the default provider by default", func(t *testing.T) {
+		svc := setup(t, `
+		[security]
+		secret_key = sdDkslslld
+		`)
+
+		providers, err := svc.Provide()
+		require.NoError(t, err)
+		assert.Len(t, providers, 1)
+		assert.Contains(t, providers, secrets.ProviderID(kmsproviders.Default))
+	})
+
+	t.Run("provides the configured providers", func(t *testing.T) {
+		svc := setup(t, `
+		[security]
+		secret_key = sdDkslslld
+		encryption_provider = age.v1
+		available_encryption_providers = keyfile.v1 vaulttransit.v1 awskms.v1
+
+		[security.encryption.keyfile.v1]
+		key_file = `+keyFile+`
+
+		[security.encryption.age.v1]
+		identity_file = `+identityFile+`
+
+		[security.encryption.vaulttransit.v1]
+		url = http://localhost:8200
+		key_ring = grafana
+		`)
+
+		providers, err := svc.Provide()
+		require.NoError(t, err)
+
+		ids := make([]secrets.ProviderID, 0, len(providers))
+		for id := range providers {
+			ids = append(ids, id)
+		}
+		// Kinds that are not supported in OSS are ignored.
+		assert.ElementsMatch(t, []secrets.ProviderID{kmsproviders.Default, "age.v1", "keyfile.v1", "vaulttransit.v1"}, ids)
+	})
+
+	t.Run("fails when a provider is misconfigured", func(t *testing.T) {
+		svc := setup(t, `
+		[security]
+		secret_key = sdDkslslld
+		available_encryption_providers = keyfile.v1
+
+		[security.encryption.keyfile.v1]
+		key_file = `+filepath.Join(dir, "missing")+`
+		`)
+
+		_, err := svc.Provide()
+		require.ErrorContains(t, err, "keyfile.v1")
+	})
+}
@@ -0,0 +1,119 @@
+package vaulttransitprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana/pkg/services/secrets"
+)
+
+// Kind is the kind of the provider, used as prefix of the provider identifier: vaulttransit.<keyName>.
+const Kind = "vaulttransit"
+
+const defaultTransitEnginePath = "transit"
+
+// Settings are the settings of a provider, read from the [security.encryption.vaulttransit.<keyName>] section.
+type Settings struct {
+	// URL of the server implementing the Vault Transit API.
+	URL string
+	// Token used to authenticate, sent in the X-Vault-Token header.
+	Token string
+	// TransitEnginePath is the mount point of the transit secrets engine.
+	TransitEnginePath string
+	// KeyRing is the name of the encryption key.
+	KeyRing string
+}
+
+// vaultTransitProvider wraps data keys with the encrypt and decrypt endpoints of the HashiCorp Vault Transit
+// secrets engine, or of any server compatible with its HTTP API.
+type vaultTransitProvider struct {
+	client   *http.Client
+	settings Settings
+}
+
+func New(settings Settings, client *http.Client) (secrets.Provider, error) {
+	if settings.URL == "" {
+		return nil, errors.New("url is required")
+	}
+	if settings.KeyRing == "" {
+		return nil, errors.New("key_ring is required")
+	}
+	if settings.TransitEnginePath == "" {
+		settings.TransitEnginePath = defaultTransitEnginePath
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return vaultTransitProvider{client: client, settings: settings}, nil
+}
+
+func (p vaultTransitProvider) Encrypt(ctx context.Context, blob []byte) ([]byte, error) {
+	var resp struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+	if err := p.do(ctx, "encrypt", map[string]string{"plaintext": base64.StdEncoding.EncodeToString(blob)}, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Data.Ciphertext == "" {
+		return nil, errors.New("empty ciphertext in vault transit response")
+	}
+
+	return []byte(resp.Data.Ciphertext), nil
+}
+
+func (p vaultTransitProvider) Decrypt(ctx context.Context, blob []byte) ([]byte, error) {
+	var resp struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+	if err := p.do(ctx, "decrypt", map[string]string{"ciphertext": string(blob)}, &resp); err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(resp.Data.Plaintext)
+}
+
+func (p vaultTransitProvider) do(ctx context.Context, operation string, in any, out any) error {
+	endpoint, err := url.JoinPath(p.settings.URL, "v1", strings.Trim(p.settings.TransitEnginePath, "/"), operation, p.settings.KeyRing)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", p.settings.Token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault transit %s request failed: %w", operation, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("vault transit %s request failed with status %d: %s", operation, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
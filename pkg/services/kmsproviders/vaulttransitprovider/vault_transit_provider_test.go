@@ -0,0 +1,113 @@
+package vaulttransitprovider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTransit is a stand-in for the transit secrets engine that "encrypts" by reversing the base64 plaintext.
+func fakeTransit(t *testing.T, token string) *httptest.Server {
+	reverse := func(s string) string {
+		r := []rune(s)
+		for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+			r[i], r[j] = r[j], r[i]
+		}
+		return string(r)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != token {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		switch r.URL.Path {
+		case "/v1/custom-transit/encrypt/grafana":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]string{"ciphertext": "vault:v1:" + reverse(body["plaintext"])},
+			})
+		case "/v1/custom-transit/decrypt/grafana":
+			ciphertext, ok := strings.CutPrefix(body["ciphertext"], "vault:v1:")
+			if !ok {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]string{"plaintext": reverse(ciphertext)},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestVaultTransitProvider(t *testing.T) {
+	ctx := context.Background()
+	server := fakeTransit(t, "s3cr3t")
+
+	settings := Settings{
+		URL:               server.URL,
+		Token:             "s3cr3t",
+		TransitEnginePath: "custom-transit",
+		KeyRing:           "grafana",
+	}
+
+	t.Run("encrypts and decrypts with the transit engine", func(t *testing.T) {
+		provider, err := New(settings, server.Client())
+		require.NoError(t, err)
+
+		ciphertext, err := provider.Encrypt(ctx, []byte("data key"))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(ciphertext), "vault:v1:"))
+		assert.NotContains(t, string(ciphertext), base64.StdEncoding.EncodeToString([]byte("data key")))
+
+		plaintext, err := provider.Decrypt(ctx, ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("data key"), plaintext)
+	})
+
+	t.Run("returns the error of the server", func(t *testing.T) {
+		wrong := settings
+		wrong.Token = "wrong"
+
+		provider, err := New(wrong, server.Client())
+		require.NoError(t, err)
+
+		_, err = provider.Encrypt(ctx, []byte("data key"))
+		require.ErrorContains(t, err, "status 403")
+	})
+
+	t.Run("defaults to the transit mount", func(t *testing.T) {
+		defaults := settings
+		defaults.TransitEnginePath = ""
+
+		provider, err := New(defaults, server.Client())
+		require.NoError(t, err)
+
+		_, err = provider.Encrypt(ctx, []byte("data key"))
+		require.ErrorContains(t, err, "status 404")
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		_, err := New(Settings{KeyRing: "grafana"}, nil)
+		require.Error(t, err)
+
+		_, err = New(Settings{URL: server.URL}, nil)
+		require.Error(t, err)
+	})
+}
@@ -10,6 +10,9 @@ import (
 
 const (
 	openSource = "Open Source"
+
+	featureTeamSync      = "teamsync"
+	featureTeamGroupSync = "teamgroupsync"
 )
 
 type OSSLicensingService struct {
@@ -41,12 +44,19 @@ func (l *OSSLicensingService) LicenseURL(showAdminLicensingPage bool) string {
 	return "https://grafana.com/oss/grafana?utm_source=grafana_footer"
 }
 
-func (*OSSLicensingService) EnabledFeatures() map[string]bool {
-	return map[string]bool{}
+func (l *OSSLicensingService) EnabledFeatures() map[string]bool {
+	features := map[string]bool{}
+	if l.Cfg != nil && l.Cfg.TeamSync.Enabled {
+		// team sync is configured in the open source edition, so the
+		// team group pages and external member labels are enabled as well
+		features[featureTeamSync] = true
+		features[featureTeamGroupSync] = true
+	}
+	return features
 }
 
-func (*OSSLicensingService) FeatureEnabled(feature string) bool {
-	return false
+func (l *OSSLicensingService) FeatureEnabled(feature string) bool {
+	return l.EnabledFeatures()[feature]
 }
 
 func ProvideService(cfg *setting.Cfg, hooksService *hooks.HooksService) *OSSLicensingService {
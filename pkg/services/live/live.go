@@ -179,6 +179,26 @@ func ProvideService(plugCtxProvider *plugincontext.Provider, cfg *setting.Cfg, r
 
 	g.ManagedStreamRunner = managedStreamRunner
 
+	if cfg.LivePipelineEnabled {
+		// Channel rules are kept in the database, so all Grafana instances share them.
+		// Rule caches reload an organization when its rules revision changes.
+		storage := pipeline.NewSQLStorage(sqlStore, secretsService)
+		g.pipelineStorage = storage
+		builder := &pipeline.StorageRuleBuilder{
+			Node:                 node,
+			ManagedStream:        g.ManagedStreamRunner,
+			FrameStorage:         pipeline.NewFrameStorage(),
+			WindowStorage:        pipeline.NewWindowStorage(),
+			Storage:              storage,
+			ChannelHandlerGetter: g,
+			SecretsService:       secretsService,
+		}
+		g.Pipeline, err = pipeline.New(pipeline.NewCacheSegmentedTree(builder))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	g.contextGetter = liveplugin.NewContextGetter(g.PluginContextProvider, g.DataSourceCache)
 	pipelinedChannelLocalPublisher := liveplugin.NewChannelLocalPublisher(node, g.Pipeline)
 	numLocalSubscribersGetter := liveplugin.NewNumLocalSubscribersGetter(node)
@@ -1269,6 +1289,7 @@ func (g *GrafanaLive) HandlePipelineConvertTestHTTP(c *contextmodel.ReqContext)
 		Node:                 g.node,
 		ManagedStream:        g.ManagedStreamRunner,
 		FrameStorage:         pipeline.NewFrameStorage(),
+		WindowStorage:        pipeline.NewWindowStorage(),
 		Storage:              storage,
 		ChannelHandlerGetter: g,
 	}
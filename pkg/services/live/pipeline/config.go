@@ -50,11 +50,24 @@ type KeepFieldsFrameProcessorConfig struct {
 	FieldNames []string `json:"fieldNames"`
 }
 
+// WindowAggregateFrameProcessorConfig configures downsampling of frames over
+// time windows. When StepMilliseconds is not set windows are tumbling, otherwise
+// a window of WindowMilliseconds is emitted every StepMilliseconds. Rows are
+// aggregated separately for every combination of KeyFields values, by default
+// the values of all string fields. Windows are emitted when a frame with a
+// later timestamp arrives, not on a timer.
+type WindowAggregateFrameProcessorConfig struct {
+	WindowMilliseconds int64    `json:"windowMilliseconds"`
+	StepMilliseconds   int64    `json:"stepMilliseconds,omitempty"`
+	KeyFields          []string `json:"keyFields,omitempty"`
+}
+
 type FrameProcessorConfig struct {
-	Type                      string                          `json:"type" ts_type:"Omit<keyof FrameProcessorConfig, 'type'>"`
-	DropFieldsProcessorConfig *DropFieldsFrameProcessorConfig `json:"dropFields,omitempty"`
-	KeepFieldsProcessorConfig *KeepFieldsFrameProcessorConfig `json:"keepFields,omitempty"`
-	MultipleProcessorConfig   *MultipleFrameProcessorConfig   `json:"multiple,omitempty"`
+	Type                           string                               `json:"type" ts_type:"Omit<keyof FrameProcessorConfig, 'type'>"`
+	DropFieldsProcessorConfig      *DropFieldsFrameProcessorConfig      `json:"dropFields,omitempty"`
+	KeepFieldsProcessorConfig      *KeepFieldsFrameProcessorConfig      `json:"keepFields,omitempty"`
+	WindowAggregateProcessorConfig *WindowAggregateFrameProcessorConfig `json:"windowAggregate,omitempty"`
+	MultipleProcessorConfig        *MultipleFrameProcessorConfig        `json:"multiple,omitempty"`
 }
 
 type MultipleFrameProcessorConfig struct {
@@ -0,0 +1,368 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/services/live/orgchannel"
+)
+
+// WindowAggregateFrameProcessor downsamples frames. It collects values of numeric
+// fields over time windows and, once a window is complete, returns a frame with
+// min, max, mean and last value of every field per window and aggregation key.
+// Windows are aligned to Unix time, and a window is complete when a frame with
+// a later timestamp arrives. Until then the processor returns no frame, so
+// outputs are only called with aggregated frames. Windows are not flushed on
+// a timer: the last window of a stream which stops sending is only emitted
+// once the stream sends a frame again.
+type WindowAggregateFrameProcessor struct {
+	storage *WindowStorage
+	config  WindowAggregateFrameProcessorConfig
+}
+
+func NewWindowAggregateFrameProcessor(storage *WindowStorage, config WindowAggregateFrameProcessorConfig) (*WindowAggregateFrameProcessor, error) {
+	if config.WindowMilliseconds <= 0 {
+		return nil, fmt.Errorf("windowMilliseconds must be positive")
+	}
+	if config.StepMilliseconds == 0 {
+		config.StepMilliseconds = config.WindowMilliseconds
+	}
+	if config.StepMilliseconds < 0 || config.StepMilliseconds > config.WindowMilliseconds {
+		return nil, fmt.Errorf("stepMilliseconds must be positive and not greater than windowMilliseconds")
+	}
+	if config.WindowMilliseconds%config.StepMilliseconds != 0 {
+		return nil, fmt.Errorf("windowMilliseconds must be a multiple of stepMilliseconds")
+	}
+	return &WindowAggregateFrameProcessor{storage: storage, config: config}, nil
+}
+
+const FrameProcessorTypeWindowAggregate = "windowAggregate"
+
+func (p *WindowAggregateFrameProcessor) Type() string {
+	return FrameProcessorTypeWindowAggregate
+}
+
+func (p *WindowAggregateFrameProcessor) ProcessFrame(_ context.Context, vars Vars, frame *data.Frame) (*data.Frame, error) {
+	key := fmt.Sprintf("%s/%d/%d", orgchannel.PrependOrgID(vars.OrgID, vars.Channel), p.config.WindowMilliseconds, p.config.StepMilliseconds)
+	state := p.storage.get(key)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.add(p.config, frame)
+	return state.flush(p.config, frame.Name), nil
+}
+
+// WindowStorage keeps state of window aggregations in memory, so windows
+// survive rebuilding of channel rules. Not usable in HA setup.
+type WindowStorage struct {
+	mu     sync.Mutex
+	states map[string]*windowState
+}
+
+func NewWindowStorage() *WindowStorage {
+	return &WindowStorage{
+		states: map[string]*windowState{},
+	}
+}
+
+func (s *WindowStorage) get(key string) *windowState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.states[key]
+	if !ok {
+		state = &windowState{buckets: map[int64]map[string]*windowSeries{}, emitted: -1}
+		s.states[key] = state
+	}
+	return state
+}
+
+type windowState struct {
+	mu sync.Mutex
+	// keyFields and valueFields keep the order in which fields were first seen.
+	keyFields   []string
+	valueFields []windowField
+	// buckets hold aggregates per step, by the step start and then by aggregation key.
+	buckets map[int64]map[string]*windowSeries
+	// watermark is the start of the step with the latest seen timestamp.
+	watermark int64
+	// emitted is the end of the last emitted window, -1 before the first frame.
+	emitted int64
+}
+
+type windowField struct {
+	id     string
+	name   string
+	labels data.Labels
+	config *data.FieldConfig
+}
+
+type windowSeries struct {
+	keyValues map[string]string
+	values    map[string]*windowAggregate
+}
+
+type windowAggregate struct {
+	min, max, sum, last float64
+	count               int64
+	lastTime            int64
+}
+
+func (a *windowAggregate) add(ts int64, value float64) {
+	if a.count == 0 || value < a.min {
+		a.min = value
+	}
+	if a.count == 0 || value > a.max {
+		a.max = value
+	}
+	if a.count == 0 || ts >= a.lastTime {
+		a.last = value
+		a.lastTime = ts
+	}
+	a.sum += value
+	a.count++
+}
+
+func (a *windowAggregate) merge(other *windowAggregate) {
+	if other.count == 0 {
+		return
+	}
+	if a.count == 0 || other.min < a.min {
+		a.min = other.min
+	}
+	if a.count == 0 || other.max > a.max {
+		a.max = other.max
+	}
+	if a.count == 0 || other.lastTime >= a.lastTime {
+		a.last = other.last
+		a.lastTime = other.lastTime
+	}
+	a.sum += other.sum
+	a.count += other.count
+}
+
+func (s *windowState) isKeyField(config WindowAggregateFrameProcessorConfig, field *data.Field) bool {
+	if len(config.KeyFields) > 0 {
+		return stringInSlice(field.Name, config.KeyFields)
+	}
+	return field.Type() == data.FieldTypeString || field.Type() == data.FieldTypeNullableString
+}
+
+func (s *windowState) add(config WindowAggregateFrameProcessorConfig, frame *data.Frame) {
+	timeIndex := -1
+	for i, field := range frame.Fields {
+		if field.Type() == data.FieldTypeTime || field.Type() == data.FieldTypeNullableTime {
+			timeIndex = i
+			break
+		}
+	}
+
+	var keyFields, valueFields []*data.Field
+	for i, field := range frame.Fields {
+		switch {
+		case i == timeIndex:
+		case s.isKeyField(config, field):
+			keyFields = append(keyFields, field)
+			if !stringInSlice(field.Name, s.keyFields) {
+				s.keyFields = append(s.keyFields, field.Name)
+			}
+		case field.Type().Numeric():
+			valueFields = append(valueFields, field)
+			s.addValueField(field)
+		}
+	}
+
+	now := time.Now().UnixMilli()
+	for row := 0; row < frame.Rows(); row++ {
+		ts := now
+		if timeIndex >= 0 {
+			if t, ok := frame.Fields[timeIndex].ConcreteAt(row); ok {
+				ts = t.(time.Time).UnixMilli()
+			}
+		}
+		bucketStart := ts - mod(ts, config.StepMilliseconds)
+		if s.emitted >= 0 && bucketStart < s.emitted {
+			// The windows this row belongs to were already emitted.
+			continue
+		}
+
+		keyValues := make(map[string]string, len(keyFields))
+		keyParts := make([]string, 0, len(keyFields))
+		for _, field := range keyFields {
+			var value string
+			if v, ok := field.ConcreteAt(row); ok {
+				value = fmt.Sprintf("%v", v)
+			}
+			keyValues[field.Name] = value
+			keyParts = append(keyParts, field.Name+"="+value)
+		}
+		sort.Strings(keyParts)
+		seriesKey := strings.Join(keyParts, "\x00")
+
+		bucket, ok := s.buckets[bucketStart]
+		if !ok {
+			bucket = map[string]*windowSeries{}
+			s.buckets[bucketStart] = bucket
+		}
+		series, ok := bucket[seriesKey]
+		if !ok {
+			series = &windowSeries{keyValues: keyValues, values: map[string]*windowAggregate{}}
+			bucket[seriesKey] = series
+		}
+		for _, field := range valueFields {
+			value, err := field.NullableFloatAt(row)
+			if err != nil || value == nil || math.IsNaN(*value) {
+				continue
+			}
+			id := windowFieldID(field)
+			agg, ok := series.values[id]
+			if !ok {
+				agg = &windowAggregate{}
+				series.values[id] = agg
+			}
+			agg.add(ts, *value)
+		}
+
+		if bucketStart > s.watermark {
+			s.watermark = bucketStart
+		}
+		if s.emitted < 0 {
+			s.emitted = bucketStart
+		}
+	}
+}
+
+func (s *windowState) addValueField(field *data.Field) {
+	id := windowFieldID(field)
+	for _, f := range s.valueFields {
+		if f.id == id {
+			return
+		}
+	}
+	s.valueFields = append(s.valueFields, windowField{id: id, name: field.Name, labels: field.Labels, config: field.Config})
+}
+
+func windowFieldID(field *data.Field) string {
+	return field.Name + field.Labels.String()
+}
+
+// flush returns a frame with all windows completed since the last flush, or
+// nil when no window was completed.
+func (s *windowState) flush(config WindowAggregateFrameProcessorConfig, name string) *data.Frame {
+	if s.emitted < 0 || s.watermark <= s.emitted {
+		return nil
+	}
+
+	// Window ends are step aligned. Only windows containing data are emitted,
+	// so candidate ends are collected from existing buckets instead of
+	// iterating over a possibly long gap without data.
+	endSet := map[int64]struct{}{}
+	for bucketStart := range s.buckets {
+		for end := bucketStart + config.StepMilliseconds; end <= bucketStart+config.WindowMilliseconds; end += config.StepMilliseconds {
+			if end > s.emitted && end <= s.watermark {
+				endSet[end] = struct{}{}
+			}
+		}
+	}
+	ends := make([]int64, 0, len(endSet))
+	for end := range endSet {
+		ends = append(ends, end)
+	}
+	sort.Slice(ends, func(i, j int) bool { return ends[i] < ends[j] })
+
+	frame := s.newFrame(name)
+	for _, end := range ends {
+		mergedSeries := map[string]*windowSeries{}
+		for bucketStart, bucket := range s.buckets {
+			if bucketStart < end-config.WindowMilliseconds || bucketStart >= end {
+				continue
+			}
+			for seriesKey, series := range bucket {
+				merged, ok := mergedSeries[seriesKey]
+				if !ok {
+					merged = &windowSeries{keyValues: series.keyValues, values: map[string]*windowAggregate{}}
+					mergedSeries[seriesKey] = merged
+				}
+				for id, agg := range series.values {
+					mergedAgg, ok := merged.values[id]
+					if !ok {
+						mergedAgg = &windowAggregate{}
+						merged.values[id] = mergedAgg
+					}
+					mergedAgg.merge(agg)
+				}
+			}
+		}
+		seriesKeys := make([]string, 0, len(mergedSeries))
+		for seriesKey := range mergedSeries {
+			seriesKeys = append(seriesKeys, seriesKey)
+		}
+		sort.Strings(seriesKeys)
+		for _, seriesKey := range seriesKeys {
+			s.appendRow(frame, time.UnixMilli(end), mergedSeries[seriesKey])
+		}
+	}
+
+	// Remove buckets which no window ending after the watermark includes.
+	for bucketStart := range s.buckets {
+		if bucketStart+config.WindowMilliseconds <= s.watermark {
+			delete(s.buckets, bucketStart)
+		}
+	}
+	s.emitted = s.watermark
+
+	if frame.Rows() == 0 {
+		return nil
+	}
+	return frame
+}
+
+var windowAggregations = []string{"min", "max", "mean", "last"}
+
+func (s *windowState) newFrame(name string) *data.Frame {
+	fields := make([]*data.Field, 0, len(s.keyFields)+1+len(s.valueFields)*len(windowAggregations))
+	for _, keyField := range s.keyFields {
+		fields = append(fields, data.NewField(keyField, nil, []string{}))
+	}
+	fields = append(fields, data.NewField("time", nil, []time.Time{}))
+	for _, f := range s.valueFields {
+		for _, aggregation := range windowAggregations {
+			field := data.NewField(f.name+"_"+aggregation, f.labels, []*float64{})
+			field.Config = f.config
+			fields = append(fields, field)
+		}
+	}
+	return data.NewFrame(name, fields...)
+}
+
+func (s *windowState) appendRow(frame *data.Frame, end time.Time, series *windowSeries) {
+	row := make([]any, 0, len(frame.Fields))
+	for _, keyField := range s.keyFields {
+		row = append(row, series.keyValues[keyField])
+	}
+	row = append(row, end)
+	for _, f := range s.valueFields {
+		agg, ok := series.values[f.id]
+		if !ok || agg.count == 0 {
+			row = append(row, nil, nil, nil, nil)
+			continue
+		}
+		mean := agg.sum / float64(agg.count)
+		row = append(row, &agg.min, &agg.max, &mean, &agg.last)
+	}
+	frame.AppendRow(row...)
+}
+
+// mod returns the non-negative remainder of a divided by b.
+func mod(a, b int64) int64 {
+	m := a % b
+	if m < 0 {
+		m += b
+	}
+	return m
+}
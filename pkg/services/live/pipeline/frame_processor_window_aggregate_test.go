@@ -0,0 +1,153 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func windowTestFrame(ts int64, host string, value float64) *data.Frame {
+	return data.NewFrame("cpu",
+		data.NewField("labels", nil, []string{host}),
+		data.NewField("time", nil, []time.Time{time.UnixMilli(ts)}),
+		data.NewField("usage", data.Labels{"unit": "percent"}, []float64{value}),
+	)
+}
+
+func processWindowFrame(t *testing.T, p *WindowAggregateFrameProcessor, frame *data.Frame) *data.Frame {
+	t.Helper()
+	out, err := p.ProcessFrame(context.Background(), Vars{OrgID: 1, Channel: "stream/test/cpu"}, frame)
+	require.NoError(t, err)
+	return out
+}
+
+func TestWindowAggregateFrameProcessor_Tumbling(t *testing.T) {
+	p, err := NewWindowAggregateFrameProcessor(NewWindowStorage(), WindowAggregateFrameProcessorConfig{WindowMilliseconds: 1000})
+	require.NoError(t, err)
+
+	require.Nil(t, processWindowFrame(t, p, windowTestFrame(10000, "a", 1)))
+	require.Nil(t, processWindowFrame(t, p, windowTestFrame(10200, "b", 10)))
+	require.Nil(t, processWindowFrame(t, p, windowTestFrame(10500, "a", 3)))
+	require.Nil(t, processWindowFrame(t, p, windowTestFrame(10700, "a", 2)))
+
+	out := processWindowFrame(t, p, windowTestFrame(11100, "a", 5))
+	require.NotNil(t, out)
+	require.Equal(t, "cpu", out.Name)
+	require.Equal(t, 2, out.Rows())
+
+	names := make([]string, 0, len(out.Fields))
+	for _, f := range out.Fields {
+		names = append(names, f.Name)
+	}
+	require.Equal(t, []string{"labels", "time", "usage_min", "usage_max", "usage_mean", "usage_last"}, names)
+	require.Equal(t, data.Labels{"unit": "percent"}, out.Fields[2].Labels)
+
+	require.Equal(t, "a", out.Fields[0].At(0))
+	require.Equal(t, time.UnixMilli(11000), out.Fields[1].At(0))
+	require.Equal(t, 1.0, *out.Fields[2].At(0).(*float64))
+	require.Equal(t, 3.0, *out.Fields[3].At(0).(*float64))
+	require.Equal(t, 2.0, *out.Fields[4].At(0).(*float64))
+	require.Equal(t, 2.0, *out.Fields[5].At(0).(*float64))
+
+	require.Equal(t, "b", out.Fields[0].At(1))
+	require.Equal(t, 10.0, *out.Fields[4].At(1).(*float64))
+
+	// Late data for an emitted window is dropped.
+	require.Nil(t, processWindowFrame(t, p, windowTestFrame(10900, "a", 100)))
+
+	out = processWindowFrame(t, p, windowTestFrame(12000, "a", 7))
+	require.NotNil(t, out)
+	require.Equal(t, 1, out.Rows())
+	require.Equal(t, time.UnixMilli(12000), out.Fields[1].At(0))
+	require.Equal(t, 5.0, *out.Fields[5].At(0).(*float64))
+}
+
+func TestWindowAggregateFrameProcessor_Sliding(t *testing.T) {
+	p, err := NewWindowAggregateFrameProcessor(NewWindowStorage(), WindowAggregateFrameProcessorConfig{
+		WindowMilliseconds: 2000,
+		StepMilliseconds:   1000,
+	})
+	require.NoError(t, err)
+
+	require.Nil(t, processWindowFrame(t, p, windowTestFrame(10000, "a", 1)))
+	out := processWindowFrame(t, p, windowTestFrame(11000, "a", 3))
+	require.NotNil(t, out)
+	require.Equal(t, 1, out.Rows())
+	require.Equal(t, time.UnixMilli(11000), out.Fields[1].At(0))
+	require.Equal(t, 1.0, *out.Fields[4].At(0).(*float64))
+
+	out = processWindowFrame(t, p, windowTestFrame(12000, "a", 5))
+	require.NotNil(t, out)
+	require.Equal(t, 1, out.Rows())
+	require.Equal(t, time.UnixMilli(12000), out.Fields[1].At(0))
+	require.Equal(t, 1.0, *out.Fields[2].At(0).(*float64))
+	require.Equal(t, 3.0, *out.Fields[3].At(0).(*float64))
+	require.Equal(t, 2.0, *out.Fields[4].At(0).(*float64))
+}
+
+func TestWindowAggregateFrameProcessor_LastWindowWaitsForNextFrame(t *testing.T) {
+	p, err := NewWindowAggregateFrameProcessor(NewWindowStorage(), WindowAggregateFrameProcessorConfig{WindowMilliseconds: 1000})
+	require.NoError(t, err)
+
+	require.Nil(t, processWindowFrame(t, p, windowTestFrame(10000, "a", 1)))
+	require.Nil(t, processWindowFrame(t, p, windowTestFrame(10500, "a", 3)))
+
+	// Windows are not flushed on a timer, the window is only emitted once the
+	// stream sends a frame again, however long it was quiet.
+	out := processWindowFrame(t, p, windowTestFrame(70000, "a", 5))
+	require.NotNil(t, out)
+	require.Equal(t, 1, out.Rows())
+	require.Equal(t, time.UnixMilli(11000), out.Fields[1].At(0))
+	require.Equal(t, 3.0, *out.Fields[5].At(0).(*float64))
+}
+
+func TestWindowAggregateFrameProcessor_FollowedByKeepFields(t *testing.T) {
+	windowAggregate, err := NewWindowAggregateFrameProcessor(NewWindowStorage(), WindowAggregateFrameProcessorConfig{WindowMilliseconds: 1000})
+	require.NoError(t, err)
+	p := NewMultipleFrameProcessor(windowAggregate, NewKeepFieldsFrameProcessor(KeepFieldsFrameProcessorConfig{
+		FieldNames: []string{"time", "usage_mean"},
+	}))
+
+	out, err := p.ProcessFrame(context.Background(), Vars{OrgID: 1, Channel: "stream/test/cpu"}, windowTestFrame(10000, "a", 1))
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	out, err = p.ProcessFrame(context.Background(), Vars{OrgID: 1, Channel: "stream/test/cpu"}, windowTestFrame(11000, "a", 3))
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	require.Len(t, out.Fields, 2)
+	require.Equal(t, "usage_mean", out.Fields[1].Name)
+	require.Equal(t, 1.0, *out.Fields[1].At(0).(*float64))
+}
+
+func TestWindowAggregateFrameProcessor_StateSharedBetweenRuleBuilds(t *testing.T) {
+	storage := NewWindowStorage()
+	config := WindowAggregateFrameProcessorConfig{WindowMilliseconds: 1000}
+
+	p1, err := NewWindowAggregateFrameProcessor(storage, config)
+	require.NoError(t, err)
+	require.Nil(t, processWindowFrame(t, p1, windowTestFrame(10000, "a", 1)))
+
+	p2, err := NewWindowAggregateFrameProcessor(storage, config)
+	require.NoError(t, err)
+	out := processWindowFrame(t, p2, windowTestFrame(11000, "a", 2))
+	require.NotNil(t, out)
+	require.Equal(t, 1.0, *out.Fields[5].At(0).(*float64))
+}
+
+func TestNewWindowAggregateFrameProcessor_InvalidConfig(t *testing.T) {
+	for name, config := range map[string]WindowAggregateFrameProcessorConfig{
+		"no window":          {},
+		"step above window":  {WindowMilliseconds: 1000, StepMilliseconds: 2000},
+		"negative step":      {WindowMilliseconds: 1000, StepMilliseconds: -1},
+		"window not aligned": {WindowMilliseconds: 1000, StepMilliseconds: 300},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewWindowAggregateFrameProcessor(NewWindowStorage(), config)
+			require.Error(t, err)
+		})
+	}
+}
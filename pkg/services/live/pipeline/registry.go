@@ -79,6 +79,13 @@ var FrameProcessorsRegistry = []EntityInfo{
 		Description: "list the fields that should be removed",
 		Example:     DropFieldsFrameProcessorConfig{},
 	},
+	{
+		Type:        FrameProcessorTypeWindowAggregate,
+		Description: "aggregate fields over time windows to min, max, mean and last values",
+		Example: WindowAggregateFrameProcessorConfig{
+			WindowMilliseconds: 60000,
+		},
+	},
 }
 
 var DataOutputsRegistry = []EntityInfo{
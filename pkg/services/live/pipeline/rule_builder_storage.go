@@ -14,11 +14,22 @@ type StorageRuleBuilder struct {
 	Node                 *centrifuge.Node
 	ManagedStream        *managedstream.Runner
 	FrameStorage         *FrameStorage
+	WindowStorage        *WindowStorage
 	Storage              Storage
 	ChannelHandlerGetter ChannelHandlerGetter
 	SecretsService       secrets.Service
 }
 
+// RuleRevision returns the revision of the rule storage when it supports
+// revisions, so rule caches can be refreshed on changes.
+func (f *StorageRuleBuilder) RuleRevision(ctx context.Context, orgID int64) (int64, error) {
+	getter, ok := f.Storage.(RuleRevisionGetter)
+	if !ok {
+		return 0, nil
+	}
+	return getter.RuleRevision(ctx, orgID)
+}
+
 func (f *StorageRuleBuilder) extractSubscriber(config *SubscriberConfig) (Subscriber, error) {
 	if config == nil {
 		return nil, nil
@@ -90,6 +101,14 @@ func (f *StorageRuleBuilder) extractFrameProcessor(config *FrameProcessorConfig)
 			return nil, missingConfiguration
 		}
 		return NewKeepFieldsFrameProcessor(*config.KeepFieldsProcessorConfig), nil
+	case FrameProcessorTypeWindowAggregate:
+		if config.WindowAggregateProcessorConfig == nil {
+			return nil, missingConfiguration
+		}
+		if f.WindowStorage == nil {
+			return nil, fmt.Errorf("window storage required for %s", config.Type)
+		}
+		return NewWindowAggregateFrameProcessor(f.WindowStorage, *config.WindowAggregateProcessorConfig)
 	case FrameProcessorTypeMultiple:
 		if config.MultipleProcessorConfig == nil {
 			return nil, missingConfiguration
@@ -9,31 +9,75 @@ import (
 	"github.com/grafana/grafana/pkg/services/live/pipeline/tree"
 )
 
+// revisionCheckInterval is how often CacheSegmentedTree checks whether rules
+// changed when the RuleBuilder implements RuleRevisionGetter.
+var revisionCheckInterval = 2 * time.Second
+
 // CacheSegmentedTree provides a fast access to channel rule configuration.
 type CacheSegmentedTree struct {
 	radixMu     sync.RWMutex
 	radix       map[int64]*tree.Node
+	revisions   map[int64]int64
 	ruleBuilder RuleBuilder
+
+	revisionCheckInterval time.Duration
 }
 
 func NewCacheSegmentedTree(storage RuleBuilder) *CacheSegmentedTree {
 	s := &CacheSegmentedTree{
 		radix:       map[int64]*tree.Node{},
+		revisions:   map[int64]int64{},
 		ruleBuilder: storage,
+
+		revisionCheckInterval: revisionCheckInterval,
 	}
 	go s.updatePeriodically()
+	if revisionGetter, ok := storage.(RuleRevisionGetter); ok {
+		go s.watchRevisions(revisionGetter)
+	}
 	return s
 }
 
-func (s *CacheSegmentedTree) updatePeriodically() {
+func (s *CacheSegmentedTree) orgIDs() []int64 {
+	s.radixMu.RLock()
+	defer s.radixMu.RUnlock()
+	orgIDs := make([]int64, 0, len(s.radix))
+	for orgID := range s.radix {
+		orgIDs = append(orgIDs, orgID)
+	}
+	return orgIDs
+}
+
+// watchRevisions refills organizations whose rules were changed, possibly
+// by another Grafana instance, since they were loaded into the cache.
+func (s *CacheSegmentedTree) watchRevisions(revisionGetter RuleRevisionGetter) {
 	for {
-		var orgIDs []int64
-		s.radixMu.Lock()
-		for orgID := range s.radix {
-			orgIDs = append(orgIDs, orgID)
+		time.Sleep(s.revisionCheckInterval)
+		for _, orgID := range s.orgIDs() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			revision, err := revisionGetter.RuleRevision(ctx, orgID)
+			cancel()
+			if err != nil {
+				logger.Error("Error getting rule revision", "error", err, "orgId", orgID)
+				continue
+			}
+			s.radixMu.RLock()
+			cachedRevision := s.revisions[orgID]
+			s.radixMu.RUnlock()
+			if revision == cachedRevision {
+				continue
+			}
+			logger.Debug("Channel rules changed, refilling org", "orgId", orgID, "revision", revision)
+			if err := s.fillOrg(orgID); err != nil {
+				logger.Error("Error filling orgId", "error", err, "orgId", orgID)
+			}
 		}
-		s.radixMu.Unlock()
-		for _, orgID := range orgIDs {
+	}
+}
+
+func (s *CacheSegmentedTree) updatePeriodically() {
+	for {
+		for _, orgID := range s.orgIDs() {
 			err := s.fillOrg(orgID)
 			if err != nil {
 				logger.Error("Error filling orgId", "error", err, "orgId", orgID)
@@ -46,16 +90,28 @@ func (s *CacheSegmentedTree) updatePeriodically() {
 func (s *CacheSegmentedTree) fillOrg(orgID int64) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
+	// Revision is read before building rules, so a change made while building
+	// results in one more refill rather than in a missed one.
+	var revision int64
+	if revisionGetter, ok := s.ruleBuilder.(RuleRevisionGetter); ok {
+		var err error
+		revision, err = revisionGetter.RuleRevision(ctx, orgID)
+		if err != nil {
+			return err
+		}
+	}
 	channels, err := s.ruleBuilder.BuildRules(ctx, orgID)
 	if err != nil {
 		return err
 	}
-	s.radixMu.Lock()
-	defer s.radixMu.Unlock()
-	s.radix[orgID] = tree.New()
+	t := tree.New()
 	for _, ch := range channels {
-		s.radix[orgID].AddRoute("/"+ch.Pattern, ch)
+		t.AddRoute("/"+ch.Pattern, ch)
 	}
+	s.radixMu.Lock()
+	defer s.radixMu.Unlock()
+	s.radix[orgID] = t
+	s.revisions[orgID] = revision
 	return nil
 }
 
@@ -2,7 +2,9 @@ package pipeline
 
 import (
 	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -53,6 +55,54 @@ func TestStorage_Get(t *testing.T) {
 	require.Equal(t, "stream/boom:er", rule.Pattern)
 }
 
+type revisionTestBuilder struct {
+	mu       sync.Mutex
+	pattern  string
+	revision int64
+}
+
+func (t *revisionTestBuilder) set(pattern string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pattern = pattern
+	t.revision++
+}
+
+func (t *revisionTestBuilder) BuildRules(_ context.Context, orgID int64) ([]*LiveChannelRule, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return []*LiveChannelRule{{OrgId: orgID, Pattern: t.pattern}}, nil
+}
+
+func (t *revisionTestBuilder) RuleRevision(_ context.Context, _ int64) (int64, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.revision, nil
+}
+
+func TestStorage_Get_RefillsOnRevisionChange(t *testing.T) {
+	revisionCheckInterval = 10 * time.Millisecond
+	t.Cleanup(func() { revisionCheckInterval = 2 * time.Second })
+
+	builder := &revisionTestBuilder{}
+	builder.set("stream/a")
+	s := NewCacheSegmentedTree(builder)
+
+	_, ok, err := s.Get(1, "stream/a")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	builder.set("stream/b")
+	require.Eventually(t, func() bool {
+		_, ok, err := s.Get(1, "stream/b")
+		return err == nil && ok
+	}, time.Second, 10*time.Millisecond)
+
+	_, ok, err = s.Get(1, "stream/a")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
 func BenchmarkRuleGet(b *testing.B) {
 	s := NewCacheSegmentedTree(&testBuilder{})
 	for i := 0; i < b.N; i++ {
@@ -0,0 +1,428 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/secrets"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// RuleRevisionGetter is implemented by storages which can tell whether the
+// pipeline configuration of an organization changed. The revision increases
+// on every change of channel rules or write configs of the organization.
+type RuleRevisionGetter interface {
+	RuleRevision(ctx context.Context, orgID int64) (int64, error)
+}
+
+// SQLStorage keeps channel rules and write configs in the Grafana database,
+// so they are shared by all Grafana instances.
+type SQLStorage struct {
+	store          db.DB
+	secretsService secrets.Service
+}
+
+var _ Storage = (*SQLStorage)(nil)
+var _ RuleRevisionGetter = (*SQLStorage)(nil)
+
+func NewSQLStorage(store db.DB, secretsService secrets.Service) *SQLStorage {
+	return &SQLStorage{store: store, secretsService: secretsService}
+}
+
+type liveChannelRule struct {
+	ID       int64  `xorm:"pk autoincr 'id'"`
+	OrgID    int64  `xorm:"org_id"`
+	Pattern  string `xorm:"pattern"`
+	Settings string `xorm:"settings"`
+	Created  time.Time
+	Updated  time.Time
+}
+
+func (liveChannelRule) TableName() string {
+	return "live_channel_rule"
+}
+
+type liveWriteConfig struct {
+	ID             int64  `xorm:"pk autoincr 'id'"`
+	OrgID          int64  `xorm:"org_id"`
+	UID            string `xorm:"uid"`
+	Settings       string `xorm:"settings"`
+	SecureSettings string `xorm:"secure_settings"`
+	Created        time.Time
+	Updated        time.Time
+}
+
+func (liveWriteConfig) TableName() string {
+	return "live_write_config"
+}
+
+type livePipelineRevision struct {
+	ID       int64 `xorm:"pk autoincr 'id'"`
+	OrgID    int64 `xorm:"org_id"`
+	Revision int64 `xorm:"revision"`
+	Updated  time.Time
+}
+
+func (livePipelineRevision) TableName() string {
+	return "live_pipeline_revision"
+}
+
+func (s *SQLStorage) RuleRevision(ctx context.Context, orgID int64) (int64, error) {
+	var revision livePipelineRevision
+	err := s.store.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Where("org_id = ?", orgID).Get(&revision)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("can't get pipeline revision: %w", err)
+	}
+	return revision.Revision, nil
+}
+
+// bumpRevision must be called in the same transaction as the change, so other
+// instances never see a new revision before the change itself.
+func bumpRevision(sess *db.Session, orgID int64) error {
+	now := time.Now()
+	res, err := sess.Exec("UPDATE live_pipeline_revision SET revision = revision + 1, updated = ? WHERE org_id = ?", now, orgID)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+	_, err = sess.Insert(&livePipelineRevision{OrgID: orgID, Revision: 1, Updated: now})
+	return err
+}
+
+func (s *SQLStorage) ListWriteConfigs(ctx context.Context, orgID int64) ([]WriteConfig, error) {
+	var rows []liveWriteConfig
+	err := s.store.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Where("org_id = ?", orgID).OrderBy("uid").Find(&rows)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't read write configs: %w", err)
+	}
+	writeConfigs := make([]WriteConfig, 0, len(rows))
+	for _, row := range rows {
+		writeConfig, err := row.toWriteConfig()
+		if err != nil {
+			return nil, err
+		}
+		writeConfigs = append(writeConfigs, writeConfig)
+	}
+	return writeConfigs, nil
+}
+
+func (s *SQLStorage) GetWriteConfig(ctx context.Context, orgID int64, cmd WriteConfigGetCmd) (WriteConfig, bool, error) {
+	var row liveWriteConfig
+	var found bool
+	err := s.store.WithDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		found, err = sess.Where("org_id = ? AND uid = ?", orgID, cmd.UID).Get(&row)
+		return err
+	})
+	if err != nil {
+		return WriteConfig{}, false, fmt.Errorf("can't read write config: %w", err)
+	}
+	if !found {
+		return WriteConfig{}, false, nil
+	}
+	writeConfig, err := row.toWriteConfig()
+	if err != nil {
+		return WriteConfig{}, false, err
+	}
+	return writeConfig, true, nil
+}
+
+func (s *SQLStorage) CreateWriteConfig(ctx context.Context, orgID int64, cmd WriteConfigCreateCmd) (WriteConfig, error) {
+	if cmd.UID == "" {
+		cmd.UID = util.GenerateShortUID()
+	}
+	writeConfig, err := s.buildWriteConfig(ctx, orgID, cmd.UID, cmd.Settings, cmd.SecureSettings)
+	if err != nil {
+		return WriteConfig{}, err
+	}
+	err = s.store.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		exists, err := sess.Where("org_id = ? AND uid = ?", orgID, writeConfig.UID).Exist(&liveWriteConfig{})
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("backend already exists in org: %s", writeConfig.UID)
+		}
+		return insertWriteConfig(sess, writeConfig)
+	})
+	return writeConfig, err
+}
+
+func (s *SQLStorage) UpdateWriteConfig(ctx context.Context, orgID int64, cmd WriteConfigUpdateCmd) (WriteConfig, error) {
+	writeConfig, err := s.buildWriteConfig(ctx, orgID, cmd.UID, cmd.Settings, cmd.SecureSettings)
+	if err != nil {
+		return WriteConfig{}, err
+	}
+	err = s.store.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		var existing liveWriteConfig
+		found, err := sess.Where("org_id = ? AND uid = ?", orgID, writeConfig.UID).Get(&existing)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return insertWriteConfig(sess, writeConfig)
+		}
+		row, err := writeConfigToRow(writeConfig)
+		if err != nil {
+			return err
+		}
+		row.Updated = time.Now()
+		if _, err := sess.ID(existing.ID).Cols("settings", "secure_settings", "updated").Update(&row); err != nil {
+			return err
+		}
+		return bumpRevision(sess, orgID)
+	})
+	return writeConfig, err
+}
+
+func (s *SQLStorage) DeleteWriteConfig(ctx context.Context, orgID int64, cmd WriteConfigDeleteCmd) error {
+	return s.store.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		affected, err := sess.Where("org_id = ? AND uid = ?", orgID, cmd.UID).Delete(&liveWriteConfig{})
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return errors.New("write config not found")
+		}
+		return bumpRevision(sess, orgID)
+	})
+}
+
+func (s *SQLStorage) ListChannelRules(ctx context.Context, orgID int64) ([]ChannelRule, error) {
+	var rows []liveChannelRule
+	err := s.store.WithDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		rows, err = findChannelRules(sess, orgID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't read channel rules: %w", err)
+	}
+	rules := make([]ChannelRule, 0, len(rows))
+	for _, row := range rows {
+		rule, err := row.toChannelRule()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (s *SQLStorage) CreateChannelRule(ctx context.Context, orgID int64, cmd ChannelRuleCreateCmd) (ChannelRule, error) {
+	rule := ChannelRule{
+		OrgId:    orgID,
+		Pattern:  cmd.Pattern,
+		Settings: cmd.Settings,
+	}
+	ok, reason := rule.Valid()
+	if !ok {
+		return rule, fmt.Errorf("invalid channel rule: %s", reason)
+	}
+	err := s.store.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		rows, err := findChannelRules(sess, orgID)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if row.Pattern == rule.Pattern {
+				return fmt.Errorf("pattern already exists in org: %s", rule.Pattern)
+			}
+		}
+		if err := checkPatternsValid(orgID, rows, rule.Pattern); err != nil {
+			return err
+		}
+		return insertChannelRule(sess, rule)
+	})
+	return rule, err
+}
+
+func (s *SQLStorage) UpdateChannelRule(ctx context.Context, orgID int64, cmd ChannelRuleUpdateCmd) (ChannelRule, error) {
+	rule := ChannelRule{
+		OrgId:    orgID,
+		Pattern:  cmd.Pattern,
+		Settings: cmd.Settings,
+	}
+	ok, reason := rule.Valid()
+	if !ok {
+		return rule, fmt.Errorf("invalid channel rule: %s", reason)
+	}
+	err := s.store.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		rows, err := findChannelRules(sess, orgID)
+		if err != nil {
+			return err
+		}
+		var existingID int64
+		for _, row := range rows {
+			if row.Pattern == rule.Pattern {
+				existingID = row.ID
+				break
+			}
+		}
+		if existingID == 0 {
+			if err := checkPatternsValid(orgID, rows, rule.Pattern); err != nil {
+				return err
+			}
+			return insertChannelRule(sess, rule)
+		}
+		row, err := channelRuleToRow(rule)
+		if err != nil {
+			return err
+		}
+		row.Updated = time.Now()
+		if _, err := sess.ID(existingID).Cols("settings", "updated").Update(&row); err != nil {
+			return err
+		}
+		return bumpRevision(sess, orgID)
+	})
+	return rule, err
+}
+
+func (s *SQLStorage) DeleteChannelRule(ctx context.Context, orgID int64, cmd ChannelRuleDeleteCmd) error {
+	return s.store.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		affected, err := sess.Where("org_id = ? AND pattern = ?", orgID, cmd.Pattern).Delete(&liveChannelRule{})
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return errors.New("rule not found")
+		}
+		return bumpRevision(sess, orgID)
+	})
+}
+
+func (s *SQLStorage) buildWriteConfig(ctx context.Context, orgID int64, uid string, settings WriteSettings, secureSettings map[string]string) (WriteConfig, error) {
+	encrypted, err := s.secretsService.EncryptJsonData(ctx, secureSettings, secrets.WithoutScope())
+	if err != nil {
+		return WriteConfig{}, fmt.Errorf("error encrypting data: %w", err)
+	}
+	writeConfig := WriteConfig{
+		OrgId:          orgID,
+		UID:            uid,
+		Settings:       settings,
+		SecureSettings: encrypted,
+	}
+	ok, reason := writeConfig.Valid()
+	if !ok {
+		return WriteConfig{}, fmt.Errorf("invalid write config: %s", reason)
+	}
+	return writeConfig, nil
+}
+
+func findChannelRules(sess *db.Session, orgID int64) ([]liveChannelRule, error) {
+	var rows []liveChannelRule
+	err := sess.Where("org_id = ?", orgID).OrderBy("pattern").Find(&rows)
+	return rows, err
+}
+
+// checkPatternsValid makes sure a new pattern does not conflict with the
+// patterns of existing rules in the channel rule tree.
+func checkPatternsValid(orgID int64, rows []liveChannelRule, pattern string) error {
+	rules := make([]ChannelRule, 0, len(rows)+1)
+	for _, row := range rows {
+		rules = append(rules, ChannelRule{OrgId: orgID, Pattern: row.Pattern})
+	}
+	rules = append(rules, ChannelRule{OrgId: orgID, Pattern: pattern})
+	ok, reason := checkRulesValid(orgID, rules)
+	if !ok {
+		return errors.New(reason)
+	}
+	return nil
+}
+
+func insertChannelRule(sess *db.Session, rule ChannelRule) error {
+	row, err := channelRuleToRow(rule)
+	if err != nil {
+		return err
+	}
+	row.Created = time.Now()
+	row.Updated = row.Created
+	if _, err := sess.Insert(&row); err != nil {
+		return err
+	}
+	return bumpRevision(sess, rule.OrgId)
+}
+
+func insertWriteConfig(sess *db.Session, writeConfig WriteConfig) error {
+	row, err := writeConfigToRow(writeConfig)
+	if err != nil {
+		return err
+	}
+	row.Created = time.Now()
+	row.Updated = row.Created
+	if _, err := sess.Insert(&row); err != nil {
+		return err
+	}
+	return bumpRevision(sess, writeConfig.OrgId)
+}
+
+func channelRuleToRow(rule ChannelRule) (liveChannelRule, error) {
+	settings, err := json.Marshal(rule.Settings)
+	if err != nil {
+		return liveChannelRule{}, fmt.Errorf("can't marshal channel rule settings: %w", err)
+	}
+	return liveChannelRule{
+		OrgID:    rule.OrgId,
+		Pattern:  rule.Pattern,
+		Settings: string(settings),
+	}, nil
+}
+
+func (r liveChannelRule) toChannelRule() (ChannelRule, error) {
+	rule := ChannelRule{
+		OrgId:   r.OrgID,
+		Pattern: r.Pattern,
+	}
+	if err := json.Unmarshal([]byte(r.Settings), &rule.Settings); err != nil {
+		return ChannelRule{}, fmt.Errorf("can't unmarshal settings of channel rule %s: %w", r.Pattern, err)
+	}
+	return rule, nil
+}
+
+func writeConfigToRow(writeConfig WriteConfig) (liveWriteConfig, error) {
+	settings, err := json.Marshal(writeConfig.Settings)
+	if err != nil {
+		return liveWriteConfig{}, fmt.Errorf("can't marshal write config settings: %w", err)
+	}
+	secureSettings, err := json.Marshal(writeConfig.SecureSettings)
+	if err != nil {
+		return liveWriteConfig{}, fmt.Errorf("can't marshal write config secure settings: %w", err)
+	}
+	return liveWriteConfig{
+		OrgID:          writeConfig.OrgId,
+		UID:            writeConfig.UID,
+		Settings:       string(settings),
+		SecureSettings: string(secureSettings),
+	}, nil
+}
+
+func (r liveWriteConfig) toWriteConfig() (WriteConfig, error) {
+	writeConfig := WriteConfig{
+		OrgId: r.OrgID,
+		UID:   r.UID,
+	}
+	if err := json.Unmarshal([]byte(r.Settings), &writeConfig.Settings); err != nil {
+		return WriteConfig{}, fmt.Errorf("can't unmarshal settings of write config %s: %w", r.UID, err)
+	}
+	if r.SecureSettings != "" {
+		if err := json.Unmarshal([]byte(r.SecureSettings), &writeConfig.SecureSettings); err != nil {
+			return WriteConfig{}, fmt.Errorf("can't unmarshal secure settings of write config %s: %w", r.UID, err)
+		}
+	}
+	return writeConfig, nil
+}
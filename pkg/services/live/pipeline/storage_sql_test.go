@@ -0,0 +1,153 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/secrets/fakes"
+	"github.com/grafana/grafana/pkg/tests/testsuite"
+)
+
+func TestMain(m *testing.M) {
+	testsuite.Run(m)
+}
+
+func TestIntegrationSQLStorage_ChannelRules(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	ctx := context.Background()
+	storage := NewSQLStorage(db.InitTestDB(t), fakes.NewFakeSecretsService())
+
+	_, err := storage.CreateChannelRule(ctx, 1, ChannelRuleCreateCmd{
+		Pattern: "stream/telegraf/:metric",
+		Settings: ChannelRuleSettings{
+			Converter: &ConverterConfig{Type: ConverterTypeInfluxAuto},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = storage.CreateChannelRule(ctx, 1, ChannelRuleCreateCmd{Pattern: "stream/telegraf/:metric"})
+	require.ErrorContains(t, err, "pattern already exists")
+
+	_, err = storage.CreateChannelRule(ctx, 1, ChannelRuleCreateCmd{Pattern: "stream/telegraf/:other"})
+	require.Error(t, err, "conflicting pattern should not be accepted")
+
+	_, err = storage.CreateChannelRule(ctx, 2, ChannelRuleCreateCmd{Pattern: "stream/telegraf/:metric"})
+	require.NoError(t, err)
+
+	rules, err := storage.ListChannelRules(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	require.Equal(t, int64(1), rules[0].OrgId)
+	require.Equal(t, ConverterTypeInfluxAuto, rules[0].Settings.Converter.Type)
+
+	_, err = storage.UpdateChannelRule(ctx, 1, ChannelRuleUpdateCmd{
+		Pattern: "stream/telegraf/:metric",
+		Settings: ChannelRuleSettings{
+			Converter: &ConverterConfig{Type: ConverterTypeJsonAuto},
+		},
+	})
+	require.NoError(t, err)
+
+	rules, err = storage.ListChannelRules(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	require.Equal(t, ConverterTypeJsonAuto, rules[0].Settings.Converter.Type)
+
+	rules, err = storage.ListChannelRules(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	require.Equal(t, int64(2), rules[0].OrgId)
+	require.Nil(t, rules[0].Settings.Converter)
+
+	require.NoError(t, storage.DeleteChannelRule(ctx, 1, ChannelRuleDeleteCmd{Pattern: "stream/telegraf/:metric"}))
+	require.ErrorContains(t, storage.DeleteChannelRule(ctx, 1, ChannelRuleDeleteCmd{Pattern: "stream/telegraf/:metric"}), "rule not found")
+
+	rules, err = storage.ListChannelRules(ctx, 1)
+	require.NoError(t, err)
+	require.Empty(t, rules)
+}
+
+func TestIntegrationSQLStorage_WriteConfigs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	ctx := context.Background()
+	storage := NewSQLStorage(db.InitTestDB(t), fakes.NewFakeSecretsService())
+
+	created, err := storage.CreateWriteConfig(ctx, 1, WriteConfigCreateCmd{
+		Settings:       WriteSettings{Endpoint: "http://localhost:9090/api/v1/write"},
+		SecureSettings: map[string]string{"basicAuthPassword": "secret"},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, created.UID)
+
+	_, err = storage.CreateWriteConfig(ctx, 1, WriteConfigCreateCmd{UID: created.UID, Settings: WriteSettings{Endpoint: "http://localhost"}})
+	require.ErrorContains(t, err, "already exists")
+
+	_, err = storage.CreateWriteConfig(ctx, 1, WriteConfigCreateCmd{UID: "invalid"})
+	require.ErrorContains(t, err, "endpoint required")
+
+	writeConfig, ok, err := storage.GetWriteConfig(ctx, 1, WriteConfigGetCmd{UID: created.UID})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, created.Settings, writeConfig.Settings)
+	require.Equal(t, []byte("secret"), writeConfig.SecureSettings["basicAuthPassword"])
+
+	_, ok, err = storage.GetWriteConfig(ctx, 2, WriteConfigGetCmd{UID: created.UID})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = storage.UpdateWriteConfig(ctx, 1, WriteConfigUpdateCmd{
+		UID:      created.UID,
+		Settings: WriteSettings{Endpoint: "http://remote:9090/api/v1/write"},
+	})
+	require.NoError(t, err)
+
+	writeConfigs, err := storage.ListWriteConfigs(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, writeConfigs, 1)
+	require.Equal(t, "http://remote:9090/api/v1/write", writeConfigs[0].Settings.Endpoint)
+
+	require.NoError(t, storage.DeleteWriteConfig(ctx, 1, WriteConfigDeleteCmd{UID: created.UID}))
+	require.ErrorContains(t, storage.DeleteWriteConfig(ctx, 1, WriteConfigDeleteCmd{UID: created.UID}), "write config not found")
+}
+
+func TestIntegrationSQLStorage_RuleRevision(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	ctx := context.Background()
+	storage := NewSQLStorage(db.InitTestDB(t), fakes.NewFakeSecretsService())
+
+	revision, err := storage.RuleRevision(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), revision)
+
+	_, err = storage.CreateChannelRule(ctx, 1, ChannelRuleCreateCmd{Pattern: "stream/a"})
+	require.NoError(t, err)
+	_, err = storage.UpdateChannelRule(ctx, 1, ChannelRuleUpdateCmd{Pattern: "stream/a"})
+	require.NoError(t, err)
+	_, err = storage.CreateWriteConfig(ctx, 1, WriteConfigCreateCmd{UID: "remote", Settings: WriteSettings{Endpoint: "http://localhost"}})
+	require.NoError(t, err)
+
+	revision, err = storage.RuleRevision(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), revision)
+
+	// Failed changes must not bump the revision.
+	_, err = storage.CreateChannelRule(ctx, 1, ChannelRuleCreateCmd{Pattern: "stream/a"})
+	require.Error(t, err)
+
+	revision, err = storage.RuleRevision(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), revision)
+
+	revision, err = storage.RuleRevision(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), revision)
+}
@@ -0,0 +1,133 @@
+package pushbroker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/grafana/grafana-plugin-sdk-go/live"
+
+	"github.com/grafana/grafana/pkg/services/live/managedstream"
+	"github.com/grafana/grafana/pkg/services/live/pipeline"
+)
+
+type message struct {
+	// path is the Live channel path built from the topic of the message.
+	path    string
+	payload []byte
+}
+
+// consumer converts messages received from a broker and publishes them to
+// Live. Messages are buffered in a bounded queue. When Live can't keep up,
+// new messages are dropped rather than blocking the broker connection.
+type consumer struct {
+	broker    string
+	orgID     int64
+	namespace string
+	converter pipeline.Converter
+	pipeline  *pipeline.Pipeline
+	streams   *managedstream.Runner
+	queue     chan message
+}
+
+func newConsumer(broker string, s brokerSettings, streams *managedstream.Runner, p *pipeline.Pipeline) (*consumer, error) {
+	c := &consumer{
+		broker:    broker,
+		orgID:     s.OrgID,
+		namespace: s.Namespace,
+		streams:   streams,
+		queue:     make(chan message, s.QueueSize),
+	}
+	switch s.Converter {
+	case pipeline.ConverterTypeInfluxAuto:
+		c.converter = pipeline.NewAutoInfluxConverter(pipeline.AutoInfluxConverterConfig{FrameFormat: s.FrameFormat})
+	case pipeline.ConverterTypeJsonAuto:
+		c.converter = pipeline.NewAutoJsonConverter(pipeline.AutoJsonConverterConfig{})
+	case pipeline.ConverterTypeJsonFrame:
+		c.converter = pipeline.NewJsonFrameConverter(pipeline.JsonFrameConverterConfig{})
+	case converterPipeline:
+		if p == nil {
+			return nil, fmt.Errorf("[live.%s] converter %q requires [live] pipeline_enabled", broker, converterPipeline)
+		}
+		c.pipeline = p
+	default:
+		return nil, fmt.Errorf("unsupported [live.%s] converter: %s", broker, s.Converter)
+	}
+	return c, nil
+}
+
+// enqueue is called by broker clients for every received message and never blocks.
+func (c *consumer) enqueue(path string, payload []byte) {
+	messagesReceived.WithLabelValues(c.broker).Inc()
+	select {
+	case c.queue <- message{path: path, payload: payload}:
+		queueLength.WithLabelValues(c.broker).Set(float64(len(c.queue)))
+	default:
+		messagesDropped.WithLabelValues(c.broker, dropReasonQueueFull).Inc()
+	}
+}
+
+func (c *consumer) run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg := <-c.queue:
+			queueLength.WithLabelValues(c.broker).Set(float64(len(c.queue)))
+			if reason, err := c.process(ctx, msg); err != nil {
+				messagesDropped.WithLabelValues(c.broker, reason).Inc()
+				logger.Debug("Dropping broker message", "broker", c.broker, "path", msg.path, "reason", reason, "error", err)
+			}
+		}
+	}
+}
+
+// process publishes a message to Live. When the message can't be published
+// it returns the reason for the dropped messages metric.
+func (c *consumer) process(ctx context.Context, msg message) (string, error) {
+	channel := live.Channel{Scope: live.ScopeStream, Namespace: c.namespace, Path: msg.path}
+	if !channel.IsValid() {
+		return dropReasonInvalidChannel, live.ErrInvalidChannelID
+	}
+
+	if c.pipeline != nil {
+		ruleFound, err := c.pipeline.ProcessInput(ctx, c.orgID, channel.String(), msg.payload)
+		if err != nil {
+			return dropReasonConversionError, err
+		}
+		if !ruleFound {
+			return dropReasonNoChannelRule, errors.New("no channel rule")
+		}
+		return "", nil
+	}
+
+	vars := pipeline.Vars{
+		OrgID:     c.orgID,
+		Channel:   channel.String(),
+		Scope:     channel.Scope,
+		Namespace: channel.Namespace,
+		Path:      channel.Path,
+	}
+	channelFrames, err := c.converter.Convert(ctx, vars, msg.payload)
+	if err != nil {
+		return dropReasonConversionError, err
+	}
+	for _, cf := range channelFrames {
+		frameChannel := channel
+		if cf.Channel != "" {
+			frameChannel, err = live.ParseChannel(cf.Channel)
+			if err != nil || frameChannel.Scope != live.ScopeStream {
+				return dropReasonInvalidChannel, live.ErrInvalidChannelID
+			}
+		}
+		stream, err := c.streams.GetOrCreateStream(c.orgID, frameChannel.Scope, frameChannel.Namespace)
+		if err != nil {
+			return dropReasonConversionError, err
+		}
+		if err := stream.Push(ctx, frameChannel.Path, cf.Frame); err != nil {
+			return dropReasonConversionError, err
+		}
+		framesPublished.WithLabelValues(c.broker).Inc()
+	}
+	return "", nil
+}
@@ -0,0 +1,123 @@
+package pushbroker
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/live/managedstream"
+	"github.com/grafana/grafana/pkg/services/live/pipeline"
+)
+
+type testPublisher struct {
+	mu       sync.Mutex
+	channels []string
+}
+
+func (p *testPublisher) publish(_ int64, channel string, _ []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.channels = append(p.channels, channel)
+	return nil
+}
+
+func (p *testPublisher) PublishLocal(_ string, _ []byte) error {
+	return nil
+}
+
+func (p *testPublisher) published() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.channels...)
+}
+
+// freeTCPAddress returns a local address that is free, so that a broker can be
+// started again on the same address to test reconnects.
+func freeTCPAddress(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+	return l.Addr().String()
+}
+
+func newTestConsumer(t *testing.T, broker string, converter string, queueSize int) (*consumer, *testPublisher) {
+	t.Helper()
+	publisher := &testPublisher{}
+	runner := managedstream.NewRunner(publisher.publish, publisher, managedstream.NewMemoryFrameCache())
+	c, err := newConsumer(broker, brokerSettings{
+		OrgID:       1,
+		Namespace:   broker,
+		Converter:   converter,
+		FrameFormat: "labels_column",
+		QueueSize:   queueSize,
+	}, runner, nil)
+	require.NoError(t, err)
+	return c, publisher
+}
+
+func TestConsumer_Process(t *testing.T) {
+	t.Run("influx line protocol is published per measurement", func(t *testing.T) {
+		c, publisher := newTestConsumer(t, "test-influx", pipeline.ConverterTypeInfluxAuto, 1)
+		_, err := c.process(context.Background(), message{
+			path:    "sensors/room1",
+			payload: []byte("cpu,host=a usage=1 1700000000000000000\nmem,host=a used=2 1700000000000000000"),
+		})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"stream/test-influx/sensors/room1/cpu", "stream/test-influx/sensors/room1/mem"}, publisher.published())
+	})
+
+	t.Run("json is published to the topic channel", func(t *testing.T) {
+		c, publisher := newTestConsumer(t, "test-json", pipeline.ConverterTypeJsonAuto, 1)
+		_, err := c.process(context.Background(), message{path: "sensors/room1", payload: []byte(`{"temperature": 21.5}`)})
+		require.NoError(t, err)
+		require.Equal(t, []string{"stream/test-json/sensors/room1"}, publisher.published())
+	})
+
+	t.Run("invalid channel", func(t *testing.T) {
+		c, publisher := newTestConsumer(t, "test-invalid", pipeline.ConverterTypeJsonAuto, 1)
+		reason, err := c.process(context.Background(), message{path: "sensors/room #1", payload: []byte(`{}`)})
+		require.Error(t, err)
+		require.Equal(t, dropReasonInvalidChannel, reason)
+		require.Empty(t, publisher.published())
+	})
+
+	t.Run("conversion error", func(t *testing.T) {
+		c, _ := newTestConsumer(t, "test-conversion", pipeline.ConverterTypeJsonFrame, 1)
+		reason, err := c.process(context.Background(), message{path: "sensors", payload: []byte(`not json`)})
+		require.Error(t, err)
+		require.Equal(t, dropReasonConversionError, reason)
+	})
+}
+
+func TestConsumer_DropsWhenQueueIsFull(t *testing.T) {
+	c, publisher := newTestConsumer(t, "test-backpressure", pipeline.ConverterTypeJsonAuto, 1)
+	dropped := messagesDropped.WithLabelValues("test-backpressure", dropReasonQueueFull)
+	before := testutil.ToFloat64(dropped)
+	c.enqueue("a", []byte(`{"value": 1}`))
+	c.enqueue("b", []byte(`{"value": 2}`))
+	require.Equal(t, before+1, testutil.ToFloat64(dropped))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = c.run(ctx) }()
+	require.Eventually(t, func() bool {
+		return len(publisher.published()) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"stream/test-backpressure/a"}, publisher.published())
+}
+
+func TestNewConsumer_PipelineRequired(t *testing.T) {
+	_, err := newConsumer("test", brokerSettings{Converter: converterPipeline, QueueSize: 1}, nil, nil)
+	require.ErrorContains(t, err, "pipeline_enabled")
+}
+
+func TestTopicPaths(t *testing.T) {
+	require.Equal(t, "sensors/room1/temp", mqttTopicPath("/sensors/room1/temp"))
+	require.Equal(t, "sensors/room1/temp", natsSubjectPath("sensors.room1.temp"))
+}
@@ -0,0 +1,58 @@
+package pushbroker
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	dropReasonQueueFull       = "queue_full"
+	dropReasonInvalidChannel  = "invalid_channel"
+	dropReasonConversionError = "conversion_error"
+	dropReasonNoChannelRule   = "no_channel_rule"
+	dropReasonSlowConsumer    = "slow_consumer"
+)
+
+var (
+	messagesReceived = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "grafana",
+		Subsystem: "live_broker",
+		Name:      "messages_received_total",
+		Help:      "Number of messages received from message brokers.",
+	}, []string{"broker"})
+
+	messagesDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "grafana",
+		Subsystem: "live_broker",
+		Name:      "messages_dropped_total",
+		Help:      "Number of messages from message brokers which were not published to Live.",
+	}, []string{"broker", "reason"})
+
+	framesPublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "grafana",
+		Subsystem: "live_broker",
+		Name:      "frames_published_total",
+		Help:      "Number of frames published to Live channels from message broker messages.",
+	}, []string{"broker"})
+
+	queueLength = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "grafana",
+		Subsystem: "live_broker",
+		Name:      "queue_length",
+		Help:      "Number of messages waiting to be published to Live.",
+	}, []string{"broker"})
+
+	brokerConnected = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "grafana",
+		Subsystem: "live_broker",
+		Name:      "connected",
+		Help:      "Whether Grafana is connected to the message broker.",
+	}, []string{"broker"})
+
+	brokerReconnects = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "grafana",
+		Subsystem: "live_broker",
+		Name:      "reconnects_total",
+		Help:      "Number of attempts to reconnect to the message broker.",
+	}, []string{"broker"})
+)
@@ -0,0 +1,73 @@
+package pushbroker
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+const brokerMQTT = "mqtt"
+
+// mqttTopicPath builds a Live channel path from an MQTT topic. Topic levels
+// are already separated with "/", so only a leading "/" is removed.
+func mqttTopicPath(topic string) string {
+	return strings.TrimPrefix(topic, "/")
+}
+
+// mqttMessageHandler passes MQTT messages to the consumer.
+func mqttMessageHandler(c *consumer) paho.MessageHandler {
+	return func(_ paho.Client, m paho.Message) {
+		c.enqueue(mqttTopicPath(m.Topic()), m.Payload())
+	}
+}
+
+// runMQTT subscribes to MQTT topics and passes messages to the consumer until
+// ctx is done. The client reconnects and subscribes again when the connection
+// is lost.
+func runMQTT(ctx context.Context, s brokerSettings, c *consumer) error {
+	filters := make(map[string]byte, len(s.Topics))
+	for _, topic := range s.Topics {
+		filters[topic] = s.QoS
+	}
+	onMessage := mqttMessageHandler(c)
+
+	opts := paho.NewClientOptions().
+		AddBroker(s.URL).
+		SetClientID(s.ClientID).
+		SetUsername(s.Username).
+		SetPassword(s.Password).
+		SetCleanSession(true).
+		SetAutoReconnect(true).
+		SetConnectRetry(true).
+		SetConnectRetryInterval(2 * time.Second).
+		SetMaxReconnectInterval(time.Minute).
+		SetOnConnectHandler(func(client paho.Client) {
+			brokerConnected.WithLabelValues(brokerMQTT).Set(1)
+			logger.Info("Connected to MQTT broker", "url", s.URL)
+			// Subscriptions are not kept by the broker with a clean session,
+			// so they are made again on every connect.
+			token := client.SubscribeMultiple(filters, onMessage)
+			if token.Wait() && token.Error() != nil {
+				logger.Error("Error subscribing to MQTT topics", "error", token.Error(), "topics", s.Topics)
+			}
+		}).
+		SetConnectionLostHandler(func(_ paho.Client, err error) {
+			brokerConnected.WithLabelValues(brokerMQTT).Set(0)
+			logger.Warn("Connection to MQTT broker lost", "url", s.URL, "error", err)
+		}).
+		SetReconnectingHandler(func(_ paho.Client, _ *paho.ClientOptions) {
+			brokerReconnects.WithLabelValues(brokerMQTT).Inc()
+		})
+
+	client := paho.NewClient(opts)
+	// With ConnectRetry the token only completes once connected, so it is
+	// not waited for here.
+	client.Connect()
+
+	<-ctx.Done()
+	client.Disconnect(250)
+	brokerConnected.WithLabelValues(brokerMQTT).Set(0)
+	return nil
+}
@@ -0,0 +1,110 @@
+package pushbroker
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/live/pipeline"
+)
+
+type testMQTTMessage struct {
+	paho.Message
+	topic   string
+	payload []byte
+}
+
+func (m testMQTTMessage) Topic() string   { return m.topic }
+func (m testMQTTMessage) Payload() []byte { return m.payload }
+
+func TestMQTTMessageHandler(t *testing.T) {
+	c, publisher := newTestConsumer(t, brokerMQTT, pipeline.ConverterTypeInfluxAuto, 16)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() { _ = c.run(ctx) }()
+
+	mqttMessageHandler(c)(nil, testMQTTMessage{topic: "/sensors/room1", payload: []byte("temperature,sensor=a value=21.5")})
+
+	require.Eventually(t, func() bool {
+		return len(publisher.published()) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, "stream/mqtt/sensors/room1/temperature", publisher.published()[0])
+}
+
+func startMQTTBroker(t *testing.T, address string) *mochi.Server {
+	t.Helper()
+	server := mochi.New(&mochi.Options{InlineClient: true, Logger: slog.New(slog.NewTextHandler(io.Discard, nil))})
+	require.NoError(t, server.AddHook(new(auth.AllowHook), nil))
+	require.NoError(t, server.AddListener(listeners.NewTCP(listeners.Config{ID: "tcp", Address: address})))
+	require.NoError(t, server.Serve())
+	return server
+}
+
+func TestIntegrationMQTT(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	address := freeTCPAddress(t)
+	server := startMQTTBroker(t, address)
+	// The server is replaced when the client reconnects.
+	t.Cleanup(func() { _ = server.Close() })
+
+	c, publisher := newTestConsumer(t, brokerMQTT, pipeline.ConverterTypeInfluxAuto, 16)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() { _ = c.run(ctx) }()
+	done := make(chan error, 1)
+	go func() {
+		done <- runMQTT(ctx, brokerSettings{
+			URL:      "tcp://" + address,
+			ClientID: "grafana-test",
+			Topics:   []string{"sensors/#"},
+		}, c)
+	}()
+
+	// Keep publishing until the client is subscribed.
+	require.Eventually(t, func() bool {
+		require.NoError(t, server.Publish("sensors/room1", []byte("temperature,sensor=a value=21.5"), false, 0))
+		return len(publisher.published()) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Equal(t, "stream/mqtt/sensors/room1/temperature", publisher.published()[0])
+
+	t.Run("subscribes again after reconnecting", func(t *testing.T) {
+		reconnects := testutil.ToFloat64(brokerReconnects.WithLabelValues(brokerMQTT))
+		require.NoError(t, server.Close())
+		server = startMQTTBroker(t, address)
+
+		require.Eventually(t, func() bool {
+			require.NoError(t, server.Publish("sensors/room2", []byte("humidity,sensor=b value=40"), false, 0))
+			return slices.Contains(publisher.published(), "stream/mqtt/sensors/room2/humidity")
+		}, 15*time.Second, 50*time.Millisecond)
+		require.Greater(t, testutil.ToFloat64(brokerReconnects.WithLabelValues(brokerMQTT)), reconnects)
+		require.Equal(t, float64(1), testutil.ToFloat64(brokerConnected.WithLabelValues(brokerMQTT)))
+	})
+
+	t.Run("disconnects when the context is done", func(t *testing.T) {
+		cancel()
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("runMQTT did not return after the context was done")
+		}
+		require.Equal(t, float64(0), testutil.ToFloat64(brokerConnected.WithLabelValues(brokerMQTT)))
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt64(&server.Info.ClientsConnected) == 0
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+}
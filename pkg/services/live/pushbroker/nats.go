@@ -0,0 +1,86 @@
+package pushbroker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+const brokerNATS = "nats"
+
+// natsSubjectPath builds a Live channel path from a NATS subject by turning
+// subject tokens into path segments.
+func natsSubjectPath(subject string) string {
+	return strings.ReplaceAll(subject, ".", "/")
+}
+
+// natsMessageHandler passes NATS messages to the consumer.
+func natsMessageHandler(c *consumer) nats.MsgHandler {
+	return func(m *nats.Msg) {
+		c.enqueue(natsSubjectPath(m.Subject), m.Data)
+	}
+}
+
+// runNATS subscribes to NATS subjects and passes messages to the consumer
+// until ctx is done. The client reconnects without limit, and subscriptions
+// are restored by the client after a reconnect.
+func runNATS(ctx context.Context, s brokerSettings, c *consumer) error {
+	opts := []nats.Option{
+		nats.Name("grafana-live"),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+		nats.ReconnectWait(2 * time.Second),
+		nats.ConnectHandler(func(_ *nats.Conn) {
+			brokerConnected.WithLabelValues(brokerNATS).Set(1)
+			logger.Info("Connected to NATS server", "url", s.URL)
+		}),
+		nats.ReconnectHandler(func(_ *nats.Conn) {
+			brokerConnected.WithLabelValues(brokerNATS).Set(1)
+			brokerReconnects.WithLabelValues(brokerNATS).Inc()
+			logger.Info("Reconnected to NATS server", "url", s.URL)
+		}),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			brokerConnected.WithLabelValues(brokerNATS).Set(0)
+			if err != nil {
+				logger.Warn("Connection to NATS server lost", "url", s.URL, "error", err)
+			}
+		}),
+		nats.ErrorHandler(func(_ *nats.Conn, _ *nats.Subscription, err error) {
+			if errors.Is(err, nats.ErrSlowConsumer) {
+				messagesDropped.WithLabelValues(brokerNATS, dropReasonSlowConsumer).Inc()
+				return
+			}
+			logger.Error("NATS error", "error", err)
+		}),
+	}
+	if s.Username != "" {
+		opts = append(opts, nats.UserInfo(s.Username, s.Password))
+	}
+	if s.Token != "" {
+		opts = append(opts, nats.Token(s.Token))
+	}
+
+	conn, err := nats.Connect(s.URL, opts...)
+	if err != nil {
+		return fmt.Errorf("can't connect to NATS server: %w", err)
+	}
+	defer conn.Close()
+	if conn.IsConnected() {
+		brokerConnected.WithLabelValues(brokerNATS).Set(1)
+	}
+
+	for _, subject := range s.Topics {
+		_, err := conn.Subscribe(subject, natsMessageHandler(c))
+		if err != nil {
+			return fmt.Errorf("can't subscribe to NATS subject %s: %w", subject, err)
+		}
+	}
+
+	<-ctx.Done()
+	brokerConnected.WithLabelValues(brokerNATS).Set(0)
+	return nil
+}
@@ -0,0 +1,108 @@
+package pushbroker
+
+import (
+	"context"
+	"net"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	natsserver "github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/live/pipeline"
+)
+
+func TestNATSMessageHandler(t *testing.T) {
+	c, publisher := newTestConsumer(t, brokerNATS, pipeline.ConverterTypeJsonAuto, 16)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() { _ = c.run(ctx) }()
+
+	natsMessageHandler(c)(&nats.Msg{Subject: "sensors.room1", Data: []byte(`{"temperature": 21.5}`)})
+
+	require.Eventually(t, func() bool {
+		return len(publisher.published()) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, "stream/nats/sensors/room1", publisher.published()[0])
+}
+
+func startNATSServer(t *testing.T, address string) *natsserver.Server {
+	t.Helper()
+	host, port, err := net.SplitHostPort(address)
+	require.NoError(t, err)
+	p, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	server, err := natsserver.NewServer(&natsserver.Options{Host: host, Port: p, NoSigs: true})
+	require.NoError(t, err)
+	go server.Start()
+	require.True(t, server.ReadyForConnections(5*time.Second))
+	return server
+}
+
+func TestIntegrationNATS(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	address := freeTCPAddress(t)
+	server := startNATSServer(t, address)
+	// The server is replaced when the client reconnects.
+	t.Cleanup(func() { server.Shutdown() })
+
+	c, publisher := newTestConsumer(t, brokerNATS, pipeline.ConverterTypeJsonAuto, 16)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() { _ = c.run(ctx) }()
+	done := make(chan error, 1)
+	go func() {
+		done <- runNATS(ctx, brokerSettings{
+			URL:    server.ClientURL(),
+			Topics: []string{"sensors.>"},
+		}, c)
+	}()
+
+	conn, err := nats.Connect(server.ClientURL(), nats.MaxReconnects(-1), nats.ReconnectWait(50*time.Millisecond))
+	require.NoError(t, err)
+	t.Cleanup(conn.Close)
+
+	// Keep publishing until the client is subscribed.
+	require.Eventually(t, func() bool {
+		require.NoError(t, conn.Publish("sensors.room1", []byte(`{"temperature": 21.5}`)))
+		return len(publisher.published()) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Equal(t, "stream/nats/sensors/room1", publisher.published()[0])
+
+	t.Run("restores subscriptions after reconnecting", func(t *testing.T) {
+		reconnects := testutil.ToFloat64(brokerReconnects.WithLabelValues(brokerNATS))
+		server.Shutdown()
+		server.WaitForShutdown()
+		server = startNATSServer(t, address)
+
+		require.Eventually(t, func() bool {
+			// Publishing fails while the test connection is reconnecting.
+			_ = conn.Publish("sensors.room2", []byte(`{"humidity": 40}`))
+			return slices.Contains(publisher.published(), "stream/nats/sensors/room2")
+		}, 15*time.Second, 50*time.Millisecond)
+		require.Greater(t, testutil.ToFloat64(brokerReconnects.WithLabelValues(brokerNATS)), reconnects)
+		require.Equal(t, float64(1), testutil.ToFloat64(brokerConnected.WithLabelValues(brokerNATS)))
+	})
+
+	t.Run("closes the connection when the context is done", func(t *testing.T) {
+		cancel()
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("runNATS did not return after the context was done")
+		}
+		require.Equal(t, float64(0), testutil.ToFloat64(brokerConnected.WithLabelValues(brokerNATS)))
+		require.Eventually(t, func() bool {
+			// Only the connection of the test is left.
+			return server.NumClients() == 1
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+}
@@ -0,0 +1,72 @@
+package pushbroker
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/live"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+var (
+	logger = log.New("live.push_broker")
+)
+
+// Service subscribes to topics of MQTT and NATS message brokers and
+// publishes the received messages to Grafana Live.
+type Service struct {
+	live *live.GrafanaLive
+	mqtt brokerSettings
+	nats brokerSettings
+}
+
+func ProvideService(cfg *setting.Cfg, grafanaLive *live.GrafanaLive) (*Service, error) {
+	mqttSettings, err := readMQTTSettings(cfg)
+	if err != nil {
+		return nil, err
+	}
+	natsSettings, err := readNATSSettings(cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range []brokerSettings{mqttSettings, natsSettings} {
+		if s.Enabled && s.Converter == converterPipeline && !cfg.LivePipelineEnabled {
+			return nil, fmt.Errorf("converter %q requires [live] pipeline_enabled", converterPipeline)
+		}
+	}
+	return &Service{
+		live: grafanaLive,
+		mqtt: mqttSettings,
+		nats: natsSettings,
+	}, nil
+}
+
+// IsDisabled returns true when no message broker is enabled.
+func (s *Service) IsDisabled() bool {
+	return !s.mqtt.Enabled && !s.nats.Enabled
+}
+
+// Run Service.
+func (s *Service) Run(ctx context.Context) error {
+	g, ctx := errgroup.WithContext(ctx)
+	if s.mqtt.Enabled {
+		c, err := newConsumer(brokerMQTT, s.mqtt, s.live.ManagedStreamRunner, s.live.Pipeline)
+		if err != nil {
+			return err
+		}
+		g.Go(func() error { return c.run(ctx) })
+		g.Go(func() error { return runMQTT(ctx, s.mqtt, c) })
+	}
+	if s.nats.Enabled {
+		c, err := newConsumer(brokerNATS, s.nats, s.live.ManagedStreamRunner, s.live.Pipeline)
+		if err != nil {
+			return err
+		}
+		g.Go(func() error { return c.run(ctx) })
+		g.Go(func() error { return runNATS(ctx, s.nats, c) })
+	}
+	return g.Wait()
+}
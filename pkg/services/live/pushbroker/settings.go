@@ -0,0 +1,114 @@
+package pushbroker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/grafana/grafana-plugin-sdk-go/live"
+
+	"github.com/grafana/grafana/pkg/services/live/pipeline"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// converterPipeline passes messages to the Live pipeline, where channel rules
+// decide how they are converted and where the frames go.
+const converterPipeline = "pipeline"
+
+type brokerSettings struct {
+	Enabled bool
+	URL     string
+	// ClientID is only used by MQTT.
+	ClientID string
+	Username string
+	Password string
+	// Token is only used by NATS.
+	Token string
+	// QoS is only used by MQTT.
+	QoS byte
+	// Topics are MQTT topic filters or NATS subjects to subscribe to.
+	Topics []string
+	// OrgID is the organization messages are published to.
+	OrgID int64
+	// Namespace is the namespace of stream/<namespace>/<topic> channels.
+	Namespace   string
+	Converter   string
+	FrameFormat string
+	// QueueSize is the number of messages buffered before new ones are dropped.
+	QueueSize int
+}
+
+func readMQTTSettings(cfg *setting.Cfg) (brokerSettings, error) {
+	section := cfg.SectionWithEnvOverrides("live.mqtt")
+	s := brokerSettings{
+		Enabled:  section.Key("enabled").MustBool(false),
+		URL:      section.Key("url").MustString("tcp://127.0.0.1:1883"),
+		ClientID: section.Key("client_id").MustString("grafana-live"),
+		Username: section.Key("username").MustString(""),
+		Password: section.Key("password").MustString(""),
+		Topics:   splitList(section.Key("topics").MustString("")),
+	}
+	qos := section.Key("qos").MustInt(0)
+	if qos < 0 || qos > 2 {
+		return s, fmt.Errorf("unexpected value %d for [live.mqtt] qos", qos)
+	}
+	s.QoS = byte(qos)
+	if err := readCommonSettings(section, "mqtt", &s); err != nil {
+		return s, err
+	}
+	return s, nil
+}
+
+func readNATSSettings(cfg *setting.Cfg) (brokerSettings, error) {
+	section := cfg.SectionWithEnvOverrides("live.nats")
+	s := brokerSettings{
+		Enabled:  section.Key("enabled").MustBool(false),
+		URL:      section.Key("url").MustString("nats://127.0.0.1:4222"),
+		Username: section.Key("username").MustString(""),
+		Password: section.Key("password").MustString(""),
+		Token:    section.Key("token").MustString(""),
+		Topics:   splitList(section.Key("subjects").MustString("")),
+	}
+	if err := readCommonSettings(section, "nats", &s); err != nil {
+		return s, err
+	}
+	return s, nil
+}
+
+func readCommonSettings(section *setting.DynamicSection, broker string, s *brokerSettings) error {
+	s.OrgID = section.Key("org_id").MustInt64(1)
+	s.Namespace = section.Key("namespace").MustString(broker)
+	s.Converter = section.Key("converter").MustString(pipeline.ConverterTypeInfluxAuto)
+	s.FrameFormat = section.Key("frame_format").MustString("labels_column")
+	s.QueueSize = section.Key("queue_size").MustInt(1024)
+
+	if !s.Enabled {
+		return nil
+	}
+	if len(s.Topics) == 0 {
+		return fmt.Errorf("[live.%s] requires at least one topic to subscribe to", broker)
+	}
+	ch := live.Channel{Scope: live.ScopeStream, Namespace: s.Namespace, Path: "test"}
+	if !ch.IsValid() {
+		return fmt.Errorf("invalid [live.%s] namespace: %q", broker, s.Namespace)
+	}
+	switch s.Converter {
+	case pipeline.ConverterTypeInfluxAuto, pipeline.ConverterTypeJsonAuto, pipeline.ConverterTypeJsonFrame, converterPipeline:
+	default:
+		return fmt.Errorf("unsupported [live.%s] converter: %s", broker, s.Converter)
+	}
+	if s.QueueSize <= 0 {
+		return fmt.Errorf("unexpected value %d for [live.%s] queue_size", s.QueueSize, broker)
+	}
+	return nil
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
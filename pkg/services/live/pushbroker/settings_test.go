@@ -0,0 +1,63 @@
+package pushbroker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/live/pipeline"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestReadSettings(t *testing.T) {
+	cfg, err := setting.NewCfgFromBytes([]byte(`
+[live.mqtt]
+enabled = true
+url = tcp://broker:1883
+topics = """sensors/#, devices/+/telemetry"""
+qos = 1
+
+[live.nats]
+enabled = true
+subjects = telemetry.>
+converter = jsonFrame
+namespace = platform
+`))
+	require.NoError(t, err)
+
+	mqtt, err := readMQTTSettings(cfg)
+	require.NoError(t, err)
+	require.Equal(t, "tcp://broker:1883", mqtt.URL)
+	require.Equal(t, []string{"sensors/#", "devices/+/telemetry"}, mqtt.Topics)
+	require.Equal(t, byte(1), mqtt.QoS)
+	require.Equal(t, "mqtt", mqtt.Namespace)
+	require.Equal(t, pipeline.ConverterTypeInfluxAuto, mqtt.Converter)
+	require.Equal(t, int64(1), mqtt.OrgID)
+
+	nats, err := readNATSSettings(cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{"telemetry.>"}, nats.Topics)
+	require.Equal(t, pipeline.ConverterTypeJsonFrame, nats.Converter)
+	require.Equal(t, "platform", nats.Namespace)
+}
+
+func TestReadSettings_Invalid(t *testing.T) {
+	for name, ini := range map[string]string{
+		"no topics":         "[live.mqtt]\nenabled = true",
+		"unknown converter": "[live.mqtt]\nenabled = true\ntopics = a\nconverter = xml",
+		"invalid namespace": "[live.mqtt]\nenabled = true\ntopics = a\nnamespace = a/b",
+		"invalid qos":       "[live.mqtt]\nenabled = true\ntopics = a\nqos = 3",
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := setting.NewCfgFromBytes([]byte(ini))
+			require.NoError(t, err)
+			_, err = readMQTTSettings(cfg)
+			require.Error(t, err)
+		})
+	}
+
+	cfg, err := setting.NewCfgFromBytes([]byte("[live.mqtt]\nenabled = true\ntopics = a\nconverter = pipeline"))
+	require.NoError(t, err)
+	_, err = ProvideService(cfg, nil)
+	require.ErrorContains(t, err, "pipeline_enabled")
+}
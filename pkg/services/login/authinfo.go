@@ -25,12 +25,14 @@ const (
 	// modules
 	PasswordAuthModule     = "password"
 	PasswordlessAuthModule = "passwordless"
+	WebAuthnAuthModule     = "webauthn"
 	APIKeyAuthModule       = "apikey"
 	SAMLAuthModule         = "auth.saml"
 	LDAPAuthModule         = "ldap"
 	AuthProxyAuthModule    = "authproxy"
 	JWTModule              = "jwt"
 	ExtendedJWTModule      = "extendedjwt"
+	MTLSModule             = "mtls"
 	RenderModule           = "render"
 	// OAuth provider modules
 	AzureADAuthModule    = "oauth_azuread"
@@ -46,6 +48,7 @@ const (
 	SAMLLabel = "SAML"
 	LDAPLabel = "LDAP"
 	JWTLabel  = "JWT"
+	MTLSLabel = "mTLS"
 	// OAuth provider labels
 	AuthProxyLabel    = "Auth Proxy"
 	AzureADLabel      = "AzureAD"
@@ -79,6 +82,8 @@ func GetAuthProviderLabel(authModule string) string {
 		return LDAPLabel
 	case JWTModule:
 		return JWTLabel
+	case MTLSModule:
+		return MTLSLabel
 	case AuthProxyAuthModule:
 		return AuthProxyLabel
 	case GenericOAuthModule, strings.TrimPrefix(GenericOAuthModule, "oauth_"):
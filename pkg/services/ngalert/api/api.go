@@ -48,6 +48,7 @@ type RuleAccessControlService interface {
 	AuthorizeDatasourceAccessForRule(ctx context.Context, user identity.Requester, rule *models.AlertRule) error
 	AuthorizeDatasourceAccessForRuleGroup(ctx context.Context, user identity.Requester, rules models.RulesGroup) error
 	AuthorizeAccessInFolder(ctx context.Context, user identity.Requester, namespaced models.Namespaced) error
+	HasAccessOrError(ctx context.Context, user identity.Requester, evaluator ac.Evaluator, action func() string) error
 }
 
 // API handlers.
@@ -78,6 +79,7 @@ type API struct {
 	ConditionValidator   *eval.ConditionValidator
 	FeatureManager       featuremgmt.FeatureToggles
 	Historian            Historian
+	NotificationHistory  NotificationHistoryStore
 	Tracer               tracing.Tracer
 	AppUrl               *url.URL
 	UserService          user.Service
@@ -149,11 +151,12 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 			authz:           ruleAuthzService,
 			evaluator:       api.EvaluatorFactory,
 			cfg:             &api.Cfg.UnifiedAlerting,
-			backtesting:     backtesting.NewEngine(api.AppUrl, api.EvaluatorFactory, api.Tracer),
+			backtesting:     backtesting.NewEngine(api.AppUrl, api.EvaluatorFactory, api.Tracer, !api.Cfg.UnifiedAlerting.ReservedLabels.IsReservedLabelDisabled(models.FolderTitleLabel)),
 			featureManager:  api.FeatureManager,
 			appUrl:          api.AppUrl,
 			tracer:          api.Tracer,
 			folderService:   api.RuleStore,
+			amConfig:        api.MultiOrgAlertmanager,
 		}), m)
 	api.RegisterConfigurationApiEndpoints(NewConfiguration(
 		&ConfigSrv{
@@ -177,8 +180,9 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 	}), m)
 
 	api.RegisterHistoryApiEndpoints(NewStateHistoryApi(&HistorySrv{
-		logger: logger,
-		hist:   api.Historian,
+		logger:        logger,
+		hist:          api.Historian,
+		notifications: api.NotificationHistory,
 	}), m)
 
 	api.RegisterConvertPrometheusApiEndpoints(NewConvertPrometheusApi(
@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -10,6 +12,7 @@ import (
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/infra/log"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 )
 
@@ -17,9 +20,15 @@ type Historian interface {
 	Query(ctx context.Context, query models.HistoryQuery) (*data.Frame, error)
 }
 
+type NotificationHistoryStore interface {
+	GetNotificationHistory(ctx context.Context, query models.NotificationHistoryQuery) ([]models.NotificationHistoryEntry, error)
+}
+
 type HistorySrv struct {
 	logger log.Logger
 	hist   Historian
+	// notifications is nil when the notification history is disabled.
+	notifications NotificationHistoryStore
 }
 
 const labelQueryPrefix = "labels_"
@@ -56,3 +65,51 @@ func (srv *HistorySrv) RouteQueryStateHistory(c *contextmodel.ReqContext) respon
 	}
 	return response.JSON(http.StatusOK, frame)
 }
+
+func (srv *HistorySrv) RouteQueryNotificationHistory(c *contextmodel.ReqContext) response.Response {
+	if srv.notifications == nil {
+		return ErrResp(http.StatusNotFound, errors.New("notification history is not enabled"), "")
+	}
+
+	status := models.NotificationStatus(c.Query("status"))
+	if status != "" && status != models.NotificationStatusSuccess && status != models.NotificationStatusFailed {
+		return ErrResp(http.StatusBadRequest, fmt.Errorf("invalid status %q, must be %q or %q", status, models.NotificationStatusSuccess, models.NotificationStatusFailed), "")
+	}
+
+	query := models.NotificationHistoryQuery{
+		OrgID:    c.GetOrgID(),
+		RuleUID:  c.Query("ruleUID"),
+		Receiver: c.Query("receiver"),
+		Status:   status,
+		Limit:    c.QueryInt("limit"),
+	}
+	if from := c.QueryInt64("from"); from > 0 {
+		query.From = time.Unix(from, 0)
+	}
+	if to := c.QueryInt64("to"); to > 0 {
+		query.To = time.Unix(to, 0)
+	}
+
+	entries, err := srv.notifications.GetNotificationHistory(c.Req.Context(), query)
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "failed to query notification history")
+	}
+
+	result := make([]apimodels.NotificationHistoryEntry, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, apimodels.NotificationHistoryEntry{
+			Receiver:     e.Receiver,
+			GroupKey:     e.GroupKey,
+			Status:       string(e.Status),
+			Error:        e.Error,
+			ResponseCode: e.ResponseCode,
+			Attempt:      e.Attempt,
+			Duration:     e.Duration,
+			RuleUIDs:     e.RuleUIDs,
+			Alerts:       e.Alerts,
+			PayloadSize:  e.PayloadSize,
+			Timestamp:    e.Timestamp,
+		})
+	}
+	return response.JSON(http.StatusOK, result)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/benbjohnson/clock"
 	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/timeinterval"
 
 	"github.com/grafana/alerting/models"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
@@ -20,6 +21,7 @@ import (
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/tracing"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/datasources"
@@ -41,6 +43,10 @@ type folderService interface {
 	GetNamespaceByUID(ctx context.Context, uid string, orgID int64, user identity.Requester) (*folder.Folder, error)
 }
 
+type alertmanagerConfigProvider interface {
+	GetAlertmanagerConfiguration(ctx context.Context, org int64, withAutogen bool) (apimodels.GettableUserConfig, error)
+}
+
 type TestingApiSrv struct {
 	*AlertingProxy
 	DatasourceCache datasources.CacheService
@@ -53,6 +59,7 @@ type TestingApiSrv struct {
 	appUrl          *url.URL
 	tracer          tracing.Tracer
 	folderService   folderService
+	amConfig        alertmanagerConfigProvider
 }
 
 // RouteTestGrafanaRuleConfig returns a list of potential alerts for a given rule configuration. This is intended to be
@@ -274,7 +281,38 @@ func (srv TestingApiSrv) BacktestAlertRule(c *contextmodel.ReqContext, cmd apimo
 		Labels:          cmd.Labels,
 	}
 
-	result, err := srv.backtesting.Test(c.Req.Context(), c.SignedInUser, rule, cmd.From, cmd.To)
+	folderTitle := ""
+	if cmd.FolderUID != "" {
+		folder, err := srv.folderService.GetNamespaceByUID(c.Req.Context(), cmd.FolderUID, c.GetOrgID(), c.SignedInUser)
+		if err != nil {
+			return toNamespaceErrorResponse(dashboards.ErrFolderAccessDenied)
+		}
+		rule.NamespaceUID = folder.UID
+		folderTitle = folder.Fullpath
+	}
+	if cmd.NotificationSettings != nil {
+		rule.NotificationSettings, err = apivalidation.ValidateNotificationSettings(cmd.NotificationSettings)
+		if err != nil {
+			return ErrResp(400, err, "")
+		}
+	}
+
+	var result *data.Frame
+	if cmd.SimulateNotifications {
+		// The simulation reveals the notification policies and contact points of the organization.
+		if err := srv.authz.HasAccessOrError(c.Req.Context(), c.SignedInUser, ac.EvalPermission(ac.ActionAlertingNotificationsRead), func() string {
+			return "simulate notifications"
+		}); err != nil {
+			return errorToResponse(err)
+		}
+		policy, err := srv.notificationPolicy(c.Req.Context(), c.GetOrgID())
+		if err != nil {
+			return ErrResp(http.StatusInternalServerError, err, "Failed to get the notification policy")
+		}
+		result, err = srv.backtesting.TestNotifications(c.Req.Context(), c.SignedInUser, rule, folderTitle, cmd.From, cmd.To, policy)
+	} else {
+		result, err = srv.backtesting.Test(c.Req.Context(), c.SignedInUser, rule, folderTitle, cmd.From, cmd.To)
+	}
 	if err != nil {
 		if errors.Is(err, backtesting.ErrInvalidInputData) {
 			return ErrResp(400, err, "Failed to evaluate")
@@ -288,3 +326,25 @@ func (srv TestingApiSrv) BacktestAlertRule(c *contextmodel.ReqContext, cmd apimo
 	}
 	return response.JSON(http.StatusOK, body)
 }
+
+// notificationPolicy returns the notification policy tree, inhibition rules and time intervals of the organization.
+func (srv TestingApiSrv) notificationPolicy(ctx context.Context, orgID int64) (*backtesting.NotificationPolicy, error) {
+	cfg, err := srv.amConfig.GetAlertmanagerConfiguration(ctx, orgID, true)
+	if err != nil {
+		return nil, err
+	}
+	amCfg := cfg.AlertmanagerConfig
+	if amCfg.Route == nil {
+		return nil, errors.New("the Alertmanager configuration has no root notification policy")
+	}
+
+	intervals := make(map[string][]timeinterval.TimeInterval, len(amCfg.MuteTimeIntervals)+len(amCfg.TimeIntervals))
+	for _, ti := range amCfg.MuteTimeIntervals {
+		intervals[ti.Name] = ti.TimeIntervals
+	}
+	for _, ti := range amCfg.TimeIntervals {
+		intervals[ti.Name] = ti.TimeIntervals
+	}
+
+	return backtesting.NewNotificationPolicy(amCfg.Route.AsAMRoute(), amCfg.InhibitRules, intervals), nil
+}
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"testing"
 	"time"
@@ -9,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
+	prommodel "github.com/prometheus/common/model"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -392,6 +395,66 @@ func TestRouteEvalQueries(t *testing.T) {
 	})
 }
 
+func TestBacktestAlertRule(t *testing.T) {
+	query := models.GenerateAlertQuery()
+	rc := &contextmodel.ReqContext{
+		Context: &web.Context{
+			Req: &http.Request{},
+		},
+		SignedInUser: &user.SignedInUser{
+			OrgID: 1,
+		},
+	}
+	readerPermissions := []ac.Permission{
+		{Action: ac.ActionAlertingRuleRead, Scope: dashboards.ScopeFoldersAll},
+		{Action: datasources.ActionQuery, Scope: datasources.ScopeProvider.GetResourceScopeUID(query.DatasourceUID)},
+	}
+	newSrv := func(t *testing.T, permissions []ac.Permission) (*TestingApiSrv, *fakeAlertmanagerConfigProvider) {
+		amConfig := &fakeAlertmanagerConfigProvider{}
+		srv := createTestingApiSrv(t, &fakes.FakeCacheService{}, acMock.New().WithPermissions(permissions), nil, featuremgmt.WithFeatures(featuremgmt.FlagAlertingBacktesting), nil)
+		srv.amConfig = amConfig
+		return srv, amConfig
+	}
+	cmd := func(srv *TestingApiSrv) definitions.BacktestConfig {
+		return definitions.BacktestConfig{
+			From:                  time.Now().Add(-time.Hour),
+			To:                    time.Now(),
+			Interval:              prommodel.Duration(srv.cfg.BaseInterval),
+			Condition:             query.RefID,
+			Data:                  ApiAlertQueriesFromAlertQueries([]models.AlertQuery{query}),
+			NoDataState:           definitions.NoData,
+			SimulateNotifications: true,
+		}
+	}
+
+	t.Run("should return Forbidden if user cannot read notification policies when simulating notifications", func(t *testing.T) {
+		srv, amConfig := newSrv(t, readerPermissions)
+
+		response := srv.BacktestAlertRule(rc, cmd(srv))
+
+		require.Equal(t, http.StatusForbidden, response.Status())
+		require.False(t, amConfig.called)
+	})
+
+	t.Run("should read notification policies if user can read them", func(t *testing.T) {
+		srv, amConfig := newSrv(t, append(readerPermissions, ac.Permission{Action: ac.ActionAlertingNotificationsRead}))
+
+		response := srv.BacktestAlertRule(rc, cmd(srv))
+
+		require.Equal(t, http.StatusInternalServerError, response.Status())
+		require.True(t, amConfig.called)
+	})
+}
+
+type fakeAlertmanagerConfigProvider struct {
+	called bool
+}
+
+func (f *fakeAlertmanagerConfigProvider) GetAlertmanagerConfiguration(_ context.Context, _ int64, _ bool) (definitions.GettableUserConfig, error) {
+	f.called = true
+	return definitions.GettableUserConfig{}, errors.New("no configuration")
+}
+
 func createTestingApiSrv(t *testing.T, ds *fakes.FakeCacheService, ac *acMock.Mock, evaluator eval.EvaluatorFactory, featureManager featuremgmt.FeatureToggles, ruleStore RuleStore) *TestingApiSrv {
 	if ac == nil {
 		ac = acMock.New()
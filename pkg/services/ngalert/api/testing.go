@@ -157,6 +157,10 @@ func (f fakeRuleAccessControlService) AuthorizeDatasourceAccessForRuleGroup(ctx
 	return nil
 }
 
+func (f fakeRuleAccessControlService) HasAccessOrError(ctx context.Context, user identity.Requester, evaluator ac.Evaluator, action func() string) error {
+	return nil
+}
+
 type statesReader interface {
 	GetStatesForRuleUID(orgID int64, alertRuleUID string) []*state.State
 }
@@ -31,6 +31,8 @@ type DingdingIntegration struct {
 	MessageType *string `json:"msgType,omitempty" yaml:"msgType,omitempty" hcl:"message_type"`
 	Title       *string `json:"title,omitempty" yaml:"title,omitempty" hcl:"title"`
 	Message     *string `json:"message,omitempty" yaml:"message,omitempty" hcl:"message"`
+
+	HTTPConfig *HTTPClientConfig `json:"http_config,omitempty" yaml:"http_config,omitempty" hcl:"http_config,block"`
 }
 
 type DiscordIntegration struct {
@@ -41,6 +43,8 @@ type DiscordIntegration struct {
 	Message            *string `json:"message,omitempty" yaml:"message,omitempty" hcl:"message"`
 	AvatarURL          *string `json:"avatar_url,omitempty" yaml:"avatar_url,omitempty" hcl:"avatar_url"`
 	UseDiscordUsername *bool   `json:"use_discord_username,omitempty" yaml:"use_discord_username,omitempty" hcl:"use_discord_username"`
+
+	HTTPConfig *HTTPClientConfig `json:"http_config,omitempty" yaml:"http_config,omitempty" hcl:"http_config,block"`
 }
 
 type EmailIntegration struct {
@@ -60,6 +64,8 @@ type GooglechatIntegration struct {
 
 	Title   *string `json:"title,omitempty" yaml:"title,omitempty" hcl:"title"`
 	Message *string `json:"message,omitempty" yaml:"message,omitempty" hcl:"message"`
+
+	HTTPConfig *HTTPClientConfig `json:"http_config,omitempty" yaml:"http_config,omitempty" hcl:"http_config,block"`
 }
 
 type JiraIntegration struct {
@@ -83,6 +89,8 @@ type JiraIntegration struct {
 	User     *Secret `yaml:"user,omitempty" json:"user,omitempty" hcl:"user"`
 	Password *Secret `yaml:"password,omitempty" json:"password,omitempty" hcl:"password"`
 	Token    *Secret `yaml:"api_token,omitempty" json:"api_token,omitempty" hcl:"api_token"`
+
+	HTTPConfig *HTTPClientConfig `json:"http_config,omitempty" yaml:"http_config,omitempty" hcl:"http_config,block"`
 }
 
 type KafkaIntegration struct {
@@ -97,6 +105,8 @@ type KafkaIntegration struct {
 	Password       *Secret `json:"password,omitempty" yaml:"password,omitempty" hcl:"password"`
 	APIVersion     *string `json:"apiVersion,omitempty" yaml:"apiVersion,omitempty" hcl:"api_version"`
 	KafkaClusterID *string `json:"kafkaClusterId,omitempty" yaml:"kafkaClusterId,omitempty" hcl:"cluster_id"`
+
+	HTTPConfig *HTTPClientConfig `json:"http_config,omitempty" yaml:"http_config,omitempty" hcl:"http_config,block"`
 }
 
 type LineIntegration struct {
@@ -106,6 +116,8 @@ type LineIntegration struct {
 
 	Title       *string `json:"title,omitempty" yaml:"title,omitempty" hcl:"title"`
 	Description *string `json:"description,omitempty" yaml:"description,omitempty" hcl:"description"`
+
+	HTTPConfig *HTTPClientConfig `json:"http_config,omitempty" yaml:"http_config,omitempty" hcl:"http_config,block"`
 }
 
 type TLSConfig struct {
@@ -143,6 +155,8 @@ type OnCallIntegration struct {
 	Password                 *Secret `json:"password,omitempty" yaml:"password,omitempty" hcl:"basic_auth_password"`
 	Title                    *string `json:"title,omitempty" yaml:"title,omitempty" hcl:"title"`
 	Message                  *string `json:"message,omitempty" yaml:"message,omitempty" hcl:"message"`
+
+	HTTPConfig *HTTPClientConfig `json:"http_config,omitempty" yaml:"http_config,omitempty" hcl:"http_config,block"`
 }
 
 type OpsgenieIntegrationResponder struct {
@@ -164,6 +178,8 @@ type OpsgenieIntegration struct {
 	OverridePriority *bool                          `json:"overridePriority,omitempty" yaml:"overridePriority,omitempty" hcl:"override_priority"`
 	SendTagsAs       *string                        `json:"sendTagsAs,omitempty" yaml:"sendTagsAs,omitempty" hcl:"send_tags_as"`
 	Responders       []OpsgenieIntegrationResponder `json:"responders,omitempty" yaml:"responders,omitempty" hcl:"responders,block"`
+
+	HTTPConfig *HTTPClientConfig `json:"http_config,omitempty" yaml:"http_config,omitempty" hcl:"http_config,block"`
 }
 
 type PagerdutyIntegration struct {
@@ -181,6 +197,8 @@ type PagerdutyIntegration struct {
 	ClientURL *string            `json:"client_url,omitempty" yaml:"client_url,omitempty" hcl:"client_url"`
 	Details   *map[string]string `json:"details,omitempty" yaml:"details,omitempty" hcl:"details"`
 	URL       *string            `json:"url,omitempty" yaml:"url,omitempty" hcl:"url"`
+
+	HTTPConfig *HTTPClientConfig `json:"http_config,omitempty" yaml:"http_config,omitempty" hcl:"http_config,block"`
 }
 
 type PushoverIntegration struct {
@@ -199,6 +217,8 @@ type PushoverIntegration struct {
 	Title            *string `json:"title,omitempty" yaml:"title,omitempty" hcl:"title"`
 	Message          *string `json:"message,omitempty" yaml:"message,omitempty" hcl:"message"`
 	UploadImage      *bool   `json:"uploadImage,omitempty" yaml:"uploadImage,omitempty" hcl:"upload_image"`
+
+	HTTPConfig *HTTPClientConfig `json:"http_config,omitempty" yaml:"http_config,omitempty" hcl:"http_config,block"`
 }
 
 type SensugoIntegration struct {
@@ -212,6 +232,8 @@ type SensugoIntegration struct {
 	Namespace *string `json:"namespace,omitempty" yaml:"namespace,omitempty" hcl:"namespace"`
 	Handler   *string `json:"handler,omitempty" yaml:"handler,omitempty" hcl:"handler"`
 	Message   *string `json:"message,omitempty" yaml:"message,omitempty" hcl:"message"`
+
+	HTTPConfig *HTTPClientConfig `json:"http_config,omitempty" yaml:"http_config,omitempty" hcl:"http_config,block"`
 }
 
 type SigV4Config struct {
@@ -265,6 +287,8 @@ type TelegramIntegration struct {
 	DisableWebPagePreview *bool   `json:"disable_web_page_preview,omitempty" yaml:"disable_web_page_preview,omitempty" hcl:"disable_web_page_preview"`
 	ProtectContent        *bool   `json:"protect_content,omitempty" yaml:"protect_content,omitempty" hcl:"protect_content"`
 	DisableNotifications  *bool   `json:"disable_notifications,omitempty" yaml:"disable_notifications,omitempty" hcl:"disable_notifications"`
+
+	HTTPConfig *HTTPClientConfig `json:"http_config,omitempty" yaml:"http_config,omitempty" hcl:"http_config,block"`
 }
 
 type TeamsIntegration struct {
@@ -275,6 +299,8 @@ type TeamsIntegration struct {
 	Message      *string `json:"message,omitempty" yaml:"message,omitempty" hcl:"message"`
 	Title        *string `json:"title,omitempty" yaml:"title,omitempty" hcl:"title"`
 	SectionTitle *string `json:"sectiontitle,omitempty" yaml:"sectiontitle,omitempty" hcl:"section_title"`
+
+	HTTPConfig *HTTPClientConfig `json:"http_config,omitempty" yaml:"http_config,omitempty" hcl:"http_config,block"`
 }
 
 type ThreemaIntegration struct {
@@ -286,6 +312,8 @@ type ThreemaIntegration struct {
 
 	Title       *string `json:"title,omitempty" yaml:"title,omitempty" hcl:"title"`
 	Description *string `json:"description,omitempty" yaml:"description,omitempty" hcl:"description"`
+
+	HTTPConfig *HTTPClientConfig `json:"http_config,omitempty" yaml:"http_config,omitempty" hcl:"http_config,block"`
 }
 
 type VictoropsIntegration struct {
@@ -296,6 +324,8 @@ type VictoropsIntegration struct {
 	MessageType *string `json:"messageType,omitempty" yaml:"messageType,omitempty" hcl:"message_type"`
 	Title       *string `json:"title,omitempty" yaml:"title,omitempty" hcl:"title"`
 	Description *string `json:"description,omitempty" yaml:"description,omitempty" hcl:"description"`
+
+	HTTPConfig *HTTPClientConfig `json:"http_config,omitempty" yaml:"http_config,omitempty" hcl:"http_config,block"`
 }
 
 type WebexIntegration struct {
@@ -306,6 +336,8 @@ type WebexIntegration struct {
 	APIURL  *string `json:"api_url,omitempty" yaml:"api_url,omitempty" hcl:"api_url"`
 	Message *string `json:"message,omitempty" yaml:"message,omitempty" hcl:"message"`
 	RoomID  *string `json:"room_id,omitempty" yaml:"room_id,omitempty" hcl:"room_id"`
+
+	HTTPConfig *HTTPClientConfig `json:"http_config,omitempty" yaml:"http_config,omitempty" hcl:"http_config,block"`
 }
 
 type WebhookIntegration struct {
@@ -390,6 +422,8 @@ type WecomIntegration struct {
 	Title   *string `json:"title,omitempty" yaml:"title,omitempty" hcl:"title"`
 	MsgType *string `json:"msgtype,omitempty" yaml:"msgtype,omitempty" hcl:"msg_type"`
 	ToUser  *string `json:"touser,omitempty" yaml:"touser,omitempty" hcl:"to_user"`
+
+	HTTPConfig *HTTPClientConfig `json:"http_config,omitempty" yaml:"http_config,omitempty" hcl:"http_config,block"`
 }
 
 type ContactPoint struct {
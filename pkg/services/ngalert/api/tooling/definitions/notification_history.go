@@ -0,0 +1,80 @@
+package definitions
+
+import "time"
+
+// swagger:route GET /v1/notifications/history history RouteGetNotificationHistory
+//
+// Query notification history.
+//
+// Allows to query the attempts of the Grafana Alertmanager contact points to deliver notifications, including retries.
+// Requires the notification history to be enabled in the [unified_alerting.notification_history] section of the configuration.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: NotificationHistory
+//       400: ValidationError
+//       403: ForbiddenError
+//       404: NotFound
+//       500: Failure
+
+// swagger:response NotificationHistory
+type NotificationHistory struct {
+	// in:body
+	Body []NotificationHistoryEntry
+}
+
+// NotificationHistoryEntry is an attempt of a contact point to deliver a notification.
+// swagger:model
+type NotificationHistoryEntry struct {
+	Receiver string `json:"receiver"`
+	// Key of the notified alert group.
+	GroupKey string `json:"groupKey"`
+	// Either success or failed.
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// HTTP status code returned to the failed attempt, when the integration reported one.
+	ResponseCode int `json:"responseCode,omitempty"`
+	// Starts at 1 and is incremented with each retry. The attempts of all the integrations of the contact point are counted together.
+	Attempt int `json:"attempt"`
+	// Duration of the attempt in milliseconds.
+	Duration int64 `json:"duration"`
+	// UIDs of the rules of the notified alerts.
+	RuleUIDs []string `json:"ruleUIDs"`
+	// Number of notified alerts.
+	Alerts int `json:"alerts"`
+	// Size in bytes of the JSON encoded notified alerts.
+	PayloadSize int       `json:"payloadSize"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// NotificationHistoryParams is the struct used as parameters for the RouteGetNotificationHistory endpoint.
+//
+// swagger:parameters RouteGetNotificationHistory
+type NotificationHistoryParams struct {
+	// The timestamp of the start point of the time range the history is obtained.
+	// in:query
+	// required: false
+	From int64 `json:"from"`
+	// The timestamp of the end point of the time range the history is obtained.
+	// in:query
+	// required: false
+	To int64 `json:"to"`
+	// Limits the number of records that needs to be returned.
+	// in:query
+	// required: false
+	Limit int `json:"limit"`
+	// Filter by the UID of a rule whose alerts were notified.
+	// in:query
+	// required: false
+	RuleUID string `json:"ruleUID"`
+	// Filter by contact point name.
+	// in:query
+	// required: false
+	Receiver string `json:"receiver"`
+	// Filter by status of the attempt, success or failed.
+	// in:query
+	// required: false
+	Status string `json:"status"`
+}
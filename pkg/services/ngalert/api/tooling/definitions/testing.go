@@ -230,6 +230,16 @@ type BacktestConfig struct {
 	Annotations map[string]string `json:"annotations,omitempty"`
 
 	NoDataState NoDataState `json:"no_data_state"`
+
+	// The UID of the folder of the rule. The alerts get the folder labels of the rule, which notification policies can
+	// match on.
+	FolderUID string `json:"folder_uid,omitempty"`
+	// Sends the alerts of the rule to a contact point, rather than through the notification policies.
+	NotificationSettings *AlertRuleNotificationSettings `json:"notification_settings,omitempty"`
+
+	// If true, the results are replayed through the notification policies of the organization, and the
+	// notifications that would have been sent are returned instead of the state of the alerts.
+	SimulateNotifications bool `json:"simulate_notifications,omitempty"`
 }
 
 // swagger:model
@@ -42,11 +42,15 @@ type stateManager interface {
 type Engine struct {
 	evalFactory        eval.EvaluatorFactory
 	createStateManager func() stateManager
+	includeFolder      bool
 }
 
-func NewEngine(appUrl *url.URL, evalFactory eval.EvaluatorFactory, tracer tracing.Tracer) *Engine {
+// NewEngine creates a backtesting engine. includeFolder sets whether the alerts get the folder title label, like
+// the alerts of the scheduler.
+func NewEngine(appUrl *url.URL, evalFactory eval.EvaluatorFactory, tracer tracing.Tracer, includeFolder bool) *Engine {
 	return &Engine{
-		evalFactory: evalFactory,
+		evalFactory:   evalFactory,
+		includeFolder: includeFolder,
 		createStateManager: func() stateManager {
 			cfg := state.ManagerCfg{
 				Metrics:       nil,
@@ -63,41 +67,18 @@ func NewEngine(appUrl *url.URL, evalFactory eval.EvaluatorFactory, tracer tracin
 	}
 }
 
-func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models.AlertRule, from, to time.Time) (*data.Frame, error) {
-	ruleCtx := models.WithRuleKey(ctx, rule.GetKey())
-	logger := logger.FromContext(ctx)
-
-	if !from.Before(to) {
-		return nil, fmt.Errorf("%w: invalid interval of the backtesting [%d,%d]", ErrInvalidInputData, from.Unix(), to.Unix())
-	}
-	if to.Sub(from).Seconds() < float64(rule.IntervalSeconds) {
-		return nil, fmt.Errorf("%w: interval of the backtesting [%d,%d] is less than evaluation interval [%ds]", ErrInvalidInputData, from.Unix(), to.Unix(), rule.IntervalSeconds)
-	}
-	length := int(to.Sub(from).Seconds()) / int(rule.IntervalSeconds)
-
-	stateManager := e.createStateManager()
-
-	evaluator, err := backtestingEvaluatorFactory(ruleCtx, e.evalFactory, user, rule.GetEvalCondition().WithSource("backtesting"), &schedule.AlertingResultsFromRuleState{
-		Manager: stateManager,
-		Rule:    rule,
-	})
+// Test evaluates the rule over the time range and returns a frame with the state of every alert at every evaluation.
+// The folder title is used for the built-in labels of the alerts.
+func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models.AlertRule, folderTitle string, from, to time.Time) (*data.Frame, error) {
+	length, err := evaluationsCount(rule, from, to)
 	if err != nil {
-		return nil, errors.Join(ErrInvalidInputData, err)
+		return nil, err
 	}
 
-	logger.Info("Start testing alert rule", "from", from, "to", to, "interval", rule.IntervalSeconds, "evaluations", length)
-
-	start := time.Now()
-
 	tsField := data.NewField("Time", nil, make([]time.Time, length))
 	valueFields := make(map[data.Fingerprint]*data.Field)
 
-	err = evaluator.Eval(ruleCtx, from, time.Duration(rule.IntervalSeconds)*time.Second, length, func(idx int, currentTime time.Time, results eval.Results) error {
-		if idx >= length {
-			logger.Info("Unexpected evaluation. Skipping", "from", from, "to", to, "interval", rule.IntervalSeconds, "evaluationTime", currentTime, "evaluationIndex", idx, "expectedEvaluations", length)
-			return nil
-		}
-		states := stateManager.ProcessEvalResults(ruleCtx, currentTime, rule, results, nil, nil)
+	err = e.run(ctx, user, rule, folderTitle, from, length, func(idx int, currentTime time.Time, states []state.StateTransition) {
 		tsField.Set(idx, currentTime)
 		for _, s := range states {
 			field, ok := valueFields[s.CacheID]
@@ -114,20 +95,84 @@ func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models
 				continue
 			}
 		}
-		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
 	fields := make([]*data.Field, 0, len(valueFields)+1)
 	fields = append(fields, tsField)
 	for _, f := range valueFields {
 		fields = append(fields, f)
 	}
-	result := data.NewFrame("Testing results", fields...)
+	return data.NewFrame("Testing results", fields...), nil
+}
+
+// TestNotifications evaluates the rule like Test does, and replays the resulting alerts through the notification policy.
+// It returns a frame with one row per notification that would have been sent, taking into account grouping,
+// group_wait, group_interval, repeat_interval, inhibition rules and mute timings.
+func (e *Engine) TestNotifications(ctx context.Context, user identity.Requester, rule *models.AlertRule, folderTitle string, from, to time.Time, policy *NotificationPolicy) (*data.Frame, error) {
+	length, err := evaluationsCount(rule, from, to)
+	if err != nil {
+		return nil, err
+	}
 
+	simulator := newNotificationSimulator(policy)
+	err = e.run(ctx, user, rule, folderTitle, from, length, func(_ int, currentTime time.Time, states []state.StateTransition) {
+		simulator.process(currentTime, states)
+	})
 	if err != nil {
 		return nil, err
 	}
+	return notificationsToFrame(simulator.finish(to)), nil
+}
+
+// evaluationsCount validates the time range of the backtesting and returns the number of evaluations of the rule in it.
+func evaluationsCount(rule *models.AlertRule, from, to time.Time) (int, error) {
+	if !from.Before(to) {
+		return 0, fmt.Errorf("%w: invalid interval of the backtesting [%d,%d]", ErrInvalidInputData, from.Unix(), to.Unix())
+	}
+	if to.Sub(from).Seconds() < float64(rule.IntervalSeconds) {
+		return 0, fmt.Errorf("%w: interval of the backtesting [%d,%d] is less than evaluation interval [%ds]", ErrInvalidInputData, from.Unix(), to.Unix(), rule.IntervalSeconds)
+	}
+	return int(to.Sub(from).Seconds()) / int(rule.IntervalSeconds), nil
+}
+
+// run evaluates the rule the given number of times starting at from, and passes the state transitions of every evaluation to the callback.
+// The alerts get the same built-in labels as the alerts of the scheduler, so that they are routed the same way.
+func (e *Engine) run(ctx context.Context, user identity.Requester, rule *models.AlertRule, folderTitle string, from time.Time, length int, callback func(idx int, now time.Time, states []state.StateTransition)) error {
+	ruleCtx := models.WithRuleKey(ctx, rule.GetKey())
+	logger := logger.FromContext(ctx)
+	extraLabels := state.GetRuleExtraLabels(logger, rule, folderTitle, e.includeFolder)
+
+	stateManager := e.createStateManager()
+
+	evaluator, err := backtestingEvaluatorFactory(ruleCtx, e.evalFactory, user, rule.GetEvalCondition().WithSource("backtesting"), &schedule.AlertingResultsFromRuleState{
+		Manager: stateManager,
+		Rule:    rule,
+	})
+	if err != nil {
+		return errors.Join(ErrInvalidInputData, err)
+	}
+
+	logger.Info("Start testing alert rule", "from", from, "interval", rule.IntervalSeconds, "evaluations", length)
+
+	start := time.Now()
+
+	err = evaluator.Eval(ruleCtx, from, time.Duration(rule.IntervalSeconds)*time.Second, length, func(idx int, currentTime time.Time, results eval.Results) error {
+		if idx >= length {
+			logger.Info("Unexpected evaluation. Skipping", "from", from, "interval", rule.IntervalSeconds, "evaluationTime", currentTime, "evaluationIndex", idx, "expectedEvaluations", length)
+			return nil
+		}
+		states := stateManager.ProcessEvalResults(ruleCtx, currentTime, rule, results, extraLabels, nil)
+		callback(idx, currentTime, states)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
 	logger.Info("Rule testing finished successfully", "duration", time.Since(start))
-	return result, nil
+	return nil
 }
 
 func newBacktestingEvaluator(ctx context.Context, evalFactory eval.EvaluatorFactory, user identity.Requester, condition models.Condition, reader eval.AlertingResultsReader) (backtestingEvaluator, error) {
@@ -217,7 +217,7 @@ func TestEvaluatorTest(t *testing.T) {
 			return states
 		}
 
-		frame, err := engine.Test(context.Background(), nil, rule, from, to)
+		frame, err := engine.Test(context.Background(), nil, rule, "", from, to)
 
 		require.NoError(t, err)
 		require.Len(t, frame.Fields, len(states)+1) // +1 - timestamp
@@ -287,12 +287,12 @@ func TestEvaluatorTest(t *testing.T) {
 			return states
 		}
 
-		frame, err := engine.Test(context.Background(), nil, rule, from, to)
+		frame, err := engine.Test(context.Background(), nil, rule, "", from, to)
 		require.NoError(t, err)
 		expectedLen := frame.Rows()
 		for i := 0; i < 100; i++ {
 			jitter := time.Duration(rand.Int63n(ruleInterval.Milliseconds())) * time.Millisecond
-			frame, err = engine.Test(context.Background(), nil, rule, from, to.Add(jitter))
+			frame, err = engine.Test(context.Background(), nil, rule, "", from, to.Add(jitter))
 			require.NoError(t, err)
 			require.Equalf(t, expectedLen, frame.Rows(), "jitter %v caused result to be different that base-line", jitter)
 		}
@@ -323,7 +323,7 @@ func TestEvaluatorTest(t *testing.T) {
 			return stateByTime[now]
 		}
 
-		frame, err := engine.Test(context.Background(), nil, rule, from, to)
+		frame, err := engine.Test(context.Background(), nil, rule, "", from, to)
 		require.NoError(t, err)
 
 		var field3 *data.Field
@@ -354,17 +354,17 @@ func TestEvaluatorTest(t *testing.T) {
 			from := time.Now()
 			t.Run("when from=to", func(t *testing.T) {
 				to := from
-				_, err := engine.Test(context.Background(), nil, rule, from, to)
+				_, err := engine.Test(context.Background(), nil, rule, "", from, to)
 				require.ErrorIs(t, err, ErrInvalidInputData)
 			})
 			t.Run("when from > to", func(t *testing.T) {
 				to := from.Add(-ruleInterval)
-				_, err := engine.Test(context.Background(), nil, rule, from, to)
+				_, err := engine.Test(context.Background(), nil, rule, "", from, to)
 				require.ErrorIs(t, err, ErrInvalidInputData)
 			})
 			t.Run("when to-from < interval", func(t *testing.T) {
 				to := from.Add(ruleInterval).Add(-time.Millisecond)
-				_, err := engine.Test(context.Background(), nil, rule, from, to)
+				_, err := engine.Test(context.Background(), nil, rule, "", from, to)
 				require.ErrorIs(t, err, ErrInvalidInputData)
 			})
 		})
@@ -376,7 +376,7 @@ func TestEvaluatorTest(t *testing.T) {
 			}
 			from := time.Now()
 			to := from.Add(ruleInterval)
-			_, err := engine.Test(context.Background(), nil, rule, from, to)
+			_, err := engine.Test(context.Background(), nil, rule, "", from, to)
 			require.ErrorIs(t, err, expectedError)
 		})
 	})
@@ -0,0 +1,294 @@
+package backtesting
+
+import (
+	"sort"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/inhibit"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+)
+
+// NotificationPolicy is the part of an Alertmanager configuration that decides which contact points are notified and when.
+type NotificationPolicy struct {
+	route         *dispatch.Route
+	inhibitRules  []*inhibit.InhibitRule
+	timeIntervals *timeinterval.Intervener
+}
+
+// NewNotificationPolicy creates a NotificationPolicy from the routing tree, the inhibition rules and the
+// time intervals (both mute timings and active time intervals) of an Alertmanager configuration.
+func NewNotificationPolicy(route *config.Route, inhibitRules []config.InhibitRule, timeIntervals map[string][]timeinterval.TimeInterval) *NotificationPolicy {
+	rules := make([]*inhibit.InhibitRule, 0, len(inhibitRules))
+	for _, r := range inhibitRules {
+		rules = append(rules, inhibit.NewInhibitRule(r))
+	}
+	return &NotificationPolicy{
+		route:         dispatch.NewRoute(route, nil),
+		inhibitRules:  rules,
+		timeIntervals: timeinterval.NewIntervener(timeIntervals),
+	}
+}
+
+// Notification is a notification that would have been sent to a contact point.
+type Notification struct {
+	Time     time.Time
+	Receiver string
+	// GroupLabels are the labels the alerts of the notification are grouped by.
+	GroupLabels model.LabelSet
+	Firing      int
+	Resolved    int
+}
+
+type simulatedAlert struct {
+	labels   model.LabelSet
+	resolved bool
+}
+
+// aggregationGroup mimics the aggregation group of the Alertmanager dispatcher.
+type aggregationGroup struct {
+	route     *dispatch.Route
+	labels    model.LabelSet
+	alerts    map[model.Fingerprint]*simulatedAlert
+	nextFlush time.Time
+
+	// notifiedFiring holds the alerts that were firing in the last notification.
+	notifiedFiring map[model.Fingerprint]struct{}
+	notifiedAt     time.Time
+}
+
+// notificationSimulator replays alert states through a notification policy. It follows the semantics of
+// the Alertmanager dispatcher: the first notification of a group is sent after group_wait, subsequent
+// notifications with changes after group_interval, and unchanged notifications after repeat_interval.
+type notificationSimulator struct {
+	policy        *NotificationPolicy
+	groups        map[string]*aggregationGroup
+	firing        map[model.Fingerprint]model.LabelSet
+	notifications []Notification
+}
+
+func newNotificationSimulator(policy *NotificationPolicy) *notificationSimulator {
+	return &notificationSimulator{
+		policy: policy,
+		groups: make(map[string]*aggregationGroup),
+		firing: make(map[model.Fingerprint]model.LabelSet),
+	}
+}
+
+// process flushes all groups that are due before now and then updates the groups with the states evaluated at now.
+func (s *notificationSimulator) process(now time.Time, states []state.StateTransition) {
+	s.flushUntil(now)
+
+	for _, st := range states {
+		lset := make(model.LabelSet, len(st.Labels))
+		for k, v := range st.Labels {
+			lset[model.LabelName(k)] = model.LabelValue(v)
+		}
+		fp := lset.Fingerprint()
+
+		if isFiring(st.State.State) {
+			s.firing[fp] = lset
+			s.addAlert(now, fp, lset, false)
+			continue
+		}
+		// Only alerts that were firing before are sent to the Alertmanager as resolved.
+		if _, ok := s.firing[fp]; ok {
+			delete(s.firing, fp)
+			s.addAlert(now, fp, lset, true)
+		}
+	}
+}
+
+// finish flushes all groups that are due until the end of the simulation and returns the notifications.
+func (s *notificationSimulator) finish(to time.Time) []Notification {
+	s.flushUntil(to)
+	return s.notifications
+}
+
+func (s *notificationSimulator) addAlert(now time.Time, fp model.Fingerprint, lset model.LabelSet, resolved bool) {
+	for _, route := range s.policy.route.Match(lset) {
+		labels := groupLabels(lset, route)
+		key := route.Key() + ":" + labels.String()
+
+		group, ok := s.groups[key]
+		if !ok {
+			if resolved {
+				continue
+			}
+			group = &aggregationGroup{
+				route:          route,
+				labels:         labels,
+				alerts:         make(map[model.Fingerprint]*simulatedAlert),
+				nextFlush:      now.Add(route.RouteOpts.GroupWait),
+				notifiedFiring: make(map[model.Fingerprint]struct{}),
+			}
+			s.groups[key] = group
+		}
+		group.alerts[fp] = &simulatedAlert{labels: lset, resolved: resolved}
+	}
+}
+
+// flushUntil flushes the groups in order of their flush time until there is no group due at or before t.
+func (s *notificationSimulator) flushUntil(t time.Time) {
+	for {
+		var (
+			next    *aggregationGroup
+			nextKey string
+		)
+		for key, group := range s.groups {
+			if group.nextFlush.After(t) {
+				continue
+			}
+			if next == nil || group.nextFlush.Before(next.nextFlush) || (group.nextFlush.Equal(next.nextFlush) && key < nextKey) {
+				next, nextKey = group, key
+			}
+		}
+		if next == nil {
+			return
+		}
+		if s.flush(next) {
+			delete(s.groups, nextKey)
+		}
+	}
+}
+
+// flush sends a notification for the group if needed and reports whether the group is empty and can be removed.
+func (s *notificationSimulator) flush(group *aggregationGroup) bool {
+	now := group.nextFlush
+	group.nextFlush = now.Add(group.route.RouteOpts.GroupInterval)
+
+	firing := make(map[model.Fingerprint]struct{})
+	resolved := make(map[model.Fingerprint]struct{})
+	for fp, alert := range group.alerts {
+		if alert.resolved {
+			if _, ok := group.notifiedFiring[fp]; ok {
+				resolved[fp] = struct{}{}
+			}
+			continue
+		}
+		if !s.inhibited(fp, alert.labels) {
+			firing[fp] = struct{}{}
+		}
+	}
+
+	if s.needsNotification(group, now, firing, resolved) && !s.muted(group.route, now) {
+		s.notifications = append(s.notifications, Notification{
+			Time:        now,
+			Receiver:    group.route.RouteOpts.Receiver,
+			GroupLabels: group.labels,
+			Firing:      len(firing),
+			Resolved:    len(resolved),
+		})
+		group.notifiedFiring = firing
+		group.notifiedAt = now
+	}
+
+	// Like in the Alertmanager, resolved alerts are removed from the group after every flush.
+	for fp, alert := range group.alerts {
+		if alert.resolved {
+			delete(group.alerts, fp)
+			delete(group.notifiedFiring, fp)
+		}
+	}
+
+	return len(group.alerts) == 0
+}
+
+// needsNotification mirrors the deduplication of the Alertmanager notification pipeline.
+func (s *notificationSimulator) needsNotification(group *aggregationGroup, now time.Time, firing, resolved map[model.Fingerprint]struct{}) bool {
+	if len(firing) == 0 && len(resolved) == 0 {
+		return false
+	}
+	if len(resolved) > 0 {
+		return true
+	}
+	for fp := range firing {
+		if _, ok := group.notifiedFiring[fp]; !ok {
+			return true
+		}
+	}
+	return !now.Before(group.notifiedAt.Add(group.route.RouteOpts.RepeatInterval))
+}
+
+func (s *notificationSimulator) inhibited(fp model.Fingerprint, lset model.LabelSet) bool {
+	for _, rule := range s.policy.inhibitRules {
+		if !rule.TargetMatchers.Matches(lset) {
+			continue
+		}
+		for sourceFp, source := range s.firing {
+			if sourceFp == fp || !rule.SourceMatchers.Matches(source) {
+				continue
+			}
+			equal := true
+			for name := range rule.Equal {
+				if source[name] != lset[name] {
+					equal = false
+					break
+				}
+			}
+			if equal {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *notificationSimulator) muted(route *dispatch.Route, now time.Time) bool {
+	if muted, err := s.policy.timeIntervals.Mutes(route.RouteOpts.MuteTimeIntervals, now); err == nil && muted {
+		return true
+	}
+	if len(route.RouteOpts.ActiveTimeIntervals) > 0 {
+		active, err := s.policy.timeIntervals.Mutes(route.RouteOpts.ActiveTimeIntervals, now)
+		return err == nil && !active
+	}
+	return false
+}
+
+func groupLabels(lset model.LabelSet, route *dispatch.Route) model.LabelSet {
+	if route.RouteOpts.GroupByAll {
+		return lset.Clone()
+	}
+	result := model.LabelSet{}
+	for name := range route.RouteOpts.GroupBy {
+		if v, ok := lset[name]; ok {
+			result[name] = v
+		}
+	}
+	return result
+}
+
+func isFiring(s eval.State) bool {
+	switch s {
+	case eval.Alerting, eval.Recovering, eval.NoData, eval.Error:
+		return true
+	default:
+		return false
+	}
+}
+
+func notificationsToFrame(notifications []Notification) *data.Frame {
+	sort.SliceStable(notifications, func(i, j int) bool {
+		return notifications[i].Time.Before(notifications[j].Time)
+	})
+
+	timeField := data.NewField("Time", nil, make([]time.Time, len(notifications)))
+	receiverField := data.NewField("Receiver", nil, make([]string, len(notifications)))
+	groupField := data.NewField("Group", nil, make([]string, len(notifications)))
+	firingField := data.NewField("Firing", nil, make([]int64, len(notifications)))
+	resolvedField := data.NewField("Resolved", nil, make([]int64, len(notifications)))
+	for i, n := range notifications {
+		timeField.Set(i, n.Time)
+		receiverField.Set(i, n.Receiver)
+		groupField.Set(i, n.GroupLabels.String())
+		firingField.Set(i, int64(n.Firing))
+		resolvedField.Set(i, int64(n.Resolved))
+	}
+	return data.NewFrame("Notifications", timeField, receiverField, groupField, firingField, resolvedField)
+}
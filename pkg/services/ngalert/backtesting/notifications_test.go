@@ -0,0 +1,227 @@
+package backtesting
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+)
+
+func TestNotificationSimulator(t *testing.T) {
+	duration := func(d time.Duration) *model.Duration {
+		md := model.Duration(d)
+		return &md
+	}
+	matcher := func(name, value string) *labels.Matcher {
+		m, err := labels.NewMatcher(labels.MatchEqual, name, value)
+		require.NoError(t, err)
+		return m
+	}
+
+	route := &config.Route{
+		Receiver:       "default",
+		GroupBy:        []model.LabelName{"team"},
+		GroupWait:      duration(30 * time.Second),
+		GroupInterval:  duration(5 * time.Minute),
+		RepeatInterval: duration(time.Hour),
+		Routes: []*config.Route{
+			{
+				Receiver: "team-a",
+				Matchers: config.Matchers{matcher("team", "a")},
+			},
+			{
+				Receiver:          "team-b",
+				Matchers:          config.Matchers{matcher("team", "b")},
+				MuteTimeIntervals: []string{"always"},
+			},
+		},
+	}
+	intervals := map[string][]timeinterval.TimeInterval{
+		"always": {{}},
+	}
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	transition := func(s eval.State, lbls data.Labels) state.StateTransition {
+		return state.StateTransition{State: &state.State{Labels: lbls, State: s}}
+	}
+
+	t.Run("notifies after group_wait, on changes after group_interval and repeats after repeat_interval", func(t *testing.T) {
+		sim := newNotificationSimulator(NewNotificationPolicy(route, nil, intervals))
+
+		a := data.Labels{"team": "a", "instance": "1"}
+		b := data.Labels{"team": "a", "instance": "2"}
+
+		// a fires during the first 90 minutes, b joins after 2 minutes and resolves after 10 minutes.
+		for now := from; now.Before(from.Add(2 * time.Hour)); now = now.Add(time.Minute) {
+			elapsed := now.Sub(from)
+			var states []state.StateTransition
+			if elapsed < 90*time.Minute {
+				states = append(states, transition(eval.Alerting, a))
+			} else {
+				states = append(states, transition(eval.Normal, a))
+			}
+			if elapsed >= 2*time.Minute && elapsed < 10*time.Minute {
+				states = append(states, transition(eval.Alerting, b))
+			} else if elapsed >= 10*time.Minute {
+				states = append(states, transition(eval.Normal, b))
+			}
+			sim.process(now, states)
+		}
+		notifications := sim.finish(from.Add(2 * time.Hour))
+
+		expected := []Notification{
+			{Time: from.Add(30 * time.Second), Receiver: "team-a", Firing: 1},                             // group_wait
+			{Time: from.Add(5*time.Minute + 30*time.Second), Receiver: "team-a", Firing: 2},               // b joined
+			{Time: from.Add(10*time.Minute + 30*time.Second), Receiver: "team-a", Firing: 1, Resolved: 1}, // b resolved
+			{Time: from.Add(70*time.Minute + 30*time.Second), Receiver: "team-a", Firing: 1},              // repeat_interval
+			{Time: from.Add(90*time.Minute + 30*time.Second), Receiver: "team-a", Firing: 0, Resolved: 1}, // a resolved
+		}
+		require.Len(t, notifications, len(expected))
+		for i, n := range notifications {
+			require.Equal(t, expected[i].Time, n.Time, "notification %d", i)
+			require.Equal(t, expected[i].Receiver, n.Receiver, "notification %d", i)
+			require.Equal(t, expected[i].Firing, n.Firing, "notification %d", i)
+			require.Equal(t, expected[i].Resolved, n.Resolved, "notification %d", i)
+			require.Equal(t, model.LabelSet{"team": "a"}, n.GroupLabels)
+		}
+	})
+
+	t.Run("does not notify muted routes", func(t *testing.T) {
+		sim := newNotificationSimulator(NewNotificationPolicy(route, nil, intervals))
+
+		sim.process(from, []state.StateTransition{transition(eval.Alerting, data.Labels{"team": "b"})})
+		require.Empty(t, sim.finish(from.Add(time.Hour)))
+	})
+
+	t.Run("does not notify inhibited alerts", func(t *testing.T) {
+		inhibitRules := []config.InhibitRule{{
+			SourceMatchers: config.Matchers{matcher("severity", "critical")},
+			TargetMatchers: config.Matchers{matcher("severity", "warning")},
+			Equal:          []string{"instance"},
+		}}
+		sim := newNotificationSimulator(NewNotificationPolicy(route, inhibitRules, intervals))
+
+		sim.process(from, []state.StateTransition{
+			transition(eval.Alerting, data.Labels{"instance": "1", "severity": "critical"}),
+			transition(eval.Alerting, data.Labels{"instance": "1", "severity": "warning"}),
+			transition(eval.Pending, data.Labels{"instance": "2", "severity": "warning"}),
+		})
+		notifications := sim.finish(from.Add(time.Minute))
+
+		require.Len(t, notifications, 1)
+		require.Equal(t, "default", notifications[0].Receiver)
+		require.Equal(t, 1, notifications[0].Firing)
+	})
+
+	t.Run("does not notify alerts that resolve before group_wait", func(t *testing.T) {
+		sim := newNotificationSimulator(NewNotificationPolicy(route, nil, intervals))
+
+		lbls := data.Labels{"instance": "1"}
+		sim.process(from, []state.StateTransition{transition(eval.Alerting, lbls)})
+		sim.process(from.Add(10*time.Second), []state.StateTransition{transition(eval.Normal, lbls)})
+		require.Empty(t, sim.finish(from.Add(time.Hour)))
+	})
+}
+
+func TestEngine_TestNotifications(t *testing.T) {
+	evaluator := &fakeBacktestingEvaluator{
+		evalCallback: func(now time.Time) (eval.Results, error) {
+			return eval.Results{{Instance: data.Labels{"instance": "1"}, State: eval.Alerting, EvaluatedAt: now}}, nil
+		},
+	}
+	backtestingEvaluatorFactory = func(ctx context.Context, evalFactory eval.EvaluatorFactory, user identity.Requester, condition models.Condition, r eval.AlertingResultsReader) (backtestingEvaluator, error) {
+		return evaluator, nil
+	}
+	t.Cleanup(func() {
+		backtestingEvaluatorFactory = newBacktestingEvaluator
+	})
+
+	matcher := func(name, value string) *labels.Matcher {
+		m, err := labels.NewMatcher(labels.MatchEqual, name, value)
+		require.NoError(t, err)
+		return m
+	}
+	duration := model.Duration(time.Minute)
+	route := &config.Route{
+		Receiver:       "default",
+		GroupBy:        []model.LabelName{models.FolderTitleLabel, model.AlertNameLabel},
+		GroupWait:      &duration,
+		GroupInterval:  &duration,
+		RepeatInterval: &duration,
+		Routes: []*config.Route{
+			{
+				Receiver: "team-a",
+				Matchers: config.Matchers{matcher(models.AutogeneratedRouteLabel, "true"), matcher(models.AutogeneratedRouteReceiverNameLabel, "team-a")},
+			},
+			{
+				Receiver: "infra",
+				Matchers: config.Matchers{matcher(models.FolderTitleLabel, "Infra"), matcher(model.AlertNameLabel, "High CPU")},
+			},
+		},
+	}
+
+	gen := models.RuleGen
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(5 * time.Minute)
+
+	t.Run("routes alerts by the built-in labels of the rule", func(t *testing.T) {
+		engine := NewEngine(&url.URL{}, nil, tracing.InitializeTracerForTest(), true)
+		rule := gen.With(gen.WithTitle("High CPU"), gen.WithInterval(time.Minute), gen.WithFor(0), gen.WithLabels(nil), gen.WithNoNotificationSettings()).GenerateRef()
+
+		frame, err := engine.TestNotifications(context.Background(), nil, rule, "Infra", from, to, NewNotificationPolicy(route, nil, nil))
+		require.NoError(t, err)
+		require.Positive(t, frame.Rows())
+		require.Equal(t, "infra", frame.Fields[1].At(0))
+		require.Equal(t, `{alertname="High CPU", grafana_folder="Infra"}`, frame.Fields[2].At(0))
+	})
+
+	t.Run("does not add the folder title when it is disabled", func(t *testing.T) {
+		engine := NewEngine(&url.URL{}, nil, tracing.InitializeTracerForTest(), false)
+		rule := gen.With(gen.WithTitle("High CPU"), gen.WithInterval(time.Minute), gen.WithFor(0), gen.WithLabels(nil), gen.WithNoNotificationSettings()).GenerateRef()
+
+		frame, err := engine.TestNotifications(context.Background(), nil, rule, "Infra", from, to, NewNotificationPolicy(route, nil, nil))
+		require.NoError(t, err)
+		require.Positive(t, frame.Rows())
+		require.Equal(t, "default", frame.Fields[1].At(0))
+		require.Equal(t, `{alertname="High CPU"}`, frame.Fields[2].At(0))
+	})
+
+	t.Run("routes alerts with notification settings to the autogenerated route", func(t *testing.T) {
+		engine := NewEngine(&url.URL{}, nil, tracing.InitializeTracerForTest(), true)
+		rule := gen.With(gen.WithTitle("High CPU"), gen.WithInterval(time.Minute), gen.WithFor(0), gen.WithLabels(nil),
+			gen.WithNotificationSettings(models.NotificationSettings{Receiver: "team-a"})).GenerateRef()
+
+		frame, err := engine.TestNotifications(context.Background(), nil, rule, "Infra", from, to, NewNotificationPolicy(route, nil, nil))
+		require.NoError(t, err)
+		require.Positive(t, frame.Rows())
+		require.Equal(t, "team-a", frame.Fields[1].At(0))
+	})
+}
+
+func TestNotificationsToFrame(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	frame := notificationsToFrame([]Notification{
+		{Time: from.Add(time.Minute), Receiver: "b", GroupLabels: model.LabelSet{"alertname": "test"}, Resolved: 1},
+		{Time: from, Receiver: "a", GroupLabels: model.LabelSet{"alertname": "test"}, Firing: 2},
+	})
+
+	require.Equal(t, 2, frame.Rows())
+	require.Equal(t, from, frame.Fields[0].At(0))
+	require.Equal(t, "a", frame.Fields[1].At(0))
+	require.Equal(t, `{alertname="test"}`, frame.Fields[2].At(0))
+	require.Equal(t, int64(2), frame.Fields[3].At(0))
+	require.Equal(t, int64(1), frame.Fields[4].At(1))
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+)
+
+// NotificationStatus is the outcome of a notification attempt.
+type NotificationStatus string
+
+const (
+	NotificationStatusSuccess NotificationStatus = "success"
+	NotificationStatusFailed  NotificationStatus = "failed"
+)
+
+// NotificationHistoryEntry is a single attempt of a receiver to deliver a notification.
+type NotificationHistoryEntry struct {
+	ID int64 `xorm:"pk autoincr 'id'" json:"id"`
+	// OrgID is the organization of the Alertmanager that sent the notification.
+	OrgID int64 `xorm:"org_id" json:"orgId"`
+	// DedupKey identifies the attempt across the Alertmanagers of a cluster, which record each attempt only once.
+	DedupKey string `xorm:"dedup_key" json:"-"`
+	// Receiver is the name of the contact point.
+	Receiver string `xorm:"receiver" json:"receiver"`
+	// GroupKey is the key of the alert group that was notified.
+	GroupKey string             `xorm:"group_key" json:"groupKey"`
+	Status   NotificationStatus `xorm:"status" json:"status"`
+	// Error returned by the integration when the attempt failed.
+	Error string `xorm:"error" json:"error,omitempty"`
+	// ResponseCode is the HTTP status code of the failed attempt, when the integration reported one.
+	ResponseCode int `xorm:"response_code" json:"responseCode,omitempty"`
+	// Attempt is 1 for the first attempt to deliver the notification and is incremented with each retry.
+	// The Alertmanager does not report which integration of the receiver made the attempt, so the attempts of
+	// all the integrations of the receiver are counted together.
+	Attempt int `xorm:"attempt" json:"attempt"`
+	// Duration of the attempt in milliseconds.
+	Duration int64 `xorm:"duration" json:"duration"`
+	// RuleUIDs are the rules of the alerts in the notification.
+	RuleUIDs []string `xorm:"rule_uids" json:"ruleUIDs"`
+	// Alerts is the number of alerts in the notification.
+	Alerts int `xorm:"alerts" json:"alerts"`
+	// PayloadSize is the size in bytes of the JSON encoded alerts in the notification.
+	PayloadSize int       `xorm:"payload_size" json:"payloadSize"`
+	Timestamp   time.Time `xorm:"timestamp" json:"timestamp"`
+}
+
+// A XORM interface that defines the used table for this struct.
+func (e *NotificationHistoryEntry) TableName() string {
+	return "alert_notification_history"
+}
+
+// NotificationHistoryQuery filters the notification history. Empty fields are not used as filters.
+type NotificationHistoryQuery struct {
+	OrgID    int64
+	RuleUID  string
+	Receiver string
+	Status   NotificationStatus
+	From     time.Time
+	To       time.Time
+	Limit    int
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+// StateHistoryEntry is a state transition of an alert instance, stored by the "sql" state history backend.
+type StateHistoryEntry struct {
+	ID           int64  `xorm:"pk autoincr 'id'"`
+	OrgID        int64  `xorm:"org_id"`
+	RuleUID      string `xorm:"rule_uid"`
+	RuleID       int64  `xorm:"rule_id"`
+	RuleTitle    string `xorm:"rule_title"`
+	RuleVersion  int64  `xorm:"rule_version"`
+	RuleGroup    string `xorm:"rule_group"`
+	NamespaceUID string `xorm:"namespace_uid"`
+	DashboardUID string `xorm:"dashboard_uid"`
+	PanelID      int64  `xorm:"panel_id"`
+	// Condition is the refID of the query or expression that is the condition of the rule.
+	Condition string `xorm:"rule_condition"`
+	// Fingerprint identifies the alert instance, it is computed from Labels.
+	Fingerprint string `xorm:"fingerprint"`
+	// Labels are the labels of the alert instance, without the private labels.
+	Labels        map[string]string `xorm:"labels"`
+	PreviousState string            `xorm:"previous_state"`
+	CurrentState  string            `xorm:"current_state"`
+	Error         string            `xorm:"error"`
+	// Values are the values of the queries and expressions of the rule, encoded as JSON.
+	Values    string    `xorm:"state_values"`
+	Timestamp time.Time `xorm:"timestamp"`
+}
+
+// A XORM interface that defines the used table for this struct.
+func (e *StateHistoryEntry) TableName() string {
+	return "alert_state_history"
+}
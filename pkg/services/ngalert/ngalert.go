@@ -284,6 +284,10 @@ func (ng *AlertNG) init() error {
 		overrides = append(overrides, override)
 	}
 
+	if ng.Cfg.UnifiedAlerting.NotificationHistory.Enabled {
+		overrides = append(overrides, notifier.WithNotificationHistory(ng.store))
+	}
+
 	decryptFn := ng.SecretsService.GetDecryptedValue
 	multiOrgMetrics := ng.Metrics.GetMultiOrgAlertmanagerMetrics()
 	moa, err := notifier.NewMultiOrgAlertmanager(
@@ -369,6 +373,7 @@ func (ng *AlertNG) init() error {
 		ng.annotationsRepo,
 		ng.dashboardService,
 		ng.store,
+		ng.store,
 		ng.Metrics.GetHistorianMetrics(),
 		ng.Log,
 		ng.tracer,
@@ -411,6 +416,7 @@ func (ng *AlertNG) init() error {
 
 	ng.stateManager = stateManager
 	ng.schedule = scheduler
+	ng.registerSQLCatalogTables()
 
 	configStore := legacy_storage.NewAlertmanagerConfigStore(ng.store)
 	receiverService := notifier.NewReceiverService(
@@ -479,6 +485,9 @@ func (ng *AlertNG) init() error {
 		Tracer:               ng.tracer,
 		UserService:          ng.userService,
 	}
+	if ng.Cfg.UnifiedAlerting.NotificationHistory.Enabled {
+		ng.Api.NotificationHistory = ng.store
+	}
 	ng.Api.RegisterAPIEndpoints(ng.Metrics.GetAPIMetrics())
 
 	if err := RegisterQuotas(ng.Cfg, ng.QuotaService, ng.store); err != nil {
@@ -628,6 +637,7 @@ func configureHistorianBackend(
 	ar annotations.Repository,
 	ds dashboards.DashboardService,
 	rs historian.RuleStore,
+	ss historian.SQLStore,
 	met *metrics.Historian,
 	l log.Logger,
 	tracer tracing.Tracer,
@@ -652,7 +662,7 @@ func configureHistorianBackend(
 	if backend == historian.BackendTypeMultiple {
 		primaryCfg := cfg
 		primaryCfg.Backend = cfg.MultiPrimary
-		primary, err := configureHistorianBackend(ctx, primaryCfg, ar, ds, rs, met, l, tracer, ac, datasourceService, httpClientProvider, pluginContextProvider, clock, mw)
+		primary, err := configureHistorianBackend(ctx, primaryCfg, ar, ds, rs, ss, met, l, tracer, ac, datasourceService, httpClientProvider, pluginContextProvider, clock, mw)
 		if err != nil {
 			return nil, fmt.Errorf("multi-backend target \"%s\" was misconfigured: %w", cfg.MultiPrimary, err)
 		}
@@ -661,7 +671,7 @@ func configureHistorianBackend(
 		for _, b := range cfg.MultiSecondaries {
 			secCfg := cfg
 			secCfg.Backend = b
-			sec, err := configureHistorianBackend(ctx, secCfg, ar, ds, rs, met, l, tracer, ac, datasourceService, httpClientProvider, pluginContextProvider, clock, mw)
+			sec, err := configureHistorianBackend(ctx, secCfg, ar, ds, rs, ss, met, l, tracer, ac, datasourceService, httpClientProvider, pluginContextProvider, clock, mw)
 			if err != nil {
 				return nil, fmt.Errorf("multi-backend target \"%s\" was miconfigured: %w", b, err)
 			}
@@ -695,6 +705,15 @@ func configureHistorianBackend(
 		return backend, nil
 	}
 
+	if backend == historian.BackendTypeSQL {
+		if ss == nil {
+			return nil, fmt.Errorf("sql state history backend requires a database store")
+		}
+		logCtx := log.WithContextualAttributes(ctx, []any{"backend", "sql"})
+		sqlBackendLogger := log.New("ngalert.state.historian").FromContext(logCtx)
+		return historian.NewSQLBackend(sqlBackendLogger, ss, rs, met, ac), nil
+	}
+
 	if backend == historian.BackendTypePrometheus {
 		pcfg, err := historian.NewPrometheusConfig(cfg)
 		if err != nil {
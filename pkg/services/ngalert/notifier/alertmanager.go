@@ -11,6 +11,7 @@ import (
 	"time"
 
 	alertingNotify "github.com/grafana/alerting/notify"
+	"github.com/grafana/alerting/notify/nfstatus"
 	"github.com/prometheus/alertmanager/config"
 
 	amv2 "github.com/prometheus/alertmanager/api/v2/models"
@@ -87,7 +88,7 @@ func (m maintenanceOptions) MaintenanceFunc(state alertingNotify.State) (int64,
 
 func NewAlertmanager(ctx context.Context, orgID int64, cfg *setting.Cfg, store AlertingStore, stateStore stateStore,
 	peer alertingNotify.ClusterPeer, decryptFn alertingNotify.GetDecryptedValueFn, ns notifications.Service,
-	m *metrics.Alertmanager, featureToggles featuremgmt.FeatureToggles, crypto Crypto,
+	m *metrics.Alertmanager, featureToggles featuremgmt.FeatureToggles, crypto Crypto, historian nfstatus.NotificationHistorian,
 ) (*alertmanager, error) {
 	nflog, err := stateStore.GetNotificationLog(ctx)
 	if err != nil {
@@ -138,6 +139,8 @@ func NewAlertmanager(ctx context.Context, orgID int64, cfg *setting.Cfg, store A
 		Peer:          peer,
 		Logger:        l,
 		Metrics:       alertingNotify.NewGrafanaAlertmanagerMetrics(m.Registerer, l),
+
+		NotificationHistorian: historian,
 	}
 
 	gam, err := alertingNotify.NewGrafanaAlertmanager(opts)
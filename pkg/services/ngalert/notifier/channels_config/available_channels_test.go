@@ -7,21 +7,27 @@ import (
 )
 
 func TestGetSecretKeysForContactPointType(t *testing.T) {
+	httpConfigSecretFields := []string{
+		"http_config.oauth2.client_secret",
+		"http_config.oauth2.tls_config.caCertificate",
+		"http_config.oauth2.tls_config.clientCertificate",
+		"http_config.oauth2.tls_config.clientKey",
+	}
 	testCases := []struct {
 		receiverType         string
 		expectedSecretFields []string
 	}{
-		{receiverType: "dingding", expectedSecretFields: []string{"url"}},
-		{receiverType: "kafka", expectedSecretFields: []string{"password"}},
+		{receiverType: "dingding", expectedSecretFields: append([]string{"url"}, httpConfigSecretFields...)},
+		{receiverType: "kafka", expectedSecretFields: append([]string{"password"}, httpConfigSecretFields...)},
 		{receiverType: "email", expectedSecretFields: []string{}},
-		{receiverType: "pagerduty", expectedSecretFields: []string{"integrationKey"}},
-		{receiverType: "victorops", expectedSecretFields: []string{"url"}},
-		{receiverType: "oncall", expectedSecretFields: []string{"password", "authorization_credentials"}},
-		{receiverType: "pushover", expectedSecretFields: []string{"apiToken", "userKey"}},
+		{receiverType: "pagerduty", expectedSecretFields: append([]string{"integrationKey"}, httpConfigSecretFields...)},
+		{receiverType: "victorops", expectedSecretFields: append([]string{"url"}, httpConfigSecretFields...)},
+		{receiverType: "oncall", expectedSecretFields: append([]string{"password", "authorization_credentials"}, httpConfigSecretFields...)},
+		{receiverType: "pushover", expectedSecretFields: append([]string{"apiToken", "userKey"}, httpConfigSecretFields...)},
 		{receiverType: "slack", expectedSecretFields: []string{"token", "url"}},
-		{receiverType: "sensugo", expectedSecretFields: []string{"apikey"}},
-		{receiverType: "teams", expectedSecretFields: []string{}},
-		{receiverType: "telegram", expectedSecretFields: []string{"bottoken"}},
+		{receiverType: "sensugo", expectedSecretFields: append([]string{"apikey"}, httpConfigSecretFields...)},
+		{receiverType: "teams", expectedSecretFields: httpConfigSecretFields},
+		{receiverType: "telegram", expectedSecretFields: append([]string{"bottoken"}, httpConfigSecretFields...)},
 		{receiverType: "webhook", expectedSecretFields: []string{
 			"password",
 			"authorization_credentials",
@@ -34,17 +40,17 @@ func TestGetSecretKeysForContactPointType(t *testing.T) {
 			"http_config.oauth2.tls_config.clientCertificate",
 			"http_config.oauth2.tls_config.clientKey",
 		}},
-		{receiverType: "wecom", expectedSecretFields: []string{"url", "secret"}},
+		{receiverType: "wecom", expectedSecretFields: append([]string{"url", "secret"}, httpConfigSecretFields...)},
 		{receiverType: "prometheus-alertmanager", expectedSecretFields: []string{"basicAuthPassword"}},
-		{receiverType: "discord", expectedSecretFields: []string{"url"}},
-		{receiverType: "googlechat", expectedSecretFields: []string{"url"}},
-		{receiverType: "LINE", expectedSecretFields: []string{"token"}},
-		{receiverType: "threema", expectedSecretFields: []string{"api_secret"}},
-		{receiverType: "opsgenie", expectedSecretFields: []string{"apiKey"}},
-		{receiverType: "webex", expectedSecretFields: []string{"bot_token"}},
+		{receiverType: "discord", expectedSecretFields: append([]string{"url"}, httpConfigSecretFields...)},
+		{receiverType: "googlechat", expectedSecretFields: append([]string{"url"}, httpConfigSecretFields...)},
+		{receiverType: "LINE", expectedSecretFields: append([]string{"token"}, httpConfigSecretFields...)},
+		{receiverType: "threema", expectedSecretFields: append([]string{"api_secret"}, httpConfigSecretFields...)},
+		{receiverType: "opsgenie", expectedSecretFields: append([]string{"apiKey"}, httpConfigSecretFields...)},
+		{receiverType: "webex", expectedSecretFields: append([]string{"bot_token"}, httpConfigSecretFields...)},
 		{receiverType: "sns", expectedSecretFields: []string{"sigv4.access_key", "sigv4.secret_key"}},
 		{receiverType: "mqtt", expectedSecretFields: []string{"password", "tlsConfig.caCertificate", "tlsConfig.clientCertificate", "tlsConfig.clientKey"}},
-		{receiverType: "jira", expectedSecretFields: []string{"user", "password", "api_token"}},
+		{receiverType: "jira", expectedSecretFields: append([]string{"user", "password", "api_token"}, httpConfigSecretFields...)},
 	}
 	n := GetAvailableNotifiers()
 	allTypes := make(map[string]struct{}, len(n))
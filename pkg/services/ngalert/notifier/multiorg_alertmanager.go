@@ -15,6 +15,7 @@ import (
 	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
 
 	alertingNotify "github.com/grafana/alerting/notify"
+	"github.com/grafana/alerting/notify/nfstatus"
 
 	"github.com/grafana/grafana/pkg/infra/kvstore"
 	"github.com/grafana/grafana/pkg/infra/log"
@@ -103,12 +104,23 @@ type MultiOrgAlertmanager struct {
 	ns      notifications.Service
 
 	receiverResourcePermissions ac.ReceiverPermissionsService
+
+	// notificationHistory stores the notification attempts of the Alertmanagers. It is nil when the notification
+	// history is disabled.
+	notificationHistory NotificationHistoryStore
 }
 
 type OrgAlertmanagerFactory func(ctx context.Context, orgID int64) (Alertmanager, error)
 
 type Option func(*MultiOrgAlertmanager)
 
+// WithNotificationHistory records the notification attempts of the Alertmanagers in the store.
+func WithNotificationHistory(store NotificationHistoryStore) Option {
+	return func(moa *MultiOrgAlertmanager) {
+		moa.notificationHistory = store
+	}
+}
+
 func WithAlertmanagerOverride(f func(OrgAlertmanagerFactory) OrgAlertmanagerFactory) Option {
 	return func(moa *MultiOrgAlertmanager) {
 		moa.factory = f(moa.factory)
@@ -160,7 +172,12 @@ func NewMultiOrgAlertmanager(
 	moa.factory = func(ctx context.Context, orgID int64) (Alertmanager, error) {
 		m := metrics.NewAlertmanagerMetrics(moa.metrics.GetOrCreateOrgRegistry(orgID), l)
 		stateStore := NewFileStore(orgID, kvStore)
-		return NewAlertmanager(ctx, orgID, moa.settings, moa.configStore, stateStore, moa.peer, moa.decryptFn, moa.ns, m, featureManager, moa.Crypto)
+		// The historian must be a nil interface when the history is disabled, the Alertmanager checks it against nil.
+		var historian nfstatus.NotificationHistorian
+		if moa.notificationHistory != nil {
+			historian = NewNotificationHistorian(orgID, moa.notificationHistory, l.New("component", "notification-history", "org", orgID))
+		}
+		return NewAlertmanager(ctx, orgID, moa.settings, moa.configStore, stateStore, moa.peer, moa.decryptFn, moa.ns, m, featureManager, moa.Crypto, historian)
 	}
 
 	for _, opt := range opts {
@@ -0,0 +1,213 @@
+package notifier
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	alertingModels "github.com/grafana/alerting/models"
+	"github.com/grafana/alerting/notify/nfstatus"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// NotificationHistoryStore stores the notification attempts recorded by the NotificationHistorian.
+type NotificationHistoryStore interface {
+	// SaveNotificationHistory stores the entries, skipping the ones whose dedup key is already stored.
+	SaveNotificationHistory(ctx context.Context, entries []models.NotificationHistoryEntry) error
+}
+
+// responseCodeRegexp extracts the HTTP status code from the errors returned by the integrations,
+// for example "failed to send HTTP request - status code 500" or "unexpected 5xx status code: 503".
+var responseCodeRegexp = regexp.MustCompile(`status ?code:? (\d{3})\b`)
+
+// notificationHistoryTimeout is the time to store a notification attempt.
+const notificationHistoryTimeout = 10 * time.Second
+
+// NotificationHistorian records the notification attempts of the receivers of an Alertmanager. It is called by the
+// notification pipeline after every attempt of an integration, including retries, with the alerts of the notification.
+//
+// Each attempt is identified by a dedup key, computed from the notification and the attempt number, so that the
+// Alertmanagers of a cluster that deliver the same notification store it only once.
+type NotificationHistorian struct {
+	orgID  int64
+	store  NotificationHistoryStore
+	logger log.Logger
+
+	mtx sync.Mutex
+	// attempts counts the attempts made for each notification, by notification key.
+	attempts map[string]*notificationAttempts
+}
+
+type notificationAttempts struct {
+	count   int
+	expires time.Time
+}
+
+var _ nfstatus.NotificationHistorian = (*NotificationHistorian)(nil)
+
+func NewNotificationHistorian(orgID int64, store NotificationHistoryStore, logger log.Logger) *NotificationHistorian {
+	return &NotificationHistorian{
+		orgID:    orgID,
+		store:    store,
+		logger:   logger,
+		attempts: make(map[string]*notificationAttempts),
+	}
+}
+
+// Record stores the attempt asynchronously. The returned channel receives the result of the write and is closed
+// afterwards.
+func (h *NotificationHistorian) Record(ctx context.Context, alerts []*types.Alert, _ bool, notificationErr error, duration time.Duration) <-chan error {
+	entry, err := h.newEntry(ctx, alerts, notificationErr, duration)
+	errCh := make(chan error, 1)
+	if err != nil {
+		h.logger.Error("Failed to record notification attempt", "error", err)
+		errCh <- err
+		close(errCh)
+		return errCh
+	}
+
+	go func() {
+		defer close(errCh)
+		writeCtx, cancel := context.WithTimeout(context.Background(), notificationHistoryTimeout)
+		defer cancel()
+		if err := h.store.SaveNotificationHistory(writeCtx, []models.NotificationHistoryEntry{entry}); err != nil {
+			h.logger.Error("Failed to save notification attempt", "receiver", entry.Receiver, "error", err)
+			errCh <- err
+		}
+	}()
+	return errCh
+}
+
+func (h *NotificationHistorian) newEntry(ctx context.Context, alerts []*types.Alert, notificationErr error, duration time.Duration) (models.NotificationHistoryEntry, error) {
+	receiver, _ := notify.ReceiverName(ctx)
+	groupKey, _ := notify.GroupKey(ctx)
+
+	payload, err := json.Marshal(alerts)
+	if err != nil {
+		return models.NotificationHistoryEntry{}, fmt.Errorf("failed to encode alerts: %w", err)
+	}
+
+	key := notificationKey(ctx, receiver, groupKey, alerts)
+	attempt := h.nextAttempt(ctx, key)
+
+	entry := models.NotificationHistoryEntry{
+		OrgID:       h.orgID,
+		DedupKey:    dedupKey(key, attempt),
+		Receiver:    receiver,
+		GroupKey:    groupKey,
+		Status:      models.NotificationStatusSuccess,
+		Attempt:     attempt,
+		Duration:    duration.Milliseconds(),
+		RuleUIDs:    ruleUIDs(alerts),
+		Alerts:      len(alerts),
+		PayloadSize: len(payload),
+		Timestamp:   time.Now().Add(-duration).UTC(),
+	}
+	if notificationErr != nil {
+		entry.Status = models.NotificationStatusFailed
+		entry.Error = notificationErr.Error()
+		if m := responseCodeRegexp.FindStringSubmatch(entry.Error); m != nil {
+			entry.ResponseCode, _ = strconv.Atoi(m[1])
+		}
+	}
+	return entry, nil
+}
+
+// nextAttempt returns the number of the attempt to deliver the notification. Notifications are repeated after the
+// repeat interval of their route, so the attempts of a notification are only counted until then.
+func (h *NotificationHistorian) nextAttempt(ctx context.Context, key string) int {
+	now := time.Now()
+	expires := notificationWindow(ctx).Add(repeatInterval(ctx))
+	if !expires.After(now) {
+		expires = now.Add(time.Hour)
+	}
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	for k, a := range h.attempts {
+		if !a.expires.After(now) {
+			delete(h.attempts, k)
+		}
+	}
+
+	a, ok := h.attempts[key]
+	if !ok {
+		a = &notificationAttempts{expires: expires}
+		h.attempts[key] = a
+	}
+	a.count++
+	return a.count
+}
+
+// notificationWindow returns the start of the repeat interval of the notification. The Alertmanagers of a cluster
+// flush the same alert group at slightly different times, so the time of the pipeline is truncated to the repeat
+// interval to identify the notification across the cluster.
+func notificationWindow(ctx context.Context) time.Time {
+	now, ok := notify.Now(ctx)
+	if !ok {
+		now = time.Now()
+	}
+	if interval := repeatInterval(ctx); interval > 0 {
+		return now.Truncate(interval)
+	}
+	return now
+}
+
+func repeatInterval(ctx context.Context) time.Duration {
+	interval, _ := notify.RepeatInterval(ctx)
+	return interval
+}
+
+// notificationKey identifies a notification by its receiver, alert group, repeat interval and alerts.
+func notificationKey(ctx context.Context, receiver, groupKey string, alerts []*types.Alert) string {
+	alertKeys := make([]string, 0, len(alerts))
+	for _, alert := range alerts {
+		status := "firing"
+		if alert.Resolved() {
+			status = "resolved"
+		}
+		alertKeys = append(alertKeys, alert.Fingerprint().String()+":"+status)
+	}
+	sort.Strings(alertKeys)
+
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s\n%s\n%d\n", receiver, groupKey, notificationWindow(ctx).UnixNano())
+	for _, k := range alertKeys {
+		_, _ = fmt.Fprintln(h, k)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func dedupKey(notificationKey string, attempt int) string {
+	sum := sha256.Sum256([]byte(notificationKey + ":" + strconv.Itoa(attempt)))
+	return hex.EncodeToString(sum[:])
+}
+
+// ruleUIDs returns the sorted UIDs of the rules of the alerts.
+func ruleUIDs(alerts []*types.Alert) []string {
+	uids := make(map[string]struct{})
+	for _, alert := range alerts {
+		if uid := string(alert.Labels[alertingModels.RuleUIDLabel]); uid != "" {
+			uids[uid] = struct{}{}
+		}
+	}
+
+	result := make([]string, 0, len(uids))
+	for uid := range uids {
+		result = append(result, uid)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,122 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	alertingModels "github.com/grafana/alerting/models"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// fakeNotificationHistoryStore is shared by the historians of several Alertmanagers, and skips duplicate dedup keys
+// like the database does.
+type fakeNotificationHistoryStore struct {
+	mtx     sync.Mutex
+	entries []ngmodels.NotificationHistoryEntry
+}
+
+func (f *fakeNotificationHistoryStore) SaveNotificationHistory(_ context.Context, entries []ngmodels.NotificationHistoryEntry) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	for _, entry := range entries {
+		duplicate := false
+		for _, e := range f.entries {
+			if e.OrgID == entry.OrgID && e.DedupKey == entry.DedupKey {
+				duplicate = true
+			}
+		}
+		if !duplicate {
+			f.entries = append(f.entries, entry)
+		}
+	}
+	return nil
+}
+
+func notificationContext(receiver, groupKey string, now time.Time) context.Context {
+	ctx := notify.WithReceiverName(context.Background(), receiver)
+	ctx = notify.WithGroupKey(ctx, groupKey)
+	ctx = notify.WithNow(ctx, now)
+	return notify.WithRepeatInterval(ctx, 4*time.Hour)
+}
+
+func historyAlert(ruleUID, name string) *types.Alert {
+	return &types.Alert{Alert: model.Alert{
+		Labels:   model.LabelSet{alertingModels.RuleUIDLabel: model.LabelValue(ruleUID), model.AlertNameLabel: model.LabelValue(name)},
+		StartsAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}}
+}
+
+func TestNotificationHistorian(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 1, 0, 0, time.UTC)
+	alerts := []*types.Alert{historyAlert("rule-b", "b"), historyAlert("rule-a", "a1"), historyAlert("rule-a", "a2")}
+
+	t.Run("should record the alerts, result and retries of the attempts", func(t *testing.T) {
+		store := &fakeNotificationHistoryStore{}
+		historian := NewNotificationHistorian(1, store, log.NewNopLogger())
+		ctx := notificationContext("my-receiver", "{}:{alertname=\"a\"}", now)
+
+		require.NoError(t, <-historian.Record(ctx, alerts, true, errors.New("failed to send HTTP request - status code 503"), 1500*time.Millisecond))
+		require.NoError(t, <-historian.Record(ctx, alerts, false, nil, 200*time.Millisecond))
+
+		require.Len(t, store.entries, 2)
+		failed := store.entries[0]
+		require.Equal(t, int64(1), failed.OrgID)
+		require.Equal(t, "my-receiver", failed.Receiver)
+		require.Equal(t, "{}:{alertname=\"a\"}", failed.GroupKey)
+		require.Equal(t, ngmodels.NotificationStatusFailed, failed.Status)
+		require.Equal(t, 503, failed.ResponseCode)
+		require.Equal(t, 1, failed.Attempt)
+		require.Equal(t, int64(1500), failed.Duration)
+		require.Equal(t, []string{"rule-a", "rule-b"}, failed.RuleUIDs)
+		require.Equal(t, 3, failed.Alerts)
+		require.Positive(t, failed.PayloadSize)
+
+		succeeded := store.entries[1]
+		require.Equal(t, ngmodels.NotificationStatusSuccess, succeeded.Status)
+		require.Empty(t, succeeded.Error)
+		require.Equal(t, 2, succeeded.Attempt)
+		require.NotEqual(t, failed.DedupKey, succeeded.DedupKey)
+	})
+
+	t.Run("should count the attempts of each notification", func(t *testing.T) {
+		store := &fakeNotificationHistoryStore{}
+		historian := NewNotificationHistorian(1, store, log.NewNopLogger())
+
+		require.NoError(t, <-historian.Record(notificationContext("my-receiver", "group", now), alerts, false, nil, time.Second))
+		// Other alerts are another notification.
+		require.NoError(t, <-historian.Record(notificationContext("my-receiver", "group", now), alerts[:1], false, nil, time.Second))
+		// The same alerts notified again after the repeat interval are another notification.
+		require.NoError(t, <-historian.Record(notificationContext("my-receiver", "group", now.Add(4*time.Hour)), alerts, false, nil, time.Second))
+
+		require.Len(t, store.entries, 3)
+		for _, entry := range store.entries {
+			require.Equal(t, 1, entry.Attempt)
+		}
+	})
+
+	t.Run("should record an attempt once when several Alertmanagers deliver the notification", func(t *testing.T) {
+		store := &fakeNotificationHistoryStore{}
+		first := NewNotificationHistorian(1, store, log.NewNopLogger())
+		second := NewNotificationHistorian(1, store, log.NewNopLogger())
+
+		require.NoError(t, <-first.Record(notificationContext("my-receiver", "group", now), alerts, false, nil, time.Second))
+		// The peers of the cluster flush the alert group at slightly different times.
+		require.NoError(t, <-second.Record(notificationContext("my-receiver", "group", now.Add(5*time.Second)), alerts, false, nil, time.Second))
+
+		require.Len(t, store.entries, 1)
+
+		// Another organization is not a duplicate.
+		other := NewNotificationHistorian(2, store, log.NewNopLogger())
+		require.NoError(t, <-other.Record(notificationContext("my-receiver", "group", now), alerts, false, nil, time.Second))
+		require.Len(t, store.entries, 2)
+	})
+}
@@ -437,7 +437,8 @@ func TestRemoveSecretsForContactPoint(t *testing.T) {
 	for _, integrationType := range keys {
 		cfg := configs[integrationType]
 		var settings map[string]any
-		require.NoError(t, json.Unmarshal([]byte(cfg.Config), &settings))
+		// the raw config includes the common HTTP config of the integrations that support it
+		require.NoError(t, json.Unmarshal(cfg.GetRawNotifierConfig(integrationType).Settings, &settings))
 		if f, ok := overrides[integrationType]; ok {
 			f(settings)
 		}
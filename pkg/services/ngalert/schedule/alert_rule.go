@@ -15,6 +15,8 @@ import (
 
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/datasources"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
@@ -386,7 +388,7 @@ func (a *alertRule) evaluate(ctx context.Context, e *Evaluation, span trace.Span
 
 	start := a.clock.Now()
 
-	evalCtx := eval.NewContextWithPreviousResults(ctx, SchedulerUserFor(e.rule.OrgID), a.newLoadedMetricsReader(e.rule))
+	evalCtx := eval.NewContextWithPreviousResults(ctx, SchedulerUserForRule(e.rule), a.newLoadedMetricsReader(e.rule))
 	ruleEval, err := a.evalFactory.Create(evalCtx, e.rule.GetEvalCondition().WithSource("scheduler").WithFolder(e.folderTitle))
 	var results eval.Results
 	var dur time.Duration
@@ -541,3 +543,22 @@ func SchedulerUserFor(orgID int64) *user.SignedInUser {
 		},
 	}
 }
+
+// SchedulerUserForRule returns the identity that evaluates the rule. In addition to the data sources, it can read the
+// alert rules, silences, dashboards and annotations of the rule's folder, which the SQL expression catalog tables
+// are filtered by.
+func SchedulerUserForRule(rule *ngmodels.AlertRule) *user.SignedInUser {
+	u := SchedulerUserFor(rule.OrgID)
+	folderScope := dashboards.ScopeFoldersProvider.GetResourceScopeUID(rule.NamespaceUID)
+	permissions := u.Permissions[rule.OrgID]
+	permissions[dashboards.ActionFoldersRead] = []string{folderScope}
+	permissions[dashboards.ActionDashboardsRead] = []string{folderScope}
+	permissions[accesscontrol.ActionAlertingRuleRead] = []string{folderScope}
+	permissions[accesscontrol.ActionAlertingSilencesRead] = []string{folderScope}
+	permissions[accesscontrol.ActionAnnotationsRead] = []string{
+		accesscontrol.ScopeAnnotationsTypeOrganization,
+		accesscontrol.ScopeAnnotationsTypeDashboard,
+		folderScope,
+	}
+	return u
+}
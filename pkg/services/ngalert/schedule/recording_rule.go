@@ -243,7 +243,7 @@ func (r *recordingRule) doEvaluate(ctx context.Context, ev *Evaluation) {
 
 func (r *recordingRule) tryEvaluation(ctx context.Context, ev *Evaluation, logger log.Logger) error {
 	evalStart := r.clock.Now()
-	evalCtx := eval.NewContext(ctx, SchedulerUserFor(ev.rule.OrgID))
+	evalCtx := eval.NewContext(ctx, SchedulerUserForRule(ev.rule))
 	result, err := r.buildAndExecutePipeline(ctx, evalCtx, ev, logger)
 	evalDur := r.clock.Now().Sub(evalStart)
 	if err != nil {
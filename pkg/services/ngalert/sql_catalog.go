@@ -0,0 +1,207 @@
+package ngalert
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/annotations"
+	ac "github.com/grafana/grafana/pkg/services/ngalert/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+)
+
+// sqlCatalogAnnotationsLimit is the maximum number of annotations in the grafana.annotations table.
+const sqlCatalogAnnotationsLimit = 1000
+
+type alertInstancesReader interface {
+	GetAll(orgID int64) []*state.State
+}
+
+type silencesReader interface {
+	ListSilences(ctx context.Context, user identity.Requester, filter []string) ([]*models.Silence, error)
+}
+
+type ruleFolderAccess interface {
+	HasAccessInFolder(ctx context.Context, user identity.Requester, rule models.Namespaced) (bool, error)
+}
+
+// sqlCatalog provides the tables SQL expressions can join as grafana.<table>. Each table only holds the rows
+// the user can read through the HTTP API.
+type sqlCatalog struct {
+	instances   alertInstancesReader
+	rules       notifier.RuleStore
+	ruleAuthz   ruleFolderAccess
+	silences    silencesReader
+	annotations annotations.Repository
+}
+
+func (ng *AlertNG) registerSQLCatalogTables() {
+	if ng.ExpressionService == nil {
+		return
+	}
+	ruleAuthz := ac.NewRuleService(ng.accesscontrol)
+	catalog := &sqlCatalog{
+		instances: ng.stateManager,
+		rules:     ng.store,
+		ruleAuthz: ruleAuthz,
+		silences: notifier.NewSilenceService(
+			ac.NewSilenceService(ng.accesscontrol, ng.store),
+			ng.store,
+			log.New("ngalert.sql-catalog"),
+			ng.MultiOrgAlertmanager,
+			ng.store,
+			ruleAuthz,
+		),
+		annotations: ng.annotationsRepo,
+	}
+	ng.ExpressionService.RegisterSQLCatalogTable("alert_instances", catalog.alertInstances)
+	ng.ExpressionService.RegisterSQLCatalogTable("silences", catalog.silenceList)
+	ng.ExpressionService.RegisterSQLCatalogTable("annotations", catalog.annotationList)
+}
+
+// alertInstances returns the current alert instances of the rules in the folders the user can read.
+func (c *sqlCatalog) alertInstances(ctx context.Context, user identity.Requester, _ backend.TimeRange) (*data.Frame, error) {
+	rules, err := c.rules.ListAlertRules(ctx, &models.ListAlertRulesQuery{OrgID: user.GetOrgID()})
+	if err != nil {
+		return nil, err
+	}
+
+	folderAccess := make(map[string]bool)
+	readable := make(map[string]*models.AlertRule, len(rules))
+	for _, rule := range rules {
+		canRead, ok := folderAccess[rule.NamespaceUID]
+		if !ok {
+			canRead, err = c.ruleAuthz.HasAccessInFolder(ctx, user, rule)
+			if err != nil {
+				return nil, err
+			}
+			folderAccess[rule.NamespaceUID] = canRead
+		}
+		if canRead {
+			readable[rule.UID] = rule
+		}
+	}
+
+	frame := data.NewFrame("alert_instances",
+		data.NewField("rule_uid", nil, []string{}),
+		data.NewField("rule_title", nil, []string{}),
+		data.NewField("folder_uid", nil, []string{}),
+		data.NewField("state", nil, []string{}),
+		data.NewField("state_reason", nil, []string{}),
+		data.NewField("labels", nil, []json.RawMessage{}),
+		data.NewField("annotations", nil, []json.RawMessage{}),
+		data.NewField("started_at", nil, []time.Time{}),
+		data.NewField("last_evaluated_at", nil, []time.Time{}),
+	)
+	for _, s := range c.instances.GetAll(user.GetOrgID()) {
+		rule, ok := readable[s.AlertRuleUID]
+		if !ok {
+			continue
+		}
+		labels, err := json.Marshal(s.GetLabels(models.WithoutInternalLabels()))
+		if err != nil {
+			return nil, err
+		}
+		rawAnnotations, err := json.Marshal(s.Annotations)
+		if err != nil {
+			return nil, err
+		}
+		frame.AppendRow(rule.UID, rule.Title, rule.NamespaceUID, s.State.String(), s.StateReason,
+			json.RawMessage(labels), json.RawMessage(rawAnnotations), s.StartsAt, s.LastEvaluationTime)
+	}
+	return frame, nil
+}
+
+// silenceList returns the silences the user can read.
+func (c *sqlCatalog) silenceList(ctx context.Context, user identity.Requester, _ backend.TimeRange) (*data.Frame, error) {
+	silences, err := c.silences.ListSilences(ctx, user, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	frame := data.NewFrame("silences",
+		data.NewField("id", nil, []string{}),
+		data.NewField("state", nil, []string{}),
+		data.NewField("matchers", nil, []json.RawMessage{}),
+		data.NewField("comment", nil, []string{}),
+		data.NewField("created_by", nil, []string{}),
+		data.NewField("starts_at", nil, []*time.Time{}),
+		data.NewField("ends_at", nil, []*time.Time{}),
+	)
+	for _, s := range silences {
+		var silenceState string
+		if s.Status != nil && s.Status.State != nil {
+			silenceState = *s.Status.State
+		}
+		matchers, err := json.Marshal(s.Matchers)
+		if err != nil {
+			return nil, err
+		}
+		var startsAt, endsAt *time.Time
+		if s.StartsAt != nil {
+			t := time.Time(*s.StartsAt)
+			startsAt = &t
+		}
+		if s.EndsAt != nil {
+			t := time.Time(*s.EndsAt)
+			endsAt = &t
+		}
+		frame.AppendRow(stringValue(s.ID), silenceState, json.RawMessage(matchers), stringValue(s.Comment), stringValue(s.CreatedBy), startsAt, endsAt)
+	}
+	return frame, nil
+}
+
+// annotationList returns the annotations in the time range of the query that the user can read.
+func (c *sqlCatalog) annotationList(ctx context.Context, user identity.Requester, timeRange backend.TimeRange) (*data.Frame, error) {
+	query := &annotations.ItemQuery{
+		OrgID:        user.GetOrgID(),
+		SignedInUser: user,
+		Limit:        sqlCatalogAnnotationsLimit,
+	}
+	if !timeRange.From.IsZero() && !timeRange.To.IsZero() {
+		query.From = timeRange.From.UnixMilli()
+		query.To = timeRange.To.UnixMilli()
+	}
+	items, err := c.annotations.Find(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	frame := data.NewFrame("annotations",
+		data.NewField("id", nil, []int64{}),
+		data.NewField("dashboard_uid", nil, []*string{}),
+		data.NewField("panel_id", nil, []int64{}),
+		data.NewField("alert_id", nil, []int64{}),
+		data.NewField("time", nil, []time.Time{}),
+		data.NewField("time_end", nil, []time.Time{}),
+		data.NewField("text", nil, []string{}),
+		data.NewField("tags", nil, []json.RawMessage{}),
+	)
+	for _, item := range items {
+		tags := item.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		rawTags, err := json.Marshal(tags)
+		if err != nil {
+			return nil, err
+		}
+		frame.AppendRow(item.ID, item.DashboardUID, item.PanelID, item.AlertID,
+			time.UnixMilli(item.Time).UTC(), time.UnixMilli(item.TimeEnd).UTC(), item.Text, json.RawMessage(rawTags))
+	}
+	return frame, nil
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,144 @@
+package ngalert
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	alertingModels "github.com/grafana/alerting/models"
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	ac "github.com/grafana/grafana/pkg/services/ngalert/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/schedule"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+type fakeCatalogInstances []*state.State
+
+func (f fakeCatalogInstances) GetAll(orgID int64) []*state.State {
+	var res []*state.State
+	for _, s := range f {
+		if s.OrgID == orgID {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+type fakeCatalogRules models.RulesGroup
+
+func (f fakeCatalogRules) ListAlertRules(_ context.Context, query *models.ListAlertRulesQuery) (models.RulesGroup, error) {
+	var res models.RulesGroup
+	for _, r := range f {
+		if r.OrgID == query.OrgID {
+			res = append(res, r)
+		}
+	}
+	return res, nil
+}
+
+func (f fakeCatalogRules) GetNamespacesByRuleUID(_ context.Context, orgID int64, uids ...string) (map[string]string, error) {
+	res := make(map[string]string)
+	for _, r := range f {
+		for _, uid := range uids {
+			if r.OrgID == orgID && r.UID == uid {
+				res[uid] = r.NamespaceUID
+			}
+		}
+	}
+	return res, nil
+}
+
+// fakeCatalogSilences filters the silences by access like the silence service.
+type fakeCatalogSilences struct {
+	silences []*models.Silence
+	authz    *ac.SilenceService
+}
+
+func (f fakeCatalogSilences) ListSilences(ctx context.Context, user identity.Requester, _ []string) ([]*models.Silence, error) {
+	return f.authz.FilterByAccess(ctx, user, f.silences...)
+}
+
+type fakeFolderAccess map[string]bool
+
+func (f fakeFolderAccess) HasAccessInFolder(_ context.Context, _ identity.Requester, rule models.Namespaced) (bool, error) {
+	return f[rule.GetNamespaceUID()], nil
+}
+
+func TestSQLCatalogAlertInstances(t *testing.T) {
+	startedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	catalog := &sqlCatalog{
+		instances: fakeCatalogInstances{
+			{OrgID: 1, AlertRuleUID: "visible", State: eval.Alerting, Labels: data.Labels{"host": "a", alertingModels.RuleUIDLabel: "visible"}, StartsAt: startedAt},
+			{OrgID: 1, AlertRuleUID: "hidden", State: eval.Alerting, Labels: data.Labels{"host": "b"}, StartsAt: startedAt},
+			{OrgID: 2, AlertRuleUID: "other-org", State: eval.Normal},
+		},
+		rules: fakeCatalogRules{
+			{OrgID: 1, UID: "visible", Title: "Visible", NamespaceUID: "readable-folder"},
+			{OrgID: 1, UID: "hidden", Title: "Hidden", NamespaceUID: "private-folder"},
+			{OrgID: 2, UID: "other-org", Title: "Other org", NamespaceUID: "readable-folder"},
+		},
+		ruleAuthz: fakeFolderAccess{"readable-folder": true},
+	}
+
+	frame, err := catalog.alertInstances(context.Background(), &user.SignedInUser{OrgID: 1}, backend.TimeRange{})
+	require.NoError(t, err)
+	require.Equal(t, 1, frame.Rows())
+
+	row := frame.RowCopy(0)
+	require.Equal(t, "visible", row[0])
+	require.Equal(t, "Visible", row[1])
+	require.Equal(t, "readable-folder", row[2])
+	require.Equal(t, "Alerting", row[3])
+	require.JSONEq(t, `{"host": "a"}`, string(row[5].(json.RawMessage)))
+	require.Equal(t, startedAt, row[7])
+}
+
+func TestSQLCatalogSchedulerUser(t *testing.T) {
+	rules := fakeCatalogRules{
+		{OrgID: 1, UID: "evaluated", Title: "Evaluated", NamespaceUID: "rule-folder"},
+		{OrgID: 1, UID: "same-folder", Title: "Same folder", NamespaceUID: "rule-folder"},
+		{OrgID: 1, UID: "other-folder", Title: "Other folder", NamespaceUID: "other-folder"},
+	}
+	accessControl := acimpl.ProvideAccessControl(featuremgmt.WithFeatures())
+	global := models.SilenceGen()()
+	sameFolder := models.SilenceGen(models.SilenceMuts.WithRuleUID("same-folder"))()
+	otherFolder := models.SilenceGen(models.SilenceMuts.WithRuleUID("other-folder"))()
+	catalog := &sqlCatalog{
+		instances: fakeCatalogInstances{
+			{OrgID: 1, AlertRuleUID: "same-folder", State: eval.Alerting},
+			{OrgID: 1, AlertRuleUID: "other-folder", State: eval.Alerting},
+		},
+		rules:     rules,
+		ruleAuthz: ac.NewRuleService(accessControl),
+		silences: fakeCatalogSilences{
+			silences: []*models.Silence{&global, &sameFolder, &otherFolder},
+			authz:    ac.NewSilenceService(accessControl, rules),
+		},
+	}
+	// The scheduler evaluates the rule with the identity of its folder.
+	evaluator := schedule.SchedulerUserForRule(rules[0])
+
+	t.Run("alert_instances should contain the instances of the rule's folder", func(t *testing.T) {
+		frame, err := catalog.alertInstances(context.Background(), evaluator, backend.TimeRange{})
+		require.NoError(t, err)
+		require.Equal(t, 1, frame.Rows())
+		require.Equal(t, "same-folder", frame.RowCopy(0)[0])
+	})
+
+	t.Run("silences should contain the global silences and the silences of the rule's folder", func(t *testing.T) {
+		frame, err := catalog.silenceList(context.Background(), evaluator, backend.TimeRange{})
+		require.NoError(t, err)
+		require.Equal(t, 2, frame.Rows())
+		require.ElementsMatch(t, []string{*global.ID, *sameFolder.ID}, []string{frame.RowCopy(0)[0].(string), frame.RowCopy(1)[0].(string)})
+	})
+}
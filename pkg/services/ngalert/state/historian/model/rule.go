@@ -19,6 +19,7 @@ type RuleMeta struct {
 	DashboardUID string
 	PanelID      int64
 	Condition    string
+	Version      int64
 }
 
 func NewRuleMeta(r *models.AlertRule, logger log.Logger) RuleMeta {
@@ -44,6 +45,7 @@ func NewRuleMeta(r *models.AlertRule, logger log.Logger) RuleMeta {
 		DashboardUID: dashUID,
 		PanelID:      panelID,
 		Condition:    r.Condition,
+		Version:      r.Version,
 	}
 }
 
@@ -0,0 +1,209 @@
+package historian
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	history_model "github.com/grafana/grafana/pkg/services/ngalert/state/historian/model"
+)
+
+const defaultSQLQueryLimit = 1000
+
+type SQLStore interface {
+	SaveStateHistory(ctx context.Context, entries []models.StateHistoryEntry) error
+	GetStateHistory(ctx context.Context, query models.HistoryQuery, folderUIDs []string) ([]models.StateHistoryEntry, error)
+}
+
+// SQLBackend is a state.Historian that records state history to a table of the Grafana database.
+// Unlike annotations, it keeps the labels of the alert instances, which allows filtering by label.
+type SQLBackend struct {
+	store     SQLStore
+	ruleStore RuleStore
+	clock     clock.Clock
+	metrics   *metrics.Historian
+	log       log.Logger
+	ac        AccessControl
+}
+
+func NewSQLBackend(logger log.Logger, store SQLStore, ruleStore RuleStore, metrics *metrics.Historian, ac AccessControl) *SQLBackend {
+	return &SQLBackend{
+		store:     store,
+		ruleStore: ruleStore,
+		clock:     clock.New(),
+		metrics:   metrics,
+		log:       logger,
+		ac:        ac,
+	}
+}
+
+// Record writes a number of state transitions for a given rule to the database.
+func (h *SQLBackend) Record(ctx context.Context, rule history_model.RuleMeta, states []state.StateTransition) <-chan error {
+	logger := h.log.FromContext(ctx)
+	entries := statesToEntries(rule, states, logger)
+
+	errCh := make(chan error, 1)
+	if len(entries) == 0 {
+		close(errCh)
+		return errCh
+	}
+
+	// This is a new background job, so let's create a brand new context for it.
+	// We want it to be isolated, i.e. we don't want grafana shutdowns to interrupt this work
+	// immediately but rather try to flush writes.
+	writeCtx := context.Background()
+	writeCtx, cancel := context.WithTimeout(writeCtx, StateHistoryWriteTimeout)
+	writeCtx = history_model.WithRuleData(writeCtx, rule)
+	writeCtx = trace.ContextWithSpan(writeCtx, trace.SpanFromContext(ctx))
+
+	go func(ctx context.Context) {
+		defer cancel()
+		defer close(errCh)
+		logger := h.log.FromContext(ctx)
+		logger.Debug("Saving state history batch", "samples", len(entries))
+		org := fmt.Sprint(rule.OrgID)
+		h.metrics.WritesTotal.WithLabelValues(org, "sql").Inc()
+		h.metrics.TransitionsTotal.WithLabelValues(org).Add(float64(len(entries)))
+
+		if err := h.store.SaveStateHistory(ctx, entries); err != nil {
+			logger.Error("Failed to save alert state history batch", "error", err)
+			h.metrics.WritesFailed.WithLabelValues(org, "sql").Inc()
+			h.metrics.TransitionsFailed.WithLabelValues(org).Add(float64(len(entries)))
+			errCh <- fmt.Errorf("failed to save alert state history batch: %w", err)
+			return
+		}
+		logger.Debug("Done saving alert state history batch", "samples", len(entries))
+	}(writeCtx)
+	return errCh
+}
+
+// Query retrieves state history entries from the database and formats the results into a dataframe
+// with the same layout as the one returned by the Loki backend.
+func (h *SQLBackend) Query(ctx context.Context, query models.HistoryQuery) (*data.Frame, error) {
+	uids, err := getFolderUIDsForFilter(ctx, h.ac, h.ruleStore, query)
+	if err != nil {
+		return nil, err
+	}
+
+	now := h.clock.Now().UTC()
+	if query.To.IsZero() || query.To.Unix() == 0 {
+		query.To = now
+	}
+	if query.From.IsZero() || query.From.Unix() == 0 {
+		query.From = now.Add(-defaultQueryRange)
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultSQLQueryLimit
+	}
+
+	entries, err := h.store.GetStateHistory(ctx, query, uids)
+	if err != nil {
+		return nil, err
+	}
+	return entriesToFrame(entries)
+}
+
+func statesToEntries(rule history_model.RuleMeta, states []state.StateTransition, logger log.Logger) []models.StateHistoryEntry {
+	entries := make([]models.StateHistoryEntry, 0, len(states))
+	for _, state := range states {
+		if !shouldRecord(state) {
+			continue
+		}
+
+		values, err := json.Marshal(valuesAsDataBlob(state.State))
+		if err != nil {
+			logger.Error("Failed to encode values of state, skipping", "error", err)
+			continue
+		}
+
+		sanitizedLabels := removePrivateLabels(state.Labels)
+		entry := models.StateHistoryEntry{
+			OrgID:         rule.OrgID,
+			RuleUID:       rule.UID,
+			RuleID:        rule.ID,
+			RuleTitle:     rule.Title,
+			RuleVersion:   rule.Version,
+			RuleGroup:     rule.Group,
+			NamespaceUID:  rule.NamespaceUID,
+			DashboardUID:  rule.DashboardUID,
+			PanelID:       rule.PanelID,
+			Condition:     rule.Condition,
+			Fingerprint:   labelFingerprint(sanitizedLabels),
+			Labels:        sanitizedLabels,
+			PreviousState: state.PreviousFormatted(),
+			CurrentState:  state.Formatted(),
+			Values:        string(values),
+			Timestamp:     state.LastEvaluationTime.UTC(),
+		}
+		if state.State.State == eval.Error && state.Error != nil {
+			entry.Error = state.Error.Error()
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func entriesToFrame(entries []models.StateHistoryEntry) (*data.Frame, error) {
+	frame := data.NewFrame("states")
+	lbls := data.Labels(map[string]string{})
+
+	times := make([]time.Time, 0, len(entries))
+	lines := make([]json.RawMessage, 0, len(entries))
+	labels := make([]json.RawMessage, 0, len(entries))
+
+	for _, e := range entries {
+		values, err := simplejson.NewJson([]byte(e.Values))
+		if err != nil {
+			values = simplejson.New()
+		}
+		line, err := json.Marshal(LokiEntry{
+			SchemaVersion:  1,
+			Previous:       e.PreviousState,
+			Current:        e.CurrentState,
+			Error:          e.Error,
+			Values:         values,
+			Condition:      e.Condition,
+			DashboardUID:   e.DashboardUID,
+			PanelID:        e.PanelID,
+			Fingerprint:    e.Fingerprint,
+			RuleTitle:      e.RuleTitle,
+			RuleID:         e.RuleID,
+			RuleUID:        e.RuleUID,
+			RuleVersion:    e.RuleVersion,
+			InstanceLabels: e.Labels,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize state history entry: %w", err)
+		}
+		streamLabels, err := json.Marshal(map[string]string{
+			StateHistoryLabelKey: StateHistoryLabelValue,
+			OrgIDLabel:           fmt.Sprint(e.OrgID),
+			GroupLabel:           e.RuleGroup,
+			FolderUIDLabel:       e.NamespaceUID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize stream labels: %w", err)
+		}
+
+		times = append(times, e.Timestamp)
+		lines = append(lines, line)
+		labels = append(labels, streamLabels)
+	}
+
+	frame.Fields = append(frame.Fields, data.NewField(dfTime, lbls, times))
+	frame.Fields = append(frame.Fields, data.NewField(dfLine, lbls, lines))
+	frame.Fields = append(frame.Fields, data.NewField(dfLabels, lbls, labels))
+
+	return frame, nil
+}
@@ -0,0 +1,159 @@
+package historian
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	acfakes "github.com/grafana/grafana/pkg/services/ngalert/accesscontrol/fakes"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+type fakeSQLStore struct {
+	entries    []models.StateHistoryEntry
+	lastQuery  models.HistoryQuery
+	lastUIDs   []string
+	saveErr    error
+	queryCalls int
+}
+
+func (f *fakeSQLStore) SaveStateHistory(_ context.Context, entries []models.StateHistoryEntry) error {
+	if f.saveErr != nil {
+		return f.saveErr
+	}
+	f.entries = append(f.entries, entries...)
+	return nil
+}
+
+func (f *fakeSQLStore) GetStateHistory(_ context.Context, query models.HistoryQuery, folderUIDs []string) ([]models.StateHistoryEntry, error) {
+	f.queryCalls++
+	f.lastQuery = query
+	f.lastUIDs = folderUIDs
+	return f.entries, nil
+}
+
+func TestSQLBackend(t *testing.T) {
+	t.Run("records state transitions with labels and rule version", func(t *testing.T) {
+		store := &fakeSQLStore{}
+		sut := createTestSQLBackend(t, store)
+		rule := createTestRule()
+		rule.Version = 3
+		now := time.Now().UTC().Truncate(time.Second)
+		states := singleFromNormal(&state.State{
+			State:              eval.Alerting,
+			Labels:             data.Labels{"a": "b", "__private__": "x"},
+			Values:             map[string]float64{"A": 1.5},
+			LastEvaluationTime: now,
+		})
+
+		err := <-sut.Record(context.Background(), rule, states)
+
+		require.NoError(t, err)
+		require.Len(t, store.entries, 1)
+		entry := store.entries[0]
+		require.Equal(t, "rule-uid", entry.RuleUID)
+		require.Equal(t, int64(3), entry.RuleVersion)
+		require.Equal(t, "my-folder", entry.NamespaceUID)
+		require.Equal(t, map[string]string{"a": "b"}, entry.Labels)
+		require.Equal(t, "Normal", entry.PreviousState)
+		require.Equal(t, "Alerting", entry.CurrentState)
+		require.JSONEq(t, `{"A":1.5}`, entry.Values)
+		require.Equal(t, now, entry.Timestamp)
+	})
+
+	t.Run("does not record unchanged states", func(t *testing.T) {
+		store := &fakeSQLStore{}
+		sut := createTestSQLBackend(t, store)
+		states := []state.StateTransition{{PreviousState: eval.Alerting, State: &state.State{State: eval.Alerting}}}
+
+		err := <-sut.Record(context.Background(), createTestRule(), states)
+
+		require.NoError(t, err)
+		require.Empty(t, store.entries)
+	})
+
+	t.Run("returns write errors", func(t *testing.T) {
+		store := &fakeSQLStore{saveErr: errors.New("boom")}
+		sut := createTestSQLBackend(t, store)
+		states := singleFromNormal(&state.State{State: eval.Alerting})
+
+		err := <-sut.Record(context.Background(), createTestRule(), states)
+
+		require.ErrorContains(t, err, "boom")
+	})
+
+	t.Run("queries return the same frame as the loki backend", func(t *testing.T) {
+		store := &fakeSQLStore{}
+		sut := createTestSQLBackend(t, store)
+		rule := createTestRule()
+		states := singleFromNormal(&state.State{State: eval.Alerting, Labels: data.Labels{"a": "b"}})
+		require.NoError(t, <-sut.Record(context.Background(), rule, states))
+
+		frame, err := sut.Query(context.Background(), models.HistoryQuery{OrgID: 1, Labels: map[string]string{"a": "b"}})
+
+		require.NoError(t, err)
+		require.Len(t, frame.Fields, 3)
+		require.Equal(t, 1, frame.Rows())
+		require.Equal(t, defaultSQLQueryLimit, store.lastQuery.Limit)
+		require.False(t, store.lastQuery.From.IsZero())
+
+		var line LokiEntry
+		require.NoError(t, json.Unmarshal(frame.Fields[1].At(0).(json.RawMessage), &line))
+		require.Equal(t, "rule-uid", line.RuleUID)
+		require.Equal(t, map[string]string{"a": "b"}, line.InstanceLabels)
+		var labels map[string]string
+		require.NoError(t, json.Unmarshal(frame.Fields[2].At(0).(json.RawMessage), &labels))
+		require.Equal(t, "my-folder", labels[FolderUIDLabel])
+		require.Equal(t, "my-group", labels[GroupLabel])
+	})
+
+	t.Run("queries are restricted to the folders the user can access", func(t *testing.T) {
+		store := &fakeSQLStore{}
+		sut := createTestSQLBackend(t, store)
+		ac := &acfakes.FakeRuleService{}
+		expectedErr := errors.New("test-error")
+		ac.CanReadAllRulesFunc = func(context.Context, identity.Requester) (bool, error) {
+			return false, nil
+		}
+		ac.AuthorizeAccessInFolderFunc = func(context.Context, identity.Requester, models.Namespaced) error {
+			return expectedErr
+		}
+		sut.ac = ac
+
+		_, err := sut.Query(context.Background(), models.HistoryQuery{
+			OrgID:        1,
+			RuleUID:      "my-rule",
+			SignedInUser: &user.SignedInUser{Name: "test-user", OrgID: 1},
+		})
+
+		require.ErrorIs(t, err, expectedErr)
+		require.Zero(t, store.queryCalls)
+	})
+}
+
+func createTestSQLBackend(t *testing.T, store SQLStore) *SQLBackend {
+	t.Helper()
+	met := metrics.NewHistorianMetrics(prometheus.NewRegistry(), metrics.Subsystem)
+	rules := fakes.NewRuleStore(t)
+	rules.Rules[1] = []*models.AlertRule{
+		models.RuleGen.With(models.RuleMuts.WithOrgID(1), withUID("my-rule")).GenerateRef(),
+	}
+	ac := &acfakes.FakeRuleService{}
+	ac.CanReadAllRulesFunc = func(context.Context, identity.Requester) (bool, error) {
+		return true, nil
+	}
+	return NewSQLBackend(log.NewNopLogger(), store, rules, met, ac)
+}
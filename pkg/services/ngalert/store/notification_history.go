@@ -0,0 +1,96 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+const defaultNotificationHistoryLimit = 1000
+
+// likeEscaper escapes the wildcards of a LIKE pattern using '!' as the escape character, which,
+// unlike the backslash, has no special meaning in the string literals of any supported database.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// SaveNotificationHistory stores the notification attempts. Attempts whose dedup key is already stored were
+// recorded by another Alertmanager of the cluster and are skipped.
+func (st DBstore) SaveNotificationHistory(ctx context.Context, entries []models.NotificationHistoryEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	// Every entry is inserted on its own, as a unique constraint violation aborts the current transaction in some
+	// databases.
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		for i := range entries {
+			if _, err := sess.Insert(&entries[i]); err != nil {
+				if st.SQLStore.GetDialect().IsUniqueConstraintViolation(err) {
+					continue
+				}
+				return fmt.Errorf("failed to insert notification history entry: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetNotificationHistory returns the notification attempts that match the query, most recent first.
+func (st DBstore) GetNotificationHistory(ctx context.Context, query models.NotificationHistoryQuery) ([]models.NotificationHistoryEntry, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultNotificationHistoryLimit
+	}
+
+	entries := make([]models.NotificationHistoryEntry, 0)
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		q := sess.Where("org_id = ?", query.OrgID)
+		if query.Receiver != "" {
+			q = q.And("receiver = ?", query.Receiver)
+		}
+		if query.Status != "" {
+			q = q.And("status = ?", query.Status)
+		}
+		if query.RuleUID != "" {
+			// rule_uids is stored as a JSON array of strings. The UID is escaped so that the wildcards
+			// allowed in UIDs, such as '_', only match themselves.
+			q = q.And(`rule_uids LIKE ? ESCAPE '!'`, `%"`+likeEscaper.Replace(query.RuleUID)+`"%`)
+		}
+		if !query.From.IsZero() {
+			q = q.And("timestamp >= ?", query.From.UTC())
+		}
+		if !query.To.IsZero() {
+			q = q.And("timestamp <= ?", query.To.UTC())
+		}
+		return q.Desc("timestamp", "id").Limit(limit).Find(&entries)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// CleanUpNotificationHistory deletes the notification attempts older than the configured retention.
+// It returns the number of deleted entries.
+func (st DBstore) CleanUpNotificationHistory(ctx context.Context) (int64, error) {
+	retention := st.Cfg.NotificationHistory.Retention
+	if retention <= 0 {
+		return 0, nil
+	}
+
+	var n int64
+	if err := st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		expire := TimeNow().UTC().Add(-retention)
+		st.Logger.Debug("Remove expired notification history", "before", expire)
+		rows, err := sess.Where("timestamp < ?", expire).Delete(&models.NotificationHistoryEntry{})
+		if err != nil {
+			return fmt.Errorf("failed to delete expired notification history: %w", err)
+		}
+		n = rows
+		return nil
+	}); err != nil {
+		return -1, err
+	}
+	return n, nil
+}
@@ -0,0 +1,103 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests"
+)
+
+func TestIntegrationNotificationHistory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	oldClk := store.TimeNow
+	store.TimeNow = func() time.Time { return now }
+	t.Cleanup(func() { store.TimeNow = oldClk })
+
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	entries := []models.NotificationHistoryEntry{
+		{OrgID: 1, DedupKey: "key-1", Receiver: "slack", Status: models.NotificationStatusFailed, Error: "unexpected status code 500", ResponseCode: 500, Attempt: 1, RuleUIDs: []string{"rule-a"}, Alerts: 1, Timestamp: now.Add(-3 * time.Minute)},
+		{OrgID: 1, DedupKey: "key-2", Receiver: "slack", Status: models.NotificationStatusSuccess, Attempt: 2, RuleUIDs: []string{"rule-a", "rule-b"}, Alerts: 2, Timestamp: now.Add(-2 * time.Minute)},
+		{OrgID: 1, DedupKey: "key-3", Receiver: "webhook", Status: models.NotificationStatusSuccess, Attempt: 1, RuleUIDs: []string{"rule-b"}, Alerts: 1, Timestamp: now.Add(-1 * time.Minute)},
+		{OrgID: 2, DedupKey: "key-4", Receiver: "slack", Status: models.NotificationStatusSuccess, Attempt: 1, RuleUIDs: []string{"rule-a"}, Alerts: 1, Timestamp: now.Add(-1 * time.Minute)},
+		{OrgID: 1, DedupKey: "key-5", Receiver: "slack", Status: models.NotificationStatusSuccess, Attempt: 1, RuleUIDs: []string{"rule-a"}, Alerts: 1, Timestamp: now.Add(-48 * time.Hour)},
+	}
+	require.NoError(t, dbstore.SaveNotificationHistory(ctx, entries))
+
+	t.Run("should skip entries already recorded by another Alertmanager", func(t *testing.T) {
+		duplicate := entries[0]
+		duplicate.ID = 0
+		require.NoError(t, dbstore.SaveNotificationHistory(ctx, []models.NotificationHistoryEntry{duplicate}))
+
+		result, err := dbstore.GetNotificationHistory(ctx, models.NotificationHistoryQuery{OrgID: 1, Status: models.NotificationStatusFailed})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+	})
+
+	t.Run("should return entries of the organization, most recent first", func(t *testing.T) {
+		result, err := dbstore.GetNotificationHistory(ctx, models.NotificationHistoryQuery{OrgID: 1})
+		require.NoError(t, err)
+		require.Len(t, result, 4)
+		assert.Equal(t, "webhook", result[0].Receiver)
+		assert.Equal(t, []string{"rule-a", "rule-b"}, result[1].RuleUIDs)
+		assert.Equal(t, 500, result[2].ResponseCode)
+	})
+
+	t.Run("should filter by receiver, status and rule UID", func(t *testing.T) {
+		result, err := dbstore.GetNotificationHistory(ctx, models.NotificationHistoryQuery{OrgID: 1, Receiver: "slack", Status: models.NotificationStatusFailed})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "unexpected status code 500", result[0].Error)
+
+		result, err = dbstore.GetNotificationHistory(ctx, models.NotificationHistoryQuery{OrgID: 1, RuleUID: "rule-b"})
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+	})
+
+	t.Run("should match rule UIDs with LIKE wildcards literally", func(t *testing.T) {
+		require.NoError(t, dbstore.SaveNotificationHistory(ctx, []models.NotificationHistoryEntry{
+			{OrgID: 3, DedupKey: "key-6", Receiver: "slack", Status: models.NotificationStatusSuccess, Attempt: 1, RuleUIDs: []string{"rule_c"}, Alerts: 1, Timestamp: now.Add(-1 * time.Minute)},
+			{OrgID: 3, DedupKey: "key-7", Receiver: "slack", Status: models.NotificationStatusSuccess, Attempt: 1, RuleUIDs: []string{"rulexc"}, Alerts: 1, Timestamp: now.Add(-1 * time.Minute)},
+			{OrgID: 3, DedupKey: "key-8", Receiver: "slack", Status: models.NotificationStatusSuccess, Attempt: 1, RuleUIDs: []string{"rule%c"}, Alerts: 1, Timestamp: now.Add(-1 * time.Minute)},
+		}))
+
+		result, err := dbstore.GetNotificationHistory(ctx, models.NotificationHistoryQuery{OrgID: 3, RuleUID: "rule_c"})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, []string{"rule_c"}, result[0].RuleUIDs)
+
+		result, err = dbstore.GetNotificationHistory(ctx, models.NotificationHistoryQuery{OrgID: 3, RuleUID: "rule%c"})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, []string{"rule%c"}, result[0].RuleUIDs)
+	})
+
+	t.Run("should filter by time range and limit", func(t *testing.T) {
+		result, err := dbstore.GetNotificationHistory(ctx, models.NotificationHistoryQuery{OrgID: 1, From: now.Add(-time.Hour), Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, now.Add(-1*time.Minute), result[0].Timestamp.UTC())
+	})
+
+	t.Run("should delete entries older than the retention", func(t *testing.T) {
+		dbstore.Cfg.NotificationHistory.Retention = 24 * time.Hour
+		deleted, err := dbstore.CleanUpNotificationHistory(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		result, err := dbstore.GetNotificationHistory(ctx, models.NotificationHistoryQuery{OrgID: 1})
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+	})
+}
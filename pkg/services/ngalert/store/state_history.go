@@ -0,0 +1,137 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// SaveStateHistory stores the state transitions recorded by the "sql" state history backend.
+func (st DBstore) SaveStateHistory(ctx context.Context, entries []models.StateHistoryEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		for i := range entries {
+			if _, err := sess.Insert(&entries[i]); err != nil {
+				return fmt.Errorf("failed to insert state history entry: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetStateHistory returns the most recent state transitions that match the query, ordered by time.
+// When folderUIDs is not empty, only the transitions of rules in these folders are returned.
+func (st DBstore) GetStateHistory(ctx context.Context, query models.HistoryQuery, folderUIDs []string) ([]models.StateHistoryEntry, error) {
+	// Labels are stored as a JSON object, a label matches when its encoded key and value are found in it.
+	keys := make([]string, 0, len(query.Labels))
+	for k := range query.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	labelPatterns := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pair, err := json.Marshal(map[string]string{k: query.Labels[k]})
+		if err != nil {
+			return nil, err
+		}
+		labelPatterns = append(labelPatterns, "%"+likeEscaper.Replace(string(pair[1:len(pair)-1]))+"%")
+	}
+
+	// The matching of labels is verified after reading the entries, so entries are read in pages until
+	// enough of them match.
+	var entries []models.StateHistoryEntry
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		for offset := 0; ; offset += query.Limit {
+			q := sess.Where("org_id = ?", query.OrgID)
+			if query.RuleUID != "" {
+				q = q.And("rule_uid = ?", query.RuleUID)
+			}
+			if query.DashboardUID != "" {
+				q = q.And("dashboard_uid = ?", query.DashboardUID)
+			}
+			if query.PanelID != 0 {
+				q = q.And("panel_id = ?", query.PanelID)
+			}
+			if !query.From.IsZero() {
+				q = q.And("timestamp >= ?", query.From.UTC())
+			}
+			if !query.To.IsZero() {
+				q = q.And("timestamp <= ?", query.To.UTC())
+			}
+			if len(folderUIDs) > 0 {
+				q = q.In("namespace_uid", folderUIDs)
+			}
+			for _, pattern := range labelPatterns {
+				q = q.And(`labels LIKE ? ESCAPE '!'`, pattern)
+			}
+			if query.Limit > 0 {
+				q = q.Limit(query.Limit, offset)
+			}
+
+			page := make([]models.StateHistoryEntry, 0)
+			if err := q.Desc("timestamp", "id").Find(&page); err != nil {
+				return err
+			}
+			for _, entry := range page {
+				if !matchesLabels(entry.Labels, query.Labels) {
+					continue
+				}
+				entries = append(entries, entry)
+				if len(entries) == query.Limit {
+					return nil
+				}
+			}
+			if query.Limit <= 0 || len(page) < query.Limit {
+				return nil
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.StateHistoryEntry, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		result = append(result, entries[i])
+	}
+	return result, nil
+}
+
+// CleanUpStateHistory deletes the state transitions older than the configured retention.
+// It returns the number of deleted entries.
+func (st DBstore) CleanUpStateHistory(ctx context.Context) (int64, error) {
+	retention := st.Cfg.StateHistory.SQLRetention
+	if retention <= 0 {
+		return 0, nil
+	}
+
+	var n int64
+	if err := st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		expire := TimeNow().UTC().Add(-retention)
+		st.Logger.Debug("Remove expired state history", "before", expire)
+		rows, err := sess.Where("timestamp < ?", expire).Delete(&models.StateHistoryEntry{})
+		if err != nil {
+			return fmt.Errorf("failed to delete expired state history: %w", err)
+		}
+		n = rows
+		return nil
+	}); err != nil {
+		return -1, err
+	}
+	return n, nil
+}
+
+func matchesLabels(labels, filter map[string]string) bool {
+	for k, v := range filter {
+		if lv, ok := labels[k]; !ok || lv != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests"
+)
+
+func TestIntegrationStateHistory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	oldClk := store.TimeNow
+	store.TimeNow = func() time.Time { return now }
+	t.Cleanup(func() { store.TimeNow = oldClk })
+
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	entries := []models.StateHistoryEntry{
+		{OrgID: 1, RuleUID: "rule-a", RuleVersion: 1, NamespaceUID: "folder-1", Labels: map[string]string{"team": "a", "env": "prod"}, PreviousState: "Normal", CurrentState: "Pending", Values: `{"A":1}`, Timestamp: now.Add(-3 * time.Minute)},
+		{OrgID: 1, RuleUID: "rule-a", RuleVersion: 2, NamespaceUID: "folder-1", Labels: map[string]string{"team": "a", "env": "prod"}, PreviousState: "Pending", CurrentState: "Alerting", Values: `{"A":2}`, Timestamp: now.Add(-2 * time.Minute)},
+		{OrgID: 1, RuleUID: "rule-b", RuleVersion: 1, NamespaceUID: "folder-2", Labels: map[string]string{"team": "b", "env": "prod"}, PreviousState: "Normal", CurrentState: "Alerting", Timestamp: now.Add(-1 * time.Minute)},
+		{OrgID: 2, RuleUID: "rule-c", RuleVersion: 1, NamespaceUID: "folder-3", Labels: map[string]string{"team": "a"}, PreviousState: "Normal", CurrentState: "Alerting", Timestamp: now.Add(-1 * time.Minute)},
+		{OrgID: 1, RuleUID: "rule-a", RuleVersion: 1, NamespaceUID: "folder-1", Labels: map[string]string{"team": "a", "env": "prod"}, PreviousState: "Alerting", CurrentState: "Normal", Timestamp: now.Add(-48 * time.Hour)},
+	}
+	require.NoError(t, dbstore.SaveStateHistory(ctx, entries))
+
+	t.Run("should return entries of the organization ordered by time", func(t *testing.T) {
+		result, err := dbstore.GetStateHistory(ctx, models.HistoryQuery{OrgID: 1}, nil)
+		require.NoError(t, err)
+		require.Len(t, result, 4)
+		assert.Equal(t, now.Add(-48*time.Hour), result[0].Timestamp.UTC())
+		assert.Equal(t, "rule-b", result[3].RuleUID)
+		assert.Equal(t, int64(2), result[2].RuleVersion)
+		assert.Equal(t, map[string]string{"team": "a", "env": "prod"}, result[2].Labels)
+		assert.Equal(t, `{"A":2}`, result[2].Values)
+	})
+
+	t.Run("should filter by rule UID and labels", func(t *testing.T) {
+		result, err := dbstore.GetStateHistory(ctx, models.HistoryQuery{OrgID: 1, RuleUID: "rule-a", From: now.Add(-time.Hour)}, nil)
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+
+		result, err = dbstore.GetStateHistory(ctx, models.HistoryQuery{OrgID: 1, Labels: map[string]string{"team": "b", "env": "prod"}}, nil)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "rule-b", result[0].RuleUID)
+
+		result, err = dbstore.GetStateHistory(ctx, models.HistoryQuery{OrgID: 1, Labels: map[string]string{"team": "c"}}, nil)
+		require.NoError(t, err)
+		require.Empty(t, result)
+	})
+
+	t.Run("should filter by folders", func(t *testing.T) {
+		result, err := dbstore.GetStateHistory(ctx, models.HistoryQuery{OrgID: 1}, []string{"folder-2"})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "rule-b", result[0].RuleUID)
+	})
+
+	t.Run("should return the most recent entries up to the limit", func(t *testing.T) {
+		result, err := dbstore.GetStateHistory(ctx, models.HistoryQuery{OrgID: 1, Limit: 2}, nil)
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, now.Add(-2*time.Minute), result[0].Timestamp.UTC())
+		assert.Equal(t, now.Add(-1*time.Minute), result[1].Timestamp.UTC())
+	})
+
+	t.Run("should fill the limit with matching entries when more recent entries do not match", func(t *testing.T) {
+		require.NoError(t, dbstore.SaveStateHistory(ctx, []models.StateHistoryEntry{
+			{OrgID: 3, RuleUID: "rule-d", RuleVersion: 1, NamespaceUID: "folder-4", Labels: map[string]string{"team": "a_b"}, PreviousState: "Normal", CurrentState: "Pending", Timestamp: now.Add(-5 * time.Minute)},
+			{OrgID: 3, RuleUID: "rule-d", RuleVersion: 1, NamespaceUID: "folder-4", Labels: map[string]string{"team": "a_b"}, PreviousState: "Pending", CurrentState: "Alerting", Timestamp: now.Add(-4 * time.Minute)},
+			{OrgID: 3, RuleUID: "rule-e", RuleVersion: 1, NamespaceUID: "folder-4", Labels: map[string]string{"team": "axb"}, PreviousState: "Normal", CurrentState: "Pending", Timestamp: now.Add(-3 * time.Minute)},
+			{OrgID: 3, RuleUID: "rule-e", RuleVersion: 1, NamespaceUID: "folder-4", Labels: map[string]string{"team": "axb"}, PreviousState: "Pending", CurrentState: "Alerting", Timestamp: now.Add(-2 * time.Minute)},
+			{OrgID: 3, RuleUID: "rule-f", RuleVersion: 1, NamespaceUID: "folder-4", Labels: map[string]string{"team": "a%b"}, PreviousState: "Normal", CurrentState: "Alerting", Timestamp: now.Add(-1 * time.Minute)},
+		}))
+
+		result, err := dbstore.GetStateHistory(ctx, models.HistoryQuery{OrgID: 3, Labels: map[string]string{"team": "a_b"}, Limit: 2}, nil)
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		for _, entry := range result {
+			assert.Equal(t, "rule-d", entry.RuleUID)
+		}
+
+		result, err = dbstore.GetStateHistory(ctx, models.HistoryQuery{OrgID: 3, Labels: map[string]string{"team": "a%b"}}, nil)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "rule-f", result[0].RuleUID)
+	})
+
+	t.Run("should delete entries older than the retention", func(t *testing.T) {
+		dbstore.Cfg.StateHistory.SQLRetention = 24 * time.Hour
+		deleted, err := dbstore.CleanUpStateHistory(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		result, err := dbstore.GetStateHistory(ctx, models.HistoryQuery{OrgID: 1}, nil)
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+	})
+}
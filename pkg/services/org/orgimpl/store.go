@@ -831,6 +831,8 @@ func (ss *sqlStore) userDeletions() []string {
 		"DELETE FROM user_auth WHERE user_id = ?",
 		"DELETE FROM user_auth_token WHERE user_id = ?",
 		"DELETE FROM quota WHERE user_id = ?",
+		"DELETE FROM user_two_factor WHERE user_id = ?",
+		"DELETE FROM user_webauthn_credential WHERE user_id = ?",
 	}
 	return deletes
 }
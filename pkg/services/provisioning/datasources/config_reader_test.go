@@ -33,6 +33,7 @@ var (
 
 	oneDatasourceWithTwoCorrelations   = "testdata/one-datasource-two-correlations"
 	correlationsDifferentOrganizations = "testdata/correlations-different-organizations"
+	correlationTransformations         = "testdata/one-datasource-correlation-transformations"
 )
 
 func TestDatasourceAsConfig(t *testing.T) {
@@ -291,6 +292,40 @@ func TestDatasourceAsConfig(t *testing.T) {
 			require.Equal(t, true, correlationsStore.deletedBySourceUID[0].OnlyProvisioned)
 		})
 
+		t.Run("Creates correlations with jsonpath, label and template transformations", func(t *testing.T) {
+			store := &spyStore{}
+			orgFake := &orgtest.FakeOrgService{}
+			correlationsStore := &mockCorrelationsStore{}
+			dc := newDatasourceProvisioner(logger, store, correlationsStore, orgFake)
+			err := dc.applyChanges(context.Background(), correlationTransformations)
+			if err != nil {
+				t.Fatalf("applyChanges return an error %v", err)
+			}
+
+			require.Equal(t, 1, len(correlationsStore.created))
+			require.Equal(t, correlations.Transformations{
+				{Type: "jsonpath", Field: "body", Expression: "$.resource.service", MapValue: "service"},
+				{Type: "label", Expression: "trace_id", MapValue: "traceId"},
+				{Type: "template", Expression: "${service}/${traceId}", MapValue: "tracePath"},
+			}, correlationsStore.created[0].Config.Transformations)
+		})
+
+		t.Run("Fails on invalid transformations", func(t *testing.T) {
+			correlation := map[string]any{
+				"targetUID":   "loki",
+				"label":       "traces",
+				"description": "",
+				"config": map[string]any{
+					"type":            "query",
+					"field":           "traceID",
+					"target":          map[string]any{},
+					"transformations": []map[string]any{{"type": "jsonpath", "expression": "resource.service"}},
+				},
+			}
+			_, err := makeCreateCorrelationCommand(correlation, "loki", 1)
+			require.ErrorContains(t, err, correlations.ErrTransformationInvalidJSONPath.Error())
+		})
+
 		t.Run("Updating existing datasource deletes existing correlations and creates two", func(t *testing.T) {
 			store := &spyStore{items: []*datasources.DataSource{{Name: "Graphite", OrgID: 1, ID: 1}}}
 			orgFake := &orgtest.FakeOrgService{}
@@ -31,9 +31,12 @@ import (
 	"github.com/grafana/grafana/pkg/services/provisioning/dashboards"
 	"github.com/grafana/grafana/pkg/services/provisioning/datasources"
 	"github.com/grafana/grafana/pkg/services/provisioning/plugins"
+	prov_teamsync "github.com/grafana/grafana/pkg/services/provisioning/teamsync"
 	"github.com/grafana/grafana/pkg/services/quota"
 	"github.com/grafana/grafana/pkg/services/searchV2"
 	"github.com/grafana/grafana/pkg/services/secrets"
+	"github.com/grafana/grafana/pkg/services/team"
+	"github.com/grafana/grafana/pkg/services/teamsync"
 	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/storage/legacysql/dualwrite"
 )
@@ -59,6 +62,8 @@ func ProvideService(
 	resourcePermissions accesscontrol.ReceiverPermissionsService,
 	tracer tracing.Tracer,
 	dual dualwrite.Service,
+	teamService team.Service,
+	teamSyncService *teamsync.Service,
 ) (*ProvisioningServiceImpl, error) {
 	s := &ProvisioningServiceImpl{
 		Cfg:                          cfg,
@@ -72,6 +77,7 @@ func ProvideService(
 		provisionDatasources:         datasources.Provision,
 		provisionPlugins:             plugins.Provision,
 		provisionAlerting:            prov_alerting.Provision,
+		provisionTeamSync:            prov_teamsync.Provision,
 		dashboardProvisioningService: dashboardProvisioningService,
 		dashboardService:             dashboardService,
 		datasourceService:            datasourceService,
@@ -85,6 +91,8 @@ func ProvideService(
 		folderService:                folderService,
 		resourcePermissions:          resourcePermissions,
 		tracer:                       tracer,
+		teamService:                  teamService,
+		teamSyncService:              teamSyncService,
 	}
 
 	if err := s.setDashboardProvisioner(); err != nil {
@@ -154,6 +162,7 @@ type ProvisioningServiceImpl struct {
 	provisionDatasources         func(context.Context, string, datasources.BaseDataSourceService, datasources.CorrelationsStore, org.Service) error
 	provisionPlugins             func(context.Context, string, pluginstore.Store, pluginsettings.Service, org.Service) error
 	provisionAlerting            func(context.Context, prov_alerting.ProvisionerConfig) error
+	provisionTeamSync            func(context.Context, string, prov_teamsync.TeamGroupStore, team.Service, org.Service) error
 	mutex                        sync.Mutex
 	dashboardProvisioningService dashboardservice.DashboardProvisioningService
 	dashboardService             dashboardservice.DashboardService
@@ -167,6 +176,8 @@ type ProvisioningServiceImpl struct {
 	resourcePermissions          accesscontrol.ReceiverPermissionsService
 	tracer                       tracing.Tracer
 	dual                         dualwrite.Service
+	teamService                  team.Service
+	teamSyncService              *teamsync.Service
 	onceInitProvisioners         sync.Once
 }
 
@@ -201,6 +212,12 @@ func (ps *ProvisioningServiceImpl) Run(ctx context.Context) error {
 			ps.log.Error("Failed to provision alerting", "error", err)
 			return
 		}
+
+		err = ps.ProvisionTeamSync(ctx)
+		if err != nil {
+			ps.log.Error("Failed to provision team sync", "error", err)
+			return
+		}
 	})
 
 	if err != nil {
@@ -267,6 +284,24 @@ func (ps *ProvisioningServiceImpl) ProvisionPlugins(ctx context.Context) error {
 	return nil
 }
 
+// ProvisionTeamSync maps the groups of external identity providers to teams.
+// It does nothing when team sync is disabled.
+func (ps *ProvisioningServiceImpl) ProvisionTeamSync(ctx context.Context) error {
+	if ps.teamSyncService == nil || !ps.teamSyncService.Enabled() {
+		return nil
+	}
+
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	teamSyncPath := filepath.Join(ps.Cfg.ProvisioningPath, "teamsync")
+	if err := ps.provisionTeamSync(ctx, teamSyncPath, ps.teamSyncService, ps.teamService, ps.orgService); err != nil {
+		err = fmt.Errorf("%v: %w", "team sync provisioning error", err)
+		ps.log.Error("Failed to provision team sync", "error", err)
+		return err
+	}
+	return nil
+}
+
 func (ps *ProvisioningServiceImpl) ProvisionDashboards(ctx context.Context) error {
 	err := ps.setDashboardProvisioner()
 	if err != nil {
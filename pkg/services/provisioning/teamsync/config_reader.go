@@ -0,0 +1,109 @@
+package teamsync
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+)
+
+type configReader struct {
+	log log.Logger
+}
+
+func (cr *configReader) readConfig(path string) ([]*teamGroupsAsConfig, error) {
+	var configs []*teamGroupsAsConfig
+	cr.log.Debug("Looking for team sync provisioning files", "path", path)
+
+	files, err := os.ReadDir(path)
+	if err != nil {
+		cr.log.Error("Failed to read team sync provisioning files from directory", "path", path, "error", err)
+		return configs, nil
+	}
+
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), ".yaml") || strings.HasSuffix(file.Name(), ".yml") {
+			cr.log.Debug("Parsing team sync provisioning file", "path", path, "file.Name", file.Name())
+			cfg, err := cr.parseTeamGroupsConfig(path, file)
+			if err != nil {
+				return nil, err
+			}
+
+			if cfg != nil {
+				configs = append(configs, cfg)
+			}
+		}
+	}
+
+	if err := validateRequiredField(configs); err != nil {
+		return nil, err
+	}
+
+	checkOrgIDAndOrgName(configs)
+
+	return configs, nil
+}
+
+func (cr *configReader) parseTeamGroupsConfig(path string, file fs.DirEntry) (*teamGroupsAsConfig, error) {
+	filename, err := filepath.Abs(filepath.Join(path, file.Name()))
+	if err != nil {
+		return nil, err
+	}
+
+	// nolint:gosec
+	// We can ignore the gosec G304 warning on this one because `filename` comes from ps.Cfg.ProvisioningPath
+	yamlFile, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg *teamGroupsAsConfigV1
+	if err := yaml.Unmarshal(yamlFile, &cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg.mapToTeamGroupsFromConfig(), nil
+}
+
+func validateRequiredField(configs []*teamGroupsAsConfig) error {
+	for i := range configs {
+		errs := []error{}
+		for index, t := range configs[i].Teams {
+			if t.Name == "" && t.UID == "" {
+				errs = append(errs, fmt.Errorf("team item %d in configuration doesn't contain required field name or uid", index+1))
+			}
+			for _, group := range t.Groups {
+				if strings.TrimSpace(group) == "" {
+					errs = append(errs, fmt.Errorf("team item %d in configuration contains an empty group", index+1))
+					break
+				}
+			}
+		}
+
+		if len(errs) != 0 {
+			return errors.Join(errs...)
+		}
+	}
+
+	return nil
+}
+
+func checkOrgIDAndOrgName(configs []*teamGroupsAsConfig) {
+	for i := range configs {
+		for _, t := range configs[i].Teams {
+			if t.OrgID < 1 {
+				if t.OrgName == "" {
+					t.OrgID = 1
+				} else {
+					t.OrgID = 0
+				}
+			}
+		}
+	}
+}
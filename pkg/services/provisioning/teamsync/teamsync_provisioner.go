@@ -0,0 +1,99 @@
+package teamsync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/team"
+)
+
+// TeamGroupStore replaces the groups mapped to a team.
+type TeamGroupStore interface {
+	SetGroups(ctx context.Context, orgID, teamID int64, groupIDs []string) error
+}
+
+// Provision scans a directory for provisioning config files
+// and maps the groups in those files to teams.
+func Provision(ctx context.Context, configDirectory string, teamGroups TeamGroupStore, teamService team.Service, orgService org.Service) error {
+	logger := log.New("provisioning.teamsync")
+	tp := TeamSyncProvisioner{
+		log:         logger,
+		cfgProvider: &configReader{log: logger},
+		teamGroups:  teamGroups,
+		teamService: teamService,
+		orgService:  orgService,
+	}
+	return tp.applyChanges(ctx, configDirectory)
+}
+
+// TeamSyncProvisioner is responsible for provisioning team group mappings
+// based on configuration read by the `configReader`
+type TeamSyncProvisioner struct {
+	log         log.Logger
+	cfgProvider *configReader
+	teamGroups  TeamGroupStore
+	teamService team.Service
+	orgService  org.Service
+}
+
+func (tp *TeamSyncProvisioner) apply(ctx context.Context, cfg *teamGroupsAsConfig) error {
+	for _, t := range cfg.Teams {
+		if t.OrgID == 0 && t.OrgName != "" {
+			res, err := tp.orgService.GetByName(ctx, &org.GetOrgByNameQuery{Name: t.OrgName})
+			if err != nil {
+				return err
+			}
+			t.OrgID = res.ID
+		}
+
+		teamID, err := tp.getTeamID(ctx, t)
+		if err != nil {
+			return err
+		}
+
+		tp.log.Info("Updating team groups from configuration", "orgId", t.OrgID, "teamId", teamID, "groups", len(t.Groups))
+		if err := tp.teamGroups.SetGroups(ctx, t.OrgID, teamID, t.Groups); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (tp *TeamSyncProvisioner) getTeamID(ctx context.Context, t *teamFromConfig) (int64, error) {
+	ctx, requester := identity.WithServiceIdentity(ctx, t.OrgID)
+	if t.UID != "" {
+		res, err := tp.teamService.GetTeamByID(ctx, &team.GetTeamByIDQuery{OrgID: t.OrgID, UID: t.UID, SignedInUser: requester})
+		if err != nil {
+			return 0, fmt.Errorf("failed to get team with uid %q: %w", t.UID, err)
+		}
+		return res.ID, nil
+	}
+
+	res, err := tp.teamService.SearchTeams(ctx, &team.SearchTeamsQuery{OrgID: t.OrgID, Name: t.Name, Limit: 1, SignedInUser: requester})
+	if err != nil {
+		return 0, err
+	}
+	if len(res.Teams) == 0 {
+		return 0, fmt.Errorf("team %q not found in organization %d: %w", t.Name, t.OrgID, team.ErrTeamNotFound)
+	}
+	return res.Teams[0].ID, nil
+}
+
+func (tp *TeamSyncProvisioner) applyChanges(ctx context.Context, configPath string) error {
+	configs, err := tp.cfgProvider.readConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	for _, cfg := range configs {
+		if err := tp.apply(ctx, cfg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
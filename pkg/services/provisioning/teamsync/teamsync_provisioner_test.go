@@ -0,0 +1,102 @@
+package teamsync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/org/orgtest"
+	"github.com/grafana/grafana/pkg/services/team"
+	"github.com/grafana/grafana/pkg/services/team/teamtest"
+)
+
+const (
+	correctProperties = "./testdata/correct-properties"
+	missingTeam       = "./testdata/missing-team"
+	brokenYaml        = "./testdata/broken-yaml"
+)
+
+func TestTeamSyncProvisioner(t *testing.T) {
+	t.Run("Should map groups to teams by name and uid", func(t *testing.T) {
+		store := &fakeTeamGroupStore{groups: map[int64][]string{}}
+		orgService := orgtest.NewOrgServiceFake()
+		orgService.ExpectedOrgs = []*org.OrgDTO{{ID: 2, Name: "Second Org"}}
+		teamService := &fakeTeamService{teams: map[string]*team.TeamDTO{
+			"Admins":  {ID: 1, OrgID: 1, Name: "Admins"},
+			"editors": {ID: 2, OrgID: 2, UID: "editors"},
+		}}
+
+		err := Provision(context.Background(), correctProperties, store, teamService, orgService)
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"cn=admins,ou=groups,dc=grafana,dc=org", "admins@example.com"}, store.groups[1])
+		require.Equal(t, []string{"editors"}, store.groups[2])
+		require.Equal(t, map[int64]int64{1: 1, 2: 2}, store.orgs)
+	})
+
+	t.Run("Should fail when the team does not exist", func(t *testing.T) {
+		store := &fakeTeamGroupStore{groups: map[int64][]string{}}
+		orgService := orgtest.NewOrgServiceFake()
+		orgService.ExpectedOrgs = []*org.OrgDTO{{ID: 2, Name: "Second Org"}}
+
+		err := Provision(context.Background(), correctProperties, store, &fakeTeamService{}, orgService)
+		require.ErrorIs(t, err, team.ErrTeamNotFound)
+		require.Empty(t, store.groups)
+	})
+
+	t.Run("Should fail when the team is not named", func(t *testing.T) {
+		cr := &configReader{log: log.NewNopLogger()}
+		_, err := cr.readConfig(missingTeam)
+		require.EqualError(t, err, "team item 1 in configuration doesn't contain required field name or uid")
+	})
+
+	t.Run("Should fail on broken yaml", func(t *testing.T) {
+		cr := &configReader{log: log.NewNopLogger()}
+		_, err := cr.readConfig(brokenYaml)
+		require.Error(t, err)
+	})
+
+	t.Run("Should skip a missing directory", func(t *testing.T) {
+		cr := &configReader{log: log.NewNopLogger()}
+		configs, err := cr.readConfig("./testdata/does-not-exist")
+		require.NoError(t, err)
+		require.Empty(t, configs)
+	})
+}
+
+type fakeTeamGroupStore struct {
+	groups map[int64][]string
+	orgs   map[int64]int64
+}
+
+func (s *fakeTeamGroupStore) SetGroups(_ context.Context, orgID, teamID int64, groupIDs []string) error {
+	if s.orgs == nil {
+		s.orgs = map[int64]int64{}
+	}
+	s.groups[teamID] = groupIDs
+	s.orgs[teamID] = orgID
+	return nil
+}
+
+type fakeTeamService struct {
+	teamtest.FakeService
+	teams map[string]*team.TeamDTO
+}
+
+func (s *fakeTeamService) SearchTeams(_ context.Context, query *team.SearchTeamsQuery) (team.SearchTeamQueryResult, error) {
+	result := team.SearchTeamQueryResult{}
+	if t, ok := s.teams[query.Name]; ok && t.OrgID == query.OrgID {
+		result.Teams = append(result.Teams, t)
+	}
+	return result, nil
+}
+
+func (s *fakeTeamService) GetTeamByID(_ context.Context, query *team.GetTeamByIDQuery) (*team.TeamDTO, error) {
+	if t, ok := s.teams[query.UID]; ok && t.OrgID == query.OrgID {
+		return t, nil
+	}
+	return nil, team.ErrTeamNotFound
+}
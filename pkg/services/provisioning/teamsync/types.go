@@ -0,0 +1,56 @@
+package teamsync
+
+import "github.com/grafana/grafana/pkg/services/provisioning/values"
+
+// teamGroupsAsConfig is a normalized data object for team sync config data. Any config version should be mappable
+// to this type.
+type teamGroupsAsConfig struct {
+	Teams []*teamFromConfig
+}
+
+type teamFromConfig struct {
+	OrgID   int64
+	OrgName string
+	Name    string
+	UID     string
+	Groups  []string
+}
+
+type teamFromConfigV1 struct {
+	OrgID   values.Int64Value    `json:"orgId" yaml:"orgId"`
+	OrgName values.StringValue   `json:"orgName" yaml:"orgName"`
+	Name    values.StringValue   `json:"name" yaml:"name"`
+	UID     values.StringValue   `json:"uid" yaml:"uid"`
+	Groups  []values.StringValue `json:"groups" yaml:"groups"`
+}
+
+// teamGroupsAsConfigV1 is a mapping for version 1 configs. This is mapped to its normalised version.
+type teamGroupsAsConfigV1 struct {
+	Teams []*teamFromConfigV1 `json:"teams" yaml:"teams"`
+}
+
+// mapToTeamGroupsFromConfig maps config syntax to a normalized teamGroupsAsConfig object. Every version
+// of the config syntax should have this function.
+func (cfg *teamGroupsAsConfigV1) mapToTeamGroupsFromConfig() *teamGroupsAsConfig {
+	r := &teamGroupsAsConfig{}
+	if cfg == nil {
+		return r
+	}
+
+	for _, t := range cfg.Teams {
+		groups := make([]string, 0, len(t.Groups))
+		for _, group := range t.Groups {
+			groups = append(groups, group.Value())
+		}
+
+		r.Teams = append(r.Teams, &teamFromConfig{
+			OrgID:   t.OrgID.Value(),
+			OrgName: t.OrgName.Value(),
+			Name:    t.Name.Value(),
+			UID:     t.UID.Value(),
+			Groups:  groups,
+		})
+	}
+
+	return r
+}
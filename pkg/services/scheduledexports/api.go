@@ -0,0 +1,91 @@
+package scheduledexports
+
+import (
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/middleware"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func (s *Service) registerAPIEndpoints() {
+	authorize := accesscontrol.Middleware(s.accessControl)
+	s.routeRegister.Group("/api/scheduled-exports", func(r routing.RouteRegister) {
+		r.Get("/", authorize(accesscontrol.EvalPermission(ActionRead)), routing.Wrap(s.listHandler))
+		r.Post("/", authorize(accesscontrol.EvalPermission(ActionCreate)), routing.Wrap(s.createHandler))
+		r.Get("/:uid", authorize(accesscontrol.EvalPermission(ActionRead, ScopeUID)), routing.Wrap(s.getHandler))
+		r.Put("/:uid", authorize(accesscontrol.EvalPermission(ActionWrite, ScopeUID)), routing.Wrap(s.updateHandler))
+		r.Delete("/:uid", authorize(accesscontrol.EvalPermission(ActionDelete, ScopeUID)), routing.Wrap(s.deleteHandler))
+		r.Get("/:uid/runs", authorize(accesscontrol.EvalPermission(ActionRead, ScopeUID)), routing.Wrap(s.runsHandler))
+		r.Post("/:uid/run", authorize(accesscontrol.EvalPermission(ActionWrite, ScopeUID)), routing.Wrap(s.runHandler))
+	}, middleware.ReqSignedIn)
+}
+
+// listHandler returns the scheduled exports the user can read.
+func (s *Service) listHandler(c *contextmodel.ReqContext) response.Response {
+	exports, err := s.List(c.Req.Context(), c.SignedInUser)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to list scheduled exports", err)
+	}
+	return response.JSON(http.StatusOK, exports)
+}
+
+func (s *Service) createHandler(c *contextmodel.ReqContext) response.Response {
+	cmd := CreateScheduledExportCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	export, err := s.Create(c.Req.Context(), c.SignedInUser, cmd)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to create scheduled export", err)
+	}
+	return response.JSON(http.StatusOK, export)
+}
+
+func (s *Service) getHandler(c *contextmodel.ReqContext) response.Response {
+	export, err := s.Get(c.Req.Context(), c.GetOrgID(), web.Params(c.Req)[":uid"])
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to get scheduled export", err)
+	}
+	return response.JSON(http.StatusOK, export)
+}
+
+func (s *Service) updateHandler(c *contextmodel.ReqContext) response.Response {
+	cmd := UpdateScheduledExportCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	export, err := s.Update(c.Req.Context(), c.SignedInUser, web.Params(c.Req)[":uid"], cmd)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to update scheduled export", err)
+	}
+	return response.JSON(http.StatusOK, export)
+}
+
+func (s *Service) deleteHandler(c *contextmodel.ReqContext) response.Response {
+	if err := s.Delete(c.Req.Context(), c.GetOrgID(), web.Params(c.Req)[":uid"]); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to delete scheduled export", err)
+	}
+	return response.Success("Scheduled export deleted")
+}
+
+// runsHandler returns the run history of a scheduled export, latest run first.
+func (s *Service) runsHandler(c *contextmodel.ReqContext) response.Response {
+	runs, err := s.Runs(c.Req.Context(), c.GetOrgID(), web.Params(c.Req)[":uid"])
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to get scheduled export runs", err)
+	}
+	return response.JSON(http.StatusOK, runs)
+}
+
+// runHandler runs a scheduled export immediately and returns the run.
+func (s *Service) runHandler(c *contextmodel.ReqContext) response.Response {
+	run, err := s.RunNow(c.Req.Context(), c.GetOrgID(), web.Params(c.Req)[":uid"])
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to run scheduled export", err)
+	}
+	return response.JSON(http.StatusOK, run)
+}
@@ -0,0 +1,427 @@
+package scheduledexports
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/expr"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/notifications"
+	"github.com/grafana/grafana/pkg/services/rendering"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+const (
+	emailTemplate = "scheduled_export"
+
+	defaultMaxDataPoints = 1000
+	renderTimeout        = 60 * time.Second
+	renderWidth          = 1000
+	renderHeight         = 500
+)
+
+type exportPanel struct {
+	id      int64
+	title   string
+	queries []*simplejson.Json
+}
+
+// exportPanels returns the panels of a dashboard with queries. When panel IDs are given,
+// only these panels are returned, and all of them must exist.
+func exportPanels(dashboard *simplejson.Json, panelIDs []int64) ([]exportPanel, error) {
+	var panels []exportPanel
+	collectPanels(dashboard.Get("panels").MustArray(), &panels)
+
+	if len(panelIDs) == 0 {
+		if len(panels) == 0 {
+			return nil, ErrNoPanelQueries.Errorf("dashboard has no panels with queries")
+		}
+		return panels, nil
+	}
+
+	selected := make([]exportPanel, 0, len(panelIDs))
+	for _, id := range panelIDs {
+		found := false
+		for _, p := range panels {
+			if p.id == id {
+				selected = append(selected, p)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, ErrPanelNotFound.Errorf("panel %d not found or has no queries", id)
+		}
+	}
+	return selected, nil
+}
+
+func collectPanels(panelObjs []any, panels *[]exportPanel) {
+	for _, panelObj := range panelObjs {
+		panel := simplejson.NewFromAny(panelObj)
+
+		// Panels of collapsed rows are nested in the row.
+		if panel.Get("type").MustString() == "row" {
+			collectPanels(panel.Get("panels").MustArray(), panels)
+			continue
+		}
+
+		var queries []*simplejson.Json
+		hasExpression := false
+		for _, queryObj := range panel.Get("targets").MustArray() {
+			if expr.NodeTypeFromDatasourceUID(dataSourceUID(simplejson.NewFromAny(queryObj))) == expr.TypeCMDNode {
+				hasExpression = true
+			}
+		}
+		for _, queryObj := range panel.Get("targets").MustArray() {
+			q := simplejson.NewFromAny(queryObj)
+			// Hidden queries are skipped, unless an expression may depend on them.
+			if !hasExpression && q.Get("hide").MustBool() {
+				continue
+			}
+			if _, ok := q.CheckGet("datasource"); !ok {
+				if ds, ok := panel.CheckGet("datasource"); ok {
+					q.Set("datasource", ds.Interface())
+				}
+			}
+			queries = append(queries, q)
+		}
+		if len(queries) == 0 {
+			continue
+		}
+		*panels = append(*panels, exportPanel{
+			id:      panel.Get("id").MustInt64(),
+			title:   panel.Get("title").MustString(),
+			queries: queries,
+		})
+	}
+}
+
+func dataSourceUID(q *simplejson.Json) string {
+	uid := q.Get("datasource").Get("uid").MustString()
+	// before 8.3 special types could be sent as datasource (expr)
+	if uid == "" {
+		uid = q.Get("datasource").MustString()
+	}
+	return uid
+}
+
+// execute runs a scheduled export and records the run in the history.
+func (s *Service) execute(ctx context.Context, export *ScheduledExport, manual bool) *Run {
+	run := &Run{
+		OrgID:     export.OrgID,
+		ExportUID: export.UID,
+		State:     RunStateRunning,
+		Manual:    manual,
+		Started:   s.now(),
+	}
+	if err := s.store.insertRun(ctx, run); err != nil {
+		s.log.Error("Failed to store scheduled export run", "uid", export.UID, "error", err)
+	}
+
+	files, err := s.send(ctx, export)
+	finished := s.now()
+	run.Finished = &finished
+	run.Files = files
+	if err != nil {
+		s.log.Warn("Scheduled export failed", "uid", export.UID, "orgId", export.OrgID, "error", err)
+		run.State = RunStateFailed
+		run.Error = err.Error()
+	} else {
+		s.log.Info("Scheduled export sent", "uid", export.UID, "orgId", export.OrgID, "files", files)
+		run.State = RunStateSuccess
+	}
+
+	if run.ID != 0 {
+		if err := s.store.finishRun(ctx, run); err != nil {
+			s.log.Error("Failed to store scheduled export run", "uid", export.UID, "error", err)
+		}
+	}
+	return run
+}
+
+// send exports the panels and sends the email. It returns the number of attached files.
+func (s *Service) send(ctx context.Context, export *ScheduledExport) (int, error) {
+	usr, err := s.signedInUser(ctx, export.OrgID, export.UserID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user %d: %w", export.UserID, err)
+	}
+	ctx = identity.WithRequester(ctx, usr)
+
+	dashboard, err := s.dashboardService.GetDashboard(ctx, &dashboards.GetDashboardQuery{UID: export.DashboardUID, OrgID: export.OrgID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get dashboard %s: %w", export.DashboardUID, err)
+	}
+	if err := s.checkDashboardAccess(ctx, usr, dashboard.UID); err != nil {
+		return 0, err
+	}
+	panels, err := exportPanels(dashboard.Data, export.PanelIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	loc, err := time.LoadLocation(export.Timezone)
+	if err != nil {
+		return 0, err
+	}
+	from, to, err := s.timeRange(export, dashboard, loc)
+	if err != nil {
+		return 0, err
+	}
+
+	var files []*notifications.SendEmailAttachFile
+	for _, panel := range panels {
+		var panelFiles []*notifications.SendEmailAttachFile
+		switch export.Format {
+		case FormatPNG:
+			panelFiles, err = s.renderPanel(ctx, usr, export, panel, from, to)
+		default:
+			panelFiles, err = s.queryPanel(ctx, usr, panel, from, to, loc)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("panel %d: %w", panel.id, err)
+		}
+		files = append(files, panelFiles...)
+	}
+
+	err = s.emailSender.SendEmailCommandHandlerSync(ctx, &notifications.SendEmailCommandSync{
+		SendEmailCommand: notifications.SendEmailCommand{
+			To:       export.Recipients,
+			Template: emailTemplate,
+			Subject:  export.Name,
+			Data: map[string]any{
+				"Name":           export.Name,
+				"DashboardTitle": dashboard.Title,
+				"DashboardURL":   fmt.Sprintf("%sd/%s?orgId=%d&from=%d&to=%d", s.cfg.AppURL, dashboard.UID, export.OrgID, from.UnixMilli(), to.UnixMilli()),
+				"TimeFrom":       from.In(loc).Format(time.RFC1123),
+				"TimeTo":         to.In(loc).Format(time.RFC1123),
+			},
+			AttachedFiles: files,
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to send email: %w", err)
+	}
+	return len(files), nil
+}
+
+// timeRange resolves the time range of the export in its timezone. The
+// dashboard time range is used when the export has none.
+func (s *Service) timeRange(export *ScheduledExport, dashboard *dashboards.Dashboard, loc *time.Location) (time.Time, time.Time, error) {
+	fromRaw, toRaw := export.TimeFrom, export.TimeTo
+	if fromRaw == "" {
+		fromRaw = dashboard.Data.Get("time").Get("from").MustString("now-6h")
+	}
+	if toRaw == "" {
+		toRaw = dashboard.Data.Get("time").Get("to").MustString("now")
+	}
+	tr := gtime.NewTimeRange(fromRaw, toRaw)
+	from, err := tr.ParseFrom(gtime.WithLocation(loc), gtime.WithNow(s.now()))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid time range from %q: %w", fromRaw, err)
+	}
+	to, err := tr.ParseTo(gtime.WithLocation(loc), gtime.WithNow(s.now()))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid time range to %q: %w", toRaw, err)
+	}
+	return from, to, nil
+}
+
+// signedInUser returns the user with their permissions in the organization of the export.
+func (s *Service) signedInUser(ctx context.Context, orgID, userID int64) (*user.SignedInUser, error) {
+	usr, err := s.userService.GetSignedInUser(ctx, &user.GetSignedInUserQuery{UserID: userID, OrgID: orgID})
+	if err != nil {
+		return nil, err
+	}
+	if usr.Permissions == nil {
+		usr.Permissions = make(map[int64]map[string][]string)
+	}
+	permissions, err := s.accessControlService.GetUserPermissions(ctx, usr, accesscontrol.Options{ReloadCache: false})
+	if err != nil {
+		return nil, err
+	}
+	usr.Permissions[orgID] = accesscontrol.GroupScopesByActionContext(ctx, permissions)
+	return usr, nil
+}
+
+// queryPanel runs the queries of a panel and returns a CSV file per data frame.
+func (s *Service) queryPanel(ctx context.Context, usr *user.SignedInUser, panel exportPanel, from, to time.Time, loc *time.Location) ([]*notifications.SendEmailAttachFile, error) {
+	var defaultDataSource map[string]any
+	intervalMs := max(to.Sub(from).Milliseconds()/defaultMaxDataPoints, 1)
+	queries := make([]*simplejson.Json, 0, len(panel.queries))
+	for _, q := range panel.queries {
+		if dataSourceUID(q) == "" {
+			if defaultDataSource == nil {
+				ds, err := s.defaultDataSource(ctx, usr.GetOrgID())
+				if err != nil {
+					return nil, err
+				}
+				defaultDataSource = map[string]any{"uid": ds.UID, "type": ds.Type}
+			}
+			q.Set("datasource", defaultDataSource)
+		}
+		if _, ok := q.CheckGet("maxDataPoints"); !ok {
+			q.Set("maxDataPoints", defaultMaxDataPoints)
+		}
+		if _, ok := q.CheckGet("intervalMs"); !ok {
+			q.Set("intervalMs", intervalMs)
+		}
+		queries = append(queries, q)
+	}
+
+	resp, err := s.queryService.QueryData(ctx, usr, false, dtos.MetricRequest{
+		From:    strconv.FormatInt(from.UnixMilli(), 10),
+		To:      strconv.FormatInt(to.UnixMilli(), 10),
+		Queries: queries,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	refIDs := make([]string, 0, len(resp.Responses))
+	for refID := range resp.Responses {
+		refIDs = append(refIDs, refID)
+	}
+	sort.Strings(refIDs)
+
+	var frames data.Frames
+	for _, refID := range refIDs {
+		res := resp.Responses[refID]
+		if res.Error != nil {
+			return nil, fmt.Errorf("query %s: %w", refID, res.Error)
+		}
+		frames = append(frames, res.Frames...)
+	}
+
+	name := fileName(panel)
+	files := make([]*notifications.SendEmailAttachFile, 0, len(frames))
+	for i, frame := range frames {
+		content, err := frameToCSV(frame, loc)
+		if err != nil {
+			return nil, err
+		}
+		fileName := name + ".csv"
+		if len(frames) > 1 {
+			fileName = fmt.Sprintf("%s-%d.csv", name, i+1)
+		}
+		files = append(files, &notifications.SendEmailAttachFile{Name: fileName, Content: content})
+	}
+	return files, nil
+}
+
+func (s *Service) defaultDataSource(ctx context.Context, orgID int64) (*datasources.DataSource, error) {
+	dataSources, err := s.dataSourceService.GetDataSources(ctx, &datasources.GetDataSourcesQuery{OrgID: orgID})
+	if err != nil {
+		return nil, err
+	}
+	for _, ds := range dataSources {
+		if ds.IsDefault {
+			return ds, nil
+		}
+	}
+	return nil, datasources.ErrDataSourceNotFound
+}
+
+// renderPanel renders a panel to a PNG image with the image renderer.
+func (s *Service) renderPanel(ctx context.Context, usr *user.SignedInUser, export *ScheduledExport, panel exportPanel, from, to time.Time) ([]*notifications.SendEmailAttachFile, error) {
+	result, err := s.renderService.Render(ctx, rendering.RenderPNG, rendering.Opts{
+		CommonOpts: rendering.CommonOpts{
+			TimeoutOpts: rendering.TimeoutOpts{Timeout: renderTimeout},
+			AuthOpts: rendering.AuthOpts{
+				OrgID:   export.OrgID,
+				UserID:  usr.UserID,
+				OrgRole: usr.OrgRole,
+			},
+			Path:            fmt.Sprintf("d-solo/%s/_?orgId=%d&panelId=%d&from=%d&to=%d", export.DashboardUID, export.OrgID, panel.id, from.UnixMilli(), to.UnixMilli()),
+			Timezone:        export.Timezone,
+			ConcurrentLimit: s.cfg.RendererConcurrentRequestLimit,
+		},
+		Width:  renderWidth,
+		Height: renderHeight,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(result.FilePath)
+	if err != nil {
+		return nil, err
+	}
+	return []*notifications.SendEmailAttachFile{{Name: fileName(panel) + ".png", Content: content}}, nil
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^a-zA-Z0-9_\-]+`)
+
+func fileName(panel exportPanel) string {
+	name := strings.Trim(unsafeFileNameChars.ReplaceAllString(panel.title, "-"), "-")
+	if name == "" {
+		name = fmt.Sprintf("panel-%d", panel.id)
+	}
+	return name
+}
+
+// frameToCSV writes a data frame as CSV with a header row of field names.
+func frameToCSV(frame *data.Frame, loc *time.Location) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := make([]string, len(frame.Fields))
+	for i, field := range frame.Fields {
+		header[i] = fieldName(field)
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	record := make([]string, len(frame.Fields))
+	for row := 0; row < frame.Rows(); row++ {
+		for i, field := range frame.Fields {
+			record[i] = ""
+			v, ok := field.ConcreteAt(row)
+			if !ok {
+				continue
+			}
+			switch v := v.(type) {
+			case time.Time:
+				record[i] = v.In(loc).Format(time.RFC3339)
+			case float64:
+				record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+			case float32:
+				record[i] = strconv.FormatFloat(float64(v), 'f', -1, 32)
+			default:
+				record[i] = fmt.Sprint(v)
+			}
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func fieldName(field *data.Field) string {
+	if field.Config != nil && field.Config.DisplayNameFromDS != "" {
+		return field.Config.DisplayNameFromDS
+	}
+	if len(field.Labels) > 0 {
+		return field.Name + " {" + field.Labels.String() + "}"
+	}
+	return field.Name
+}
@@ -0,0 +1,104 @@
+package scheduledexports
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func TestExportPanels(t *testing.T) {
+	dashboard, err := simplejson.NewJson([]byte(`{
+		"panels": [
+			{"id": 1, "title": "CPU", "datasource": {"uid": "prom"}, "targets": [{"refId": "A"}, {"refId": "B", "hide": true}]},
+			{"id": 2, "title": "Text", "type": "text"},
+			{"id": 3, "type": "row", "panels": [
+				{"id": 4, "title": "Memory", "targets": [
+					{"refId": "A", "hide": true, "datasource": {"uid": "prom"}},
+					{"refId": "B", "datasource": {"uid": "__expr__"}}
+				]}
+			]}
+		]
+	}`))
+	require.NoError(t, err)
+
+	t.Run("returns all panels with queries", func(t *testing.T) {
+		panels, err := exportPanels(dashboard, nil)
+		require.NoError(t, err)
+		require.Len(t, panels, 2)
+
+		require.Equal(t, int64(1), panels[0].id)
+		require.Len(t, panels[0].queries, 1, "hidden query should be skipped")
+		require.Equal(t, "prom", dataSourceUID(panels[0].queries[0]), "query should inherit the panel datasource")
+
+		require.Equal(t, int64(4), panels[1].id)
+		require.Len(t, panels[1].queries, 2, "hidden query should be kept for the expression")
+	})
+
+	t.Run("returns selected panels", func(t *testing.T) {
+		panels, err := exportPanels(dashboard, []int64{4})
+		require.NoError(t, err)
+		require.Len(t, panels, 1)
+		require.Equal(t, "Memory", panels[0].title)
+	})
+
+	t.Run("fails for panels without queries", func(t *testing.T) {
+		_, err := exportPanels(dashboard, []int64{1, 2})
+		require.ErrorIs(t, err, ErrPanelNotFound)
+
+		_, err = exportPanels(simplejson.New(), nil)
+		require.ErrorIs(t, err, ErrNoPanelQueries)
+	})
+}
+
+func TestNextRun(t *testing.T) {
+	after := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	export := &ScheduledExport{Schedule: "0 8 * * *", Timezone: "Europe/Berlin", Enabled: true}
+	next, err := nextRun(export, after)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 3, 2, 7, 0, 0, 0, time.UTC).Unix(), next)
+
+	export.Enabled = false
+	next, err = nextRun(export, after)
+	require.NoError(t, err)
+	require.Zero(t, next)
+
+	_, _, err = parseSchedule("every day", "UTC")
+	require.ErrorIs(t, err, ErrInvalidSchedule)
+	_, _, err = parseSchedule("0 8 * * *", "Mars/Olympus")
+	require.ErrorIs(t, err, ErrInvalidTimezone)
+}
+
+func TestFrameToCSV(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	value := data.NewField("value", data.Labels{"host": "a"}, []*float64{ptr(1.5), nil})
+	frame := data.NewFrame("",
+		data.NewField("time", nil, []time.Time{time.Unix(0, 0), time.Unix(60, 0)}),
+		value,
+		data.NewField("status", nil, []string{"ok", "a,b"}),
+	)
+
+	out, err := frameToCSV(frame, loc)
+	require.NoError(t, err)
+	require.Equal(t, "time,value {host=a},status\n"+
+		"1970-01-01T01:00:00+01:00,1.5,ok\n"+
+		"1970-01-01T01:01:00+01:00,,\"a,b\"\n", string(out))
+
+	value.Config = &data.FieldConfig{DisplayNameFromDS: "Requests"}
+	require.Equal(t, "Requests", fieldName(value))
+}
+
+func TestFileName(t *testing.T) {
+	require.Equal(t, "CPU-usage-host", fileName(exportPanel{id: 1, title: "CPU usage (host)"}))
+	require.Equal(t, "panel-7", fileName(exportPanel{id: 7, title: "??"}))
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,124 @@
+package scheduledexports
+
+import (
+	"time"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+)
+
+var (
+	ErrNotFound           = errutil.NotFound("scheduledexports.not-found", errutil.WithPublicMessage("Scheduled export not found"))
+	ErrInvalidSchedule    = errutil.ValidationFailed("scheduledexports.invalid-schedule", errutil.WithPublicMessage("Invalid cron schedule"))
+	ErrInvalidTimezone    = errutil.ValidationFailed("scheduledexports.invalid-timezone", errutil.WithPublicMessage("Invalid timezone"))
+	ErrInvalidFormat      = errutil.ValidationFailed("scheduledexports.invalid-format", errutil.WithPublicMessage("Format must be csv or png"))
+	ErrRendererMissing    = errutil.ValidationFailed("scheduledexports.renderer-missing", errutil.WithPublicMessage("PNG exports require an image renderer"))
+	ErrNoRecipients       = errutil.ValidationFailed("scheduledexports.no-recipients", errutil.WithPublicMessage("At least one recipient is required"))
+	ErrTooManyRecipients  = errutil.ValidationFailed("scheduledexports.too-many-recipients", errutil.WithPublicMessage("Too many recipients"))
+	ErrInvalidRecipient   = errutil.ValidationFailed("scheduledexports.invalid-recipient", errutil.WithPublicMessage("Invalid recipient email address"))
+	ErrMissingName        = errutil.ValidationFailed("scheduledexports.missing-name", errutil.WithPublicMessage("Name is required"))
+	ErrDashboardNotFound  = errutil.ValidationFailed("scheduledexports.dashboard-not-found", errutil.WithPublicMessage("Dashboard not found"))
+	ErrPanelNotFound      = errutil.ValidationFailed("scheduledexports.panel-not-found", errutil.WithPublicMessage("Panel not found in dashboard"))
+	ErrNoPanelQueries     = errutil.ValidationFailed("scheduledexports.no-panel-queries", errutil.WithPublicMessage("Dashboard has no panels with queries"))
+	ErrDashboardForbidden = errutil.Forbidden("scheduledexports.dashboard-forbidden", errutil.WithPublicMessage("Access to the dashboard denied"))
+)
+
+const (
+	ActionCreate = "scheduledexports:create"
+	ActionRead   = "scheduledexports:read"
+	ActionWrite  = "scheduledexports:write"
+	ActionDelete = "scheduledexports:delete"
+
+	ActionPermissionsRead  = "scheduledexports.permissions:read"
+	ActionPermissionsWrite = "scheduledexports.permissions:write"
+)
+
+var (
+	ScopeProvider = accesscontrol.NewScopeProvider("scheduledexports")
+	ScopeAll      = ScopeProvider.GetResourceAllScope()
+	ScopeUID      = accesscontrol.Scope("scheduledexports", "uid", accesscontrol.Parameter(":uid"))
+)
+
+// Format is the type of the files attached to the email of a scheduled export.
+type Format string
+
+const (
+	FormatCSV Format = "csv"
+	FormatPNG Format = "png"
+)
+
+func (f Format) IsValid() bool {
+	return f == FormatCSV || f == FormatPNG
+}
+
+// RunState is the outcome of a scheduled export run.
+type RunState string
+
+const (
+	RunStateRunning RunState = "running"
+	RunStateSuccess RunState = "success"
+	RunStateFailed  RunState = "failed"
+)
+
+// ScheduledExport sends panel data of a dashboard by email on a cron schedule.
+type ScheduledExport struct {
+	ID           int64    `json:"-" xorm:"pk autoincr 'id'"`
+	OrgID        int64    `json:"-" xorm:"org_id"`
+	UID          string   `json:"uid" xorm:"uid"`
+	Name         string   `json:"name"`
+	DashboardUID string   `json:"dashboardUid" xorm:"dashboard_uid"`
+	PanelIDs     []int64  `json:"panelIds" xorm:"panel_ids"`
+	Schedule     string   `json:"schedule"`
+	Timezone     string   `json:"timezone"`
+	TimeFrom     string   `json:"timeFrom"`
+	TimeTo       string   `json:"timeTo"`
+	Format       Format   `json:"format"`
+	Recipients   []string `json:"recipients"`
+	Enabled      bool     `json:"enabled"`
+	// UserID is the user who last created or updated the export. Dashboard
+	// queries run with the permissions of this user.
+	UserID int64 `json:"userId" xorm:"user_id"`
+	// NextRun is the Unix time of the next scheduled run, or 0 when the export is disabled.
+	NextRun int64     `json:"nextRun" xorm:"next_run"`
+	Created time.Time `json:"created"`
+	Updated time.Time `json:"updated"`
+}
+
+func (ScheduledExport) TableName() string {
+	return "scheduled_export"
+}
+
+// Run is an entry of the run history of a scheduled export.
+type Run struct {
+	ID        int64      `json:"id" xorm:"pk autoincr 'id'"`
+	OrgID     int64      `json:"-" xorm:"org_id"`
+	ExportUID string     `json:"exportUid" xorm:"export_uid"`
+	State     RunState   `json:"state"`
+	Error     string     `json:"error,omitempty"`
+	Manual    bool       `json:"manual"`
+	Files     int        `json:"files"`
+	Started   time.Time  `json:"started"`
+	Finished  *time.Time `json:"finished,omitempty"`
+}
+
+func (Run) TableName() string {
+	return "scheduled_export_run"
+}
+
+// CreateScheduledExportCommand is the payload to create a scheduled export.
+type CreateScheduledExportCommand struct {
+	Name         string `json:"name"`
+	DashboardUID string `json:"dashboardUid"`
+	// PanelIDs limits the export to these panels. All panels with queries are exported when empty.
+	PanelIDs   []int64  `json:"panelIds"`
+	Schedule   string   `json:"schedule"`
+	Timezone   string   `json:"timezone"`
+	TimeFrom   string   `json:"timeFrom"`
+	TimeTo     string   `json:"timeTo"`
+	Format     Format   `json:"format"`
+	Recipients []string `json:"recipients"`
+	Enabled    *bool    `json:"enabled"`
+}
+
+// UpdateScheduledExportCommand is the payload to update a scheduled export. It replaces all settings.
+type UpdateScheduledExportCommand = CreateScheduledExportCommand
@@ -0,0 +1,52 @@
+package scheduledexports
+
+import (
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/org"
+)
+
+func registerRoles(service accesscontrol.Service) error {
+	reader := accesscontrol.RoleRegistration{
+		Role: accesscontrol.RoleDTO{
+			Name:        "fixed:scheduledexports:reader",
+			DisplayName: "Reader",
+			Description: "Read all scheduled exports and their run history.",
+			Group:       "Scheduled exports",
+			Permissions: []accesscontrol.Permission{
+				{Action: ActionRead, Scope: ScopeAll},
+			},
+		},
+		Grants: []string{string(org.RoleAdmin)},
+	}
+
+	creator := accesscontrol.RoleRegistration{
+		Role: accesscontrol.RoleDTO{
+			Name:        "fixed:scheduledexports:creator",
+			DisplayName: "Creator",
+			Description: "Create scheduled exports. Creators get admin permission on the exports they create.",
+			Group:       "Scheduled exports",
+			Permissions: []accesscontrol.Permission{
+				{Action: ActionCreate},
+			},
+		},
+		Grants: []string{string(org.RoleEditor)},
+	}
+
+	writer := accesscontrol.RoleRegistration{
+		Role: accesscontrol.RoleDTO{
+			Name:        "fixed:scheduledexports:writer",
+			DisplayName: "Writer",
+			Description: "Create, update, run and delete all scheduled exports and manage their permissions.",
+			Group:       "Scheduled exports",
+			Permissions: accesscontrol.ConcatPermissions(reader.Role.Permissions, creator.Role.Permissions, []accesscontrol.Permission{
+				{Action: ActionWrite, Scope: ScopeAll},
+				{Action: ActionDelete, Scope: ScopeAll},
+				{Action: ActionPermissionsRead, Scope: ScopeAll},
+				{Action: ActionPermissionsWrite, Scope: ScopeAll},
+			}),
+		},
+		Grants: []string{string(org.RoleAdmin)},
+	}
+
+	return service.DeclareFixedRoles(reader, creator, writer)
+}
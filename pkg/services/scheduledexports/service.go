@@ -0,0 +1,310 @@
+package scheduledexports
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/notifications"
+	"github.com/grafana/grafana/pkg/services/query"
+	"github.com/grafana/grafana/pkg/services/rendering"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// tickInterval is how often the service looks for due scheduled exports.
+var tickInterval = 30 * time.Second
+
+// Service runs panel queries of dashboards on a cron schedule and sends the
+// results by email. Schedules are stored in the database, and every run is
+// claimed by a single Grafana instance.
+type Service struct {
+	cfg                  *setting.Cfg
+	store                *store
+	log                  log.Logger
+	routeRegister        routing.RouteRegister
+	accessControl        accesscontrol.AccessControl
+	accessControlService accesscontrol.Service
+	permissions          accesscontrol.ScheduledExportPermissionsService
+	dashboardService     dashboards.DashboardService
+	dataSourceService    datasources.DataSourceService
+	queryService         query.Service
+	renderService        rendering.Service
+	emailSender          notifications.EmailSender
+	userService          user.Service
+	now                  func() time.Time
+
+	enabled       bool
+	maxRecipients int
+}
+
+func ProvideService(
+	cfg *setting.Cfg, sqlStore db.DB, routeRegister routing.RouteRegister,
+	accessControl accesscontrol.AccessControl, accessControlService accesscontrol.Service,
+	permissions accesscontrol.ScheduledExportPermissionsService, dashboardService dashboards.DashboardService,
+	dataSourceService datasources.DataSourceService, queryService query.Service, renderService rendering.Service,
+	emailSender notifications.EmailSender, userService user.Service,
+) (*Service, error) {
+	section := cfg.SectionWithEnvOverrides("scheduled_exports")
+	s := &Service{
+		cfg:                  cfg,
+		store:                &store{db: sqlStore},
+		log:                  log.New("scheduledexports"),
+		routeRegister:        routeRegister,
+		accessControl:        accessControl,
+		accessControlService: accessControlService,
+		permissions:          permissions,
+		dashboardService:     dashboardService,
+		dataSourceService:    dataSourceService,
+		queryService:         queryService,
+		renderService:        renderService,
+		emailSender:          emailSender,
+		userService:          userService,
+		now:                  time.Now,
+		enabled:              section.Key("enabled").MustBool(false),
+		maxRecipients:        section.Key("max_recipients").MustInt(20),
+	}
+
+	if !s.enabled {
+		return s, nil
+	}
+
+	if err := registerRoles(accessControlService); err != nil {
+		return nil, err
+	}
+	s.registerAPIEndpoints()
+	return s, nil
+}
+
+func (s *Service) IsDisabled() bool {
+	return !s.enabled
+}
+
+// Run starts due scheduled exports until the context is cancelled.
+func (s *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.runDue(ctx)
+		}
+	}
+}
+
+func (s *Service) runDue(ctx context.Context) {
+	now := s.now()
+	exports, err := s.store.due(ctx, now)
+	if err != nil {
+		s.log.Error("Failed to get due scheduled exports", "error", err)
+		return
+	}
+	for _, export := range exports {
+		next, err := nextRun(export, now)
+		if err != nil {
+			// Schedules are validated on save, so this only happens when the
+			// timezone database of the host changed.
+			s.log.Error("Failed to compute next run of scheduled export", "uid", export.UID, "error", err)
+		}
+		claimed, err := s.store.claim(ctx, export, next)
+		if err != nil {
+			s.log.Error("Failed to claim scheduled export run", "uid", export.UID, "error", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		s.execute(ctx, export, false)
+	}
+}
+
+// nextRun returns the Unix time of the first scheduled run of the export after the given time,
+// or 0 when the export is disabled.
+func nextRun(export *ScheduledExport, after time.Time) (int64, error) {
+	if !export.Enabled {
+		return 0, nil
+	}
+	schedule, loc, err := parseSchedule(export.Schedule, export.Timezone)
+	if err != nil {
+		return 0, err
+	}
+	return schedule.Next(after.In(loc)).Unix(), nil
+}
+
+func parseSchedule(spec, timezone string) (cron.Schedule, *time.Location, error) {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, nil, ErrInvalidTimezone.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, nil, ErrInvalidSchedule.Errorf("invalid schedule %q: %w", spec, err)
+	}
+	return schedule, loc, nil
+}
+
+func (s *Service) List(ctx context.Context, usr identity.Requester) ([]*ScheduledExport, error) {
+	return s.store.list(ctx, usr)
+}
+
+func (s *Service) Get(ctx context.Context, orgID int64, uid string) (*ScheduledExport, error) {
+	return s.store.get(ctx, orgID, uid)
+}
+
+func (s *Service) Runs(ctx context.Context, orgID int64, uid string) ([]*Run, error) {
+	if _, err := s.store.get(ctx, orgID, uid); err != nil {
+		return nil, err
+	}
+	return s.store.runs(ctx, orgID, uid)
+}
+
+// Create stores a new scheduled export and gives its creator admin permission on it.
+func (s *Service) Create(ctx context.Context, usr identity.Requester, cmd CreateScheduledExportCommand) (*ScheduledExport, error) {
+	userID, err := usr.GetInternalID()
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	export := &ScheduledExport{
+		OrgID:   usr.GetOrgID(),
+		UID:     util.GenerateShortUID(),
+		Created: now,
+	}
+	if err := s.apply(ctx, usr, export, cmd); err != nil {
+		return nil, err
+	}
+	export.UserID = userID
+	export.Updated = now
+	if err := s.store.insert(ctx, export); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.permissions.SetUserPermission(ctx, export.OrgID, accesscontrol.User{ID: userID}, export.UID, "Admin"); err != nil {
+		return nil, err
+	}
+	// Clear the permission cache of the creator, so the new permission is used on their next request.
+	s.accessControlService.ClearUserPermissionCache(usr)
+	return export, nil
+}
+
+// Update replaces the settings of a scheduled export. The export runs with the
+// permissions of the user who updated it from then on.
+func (s *Service) Update(ctx context.Context, usr identity.Requester, uid string, cmd UpdateScheduledExportCommand) (*ScheduledExport, error) {
+	userID, err := usr.GetInternalID()
+	if err != nil {
+		return nil, err
+	}
+	export, err := s.store.get(ctx, usr.GetOrgID(), uid)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, usr, export, cmd); err != nil {
+		return nil, err
+	}
+	export.UserID = userID
+	export.Updated = s.now()
+	if err := s.store.update(ctx, export); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+func (s *Service) Delete(ctx context.Context, orgID int64, uid string) error {
+	if err := s.store.delete(ctx, orgID, uid); err != nil {
+		return err
+	}
+	return s.permissions.DeleteResourcePermissions(ctx, orgID, uid)
+}
+
+// RunNow runs a scheduled export immediately, regardless of its schedule.
+func (s *Service) RunNow(ctx context.Context, orgID int64, uid string) (*Run, error) {
+	export, err := s.store.get(ctx, orgID, uid)
+	if err != nil {
+		return nil, err
+	}
+	return s.execute(ctx, export, true), nil
+}
+
+// apply validates the command and sets its values on the export.
+func (s *Service) apply(ctx context.Context, usr identity.Requester, export *ScheduledExport, cmd CreateScheduledExportCommand) error {
+	if cmd.Name == "" {
+		return ErrMissingName.Errorf("missing name")
+	}
+	if cmd.Format == "" {
+		cmd.Format = FormatCSV
+	}
+	if !cmd.Format.IsValid() {
+		return ErrInvalidFormat.Errorf("invalid format %q", cmd.Format)
+	}
+	if cmd.Format == FormatPNG && !s.renderService.IsAvailable(ctx) {
+		return ErrRendererMissing.Errorf("no image renderer available")
+	}
+	if len(cmd.Recipients) == 0 {
+		return ErrNoRecipients.Errorf("no recipients")
+	}
+	if len(cmd.Recipients) > s.maxRecipients {
+		return ErrTooManyRecipients.Errorf("%d recipients exceed the limit of %d", len(cmd.Recipients), s.maxRecipients)
+	}
+	for _, recipient := range cmd.Recipients {
+		if _, err := mail.ParseAddress(recipient); err != nil {
+			return ErrInvalidRecipient.Errorf("invalid recipient %q: %w", recipient, err)
+		}
+	}
+	if cmd.Timezone == "" {
+		cmd.Timezone = "UTC"
+	}
+	if _, _, err := parseSchedule(cmd.Schedule, cmd.Timezone); err != nil {
+		return err
+	}
+
+	dashboard, err := s.dashboardService.GetDashboard(ctx, &dashboards.GetDashboardQuery{UID: cmd.DashboardUID, OrgID: usr.GetOrgID()})
+	if err != nil {
+		if errors.Is(err, dashboards.ErrDashboardNotFound) {
+			return ErrDashboardNotFound.Errorf("dashboard %s not found", cmd.DashboardUID)
+		}
+		return err
+	}
+	if err := s.checkDashboardAccess(ctx, usr, dashboard.UID); err != nil {
+		return err
+	}
+	if _, err := exportPanels(dashboard.Data, cmd.PanelIDs); err != nil {
+		return err
+	}
+
+	export.Name = cmd.Name
+	export.DashboardUID = cmd.DashboardUID
+	export.PanelIDs = cmd.PanelIDs
+	export.Schedule = cmd.Schedule
+	export.Timezone = cmd.Timezone
+	export.TimeFrom = cmd.TimeFrom
+	export.TimeTo = cmd.TimeTo
+	export.Format = cmd.Format
+	export.Recipients = cmd.Recipients
+	export.Enabled = cmd.Enabled == nil || *cmd.Enabled
+	export.NextRun, err = nextRun(export, s.now())
+	return err
+}
+
+func (s *Service) checkDashboardAccess(ctx context.Context, usr identity.Requester, dashboardUID string) error {
+	ok, err := s.accessControl.Evaluate(ctx, usr, accesscontrol.EvalPermission(dashboards.ActionDashboardsRead, dashboards.ScopeDashboardsProvider.GetResourceScopeUID(dashboardUID)))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrDashboardForbidden.Errorf("user cannot read dashboard %s", dashboardUID)
+	}
+	return nil
+}
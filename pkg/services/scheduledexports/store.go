@@ -0,0 +1,150 @@
+package scheduledexports
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+)
+
+// runHistoryLimit is the number of runs kept per scheduled export.
+const runHistoryLimit = 100
+
+type store struct {
+	db db.DB
+}
+
+func (s *store) insert(ctx context.Context, export *ScheduledExport) error {
+	return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Insert(export)
+		return err
+	})
+}
+
+func (s *store) update(ctx context.Context, export *ScheduledExport) error {
+	return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		affected, err := sess.ID(export.ID).AllCols().Update(export)
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrNotFound.Errorf("scheduled export %s not found", export.UID)
+		}
+		return nil
+	})
+}
+
+func (s *store) delete(ctx context.Context, orgID int64, uid string) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context) error {
+		return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+			affected, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Delete(&ScheduledExport{})
+			if err != nil {
+				return err
+			}
+			if affected == 0 {
+				return ErrNotFound.Errorf("scheduled export %s not found", uid)
+			}
+			_, err = sess.Where("org_id = ? AND export_uid = ?", orgID, uid).Delete(&Run{})
+			return err
+		})
+	})
+}
+
+func (s *store) get(ctx context.Context, orgID int64, uid string) (*ScheduledExport, error) {
+	export := &ScheduledExport{}
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		ok, err := sess.Where("org_id = ? AND uid = ?", orgID, uid).Get(export)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrNotFound.Errorf("scheduled export %s not found", uid)
+		}
+		return nil
+	})
+	return export, err
+}
+
+// list returns the scheduled exports of the organization of the user which the user can read.
+func (s *store) list(ctx context.Context, user identity.Requester) ([]*ScheduledExport, error) {
+	filter, err := accesscontrol.Filter(user, "scheduled_export.uid", ScopeProvider.GetResourceScopeUID(""), ActionRead)
+	if err != nil {
+		return nil, err
+	}
+	exports := make([]*ScheduledExport, 0)
+	err = s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Where("org_id = ?", user.GetOrgID()).
+			And(filter.Where, filter.Args...).
+			Asc("name").
+			Find(&exports)
+	})
+	return exports, err
+}
+
+// due returns the enabled scheduled exports of all organizations with a next run before now.
+func (s *store) due(ctx context.Context, now time.Time) ([]*ScheduledExport, error) {
+	exports := make([]*ScheduledExport, 0)
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Where("enabled = ? AND next_run > 0 AND next_run <= ?", true, now.Unix()).Find(&exports)
+	})
+	return exports, err
+}
+
+// claim moves the next run of a due scheduled export forward. It returns false when
+// another Grafana instance claimed the run first.
+func (s *store) claim(ctx context.Context, export *ScheduledExport, next int64) (bool, error) {
+	var claimed bool
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		res, err := sess.Exec("UPDATE scheduled_export SET next_run = ? WHERE id = ? AND next_run = ?", next, export.ID, export.NextRun)
+		if err != nil {
+			return err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		claimed = affected == 1
+		return nil
+	})
+	return claimed, err
+}
+
+func (s *store) insertRun(ctx context.Context, run *Run) error {
+	return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Insert(run)
+		return err
+	})
+}
+
+// finishRun stores the outcome of a run and removes runs beyond the history limit.
+func (s *store) finishRun(ctx context.Context, run *Run) error {
+	return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		if _, err := sess.ID(run.ID).Cols("state", "error", "files", "finished").Update(run); err != nil {
+			return err
+		}
+		var ids []int64
+		err := sess.Table(&Run{}).Cols("id").
+			Where("org_id = ? AND export_uid = ?", run.OrgID, run.ExportUID).
+			Desc("started").Desc("id").
+			Limit(runHistoryLimit, runHistoryLimit).
+			Find(&ids)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		_, err = sess.In("id", ids).Delete(&Run{})
+		return err
+	})
+}
+
+func (s *store) runs(ctx context.Context, orgID int64, uid string) ([]*Run, error) {
+	runs := make([]*Run, 0)
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Where("org_id = ? AND export_uid = ?", orgID, uid).
+			Desc("started").Desc("id").
+			Limit(runHistoryLimit).
+			Find(&runs)
+	})
+	return runs, err
+}
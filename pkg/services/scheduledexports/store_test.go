@@ -0,0 +1,102 @@
+package scheduledexports
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/tests/testsuite"
+)
+
+func TestMain(m *testing.M) {
+	testsuite.Run(m)
+}
+
+func TestIntegrationStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	s := &store{db: db.InitTestDB(t)}
+	now := time.Unix(1700000000, 0)
+
+	export := &ScheduledExport{
+		OrgID:        1,
+		UID:          "export",
+		Name:         "Daily",
+		DashboardUID: "dash",
+		PanelIDs:     []int64{1, 2},
+		Schedule:     "0 8 * * *",
+		Timezone:     "UTC",
+		Format:       FormatCSV,
+		Recipients:   []string{"ops@example.com"},
+		Enabled:      true,
+		UserID:       1,
+		NextRun:      now.Add(-time.Minute).Unix(),
+		Created:      now,
+		Updated:      now,
+	}
+	require.NoError(t, s.insert(ctx, export))
+
+	t.Run("get returns the stored export", func(t *testing.T) {
+		got, err := s.get(ctx, 1, "export")
+		require.NoError(t, err)
+		require.Equal(t, []int64{1, 2}, got.PanelIDs)
+		require.Equal(t, []string{"ops@example.com"}, got.Recipients)
+
+		_, err = s.get(ctx, 2, "export")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("due exports are claimed only once", func(t *testing.T) {
+		due, err := s.due(ctx, now)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+
+		next := now.Add(time.Hour).Unix()
+		claimed, err := s.claim(ctx, due[0], next)
+		require.NoError(t, err)
+		require.True(t, claimed)
+
+		claimed, err = s.claim(ctx, due[0], next)
+		require.NoError(t, err)
+		require.False(t, claimed)
+
+		due, err = s.due(ctx, now)
+		require.NoError(t, err)
+		require.Empty(t, due)
+	})
+
+	t.Run("run history is limited", func(t *testing.T) {
+		for i := 0; i < runHistoryLimit+5; i++ {
+			started := now.Add(time.Duration(i) * time.Second)
+			run := &Run{OrgID: 1, ExportUID: "export", State: RunStateRunning, Started: started}
+			require.NoError(t, s.insertRun(ctx, run))
+			run.State = RunStateSuccess
+			run.Finished = &started
+			require.NoError(t, s.finishRun(ctx, run))
+		}
+
+		runs, err := s.runs(ctx, 1, "export")
+		require.NoError(t, err)
+		require.Len(t, runs, runHistoryLimit)
+		require.Equal(t, RunStateSuccess, runs[0].State)
+		require.Equal(t, now.Add(time.Duration(runHistoryLimit+4)*time.Second).Unix(), runs[0].Started.Unix())
+	})
+
+	t.Run("delete removes the export and its runs", func(t *testing.T) {
+		require.NoError(t, s.delete(ctx, 1, "export"))
+
+		_, err := s.get(ctx, 1, "export")
+		require.ErrorIs(t, err, ErrNotFound)
+		runs, err := s.runs(ctx, 1, "export")
+		require.NoError(t, err)
+		require.Empty(t, runs)
+
+		require.ErrorIs(t, s.delete(ctx, 1, "export"), ErrNotFound)
+	})
+}
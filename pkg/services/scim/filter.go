@@ -0,0 +1,503 @@
+package scim
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Filter is a parsed SCIM filter, see RFC 7644 section 3.4.2.2.
+type Filter interface {
+	// Match reports whether the JSON representation of a resource matches
+	// the filter.
+	Match(resource map[string]any) bool
+}
+
+// caseExactAttributes are compared case sensitively, all other string
+// attributes are compared case insensitively.
+var caseExactAttributes = map[string]bool{
+	"id":         true,
+	"externalid": true,
+}
+
+// ParseFilter parses a filter expression. It returns a nil filter for an
+// empty expression.
+func ParseFilter(expression string) (Filter, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, nil
+	}
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, ErrInvalidFilter.Errorf("unexpected %q in filter", p.peek().text)
+	}
+	return filter, nil
+}
+
+// EqualValue returns the value the filter compares the attribute to, when the
+// filter is a single eq comparison of that attribute with a string. It lets
+// the lookups identity providers make before provisioning a resource, such as
+// userName eq "jane", use an index instead of filtering every resource.
+func EqualValue(filter Filter, attribute string) (string, bool) {
+	expr, ok := filter.(*attributeExpression)
+	if !ok || expr.op != "eq" || expr.path.subAttribute != "" || !strings.EqualFold(expr.path.attribute, attribute) {
+		return "", false
+	}
+	value, ok := expr.value.(string)
+	return value, ok
+}
+
+// attributePath is an attribute name with an optional sub-attribute, such as
+// name.givenName.
+type attributePath struct {
+	attribute    string
+	subAttribute string
+}
+
+func parseAttributePath(path string) (attributePath, error) {
+	// attributes can be prefixed with the URN of their schema
+	if strings.HasPrefix(strings.ToLower(path), "urn:") {
+		path = path[strings.LastIndex(path, ":")+1:]
+	}
+	attribute, subAttribute, _ := strings.Cut(path, ".")
+	if attribute == "" || strings.Contains(subAttribute, ".") {
+		return attributePath{}, ErrInvalidPath.Errorf("invalid attribute path %q", path)
+	}
+	return attributePath{attribute: attribute, subAttribute: subAttribute}, nil
+}
+
+// values returns the values of the attribute in the resource. The values of
+// a multi-valued complex attribute without sub-attribute are the value
+// sub-attributes of its elements.
+func (p attributePath) values(resource map[string]any) []any {
+	value, ok := lookup(resource, p.attribute)
+	if !ok {
+		return nil
+	}
+
+	subAttribute := p.subAttribute
+	elements, isMultiValued := value.([]any)
+	if !isMultiValued {
+		elements = []any{value}
+	} else if subAttribute == "" {
+		subAttribute = "value"
+	}
+
+	values := make([]any, 0, len(elements))
+	for _, element := range elements {
+		complexValue, isComplex := element.(map[string]any)
+		switch {
+		case subAttribute == "":
+			values = append(values, element)
+		case isComplex:
+			if v, ok := lookup(complexValue, subAttribute); ok {
+				values = append(values, v)
+			}
+		case isMultiValued:
+			// multi-valued attribute of simple values
+			values = append(values, element)
+		}
+	}
+	return values
+}
+
+type logicalExpression struct {
+	and         bool
+	left, right Filter
+}
+
+func (e *logicalExpression) Match(resource map[string]any) bool {
+	if e.and {
+		return e.left.Match(resource) && e.right.Match(resource)
+	}
+	return e.left.Match(resource) || e.right.Match(resource)
+}
+
+type notExpression struct {
+	filter Filter
+}
+
+func (e *notExpression) Match(resource map[string]any) bool {
+	return !e.filter.Match(resource)
+}
+
+// valuePathExpression matches resources that have an element of a
+// multi-valued attribute matching the filter, such as emails[type eq "work"].
+type valuePathExpression struct {
+	attribute string
+	filter    Filter
+}
+
+func (e *valuePathExpression) Match(resource map[string]any) bool {
+	value, _ := lookup(resource, e.attribute)
+	elements, _ := value.([]any)
+	for _, element := range elements {
+		if complexValue, ok := element.(map[string]any); ok && e.filter.Match(complexValue) {
+			return true
+		}
+	}
+	return false
+}
+
+type attributeExpression struct {
+	path  attributePath
+	op    string
+	value any
+}
+
+func (e *attributeExpression) Match(resource map[string]any) bool {
+	values := e.path.values(resource)
+	if e.op == "ne" {
+		// ne matches when no value is equal
+		for _, v := range values {
+			if compare("eq", v, e.value, e.caseExact()) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, v := range values {
+		if compare(e.op, v, e.value, e.caseExact()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *attributeExpression) caseExact() bool {
+	return e.path.subAttribute == "" && caseExactAttributes[strings.ToLower(e.path.attribute)]
+}
+
+func compare(op string, actual, expected any, caseExact bool) bool {
+	if op == "pr" {
+		switch v := actual.(type) {
+		case nil:
+			return false
+		case string:
+			return v != ""
+		case []any:
+			return len(v) > 0
+		case map[string]any:
+			return len(v) > 0
+		}
+		return true
+	}
+
+	switch want := expected.(type) {
+	case nil:
+		return op == "eq" && actual == nil
+	case bool:
+		got, ok := actual.(bool)
+		return ok && op == "eq" && got == want
+	case float64:
+		got, ok := actual.(float64)
+		if !ok {
+			return false
+		}
+		return compareOrdered(op, got, want)
+	case string:
+		got, ok := actual.(string)
+		if !ok {
+			return false
+		}
+		if !caseExact {
+			got, want = strings.ToLower(got), strings.ToLower(want)
+		}
+		switch op {
+		case "co":
+			return strings.Contains(got, want)
+		case "sw":
+			return strings.HasPrefix(got, want)
+		case "ew":
+			return strings.HasSuffix(got, want)
+		}
+		// dates are compared as strings, which orders RFC 3339 timestamps
+		return compareOrdered(op, got, want)
+	}
+	return false
+}
+
+func compareOrdered[T float64 | string](op string, got, want T) bool {
+	switch op {
+	case "eq":
+		return got == want
+	case "gt":
+		return got > want
+	case "ge":
+		return got >= want
+	case "lt":
+		return got < want
+	case "le":
+		return got <= want
+	}
+	return false
+}
+
+// lookup returns the value of an attribute, attribute names are case
+// insensitive.
+func lookup(m map[string]any, name string) (any, bool) {
+	key, ok := lookupKey(m, name)
+	if !ok {
+		return nil, false
+	}
+	return m[key], true
+}
+
+func lookupKey(m map[string]any, name string) (string, bool) {
+	if _, ok := m[name]; ok {
+		return name, true
+	}
+	for key := range m {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+var compareOperators = map[string]bool{
+	"eq": true, "ne": true, "co": true, "sw": true, "ew": true, "gt": true, "ge": true, "lt": true, "le": true,
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenOpenParen
+	tokenCloseParen
+	tokenOpenBracket
+	tokenCloseBracket
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(expression string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenOpenParen, text: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenCloseParen, text: ")"})
+			i++
+		case r == '[':
+			tokens = append(tokens, token{kind: tokenOpenBracket, text: "["})
+			i++
+		case r == ']':
+			tokens = append(tokens, token{kind: tokenCloseBracket, text: "]"})
+			i++
+		case r == '"':
+			// strings are JSON strings, find the closing quote and let
+			// strconv handle the escape sequences
+			end := i + 1
+			for ; end < len(runes) && runes[end] != '"'; end++ {
+				if runes[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(runes) {
+				return nil, ErrInvalidFilter.Errorf("unterminated string in filter")
+			}
+			value, err := strconv.Unquote(string(runes[i : end+1]))
+			if err != nil {
+				return nil, ErrInvalidFilter.Errorf("invalid string in filter: %w", err)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: value})
+			i = end + 1
+		default:
+			end := i
+			for ; end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune("()[]\"", runes[end]); end++ {
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: string(runes[i:end])})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *filterParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *filterParser) peek() token {
+	if p.done() {
+		return token{kind: tokenWord}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() (token, error) {
+	if p.done() {
+		return token{}, ErrInvalidFilter.Errorf("unexpected end of filter")
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	return t, nil
+}
+
+func (p *filterParser) peekKeyword(keyword string) bool {
+	t := p.peek()
+	return !p.done() && t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+func (p *filterParser) expect(kind tokenKind, text string) error {
+	t, err := p.next()
+	if err != nil {
+		return err
+	}
+	if t.kind != kind {
+		return ErrInvalidFilter.Errorf("expected %q but got %q in filter", text, t.text)
+	}
+	return nil
+}
+
+// parseOr parses expressions joined by or, which has the lowest precedence.
+func (p *filterParser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpression{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (Filter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("and") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpression{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (Filter, error) {
+	if p.peekKeyword("not") {
+		p.pos++
+		if p.peek().kind != tokenOpenParen {
+			return nil, ErrInvalidFilter.Errorf("expected \"(\" after not in filter")
+		}
+		filter, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpression{filter: filter}, nil
+	}
+
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	switch t.kind {
+	case tokenOpenParen:
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return filter, p.expect(tokenCloseParen, ")")
+	case tokenWord:
+		return p.parseAttributeExpression(t.text)
+	}
+	return nil, ErrInvalidFilter.Errorf("unexpected %q in filter", t.text)
+}
+
+func (p *filterParser) parseAttributeExpression(attribute string) (Filter, error) {
+	if p.peek().kind == tokenOpenBracket && !p.done() {
+		p.pos++
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenCloseBracket, "]"); err != nil {
+			return nil, err
+		}
+		path, err := parseAttributePath(attribute)
+		if err != nil {
+			return nil, ErrInvalidFilter.Errorf("invalid attribute %q in filter", attribute)
+		}
+		return &valuePathExpression{attribute: path.attribute, filter: filter}, nil
+	}
+
+	path, err := parseAttributePath(attribute)
+	if err != nil {
+		return nil, ErrInvalidFilter.Errorf("invalid attribute %q in filter", attribute)
+	}
+
+	opToken, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	op := strings.ToLower(opToken.text)
+	if opToken.kind != tokenWord || (op != "pr" && !compareOperators[op]) {
+		return nil, ErrInvalidFilter.Errorf("invalid operator %q in filter", opToken.text)
+	}
+	if op == "pr" {
+		return &attributeExpression{path: path, op: op}, nil
+	}
+
+	valueToken, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	value, err := parseValue(valueToken)
+	if err != nil {
+		return nil, err
+	}
+	return &attributeExpression{path: path, op: op, value: value}, nil
+}
+
+func parseValue(t token) (any, error) {
+	if t.kind == tokenString {
+		return t.text, nil
+	}
+	if t.kind != tokenWord {
+		return nil, ErrInvalidFilter.Errorf("invalid value %q in filter", t.text)
+	}
+	switch strings.ToLower(t.text) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	number, err := strconv.ParseFloat(t.text, 64)
+	if err != nil {
+		return nil, ErrInvalidFilter.Errorf("invalid value %q in filter", t.text)
+	}
+	return number, nil
+}
@@ -0,0 +1,106 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	user := map[string]any{
+		"schemas":     []any{SchemaUser},
+		"id":          "abc-123",
+		"externalId":  "Ext-1",
+		"userName":    "Jane.Doe",
+		"displayName": "Jane Doe",
+		"name":        map[string]any{"givenName": "Jane", "familyName": "Doe"},
+		"emails": []any{
+			map[string]any{"value": "jane@example.com", "type": "work", "primary": true},
+			map[string]any{"value": "jane@home.example.com", "type": "home"},
+		},
+		"active": true,
+		"meta":   map[string]any{"created": "2024-01-02T15:04:05Z"},
+	}
+
+	tests := []struct {
+		name   string
+		filter string
+		match  bool
+	}{
+		{name: "eq is case insensitive", filter: `userName eq "jane.doe"`, match: true},
+		{name: "attribute names are case insensitive", filter: `USERNAME eq "Jane.Doe"`, match: true},
+		{name: "id is case exact", filter: `externalId eq "ext-1"`, match: false},
+		{name: "ne", filter: `userName ne "john"`, match: true},
+		{name: "co", filter: `displayName co "ane d"`, match: true},
+		{name: "sw", filter: `userName sw "jane"`, match: true},
+		{name: "ew", filter: `userName ew "smith"`, match: false},
+		{name: "pr", filter: `externalId pr`, match: true},
+		{name: "pr on missing attribute", filter: `nickName pr`, match: false},
+		{name: "boolean", filter: `active eq true`, match: true},
+		{name: "sub-attribute", filter: `name.familyName eq "Doe"`, match: true},
+		{name: "multi-valued attribute", filter: `emails eq "jane@home.example.com"`, match: true},
+		{name: "multi-valued sub-attribute", filter: `emails.type eq "home"`, match: true},
+		{name: "schema URN prefix", filter: `urn:ietf:params:scim:schemas:core:2.0:User:userName eq "jane.doe"`, match: true},
+		{name: "date comparison", filter: `meta.created gt "2024-01-01T00:00:00Z"`, match: true},
+		{name: "and", filter: `userName eq "jane.doe" and active eq false`, match: false},
+		{name: "or", filter: `userName eq "john" or active eq true`, match: true},
+		{name: "and binds tighter than or", filter: `userName eq "john" and active eq true or displayName sw "Jane"`, match: true},
+		{name: "parentheses", filter: `userName eq "john" and (active eq true or displayName sw "Jane")`, match: false},
+		{name: "not", filter: `not (userName eq "john")`, match: true},
+		{name: "value path", filter: `emails[type eq "work" and value co "@example.com"]`, match: true},
+		{name: "value path without match", filter: `emails[type eq "work" and value co "home"]`, match: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := ParseFilter(tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.match, filter.Match(user))
+		})
+	}
+}
+
+func TestParseFilter_Invalid(t *testing.T) {
+	for _, filter := range []string{
+		`userName`,
+		`userName eq`,
+		`userName foo "jane"`,
+		`userName eq "jane`,
+		`userName eq jane`,
+		`(userName eq "jane"`,
+		`emails[type eq "work"`,
+		`userName eq "jane" and`,
+		`not userName eq "jane"`,
+		`a.b.c eq "jane"`,
+	} {
+		t.Run(filter, func(t *testing.T) {
+			_, err := ParseFilter(filter)
+			require.ErrorIs(t, err, ErrInvalidFilter)
+			assert.Equal(t, "invalidFilter", ErrorType(err))
+		})
+	}
+}
+
+func TestParseFilter_Empty(t *testing.T) {
+	filter, err := ParseFilter(" ")
+	require.NoError(t, err)
+	assert.Nil(t, filter)
+}
+
+func TestEqualValue(t *testing.T) {
+	filter, err := ParseFilter(`userName eq "jane"`)
+	require.NoError(t, err)
+
+	value, ok := EqualValue(filter, "username")
+	assert.True(t, ok)
+	assert.Equal(t, "jane", value)
+
+	_, ok = EqualValue(filter, "externalId")
+	assert.False(t, ok)
+
+	filter, err = ParseFilter(`userName eq "jane" or userName eq "john"`)
+	require.NoError(t, err)
+	_, ok = EqualValue(filter, "userName")
+	assert.False(t, ok)
+}
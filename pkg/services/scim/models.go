@@ -0,0 +1,189 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+)
+
+const (
+	SchemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	// ContentType is the media type of SCIM requests and responses.
+	ContentType = "application/scim+json"
+)
+
+var (
+	ErrInvalidFilter = errutil.BadRequest("scim.invalid-filter", errutil.WithPublicMessage("The filter is not valid or not supported"))
+	ErrInvalidPath   = errutil.BadRequest("scim.invalid-path", errutil.WithPublicMessage("The path attribute is not valid or not supported"))
+	ErrInvalidValue  = errutil.BadRequest("scim.invalid-value", errutil.WithPublicMessage("A required value is missing or a value is not compatible with the attribute"))
+	ErrInvalidSyntax = errutil.BadRequest("scim.invalid-syntax", errutil.WithPublicMessage("The request body is not valid"))
+	ErrNoTarget      = errutil.BadRequest("scim.no-target", errutil.WithPublicMessage("The path did not match any value"))
+	ErrUniqueness    = errutil.Conflict("scim.uniqueness", errutil.WithPublicMessage("A resource with the same unique attributes already exists"))
+	ErrNotFound      = errutil.NotFound("scim.not-found", errutil.WithPublicMessage("Resource not found"))
+	ErrNotManaged    = errutil.Forbidden("scim.not-managed", errutil.WithPublicMessage("The user is not managed by the identity provider"))
+)
+
+// errorTypes are the scimType values of the errors, see RFC 7644 section 3.12.
+var errorTypes = []struct {
+	base     errutil.Base
+	scimType string
+}{
+	{ErrInvalidFilter, "invalidFilter"},
+	{ErrInvalidPath, "invalidPath"},
+	{ErrInvalidValue, "invalidValue"},
+	{ErrInvalidSyntax, "invalidSyntax"},
+	{ErrNoTarget, "noTarget"},
+	{ErrUniqueness, "uniqueness"},
+}
+
+// User is the SCIM representation of a Grafana user.
+type User struct {
+	Schemas     []string               `json:"schemas"`
+	ID          string                 `json:"id,omitempty"`
+	ExternalID  string                 `json:"externalId,omitempty"`
+	UserName    string                 `json:"userName"`
+	Name        *Name                  `json:"name,omitempty"`
+	DisplayName string                 `json:"displayName,omitempty"`
+	Emails      []MultiValuedAttribute `json:"emails,omitempty"`
+	// Active is nil when the attribute is omitted from a request.
+	Active *bool `json:"active,omitempty"`
+	Meta   *Meta `json:"meta,omitempty"`
+}
+
+// PrimaryEmail returns the primary email of the user, or the first email
+// when none is marked as primary.
+func (u *User) PrimaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// FullName returns the display name of the user, falling back to the
+// components of the name attribute.
+func (u *User) FullName() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	if u.Name == nil {
+		return ""
+	}
+	if u.Name.Formatted != "" {
+		return u.Name.Formatted
+	}
+	if u.Name.GivenName != "" && u.Name.FamilyName != "" {
+		return u.Name.GivenName + " " + u.Name.FamilyName
+	}
+	return u.Name.GivenName + u.Name.FamilyName
+}
+
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// Group is the SCIM representation of a Grafana team.
+type Group struct {
+	Schemas     []string               `json:"schemas"`
+	ID          string                 `json:"id,omitempty"`
+	ExternalID  string                 `json:"externalId,omitempty"`
+	DisplayName string                 `json:"displayName"`
+	Members     []MultiValuedAttribute `json:"members,omitempty"`
+	Meta        *Meta                  `json:"meta,omitempty"`
+}
+
+// MultiValuedAttribute is a value of a multi-valued attribute, such as the
+// emails of a user or the members of a group.
+type MultiValuedAttribute struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+type Meta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation is an operation of a PATCH request. Op is add, remove or
+// replace, identity providers differ in the case they use.
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// Error is the body of SCIM error responses.
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// ErrorType returns the scimType of an error, or an empty string when the
+// error has none.
+func ErrorType(err error) string {
+	for _, t := range errorTypes {
+		if errors.Is(err, t.base) {
+			return t.scimType
+		}
+	}
+	return ""
+}
+
+// ToMap returns the JSON representation of a resource, which is what filters
+// and patch operations are evaluated against.
+func ToMap(resource any) (map[string]any, error) {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]any{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FromMap decodes the JSON representation of a resource.
+func FromMap(m map[string]any, resource any) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, resource); err != nil {
+		return ErrInvalidValue.Errorf("failed to decode resource: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package scim
+
+import (
+	"reflect"
+	"strings"
+)
+
+// patchPath is the target of a patch operation, such as
+// emails[type eq "work"].value, see RFC 7644 section 3.5.2.
+type patchPath struct {
+	attributePath
+	// filter selects elements of a multi-valued attribute, it is nil when
+	// the path has no value filter.
+	filter Filter
+}
+
+func parsePatchPath(path string) (patchPath, error) {
+	open := strings.Index(path, "[")
+	if open < 0 {
+		attribute, err := parseAttributePath(path)
+		return patchPath{attributePath: attribute}, err
+	}
+
+	closing := strings.LastIndex(path, "]")
+	if closing < open {
+		return patchPath{}, ErrInvalidPath.Errorf("invalid path %q", path)
+	}
+	attribute, err := parseAttributePath(path[:open])
+	if err != nil || attribute.subAttribute != "" {
+		return patchPath{}, ErrInvalidPath.Errorf("invalid path %q", path)
+	}
+
+	rest := path[closing+1:]
+	if rest != "" {
+		if !strings.HasPrefix(rest, ".") || len(rest) == 1 || strings.Contains(rest[1:], ".") {
+			return patchPath{}, ErrInvalidPath.Errorf("invalid path %q", path)
+		}
+		attribute.subAttribute = rest[1:]
+	}
+
+	filter, err := ParseFilter(path[open+1 : closing])
+	if err != nil {
+		return patchPath{}, ErrInvalidPath.Errorf("invalid filter in path %q: %w", path, err)
+	}
+	if filter == nil {
+		return patchPath{}, ErrInvalidPath.Errorf("empty filter in path %q", path)
+	}
+	return patchPath{attributePath: attribute, filter: filter}, nil
+}
+
+// ApplyPatch applies the operations of a PATCH request to the JSON
+// representation of a resource.
+func ApplyPatch(resource map[string]any, operations []PatchOperation) error {
+	for _, operation := range operations {
+		op := strings.ToLower(operation.Op)
+		if op != "add" && op != "replace" && op != "remove" {
+			return ErrInvalidSyntax.Errorf("invalid patch operation %q", operation.Op)
+		}
+
+		if operation.Path != "" {
+			path, err := parsePatchPath(operation.Path)
+			if err != nil {
+				return err
+			}
+			if err := applyPatchOperation(resource, op, path, operation.Value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if op == "remove" {
+			return ErrNoTarget.Errorf("remove operation without path")
+		}
+		// without path the value holds the attributes to add or replace,
+		// some identity providers use attribute paths as keys
+		values, ok := operation.Value.(map[string]any)
+		if !ok {
+			return ErrInvalidValue.Errorf("%s operation without path requires an object value", op)
+		}
+		for key, value := range values {
+			path, err := parsePatchPath(key)
+			if err != nil {
+				return err
+			}
+			if err := applyPatchOperation(resource, op, path, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func applyPatchOperation(resource map[string]any, op string, path patchPath, value any) error {
+	if op != "remove" && value == nil {
+		return ErrInvalidValue.Errorf("%s operation requires a value", op)
+	}
+
+	key, exists := lookupKey(resource, path.attribute)
+	if !exists {
+		key = path.attribute
+	}
+	current := resource[key]
+
+	if path.filter != nil {
+		elements, ok := current.([]any)
+		if exists && !ok {
+			return ErrInvalidPath.Errorf("attribute %q is not multi-valued", path.attribute)
+		}
+		elements, err := patchElements(elements, op, path, value)
+		if err != nil {
+			return err
+		}
+		resource[key] = elements
+		return nil
+	}
+
+	if path.subAttribute != "" {
+		return patchSubAttribute(resource, key, op, path.subAttribute, value)
+	}
+
+	switch op {
+	case "remove":
+		elements, isMultiValued := current.([]any)
+		if value == nil || !isMultiValued {
+			delete(resource, key)
+			return nil
+		}
+		// remove the given values from a multi-valued attribute
+		remaining := make([]any, 0, len(elements))
+		for _, element := range elements {
+			if !containsValue(toSlice(value), element) {
+				remaining = append(remaining, element)
+			}
+		}
+		resource[key] = remaining
+	case "add":
+		if elements, isMultiValued := current.([]any); isMultiValued {
+			for _, v := range toSlice(value) {
+				if !containsValue(elements, v) {
+					elements = append(elements, v)
+				}
+			}
+			resource[key] = elements
+			return nil
+		}
+		resource[key] = merge(current, value)
+	case "replace":
+		resource[key] = merge(current, value)
+	}
+	return nil
+}
+
+// patchSubAttribute applies an operation to a sub-attribute, such as
+// name.givenName. On multi-valued attributes it applies to every element.
+func patchSubAttribute(resource map[string]any, key, op, subAttribute string, value any) error {
+	switch current := resource[key].(type) {
+	case nil:
+		if op != "remove" {
+			resource[key] = map[string]any{subAttribute: value}
+		}
+	case map[string]any:
+		setSubAttribute(current, op, subAttribute, value)
+	case []any:
+		for _, element := range current {
+			if complexValue, ok := element.(map[string]any); ok {
+				setSubAttribute(complexValue, op, subAttribute, value)
+			}
+		}
+	default:
+		return ErrInvalidPath.Errorf("attribute %q has no sub-attributes", key)
+	}
+	return nil
+}
+
+// patchElements applies an operation to the elements of a multi-valued
+// attribute that match the filter of the path.
+func patchElements(elements []any, op string, path patchPath, value any) ([]any, error) {
+	result := make([]any, 0, len(elements))
+	matched := false
+	for _, element := range elements {
+		complexValue, ok := element.(map[string]any)
+		if !ok || !path.filter.Match(complexValue) {
+			result = append(result, element)
+			continue
+		}
+		matched = true
+
+		switch {
+		case op == "remove" && path.subAttribute == "":
+			// drop the element
+		case path.subAttribute != "":
+			setSubAttribute(complexValue, op, path.subAttribute, value)
+			result = append(result, complexValue)
+		case op == "replace":
+			result = append(result, value)
+		default:
+			result = append(result, merge(complexValue, value))
+		}
+	}
+
+	if matched || op == "remove" {
+		return result, nil
+	}
+
+	// nothing matched, add a new element built from the filter, such as
+	// the work email for emails[type eq "work"].value
+	if path.subAttribute != "" {
+		element := equalAttributes(path.filter)
+		element[path.subAttribute] = value
+		return append(result, element), nil
+	}
+	if op == "add" {
+		return append(result, value), nil
+	}
+	return nil, ErrNoTarget.Errorf("no value of %q matches the filter", path.attribute)
+}
+
+func setSubAttribute(m map[string]any, op, subAttribute string, value any) {
+	key, ok := lookupKey(m, subAttribute)
+	if !ok {
+		key = subAttribute
+	}
+	if op == "remove" {
+		delete(m, key)
+		return
+	}
+	m[key] = value
+}
+
+// equalAttributes returns the attributes the filter requires to be equal to
+// a value, for filters such as type eq "work" and primary eq true.
+func equalAttributes(filter Filter) map[string]any {
+	attributes := map[string]any{}
+	switch f := filter.(type) {
+	case *attributeExpression:
+		if f.op == "eq" && f.path.subAttribute == "" {
+			attributes[f.path.attribute] = f.value
+		}
+	case *logicalExpression:
+		if f.and {
+			for k, v := range equalAttributes(f.left) {
+				attributes[k] = v
+			}
+			for k, v := range equalAttributes(f.right) {
+				attributes[k] = v
+			}
+		}
+	}
+	return attributes
+}
+
+// merge adds the sub-attributes of a complex value to the current value, any
+// other value replaces the current value.
+func merge(current, value any) any {
+	currentMap, ok := current.(map[string]any)
+	if !ok {
+		return value
+	}
+	valueMap, ok := value.(map[string]any)
+	if !ok {
+		return value
+	}
+	for k, v := range valueMap {
+		setSubAttribute(currentMap, "replace", k, v)
+	}
+	return currentMap
+}
+
+func toSlice(value any) []any {
+	if values, ok := value.([]any); ok {
+		return values
+	}
+	return []any{value}
+}
+
+// containsValue reports whether the elements contain the element, complex
+// values are compared by their value sub-attribute.
+func containsValue(elements []any, element any) bool {
+	for _, e := range elements {
+		if reflect.DeepEqual(identity(e), identity(element)) {
+			return true
+		}
+	}
+	return false
+}
+
+func identity(element any) any {
+	if complexValue, ok := element.(map[string]any); ok {
+		if value, ok := lookup(complexValue, "value"); ok {
+			return value
+		}
+	}
+	return element
+}
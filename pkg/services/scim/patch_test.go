@@ -0,0 +1,131 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPatchTestUser() map[string]any {
+	return map[string]any{
+		"userName": "jane",
+		"name":     map[string]any{"givenName": "Jane", "familyName": "Doe"},
+		"emails": []any{
+			map[string]any{"value": "jane@example.com", "type": "work", "primary": true},
+		},
+		"active": true,
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		operations []PatchOperation
+		expected   func(user map[string]any)
+	}{
+		{
+			name:       "replace attribute",
+			operations: []PatchOperation{{Op: "Replace", Path: "active", Value: false}},
+			expected:   func(user map[string]any) { user["active"] = false },
+		},
+		{
+			name:       "replace sub-attribute",
+			operations: []PatchOperation{{Op: "replace", Path: "name.familyName", Value: "Smith"}},
+			expected: func(user map[string]any) {
+				user["name"] = map[string]any{"givenName": "Jane", "familyName": "Smith"}
+			},
+		},
+		{
+			name: "replace without path",
+			operations: []PatchOperation{{Op: "replace", Value: map[string]any{
+				"userName":       "jane.doe",
+				"name.givenName": "Janet",
+			}}},
+			expected: func(user map[string]any) {
+				user["userName"] = "jane.doe"
+				user["name"] = map[string]any{"givenName": "Janet", "familyName": "Doe"}
+			},
+		},
+		{
+			name:       "replace value of filtered element",
+			operations: []PatchOperation{{Op: "replace", Path: `emails[type eq "work"].value`, Value: "jane.doe@example.com"}},
+			expected: func(user map[string]any) {
+				user["emails"] = []any{
+					map[string]any{"value": "jane.doe@example.com", "type": "work", "primary": true},
+				}
+			},
+		},
+		{
+			name:       "add value of missing filtered element",
+			operations: []PatchOperation{{Op: "add", Path: `emails[type eq "home"].value`, Value: "jane@home.example.com"}},
+			expected: func(user map[string]any) {
+				user["emails"] = append(user["emails"].([]any), map[string]any{"value": "jane@home.example.com", "type": "home"})
+			},
+		},
+		{
+			name: "add to multi-valued attribute skips existing values",
+			operations: []PatchOperation{{Op: "add", Path: "emails", Value: []any{
+				map[string]any{"value": "jane@example.com"},
+				map[string]any{"value": "jane@home.example.com"},
+			}}},
+			expected: func(user map[string]any) {
+				user["emails"] = append(user["emails"].([]any), map[string]any{"value": "jane@home.example.com"})
+			},
+		},
+		{
+			name:       "remove values from multi-valued attribute",
+			operations: []PatchOperation{{Op: "remove", Path: "emails", Value: []any{map[string]any{"value": "jane@example.com"}}}},
+			expected:   func(user map[string]any) { user["emails"] = []any{} },
+		},
+		{
+			name:       "remove filtered element",
+			operations: []PatchOperation{{Op: "remove", Path: `emails[value eq "jane@example.com"]`}},
+			expected:   func(user map[string]any) { user["emails"] = []any{} },
+		},
+		{
+			name:       "remove attribute",
+			operations: []PatchOperation{{Op: "remove", Path: "name"}},
+			expected:   func(user map[string]any) { delete(user, "name") },
+		},
+		{
+			name:       "attribute names are case insensitive",
+			operations: []PatchOperation{{Op: "replace", Path: "USERNAME", Value: "janet"}},
+			expected:   func(user map[string]any) { user["userName"] = "janet" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := newPatchTestUser()
+			expected := newPatchTestUser()
+			tt.expected(expected)
+
+			require.NoError(t, ApplyPatch(user, tt.operations))
+			assert.Equal(t, expected, user)
+		})
+	}
+}
+
+func TestApplyPatch_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation PatchOperation
+		err       error
+	}{
+		{name: "invalid operation", operation: PatchOperation{Op: "move", Path: "active"}, err: ErrInvalidSyntax},
+		{name: "invalid path", operation: PatchOperation{Op: "replace", Path: "name.given.name", Value: "Jane"}, err: ErrInvalidPath},
+		{name: "invalid filter in path", operation: PatchOperation{Op: "replace", Path: `emails[type eq].value`, Value: "x"}, err: ErrInvalidPath},
+		{name: "remove without path", operation: PatchOperation{Op: "remove"}, err: ErrNoTarget},
+		{name: "replace without value", operation: PatchOperation{Op: "replace", Path: "active"}, err: ErrInvalidValue},
+		{name: "replace without path requires object", operation: PatchOperation{Op: "replace", Value: "jane"}, err: ErrInvalidValue},
+		{name: "replace missing filtered element", operation: PatchOperation{Op: "replace", Path: `emails[type eq "home"]`, Value: map[string]any{"value": "x"}}, err: ErrNoTarget},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ApplyPatch(newPatchTestUser(), []PatchOperation{tt.operation})
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
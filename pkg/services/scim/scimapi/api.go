@@ -0,0 +1,279 @@
+package scimapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	claims "github.com/grafana/authlib/types"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/middleware/requestmeta"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/auth"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/login"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/scim"
+	"github.com/grafana/grafana/pkg/services/scimutil"
+	"github.com/grafana/grafana/pkg/services/serviceaccounts"
+	"github.com/grafana/grafana/pkg/services/team"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const (
+	defaultCount = 100
+	maxCount     = 1000
+)
+
+// settings are read from the [auth.scim] section.
+type settings struct {
+	userSyncEnabled  bool
+	groupSyncEnabled bool
+	// orgID is the org SCIM provisions users and teams in. Only users of
+	// this org can be read or updated.
+	orgID int64
+	// serviceAccount is the name of the service account identity providers
+	// authenticate as.
+	serviceAccount string
+	// authModule is the login module of provisioned users, their SCIM
+	// externalId is stored as the id of that module.
+	authModule string
+}
+
+// SCIMAPI serves the SCIM 2.0 endpoints identity providers use to provision
+// users and teams, see RFC 7644.
+type SCIMAPI struct {
+	cfg                    *setting.Cfg
+	settings               settings
+	scimUtil               *scimutil.SCIMUtil
+	userService            user.Service
+	orgService             org.Service
+	teamService            team.Service
+	ac                     accesscontrol.Service
+	teamPermissionsService accesscontrol.TeamPermissionsService
+	authInfoService        login.AuthInfoService
+	userAuthTokenService   auth.UserTokenService
+	serviceAccountService  serviceaccounts.Service
+	logger                 log.Logger
+}
+
+func ProvideSCIMAPI(
+	routeRegister routing.RouteRegister,
+	cfg *setting.Cfg,
+	features featuremgmt.FeatureToggles,
+	userService user.Service,
+	orgService org.Service,
+	teamService team.Service,
+	ac accesscontrol.Service,
+	teamPermissionsService accesscontrol.TeamPermissionsService,
+	authInfoService login.AuthInfoService,
+	userAuthTokenService auth.UserTokenService,
+	serviceAccountService serviceaccounts.Service,
+) *SCIMAPI {
+	scimSection := cfg.Raw.Section("auth.scim")
+	api := &SCIMAPI{
+		cfg: cfg,
+		settings: settings{
+			userSyncEnabled:  scimSection.Key("user_sync_enabled").MustBool(false),
+			groupSyncEnabled: scimSection.Key("group_sync_enabled").MustBool(false),
+			orgID:            scimSection.Key("org_id").MustInt64(1),
+			serviceAccount:   scimSection.Key("service_account").MustString(""),
+			authModule:       scimSection.Key("auth_module").MustString(""),
+		},
+		scimUtil:               scimutil.NewSCIMUtil(nil),
+		userService:            userService,
+		orgService:             orgService,
+		teamService:            teamService,
+		ac:                     ac,
+		teamPermissionsService: teamPermissionsService,
+		authInfoService:        authInfoService,
+		userAuthTokenService:   userAuthTokenService,
+		serviceAccountService:  serviceAccountService,
+		logger:                 log.New("scim.api"),
+	}
+
+	if features.IsEnabledGlobally(featuremgmt.FlagEnableSCIM) {
+		api.registerRoutes(routeRegister)
+	}
+	return api
+}
+
+func (api *SCIMAPI) registerRoutes(router routing.RouteRegister) {
+	router.Group("/scim/v2", func(scimRoute routing.RouteRegister) {
+		scimRoute.Group("/Users", func(usersRoute routing.RouteRegister) {
+			usersRoute.Get("/", routing.Wrap(api.listUsers))
+			usersRoute.Post("/", routing.Wrap(api.createUser))
+			usersRoute.Get("/:id", routing.Wrap(api.getUser))
+			usersRoute.Put("/:id", routing.Wrap(api.replaceUser))
+			usersRoute.Patch("/:id", routing.Wrap(api.patchUser))
+			usersRoute.Delete("/:id", routing.Wrap(api.deleteUser))
+		}, api.requireUserSync)
+
+		scimRoute.Group("/Groups", func(groupsRoute routing.RouteRegister) {
+			groupsRoute.Get("/", routing.Wrap(api.listGroups))
+			groupsRoute.Post("/", routing.Wrap(api.createGroup))
+			groupsRoute.Get("/:id", routing.Wrap(api.getGroup))
+			groupsRoute.Put("/:id", routing.Wrap(api.replaceGroup))
+			groupsRoute.Patch("/:id", routing.Wrap(api.patchGroup))
+			groupsRoute.Delete("/:id", routing.Wrap(api.deleteGroup))
+		}, api.requireGroupSync)
+	}, api.authorize, requestmeta.SetOwner(requestmeta.TeamAuth))
+}
+
+// authorize only lets the configured service account of the SCIM org
+// through. SCIM requests manage the users of the org, so they are not
+// authorized with the role or permissions of the caller.
+func (api *SCIMAPI) authorize(c *contextmodel.ReqContext) {
+	if !c.IsSignedIn || !c.SignedInUser.IsIdentityType(claims.TypeServiceAccount) {
+		api.errorResponse(c, errUnauthorized.Errorf("SCIM requests require a service account token")).WriteTo(c)
+		return
+	}
+
+	if api.settings.serviceAccount == "" {
+		api.errorResponse(c, errForbidden.Errorf("no service account is configured for SCIM")).WriteTo(c)
+		return
+	}
+
+	// service accounts with the configured name can be created in any org
+	if c.GetOrgID() != api.settings.orgID {
+		api.errorResponse(c, errForbidden.Errorf("service account of org %d cannot provision org %d", c.GetOrgID(), api.settings.orgID)).WriteTo(c)
+		return
+	}
+
+	serviceAccountID, err := api.serviceAccountService.RetrieveServiceAccountIdByName(c.Req.Context(), api.settings.orgID, api.settings.serviceAccount)
+	if err != nil && !errors.Is(err, serviceaccounts.ErrServiceAccountNotFound) {
+		api.errorResponse(c, err).WriteTo(c)
+		return
+	}
+
+	internalID, _ := c.GetInternalID()
+	if err != nil || serviceAccountID != internalID {
+		api.errorResponse(c, errForbidden.Errorf("service account %d is not the SCIM service account", internalID)).WriteTo(c)
+	}
+}
+
+func (api *SCIMAPI) requireUserSync(c *contextmodel.ReqContext) {
+	if !api.scimUtil.IsUserSyncEnabled(c.Req.Context(), c.GetOrgID(), api.settings.userSyncEnabled) {
+		api.errorResponse(c, errForbidden.Errorf("SCIM user sync is disabled")).WriteTo(c)
+		return
+	}
+
+	// without auth module provisioned users could not be matched when
+	// they log in
+	if api.settings.authModule == "" {
+		api.logger.Error("SCIM user sync is enabled without auth_module in the [auth.scim] section")
+		api.errorResponse(c, errForbidden.Errorf("SCIM user sync requires auth_module")).WriteTo(c)
+	}
+}
+
+func (api *SCIMAPI) requireGroupSync(c *contextmodel.ReqContext) {
+	if !api.scimUtil.IsGroupSyncEnabled(c.Req.Context(), c.GetOrgID(), api.settings.groupSyncEnabled) {
+		api.errorResponse(c, errForbidden.Errorf("SCIM group sync is disabled")).WriteTo(c)
+	}
+}
+
+var (
+	errUnauthorized = errutil.Unauthorized("scim.unauthorized", errutil.WithPublicMessage("A service account token is required"))
+	errForbidden    = errutil.Forbidden("scim.forbidden", errutil.WithPublicMessage("SCIM provisioning is not enabled for this service account"))
+)
+
+// requester is used for the searches of the API, which would otherwise be
+// limited to the users and teams the service account can read.
+func requester(orgID int64) *user.SignedInUser {
+	return &user.SignedInUser{
+		Login:            "sa-scim",
+		OrgID:            orgID,
+		OrgRole:          org.RoleAdmin,
+		IsServiceAccount: true,
+		Permissions: map[int64]map[string][]string{orgID: {
+			accesscontrol.ActionUsersRead:    {accesscontrol.ScopeGlobalUsersAll},
+			accesscontrol.ActionTeamsRead:    {accesscontrol.ScopeTeamsAll},
+			accesscontrol.ActionOrgUsersRead: {accesscontrol.ScopeUsersAll},
+		}},
+	}
+}
+
+func respond(status int, body any) *response.NormalResponse {
+	return response.Respond(status, body).SetHeader("Content-Type", scim.ContentType)
+}
+
+// errorResponse renders an error as SCIM error, see RFC 7644 section 3.12.
+func (api *SCIMAPI) errorResponse(c *contextmodel.ReqContext, err error) *response.NormalResponse {
+	body := scim.Error{
+		Schemas:  []string{scim.SchemaError},
+		ScimType: scim.ErrorType(err),
+	}
+
+	status := http.StatusInternalServerError
+	var grafanaErr errutil.Error
+	if errors.As(err, &grafanaErr) {
+		public := grafanaErr.Public()
+		status = public.StatusCode
+		body.Detail = public.Message
+	}
+
+	if status >= http.StatusInternalServerError {
+		api.logger.FromContext(c.Req.Context()).Error("SCIM request failed", "path", c.Req.URL.Path, "error", err)
+		body.Detail = "Internal server error"
+	} else {
+		api.logger.FromContext(c.Req.Context()).Debug("SCIM request rejected", "path", c.Req.URL.Path, "error", err)
+	}
+
+	body.Status = strconv.Itoa(status)
+	return respond(status, body)
+}
+
+// decode reads the body of a request. It doesn't use web.Bind, which only
+// accepts application/json.
+func decode(c *contextmodel.ReqContext, v any) error {
+	if err := json.NewDecoder(c.Req.Body).Decode(v); err != nil {
+		return scim.ErrInvalidSyntax.Errorf("failed to decode request body: %w", err)
+	}
+	return nil
+}
+
+// list filters and paginates resources, see RFC 7644 section 3.4.2.
+func list(c *contextmodel.ReqContext, filter scim.Filter, resources []map[string]any) response.Response {
+	startIndex := max(c.QueryInt("startIndex"), 1)
+	count := defaultCount
+	if c.Query("count") != "" {
+		count = min(max(c.QueryInt("count"), 0), maxCount)
+	}
+
+	excluded := strings.Split(c.Query("excludedAttributes"), ",")
+	matching := make([]any, 0, len(resources))
+	for _, resource := range resources {
+		if filter != nil && !filter.Match(resource) {
+			continue
+		}
+		for _, attribute := range excluded {
+			delete(resource, strings.TrimSpace(attribute))
+		}
+		matching = append(matching, resource)
+	}
+
+	page := []any{}
+	if startIndex <= len(matching) {
+		page = matching[startIndex-1 : min(startIndex-1+count, len(matching))]
+	}
+
+	return respond(http.StatusOK, scim.ListResponse{
+		Schemas:      []string{scim.SchemaListResponse},
+		TotalResults: len(matching),
+		StartIndex:   startIndex,
+		ItemsPerPage: len(page),
+		Resources:    page,
+	})
+}
+
+func (api *SCIMAPI) location(resourceType, id string) string {
+	return api.cfg.AppURL + "scim/v2/" + resourceType + "/" + id
+}
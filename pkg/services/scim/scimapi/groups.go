@@ -0,0 +1,323 @@
+package scimapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/scim"
+	"github.com/grafana/grafana/pkg/services/team"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func (api *SCIMAPI) listGroups(c *contextmodel.ReqContext) response.Response {
+	ctx := c.Req.Context()
+	filter, err := scim.ParseFilter(c.Query("filter"))
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	// identity providers look groups up by displayName before creating them
+	query := &team.SearchTeamsQuery{OrgID: c.GetOrgID(), SignedInUser: requester(c.GetOrgID())}
+	if displayName, ok := scim.EqualValue(filter, "displayName"); ok {
+		query.Name = displayName
+	}
+	result, err := api.teamService.SearchTeams(ctx, query)
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	resources := make([]map[string]any, 0, len(result.Teams))
+	for _, t := range result.Teams {
+		group, err := api.toSCIMGroup(ctx, t)
+		if err != nil {
+			return api.errorResponse(c, err)
+		}
+		resource, err := scim.ToMap(group)
+		if err != nil {
+			return api.errorResponse(c, err)
+		}
+		resources = append(resources, resource)
+	}
+	return list(c, filter, resources)
+}
+
+func (api *SCIMAPI) getGroup(c *contextmodel.ReqContext) response.Response {
+	t, err := api.getTeamByUID(c.Req.Context(), c.GetOrgID(), web.Params(c.Req)[":id"])
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+	return api.groupResponse(c, http.StatusOK, t)
+}
+
+func (api *SCIMAPI) createGroup(c *contextmodel.ReqContext) response.Response {
+	ctx := c.Req.Context()
+	var group scim.Group
+	if err := decode(c, &group); err != nil {
+		return api.errorResponse(c, err)
+	}
+	if group.DisplayName == "" {
+		return api.errorResponse(c, scim.ErrInvalidValue.Errorf("displayName is required"))
+	}
+
+	// resolve the members first, so that no team is created for an invalid
+	// request
+	memberIDs, err := api.getMemberIDs(ctx, c.GetOrgID(), group.Members)
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	created, err := api.teamService.CreateTeam(ctx, &team.CreateTeamCommand{
+		Name:          group.DisplayName,
+		ExternalUID:   group.ExternalID,
+		IsProvisioned: true,
+		OrgID:         c.GetOrgID(),
+	})
+	if err != nil {
+		if errors.Is(err, team.ErrTeamNameTaken) {
+			return api.errorResponse(c, scim.ErrUniqueness.Errorf("team %q already exists", group.DisplayName))
+		}
+		return api.errorResponse(c, err)
+	}
+
+	if err := api.setMembers(ctx, c.GetOrgID(), created.ID, memberIDs); err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	t, err := api.getTeamByUID(ctx, c.GetOrgID(), created.UID)
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+	return api.groupResponse(c, http.StatusCreated, t).SetHeader("Location", api.location("Groups", t.UID))
+}
+
+func (api *SCIMAPI) replaceGroup(c *contextmodel.ReqContext) response.Response {
+	t, err := api.getTeamByUID(c.Req.Context(), c.GetOrgID(), web.Params(c.Req)[":id"])
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	var group scim.Group
+	if err := decode(c, &group); err != nil {
+		return api.errorResponse(c, err)
+	}
+	return api.updateGroup(c, t, group)
+}
+
+func (api *SCIMAPI) patchGroup(c *contextmodel.ReqContext) response.Response {
+	ctx := c.Req.Context()
+	t, err := api.getTeamByUID(ctx, c.GetOrgID(), web.Params(c.Req)[":id"])
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	var patch scim.PatchRequest
+	if err := decode(c, &patch); err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	current, err := api.toSCIMGroup(ctx, t)
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+	resource, err := scim.ToMap(current)
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+	if err := scim.ApplyPatch(resource, patch.Operations); err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	var group scim.Group
+	if err := scim.FromMap(resource, &group); err != nil {
+		return api.errorResponse(c, err)
+	}
+	return api.updateGroup(c, t, group)
+}
+
+// updateGroup updates a team and its members to match its SCIM
+// representation.
+func (api *SCIMAPI) updateGroup(c *contextmodel.ReqContext, t *team.TeamDTO, group scim.Group) response.Response {
+	ctx := c.Req.Context()
+	if group.DisplayName == "" {
+		return api.errorResponse(c, scim.ErrInvalidValue.Errorf("displayName is required"))
+	}
+
+	memberIDs, err := api.getMemberIDs(ctx, c.GetOrgID(), group.Members)
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	err = api.teamService.UpdateTeam(ctx, &team.UpdateTeamCommand{
+		ID:          t.ID,
+		Name:        group.DisplayName,
+		Email:       t.Email,
+		ExternalUID: group.ExternalID,
+		OrgID:       t.OrgID,
+	})
+	if err != nil {
+		if errors.Is(err, team.ErrTeamNameTaken) {
+			return api.errorResponse(c, scim.ErrUniqueness.Errorf("team %q already exists", group.DisplayName))
+		}
+		return api.errorResponse(c, err)
+	}
+
+	if err := api.setMembers(ctx, t.OrgID, t.ID, memberIDs); err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	updated, err := api.getTeamByUID(ctx, t.OrgID, t.UID)
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+	return api.groupResponse(c, http.StatusOK, updated)
+}
+
+func (api *SCIMAPI) deleteGroup(c *contextmodel.ReqContext) response.Response {
+	ctx := c.Req.Context()
+	t, err := api.getTeamByUID(ctx, c.GetOrgID(), web.Params(c.Req)[":id"])
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	if err := api.teamService.DeleteTeam(ctx, &team.DeleteTeamCommand{OrgID: t.OrgID, ID: t.ID}); err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	// clear associated team assignments, managed role and permissions
+	if err := api.ac.DeleteTeamPermissions(ctx, t.OrgID, t.ID); err != nil {
+		return api.errorResponse(c, err)
+	}
+	return respond(http.StatusNoContent, nil)
+}
+
+// getMemberIDs resolves the ids of the members of a group, which are UIDs of
+// users of the org.
+func (api *SCIMAPI) getMemberIDs(ctx context.Context, orgID int64, members []scim.MultiValuedAttribute) (map[int64]bool, error) {
+	ids := map[int64]bool{}
+	if len(members) == 0 {
+		return ids, nil
+	}
+
+	uids := make([]string, 0, len(members))
+	for _, member := range members {
+		uids = append(uids, member.Value)
+	}
+	users, err := api.userService.ListByIdOrUID(ctx, uids, []int64{})
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[string]bool{}
+	for _, usr := range users {
+		isMember, err := api.isOrgMember(ctx, orgID, usr)
+		if err != nil {
+			return nil, err
+		}
+		if !isMember {
+			continue
+		}
+		found[usr.UID] = true
+		ids[usr.ID] = true
+	}
+	for _, uid := range uids {
+		if !found[uid] {
+			return nil, scim.ErrInvalidValue.Errorf("member %q not found", uid)
+		}
+	}
+	return ids, nil
+}
+
+// setMembers adds and removes members of a team, so that its members are
+// the given users.
+func (api *SCIMAPI) setMembers(ctx context.Context, orgID, teamID int64, userIDs map[int64]bool) error {
+	members, err := api.teamService.GetTeamMembers(ctx, &team.GetTeamMembersQuery{
+		OrgID:        orgID,
+		TeamID:       teamID,
+		SignedInUser: requester(orgID),
+	})
+	if err != nil {
+		return err
+	}
+
+	current := map[int64]bool{}
+	for _, member := range members {
+		current[member.UserID] = true
+	}
+
+	teamIDString := strconv.FormatInt(teamID, 10)
+	for userID := range userIDs {
+		if current[userID] {
+			continue
+		}
+
+		if _, err := api.teamPermissionsService.SetUserPermission(ctx, orgID, accesscontrol.User{ID: userID}, teamIDString, team.PermissionTypeMember.String()); err != nil {
+			return err
+		}
+	}
+
+	for userID := range current {
+		if userIDs[userID] {
+			continue
+		}
+		if _, err := api.teamPermissionsService.SetUserPermission(ctx, orgID, accesscontrol.User{ID: userID}, teamIDString, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (api *SCIMAPI) getTeamByUID(ctx context.Context, orgID int64, uid string) (*team.TeamDTO, error) {
+	t, err := api.teamService.GetTeamByID(ctx, &team.GetTeamByIDQuery{
+		OrgID:        orgID,
+		UID:          uid,
+		SignedInUser: requester(orgID),
+	})
+	if errors.Is(err, team.ErrTeamNotFound) {
+		return nil, scim.ErrNotFound.Errorf("team %q not found", uid)
+	}
+	return t, err
+}
+
+func (api *SCIMAPI) toSCIMGroup(ctx context.Context, t *team.TeamDTO) (*scim.Group, error) {
+	members, err := api.teamService.GetTeamMembers(ctx, &team.GetTeamMembersQuery{
+		OrgID:        t.OrgID,
+		TeamID:       t.ID,
+		SignedInUser: requester(t.OrgID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	group := &scim.Group{
+		Schemas:     []string{scim.SchemaGroup},
+		ID:          t.UID,
+		ExternalID:  t.ExternalUID,
+		DisplayName: t.Name,
+		Members:     make([]scim.MultiValuedAttribute, 0, len(members)),
+		Meta: &scim.Meta{
+			ResourceType: "Group",
+			Location:     api.location("Groups", t.UID),
+		},
+	}
+	for _, member := range members {
+		group.Members = append(group.Members, scim.MultiValuedAttribute{
+			Value:   member.UserUID,
+			Display: member.Login,
+			Ref:     api.location("Users", member.UserUID),
+		})
+	}
+	return group, nil
+}
+
+func (api *SCIMAPI) groupResponse(c *contextmodel.ReqContext, status int, t *team.TeamDTO) *response.NormalResponse {
+	group, err := api.toSCIMGroup(c.Req.Context(), t)
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+	return respond(status, group)
+}
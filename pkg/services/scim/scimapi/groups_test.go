@@ -0,0 +1,172 @@
+package scimapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/org/orgtest"
+	"github.com/grafana/grafana/pkg/services/scim"
+	"github.com/grafana/grafana/pkg/services/team"
+	"github.com/grafana/grafana/pkg/services/team/teamtest"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/services/user/usertest"
+)
+
+type permissionCall struct {
+	userID     int64
+	teamID     string
+	permission string
+}
+
+type recordingPermissionsService struct {
+	actest.FakePermissionsService
+	calls []permissionCall
+}
+
+func (s *recordingPermissionsService) SetUserPermission(ctx context.Context, orgID int64, user accesscontrol.User, resourceID, permission string) (*accesscontrol.ResourcePermission, error) {
+	s.calls = append(s.calls, permissionCall{userID: user.ID, teamID: resourceID, permission: permission})
+	return nil, nil
+}
+
+func TestSCIMAPI_GetGroup(t *testing.T) {
+	server := setupSCIMServer(t, featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), func(api *SCIMAPI) {
+		api.teamService = &teamtest.FakeService{
+			ExpectedTeamDTO: &team.TeamDTO{ID: 1, UID: "team-uid", OrgID: 1, Name: "Engineering", ExternalUID: "ext-team"},
+			ExpectedMembers: []*team.TeamMemberDTO{{UserID: 2, UserUID: "jane-uid", Login: "jane"}},
+		}
+	})
+
+	res, err := server.Send(scimRequest(server, http.MethodGet, "/scim/v2/Groups/team-uid", "", scimServiceAccount()))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var group scim.Group
+	decodeResponse(t, res, &group)
+	assert.Equal(t, "team-uid", group.ID)
+	assert.Equal(t, "ext-team", group.ExternalID)
+	assert.Equal(t, "Engineering", group.DisplayName)
+	assert.Equal(t, []scim.MultiValuedAttribute{
+		{Value: "jane-uid", Display: "jane", Ref: "http://localhost:3000/scim/v2/Users/jane-uid"},
+	}, group.Members)
+}
+
+func TestSCIMAPI_PatchGroup(t *testing.T) {
+	t.Run("should add and remove members", func(t *testing.T) {
+		permissions := &recordingPermissionsService{}
+		server := setupSCIMServer(t, featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), func(api *SCIMAPI) {
+			api.teamService = &teamtest.FakeService{
+				ExpectedTeamDTO: &team.TeamDTO{ID: 1, UID: "team-uid", OrgID: 1, Name: "Engineering"},
+				ExpectedMembers: []*team.TeamMemberDTO{{UserID: 2, UserUID: "jane-uid", Login: "jane"}},
+			}
+			api.userService = &usertest.FakeUserService{
+				ExpectedListUsersByIdOrUid: []*user.User{{ID: 3, UID: "john-uid", Login: "john"}},
+			}
+			api.teamPermissionsService = permissions
+		})
+
+		res, err := server.Send(scimRequest(server, http.MethodPatch, "/scim/v2/Groups/team-uid", `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [
+				{"op": "Add", "path": "members", "value": [{"value": "john-uid"}]},
+				{"op": "remove", "path": "members[value eq \"jane-uid\"]"}
+			]
+		}`, scimServiceAccount()))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+
+		assert.ElementsMatch(t, []permissionCall{
+			{userID: 3, teamID: "1", permission: team.PermissionTypeMember.String()},
+			{userID: 2, teamID: "1", permission: ""},
+		}, permissions.calls)
+	})
+
+	t.Run("should reject unknown members", func(t *testing.T) {
+		permissions := &recordingPermissionsService{}
+		server := setupSCIMServer(t, featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), func(api *SCIMAPI) {
+			api.teamService = &teamtest.FakeService{
+				ExpectedTeamDTO: &team.TeamDTO{ID: 1, UID: "team-uid", OrgID: 1, Name: "Engineering"},
+			}
+			api.teamPermissionsService = permissions
+		})
+
+		res, err := server.Send(scimRequest(server, http.MethodPatch, "/scim/v2/Groups/team-uid", `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [{"op": "add", "path": "members", "value": [{"value": "unknown-uid"}]}]
+		}`, scimServiceAccount()))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+		var body scim.Error
+		decodeResponse(t, res, &body)
+		assert.Equal(t, "invalidValue", body.ScimType)
+		assert.Empty(t, permissions.calls)
+	})
+}
+
+func TestSCIMAPI_CreateGroup(t *testing.T) {
+	t.Run("should reject existing team", func(t *testing.T) {
+		server := setupSCIMServer(t, featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), func(api *SCIMAPI) {
+			api.teamService = &teamtest.FakeService{ExpectedError: team.ErrTeamNameTaken}
+		})
+
+		res, err := server.Send(scimRequest(server, http.MethodPost, "/scim/v2/Groups", `{
+			"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+			"displayName": "Engineering"
+		}`, scimServiceAccount()))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("should require displayName", func(t *testing.T) {
+		server := setupSCIMServer(t, featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM))
+
+		res, err := server.Send(scimRequest(server, http.MethodPost, "/scim/v2/Groups", `{"schemas": []}`, scimServiceAccount()))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+}
+
+func TestSCIMAPI_DeleteGroup(t *testing.T) {
+	server := setupSCIMServer(t, featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), func(api *SCIMAPI) {
+		api.teamService = &teamtest.FakeService{ExpectedError: team.ErrTeamNotFound}
+	})
+
+	res, err := server.Send(scimRequest(server, http.MethodDelete, "/scim/v2/Groups/team-uid", "", scimServiceAccount()))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	require.NoError(t, res.Body.Close())
+}
+
+func TestSCIMAPI_GroupMembersOfOtherOrgs(t *testing.T) {
+	permissions := &recordingPermissionsService{}
+	server := setupSCIMServer(t, featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), func(api *SCIMAPI) {
+		api.orgService = &orgtest.FakeOrgService{ExpectedUserOrgDTO: []*org.UserOrgDTO{{OrgID: 2}}}
+		api.teamService = &teamtest.FakeService{
+			ExpectedTeamDTO: &team.TeamDTO{ID: 1, UID: "team-uid", OrgID: 1, Name: "Engineering"},
+		}
+		api.userService = &usertest.FakeUserService{
+			ExpectedListUsersByIdOrUid: []*user.User{{ID: 1, UID: "admin-uid", Login: "admin", IsAdmin: true}},
+		}
+		api.teamPermissionsService = permissions
+	})
+
+	res, err := server.Send(scimRequest(server, http.MethodPatch, "/scim/v2/Groups/team-uid", `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [{"op": "add", "path": "members", "value": [{"value": "admin-uid"}]}]
+	}`, scimServiceAccount()))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	require.NoError(t, res.Body.Close())
+	assert.Empty(t, permissions.calls)
+}
@@ -0,0 +1,468 @@
+package scimapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/login"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/scim"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func (api *SCIMAPI) listUsers(c *contextmodel.ReqContext) response.Response {
+	ctx := c.Req.Context()
+	filter, err := scim.ParseFilter(c.Query("filter"))
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	users, err := api.findUsers(ctx, c.GetOrgID(), filter)
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	resources := make([]map[string]any, 0, len(users))
+	for _, usr := range users {
+		scimUser, err := api.toSCIMUser(ctx, usr)
+		if err != nil {
+			return api.errorResponse(c, err)
+		}
+		resource, err := scim.ToMap(scimUser)
+		if err != nil {
+			return api.errorResponse(c, err)
+		}
+		resources = append(resources, resource)
+	}
+	return list(c, filter, resources)
+}
+
+// findUsers returns the users of the org the filter could match. Identity
+// providers look users up by userName or externalId before provisioning
+// them, these lookups don't need to load every user.
+func (api *SCIMAPI) findUsers(ctx context.Context, orgID int64, filter scim.Filter) ([]*user.User, error) {
+	var usr *user.User
+	var err error
+	if userName, ok := scim.EqualValue(filter, "userName"); ok {
+		usr, err = api.userService.GetByLogin(ctx, &user.GetUserByLoginQuery{LoginOrEmail: userName})
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil, nil
+		}
+	} else if externalID, ok := scim.EqualValue(filter, "externalId"); ok {
+		usr, err = api.getUserByExternalID(ctx, externalID)
+		if errors.Is(err, scim.ErrNotFound) {
+			return nil, nil
+		}
+	} else {
+		return api.getOrgUsers(ctx, orgID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	isMember, err := api.isOrgMember(ctx, orgID, usr)
+	if err != nil || !isMember {
+		return nil, err
+	}
+	return []*user.User{usr}, nil
+}
+
+func (api *SCIMAPI) getOrgUsers(ctx context.Context, orgID int64) ([]*user.User, error) {
+	orgUsers, err := api.orgService.GetOrgUsers(ctx, &org.GetOrgUsersQuery{
+		OrgID:                    orgID,
+		DontEnforceAccessControl: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(orgUsers) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, 0, len(orgUsers))
+	for _, orgUser := range orgUsers {
+		ids = append(ids, orgUser.UserID)
+	}
+	return api.userService.ListByIdOrUID(ctx, []string{}, ids)
+}
+
+func (api *SCIMAPI) getUser(c *contextmodel.ReqContext) response.Response {
+	usr, err := api.getUserByUID(c.Req.Context(), c.GetOrgID(), web.Params(c.Req)[":id"])
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+	return api.userResponse(c, http.StatusOK, usr)
+}
+
+func (api *SCIMAPI) createUser(c *contextmodel.ReqContext) response.Response {
+	ctx := c.Req.Context()
+	var scimUser scim.User
+	if err := decode(c, &scimUser); err != nil {
+		return api.errorResponse(c, err)
+	}
+	if scimUser.UserName == "" {
+		return api.errorResponse(c, scim.ErrInvalidValue.Errorf("userName is required"))
+	}
+
+	if scimUser.ExternalID != "" {
+		if _, err := api.getUserByExternalID(ctx, scimUser.ExternalID); err == nil {
+			return api.errorResponse(c, scim.ErrUniqueness.Errorf("externalId %q is already used", scimUser.ExternalID))
+		} else if !errors.Is(err, scim.ErrNotFound) {
+			return api.errorResponse(c, err)
+		}
+	}
+
+	// provisioned users are created in the org of the service account
+	usr, err := api.userService.Create(ctx, &user.CreateUserCommand{
+		Login:         scimUser.UserName,
+		Email:         scimUser.PrimaryEmail(),
+		Name:          scimUser.FullName(),
+		IsDisabled:    scimUser.Active != nil && !*scimUser.Active,
+		IsProvisioned: true,
+	})
+	if err != nil {
+		if errors.Is(err, user.ErrUserAlreadyExists) {
+			return api.errorResponse(c, scim.ErrUniqueness.Errorf("user %q already exists", scimUser.UserName))
+		}
+		return api.errorResponse(c, err)
+	}
+
+	err = api.orgService.AddOrgUser(ctx, &org.AddOrgUserCommand{
+		OrgID:  c.GetOrgID(),
+		UserID: usr.ID,
+		Role:   org.RoleType(api.cfg.AutoAssignOrgRole),
+	})
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	if err := api.setExternalID(ctx, usr.ID, "", scimUser.ExternalID); err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	return api.userResponse(c, http.StatusCreated, usr).SetHeader("Location", api.location("Users", usr.UID))
+}
+
+func (api *SCIMAPI) replaceUser(c *contextmodel.ReqContext) response.Response {
+	usr, err := api.getUserByUID(c.Req.Context(), c.GetOrgID(), web.Params(c.Req)[":id"])
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	var scimUser scim.User
+	if err := decode(c, &scimUser); err != nil {
+		return api.errorResponse(c, err)
+	}
+	return api.updateUser(c, usr, scimUser)
+}
+
+func (api *SCIMAPI) patchUser(c *contextmodel.ReqContext) response.Response {
+	ctx := c.Req.Context()
+	usr, err := api.getUserByUID(ctx, c.GetOrgID(), web.Params(c.Req)[":id"])
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	var patch scim.PatchRequest
+	if err := decode(c, &patch); err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	current, err := api.toSCIMUser(ctx, usr)
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+	resource, err := scim.ToMap(current)
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+	if err := scim.ApplyPatch(resource, patch.Operations); err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	// some identity providers send active as string, such as "False"
+	if active, ok := resource["active"].(string); ok {
+		value, err := strconv.ParseBool(active)
+		if err != nil {
+			return api.errorResponse(c, scim.ErrInvalidValue.Errorf("invalid active value %q", active))
+		}
+		resource["active"] = value
+	}
+
+	var scimUser scim.User
+	if err := scim.FromMap(resource, &scimUser); err != nil {
+		return api.errorResponse(c, err)
+	}
+	return api.updateUser(c, usr, scimUser)
+}
+
+// updateUser updates a user to match its SCIM representation. Users that log
+// in through the auth module become provisioned users, which are managed by
+// the identity provider.
+func (api *SCIMAPI) updateUser(c *contextmodel.ReqContext, usr *user.User, scimUser scim.User) response.Response {
+	ctx := c.Req.Context()
+	if scimUser.UserName == "" {
+		return api.errorResponse(c, scim.ErrInvalidValue.Errorf("userName is required"))
+	}
+
+	externalID, err := api.getExternalID(ctx, usr.ID)
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+	if err := checkManaged(usr, externalID); err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	email := scimUser.PrimaryEmail()
+	if err := api.checkUserConflicts(ctx, usr, scimUser.UserName, email); err != nil {
+		return api.errorResponse(c, err)
+	}
+	if scimUser.ExternalID != "" && scimUser.ExternalID != externalID {
+		if _, err := api.getUserByExternalID(ctx, scimUser.ExternalID); err == nil {
+			return api.errorResponse(c, scim.ErrUniqueness.Errorf("externalId %q is already used", scimUser.ExternalID))
+		} else if !errors.Is(err, scim.ErrNotFound) {
+			return api.errorResponse(c, err)
+		}
+	}
+
+	isProvisioned := true
+	cmd := &user.UpdateUserCommand{
+		UserID:        usr.ID,
+		Login:         scimUser.UserName,
+		Email:         email,
+		Name:          scimUser.FullName(),
+		IsProvisioned: &isProvisioned,
+	}
+	if scimUser.Active != nil {
+		isDisabled := !*scimUser.Active
+		cmd.IsDisabled = &isDisabled
+	}
+	if err := api.userService.Update(ctx, cmd); err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	if scimUser.Active != nil && !*scimUser.Active && !usr.IsDisabled {
+		if err := api.userAuthTokenService.RevokeAllUserTokens(ctx, usr.ID); err != nil {
+			return api.errorResponse(c, err)
+		}
+	}
+
+	if scimUser.ExternalID != externalID {
+		if err := api.setExternalID(ctx, usr.ID, externalID, scimUser.ExternalID); err != nil {
+			return api.errorResponse(c, err)
+		}
+	}
+
+	updated, err := api.userService.GetByID(ctx, &user.GetUserByIDQuery{ID: usr.ID})
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+	return api.userResponse(c, http.StatusOK, updated)
+}
+
+// deleteUser disables the user and revokes its sessions. Provisioned users
+// are deactivated rather than deleted, so that the resources they own are
+// kept.
+func (api *SCIMAPI) deleteUser(c *contextmodel.ReqContext) response.Response {
+	ctx := c.Req.Context()
+	usr, err := api.getUserByUID(ctx, c.GetOrgID(), web.Params(c.Req)[":id"])
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+	externalID, err := api.getExternalID(ctx, usr.ID)
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+	if err := checkManaged(usr, externalID); err != nil {
+		return api.errorResponse(c, err)
+	}
+
+	isDisabled := true
+	if err := api.userService.Update(ctx, &user.UpdateUserCommand{UserID: usr.ID, IsDisabled: &isDisabled}); err != nil {
+		return api.errorResponse(c, err)
+	}
+	if err := api.userAuthTokenService.RevokeAllUserTokens(ctx, usr.ID); err != nil {
+		return api.errorResponse(c, err)
+	}
+	return respond(http.StatusNoContent, nil)
+}
+
+// checkManaged returns an error unless the identity provider manages the
+// user, because it provisioned the user or the user logs in through the auth
+// module. The login, email and disabled state of a user are not scoped to the
+// org, so changing them for other users or server admins would let the SCIM
+// token take over accounts.
+func checkManaged(usr *user.User, externalID string) error {
+	if usr.IsAdmin {
+		return scim.ErrNotManaged.Errorf("user %q is a server admin", usr.UID)
+	}
+	if !usr.IsProvisioned && externalID == "" {
+		return scim.ErrNotManaged.Errorf("user %q is not provisioned and has no auth info for the auth module", usr.UID)
+	}
+	return nil
+}
+
+func (api *SCIMAPI) checkUserConflicts(ctx context.Context, usr *user.User, login, email string) error {
+	existing, err := api.userService.GetByLogin(ctx, &user.GetUserByLoginQuery{LoginOrEmail: login})
+	if err != nil && !errors.Is(err, user.ErrUserNotFound) {
+		return err
+	}
+	if err == nil && existing.ID != usr.ID {
+		return scim.ErrUniqueness.Errorf("userName %q is already used", login)
+	}
+
+	if email == "" {
+		return nil
+	}
+	existing, err = api.userService.GetByEmail(ctx, &user.GetUserByEmailQuery{Email: email})
+	if err != nil && !errors.Is(err, user.ErrUserNotFound) {
+		return err
+	}
+	if err == nil && existing.ID != usr.ID {
+		return scim.ErrUniqueness.Errorf("email %q is already used", email)
+	}
+	return nil
+}
+
+// getUserByUID returns a user of the org. Users of other orgs are not found,
+// so that SCIM cannot update them.
+func (api *SCIMAPI) getUserByUID(ctx context.Context, orgID int64, uid string) (*user.User, error) {
+	usr, err := api.userService.GetByUID(ctx, &user.GetUserByUIDQuery{UID: uid})
+	if errors.Is(err, user.ErrUserNotFound) {
+		return nil, scim.ErrNotFound.Errorf("user %q not found", uid)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	isMember, err := api.isOrgMember(ctx, orgID, usr)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, scim.ErrNotFound.Errorf("user %q not found", uid)
+	}
+	return usr, nil
+}
+
+// isOrgMember returns whether a user, which is not a service account, is a
+// member of the org.
+func (api *SCIMAPI) isOrgMember(ctx context.Context, orgID int64, usr *user.User) (bool, error) {
+	if usr.IsServiceAccount {
+		return false, nil
+	}
+
+	orgs, err := api.orgService.GetUserOrgList(ctx, &org.GetUserOrgListQuery{UserID: usr.ID})
+	if err != nil {
+		return false, err
+	}
+	for _, o := range orgs {
+		if o.OrgID == orgID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (api *SCIMAPI) getUserByExternalID(ctx context.Context, externalID string) (*user.User, error) {
+	authInfo, err := api.authInfoService.GetAuthInfo(ctx, &login.GetAuthInfoQuery{
+		AuthModule: api.settings.authModule,
+		AuthId:     externalID,
+	})
+	if errors.Is(err, user.ErrUserNotFound) {
+		return nil, scim.ErrNotFound.Errorf("user with externalId %q not found", externalID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	usr, err := api.userService.GetByID(ctx, &user.GetUserByIDQuery{ID: authInfo.UserId})
+	if errors.Is(err, user.ErrUserNotFound) {
+		return nil, scim.ErrNotFound.Errorf("user with externalId %q not found", externalID)
+	}
+	return usr, err
+}
+
+// getExternalID returns the id of the user for the auth module, or an empty
+// string when the user has no auth info for it.
+func (api *SCIMAPI) getExternalID(ctx context.Context, userID int64) (string, error) {
+	authInfo, err := api.authInfoService.GetAuthInfo(ctx, &login.GetAuthInfoQuery{
+		UserId:     userID,
+		AuthModule: api.settings.authModule,
+	})
+	if errors.Is(err, user.ErrUserNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if authInfo.ExternalUID != "" {
+		return authInfo.ExternalUID, nil
+	}
+	return authInfo.AuthId, nil
+}
+
+// setExternalID stores the externalId as the id of the user for the auth
+// module, which is how the user is matched when it logs in.
+func (api *SCIMAPI) setExternalID(ctx context.Context, userID int64, current, externalID string) error {
+	if externalID == "" {
+		return nil
+	}
+	if current == "" {
+		return api.authInfoService.SetAuthInfo(ctx, &login.SetAuthInfoCommand{
+			UserId:      userID,
+			AuthModule:  api.settings.authModule,
+			AuthId:      externalID,
+			ExternalUID: externalID,
+		})
+	}
+	return api.authInfoService.UpdateAuthInfo(ctx, &login.UpdateAuthInfoCommand{
+		UserId:      userID,
+		AuthModule:  api.settings.authModule,
+		AuthId:      externalID,
+		ExternalUID: externalID,
+	})
+}
+
+func (api *SCIMAPI) toSCIMUser(ctx context.Context, usr *user.User) (*scim.User, error) {
+	externalID, err := api.getExternalID(ctx, usr.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	active := !usr.IsDisabled
+	scimUser := &scim.User{
+		Schemas:     []string{scim.SchemaUser},
+		ID:          usr.UID,
+		ExternalID:  externalID,
+		UserName:    usr.Login,
+		DisplayName: usr.Name,
+		Active:      &active,
+		Meta: &scim.Meta{
+			ResourceType: "User",
+			Created:      &usr.Created,
+			LastModified: &usr.Updated,
+			Location:     api.location("Users", usr.UID),
+		},
+	}
+	if usr.Name != "" {
+		scimUser.Name = &scim.Name{Formatted: usr.Name}
+	}
+	if usr.Email != "" {
+		scimUser.Emails = []scim.MultiValuedAttribute{{Value: usr.Email, Type: "work", Primary: true}}
+	}
+	return scimUser, nil
+}
+
+func (api *SCIMAPI) userResponse(c *contextmodel.ReqContext, status int, usr *user.User) *response.NormalResponse {
+	scimUser, err := api.toSCIMUser(c.Req.Context(), usr)
+	if err != nil {
+		return api.errorResponse(c, err)
+	}
+	return respond(status, scimUser)
+}
@@ -0,0 +1,481 @@
+package scimapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
+	"github.com/grafana/grafana/pkg/services/auth/authtest"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/login"
+	"github.com/grafana/grafana/pkg/services/login/authinfotest"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/org/orgtest"
+	"github.com/grafana/grafana/pkg/services/scim"
+	satests "github.com/grafana/grafana/pkg/services/serviceaccounts/tests"
+	"github.com/grafana/grafana/pkg/services/team/teamtest"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/services/user/usertest"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+const scimServiceAccountID = 10
+
+func setupSCIMServer(t *testing.T, features featuremgmt.FeatureToggles, opts ...func(api *SCIMAPI)) *webtest.Server {
+	t.Helper()
+	router := routing.NewRouteRegister()
+	cfg := setting.NewCfg()
+	cfg.AppURL = "http://localhost:3000/"
+	cfg.AutoAssignOrgRole = "Viewer"
+	scimSection := cfg.Raw.Section("auth.scim")
+	scimSection.Key("user_sync_enabled").SetValue("true")
+	scimSection.Key("group_sync_enabled").SetValue("true")
+	scimSection.Key("service_account").SetValue("scim")
+	scimSection.Key("auth_module").SetValue(login.AzureADAuthModule)
+
+	tokenService := authtest.NewFakeUserAuthTokenService()
+	tokenService.RevokeAllUserTokensProvider = func(ctx context.Context, userID int64) error { return nil }
+
+	api := ProvideSCIMAPI(router,
+		cfg,
+		features,
+		&usertest.FakeUserService{},
+		&orgtest.FakeOrgService{ExpectedUserOrgDTO: []*org.UserOrgDTO{{OrgID: 1}}},
+		teamtest.NewFakeService(),
+		actest.FakeService{},
+		&actest.FakePermissionsService{},
+		&authinfotest.FakeService{ExpectedError: user.ErrUserNotFound},
+		tokenService,
+		&satests.FakeServiceAccountService{ExpectedServiceAccountID: scimServiceAccountID},
+	)
+	for _, o := range opts {
+		o(api)
+	}
+
+	return webtest.NewServer(t, router)
+}
+
+func scimRequest(server *webtest.Server, method, target, body string, usr *user.SignedInUser) *http.Request {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req := server.NewRequest(method, target, reader)
+	req.Header.Set("Content-Type", scim.ContentType)
+	return webtest.RequestWithSignedInUser(req, usr)
+}
+
+func scimServiceAccount() *user.SignedInUser {
+	return &user.SignedInUser{UserID: scimServiceAccountID, OrgID: 1, IsServiceAccount: true}
+}
+
+func decodeResponse(t *testing.T, res *http.Response, v any) {
+	t.Helper()
+	require.NoError(t, json.NewDecoder(res.Body).Decode(v))
+	require.NoError(t, res.Body.Close())
+}
+
+func TestSCIMAPI_Authorize(t *testing.T) {
+	tests := []struct {
+		name     string
+		features featuremgmt.FeatureToggles
+		usr      *user.SignedInUser
+		status   int
+	}{
+		{name: "SCIM service account is allowed", features: featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), usr: scimServiceAccount(), status: http.StatusOK},
+		{name: "users are not allowed", features: featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), usr: &user.SignedInUser{UserID: 1, OrgID: 1}, status: http.StatusUnauthorized},
+		{name: "other service accounts are not allowed", features: featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), usr: &user.SignedInUser{UserID: 11, OrgID: 1, IsServiceAccount: true}, status: http.StatusForbidden},
+		{name: "service accounts of other orgs are not allowed", features: featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), usr: &user.SignedInUser{UserID: scimServiceAccountID, OrgID: 2, IsServiceAccount: true}, status: http.StatusForbidden},
+		{name: "routes are not registered without feature flag", features: featuremgmt.WithFeatures(), usr: scimServiceAccount(), status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := setupSCIMServer(t, tt.features)
+			res, err := server.Send(scimRequest(server, http.MethodGet, "/scim/v2/Users", "", tt.usr))
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, res.StatusCode)
+			require.NoError(t, res.Body.Close())
+		})
+	}
+
+	t.Run("user sync can be disabled", func(t *testing.T) {
+		server := setupSCIMServer(t, featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), func(api *SCIMAPI) {
+			api.settings.userSyncEnabled = false
+		})
+		res, err := server.Send(scimRequest(server, http.MethodGet, "/scim/v2/Users", "", scimServiceAccount()))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+		assert.Equal(t, scim.ContentType, res.Header.Get("Content-Type"))
+
+		var body scim.Error
+		decodeResponse(t, res, &body)
+		assert.Equal(t, "403", body.Status)
+	})
+}
+
+func TestSCIMAPI_ListUsers(t *testing.T) {
+	jane := &user.User{ID: 2, UID: "jane-uid", Login: "jane", Email: "jane@example.com", Name: "Jane Doe", Created: time.Now()}
+
+	t.Run("should look users up by userName", func(t *testing.T) {
+		var query *user.GetUserByLoginQuery
+		server := setupSCIMServer(t, featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), func(api *SCIMAPI) {
+			api.userService = &usertest.FakeUserService{GetByLoginFn: func(ctx context.Context, q *user.GetUserByLoginQuery) (*user.User, error) {
+				query = q
+				return jane, nil
+			}}
+			api.authInfoService = &authinfotest.FakeService{ExpectedUserAuth: &login.UserAuth{UserId: 2, AuthId: "ext-1", ExternalUID: "ext-1"}}
+		})
+
+		res, err := server.Send(scimRequest(server, http.MethodGet, `/scim/v2/Users?filter=userName+eq+%22Jane%22`, "", scimServiceAccount()))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var body struct {
+			TotalResults int         `json:"totalResults"`
+			Resources    []scim.User `json:"Resources"`
+		}
+		decodeResponse(t, res, &body)
+		assert.Equal(t, "Jane", query.LoginOrEmail)
+		require.Equal(t, 1, body.TotalResults)
+		assert.Equal(t, "jane-uid", body.Resources[0].ID)
+		assert.Equal(t, "ext-1", body.Resources[0].ExternalID)
+		assert.Equal(t, "jane@example.com", body.Resources[0].PrimaryEmail())
+		assert.True(t, *body.Resources[0].Active)
+	})
+
+	t.Run("should filter and paginate users", func(t *testing.T) {
+		server := setupSCIMServer(t, featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), func(api *SCIMAPI) {
+			api.orgService = &orgtest.FakeOrgService{
+				ExpectedOrgUsers: []*org.OrgUserDTO{{UserID: 2}, {UserID: 3}, {UserID: 4}},
+			}
+			api.userService = &usertest.FakeUserService{
+				ExpectedListUsersByIdOrUid: []*user.User{
+					jane,
+					{ID: 3, UID: "john-uid", Login: "john", IsDisabled: true},
+					{ID: 4, UID: "jack-uid", Login: "jack"},
+				},
+			}
+		})
+
+		res, err := server.Send(scimRequest(server, http.MethodGet, `/scim/v2/Users?filter=active+eq+true&startIndex=2&count=1`, "", scimServiceAccount()))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var body scim.ListResponse
+		decodeResponse(t, res, &body)
+		assert.Equal(t, 2, body.TotalResults)
+		assert.Equal(t, 2, body.StartIndex)
+		require.Len(t, body.Resources, 1)
+		assert.Equal(t, "jack", body.Resources[0].(map[string]any)["userName"])
+	})
+
+	t.Run("should reject invalid filters", func(t *testing.T) {
+		server := setupSCIMServer(t, featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM))
+		res, err := server.Send(scimRequest(server, http.MethodGet, `/scim/v2/Users?filter=userName+eq`, "", scimServiceAccount()))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+		var body scim.Error
+		decodeResponse(t, res, &body)
+		assert.Equal(t, "invalidFilter", body.ScimType)
+	})
+}
+
+func TestSCIMAPI_CreateUser(t *testing.T) {
+	const body = `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"externalId": "ext-1",
+		"userName": "jane",
+		"name": {"givenName": "Jane", "familyName": "Doe"},
+		"emails": [{"value": "jane@example.com", "type": "work", "primary": true}],
+		"active": true
+	}`
+
+	t.Run("should create provisioned user", func(t *testing.T) {
+		var created *user.CreateUserCommand
+		var authInfo *login.SetAuthInfoCommand
+		server := setupSCIMServer(t, featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), func(api *SCIMAPI) {
+			api.userService = &usertest.FakeUserService{CreateFn: func(ctx context.Context, cmd *user.CreateUserCommand) (*user.User, error) {
+				created = cmd
+				return &user.User{ID: 2, UID: "jane-uid", Login: cmd.Login, Email: cmd.Email, Name: cmd.Name}, nil
+			}}
+			api.authInfoService = &authinfotest.FakeService{
+				ExpectedError: user.ErrUserNotFound,
+				SetAuthInfoFn: func(ctx context.Context, cmd *login.SetAuthInfoCommand) error {
+					authInfo = cmd
+					return nil
+				},
+			}
+		})
+
+		res, err := server.Send(scimRequest(server, http.MethodPost, "/scim/v2/Users", body, scimServiceAccount()))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusCreated, res.StatusCode)
+		assert.Equal(t, "http://localhost:3000/scim/v2/Users/jane-uid", res.Header.Get("Location"))
+		require.NoError(t, res.Body.Close())
+
+		assert.Equal(t, &user.CreateUserCommand{
+			Login:         "jane",
+			Email:         "jane@example.com",
+			Name:          "Jane Doe",
+			IsProvisioned: true,
+		}, created)
+		assert.Equal(t, &login.SetAuthInfoCommand{
+			UserId:      2,
+			AuthModule:  login.AzureADAuthModule,
+			AuthId:      "ext-1",
+			ExternalUID: "ext-1",
+		}, authInfo)
+	})
+
+	t.Run("should reject existing externalId", func(t *testing.T) {
+		server := setupSCIMServer(t, featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), func(api *SCIMAPI) {
+			api.userService = &usertest.FakeUserService{ExpectedUser: &user.User{ID: 3}}
+			api.authInfoService = &authinfotest.FakeService{ExpectedUserAuth: &login.UserAuth{UserId: 3, AuthId: "ext-1"}}
+		})
+
+		res, err := server.Send(scimRequest(server, http.MethodPost, "/scim/v2/Users", body, scimServiceAccount()))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+
+		var errBody scim.Error
+		decodeResponse(t, res, &errBody)
+		assert.Equal(t, "uniqueness", errBody.ScimType)
+	})
+
+	t.Run("should reject existing user", func(t *testing.T) {
+		server := setupSCIMServer(t, featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), func(api *SCIMAPI) {
+			api.userService = &usertest.FakeUserService{ExpectedError: user.ErrUserAlreadyExists}
+		})
+
+		res, err := server.Send(scimRequest(server, http.MethodPost, "/scim/v2/Users", body, scimServiceAccount()))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+}
+
+func TestSCIMAPI_PatchUser(t *testing.T) {
+	var updated *user.UpdateUserCommand
+	var revoked int64
+	server := setupSCIMServer(t, featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), func(api *SCIMAPI) {
+		api.userService = &usertest.FakeUserService{
+			ExpectedUser: &user.User{ID: 2, UID: "jane-uid", Login: "jane", Email: "jane@example.com", Name: "Jane Doe", IsProvisioned: true},
+			UpdateFn: func(ctx context.Context, cmd *user.UpdateUserCommand) error {
+				updated = cmd
+				return nil
+			},
+		}
+		tokenService := authtest.NewFakeUserAuthTokenService()
+		tokenService.RevokeAllUserTokensProvider = func(ctx context.Context, userID int64) error {
+			revoked = userID
+			return nil
+		}
+		api.userAuthTokenService = tokenService
+	})
+
+	// the identity provider deprovisions a leaver
+	res, err := server.Send(scimRequest(server, http.MethodPatch, "/scim/v2/Users/jane-uid", `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [{"op": "Replace", "path": "active", "value": "False"}]
+	}`, scimServiceAccount()))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.NoError(t, res.Body.Close())
+
+	require.NotNil(t, updated)
+	assert.Equal(t, "jane", updated.Login)
+	assert.Equal(t, "jane@example.com", updated.Email)
+	assert.Equal(t, "Jane Doe", updated.Name)
+	assert.True(t, *updated.IsDisabled)
+	assert.True(t, *updated.IsProvisioned)
+	assert.Equal(t, int64(2), revoked)
+}
+
+func TestSCIMAPI_DeleteUser(t *testing.T) {
+	t.Run("should disable user and revoke its sessions", func(t *testing.T) {
+		var updated *user.UpdateUserCommand
+		var revoked int64
+		server := setupSCIMServer(t, featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), func(api *SCIMAPI) {
+			api.userService = &usertest.FakeUserService{
+				ExpectedUser: &user.User{ID: 2, UID: "jane-uid", Login: "jane", IsProvisioned: true},
+				UpdateFn: func(ctx context.Context, cmd *user.UpdateUserCommand) error {
+					updated = cmd
+					return nil
+				},
+			}
+			tokenService := authtest.NewFakeUserAuthTokenService()
+			tokenService.RevokeAllUserTokensProvider = func(ctx context.Context, userID int64) error {
+				revoked = userID
+				return nil
+			}
+			api.userAuthTokenService = tokenService
+		})
+
+		res, err := server.Send(scimRequest(server, http.MethodDelete, "/scim/v2/Users/jane-uid", "", scimServiceAccount()))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+
+		require.NotNil(t, updated)
+		assert.True(t, *updated.IsDisabled)
+		assert.Equal(t, int64(2), revoked)
+	})
+
+	t.Run("should not find service accounts", func(t *testing.T) {
+		server := setupSCIMServer(t, featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), func(api *SCIMAPI) {
+			api.userService = &usertest.FakeUserService{ExpectedUser: &user.User{ID: 3, UID: "sa-uid", IsServiceAccount: true}}
+		})
+
+		res, err := server.Send(scimRequest(server, http.MethodDelete, "/scim/v2/Users/sa-uid", "", scimServiceAccount()))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+}
+
+func TestSCIMAPI_UnmanagedUsers(t *testing.T) {
+	tests := []struct {
+		name     string
+		usr      *user.User
+		authInfo *login.UserAuth
+		status   int
+	}{
+		{
+			name:     "server admins are not changed",
+			usr:      &user.User{ID: 1, UID: "admin-uid", Login: "admin", Email: "admin@example.com", IsAdmin: true, IsProvisioned: true},
+			authInfo: &login.UserAuth{UserId: 1, AuthId: "ext-admin", ExternalUID: "ext-admin"},
+			status:   http.StatusForbidden,
+		},
+		{
+			name:   "local users are not changed",
+			usr:    &user.User{ID: 2, UID: "jane-uid", Login: "jane", Email: "jane@example.com"},
+			status: http.StatusForbidden,
+		},
+		{
+			name:     "users of the auth module are changed",
+			usr:      &user.User{ID: 2, UID: "jane-uid", Login: "jane", Email: "jane@example.com"},
+			authInfo: &login.UserAuth{UserId: 2, AuthId: "ext-1", ExternalUID: "ext-1"},
+			status:   http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated *user.UpdateUserCommand
+			revoked := false
+			server := setupSCIMServer(t, featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), func(api *SCIMAPI) {
+				api.userService = &usertest.FakeUserService{
+					ExpectedUser: tt.usr,
+					GetByLoginFn: func(ctx context.Context, q *user.GetUserByLoginQuery) (*user.User, error) {
+						return tt.usr, nil
+					},
+					UpdateFn: func(ctx context.Context, cmd *user.UpdateUserCommand) error {
+						updated = cmd
+						return nil
+					},
+				}
+				if tt.authInfo != nil {
+					api.authInfoService = &authinfotest.FakeService{ExpectedUserAuth: tt.authInfo}
+				}
+				tokenService := authtest.NewFakeUserAuthTokenService()
+				tokenService.RevokeAllUserTokensProvider = func(ctx context.Context, userID int64) error {
+					revoked = true
+					return nil
+				}
+				api.userAuthTokenService = tokenService
+			})
+
+			target := "/scim/v2/Users/" + tt.usr.UID
+			res, err := server.Send(scimRequest(server, http.MethodPut, target,
+				`{"userName": "`+tt.usr.Login+`", "emails": [{"value": "attacker@example.com"}], "active": false}`, scimServiceAccount()))
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, res.StatusCode)
+			require.NoError(t, res.Body.Close())
+
+			if tt.status != http.StatusOK {
+				res, err = server.Send(scimRequest(server, http.MethodDelete, target, "", scimServiceAccount()))
+				require.NoError(t, err)
+				assert.Equal(t, tt.status, res.StatusCode)
+
+				var body scim.Error
+				decodeResponse(t, res, &body)
+				assert.Equal(t, "403", body.Status)
+
+				assert.Nil(t, updated)
+				assert.False(t, revoked)
+				return
+			}
+			require.NotNil(t, updated)
+			assert.Equal(t, "attacker@example.com", updated.Email)
+			assert.True(t, *updated.IsProvisioned)
+		})
+	}
+}
+
+func TestSCIMAPI_UsersOfOtherOrgs(t *testing.T) {
+	admin := &user.User{ID: 1, UID: "admin-uid", Login: "admin", Email: "admin@example.com", IsAdmin: true}
+	var updated *user.UpdateUserCommand
+	authInfoChanged := false
+	server := setupSCIMServer(t, featuremgmt.WithFeatures(featuremgmt.FlagEnableSCIM), func(api *SCIMAPI) {
+		// the server admin is only a member of another org
+		api.orgService = &orgtest.FakeOrgService{ExpectedUserOrgDTO: []*org.UserOrgDTO{{OrgID: 2}}}
+		api.userService = &usertest.FakeUserService{
+			ExpectedUser: admin,
+			GetByLoginFn: func(ctx context.Context, q *user.GetUserByLoginQuery) (*user.User, error) {
+				return admin, nil
+			},
+			UpdateFn: func(ctx context.Context, cmd *user.UpdateUserCommand) error {
+				updated = cmd
+				return nil
+			},
+		}
+		api.authInfoService = &authinfotest.FakeService{
+			ExpectedError: user.ErrUserNotFound,
+			SetAuthInfoFn: func(ctx context.Context, cmd *login.SetAuthInfoCommand) error {
+				authInfoChanged = true
+				return nil
+			},
+			UpdateAuthInfoFn: func(ctx context.Context, cmd *login.UpdateAuthInfoCommand) error {
+				authInfoChanged = true
+				return nil
+			},
+		}
+	})
+
+	for _, req := range []struct {
+		method string
+		body   string
+	}{
+		{method: http.MethodGet},
+		{method: http.MethodPut, body: `{"userName": "admin", "externalId": "attacker", "emails": [{"value": "attacker@example.com"}]}`},
+		{method: http.MethodPatch, body: `{"Operations": [{"op": "replace", "path": "externalId", "value": "attacker"}]}`},
+		{method: http.MethodDelete},
+	} {
+		res, err := server.Send(scimRequest(server, req.method, "/scim/v2/Users/admin-uid", req.body, scimServiceAccount()))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode, req.method)
+		require.NoError(t, res.Body.Close())
+	}
+	assert.Nil(t, updated)
+	assert.False(t, authInfoChanged)
+
+	res, err := server.Send(scimRequest(server, http.MethodGet, `/scim/v2/Users?filter=userName+eq+%22admin%22`, "", scimServiceAccount()))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var body scim.ListResponse
+	decodeResponse(t, res, &body)
+	assert.Equal(t, 0, body.TotalResults)
+}
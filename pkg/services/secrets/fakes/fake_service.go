@@ -44,6 +44,10 @@ func (f FakeSecretsService) RotateDataKeys(_ context.Context) error {
 	return nil
 }
 
+func (f FakeSecretsService) ReEncryptDataKeysWithProvider(_ context.Context, _ secrets.ProviderID) error {
+	return nil
+}
+
 func (f FakeSecretsService) ReEncryptDataKeys(_ context.Context) error {
 	return nil
 }
@@ -146,6 +146,20 @@ func (_m *MockService) ReEncryptDataKeys(ctx context.Context) error {
 	return r0
 }
 
+// ReEncryptDataKeysWithProvider provides a mock function with given fields: ctx, providerID
+func (_m *MockService) ReEncryptDataKeysWithProvider(ctx context.Context, providerID secrets.ProviderID) error {
+	ret := _m.Called(ctx, providerID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, secrets.ProviderID) error); ok {
+		r0 = rf(ctx, providerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RotateDataKeys provides a mock function with given fields: ctx
 func (_m *MockService) RotateDataKeys(ctx context.Context) error {
 	ret := _m.Called(ctx)
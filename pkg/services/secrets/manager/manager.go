@@ -477,7 +477,12 @@ func (s *SecretsService) RotateDataKeys(ctx context.Context) error {
 }
 
 func (s *SecretsService) ReEncryptDataKeys(ctx context.Context) error {
-	s.log.Info("Data keys re-encryption triggered")
+	return s.ReEncryptDataKeysWithProvider(ctx, s.currentProviderID)
+}
+
+func (s *SecretsService) ReEncryptDataKeysWithProvider(ctx context.Context, providerID secrets.ProviderID) error {
+	providerID = kmsproviders.NormalizeProviderID(providerID)
+	s.log.Info("Data keys re-encryption triggered", "provider", providerID)
 
 	if s.features.IsEnabled(ctx, featuremgmt.FlagDisableEnvelopeEncryption) {
 		s.log.Info("Envelope encryption is not enabled but trying to init providers anyway...")
@@ -488,7 +493,13 @@ func (s *SecretsService) ReEncryptDataKeys(ctx context.Context) error {
 		}
 	}
 
-	if err := s.store.ReEncryptDataKeys(ctx, s.providers, s.currentProviderID); err != nil {
+	if _, ok := s.providers[providerID]; !ok {
+		err := fmt.Errorf("%w: %s", secrets.ErrProviderNotConfigured, providerID)
+		s.log.Error("Data keys re-encryption failed", "error", err)
+		return err
+	}
+
+	if err := s.store.ReEncryptDataKeys(ctx, s.providers, providerID); err != nil {
 		s.log.Error("Data keys re-encryption failed", "error", err)
 		return err
 	}
@@ -3,6 +3,9 @@ package manager
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +19,7 @@ import (
 	encryptionprovider "github.com/grafana/grafana/pkg/services/encryption/provider"
 	encryptionservice "github.com/grafana/grafana/pkg/services/encryption/service"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/kmsproviders"
 	"github.com/grafana/grafana/pkg/services/kmsproviders/osskmsproviders"
 	"github.com/grafana/grafana/pkg/services/secrets"
 	"github.com/grafana/grafana/pkg/services/secrets/database"
@@ -370,6 +374,79 @@ func TestIntegrationSecretsService_ReEncryptDataKeys(t *testing.T) {
 	})
 }
 
+func TestIntegrationSecretsService_ReEncryptDataKeysWithProvider(t *testing.T) {
+	ctx := context.Background()
+
+	keyFile := filepath.Join(t.TempDir(), "kek.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(strings.Repeat("ab", 32)), 0o600))
+
+	raw, err := ini.Load([]byte(`
+		[security]
+		secret_key = sdDkslslld
+		available_encryption_providers = keyfile.v1
+
+		[security.encryption.keyfile.v1]
+		key_file = ` + keyFile))
+	require.NoError(t, err)
+	cfg := &setting.Cfg{Raw: raw}
+
+	encryptionService, err := encryptionservice.ProvideEncryptionService(tracing.InitializeTracerForTest(), encryptionprovider.Provider{}, &usagestats.UsageStatsMock{}, cfg)
+	require.NoError(t, err)
+
+	features := featuremgmt.WithFeatures()
+	store := database.ProvideSecretsStore(db.InitTestDB(t))
+	newService := func(t *testing.T) *SecretsService {
+		svc, err := ProvideSecretsService(
+			tracing.InitializeTracerForTest(),
+			store,
+			osskmsproviders.ProvideService(encryptionService, cfg, features),
+			encryptionService,
+			cfg,
+			features,
+			&usagestats.UsageStatsMock{T: t},
+		)
+		require.NoError(t, err)
+		return svc
+	}
+
+	svc := newService(t)
+	ciphertext, err := svc.Encrypt(ctx, []byte("grafana"), secrets.WithoutScope())
+	require.NoError(t, err)
+
+	t.Run("data keys are moved to the given provider", func(t *testing.T) {
+		require.NoError(t, svc.ReEncryptDataKeysWithProvider(ctx, "keyfile.v1"))
+
+		dataKeys, err := store.GetAllDataKeys(ctx)
+		require.NoError(t, err)
+		require.Len(t, dataKeys, 1)
+		assert.Equal(t, secrets.ProviderID("keyfile.v1"), dataKeys[0].Provider)
+		assert.True(t, strings.HasSuffix(dataKeys[0].Label, "@keyfile.v1"))
+
+		// Use a fresh service to bypass the data keys cache.
+		plaintext, err := newService(t).Decrypt(ctx, ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("grafana"), plaintext)
+	})
+
+	t.Run("data keys are moved back to the current provider", func(t *testing.T) {
+		require.NoError(t, svc.ReEncryptDataKeys(ctx))
+
+		dataKeys, err := store.GetAllDataKeys(ctx)
+		require.NoError(t, err)
+		require.Len(t, dataKeys, 1)
+		assert.Equal(t, secrets.ProviderID(kmsproviders.Default), dataKeys[0].Provider)
+
+		plaintext, err := newService(t).Decrypt(ctx, ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("grafana"), plaintext)
+	})
+
+	t.Run("providers that are not configured are rejected", func(t *testing.T) {
+		err := svc.ReEncryptDataKeysWithProvider(ctx, "keyfile.v2")
+		require.ErrorIs(t, err, secrets.ErrProviderNotConfigured)
+	})
+}
+
 func TestIntegrationSecretsService_Decrypt(t *testing.T) {
 	ctx := context.Background()
 	testDB := db.InitTestDB(t)
@@ -32,6 +32,9 @@ type Service interface {
 
 	RotateDataKeys(ctx context.Context) error
 	ReEncryptDataKeys(ctx context.Context) error
+	// ReEncryptDataKeysWithProvider re-encrypts the data keys with the given provider instead of the current one.
+	// Used to move data keys between providers, the given provider must be configured.
+	ReEncryptDataKeysWithProvider(ctx context.Context, providerID ProviderID) error
 }
 
 // Store defines methods to interact with secrets storage
@@ -5,7 +5,10 @@ import (
 	"time"
 )
 
-var ErrDataKeyNotFound = errors.New("data key not found")
+var (
+	ErrDataKeyNotFound       = errors.New("data key not found")
+	ErrProviderNotConfigured = errors.New("encryption provider not configured")
+)
 
 type DataKey struct {
 	Active        bool
@@ -155,4 +155,16 @@ func (oss *OSSMigrations) AddMigration(mg *Migrator) {
 	ualert.DropTitleUniqueIndexMigration(mg)
 
 	ualert.AddStateFiredAtColumn(mg)
+
+	ualert.AddNotificationHistoryTable(mg)
+
+	ualert.AddStateHistoryTable(mg)
+
+	addLivePipelineMigrations(mg)
+
+	addScheduledExportMigrations(mg)
+
+	addTeamSyncMigrations(mg)
+	addTwoFactorMigrations(mg)
+	addWebAuthnMigrations(mg)
 }
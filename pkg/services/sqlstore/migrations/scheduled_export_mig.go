@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	. "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+)
+
+func addScheduledExportMigrations(mg *Migrator) {
+	scheduledExportV1 := Table{
+		Name: "scheduled_export",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, Nullable: false, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: DB_BigInt, Nullable: false},
+			{Name: "uid", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "name", Type: DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "dashboard_uid", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "panel_ids", Type: DB_Text, Nullable: true},
+			{Name: "schedule", Type: DB_NVarchar, Length: 100, Nullable: false},
+			{Name: "timezone", Type: DB_NVarchar, Length: 50, Nullable: false},
+			{Name: "time_from", Type: DB_NVarchar, Length: 100, Nullable: false},
+			{Name: "time_to", Type: DB_NVarchar, Length: 100, Nullable: false},
+			{Name: "format", Type: DB_NVarchar, Length: 20, Nullable: false},
+			{Name: "recipients", Type: DB_Text, Nullable: false},
+			{Name: "enabled", Type: DB_Bool, Nullable: false},
+			{Name: "user_id", Type: DB_BigInt, Nullable: false},
+			{Name: "next_run", Type: DB_BigInt, Nullable: false},
+			{Name: "created", Type: DB_DateTime, Nullable: false},
+			{Name: "updated", Type: DB_DateTime, Nullable: false},
+		},
+		Indices: []*Index{
+			{Cols: []string{"org_id", "uid"}, Type: UniqueIndex},
+			{Cols: []string{"next_run"}},
+		},
+	}
+
+	mg.AddMigration("create scheduled_export table v1", NewAddTableMigration(scheduledExportV1))
+	mg.AddMigration("add unique index scheduled_export.org_id-uid", NewAddIndexMigration(scheduledExportV1, scheduledExportV1.Indices[0]))
+	mg.AddMigration("add index scheduled_export.next_run", NewAddIndexMigration(scheduledExportV1, scheduledExportV1.Indices[1]))
+
+	scheduledExportRunV1 := Table{
+		Name: "scheduled_export_run",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, Nullable: false, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: DB_BigInt, Nullable: false},
+			{Name: "export_uid", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "state", Type: DB_NVarchar, Length: 20, Nullable: false},
+			{Name: "error", Type: DB_Text, Nullable: true},
+			{Name: "manual", Type: DB_Bool, Nullable: false},
+			{Name: "files", Type: DB_Int, Nullable: false},
+			{Name: "started", Type: DB_DateTime, Nullable: false},
+			{Name: "finished", Type: DB_DateTime, Nullable: true},
+		},
+		Indices: []*Index{
+			{Cols: []string{"org_id", "export_uid", "started"}},
+		},
+	}
+
+	mg.AddMigration("create scheduled_export_run table v1", NewAddTableMigration(scheduledExportRunV1))
+	mg.AddMigration("add index scheduled_export_run.org_id-export_uid-started", NewAddIndexMigration(scheduledExportRunV1, scheduledExportRunV1.Indices[0]))
+}
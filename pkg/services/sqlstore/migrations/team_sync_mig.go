@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	. "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+)
+
+func addTeamSyncMigrations(mg *Migrator) {
+	teamGroupV1 := Table{
+		Name: "team_group",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, Nullable: false, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: DB_BigInt, Nullable: false},
+			{Name: "team_id", Type: DB_BigInt, Nullable: false},
+			{Name: "group_id", Type: DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "created", Type: DB_DateTime, Nullable: false},
+			{Name: "updated", Type: DB_DateTime, Nullable: false},
+		},
+		Indices: []*Index{
+			{Cols: []string{"org_id", "team_id", "group_id"}, Type: UniqueIndex},
+			{Cols: []string{"group_id"}},
+		},
+	}
+
+	mg.AddMigration("create team_group table v1", NewAddTableMigration(teamGroupV1))
+	mg.AddMigration("add unique index team_group.org_id-team_id-group_id", NewAddIndexMigration(teamGroupV1, teamGroupV1.Indices[0]))
+	mg.AddMigration("add index team_group.group_id", NewAddIndexMigration(teamGroupV1, teamGroupV1.Indices[1]))
+
+	teamSyncAuditV1 := Table{
+		Name: "team_sync_audit",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, Nullable: false, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: DB_BigInt, Nullable: false},
+			{Name: "team_id", Type: DB_BigInt, Nullable: false},
+			{Name: "action", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "source", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "group_id", Type: DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "user_id", Type: DB_BigInt, Nullable: false},
+			{Name: "actor_id", Type: DB_BigInt, Nullable: false},
+			{Name: "created", Type: DB_DateTime, Nullable: false},
+		},
+		Indices: []*Index{
+			{Cols: []string{"org_id", "team_id", "created"}},
+		},
+	}
+
+	mg.AddMigration("create team_sync_audit table v1", NewAddTableMigration(teamSyncAuditV1))
+	mg.AddMigration("add index team_sync_audit.org_id-team_id-created", NewAddIndexMigration(teamSyncAuditV1, teamSyncAuditV1.Indices[0]))
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	. "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+)
+
+func addTwoFactorMigrations(mg *Migrator) {
+	userTwoFactorV1 := Table{
+		Name: "user_two_factor",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, Nullable: false, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "user_id", Type: DB_BigInt, Nullable: false},
+			{Name: "secret", Type: DB_Blob, Nullable: false},
+			{Name: "recovery_codes", Type: DB_Blob, Nullable: true},
+			{Name: "enabled", Type: DB_Bool, Nullable: false},
+			{Name: "last_used_step", Type: DB_BigInt, Nullable: false},
+			{Name: "created", Type: DB_DateTime, Nullable: false},
+			{Name: "updated", Type: DB_DateTime, Nullable: false},
+		},
+		Indices: []*Index{
+			{Cols: []string{"user_id"}, Type: UniqueIndex},
+		},
+	}
+
+	mg.AddMigration("create user_two_factor table v1", NewAddTableMigration(userTwoFactorV1))
+	mg.AddMigration("add unique index user_two_factor.user_id", NewAddIndexMigration(userTwoFactorV1, userTwoFactorV1.Indices[0]))
+
+	orgTwoFactorSettingV1 := Table{
+		Name: "org_two_factor_setting",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, Nullable: false, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: DB_BigInt, Nullable: false},
+			{Name: "required", Type: DB_Bool, Nullable: false},
+			{Name: "updated", Type: DB_DateTime, Nullable: false},
+		},
+		Indices: []*Index{
+			{Cols: []string{"org_id"}, Type: UniqueIndex},
+		},
+	}
+
+	mg.AddMigration("create org_two_factor_setting table v1", NewAddTableMigration(orgTwoFactorSettingV1))
+	mg.AddMigration("add unique index org_two_factor_setting.org_id", NewAddIndexMigration(orgTwoFactorSettingV1, orgTwoFactorSettingV1.Indices[0]))
+}
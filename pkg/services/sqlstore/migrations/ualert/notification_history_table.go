@@ -0,0 +1,48 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddNotificationHistoryTable creates the table that stores the notification attempts of the Grafana Alertmanager integrations.
+func AddNotificationHistoryTable(mg *migrator.Migrator) {
+	notificationHistoryTable := migrator.Table{
+		Name: "alert_notification_history",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "dedup_key", Type: migrator.DB_NVarchar, Length: 64, Nullable: false},
+			{Name: "receiver", Type: migrator.DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "group_key", Type: migrator.DB_Text, Nullable: false},
+			{Name: "status", Type: migrator.DB_NVarchar, Length: 20, Nullable: false},
+			{Name: "error", Type: migrator.DB_Text, Nullable: true},
+			{Name: "response_code", Type: migrator.DB_Int, Nullable: false},
+			{Name: "attempt", Type: migrator.DB_Int, Nullable: false},
+			{Name: "duration", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "rule_uids", Type: migrator.DB_Text, Nullable: true},
+			{Name: "alerts", Type: migrator.DB_Int, Nullable: false},
+			{Name: "payload_size", Type: migrator.DB_Int, Nullable: false},
+			{Name: "timestamp", Type: migrator.DB_DateTime, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "timestamp"}, Type: migrator.IndexType},
+			{Cols: []string{"org_id", "receiver"}, Type: migrator.IndexType},
+			{Cols: []string{"org_id", "dedup_key"}, Type: migrator.UniqueIndex},
+		},
+	}
+
+	mg.AddMigration(
+		"add alert_notification_history table",
+		migrator.NewAddTableMigration(notificationHistoryTable),
+	)
+	mg.AddMigration(
+		"add index to alert_notification_history on org_id and timestamp columns",
+		migrator.NewAddIndexMigration(notificationHistoryTable, notificationHistoryTable.Indices[0]),
+	)
+	mg.AddMigration(
+		"add index to alert_notification_history on org_id and receiver columns",
+		migrator.NewAddIndexMigration(notificationHistoryTable, notificationHistoryTable.Indices[1]),
+	)
+	mg.AddMigration(
+		"add unique index to alert_notification_history on org_id and dedup_key columns",
+		migrator.NewAddIndexMigration(notificationHistoryTable, notificationHistoryTable.Indices[2]),
+	)
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	. "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+)
+
+func addWebAuthnMigrations(mg *Migrator) {
+	userWebAuthnCredentialV1 := Table{
+		Name: "user_webauthn_credential",
+		Columns: []*Column{
+			{Name: "id", Type: DB_BigInt, Nullable: false, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "uid", Type: DB_NVarchar, Length: 40, Nullable: false},
+			{Name: "user_id", Type: DB_BigInt, Nullable: false},
+			{Name: "name", Type: DB_NVarchar, Length: 190, Nullable: false},
+			{Name: "credential_id", Type: DB_Text, Nullable: false},
+			{Name: "credential_id_hash", Type: DB_NVarchar, Length: 64, Nullable: false},
+			{Name: "public_key", Type: DB_Blob, Nullable: false},
+			{Name: "sign_count", Type: DB_BigInt, Nullable: false},
+			{Name: "aaguid", Type: DB_NVarchar, Length: 36, Nullable: false},
+			{Name: "transports", Type: DB_NVarchar, Length: 255, Nullable: false},
+			{Name: "created", Type: DB_DateTime, Nullable: false},
+			{Name: "last_used", Type: DB_DateTime, Nullable: true},
+		},
+		Indices: []*Index{
+			{Cols: []string{"uid"}, Type: UniqueIndex},
+			{Cols: []string{"credential_id_hash"}, Type: UniqueIndex},
+			{Cols: []string{"user_id"}},
+		},
+	}
+
+	mg.AddMigration("create user_webauthn_credential table v1", NewAddTableMigration(userWebAuthnCredentialV1))
+	mg.AddMigration("add unique index user_webauthn_credential.uid", NewAddIndexMigration(userWebAuthnCredentialV1, userWebAuthnCredentialV1.Indices[0]))
+	mg.AddMigration("add unique index user_webauthn_credential.credential_id_hash", NewAddIndexMigration(userWebAuthnCredentialV1, userWebAuthnCredentialV1.Indices[1]))
+	mg.AddMigration("add index user_webauthn_credential.user_id", NewAddIndexMigration(userWebAuthnCredentialV1, userWebAuthnCredentialV1.Indices[2]))
+}
@@ -0,0 +1,93 @@
+package teamsync
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/middleware"
+	"github.com/grafana/grafana/pkg/middleware/requestmeta"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/team"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func (s *Service) registerAPIEndpoints() {
+	authorize := accesscontrol.Middleware(s.accessControl)
+	teamResolver := team.MiddlewareTeamUIDResolver(s.teamService, ":teamId")
+	readGroups := accesscontrol.EvalPermission(accesscontrol.ActionTeamsPermissionsRead, accesscontrol.ScopeTeamsID)
+	writeGroups := accesscontrol.EvalPermission(accesscontrol.ActionTeamsPermissionsWrite, accesscontrol.ScopeTeamsID)
+
+	s.routeRegister.Group("/api/teams/:teamId/groups", func(r routing.RouteRegister) {
+		r.Get("/", teamResolver, authorize(readGroups), routing.Wrap(s.getGroupsHandler))
+		r.Post("/", teamResolver, authorize(writeGroups), routing.Wrap(s.addGroupHandler))
+		// the group ID is passed as a query parameter, because group IDs such
+		// as LDAP distinguished names contain characters that are escaped in paths
+		r.Delete("/", teamResolver, authorize(writeGroups), routing.Wrap(s.removeGroupHandler))
+		r.Get("/audit", teamResolver, authorize(readGroups), routing.Wrap(s.auditLogHandler))
+	}, middleware.ReqSignedIn, requestmeta.SetOwner(requestmeta.TeamAuth))
+}
+
+func (s *Service) getGroupsHandler(c *contextmodel.ReqContext) response.Response {
+	teamID, err := strconv.ParseInt(web.Params(c.Req)[":teamId"], 10, 64)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "teamId is invalid", err)
+	}
+	groups, err := s.GetGroups(c.Req.Context(), c.GetOrgID(), teamID)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to get team groups", err)
+	}
+	return response.JSON(http.StatusOK, groups)
+}
+
+func (s *Service) addGroupHandler(c *contextmodel.ReqContext) response.Response {
+	cmd := AddTeamGroupCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	teamID, err := strconv.ParseInt(web.Params(c.Req)[":teamId"], 10, 64)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "teamId is invalid", err)
+	}
+	userID, _ := c.SignedInUser.GetInternalID()
+	if err := s.AddGroup(c.Req.Context(), c.GetOrgID(), teamID, cmd.GroupID, AuditSourceAPI, userID); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to add group to team", err)
+	}
+	return response.Success("Group added to Team")
+}
+
+func (s *Service) removeGroupHandler(c *contextmodel.ReqContext) response.Response {
+	teamID, err := strconv.ParseInt(web.Params(c.Req)[":teamId"], 10, 64)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "teamId is invalid", err)
+	}
+	groupID := c.Query("groupId")
+	if groupID == "" {
+		return response.Error(http.StatusBadRequest, "groupId is required", nil)
+	}
+	userID, _ := c.SignedInUser.GetInternalID()
+	if err := s.RemoveGroup(c.Req.Context(), c.GetOrgID(), teamID, groupID, AuditSourceAPI, userID); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to remove group from team", err)
+	}
+	return response.Success("Team Group removed")
+}
+
+// auditLogHandler returns the team sync changes of a team, latest change first.
+func (s *Service) auditLogHandler(c *contextmodel.ReqContext) response.Response {
+	teamID, err := strconv.ParseInt(web.Params(c.Req)[":teamId"], 10, 64)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "teamId is invalid", err)
+	}
+	entries, err := s.GetAuditLog(c.Req.Context(), GetAuditLogQuery{
+		OrgID:  c.GetOrgID(),
+		TeamID: teamID,
+		Action: AuditAction(c.Query("action")),
+		Limit:  c.QueryInt("limit"),
+	})
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to get team sync audit log", err)
+	}
+	return response.JSON(http.StatusOK, entries)
+}
@@ -0,0 +1,100 @@
+package teamsync
+
+import (
+	"time"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+)
+
+var (
+	ErrGroupNotFound      = errutil.NotFound("teamsync.group-not-found", errutil.WithPublicMessage("Group is not mapped to the team"))
+	ErrGroupAlreadyAdded  = errutil.Conflict("teamsync.group-already-added", errutil.WithPublicMessage("Group is already mapped to the team"))
+	ErrMissingGroupID     = errutil.ValidationFailed("teamsync.missing-group-id", errutil.WithPublicMessage("Group ID is required"))
+	ErrGroupIDTooLong     = errutil.ValidationFailed("teamsync.group-id-too-long", errutil.WithPublicMessage("Group ID must be at most 190 characters"))
+	ErrTeamNotFound       = errutil.NotFound("teamsync.team-not-found", errutil.WithPublicMessage("Team not found"))
+	ErrTeamIsProvisioned  = errutil.BadRequest("teamsync.team-provisioned", errutil.WithPublicMessage("Groups cannot be mapped to provisioned teams"))
+	ErrInvalidAuditFilter = errutil.ValidationFailed("teamsync.invalid-audit-filter", errutil.WithPublicMessage("Invalid audit log filter"))
+)
+
+// maxGroupIDLength is the length of the group_id column.
+const maxGroupIDLength = 190
+
+// TeamGroup maps the ID of a group of an external identity provider, such as an
+// LDAP group DN or the value of an OAuth groups claim, to a team. Users in the
+// group are added to the team when they sign in.
+type TeamGroup struct {
+	ID      int64     `json:"-" xorm:"pk autoincr 'id'"`
+	OrgID   int64     `json:"orgId" xorm:"org_id"`
+	TeamID  int64     `json:"teamId" xorm:"team_id"`
+	GroupID string    `json:"groupId" xorm:"group_id"`
+	Created time.Time `json:"created"`
+	Updated time.Time `json:"updated"`
+}
+
+func (TeamGroup) TableName() string {
+	return "team_group"
+}
+
+// AuditAction is the change recorded by an audit log entry.
+type AuditAction string
+
+const (
+	AuditActionMemberAdded   AuditAction = "member_added"
+	AuditActionMemberRemoved AuditAction = "member_removed"
+	AuditActionGroupAdded    AuditAction = "group_added"
+	AuditActionGroupRemoved  AuditAction = "group_removed"
+)
+
+// AuditSource is what triggered the change recorded by an audit log entry.
+type AuditSource string
+
+const (
+	AuditSourceLogin        AuditSource = "login"
+	AuditSourceAPI          AuditSource = "api"
+	AuditSourceProvisioning AuditSource = "provisioning"
+)
+
+// AuditEntry records a change of a team made by team sync: a group mapping
+// that was added or removed, or a member that was added or removed on login.
+type AuditEntry struct {
+	ID      int64       `json:"id" xorm:"pk autoincr 'id'"`
+	OrgID   int64       `json:"orgId" xorm:"org_id"`
+	TeamID  int64       `json:"teamId" xorm:"team_id"`
+	Action  AuditAction `json:"action"`
+	Source  AuditSource `json:"source"`
+	GroupID string      `json:"groupId" xorm:"group_id"`
+	// UserID is the member that was added or removed. It is 0 for group changes.
+	UserID int64 `json:"userId" xorm:"user_id"`
+	// ActorID is the user that changed a group mapping through the API. It is 0
+	// for changes made on login or by provisioning.
+	ActorID int64     `json:"actorId" xorm:"actor_id"`
+	Created time.Time `json:"created"`
+}
+
+func (AuditEntry) TableName() string {
+	return "team_sync_audit"
+}
+
+// AddTeamGroupCommand is the body of a request that maps a group to a team.
+type AddTeamGroupCommand struct {
+	GroupID string `json:"groupId"`
+}
+
+// GetAuditLogQuery filters the audit log of a team, latest entry first.
+type GetAuditLogQuery struct {
+	OrgID  int64
+	TeamID int64
+	// Action only returns entries of the given action when set.
+	Action AuditAction
+	Limit  int
+}
+
+// defaultAuditLogLimit is the number of audit log entries returned when no
+// limit is requested, and the maximum limit.
+const defaultAuditLogLimit = 100
+
+// membership is a team of the organization of a user.
+type membership struct {
+	orgID  int64
+	teamID int64
+}
@@ -0,0 +1,272 @@
+package teamsync
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	claims "github.com/grafana/authlib/types"
+
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/authn"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/team"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// syncHookPriority runs team sync after the user and its organization roles
+// are synced, so that users are only added to teams of organizations they are
+// a member of.
+const syncHookPriority = 50
+
+// Service maps groups of external identity providers to teams, and syncs the
+// team memberships of users from their groups every time they sign in.
+// Memberships added by team sync are marked as external, and only those are
+// removed when the user is no longer in a mapped group. Every change is
+// recorded in the audit log of the team.
+type Service struct {
+	cfg             *setting.Cfg
+	store           *store
+	log             log.Logger
+	routeRegister   routing.RouteRegister
+	accessControl   accesscontrol.AccessControl
+	teamService     team.Service
+	teamPermissions accesscontrol.TeamPermissionsService
+	orgService      org.Service
+	now             func() time.Time
+}
+
+func ProvideService(
+	cfg *setting.Cfg, sqlStore db.DB, routeRegister routing.RouteRegister,
+	accessControl accesscontrol.AccessControl, authnService authn.Service, teamService team.Service,
+	teamPermissions accesscontrol.TeamPermissionsService, orgService org.Service,
+) *Service {
+	s := &Service{
+		cfg:             cfg,
+		store:           &store{db: sqlStore},
+		log:             log.New("teamsync"),
+		routeRegister:   routeRegister,
+		accessControl:   accessControl,
+		teamService:     teamService,
+		teamPermissions: teamPermissions,
+		orgService:      orgService,
+		now:             time.Now,
+	}
+
+	teamService.RegisterDelete("DELETE FROM team_group WHERE org_id = ? AND team_id = ?")
+
+	if !cfg.TeamSync.Enabled {
+		return s
+	}
+
+	authnService.RegisterPostAuthHook(s.SyncTeamsHook, syncHookPriority)
+	s.registerAPIEndpoints()
+	return s
+}
+
+// Enabled returns whether team memberships are synced on sign in.
+func (s *Service) Enabled() bool {
+	return s.cfg.TeamSync.Enabled
+}
+
+// SyncTeamsHook syncs the team memberships of a user from the groups provided
+// by the identity provider the user signed in with.
+func (s *Service) SyncTeamsHook(ctx context.Context, id *authn.Identity, _ *authn.Request) error {
+	if !id.ClientParams.SyncTeams {
+		return nil
+	}
+
+	ctxLogger := s.log.FromContext(ctx).New("id", id.ID, "login", id.Login)
+	if !id.IsIdentityType(claims.TypeUser) {
+		return nil
+	}
+
+	userID, err := id.GetInternalID()
+	if err != nil {
+		ctxLogger.Warn("Failed to sync teams, invalid ID for identity", "type", id.GetIdentityType(), "err", err)
+		return nil
+	}
+
+	// failing to sync teams should not prevent the user from signing in,
+	// the memberships are synced again on the next sign in
+	if err := s.SyncUserTeams(ctx, userID, id.Groups); err != nil {
+		ctxLogger.Error("Failed to sync teams", "groups", id.Groups, "error", err)
+	}
+	return nil
+}
+
+// SyncUserTeams adds the user to the teams its groups are mapped to, in the
+// organizations the user is a member of, and removes the user from the teams
+// it was added to by a previous sync which none of its groups are mapped to.
+// Memberships that were added manually are never changed.
+func (s *Service) SyncUserTeams(ctx context.Context, userID int64, groups []string) error {
+	orgs, err := s.orgService.GetUserOrgList(ctx, &org.GetUserOrgListQuery{UserID: userID})
+	if err != nil {
+		return err
+	}
+	userOrgs := make(map[int64]bool, len(orgs))
+	for _, o := range orgs {
+		userOrgs[o.OrgID] = true
+	}
+
+	wanted, err := s.store.teamsByGroups(ctx, groups)
+	if err != nil {
+		return err
+	}
+	current, err := s.store.memberships(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var entries []*AuditEntry
+	var errs []error
+	for m, groupID := range wanted {
+		if _, isMember := current[m]; isMember || !userOrgs[m.orgID] {
+			continue
+		}
+		if err := s.setMember(ctx, m, userID, team.PermissionTypeMember.String()); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		s.log.Info("Added user to team", "userId", userID, "orgId", m.orgID, "teamId", m.teamID, "group", groupID)
+		entries = append(entries, s.auditEntry(m, AuditActionMemberAdded, AuditSourceLogin, groupID, userID, 0))
+	}
+
+	for m, external := range current {
+		if _, ok := wanted[m]; ok || !external {
+			continue
+		}
+		if err := s.setMember(ctx, m, userID, ""); err != nil && !errors.Is(err, team.ErrTeamMemberNotFound) {
+			errs = append(errs, err)
+			continue
+		}
+		s.log.Info("Removed user from team", "userId", userID, "orgId", m.orgID, "teamId", m.teamID)
+		entries = append(entries, s.auditEntry(m, AuditActionMemberRemoved, AuditSourceLogin, "", userID, 0))
+	}
+
+	if err := s.store.insertAudit(ctx, entries...); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// setMember adds or removes an external member through the team permissions
+// service, which also grants or revokes the permissions of team members.
+func (s *Service) setMember(ctx context.Context, m membership, userID int64, permission string) error {
+	_, err := s.teamPermissions.SetUserPermission(ctx, m.orgID, accesscontrol.User{ID: userID, IsExternal: true}, strconv.FormatInt(m.teamID, 10), permission)
+	return err
+}
+
+// GetGroups returns the groups mapped to a team.
+func (s *Service) GetGroups(ctx context.Context, orgID, teamID int64) ([]*TeamGroup, error) {
+	return s.store.groups(ctx, orgID, teamID)
+}
+
+// AddGroup maps a group to a team. actorID is the user making the change, or
+// 0 when the change is made by provisioning.
+func (s *Service) AddGroup(ctx context.Context, orgID, teamID int64, groupID string, source AuditSource, actorID int64) error {
+	groupID = strings.TrimSpace(groupID)
+	if err := validateGroupID(groupID); err != nil {
+		return err
+	}
+	if err := s.validateTeam(ctx, orgID, teamID); err != nil {
+		return err
+	}
+
+	now := s.now()
+	group := &TeamGroup{OrgID: orgID, TeamID: teamID, GroupID: groupID, Created: now, Updated: now}
+	entry := s.auditEntry(membership{orgID: orgID, teamID: teamID}, AuditActionGroupAdded, source, groupID, 0, actorID)
+	return s.store.addGroup(ctx, group, entry)
+}
+
+// RemoveGroup removes a group from a team. Members that were added because of
+// the group are removed when they sign in next.
+func (s *Service) RemoveGroup(ctx context.Context, orgID, teamID int64, groupID string, source AuditSource, actorID int64) error {
+	entry := s.auditEntry(membership{orgID: orgID, teamID: teamID}, AuditActionGroupRemoved, source, groupID, 0, actorID)
+	return s.store.removeGroup(ctx, orgID, teamID, groupID, entry)
+}
+
+// SetGroups replaces the groups mapped to a team. It is used by provisioning.
+func (s *Service) SetGroups(ctx context.Context, orgID, teamID int64, groupIDs []string) error {
+	existing, err := s.store.groups(ctx, orgID, teamID)
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]bool, len(groupIDs))
+	for _, groupID := range groupIDs {
+		wanted[strings.TrimSpace(groupID)] = true
+	}
+
+	for _, group := range existing {
+		if wanted[group.GroupID] {
+			delete(wanted, group.GroupID)
+			continue
+		}
+		if err := s.RemoveGroup(ctx, orgID, teamID, group.GroupID, AuditSourceProvisioning, 0); err != nil {
+			return err
+		}
+	}
+
+	for groupID := range wanted {
+		if err := s.AddGroup(ctx, orgID, teamID, groupID, AuditSourceProvisioning, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAuditLog returns the audit log of a team, latest entry first.
+func (s *Service) GetAuditLog(ctx context.Context, query GetAuditLogQuery) ([]*AuditEntry, error) {
+	switch query.Action {
+	case "", AuditActionMemberAdded, AuditActionMemberRemoved, AuditActionGroupAdded, AuditActionGroupRemoved:
+	default:
+		return nil, ErrInvalidAuditFilter.Errorf("unknown action %q", query.Action)
+	}
+	if query.Limit <= 0 || query.Limit > defaultAuditLogLimit {
+		query.Limit = defaultAuditLogLimit
+	}
+	return s.store.auditLog(ctx, query)
+}
+
+func (s *Service) validateTeam(ctx context.Context, orgID, teamID int64) error {
+	t, err := s.teamService.GetTeamByID(ctx, &team.GetTeamByIDQuery{OrgID: orgID, ID: teamID})
+	if err != nil {
+		if errors.Is(err, team.ErrTeamNotFound) {
+			return ErrTeamNotFound.Errorf("team %d not found", teamID)
+		}
+		return err
+	}
+	if t.IsProvisioned {
+		return ErrTeamIsProvisioned.Errorf("team %d is provisioned", teamID)
+	}
+	return nil
+}
+
+func (s *Service) auditEntry(m membership, action AuditAction, source AuditSource, groupID string, userID, actorID int64) *AuditEntry {
+	return &AuditEntry{
+		OrgID:   m.orgID,
+		TeamID:  m.teamID,
+		Action:  action,
+		Source:  source,
+		GroupID: groupID,
+		UserID:  userID,
+		ActorID: actorID,
+		Created: s.now(),
+	}
+}
+
+func validateGroupID(groupID string) error {
+	if groupID == "" {
+		return ErrMissingGroupID.Errorf("group ID is empty")
+	}
+	if len(groupID) > maxGroupIDLength {
+		return ErrGroupIDTooLong.Errorf("group ID is %d characters long", len(groupID))
+	}
+	return nil
+}
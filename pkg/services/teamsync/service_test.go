@@ -0,0 +1,126 @@
+package teamsync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/authn"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/org/orgtest"
+	"github.com/grafana/grafana/pkg/services/team"
+	"github.com/grafana/grafana/pkg/services/team/teamtest"
+)
+
+func TestIntegrationSyncUserTeams(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	sqlStore := db.InitTestDB(t)
+	now := time.Unix(1700000000, 0)
+	permissions := &fakeTeamPermissions{}
+	s := &Service{
+		store:           &store{db: sqlStore},
+		log:             log.NewNopLogger(),
+		teamService:     &teamtest.FakeService{ExpectedTeamDTO: &team.TeamDTO{ID: 1, OrgID: 1}},
+		teamPermissions: permissions,
+		orgService:      &orgtest.FakeOrgService{ExpectedUserOrgDTO: []*org.UserOrgDTO{{OrgID: 1}}},
+		now:             func() time.Time { return now },
+	}
+
+	require.NoError(t, s.AddGroup(ctx, 1, 1, " admins ", AuditSourceAPI, 5))
+	require.NoError(t, s.AddGroup(ctx, 1, 2, "admins", AuditSourceAPI, 5))
+	// the user is not a member of organization 2
+	require.NoError(t, s.AddGroup(ctx, 2, 5, "admins", AuditSourceAPI, 5))
+
+	err := sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Insert(
+			// added by a previous sync, but the user is no longer in the group
+			&team.TeamMember{OrgID: 1, TeamID: 3, UserID: 10, External: true, Created: now, Updated: now},
+			// added manually
+			&team.TeamMember{OrgID: 1, TeamID: 4, UserID: 10, Created: now, Updated: now},
+			// added manually to a mapped team
+			&team.TeamMember{OrgID: 1, TeamID: 2, UserID: 10, Permission: team.PermissionTypeAdmin, Created: now, Updated: now},
+		)
+		return err
+	})
+	require.NoError(t, err)
+
+	hook := s.SyncTeamsHook
+	err = hook(ctx, &authn.Identity{ID: "10", Type: "user", Groups: []string{"Admins"}, ClientParams: authn.ClientParams{SyncTeams: true}}, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, []permissionCall{
+		{orgID: 1, userID: 10, teamID: "1", permission: "Member"},
+		{orgID: 1, userID: 10, teamID: "3", permission: ""},
+	}, permissions.calls)
+
+	entries, err := s.GetAuditLog(ctx, GetAuditLogQuery{OrgID: 1, TeamID: 1, Action: AuditActionMemberAdded})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, AuditSourceLogin, entries[0].Source)
+	require.Equal(t, "admins", entries[0].GroupID)
+	require.Equal(t, int64(10), entries[0].UserID)
+
+	entries, err = s.GetAuditLog(ctx, GetAuditLogQuery{OrgID: 1, TeamID: 3})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, AuditActionMemberRemoved, entries[0].Action)
+
+	t.Run("identities without team sync are skipped", func(t *testing.T) {
+		permissions.calls = nil
+		err := hook(ctx, &authn.Identity{ID: "10", Type: "user"}, nil)
+		require.NoError(t, err)
+		require.Empty(t, permissions.calls)
+	})
+
+	t.Run("groups replaced by provisioning are audited", func(t *testing.T) {
+		require.NoError(t, s.SetGroups(ctx, 1, 1, []string{"editors"}))
+
+		groups, err := s.GetGroups(ctx, 1, 1)
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		require.Equal(t, "editors", groups[0].GroupID)
+
+		entries, err := s.GetAuditLog(ctx, GetAuditLogQuery{OrgID: 1, TeamID: 1, Action: AuditActionGroupRemoved})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		require.Equal(t, AuditSourceProvisioning, entries[0].Source)
+	})
+
+	t.Run("invalid groups and filters are rejected", func(t *testing.T) {
+		require.ErrorIs(t, s.AddGroup(ctx, 1, 1, " ", AuditSourceAPI, 5), ErrMissingGroupID)
+		require.ErrorIs(t, s.AddGroup(ctx, 1, 1, "editors", AuditSourceAPI, 5), ErrGroupAlreadyAdded)
+
+		_, err := s.GetAuditLog(ctx, GetAuditLogQuery{OrgID: 1, TeamID: 1, Action: "deleted"})
+		require.ErrorIs(t, err, ErrInvalidAuditFilter)
+	})
+}
+
+type permissionCall struct {
+	orgID      int64
+	userID     int64
+	teamID     string
+	permission string
+}
+
+type fakeTeamPermissions struct {
+	accesscontrol.TeamPermissionsService
+	calls []permissionCall
+}
+
+func (f *fakeTeamPermissions) SetUserPermission(_ context.Context, orgID int64, user accesscontrol.User, resourceID, permission string) (*accesscontrol.ResourcePermission, error) {
+	if !user.IsExternal {
+		return nil, errors.New("team sync memberships must be external")
+	}
+	f.calls = append(f.calls, permissionCall{orgID: orgID, userID: user.ID, teamID: resourceID, permission: permission})
+	return &accesscontrol.ResourcePermission{}, nil
+}
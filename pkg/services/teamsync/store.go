@@ -0,0 +1,151 @@
+package teamsync
+
+import (
+	"context"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+)
+
+type store struct {
+	db db.DB
+}
+
+// groups returns the groups mapped to a team.
+func (s *store) groups(ctx context.Context, orgID, teamID int64) ([]*TeamGroup, error) {
+	groups := make([]*TeamGroup, 0)
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Where("org_id = ? AND team_id = ?", orgID, teamID).Asc("group_id").Find(&groups)
+	})
+	return groups, err
+}
+
+// addGroup maps a group to a team and records the change in the audit log.
+func (s *store) addGroup(ctx context.Context, group *TeamGroup, entry *AuditEntry) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context) error {
+		return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+			exists, err := sess.Where("org_id = ? AND team_id = ? AND group_id = ?", group.OrgID, group.TeamID, group.GroupID).Exist(&TeamGroup{})
+			if err != nil {
+				return err
+			}
+			if exists {
+				return ErrGroupAlreadyAdded.Errorf("group %s is already mapped to team %d", group.GroupID, group.TeamID)
+			}
+			if _, err := sess.Insert(group); err != nil {
+				return err
+			}
+			_, err = sess.Insert(entry)
+			return err
+		})
+	})
+}
+
+// removeGroup removes a group from a team and records the change in the audit log.
+func (s *store) removeGroup(ctx context.Context, orgID, teamID int64, groupID string, entry *AuditEntry) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context) error {
+		return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+			affected, err := sess.Where("org_id = ? AND team_id = ? AND group_id = ?", orgID, teamID, groupID).Delete(&TeamGroup{})
+			if err != nil {
+				return err
+			}
+			if affected == 0 {
+				return ErrGroupNotFound.Errorf("group %s is not mapped to team %d", groupID, teamID)
+			}
+			_, err = sess.Insert(entry)
+			return err
+		})
+	})
+}
+
+// wildcardPrefix starts LDAP group mappings that match every group of an
+// organizational unit, such as cn=*,ou=groups,dc=grafana,dc=org.
+const wildcardPrefix = "cn=*,"
+
+// teamsByGroups returns the teams that any of the groups is mapped to, with
+// the first matching group of each team. Group IDs are compared case
+// insensitively, because LDAP distinguished names are.
+func (s *store) teamsByGroups(ctx context.Context, groups []string) (map[membership]string, error) {
+	teams := map[membership]string{}
+	if len(groups) == 0 {
+		return teams, nil
+	}
+
+	lowered := make([]any, 0, len(groups)*2)
+	for _, group := range groups {
+		lowered = append(lowered, strings.ToLower(group))
+	}
+	// a group also matches the wildcard mapping of its organizational unit
+	for _, group := range groups {
+		if _, ou, ok := strings.Cut(group, ","); ok {
+			lowered = append(lowered, wildcardPrefix+strings.ToLower(ou))
+		}
+	}
+
+	var rows []*TeamGroup
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Where("LOWER(group_id) IN (?"+strings.Repeat(",?", len(lowered)-1)+")", lowered...).
+			Asc("org_id", "team_id", "group_id").
+			Find(&rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		key := membership{orgID: row.OrgID, teamID: row.TeamID}
+		if _, ok := teams[key]; !ok {
+			teams[key] = row.GroupID
+		}
+	}
+	return teams, nil
+}
+
+// memberships returns the teams a user is a member of, and whether the membership
+// was added by team sync. It reads the team_member table directly, because the
+// memberships cached by the team service can be outdated right after a sync.
+func (s *store) memberships(ctx context.Context, userID int64) (map[membership]bool, error) {
+	var rows []struct {
+		OrgID    int64 `xorm:"org_id"`
+		TeamID   int64 `xorm:"team_id"`
+		External bool  `xorm:"external"`
+	}
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.SQL("SELECT org_id, team_id, external FROM team_member WHERE user_id = ?", userID).Find(&rows)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	memberships := make(map[membership]bool, len(rows))
+	for _, row := range rows {
+		memberships[membership{orgID: row.OrgID, teamID: row.TeamID}] = row.External
+	}
+	return memberships, nil
+}
+
+func (s *store) insertAudit(ctx context.Context, entries ...*AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		for _, entry := range entries {
+			if _, err := sess.Insert(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// auditLog returns the audit log of a team, latest entry first.
+func (s *store) auditLog(ctx context.Context, query GetAuditLogQuery) ([]*AuditEntry, error) {
+	entries := make([]*AuditEntry, 0)
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		sess.Where("org_id = ? AND team_id = ?", query.OrgID, query.TeamID)
+		if query.Action != "" {
+			sess.And("action = ?", query.Action)
+		}
+		return sess.Desc("created", "id").Limit(query.Limit).Find(&entries)
+	})
+	return entries, err
+}
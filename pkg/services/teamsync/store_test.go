@@ -0,0 +1,93 @@
+package teamsync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/team"
+	"github.com/grafana/grafana/pkg/tests/testsuite"
+)
+
+func TestMain(m *testing.M) {
+	testsuite.Run(m)
+}
+
+func TestIntegrationStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	sqlStore := db.InitTestDB(t)
+	s := &store{db: sqlStore}
+	now := time.Unix(1700000000, 0)
+
+	addGroup := func(orgID, teamID int64, groupID string) error {
+		group := &TeamGroup{OrgID: orgID, TeamID: teamID, GroupID: groupID, Created: now, Updated: now}
+		entry := &AuditEntry{OrgID: orgID, TeamID: teamID, Action: AuditActionGroupAdded, Source: AuditSourceAPI, GroupID: groupID, Created: now}
+		return s.addGroup(ctx, group, entry)
+	}
+
+	t.Run("groups are mapped to teams once", func(t *testing.T) {
+		require.NoError(t, addGroup(1, 1, "cn=Admins,dc=grafana,dc=org"))
+		require.NoError(t, addGroup(1, 1, "admins"))
+		require.NoError(t, addGroup(1, 2, "admins"))
+		require.NoError(t, addGroup(2, 3, "editors"))
+		require.NoError(t, addGroup(2, 4, "cn=*,ou=Viewers,dc=grafana,dc=org"))
+		require.ErrorIs(t, addGroup(1, 1, "admins"), ErrGroupAlreadyAdded)
+
+		groups, err := s.groups(ctx, 1, 1)
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+		require.Equal(t, "admins", groups[0].GroupID)
+	})
+
+	t.Run("teams are matched by groups case insensitively", func(t *testing.T) {
+		teams, err := s.teamsByGroups(ctx, []string{"CN=admins,DC=grafana,DC=org", "editors", "cn=support,ou=viewers,dc=grafana,dc=org"})
+		require.NoError(t, err)
+		require.Equal(t, map[membership]string{
+			{orgID: 1, teamID: 1}: "cn=Admins,dc=grafana,dc=org",
+			{orgID: 2, teamID: 3}: "editors",
+			{orgID: 2, teamID: 4}: "cn=*,ou=Viewers,dc=grafana,dc=org",
+		}, teams)
+
+		teams, err = s.teamsByGroups(ctx, nil)
+		require.NoError(t, err)
+		require.Empty(t, teams)
+	})
+
+	t.Run("memberships are read from team members", func(t *testing.T) {
+		err := sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+			_, err := sess.Insert(
+				&team.TeamMember{OrgID: 1, TeamID: 1, UserID: 10, External: true, Created: now, Updated: now},
+				&team.TeamMember{OrgID: 1, TeamID: 2, UserID: 10, Created: now, Updated: now},
+				&team.TeamMember{OrgID: 1, TeamID: 2, UserID: 11, Created: now, Updated: now},
+			)
+			return err
+		})
+		require.NoError(t, err)
+
+		memberships, err := s.memberships(ctx, 10)
+		require.NoError(t, err)
+		require.Equal(t, map[membership]bool{{orgID: 1, teamID: 1}: true, {orgID: 1, teamID: 2}: false}, memberships)
+	})
+
+	t.Run("removing a group is audited", func(t *testing.T) {
+		entry := &AuditEntry{OrgID: 1, TeamID: 2, Action: AuditActionGroupRemoved, Source: AuditSourceAPI, GroupID: "admins", Created: now.Add(time.Minute)}
+		require.NoError(t, s.removeGroup(ctx, 1, 2, "admins", entry))
+		require.ErrorIs(t, s.removeGroup(ctx, 1, 2, "admins", entry), ErrGroupNotFound)
+
+		entries, err := s.auditLog(ctx, GetAuditLogQuery{OrgID: 1, TeamID: 2, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		require.Equal(t, AuditActionGroupRemoved, entries[0].Action)
+
+		entries, err = s.auditLog(ctx, GetAuditLogQuery{OrgID: 1, TeamID: 2, Action: AuditActionGroupAdded, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+	})
+}
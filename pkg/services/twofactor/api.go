@@ -0,0 +1,153 @@
+package twofactor
+
+import (
+	"net/http"
+	"strconv"
+
+	claims "github.com/grafana/authlib/types"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/middleware"
+	"github.com/grafana/grafana/pkg/middleware/requestmeta"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func (s *Service) registerAPIEndpoints() {
+	authorize := accesscontrol.Middleware(s.accessControl)
+	authorizeInOrg := accesscontrol.AuthorizeInOrgMiddleware(s.accessControl, s.authnService)
+	userIDScope := accesscontrol.Scope("global.users", "id", accesscontrol.Parameter(":id"))
+
+	s.routeRegister.Group("/api/user/2fa", func(r routing.RouteRegister) {
+		r.Get("/", routing.Wrap(s.getStatusHandler))
+		r.Post("/enroll", routing.Wrap(s.startEnrollmentHandler))
+		r.Post("/activate", routing.Wrap(s.completeEnrollmentHandler))
+		r.Post("/recovery-codes", routing.Wrap(s.regenerateRecoveryCodesHandler))
+		r.Post("/disable", routing.Wrap(s.disableHandler))
+	}, middleware.ReqSignedInNoAnonymous, requestmeta.SetOwner(requestmeta.TeamAuth))
+
+	s.routeRegister.Group("/api/org/2fa", func(r routing.RouteRegister) {
+		r.Get("/", authorize(accesscontrol.EvalPermission(accesscontrol.ActionOrgsRead)), routing.Wrap(s.getOrgSettingHandler))
+		r.Put("/", authorize(accesscontrol.EvalPermission(accesscontrol.ActionOrgsWrite)), routing.Wrap(s.updateOrgSettingHandler))
+	}, middleware.ReqSignedIn, requestmeta.SetOwner(requestmeta.TeamAuth))
+
+	s.routeRegister.Delete("/api/admin/users/:id/2fa", middleware.ReqSignedIn, requestmeta.SetOwner(requestmeta.TeamAuth),
+		authorizeInOrg(accesscontrol.UseGlobalOrg, accesscontrol.EvalPermission(accesscontrol.ActionUsersWrite, userIDScope)),
+		routing.Wrap(s.resetHandler))
+}
+
+func (s *Service) getStatusHandler(c *contextmodel.ReqContext) response.Response {
+	userID, errResponse := signedInUserID(c)
+	if errResponse != nil {
+		return errResponse
+	}
+	status, err := s.GetStatus(c.Req.Context(), userID)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to get two-factor authentication status", err)
+	}
+	return response.JSON(http.StatusOK, status)
+}
+
+func (s *Service) startEnrollmentHandler(c *contextmodel.ReqContext) response.Response {
+	userID, errResponse := signedInUserID(c)
+	if errResponse != nil {
+		return errResponse
+	}
+	key, err := s.StartEnrollment(c.Req.Context(), userID, c.SignedInUser.GetLogin())
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to start two-factor authentication setup", err)
+	}
+	return response.JSON(http.StatusOK, key)
+}
+
+func (s *Service) completeEnrollmentHandler(c *contextmodel.ReqContext) response.Response {
+	cmd := CodeCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	userID, errResponse := signedInUserID(c)
+	if errResponse != nil {
+		return errResponse
+	}
+	codes, err := s.CompleteEnrollment(c.Req.Context(), userID, cmd.Code, c.SignedInUser.GetLogin(), web.RemoteAddr(c.Req))
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to set up two-factor authentication", err)
+	}
+	return response.JSON(http.StatusOK, RecoveryCodesDTO{RecoveryCodes: codes})
+}
+
+func (s *Service) regenerateRecoveryCodesHandler(c *contextmodel.ReqContext) response.Response {
+	cmd := CodeCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	userID, errResponse := signedInUserID(c)
+	if errResponse != nil {
+		return errResponse
+	}
+	codes, err := s.RegenerateRecoveryCodes(c.Req.Context(), userID, cmd.Code, c.SignedInUser.GetLogin(), web.RemoteAddr(c.Req))
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to generate recovery codes", err)
+	}
+	return response.JSON(http.StatusOK, RecoveryCodesDTO{RecoveryCodes: codes})
+}
+
+func (s *Service) disableHandler(c *contextmodel.ReqContext) response.Response {
+	cmd := CodeCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	userID, errResponse := signedInUserID(c)
+	if errResponse != nil {
+		return errResponse
+	}
+	if err := s.Disable(c.Req.Context(), userID, cmd.Code, c.SignedInUser.GetLogin(), web.RemoteAddr(c.Req)); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to disable two-factor authentication", err)
+	}
+	return response.Success("Two-factor authentication disabled")
+}
+
+func (s *Service) getOrgSettingHandler(c *contextmodel.ReqContext) response.Response {
+	orgSetting, err := s.GetOrgSetting(c.Req.Context(), c.GetOrgID())
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to get two-factor authentication policy", err)
+	}
+	return response.JSON(http.StatusOK, orgSetting)
+}
+
+func (s *Service) updateOrgSettingHandler(c *contextmodel.ReqContext) response.Response {
+	cmd := UpdateOrgSettingCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	if err := s.UpdateOrgSetting(c.Req.Context(), c.GetOrgID(), cmd.Required); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to update two-factor authentication policy", err)
+	}
+	return response.Success("Two-factor authentication policy updated")
+}
+
+// resetHandler removes the enrollment of a user, so that they can sign in with
+// their password only and set up two-factor authentication again.
+func (s *Service) resetHandler(c *contextmodel.ReqContext) response.Response {
+	userID, err := strconv.ParseInt(web.Params(c.Req)[":id"], 10, 64)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "id is invalid", err)
+	}
+	if err := s.Reset(c.Req.Context(), userID); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to reset two-factor authentication", err)
+	}
+	return response.Success("Two-factor authentication reset")
+}
+
+func signedInUserID(c *contextmodel.ReqContext) (int64, response.Response) {
+	if !c.SignedInUser.IsIdentityType(claims.TypeUser) {
+		return 0, response.Err(ErrUnsupportedIdentity.Errorf("identity of type %s cannot set up two-factor authentication", c.SignedInUser.GetIdentityType()))
+	}
+	userID, err := c.SignedInUser.GetInternalID()
+	if err != nil {
+		return 0, response.Error(http.StatusInternalServerError, "Failed to parse user id", err)
+	}
+	return userID, nil
+}
@@ -0,0 +1,84 @@
+package twofactor
+
+import (
+	"time"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+)
+
+var (
+	ErrCodeRequired         = errutil.Unauthorized("two-factor.code-required", errutil.WithPublicMessage("Two-factor authentication code required"))
+	ErrInvalidCode          = errutil.Unauthorized("two-factor.invalid-code", errutil.WithPublicMessage("Invalid two-factor authentication code"))
+	ErrEnrollmentRequired   = errutil.Unauthorized("two-factor.enrollment-required", errutil.WithPublicMessage("Two-factor authentication must be set up to sign in"))
+	ErrTooManyAttempts      = errutil.Unauthorized("two-factor.blocked", errutil.WithPublicMessage("Too many invalid two-factor authentication codes. Please try again later."))
+	ErrNotEnrolled          = errutil.NotFound("two-factor.not-enrolled", errutil.WithPublicMessage("Two-factor authentication is not set up"))
+	ErrAlreadyEnrolled      = errutil.Conflict("two-factor.already-enrolled", errutil.WithPublicMessage("Two-factor authentication is already set up"))
+	ErrEnrollmentNotStarted = errutil.BadRequest("two-factor.enrollment-not-started", errutil.WithPublicMessage("Two-factor authentication setup has not been started"))
+	ErrUnsupportedIdentity  = errutil.BadRequest("two-factor.unsupported-identity", errutil.WithPublicMessage("Two-factor authentication can only be set up by users"))
+)
+
+// Enrollment is the TOTP second factor of a user. The secret and the recovery
+// codes are encrypted with the secrets service. An enrollment is pending until
+// the user confirms it with a code from their authenticator app.
+type Enrollment struct {
+	ID     int64 `xorm:"pk autoincr 'id'"`
+	UserID int64 `xorm:"user_id"`
+	// Secret is the encrypted base32 encoded TOTP secret.
+	Secret []byte `xorm:"secret"`
+	// RecoveryCodes is the encrypted JSON list of unused recovery codes.
+	RecoveryCodes []byte `xorm:"recovery_codes"`
+	Enabled       bool   `xorm:"enabled"`
+	// LastUsedStep is the time step of the last accepted code, codes of the
+	// same or earlier time steps are rejected so that a code cannot be replayed.
+	LastUsedStep int64 `xorm:"last_used_step"`
+	Created      time.Time
+	Updated      time.Time
+}
+
+func (Enrollment) TableName() string {
+	return "user_two_factor"
+}
+
+// OrgSetting is the two-factor authentication policy of an organization.
+type OrgSetting struct {
+	ID    int64 `json:"-" xorm:"pk autoincr 'id'"`
+	OrgID int64 `json:"orgId" xorm:"org_id"`
+	// Required makes members of the organization set up two-factor
+	// authentication the next time they sign in with a username and password.
+	Required bool      `json:"required"`
+	Updated  time.Time `json:"updated"`
+}
+
+func (OrgSetting) TableName() string {
+	return "org_two_factor_setting"
+}
+
+// StatusDTO is the two-factor authentication status of the signed in user.
+type StatusDTO struct {
+	Enabled                bool `json:"enabled"`
+	RecoveryCodesRemaining int  `json:"recoveryCodesRemaining"`
+}
+
+// KeyDTO is the TOTP secret of a pending enrollment, and the otpauth URL that
+// authenticator apps import from a QR code.
+type KeyDTO struct {
+	Secret string `json:"secret"`
+	URL    string `json:"url"`
+}
+
+// RecoveryCodesDTO lists the recovery codes of a user. They are only shown once.
+type RecoveryCodesDTO struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// CodeCommand is the body of requests that are confirmed with a TOTP or
+// recovery code.
+type CodeCommand struct {
+	Code string `json:"code" binding:"Required"`
+}
+
+// UpdateOrgSettingCommand is the body of a request that changes the
+// two-factor authentication policy of an organization.
+type UpdateOrgSettingCommand struct {
+	Required bool `json:"required"`
+}
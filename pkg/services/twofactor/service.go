@@ -0,0 +1,445 @@
+package twofactor
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"time"
+
+	claims "github.com/grafana/authlib/types"
+
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/authn"
+	"github.com/grafana/grafana/pkg/services/loginattempt"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/secrets"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// verifyHookPriority runs the second factor check after the user is synced,
+// so that users that sign in with LDAP have an ID, and before the organization
+// roles are synced.
+const verifyHookPriority = 25
+
+// Service adds TOTP two-factor authentication to the form and basic auth
+// clients. Users enroll with an authenticator app and get single use recovery
+// codes. Once enrolled, signing in with a username and password also requires
+// a code. Organizations can require two-factor authentication, members that
+// are not enrolled yet then set it up the next time they sign in.
+type Service struct {
+	cfg            *setting.Cfg
+	store          *store
+	log            log.Logger
+	routeRegister  routing.RouteRegister
+	accessControl  accesscontrol.AccessControl
+	authnService   authn.Service
+	secretsService secrets.Service
+	loginAttempts  loginattempt.Service
+	now            func() time.Time
+}
+
+func ProvideService(
+	cfg *setting.Cfg, sqlStore db.DB, routeRegister routing.RouteRegister, accessControl accesscontrol.AccessControl,
+	authnService authn.Service, secretsService secrets.Service, loginAttempts loginattempt.Service, orgService org.Service,
+) *Service {
+	s := &Service{
+		cfg:            cfg,
+		store:          &store{db: sqlStore},
+		log:            log.New("twofactor"),
+		routeRegister:  routeRegister,
+		accessControl:  accessControl,
+		authnService:   authnService,
+		secretsService: secretsService,
+		loginAttempts:  loginAttempts,
+		now:            time.Now,
+	}
+
+	orgService.RegisterDelete("DELETE FROM org_two_factor_setting WHERE org_id = ?")
+
+	if !cfg.TwoFactor.Enabled {
+		return s
+	}
+
+	authnService.RegisterPostAuthHook(s.VerifyHook, verifyHookPriority)
+	s.registerAPIEndpoints()
+	return s
+}
+
+// Enabled returns whether users can set up two-factor authentication.
+func (s *Service) Enabled() bool {
+	return s.cfg.TwoFactor.Enabled
+}
+
+// VerifyHook checks the second factor of users that are authenticated with a
+// username and password. Users without an enrollment only need their password,
+// unless an organization they are a member of requires two-factor
+// authentication.
+func (s *Service) VerifyHook(ctx context.Context, id *authn.Identity, r *authn.Request) error {
+	if r.GetMeta(authn.MetaKeyPasswordAuth) == "" || !id.IsIdentityType(claims.TypeUser) {
+		return nil
+	}
+
+	userID, err := id.GetInternalID()
+	if err != nil {
+		return err
+	}
+
+	enrollment, err := s.store.enrollment(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	code := normalizeCode(r.GetMeta(authn.MetaKeyTwoFactorCode))
+	username := r.GetMeta(authn.MetaKeyUsername)
+	ipAddress := web.RemoteAddr(r.HTTPRequest)
+
+	if enrollment != nil && enrollment.Enabled {
+		if code == "" {
+			return ErrCodeRequired.Errorf("user %d has two-factor authentication enabled", userID)
+		}
+		return s.verifyCode(ctx, enrollment, code, username, ipAddress)
+	}
+
+	required, err := s.store.requiredForUser(ctx, userID)
+	if err != nil || !required {
+		return err
+	}
+	return s.enrollOnLogin(ctx, enrollment, userID, id.Login, code, username, ipAddress)
+}
+
+// enrollOnLogin lets users that are required to use two-factor authentication
+// set it up while signing in. Without a code, the sign in fails with the key
+// of a pending enrollment, which the user adds to their authenticator app.
+// Signing in again with a code of the key completes the enrollment.
+func (s *Service) enrollOnLogin(ctx context.Context, enrollment *Enrollment, userID int64, login, code, username, ipAddress string) error {
+	if enrollment == nil || code == "" {
+		key, err := s.pendingKey(ctx, enrollment, userID, login)
+		if err != nil {
+			return err
+		}
+		enrollErr := ErrEnrollmentRequired.Errorf("user %d is required to set up two-factor authentication", userID)
+		enrollErr.PublicPayload = map[string]any{"secret": key.Secret, "url": key.URL}
+		return enrollErr
+	}
+
+	if err := s.verifyPendingCode(ctx, enrollment, code, username, ipAddress); err != nil {
+		return err
+	}
+	// recovery codes are shown when the user generates them from their
+	// profile, there is no way to show them while signing in
+	return s.activate(ctx, enrollment, []string{})
+}
+
+// GetStatus returns whether a user has two-factor authentication enabled.
+func (s *Service) GetStatus(ctx context.Context, userID int64) (*StatusDTO, error) {
+	enrollment, err := s.store.enrollment(ctx, userID)
+	if err != nil || enrollment == nil || !enrollment.Enabled {
+		return &StatusDTO{}, err
+	}
+	codes, err := s.decryptRecoveryCodes(ctx, enrollment)
+	if err != nil {
+		return nil, err
+	}
+	return &StatusDTO{Enabled: true, RecoveryCodesRemaining: len(codes)}, nil
+}
+
+// StartEnrollment creates a pending enrollment with a new key. Starting again
+// replaces the key of a pending enrollment.
+func (s *Service) StartEnrollment(ctx context.Context, userID int64, login string) (*KeyDTO, error) {
+	enrollment, err := s.store.enrollment(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment != nil && enrollment.Enabled {
+		return nil, ErrAlreadyEnrolled.Errorf("user %d has two-factor authentication enabled", userID)
+	}
+	return s.newPendingKey(ctx, userID, login)
+}
+
+// CompleteEnrollment enables a pending enrollment when the code matches its
+// key, and returns the recovery codes of the user.
+func (s *Service) CompleteEnrollment(ctx context.Context, userID int64, code, username, ipAddress string) ([]string, error) {
+	enrollment, err := s.store.enrollment(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment == nil {
+		return nil, ErrEnrollmentNotStarted.Errorf("user %d has no pending enrollment", userID)
+	}
+	if enrollment.Enabled {
+		return nil, ErrAlreadyEnrolled.Errorf("user %d has two-factor authentication enabled", userID)
+	}
+
+	if err := s.verifyPendingCode(ctx, enrollment, normalizeCode(code), username, ipAddress); err != nil {
+		return nil, err
+	}
+
+	codes := generateRecoveryCodes()
+	if err := s.activate(ctx, enrollment, codes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of a user after checking
+// a current code.
+func (s *Service) RegenerateRecoveryCodes(ctx context.Context, userID int64, code, username, ipAddress string) ([]string, error) {
+	enrollment, err := s.enabledEnrollment(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verifyCode(ctx, enrollment, normalizeCode(code), username, ipAddress); err != nil {
+		return nil, err
+	}
+
+	// the enrollment is read again, verifying a recovery code changes it
+	enrollment, err = s.enabledEnrollment(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	codes := generateRecoveryCodes()
+	previous := enrollment.RecoveryCodes
+	if enrollment.RecoveryCodes, err = s.encryptRecoveryCodes(ctx, codes); err != nil {
+		return nil, err
+	}
+	enrollment.Updated = s.now()
+	if ok, err := s.store.updateRecoveryCodes(ctx, enrollment, previous); err != nil || !ok {
+		if err == nil {
+			err = ErrInvalidCode.Errorf("recovery codes of user %d changed concurrently", userID)
+		}
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Disable removes the enrollment of a user after checking a current code.
+func (s *Service) Disable(ctx context.Context, userID int64, code, username, ipAddress string) error {
+	enrollment, err := s.enabledEnrollment(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.verifyCode(ctx, enrollment, normalizeCode(code), username, ipAddress); err != nil {
+		return err
+	}
+	_, err = s.store.deleteEnrollment(ctx, userID)
+	return err
+}
+
+// Reset removes the enrollment of a user that lost access to their
+// authenticator app and recovery codes. It is used by admins.
+func (s *Service) Reset(ctx context.Context, userID int64) error {
+	deleted, err := s.store.deleteEnrollment(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrNotEnrolled.Errorf("user %d has not set up two-factor authentication", userID)
+	}
+	s.log.FromContext(ctx).Info("Reset two-factor authentication", "userId", userID)
+	return nil
+}
+
+// GetOrgSetting returns the two-factor authentication policy of an organization.
+func (s *Service) GetOrgSetting(ctx context.Context, orgID int64) (*OrgSetting, error) {
+	return s.store.orgSetting(ctx, orgID)
+}
+
+// UpdateOrgSetting changes the two-factor authentication policy of an organization.
+func (s *Service) UpdateOrgSetting(ctx context.Context, orgID int64, required bool) error {
+	return s.store.saveOrgSetting(ctx, &OrgSetting{OrgID: orgID, Required: required, Updated: s.now()})
+}
+
+func (s *Service) enabledEnrollment(ctx context.Context, userID int64) (*Enrollment, error) {
+	enrollment, err := s.store.enrollment(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment == nil || !enrollment.Enabled {
+		return nil, ErrNotEnrolled.Errorf("user %d has not set up two-factor authentication", userID)
+	}
+	return enrollment, nil
+}
+
+// pendingKey returns the key of a pending enrollment, so that signing in
+// without a code again does not invalidate the key the user already added to
+// their authenticator app.
+func (s *Service) pendingKey(ctx context.Context, enrollment *Enrollment, userID int64, login string) (*KeyDTO, error) {
+	if enrollment == nil {
+		return s.newPendingKey(ctx, userID, login)
+	}
+	secret, err := s.secretsService.Decrypt(ctx, enrollment.Secret)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyDTO{Secret: string(secret), URL: keyURL(s.cfg.TwoFactor.Issuer, login, string(secret))}, nil
+}
+
+func (s *Service) newPendingKey(ctx context.Context, userID int64, login string) (*KeyDTO, error) {
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := s.secretsService.Encrypt(ctx, []byte(secret), secrets.WithoutScope())
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	enrollment := &Enrollment{UserID: userID, Secret: encrypted, Created: now, Updated: now}
+	if err := s.store.saveEnrollment(ctx, enrollment); err != nil {
+		return nil, err
+	}
+	return &KeyDTO{Secret: secret, URL: keyURL(s.cfg.TwoFactor.Issuer, login, secret)}, nil
+}
+
+func (s *Service) activate(ctx context.Context, enrollment *Enrollment, codes []string) error {
+	encrypted, err := s.encryptRecoveryCodes(ctx, codes)
+	if err != nil {
+		return err
+	}
+	enrollment.RecoveryCodes = encrypted
+	enrollment.Enabled = true
+	enrollment.Updated = s.now()
+	if err := s.store.saveEnrollment(ctx, enrollment); err != nil {
+		return err
+	}
+	s.log.FromContext(ctx).Info("Enabled two-factor authentication", "userId", enrollment.UserID)
+	return nil
+}
+
+// verifyPendingCode checks a TOTP code against the key of a pending
+// enrollment. Recovery codes are only created once the enrollment is complete.
+func (s *Service) verifyPendingCode(ctx context.Context, enrollment *Enrollment, code, username, ipAddress string) error {
+	if err := s.checkAttempts(ctx, username, ipAddress); err != nil {
+		return err
+	}
+	step, err := s.validateTOTP(ctx, enrollment, code)
+	if err != nil {
+		return err
+	}
+	if step == 0 {
+		return s.invalidCode(ctx, enrollment.UserID, username, ipAddress)
+	}
+	enrollment.LastUsedStep = step
+	return nil
+}
+
+// verifyCode checks a TOTP or recovery code of an enabled enrollment. Invalid
+// codes count as failed login attempts of the user, so that guessing codes is
+// blocked the same way as guessing passwords.
+func (s *Service) verifyCode(ctx context.Context, enrollment *Enrollment, code, username, ipAddress string) error {
+	if err := s.checkAttempts(ctx, username, ipAddress); err != nil {
+		return err
+	}
+
+	if isTOTPCode(code) {
+		step, err := s.validateTOTP(ctx, enrollment, code)
+		if err != nil {
+			return err
+		}
+		if step == 0 {
+			return s.invalidCode(ctx, enrollment.UserID, username, ipAddress)
+		}
+		ok, err := s.store.useStep(ctx, enrollment.UserID, step)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return s.invalidCode(ctx, enrollment.UserID, username, ipAddress)
+		}
+		return nil
+	}
+
+	codes, err := s.decryptRecoveryCodes(ctx, enrollment)
+	if err != nil {
+		return err
+	}
+	for i, recoveryCode := range codes {
+		if subtle.ConstantTimeCompare([]byte(recoveryCode), []byte(code)) != 1 {
+			continue
+		}
+
+		previous := enrollment.RecoveryCodes
+		remaining := append(codes[:i:i], codes[i+1:]...)
+		if enrollment.RecoveryCodes, err = s.encryptRecoveryCodes(ctx, remaining); err != nil {
+			return err
+		}
+		enrollment.Updated = s.now()
+		ok, err := s.store.updateRecoveryCodes(ctx, enrollment, previous)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return s.invalidCode(ctx, enrollment.UserID, username, ipAddress)
+		}
+		s.log.FromContext(ctx).Info("Used two-factor recovery code", "userId", enrollment.UserID, "remaining", len(remaining))
+		return nil
+	}
+	return s.invalidCode(ctx, enrollment.UserID, username, ipAddress)
+}
+
+// validateTOTP returns the time step the code is valid in, or 0 when the code
+// is invalid or was used before.
+func (s *Service) validateTOTP(ctx context.Context, enrollment *Enrollment, code string) (int64, error) {
+	secret, err := s.secretsService.Decrypt(ctx, enrollment.Secret)
+	if err != nil {
+		return 0, err
+	}
+	step, ok := validateTOTP(string(secret), code, s.now())
+	if !ok || step <= enrollment.LastUsedStep {
+		return 0, nil
+	}
+	return step, nil
+}
+
+func (s *Service) checkAttempts(ctx context.Context, username, ipAddress string) error {
+	ok, err := s.loginAttempts.Validate(ctx, username)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrTooManyAttempts.Errorf("too many consecutive incorrect login attempts for user")
+	}
+	ok, err = s.loginAttempts.ValidateIPAddress(ctx, ipAddress)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrTooManyAttempts.Errorf("too many consecutive incorrect login attempts for IP address")
+	}
+	return nil
+}
+
+func (s *Service) invalidCode(ctx context.Context, userID int64, username, ipAddress string) error {
+	if err := s.loginAttempts.Add(ctx, username, ipAddress); err != nil {
+		return err
+	}
+	return ErrInvalidCode.Errorf("invalid two-factor authentication code for user %d", userID)
+}
+
+func (s *Service) encryptRecoveryCodes(ctx context.Context, codes []string) ([]byte, error) {
+	b, err := json.Marshal(codes)
+	if err != nil {
+		return nil, err
+	}
+	return s.secretsService.Encrypt(ctx, b, secrets.WithoutScope())
+}
+
+func (s *Service) decryptRecoveryCodes(ctx context.Context, enrollment *Enrollment) ([]string, error) {
+	if len(enrollment.RecoveryCodes) == 0 {
+		return []string{}, nil
+	}
+	b, err := s.secretsService.Decrypt(ctx, enrollment.RecoveryCodes)
+	if err != nil {
+		return nil, err
+	}
+	var codes []string
+	if err := json.Unmarshal(b, &codes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
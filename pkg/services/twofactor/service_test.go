@@ -0,0 +1,180 @@
+package twofactor
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	claims "github.com/grafana/authlib/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/authn"
+	"github.com/grafana/grafana/pkg/services/loginattempt/loginattempttest"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/secrets/fakes"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/tests/testsuite"
+)
+
+func TestMain(m *testing.M) {
+	testsuite.Run(m)
+}
+
+func TestIntegrationService(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	sqlStore := db.InitTestDB(t)
+	loginAttempts := &loginattempttest.MockLoginAttemptService{ExpectedValid: true}
+	now := time.Unix(1700000000, 0)
+	s := &Service{
+		cfg:            &setting.Cfg{TwoFactor: setting.TwoFactorSettings{Enabled: true, Issuer: "Grafana"}},
+		store:          &store{db: sqlStore},
+		log:            log.NewNopLogger(),
+		secretsService: fakes.NewFakeSecretsService(),
+		loginAttempts:  loginAttempts,
+		now:            func() time.Time { return now },
+	}
+
+	codeAt := func(t *testing.T, secret string, at time.Time) string {
+		key, err := secretEncoding.DecodeString(secret)
+		require.NoError(t, err)
+		return totpCode(key, at.Unix()/totpPeriod)
+	}
+
+	login := func(code string) error {
+		r := &authn.Request{HTTPRequest: &http.Request{RemoteAddr: "127.0.0.1:1234"}}
+		r.SetMeta(authn.MetaKeyPasswordAuth, "true")
+		r.SetMeta(authn.MetaKeyUsername, "user")
+		r.SetMeta(authn.MetaKeyTwoFactorCode, code)
+		return s.VerifyHook(ctx, &authn.Identity{ID: "1", Type: claims.TypeUser, Login: "user"}, r)
+	}
+
+	var secret string
+	var recoveryCodes []string
+
+	t.Run("users without an enrollment sign in with their password", func(t *testing.T) {
+		require.NoError(t, login(""))
+	})
+
+	t.Run("enrollment is completed with a code of the key", func(t *testing.T) {
+		key, err := s.StartEnrollment(ctx, 1, "user")
+		require.NoError(t, err)
+		secret = key.Secret
+		assert.Contains(t, key.URL, "secret="+secret)
+
+		_, err = s.CompleteEnrollment(ctx, 1, "000000", "user", "127.0.0.1")
+		require.ErrorIs(t, err, ErrInvalidCode)
+		require.True(t, loginAttempts.AddCalled)
+
+		recoveryCodes, err = s.CompleteEnrollment(ctx, 1, codeAt(t, secret, now), "user", "127.0.0.1")
+		require.NoError(t, err)
+		require.Len(t, recoveryCodes, recoveryCodeCount)
+
+		_, err = s.StartEnrollment(ctx, 1, "user")
+		require.ErrorIs(t, err, ErrAlreadyEnrolled)
+
+		status, err := s.GetStatus(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, &StatusDTO{Enabled: true, RecoveryCodesRemaining: recoveryCodeCount}, status)
+	})
+
+	t.Run("enrolled users need a code to sign in", func(t *testing.T) {
+		require.ErrorIs(t, login(""), ErrCodeRequired)
+	})
+
+	t.Run("codes cannot be replayed", func(t *testing.T) {
+		// the code used to complete the enrollment
+		require.ErrorIs(t, login(codeAt(t, secret, now)), ErrInvalidCode)
+
+		next := now.Add(totpPeriod * time.Second)
+		require.NoError(t, login(codeAt(t, secret, next)))
+		require.ErrorIs(t, login(codeAt(t, secret, next)), ErrInvalidCode)
+	})
+
+	t.Run("recovery codes are used once", func(t *testing.T) {
+		require.NoError(t, login(" "+recoveryCodes[0]+" "))
+		require.ErrorIs(t, login(recoveryCodes[0]), ErrInvalidCode)
+
+		status, err := s.GetStatus(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, recoveryCodeCount-1, status.RecoveryCodesRemaining)
+	})
+
+	t.Run("blocked users cannot try codes", func(t *testing.T) {
+		loginAttempts.ExpectedValid = false
+		defer func() { loginAttempts.ExpectedValid = true }()
+		require.ErrorIs(t, login(recoveryCodes[1]), ErrTooManyAttempts)
+	})
+
+	t.Run("regenerating recovery codes replaces the previous ones", func(t *testing.T) {
+		codes, err := s.RegenerateRecoveryCodes(ctx, 1, recoveryCodes[1], "user", "127.0.0.1")
+		require.NoError(t, err)
+		require.Len(t, codes, recoveryCodeCount)
+		require.ErrorIs(t, login(recoveryCodes[2]), ErrInvalidCode)
+		require.NoError(t, login(codes[0]))
+	})
+
+	t.Run("admins reset enrollments", func(t *testing.T) {
+		require.NoError(t, s.Reset(ctx, 1))
+		require.ErrorIs(t, s.Reset(ctx, 1), ErrNotEnrolled)
+		require.NoError(t, login(""))
+	})
+
+	t.Run("members of organizations that require two-factor enroll on sign in", func(t *testing.T) {
+		err := sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+			_, err := sess.Insert(&org.OrgUser{OrgID: 2, UserID: 1, Role: org.RoleViewer, Created: now, Updated: now})
+			return err
+		})
+		require.NoError(t, err)
+		require.NoError(t, s.UpdateOrgSetting(ctx, 2, true))
+
+		orgSetting, err := s.GetOrgSetting(ctx, 2)
+		require.NoError(t, err)
+		assert.True(t, orgSetting.Required)
+
+		err = login("")
+		require.ErrorIs(t, err, ErrEnrollmentRequired)
+		var enrollErr errutil.Error
+		require.ErrorAs(t, err, &enrollErr)
+		pendingSecret, ok := enrollErr.PublicPayload["secret"].(string)
+		require.True(t, ok)
+
+		// signing in again without a code keeps the pending key
+		err = login("")
+		require.ErrorAs(t, err, &enrollErr)
+		assert.Equal(t, pendingSecret, enrollErr.PublicPayload["secret"])
+
+		require.NoError(t, login(codeAt(t, pendingSecret, now)))
+		require.ErrorIs(t, login(""), ErrCodeRequired)
+
+		status, err := s.GetStatus(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, &StatusDTO{Enabled: true}, status)
+	})
+
+	t.Run("the policy of other organizations does not apply", func(t *testing.T) {
+		require.NoError(t, s.UpdateOrgSetting(ctx, 3, true))
+		require.NoError(t, s.Reset(ctx, 1))
+		require.NoError(t, s.UpdateOrgSetting(ctx, 2, false))
+		require.NoError(t, login(""))
+	})
+
+	t.Run("requests that are not authenticated with a password are skipped", func(t *testing.T) {
+		key, err := s.StartEnrollment(ctx, 1, "user")
+		require.NoError(t, err)
+		_, err = s.CompleteEnrollment(ctx, 1, codeAt(t, key.Secret, now), "user", "127.0.0.1")
+		require.NoError(t, err)
+		require.ErrorIs(t, login(""), ErrCodeRequired)
+
+		r := &authn.Request{HTTPRequest: &http.Request{}}
+		require.NoError(t, s.VerifyHook(ctx, &authn.Identity{ID: "1", Type: claims.TypeUser}, r))
+	})
+}
@@ -0,0 +1,118 @@
+package twofactor
+
+import (
+	"context"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+)
+
+type store struct {
+	db db.DB
+}
+
+// enrollment returns the enrollment of a user, or nil when the user has not
+// started to set up two-factor authentication.
+func (s *store) enrollment(ctx context.Context, userID int64) (*Enrollment, error) {
+	var enrollment Enrollment
+	var has bool
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		has, err = sess.Where("user_id = ?", userID).Get(&enrollment)
+		return err
+	})
+	if err != nil || !has {
+		return nil, err
+	}
+	return &enrollment, nil
+}
+
+// saveEnrollment inserts or replaces the enrollment of a user.
+func (s *store) saveEnrollment(ctx context.Context, enrollment *Enrollment) error {
+	return s.db.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		if _, err := sess.Where("user_id = ?", enrollment.UserID).Delete(&Enrollment{}); err != nil {
+			return err
+		}
+		_, err := sess.Insert(enrollment)
+		return err
+	})
+}
+
+// useStep records the time step of an accepted code. It returns false when a
+// code of the same or a later time step was accepted before, which means the
+// code is replayed.
+func (s *store) useStep(ctx context.Context, userID, step int64) (bool, error) {
+	var affected int64
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		affected, err = sess.Where("user_id = ? AND last_used_step < ?", userID, step).
+			Cols("last_used_step").
+			Update(&Enrollment{LastUsedStep: step})
+		return err
+	})
+	return affected > 0, err
+}
+
+// updateRecoveryCodes replaces the recovery codes of an enrollment. It returns
+// false when the codes were changed since they were read, so that a recovery
+// code cannot be used twice by concurrent requests.
+func (s *store) updateRecoveryCodes(ctx context.Context, enrollment *Enrollment, previous []byte) (bool, error) {
+	var affected int64
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		affected, err = sess.Where("user_id = ? AND recovery_codes = ?", enrollment.UserID, previous).
+			Cols("recovery_codes", "updated").
+			Update(enrollment)
+		return err
+	})
+	return affected > 0, err
+}
+
+// deleteEnrollment removes the enrollment of a user and returns whether there
+// was one.
+func (s *store) deleteEnrollment(ctx context.Context, userID int64) (bool, error) {
+	var affected int64
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		affected, err = sess.Where("user_id = ?", userID).Delete(&Enrollment{})
+		return err
+	})
+	return affected > 0, err
+}
+
+// orgSetting returns the two-factor policy of an organization. Organizations
+// without a stored policy do not require two-factor authentication.
+func (s *store) orgSetting(ctx context.Context, orgID int64) (*OrgSetting, error) {
+	setting := OrgSetting{OrgID: orgID}
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Where("org_id = ?", orgID).Get(&setting)
+		return err
+	})
+	return &setting, err
+}
+
+// saveOrgSetting inserts or updates the two-factor policy of an organization.
+func (s *store) saveOrgSetting(ctx context.Context, setting *OrgSetting) error {
+	return s.db.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		affected, err := sess.Where("org_id = ?", setting.OrgID).Cols("required", "updated").Update(setting)
+		if err != nil || affected > 0 {
+			return err
+		}
+		_, err = sess.Insert(setting)
+		return err
+	})
+}
+
+// requiredForUser returns whether any organization the user is a member of
+// requires two-factor authentication.
+func (s *store) requiredForUser(ctx context.Context, userID int64) (bool, error) {
+	var required bool
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		required, err = sess.SQL(
+			"SELECT 1 FROM org_two_factor_setting s INNER JOIN org_user ou ON ou.org_id = s.org_id WHERE ou.user_id = ? AND s.required = ?",
+			userID, s.db.GetDialect().BooleanValue(true),
+		).Exist()
+		return err
+	})
+	return required, err
+}
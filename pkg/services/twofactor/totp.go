@@ -0,0 +1,123 @@
+package twofactor
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" // #nosec G505 -- RFC 6238 TOTP codes are computed with HMAC-SHA1 by authenticator apps
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// totpPeriod is the number of seconds a code is valid for.
+	totpPeriod = 30
+	totpDigits = 6
+	// totpSkew is the number of periods before and after the current one in
+	// which a code is still accepted, to allow for clock drift.
+	totpSkew = 1
+	// secretSize is the size of TOTP secrets in bytes, as recommended by RFC 4226.
+	secretSize = 20
+
+	recoveryCodeCount = 10
+)
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// generateSecret returns a random TOTP secret encoded in base32, the format
+// authenticator apps expect.
+func generateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return secretEncoding.EncodeToString(b), nil
+}
+
+// totpCode computes the code of a time step as defined by RFC 6238.
+func totpCode(secret []byte, step int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step)) // #nosec G115 -- time steps are positive
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000)
+}
+
+// validateTOTP returns the time step in which code is valid, allowing for
+// clock drift of totpSkew periods.
+func validateTOTP(secret string, code string, now time.Time) (int64, bool) {
+	key, err := secretEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// isTOTPCode returns whether code has the format of a TOTP code. Anything else
+// is treated as a recovery code.
+func isTOTPCode(code string) bool {
+	if len(code) != totpDigits {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// keyURL returns the otpauth URL of a secret, which authenticator apps import
+// from a QR code.
+func keyURL(issuer, account, secret string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(totpDigits))
+	params.Set("period", fmt.Sprint(totpPeriod))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: params.Encode(),
+	}
+	return u.String()
+}
+
+// generateRecoveryCodes returns single use codes that let users sign in when
+// they lose access to their authenticator app. Codes are formatted as two
+// groups of five characters, such as abcde-23456.
+func generateRecoveryCodes() []string {
+	codes := make([]string, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		// rand.Text has 130 bits of entropy in 26 base32 characters, the first
+		// ten characters still have 50 bits
+		text := strings.ToLower(rand.Text())
+		codes = append(codes, text[:5]+"-"+text[5:10])
+	}
+	return codes
+}
+
+// normalizeCode removes whitespace users may copy along with a code, and lower
+// cases recovery codes. Dashes of recovery codes are kept.
+func normalizeCode(code string) string {
+	return strings.ToLower(strings.Join(strings.Fields(code), ""))
+}
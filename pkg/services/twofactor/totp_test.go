@@ -0,0 +1,99 @@
+package twofactor
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTPCode(t *testing.T) {
+	// test vectors of RFC 6238 appendix B, truncated to six digits
+	secret := []byte("12345678901234567890")
+	tests := []struct {
+		unix     int64
+		expected string
+	}{
+		{unix: 59, expected: "287082"},
+		{unix: 1111111109, expected: "081804"},
+		{unix: 1111111111, expected: "050471"},
+		{unix: 1234567890, expected: "005924"},
+		{unix: 2000000000, expected: "279037"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, totpCode(secret, tt.unix/totpPeriod))
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := generateSecret()
+	require.NoError(t, err)
+	key, err := secretEncoding.DecodeString(secret)
+	require.NoError(t, err)
+	require.Len(t, key, secretSize)
+
+	now := time.Unix(1700000000, 0)
+	current := now.Unix() / totpPeriod
+
+	t.Run("codes of the current and adjacent periods are accepted", func(t *testing.T) {
+		for _, step := range []int64{current - 1, current, current + 1} {
+			got, ok := validateTOTP(secret, totpCode(key, step), now)
+			require.True(t, ok)
+			assert.Equal(t, step, got)
+		}
+	})
+
+	t.Run("codes of other periods are rejected", func(t *testing.T) {
+		for _, step := range []int64{current - 2, current + 2} {
+			_, ok := validateTOTP(secret, totpCode(key, step), now)
+			assert.False(t, ok)
+		}
+	})
+
+	t.Run("lower case secrets are accepted", func(t *testing.T) {
+		_, ok := validateTOTP(strings.ToLower(secret), totpCode(key, current), now)
+		assert.True(t, ok)
+	})
+}
+
+func TestIsTOTPCode(t *testing.T) {
+	assert.True(t, isTOTPCode("012345"))
+	assert.False(t, isTOTPCode("01234"))
+	assert.False(t, isTOTPCode("01234a"))
+	assert.False(t, isTOTPCode("abcde-fghij"))
+}
+
+func TestKeyURL(t *testing.T) {
+	u, err := url.Parse(keyURL("Grafana", "admin@example.com", "JBSWY3DPEHPK3PXP"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/Grafana:admin@example.com", u.Path)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", u.Query().Get("secret"))
+	assert.Equal(t, "Grafana", u.Query().Get("issuer"))
+	assert.Equal(t, "6", u.Query().Get("digits"))
+	assert.Equal(t, "30", u.Query().Get("period"))
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes := generateRecoveryCodes()
+	require.Len(t, codes, recoveryCodeCount)
+
+	seen := map[string]bool{}
+	for _, code := range codes {
+		assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, code)
+		assert.Equal(t, code, normalizeCode(" "+code+" "))
+		assert.False(t, isTOTPCode(code))
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+}
+
+func TestNormalizeCode(t *testing.T) {
+	assert.Equal(t, "123456", normalizeCode(" 123 456 "))
+	assert.Equal(t, "abcde-fghij", normalizeCode("ABCDE-FGHIJ"))
+}
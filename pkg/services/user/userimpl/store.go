@@ -274,6 +274,10 @@ func (ss *sqlStore) Update(ctx context.Context, cmd *user.UpdateUserCommand) err
 			q = q.UseBool("is_admin")
 			usr.IsAdmin = v
 		})
+		setOptional(cmd.IsProvisioned, func(v bool) {
+			q = q.UseBool("is_provisioned")
+			usr.IsProvisioned = v
+		})
 		setOptional(cmd.HelpFlags1, func(v user.HelpFlags1) {
 			q = q.MustCols("help_flags1")
 			usr.HelpFlags1 = *cmd.HelpFlags1
@@ -0,0 +1,196 @@
+package webauthn
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+)
+
+var (
+	ErrInvalidCredential     = errutil.Unauthorized("webauthn.invalid-credential", errutil.WithPublicMessage("Invalid passkey"))
+	ErrRegistrationFailed    = errutil.BadRequest("webauthn.registration-failed", errutil.WithPublicMessage("The passkey could not be verified"))
+	ErrChallengeExpired      = errutil.BadRequest("webauthn.challenge-expired", errutil.WithPublicMessage("The passkey request expired, please try again"))
+	ErrCredentialExists      = errutil.Conflict("webauthn.credential-exists", errutil.WithPublicMessage("The passkey is already registered"))
+	ErrCredentialNotFound    = errutil.NotFound("webauthn.credential-not-found", errutil.WithPublicMessage("Passkey not found"))
+	ErrUnsupportedIdentity   = errutil.BadRequest("webauthn.unsupported-identity", errutil.WithPublicMessage("Passkeys can only be registered by users"))
+	ErrInvalidCredentialName = errutil.BadRequest("webauthn.invalid-name", errutil.WithPublicMessage("Passkey name must be at most 190 characters"))
+)
+
+const (
+	// PublicKeyCredentialType is the only credential type of WebAuthn.
+	PublicKeyCredentialType = "public-key"
+
+	UserVerificationRequired    = "required"
+	UserVerificationPreferred   = "preferred"
+	UserVerificationDiscouraged = "discouraged"
+)
+
+// Credential is a public key credential of a user. The private key never
+// leaves the authenticator of the user.
+type Credential struct {
+	ID     int64  `xorm:"pk autoincr 'id'"`
+	UID    string `xorm:"uid"`
+	UserID int64  `xorm:"user_id"`
+	Name   string `xorm:"name"`
+	// CredentialID is the base64url encoded ID the authenticator assigned to
+	// the credential.
+	CredentialID string `xorm:"credential_id"`
+	// CredentialIDHash is the hex encoded SHA-256 hash of the credential ID,
+	// credential IDs can be too long to be indexed.
+	CredentialIDHash string `xorm:"credential_id_hash"`
+	// PublicKey is the COSE encoded public key of the credential.
+	PublicKey []byte `xorm:"public_key"`
+	// SignCount is the signature counter of the authenticator. Authenticators
+	// that implement it increase it with every assertion, an assertion with a
+	// lower value means that the credential was cloned.
+	SignCount int64 `xorm:"sign_count"`
+	// AAGUID identifies the model of the authenticator.
+	AAGUID     string `xorm:"aaguid"`
+	Transports string `xorm:"transports"`
+	Created    time.Time
+	LastUsed   *time.Time `xorm:"last_used"`
+}
+
+func (Credential) TableName() string {
+	return "user_webauthn_credential"
+}
+
+// CredentialDTO is a credential as listed to its owner.
+type CredentialDTO struct {
+	UID        string     `json:"uid"`
+	Name       string     `json:"name"`
+	AAGUID     string     `json:"aaguid"`
+	Transports []string   `json:"transports"`
+	Created    time.Time  `json:"created"`
+	LastUsed   *time.Time `json:"lastUsed"`
+}
+
+// FinishRegistrationCommand is the body of the request that completes the
+// registration of a credential.
+type FinishRegistrationCommand struct {
+	UserID     int64                `json:"-"`
+	Name       string               `json:"name"`
+	Credential RegistrationResponse `json:"credential"`
+}
+
+// BeginLoginCommand is the body of the request that starts a sign in. Without
+// a login, the user picks one of the passkeys stored on their authenticator.
+// With a login, the options list the credentials of that user, which is
+// needed for security keys that do not store the credential.
+type BeginLoginCommand struct {
+	Login string `json:"login"`
+}
+
+// URLEncodedBase64 is binary data that is base64url encoded without padding
+// in JSON, like in the JSON serialization of the WebAuthn API.
+type URLEncodedBase64 []byte
+
+func (b URLEncodedBase64) String() string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func (b URLEncodedBase64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+func (b *URLEncodedBase64) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// CredentialDescriptor identifies a credential in the options of a ceremony.
+type CredentialDescriptor struct {
+	Type       string           `json:"type"`
+	ID         URLEncodedBase64 `json:"id"`
+	Transports []string         `json:"transports,omitempty"`
+}
+
+type RelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type UserEntity struct {
+	ID          URLEncodedBase64 `json:"id"`
+	Name        string           `json:"name"`
+	DisplayName string           `json:"displayName"`
+}
+
+type CredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+type AuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// CreationOptions are the options of a registration, in the format of
+// PublicKeyCredential.parseCreationOptionsFromJSON().
+type CreationOptions struct {
+	RP                     RelyingParty           `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	Challenge              URLEncodedBase64       `json:"challenge"`
+	PubKeyCredParams       []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions are the options of a sign in, in the format of
+// PublicKeyCredential.parseRequestOptionsFromJSON().
+type RequestOptions struct {
+	Challenge        URLEncodedBase64       `json:"challenge"`
+	Timeout          int64                  `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// RegistrationResponse is a new credential, as serialized by
+// PublicKeyCredential.toJSON().
+type RegistrationResponse struct {
+	ID       string                           `json:"id"`
+	RawID    URLEncodedBase64                 `json:"rawId"`
+	Type     string                           `json:"type"`
+	Response AuthenticatorAttestationResponse `json:"response"`
+}
+
+type AuthenticatorAttestationResponse struct {
+	ClientDataJSON    URLEncodedBase64 `json:"clientDataJSON"`
+	AttestationObject URLEncodedBase64 `json:"attestationObject"`
+	Transports        []string         `json:"transports"`
+}
+
+// AuthenticationResponse is an assertion, as serialized by
+// PublicKeyCredential.toJSON().
+type AuthenticationResponse struct {
+	ID       string                         `json:"id"`
+	RawID    URLEncodedBase64               `json:"rawId"`
+	Type     string                         `json:"type"`
+	Response AuthenticatorAssertionResponse `json:"response"`
+}
+
+type AuthenticatorAssertionResponse struct {
+	ClientDataJSON    URLEncodedBase64 `json:"clientDataJSON"`
+	AuthenticatorData URLEncodedBase64 `json:"authenticatorData"`
+	Signature         URLEncodedBase64 `json:"signature"`
+	UserHandle        URLEncodedBase64 `json:"userHandle"`
+}
@@ -0,0 +1,25 @@
+package webauthn
+
+import (
+	"context"
+)
+
+// Service registers WebAuthn credentials, passkeys and security keys, and
+// verifies the assertions users sign in with. Each ceremony has two steps:
+// Begin returns the options passed to navigator.credentials.create() or get()
+// in the browser, Finish verifies the response of the authenticator against
+// the challenge of these options.
+type Service interface {
+	// BeginRegistration returns the options to create a new credential for a user.
+	BeginRegistration(ctx context.Context, userID int64) (*CreationOptions, error)
+	// FinishRegistration verifies the response of the authenticator and stores the credential.
+	FinishRegistration(ctx context.Context, cmd *FinishRegistrationCommand) (*CredentialDTO, error)
+	// BeginLogin returns the options to sign in with a credential.
+	BeginLogin(ctx context.Context, cmd *BeginLoginCommand) (*RequestOptions, error)
+	// FinishLogin verifies an assertion and returns the ID of the user that owns the credential.
+	FinishLogin(ctx context.Context, response *AuthenticationResponse) (int64, error)
+	// ListCredentials returns the credentials of a user.
+	ListCredentials(ctx context.Context, userID int64) ([]*CredentialDTO, error)
+	// DeleteCredential removes a credential of a user.
+	DeleteCredential(ctx context.Context, userID int64, uid string) error
+}
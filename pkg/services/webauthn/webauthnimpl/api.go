@@ -0,0 +1,105 @@
+package webauthnimpl
+
+import (
+	"net/http"
+
+	claims "github.com/grafana/authlib/types"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/middleware"
+	"github.com/grafana/grafana/pkg/middleware/requestmeta"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/webauthn"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// registerAPIEndpoints registers the endpoints to manage the credentials of
+// the signed in user and to start a sign in. The assertion is posted to
+// /api/login/webauthn/finish, which signs the user in with the WebAuthn authn
+// client.
+func (s *Service) registerAPIEndpoints() {
+	s.routeRegister.Group("/api/user/webauthn", func(r routing.RouteRegister) {
+		r.Get("/credentials", routing.Wrap(s.listCredentialsHandler))
+		r.Delete("/credentials/:uid", routing.Wrap(s.deleteCredentialHandler))
+		r.Post("/register/begin", routing.Wrap(s.beginRegistrationHandler))
+		r.Post("/register/finish", routing.Wrap(s.finishRegistrationHandler))
+	}, middleware.ReqSignedInNoAnonymous, requestmeta.SetOwner(requestmeta.TeamAuth))
+
+	s.routeRegister.Post("/api/login/webauthn/begin", requestmeta.SetOwner(requestmeta.TeamAuth), routing.Wrap(s.beginLoginHandler))
+}
+
+func (s *Service) listCredentialsHandler(c *contextmodel.ReqContext) response.Response {
+	userID, errResponse := signedInUserID(c)
+	if errResponse != nil {
+		return errResponse
+	}
+	credentials, err := s.ListCredentials(c.Req.Context(), userID)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to list passkeys", err)
+	}
+	return response.JSON(http.StatusOK, credentials)
+}
+
+func (s *Service) deleteCredentialHandler(c *contextmodel.ReqContext) response.Response {
+	userID, errResponse := signedInUserID(c)
+	if errResponse != nil {
+		return errResponse
+	}
+	if err := s.DeleteCredential(c.Req.Context(), userID, web.Params(c.Req)[":uid"]); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to delete passkey", err)
+	}
+	return response.Success("Passkey deleted")
+}
+
+func (s *Service) beginRegistrationHandler(c *contextmodel.ReqContext) response.Response {
+	userID, errResponse := signedInUserID(c)
+	if errResponse != nil {
+		return errResponse
+	}
+	options, err := s.BeginRegistration(c.Req.Context(), userID)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to start passkey registration", err)
+	}
+	return response.JSON(http.StatusOK, options)
+}
+
+func (s *Service) finishRegistrationHandler(c *contextmodel.ReqContext) response.Response {
+	cmd := webauthn.FinishRegistrationCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	userID, errResponse := signedInUserID(c)
+	if errResponse != nil {
+		return errResponse
+	}
+	cmd.UserID = userID
+	credential, err := s.FinishRegistration(c.Req.Context(), &cmd)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to register passkey", err)
+	}
+	return response.JSON(http.StatusOK, credential)
+}
+
+func (s *Service) beginLoginHandler(c *contextmodel.ReqContext) response.Response {
+	cmd := webauthn.BeginLoginCommand{}
+	if err := web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	options, err := s.BeginLogin(c.Req.Context(), &cmd)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to start passkey sign in", err)
+	}
+	return response.JSON(http.StatusOK, options)
+}
+
+func signedInUserID(c *contextmodel.ReqContext) (int64, response.Response) {
+	if !c.SignedInUser.IsIdentityType(claims.TypeUser) {
+		return 0, response.Err(webauthn.ErrUnsupportedIdentity.Errorf("identity of type %s cannot register passkeys", c.SignedInUser.GetIdentityType()))
+	}
+	userID, err := c.SignedInUser.GetInternalID()
+	if err != nil {
+		return 0, response.Error(http.StatusInternalServerError, "Failed to parse user id", err)
+	}
+	return userID, nil
+}
@@ -0,0 +1,303 @@
+package webauthnimpl
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// COSE algorithm identifiers of the supported public keys, in order of
+// preference.
+const (
+	algES256 int64 = -7
+	algEdDSA int64 = -8
+	algRS256 int64 = -257
+)
+
+var supportedAlgorithms = []int64{algES256, algEdDSA, algRS256}
+
+// COSE key parameters, see RFC 9053.
+const (
+	coseKeyType      = 1
+	coseKeyAlgorithm = 3
+	coseKeyCurve     = -1 // EC2 and OKP keys
+	coseKeyX         = -2 // EC2 and OKP keys
+	coseKeyY         = -3 // EC2 keys
+	coseKeyN         = -1 // RSA keys
+	coseKeyE         = -2 // RSA keys
+
+	coseKeyTypeOKP = 1
+	coseKeyTypeEC2 = 2
+	coseKeyTypeRSA = 3
+
+	coseCurveP256    = 1
+	coseCurveEd25519 = 6
+)
+
+// authenticator data flags
+const (
+	flagUserPresent            = 0x01
+	flagUserVerified           = 0x04
+	flagAttestedCredentialData = 0x40
+)
+
+const (
+	ceremonyCreate = "webauthn.create"
+	ceremonyGet    = "webauthn.get"
+
+	maxCredentialIDLength = 1023
+	minRSAKeySize         = 2048
+)
+
+var errInvalidAuthenticatorData = errors.New("invalid authenticator data")
+
+// clientData is the CollectedClientData the browser passes to the
+// authenticator, the authenticator signs its hash.
+type clientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+// parseClientData parses the client data of a ceremony and checks its type
+// and origin. The challenge is checked by the caller.
+func parseClientData(raw []byte, ceremony string, origins []string) (*clientData, error) {
+	var data clientData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("invalid client data: %w", err)
+	}
+	if data.Type != ceremony {
+		return nil, fmt.Errorf("client data of type %q, expected %q", data.Type, ceremony)
+	}
+	if !slices.Contains(origins, data.Origin) {
+		return nil, fmt.Errorf("origin %q is not allowed", data.Origin)
+	}
+	if data.CrossOrigin {
+		return nil, errors.New("cross origin ceremonies are not allowed")
+	}
+	return &data, nil
+}
+
+// challenge decodes the base64url encoded challenge of the client data.
+func (d *clientData) challenge() []byte {
+	challenge, err := base64.RawURLEncoding.DecodeString(d.Challenge)
+	if err != nil {
+		return nil
+	}
+	return challenge
+}
+
+func (d *clientData) verifyChallenge(expected []byte) error {
+	if subtle.ConstantTimeCompare(d.challenge(), expected) != 1 {
+		return errors.New("challenge does not match")
+	}
+	return nil
+}
+
+// authenticatorData is the data signed by the authenticator. The attested
+// credential data is only included when a credential is registered.
+type authenticatorData struct {
+	rpIDHash     []byte
+	flags        byte
+	signCount    uint32
+	aaguid       []byte
+	credentialID []byte
+	publicKey    []byte
+}
+
+func parseAuthenticatorData(raw []byte) (*authenticatorData, error) {
+	if len(raw) < 37 {
+		return nil, errInvalidAuthenticatorData
+	}
+	data := &authenticatorData{
+		rpIDHash:  raw[:32],
+		flags:     raw[32],
+		signCount: binary.BigEndian.Uint32(raw[33:37]),
+	}
+	if data.flags&flagAttestedCredentialData == 0 {
+		return data, nil
+	}
+
+	rest := raw[37:]
+	if len(rest) < 18 {
+		return nil, errInvalidAuthenticatorData
+	}
+	data.aaguid = rest[:16]
+	length := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+	if length > maxCredentialIDLength || len(rest) < length {
+		return nil, errInvalidAuthenticatorData
+	}
+	data.credentialID = rest[:length]
+
+	// the public key is followed by the extensions, if any
+	var key cbor.RawMessage
+	remaining, err := cbor.UnmarshalFirst(rest[length:], &key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidAuthenticatorData, err)
+	}
+	data.publicKey = rest[length : len(rest)-len(remaining)]
+	return data, nil
+}
+
+// verify checks that the data is meant for the relying party and that the
+// user was present, and verified if required.
+func (d *authenticatorData) verify(rpID string, requireUserVerification bool) error {
+	hash := sha256.Sum256([]byte(rpID))
+	if !bytes.Equal(d.rpIDHash, hash[:]) {
+		return errors.New("relying party ID does not match")
+	}
+	if d.flags&flagUserPresent == 0 {
+		return errors.New("user was not present")
+	}
+	if requireUserVerification && d.flags&flagUserVerified == 0 {
+		return errors.New("user was not verified")
+	}
+	return nil
+}
+
+// attestationObject is the response of the authenticator to a registration.
+// Grafana requests no attestation, the attestation statement is not verified.
+type attestationObject struct {
+	Fmt      string          `cbor:"fmt"`
+	AttStmt  cbor.RawMessage `cbor:"attStmt"`
+	AuthData []byte          `cbor:"authData"`
+}
+
+func parseAttestationObject(raw []byte) (*attestationObject, error) {
+	var obj attestationObject
+	if err := cbor.Unmarshal(raw, &obj); err != nil {
+		return nil, fmt.Errorf("invalid attestation object: %w", err)
+	}
+	return &obj, nil
+}
+
+// parsePublicKey parses a COSE encoded public key of one of the supported
+// algorithms.
+func parsePublicKey(raw []byte) (crypto.PublicKey, int64, error) {
+	var params map[int]cbor.RawMessage
+	if err := cbor.Unmarshal(raw, &params); err != nil {
+		return nil, 0, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	var kty, alg int64
+	if err := decodeParam(params, coseKeyType, &kty); err != nil {
+		return nil, 0, err
+	}
+	if err := decodeParam(params, coseKeyAlgorithm, &alg); err != nil {
+		return nil, 0, err
+	}
+
+	switch {
+	case alg == algES256 && kty == coseKeyTypeEC2:
+		var crv int64
+		var x, y []byte
+		if err := decodeParams(params, map[int]any{coseKeyCurve: &crv, coseKeyX: &x, coseKeyY: &y}); err != nil {
+			return nil, 0, err
+		}
+		if crv != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return nil, 0, errors.New("invalid P-256 public key")
+		}
+		// ecdh validates that the point is on the curve
+		if _, err := ecdh.P256().NewPublicKey(slices.Concat([]byte{4}, x, y)); err != nil {
+			return nil, 0, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, alg, nil
+	case alg == algEdDSA && kty == coseKeyTypeOKP:
+		var crv int64
+		var x []byte
+		if err := decodeParams(params, map[int]any{coseKeyCurve: &crv, coseKeyX: &x}); err != nil {
+			return nil, 0, err
+		}
+		if crv != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, 0, errors.New("invalid Ed25519 public key")
+		}
+		return ed25519.PublicKey(x), alg, nil
+	case alg == algRS256 && kty == coseKeyTypeRSA:
+		var n, e []byte
+		if err := decodeParams(params, map[int]any{coseKeyN: &n, coseKeyE: &e}); err != nil {
+			return nil, 0, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, 0, errors.New("invalid RSA public exponent")
+		}
+		key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+		if key.N.BitLen() < minRSAKeySize {
+			return nil, 0, fmt.Errorf("RSA keys must have at least %d bits", minRSAKeySize)
+		}
+		return key, alg, nil
+	}
+	return nil, 0, fmt.Errorf("unsupported algorithm %d for key type %d", alg, kty)
+}
+
+func decodeParams(params map[int]cbor.RawMessage, values map[int]any) error {
+	for label, v := range values {
+		if err := decodeParam(params, label, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeParam(params map[int]cbor.RawMessage, label int, v any) error {
+	raw, ok := params[label]
+	if !ok {
+		return fmt.Errorf("public key parameter %d is missing", label)
+	}
+	if err := cbor.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("invalid public key parameter %d: %w", label, err)
+	}
+	return nil
+}
+
+// verifySignature verifies the signature of an assertion, which signs the
+// authenticator data followed by the hash of the client data.
+func verifySignature(publicKey []byte, authData, clientDataJSON, signature []byte) error {
+	key, alg, err := parsePublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := slices.Concat(authData, clientDataHash[:])
+	digest := sha256.Sum256(signed)
+
+	var valid bool
+	switch alg {
+	case algES256:
+		valid = ecdsa.VerifyASN1(key.(*ecdsa.PublicKey), digest[:], signature)
+	case algEdDSA:
+		valid = ed25519.Verify(key.(ed25519.PublicKey), signed, signature)
+	case algRS256:
+		valid = rsa.VerifyPKCS1v15(key.(*rsa.PublicKey), crypto.SHA256, digest[:], signature) == nil
+	}
+	if !valid {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// formatAAGUID formats the AAGUID of an authenticator like a UUID.
+func formatAAGUID(aaguid []byte) string {
+	if len(aaguid) != 16 {
+		return ""
+	}
+	return fmt.Sprintf("%x-%x-%x-%x-%x", aaguid[0:4], aaguid[4:6], aaguid[6:8], aaguid[8:10], aaguid[10:16])
+}
@@ -0,0 +1,155 @@
+package webauthnimpl
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClientData(t *testing.T) {
+	origins := []string{"https://grafana.example.com"}
+
+	t.Run("accepts client data of the ceremony and origin", func(t *testing.T) {
+		data, err := parseClientData([]byte(`{"type":"webauthn.get","challenge":"AQID","origin":"https://grafana.example.com"}`), ceremonyGet, origins)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{1, 2, 3}, data.challenge())
+		require.NoError(t, data.verifyChallenge([]byte{1, 2, 3}))
+		require.Error(t, data.verifyChallenge([]byte{1, 2, 4}))
+	})
+
+	t.Run("rejects other ceremonies", func(t *testing.T) {
+		_, err := parseClientData([]byte(`{"type":"webauthn.create","challenge":"AQID","origin":"https://grafana.example.com"}`), ceremonyGet, origins)
+		require.Error(t, err)
+	})
+
+	t.Run("rejects other origins", func(t *testing.T) {
+		_, err := parseClientData([]byte(`{"type":"webauthn.get","challenge":"AQID","origin":"https://grafana.example.com.evil.com"}`), ceremonyGet, origins)
+		require.Error(t, err)
+	})
+
+	t.Run("rejects cross origin iframes", func(t *testing.T) {
+		_, err := parseClientData([]byte(`{"type":"webauthn.get","challenge":"AQID","origin":"https://grafana.example.com","crossOrigin":true}`), ceremonyGet, origins)
+		require.Error(t, err)
+	})
+}
+
+func TestParseAuthenticatorData(t *testing.T) {
+	rpIDHash := sha256.Sum256([]byte("grafana.example.com"))
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	coseKey := ed25519COSEKey(t, key.Public().(ed25519.PublicKey))
+	aaguid := []byte{0xad, 0xce, 0x00, 0x02, 0x35, 0xbc, 0xc6, 0x0a, 0x64, 0x8b, 0x0b, 0x25, 0xf1, 0xf0, 0x55, 0x03}
+	credentialID := []byte{1, 2, 3, 4}
+
+	t.Run("parses attested credential data followed by extensions", func(t *testing.T) {
+		extensions, err := cbor.Marshal(map[string]any{"credProtect": 2})
+		require.NoError(t, err)
+		raw := slices.Concat(rpIDHash[:], []byte{flagUserPresent | flagAttestedCredentialData | 0x80}, []byte{0, 0, 0, 7},
+			aaguid, []byte{0, 4}, credentialID, coseKey, extensions)
+
+		data, err := parseAuthenticatorData(raw)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(7), data.signCount)
+		assert.Equal(t, credentialID, data.credentialID)
+		assert.Equal(t, coseKey, data.publicKey)
+		assert.Equal(t, "adce0002-35bc-c60a-648b-0b25f1f05503", formatAAGUID(data.aaguid))
+
+		require.NoError(t, data.verify("grafana.example.com", false))
+		require.Error(t, data.verify("grafana.example.com", true))
+		require.Error(t, data.verify("example.com", false))
+	})
+
+	t.Run("rejects truncated data", func(t *testing.T) {
+		_, err := parseAuthenticatorData(rpIDHash[:])
+		require.Error(t, err)
+
+		raw := slices.Concat(rpIDHash[:], []byte{flagUserPresent | flagAttestedCredentialData}, []byte{0, 0, 0, 0}, aaguid, []byte{0, 8}, credentialID)
+		_, err = parseAuthenticatorData(raw)
+		require.Error(t, err)
+	})
+
+	t.Run("requires the user to be present", func(t *testing.T) {
+		data, err := parseAuthenticatorData(slices.Concat(rpIDHash[:], []byte{flagUserVerified}, []byte{0, 0, 0, 0}))
+		require.NoError(t, err)
+		require.Error(t, data.verify("grafana.example.com", false))
+	})
+}
+
+func TestVerifySignature(t *testing.T) {
+	authData := []byte("authenticator data")
+	clientDataJSON := []byte(`{"type":"webauthn.get"}`)
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := slices.Concat(authData, clientDataHash[:])
+
+	t.Run("EdDSA", func(t *testing.T) {
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		coseKey := ed25519COSEKey(t, public)
+
+		require.NoError(t, verifySignature(coseKey, authData, clientDataJSON, ed25519.Sign(private, signed)))
+		require.Error(t, verifySignature(coseKey, authData, []byte("{}"), ed25519.Sign(private, signed)))
+	})
+
+	t.Run("RS256", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		coseKey := rsaCOSEKey(t, &key.PublicKey)
+		digest := sha256.Sum256(signed)
+		signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+
+		require.NoError(t, verifySignature(coseKey, authData, clientDataJSON, signature))
+		require.Error(t, verifySignature(coseKey, []byte("other data"), clientDataJSON, signature))
+	})
+}
+
+func TestParsePublicKey(t *testing.T) {
+	t.Run("rejects short RSA keys", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+		_, _, err = parsePublicKey(rsaCOSEKey(t, &key.PublicKey))
+		require.Error(t, err)
+	})
+
+	t.Run("rejects points that are not on the curve", func(t *testing.T) {
+		coseKey, err := cbor.Marshal(map[int]any{
+			coseKeyType: coseKeyTypeEC2, coseKeyAlgorithm: algES256, coseKeyCurve: coseCurveP256,
+			coseKeyX: make([]byte, 32), coseKeyY: make([]byte, 32),
+		})
+		require.NoError(t, err)
+		_, _, err = parsePublicKey(coseKey)
+		require.Error(t, err)
+	})
+
+	t.Run("rejects unsupported algorithms", func(t *testing.T) {
+		// ES384
+		coseKey, err := cbor.Marshal(map[int]any{coseKeyType: coseKeyTypeEC2, coseKeyAlgorithm: -35, coseKeyCurve: 2})
+		require.NoError(t, err)
+		_, _, err = parsePublicKey(coseKey)
+		require.Error(t, err)
+	})
+}
+
+func ed25519COSEKey(t *testing.T, key ed25519.PublicKey) []byte {
+	t.Helper()
+	coseKey, err := cbor.Marshal(map[int]any{coseKeyType: coseKeyTypeOKP, coseKeyAlgorithm: algEdDSA, coseKeyCurve: coseCurveEd25519, coseKeyX: []byte(key)})
+	require.NoError(t, err)
+	return coseKey
+}
+
+func rsaCOSEKey(t *testing.T, key *rsa.PublicKey) []byte {
+	t.Helper()
+	e := big.NewInt(int64(key.E)).Bytes()
+	coseKey, err := cbor.Marshal(map[int]any{coseKeyType: coseKeyTypeRSA, coseKeyAlgorithm: algRS256, coseKeyN: key.N.Bytes(), coseKeyE: e})
+	require.NoError(t, err)
+	return coseKey
+}
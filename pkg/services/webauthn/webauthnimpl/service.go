@@ -0,0 +1,409 @@
+package webauthnimpl
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/remotecache"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/services/webauthn"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const (
+	registrationCacheKey = "webauthn-registration-%d"
+	loginCacheKey        = "webauthn-login-%s"
+
+	challengeSize           = 32
+	maxCredentialNameLength = 190
+	defaultCredentialName   = "Passkey"
+)
+
+var _ webauthn.Service = (*Service)(nil)
+
+// Service implements the relying party of WebAuthn. Grafana does not request
+// attestation, so any authenticator can be registered, and it supports the
+// ES256, EdDSA and RS256 algorithms. The challenge of a ceremony is kept in
+// the remote cache between its two steps.
+type Service struct {
+	cfg           *setting.Cfg
+	store         *store
+	log           log.Logger
+	routeRegister routing.RouteRegister
+	userService   user.Service
+	cache         remotecache.CacheStorage
+	now           func() time.Time
+}
+
+func ProvideService(cfg *setting.Cfg, sqlStore db.DB, routeRegister routing.RouteRegister, userService user.Service, cache remotecache.CacheStorage) *Service {
+	s := &Service{
+		cfg:           cfg,
+		store:         &store{db: sqlStore},
+		log:           log.New("webauthn"),
+		routeRegister: routeRegister,
+		userService:   userService,
+		cache:         cache,
+		now:           time.Now,
+	}
+
+	if cfg.WebAuthn.Enabled {
+		s.registerAPIEndpoints()
+	}
+	return s
+}
+
+// registrationSession is the state of a registration between its two steps.
+type registrationSession struct {
+	Challenge []byte `json:"challenge"`
+}
+
+// loginSession is the state of a sign in between its two steps. It is stored
+// under the challenge, which the assertion includes in its client data. When
+// the sign in was started for a login, only credentials of that user are
+// accepted.
+type loginSession struct {
+	UserID int64 `json:"userId,omitempty"`
+}
+
+func (s *Service) BeginRegistration(ctx context.Context, userID int64) (*webauthn.CreationOptions, error) {
+	usr, err := s.userService.GetByID(ctx, &user.GetUserByIDQuery{ID: userID})
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := s.store.listByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := newChallenge()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.saveSession(ctx, fmt.Sprintf(registrationCacheKey, userID), registrationSession{Challenge: challenge}); err != nil {
+		return nil, err
+	}
+
+	params := make([]webauthn.CredentialParameter, 0, len(supportedAlgorithms))
+	for _, alg := range supportedAlgorithms {
+		params = append(params, webauthn.CredentialParameter{Type: webauthn.PublicKeyCredentialType, Alg: alg})
+	}
+	displayName := usr.Name
+	if displayName == "" {
+		displayName = usr.Login
+	}
+
+	return &webauthn.CreationOptions{
+		RP:               webauthn.RelyingParty{ID: s.cfg.WebAuthn.RPID, Name: s.cfg.WebAuthn.RPName},
+		User:             webauthn.UserEntity{ID: userHandle(usr), Name: usr.Login, DisplayName: displayName},
+		Challenge:        challenge,
+		PubKeyCredParams: params,
+		Timeout:          s.cfg.WebAuthn.Timeout.Milliseconds(),
+		// the credentials of the user are excluded, so that an authenticator
+		// is not registered twice
+		ExcludeCredentials: descriptors(credentials),
+		AuthenticatorSelection: webauthn.AuthenticatorSelection{
+			ResidentKey:      "preferred",
+			UserVerification: s.cfg.WebAuthn.UserVerification,
+		},
+		Attestation: "none",
+	}, nil
+}
+
+func (s *Service) FinishRegistration(ctx context.Context, cmd *webauthn.FinishRegistrationCommand) (*webauthn.CredentialDTO, error) {
+	name := strings.TrimSpace(cmd.Name)
+	if len(name) > maxCredentialNameLength {
+		return nil, webauthn.ErrInvalidCredentialName.Errorf("credential name is %d characters long", len(name))
+	}
+	if name == "" {
+		name = defaultCredentialName
+	}
+
+	var session registrationSession
+	if err := s.takeSession(ctx, fmt.Sprintf(registrationCacheKey, cmd.UserID), &session); err != nil {
+		return nil, err
+	}
+
+	authData, err := s.verifyRegistration(&cmd.Credential, session.Challenge)
+	if err != nil {
+		return nil, webauthn.ErrRegistrationFailed.Errorf("failed to verify registration of user %d: %w", cmd.UserID, err)
+	}
+
+	hash := hashCredentialID(authData.credentialID)
+	existing, err := s.store.getByCredentialIDHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, webauthn.ErrCredentialExists.Errorf("credential is already registered for user %d", existing.UserID)
+	}
+
+	credential := &webauthn.Credential{
+		UID:              util.GenerateShortUID(),
+		UserID:           cmd.UserID,
+		Name:             name,
+		CredentialID:     base64.RawURLEncoding.EncodeToString(authData.credentialID),
+		CredentialIDHash: hash,
+		PublicKey:        authData.publicKey,
+		SignCount:        int64(authData.signCount),
+		AAGUID:           formatAAGUID(authData.aaguid),
+		Transports:       strings.Join(cmd.Credential.Response.Transports, ","),
+		Created:          s.now(),
+	}
+	if err := s.store.create(ctx, credential); err != nil {
+		return nil, err
+	}
+	return toDTO(credential), nil
+}
+
+func (s *Service) verifyRegistration(response *webauthn.RegistrationResponse, challenge []byte) (*authenticatorData, error) {
+	if response.Type != webauthn.PublicKeyCredentialType {
+		return nil, fmt.Errorf("unsupported credential type %q", response.Type)
+	}
+	clientData, err := parseClientData(response.Response.ClientDataJSON, ceremonyCreate, s.cfg.WebAuthn.Origins)
+	if err != nil {
+		return nil, err
+	}
+	if err := clientData.verifyChallenge(challenge); err != nil {
+		return nil, err
+	}
+
+	attestation, err := parseAttestationObject(response.Response.AttestationObject)
+	if err != nil {
+		return nil, err
+	}
+	authData, err := parseAuthenticatorData(attestation.AuthData)
+	if err != nil {
+		return nil, err
+	}
+	if err := authData.verify(s.cfg.WebAuthn.RPID, s.requireUserVerification()); err != nil {
+		return nil, err
+	}
+	if authData.credentialID == nil {
+		return nil, errors.New("authenticator data has no attested credential")
+	}
+	if !bytes.Equal(authData.credentialID, response.RawID) {
+		return nil, errors.New("credential ID does not match")
+	}
+	if _, _, err := parsePublicKey(authData.publicKey); err != nil {
+		return nil, err
+	}
+	return authData, nil
+}
+
+func (s *Service) BeginLogin(ctx context.Context, cmd *webauthn.BeginLoginCommand) (*webauthn.RequestOptions, error) {
+	session := loginSession{}
+	allowCredentials := []webauthn.CredentialDescriptor{}
+
+	if cmd.Login != "" {
+		usr, err := s.userService.GetByLogin(ctx, &user.GetUserByLoginQuery{LoginOrEmail: cmd.Login})
+		if err != nil && !errors.Is(err, user.ErrUserNotFound) {
+			return nil, err
+		}
+		// unknown logins fall back to discoverable credentials
+		if usr != nil {
+			credentials, err := s.store.listByUser(ctx, usr.ID)
+			if err != nil {
+				return nil, err
+			}
+			allowCredentials = descriptors(credentials)
+			session.UserID = usr.ID
+		}
+	}
+
+	challenge, err := newChallenge()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.saveSession(ctx, fmt.Sprintf(loginCacheKey, base64.RawURLEncoding.EncodeToString(challenge)), session); err != nil {
+		return nil, err
+	}
+
+	return &webauthn.RequestOptions{
+		Challenge:        challenge,
+		Timeout:          s.cfg.WebAuthn.Timeout.Milliseconds(),
+		RPID:             s.cfg.WebAuthn.RPID,
+		AllowCredentials: allowCredentials,
+		UserVerification: s.cfg.WebAuthn.UserVerification,
+	}, nil
+}
+
+func (s *Service) FinishLogin(ctx context.Context, response *webauthn.AuthenticationResponse) (int64, error) {
+	if response.Type != webauthn.PublicKeyCredentialType {
+		return 0, webauthn.ErrInvalidCredential.Errorf("unsupported credential type %q", response.Type)
+	}
+	clientData, err := parseClientData(response.Response.ClientDataJSON, ceremonyGet, s.cfg.WebAuthn.Origins)
+	if err != nil {
+		return 0, webauthn.ErrInvalidCredential.Errorf("invalid assertion: %w", err)
+	}
+	challenge := clientData.challenge()
+	if len(challenge) != challengeSize {
+		return 0, webauthn.ErrInvalidCredential.Errorf("invalid challenge")
+	}
+
+	// the session is removed, so that the challenge can only be used once
+	var session loginSession
+	if err := s.takeSession(ctx, fmt.Sprintf(loginCacheKey, base64.RawURLEncoding.EncodeToString(challenge)), &session); err != nil {
+		return 0, err
+	}
+
+	credential, err := s.store.getByCredentialIDHash(ctx, hashCredentialID(response.RawID))
+	if err != nil {
+		return 0, err
+	}
+	if credential == nil {
+		return 0, webauthn.ErrInvalidCredential.Errorf("credential is not registered")
+	}
+	if session.UserID != 0 && session.UserID != credential.UserID {
+		return 0, webauthn.ErrInvalidCredential.Errorf("credential of user %d used to sign in user %d", credential.UserID, session.UserID)
+	}
+
+	authData, err := parseAuthenticatorData(response.Response.AuthenticatorData)
+	if err != nil {
+		return 0, webauthn.ErrInvalidCredential.Errorf("invalid assertion: %w", err)
+	}
+	if err := authData.verify(s.cfg.WebAuthn.RPID, s.requireUserVerification()); err != nil {
+		return 0, webauthn.ErrInvalidCredential.Errorf("invalid assertion: %w", err)
+	}
+	if err := verifySignature(credential.PublicKey, response.Response.AuthenticatorData, response.Response.ClientDataJSON, response.Response.Signature); err != nil {
+		return 0, webauthn.ErrInvalidCredential.Errorf("invalid assertion: %w", err)
+	}
+
+	if len(response.Response.UserHandle) > 0 {
+		usr, err := s.userService.GetByID(ctx, &user.GetUserByIDQuery{ID: credential.UserID})
+		if err != nil {
+			return 0, err
+		}
+		if !bytes.Equal(response.Response.UserHandle, userHandle(usr)) {
+			return 0, webauthn.ErrInvalidCredential.Errorf("user handle does not match the owner of the credential")
+		}
+	}
+
+	// authenticators that do not implement the signature counter always
+	// report zero
+	signCount := int64(authData.signCount)
+	previousSignCount := credential.SignCount
+	if (signCount != 0 || previousSignCount != 0) && signCount <= previousSignCount {
+		s.log.Warn("Signature counter of credential did not increase, the credential may be cloned", "user", credential.UserID, "credential", credential.UID)
+		return 0, webauthn.ErrInvalidCredential.Errorf("signature counter did not increase")
+	}
+
+	now := s.now()
+	credential.SignCount = signCount
+	credential.LastUsed = &now
+	ok, err := s.store.updateUsage(ctx, credential, previousSignCount)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, webauthn.ErrInvalidCredential.Errorf("credential was used by a concurrent request")
+	}
+	return credential.UserID, nil
+}
+
+func (s *Service) ListCredentials(ctx context.Context, userID int64) ([]*webauthn.CredentialDTO, error) {
+	credentials, err := s.store.listByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	dtos := make([]*webauthn.CredentialDTO, 0, len(credentials))
+	for _, credential := range credentials {
+		dtos = append(dtos, toDTO(credential))
+	}
+	return dtos, nil
+}
+
+func (s *Service) DeleteCredential(ctx context.Context, userID int64, uid string) error {
+	deleted, err := s.store.delete(ctx, userID, uid)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return webauthn.ErrCredentialNotFound.Errorf("user %d has no credential %s", userID, uid)
+	}
+	return nil
+}
+
+func (s *Service) requireUserVerification() bool {
+	return s.cfg.WebAuthn.UserVerification == webauthn.UserVerificationRequired
+}
+
+func (s *Service) saveSession(ctx context.Context, key string, session any) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	return s.cache.Set(ctx, key, data, s.cfg.WebAuthn.Timeout)
+}
+
+// takeSession reads and removes the state of a ceremony.
+func (s *Service) takeSession(ctx context.Context, key string, session any) error {
+	data, err := s.cache.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, remotecache.ErrCacheItemNotFound) {
+			return webauthn.ErrChallengeExpired.Errorf("no ceremony in progress for %s", key)
+		}
+		return err
+	}
+	if err := s.cache.Delete(ctx, key); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, session)
+}
+
+func newChallenge() ([]byte, error) {
+	challenge := make([]byte, challengeSize)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+// userHandle is the ID of the user on their credentials. Authenticators
+// return it when the user picks a discoverable credential.
+func userHandle(usr *user.User) []byte {
+	return []byte(usr.UID)
+}
+
+func hashCredentialID(credentialID []byte) string {
+	hash := sha256.Sum256(credentialID)
+	return hex.EncodeToString(hash[:])
+}
+
+func descriptors(credentials []*webauthn.Credential) []webauthn.CredentialDescriptor {
+	result := make([]webauthn.CredentialDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		id, err := base64.RawURLEncoding.DecodeString(credential.CredentialID)
+		if err != nil {
+			continue
+		}
+		result = append(result, webauthn.CredentialDescriptor{
+			Type:       webauthn.PublicKeyCredentialType,
+			ID:         id,
+			Transports: util.SplitString(credential.Transports),
+		})
+	}
+	return result
+}
+
+func toDTO(credential *webauthn.Credential) *webauthn.CredentialDTO {
+	return &webauthn.CredentialDTO{
+		UID:        credential.UID,
+		Name:       credential.Name,
+		AAGUID:     credential.AAGUID,
+		Transports: util.SplitString(credential.Transports),
+		Created:    credential.Created,
+		LastUsed:   credential.LastUsed,
+	}
+}
@@ -0,0 +1,281 @@
+package webauthnimpl
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/remotecache"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/services/user/usertest"
+	"github.com/grafana/grafana/pkg/services/webauthn"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/tests/testsuite"
+)
+
+const testOrigin = "https://grafana.example.com"
+
+func TestMain(m *testing.M) {
+	testsuite.Run(m)
+}
+
+func TestIntegrationService(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	owner := &user.User{ID: 1, UID: "owner-uid", Login: "owner", Name: "Owner"}
+	userService := &usertest.FakeUserService{ExpectedUser: owner}
+	now := time.Unix(1700000000, 0)
+	s := &Service{
+		cfg: &setting.Cfg{WebAuthn: setting.WebAuthnSettings{
+			Enabled:          true,
+			RPID:             "grafana.example.com",
+			RPName:           "Grafana",
+			Origins:          []string{testOrigin},
+			UserVerification: webauthn.UserVerificationPreferred,
+			Timeout:          5 * time.Minute,
+		}},
+		store:       &store{db: db.InitTestDB(t)},
+		log:         log.NewNopLogger(),
+		userService: userService,
+		cache:       remotecache.NewFakeCacheStorage(),
+		now:         func() time.Time { return now },
+	}
+
+	authenticator := newSoftwareAuthenticator(t, testOrigin)
+	register := func(a *softwareAuthenticator) (*webauthn.CredentialDTO, error) {
+		options, err := s.BeginRegistration(ctx, owner.ID)
+		require.NoError(t, err)
+		return s.FinishRegistration(ctx, &webauthn.FinishRegistrationCommand{UserID: owner.ID, Name: "laptop", Credential: a.create(t, options)})
+	}
+	login := func(a *softwareAuthenticator, cmd *webauthn.BeginLoginCommand) (int64, error) {
+		options, err := s.BeginLogin(ctx, cmd)
+		require.NoError(t, err)
+		return s.FinishLogin(ctx, a.get(t, options))
+	}
+
+	t.Run("registration requires a challenge", func(t *testing.T) {
+		options := &webauthn.CreationOptions{RP: webauthn.RelyingParty{ID: "grafana.example.com"}, Challenge: make([]byte, challengeSize)}
+		_, err := s.FinishRegistration(ctx, &webauthn.FinishRegistrationCommand{UserID: owner.ID, Credential: authenticator.create(t, options)})
+		require.ErrorIs(t, err, webauthn.ErrChallengeExpired)
+	})
+
+	t.Run("registration returns options for the user", func(t *testing.T) {
+		options, err := s.BeginRegistration(ctx, owner.ID)
+		require.NoError(t, err)
+		assert.Equal(t, webauthn.RelyingParty{ID: "grafana.example.com", Name: "Grafana"}, options.RP)
+		assert.Equal(t, webauthn.UserEntity{ID: []byte("owner-uid"), Name: "owner", DisplayName: "Owner"}, options.User)
+		assert.Len(t, options.Challenge, challengeSize)
+		assert.Empty(t, options.ExcludeCredentials)
+		assert.Equal(t, int64(300000), options.Timeout)
+	})
+
+	t.Run("users register an authenticator", func(t *testing.T) {
+		credential, err := register(authenticator)
+		require.NoError(t, err)
+		assert.Equal(t, "laptop", credential.Name)
+		assert.Equal(t, []string{"internal"}, credential.Transports)
+		assert.Equal(t, "00000000-0000-0000-0000-000000000000", credential.AAGUID)
+
+		credentials, err := s.ListCredentials(ctx, owner.ID)
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+		assert.Equal(t, credential.UID, credentials[0].UID)
+		assert.Nil(t, credentials[0].LastUsed)
+
+		options, err := s.BeginRegistration(ctx, owner.ID)
+		require.NoError(t, err)
+		require.Len(t, options.ExcludeCredentials, 1)
+		assert.Equal(t, webauthn.URLEncodedBase64(authenticator.credentialID), options.ExcludeCredentials[0].ID)
+	})
+
+	t.Run("an authenticator is registered once", func(t *testing.T) {
+		_, err := register(authenticator)
+		require.ErrorIs(t, err, webauthn.ErrCredentialExists)
+	})
+
+	t.Run("registrations from other origins are rejected", func(t *testing.T) {
+		_, err := register(newSoftwareAuthenticator(t, "https://evil.example.com"))
+		require.ErrorIs(t, err, webauthn.ErrRegistrationFailed)
+	})
+
+	t.Run("users sign in with a discoverable credential", func(t *testing.T) {
+		userID, err := login(authenticator, &webauthn.BeginLoginCommand{})
+		require.NoError(t, err)
+		assert.Equal(t, owner.ID, userID)
+
+		credentials, err := s.ListCredentials(ctx, owner.ID)
+		require.NoError(t, err)
+		require.NotNil(t, credentials[0].LastUsed)
+		assert.True(t, now.Equal(*credentials[0].LastUsed))
+	})
+
+	t.Run("users sign in with the credentials of their login", func(t *testing.T) {
+		options, err := s.BeginLogin(ctx, &webauthn.BeginLoginCommand{Login: "owner"})
+		require.NoError(t, err)
+		require.Len(t, options.AllowCredentials, 1)
+		assert.Equal(t, webauthn.URLEncodedBase64(authenticator.credentialID), options.AllowCredentials[0].ID)
+
+		userID, err := s.FinishLogin(ctx, authenticator.get(t, options))
+		require.NoError(t, err)
+		assert.Equal(t, owner.ID, userID)
+	})
+
+	t.Run("assertions cannot be replayed", func(t *testing.T) {
+		options, err := s.BeginLogin(ctx, &webauthn.BeginLoginCommand{})
+		require.NoError(t, err)
+		assertion := authenticator.get(t, options)
+		_, err = s.FinishLogin(ctx, assertion)
+		require.NoError(t, err)
+		_, err = s.FinishLogin(ctx, assertion)
+		require.ErrorIs(t, err, webauthn.ErrChallengeExpired)
+	})
+
+	t.Run("credentials of other users are rejected for a login", func(t *testing.T) {
+		userService.ExpectedUser = &user.User{ID: 2, UID: "other-uid", Login: "other"}
+		defer func() { userService.ExpectedUser = owner }()
+		_, err := login(authenticator, &webauthn.BeginLoginCommand{Login: "other"})
+		require.ErrorIs(t, err, webauthn.ErrInvalidCredential)
+	})
+
+	t.Run("assertions with an invalid signature are rejected", func(t *testing.T) {
+		options, err := s.BeginLogin(ctx, &webauthn.BeginLoginCommand{})
+		require.NoError(t, err)
+		assertion := authenticator.get(t, options)
+		assertion.Response.Signature[len(assertion.Response.Signature)-1] ^= 0xff
+		_, err = s.FinishLogin(ctx, assertion)
+		require.ErrorIs(t, err, webauthn.ErrInvalidCredential)
+	})
+
+	t.Run("cloned authenticators are rejected", func(t *testing.T) {
+		clone := *authenticator
+		clone.signCount = 0
+		_, err := login(&clone, &webauthn.BeginLoginCommand{})
+		require.ErrorIs(t, err, webauthn.ErrInvalidCredential)
+
+		// the original authenticator can still be used
+		_, err = login(authenticator, &webauthn.BeginLoginCommand{})
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown credentials are rejected", func(t *testing.T) {
+		_, err := login(newSoftwareAuthenticator(t, testOrigin), &webauthn.BeginLoginCommand{})
+		require.ErrorIs(t, err, webauthn.ErrInvalidCredential)
+	})
+
+	t.Run("deleted credentials cannot be used", func(t *testing.T) {
+		credentials, err := s.ListCredentials(ctx, owner.ID)
+		require.NoError(t, err)
+		require.ErrorIs(t, s.DeleteCredential(ctx, 2, credentials[0].UID), webauthn.ErrCredentialNotFound)
+		require.NoError(t, s.DeleteCredential(ctx, owner.ID, credentials[0].UID))
+
+		_, err = login(authenticator, &webauthn.BeginLoginCommand{})
+		require.ErrorIs(t, err, webauthn.ErrInvalidCredential)
+	})
+}
+
+// softwareAuthenticator is an authenticator that keeps a single ES256
+// credential in memory, like the virtual authenticators of browsers.
+type softwareAuthenticator struct {
+	origin       string
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	userHandle   []byte
+	signCount    uint32
+}
+
+func newSoftwareAuthenticator(t *testing.T, origin string) *softwareAuthenticator {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	credentialID := make([]byte, 16)
+	_, err = rand.Read(credentialID)
+	require.NoError(t, err)
+	return &softwareAuthenticator{origin: origin, key: key, credentialID: credentialID}
+}
+
+// create makes the credential for the options of a registration.
+func (a *softwareAuthenticator) create(t *testing.T, options *webauthn.CreationOptions) webauthn.RegistrationResponse {
+	t.Helper()
+	publicKey, err := a.key.PublicKey.ECDH()
+	require.NoError(t, err)
+	point := publicKey.Bytes()
+	coseKey, err := cbor.Marshal(map[int]any{
+		coseKeyType:      coseKeyTypeEC2,
+		coseKeyAlgorithm: algES256,
+		coseKeyCurve:     coseCurveP256,
+		coseKeyX:         point[1:33],
+		coseKeyY:         point[33:],
+	})
+	require.NoError(t, err)
+
+	attested := slices.Concat(make([]byte, 16), binary.BigEndian.AppendUint16(nil, uint16(len(a.credentialID))), a.credentialID, coseKey)
+	authData := a.authenticatorData(options.RP.ID, flagUserPresent|flagUserVerified|flagAttestedCredentialData, attested)
+	attestationObject, err := cbor.Marshal(map[string]any{"fmt": "none", "attStmt": map[string]any{}, "authData": authData})
+	require.NoError(t, err)
+
+	a.userHandle = options.User.ID
+	return webauthn.RegistrationResponse{
+		ID:    webauthn.URLEncodedBase64(a.credentialID).String(),
+		RawID: a.credentialID,
+		Type:  webauthn.PublicKeyCredentialType,
+		Response: webauthn.AuthenticatorAttestationResponse{
+			ClientDataJSON:    a.clientData(t, ceremonyCreate, options.Challenge),
+			AttestationObject: attestationObject,
+			Transports:        []string{"internal"},
+		},
+	}
+}
+
+// get signs an assertion for the options of a sign in.
+func (a *softwareAuthenticator) get(t *testing.T, options *webauthn.RequestOptions) *webauthn.AuthenticationResponse {
+	t.Helper()
+	a.signCount++
+	clientDataJSON := a.clientData(t, ceremonyGet, options.Challenge)
+	authData := a.authenticatorData(options.RPID, flagUserPresent|flagUserVerified, nil)
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(slices.Concat(authData, clientDataHash[:]))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	require.NoError(t, err)
+
+	return &webauthn.AuthenticationResponse{
+		ID:    webauthn.URLEncodedBase64(a.credentialID).String(),
+		RawID: a.credentialID,
+		Type:  webauthn.PublicKeyCredentialType,
+		Response: webauthn.AuthenticatorAssertionResponse{
+			ClientDataJSON:    clientDataJSON,
+			AuthenticatorData: authData,
+			Signature:         signature,
+			UserHandle:        a.userHandle,
+		},
+	}
+}
+
+func (a *softwareAuthenticator) clientData(t *testing.T, ceremony string, challenge webauthn.URLEncodedBase64) []byte {
+	t.Helper()
+	data, err := json.Marshal(clientData{Type: ceremony, Challenge: challenge.String(), Origin: a.origin})
+	require.NoError(t, err)
+	return data
+}
+
+func (a *softwareAuthenticator) authenticatorData(rpID string, flags byte, attested []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append(rpIDHash[:], flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	return append(data, attested...)
+}
@@ -0,0 +1,69 @@
+package webauthnimpl
+
+import (
+	"context"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/webauthn"
+)
+
+type store struct {
+	db db.DB
+}
+
+func (s *store) create(ctx context.Context, credential *webauthn.Credential) error {
+	return s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Insert(credential)
+		return err
+	})
+}
+
+// getByCredentialIDHash returns the credential with the given credential ID
+// hash, or nil when there is none.
+func (s *store) getByCredentialIDHash(ctx context.Context, hash string) (*webauthn.Credential, error) {
+	var credential webauthn.Credential
+	var has bool
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		has, err = sess.Where("credential_id_hash = ?", hash).Get(&credential)
+		return err
+	})
+	if err != nil || !has {
+		return nil, err
+	}
+	return &credential, nil
+}
+
+func (s *store) listByUser(ctx context.Context, userID int64) ([]*webauthn.Credential, error) {
+	credentials := make([]*webauthn.Credential, 0)
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Where("user_id = ?", userID).Asc("id").Find(&credentials)
+	})
+	return credentials, err
+}
+
+// delete removes a credential of a user and returns whether there was one.
+func (s *store) delete(ctx context.Context, userID int64, uid string) (bool, error) {
+	var affected int64
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		affected, err = sess.Where("user_id = ? AND uid = ?", userID, uid).Delete(&webauthn.Credential{})
+		return err
+	})
+	return affected > 0, err
+}
+
+// updateUsage stores the signature counter of an assertion. It returns false
+// when the counter was changed since the credential was read, so that an
+// assertion cannot be used by concurrent requests.
+func (s *store) updateUsage(ctx context.Context, credential *webauthn.Credential, previousSignCount int64) (bool, error) {
+	var affected int64
+	err := s.db.WithDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		affected, err = sess.Where("id = ? AND sign_count = ?", credential.ID, previousSignCount).
+			Cols("sign_count", "last_used").
+			Update(credential)
+		return err
+	})
+	return affected > 0, err
+}
@@ -0,0 +1,42 @@
+package webauthntest
+
+import (
+	"context"
+
+	"github.com/grafana/grafana/pkg/services/webauthn"
+)
+
+var _ webauthn.Service = (*FakeService)(nil)
+
+type FakeService struct {
+	ExpectedCreationOptions *webauthn.CreationOptions
+	ExpectedRequestOptions  *webauthn.RequestOptions
+	ExpectedCredential      *webauthn.CredentialDTO
+	ExpectedCredentials     []*webauthn.CredentialDTO
+	ExpectedUserID          int64
+	ExpectedErr             error
+}
+
+func (f *FakeService) BeginRegistration(ctx context.Context, userID int64) (*webauthn.CreationOptions, error) {
+	return f.ExpectedCreationOptions, f.ExpectedErr
+}
+
+func (f *FakeService) FinishRegistration(ctx context.Context, cmd *webauthn.FinishRegistrationCommand) (*webauthn.CredentialDTO, error) {
+	return f.ExpectedCredential, f.ExpectedErr
+}
+
+func (f *FakeService) BeginLogin(ctx context.Context, cmd *webauthn.BeginLoginCommand) (*webauthn.RequestOptions, error) {
+	return f.ExpectedRequestOptions, f.ExpectedErr
+}
+
+func (f *FakeService) FinishLogin(ctx context.Context, response *webauthn.AuthenticationResponse) (int64, error) {
+	return f.ExpectedUserID, f.ExpectedErr
+}
+
+func (f *FakeService) ListCredentials(ctx context.Context, userID int64) ([]*webauthn.CredentialDTO, error) {
+	return f.ExpectedCredentials, f.ExpectedErr
+}
+
+func (f *FakeService) DeleteCredential(ctx context.Context, userID int64, uid string) error {
+	return f.ExpectedErr
+}
@@ -278,6 +278,14 @@ type Cfg struct {
 
 	PasswordlessMagicLinkAuth AuthPasswordlessMagicLinkSettings
 
+	TeamSync TeamSyncSettings
+
+	TwoFactor TwoFactorSettings
+
+	WebAuthn WebAuthnSettings
+
+	MTLSAuth MTLSSettings
+
 	// SSO Settings Auth
 	SSOSettingsReloadInterval        time.Duration
 	SSOSettingsConfigurableProviders map[string]bool
@@ -300,6 +308,9 @@ type Cfg struct {
 	// DistributedCache
 	RemoteCacheOptions *RemoteCacheSettings
 
+	// Query and resource caching
+	QueryCaching QueryCachingSettings
+
 	// Deprecated: no longer used
 	ViewersCanEdit bool
 
@@ -354,16 +365,24 @@ type Cfg struct {
 	SqlDatasourceMaxOpenConnsDefault    int
 	SqlDatasourceMaxIdleConnsDefault    int
 	SqlDatasourceMaxConnLifetimeDefault int
+	SqlDatasourceSQLiteAllowedDirectory string
 
 	// Snapshots
 	SnapshotEnabled      bool
 	ExternalSnapshotUrl  string
 	ExternalSnapshotName string
 	ExternalEnabled      bool
+	// ExternalSnapshotAPIKey is sent to the external snapshot server when publishing and deleting snapshots.
+	ExternalSnapshotAPIKey string
 
 	// Only used in https://snapshots.raintank.io/
 	SnapshotPublicMode bool
 
+	// External snapshot server mode. Other Grafana instances publish
+	// their external snapshots to this instance with a service account token.
+	SnapshotExternalServerEnabled    bool
+	SnapshotExternalServerMaxExpires time.Duration
+
 	ErrTemplateName string
 
 	StackID string
@@ -438,6 +457,9 @@ type Cfg struct {
 	// SQLExpressionTimeoutSeconds is the duration a SQL expression will run before timing out
 	SQLExpressionTimeout time.Duration
 
+	// SQLExpressionCatalogEnabled specifies whether SQL expressions can query the read-only grafana catalog tables.
+	SQLExpressionCatalogEnabled bool
+
 	ImageUploadProvider string
 
 	// LiveMaxConnections is a maximum number of WebSocket connections to
@@ -455,6 +477,9 @@ type Cfg struct {
 	// LiveAllowedOrigins is a set of origins accepted by Live. If not provided
 	// then Live uses AppURL as the only allowed origin.
 	LiveAllowedOrigins []string
+	// LivePipelineEnabled enables processing of Live data with channel rules
+	// and write configs stored in the Grafana database.
+	LivePipelineEnabled bool
 	// LiveMessageSizeLimit is the maximum size in bytes of Websocket messages
 	// from clients. Defaults to 64KB.
 	LiveMessageSizeLimit int
@@ -823,6 +848,7 @@ func (cfg *Cfg) readExpressionsSettings() {
 	cfg.SQLExpressionCellLimit = expressions.Key("sql_expression_cell_limit").MustInt64(100000)
 	cfg.SQLExpressionOutputCellLimit = expressions.Key("sql_expression_output_cell_limit").MustInt64(100000)
 	cfg.SQLExpressionTimeout = expressions.Key("sql_expression_timeout").MustDuration(time.Second * 10)
+	cfg.SQLExpressionCatalogEnabled = expressions.Key("sql_expression_catalog_enabled").MustBool(false)
 }
 
 type AnnotationCleanupSettings struct {
@@ -1317,6 +1343,12 @@ func (cfg *Cfg) parseINIFile(iniFile *ini.File) error {
 	cfg.readAuthProxySettings()
 	cfg.readSessionConfig()
 	cfg.readPasswordlessMagicLinkSettings()
+	cfg.readTeamSyncSettings()
+	cfg.readTwoFactorSettings()
+	cfg.readWebAuthnSettings()
+	if err := cfg.readMTLSSettings(); err != nil {
+		return err
+	}
 	if err := cfg.readSmtpSettings(); err != nil {
 		return err
 	}
@@ -1385,6 +1417,7 @@ func (cfg *Cfg) parseINIFile(iniFile *ini.File) error {
 	cfg.GeomapEnableCustomBaseLayers = geomapSection.Key("enable_custom_baselayers").MustBool(true)
 
 	cfg.readRemoteCacheSettings()
+	cfg.readQueryCachingSettings()
 	cfg.readDateFormats()
 	cfg.readGrafanaJavascriptAgentConfig()
 
@@ -1876,10 +1909,14 @@ func readSnapshotsSettings(cfg *Cfg, iniFile *ini.File) error {
 
 	cfg.ExternalSnapshotUrl = valueAsString(snapshots, "external_snapshot_url", "")
 	cfg.ExternalSnapshotName = valueAsString(snapshots, "external_snapshot_name", "")
+	cfg.ExternalSnapshotAPIKey = valueAsString(snapshots, "external_snapshot_api_key", "")
 
 	cfg.ExternalEnabled = snapshots.Key("external_enabled").MustBool(true)
 	cfg.SnapshotPublicMode = snapshots.Key("public_mode").MustBool(false)
 
+	cfg.SnapshotExternalServerEnabled = snapshots.Key("external_server_enabled").MustBool(false)
+	cfg.SnapshotExternalServerMaxExpires = snapshots.Key("external_server_max_expires").MustDuration(0)
+
 	return nil
 }
 
@@ -2004,6 +2041,7 @@ func (cfg *Cfg) readSqlDataSourceSettings() {
 	cfg.SqlDatasourceMaxOpenConnsDefault = sqlDatasources.Key("max_open_conns_default").MustInt(100)
 	cfg.SqlDatasourceMaxIdleConnsDefault = sqlDatasources.Key("max_idle_conns_default").MustInt(100)
 	cfg.SqlDatasourceMaxConnLifetimeDefault = sqlDatasources.Key("max_conn_lifetime_default").MustInt(14400)
+	cfg.SqlDatasourceSQLiteAllowedDirectory = sqlDatasources.Key("sqlite_allowed_directory").String()
 }
 
 func GetAllowedOriginGlobs(originPatterns []string) ([]glob.Glob, error) {
@@ -2029,6 +2067,7 @@ func (cfg *Cfg) readLiveSettings(iniFile *ini.File) error {
 	if cfg.LiveMessageSizeLimit < -1 {
 		return fmt.Errorf("unexpected value %d for [live] message_size_limit", cfg.LiveMaxConnections)
 	}
+	cfg.LivePipelineEnabled = section.Key("pipeline_enabled").MustBool(false)
 	cfg.LiveHAEngine = section.Key("ha_engine").MustString("")
 	switch cfg.LiveHAEngine {
 	case "", "redis":
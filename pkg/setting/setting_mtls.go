@@ -0,0 +1,133 @@
+package setting
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const (
+	MTLSMatchSubject    = "subject"
+	MTLSMatchCommonName = "common_name"
+	MTLSMatchDNSSAN     = "dns_san"
+	MTLSMatchEmailSAN   = "email_san"
+	MTLSMatchURISAN     = "uri_san"
+	MTLSMatchSPIFFEID   = "spiffe_id"
+
+	MTLSIdentityUser           = "user"
+	MTLSIdentityServiceAccount = "service_account"
+)
+
+type MTLSSettings struct {
+	// Enabled signs in clients with the certificate they present during the
+	// TLS handshake. It requires Grafana to serve https or h2.
+	Enabled bool
+	// ClientCAFile is the PEM bundle of the certificate authorities client
+	// certificates have to be issued by.
+	ClientCAFile string
+	// AllowedFingerprints are the hex encoded SHA-256 fingerprints of the
+	// certificates that can sign in. When empty, any certificate issued by the
+	// client CAs that matches a rule can sign in.
+	AllowedFingerprints []string
+	AutoSignUp          bool
+	SkipOrgRoleSync     bool
+	// OrgMapping maps the organizational units of the certificate subject to
+	// organizations and roles, in the format of org_mapping of the OAuth
+	// providers.
+	OrgMapping              []string
+	RoleAttributeStrict     bool
+	AllowAssignGrafanaAdmin bool
+	// Rules map certificates to identities, the first rule that matches a
+	// certificate is used.
+	Rules []MTLSRule
+}
+
+// MTLSRule maps the certificates that have an attribute matching Pattern to a
+// user or service account. It is read from a [auth.mtls.rule.<name>] section.
+type MTLSRule struct {
+	Name string
+	// Match is the attribute of the certificate the pattern is matched
+	// against: subject, common_name, dns_san, email_san, uri_san or spiffe_id.
+	Match string
+	// Pattern must match the whole attribute. Its capture groups can be used
+	// in Login and Email as $1 or $name.
+	Pattern *regexp.Regexp
+	// Identity is user or service_account.
+	Identity string
+	// Login is the login of the user or the name of the service account, $0
+	// by default.
+	Login string
+	// OrgID is the organization of the service account.
+	OrgID int64
+	// Email is the email of the user.
+	Email string
+	// Role is the role of the user in the organizations that are not mapped
+	// by org_mapping.
+	Role string
+	// GrafanaAdmin makes the user a server admin when allow_assign_grafana_admin is enabled.
+	GrafanaAdmin bool
+}
+
+func (cfg *Cfg) readMTLSSettings() error {
+	section := cfg.SectionWithEnvOverrides("auth.mtls")
+	settings := MTLSSettings{
+		Enabled:                 section.Key("enabled").MustBool(false),
+		ClientCAFile:            section.Key("client_ca_file").MustString(""),
+		AllowedFingerprints:     util.SplitString(section.Key("allowed_fingerprints").MustString("")),
+		AutoSignUp:              section.Key("auto_sign_up").MustBool(false),
+		SkipOrgRoleSync:         section.Key("skip_org_role_sync").MustBool(false),
+		OrgMapping:              util.SplitString(section.Key("org_mapping").MustString("")),
+		RoleAttributeStrict:     section.Key("role_attribute_strict").MustBool(false),
+		AllowAssignGrafanaAdmin: section.Key("allow_assign_grafana_admin").MustBool(false),
+	}
+
+	// fingerprints are compared in lower case without separators, as printed
+	// by openssl x509 -fingerprint -sha256
+	for i, fingerprint := range settings.AllowedFingerprints {
+		settings.AllowedFingerprints[i] = strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))
+	}
+
+	for _, ruleSection := range cfg.Raw.Sections() {
+		name, ok := strings.CutPrefix(ruleSection.Name(), "auth.mtls.rule.")
+		if !ok {
+			continue
+		}
+		rule, err := readMTLSRule(name, cfg.SectionWithEnvOverrides(ruleSection.Name()))
+		if err != nil {
+			return err
+		}
+		settings.Rules = append(settings.Rules, rule)
+	}
+
+	if settings.Enabled && settings.ClientCAFile == "" {
+		return fmt.Errorf("[auth.mtls] client_ca_file is required when mTLS authentication is enabled")
+	}
+
+	cfg.MTLSAuth = settings
+	return nil
+}
+
+func readMTLSRule(name string, section *DynamicSection) (MTLSRule, error) {
+	pattern, err := regexp.Compile("^(?:" + section.Key("pattern").MustString("") + ")$")
+	if err != nil {
+		return MTLSRule{}, fmt.Errorf("[auth.mtls.rule.%s] invalid pattern: %w", name, err)
+	}
+
+	rule := MTLSRule{
+		Name:         name,
+		Match:        section.Key("match").In(MTLSMatchSubject, []string{MTLSMatchSubject, MTLSMatchCommonName, MTLSMatchDNSSAN, MTLSMatchEmailSAN, MTLSMatchURISAN, MTLSMatchSPIFFEID}),
+		Pattern:      pattern,
+		Identity:     section.Key("identity").In(MTLSIdentityUser, []string{MTLSIdentityUser, MTLSIdentityServiceAccount}),
+		Login:        section.Key("login").MustString("$0"),
+		OrgID:        section.Key("org_id").MustInt64(1),
+		Email:        section.Key("email").MustString(""),
+		Role:         section.Key("role").MustString(""),
+		GrafanaAdmin: section.Key("grafana_admin").MustBool(false),
+	}
+	if rule.Identity == MTLSIdentityServiceAccount && (rule.Email != "" || rule.Role != "" || rule.GrafanaAdmin) {
+		return MTLSRule{}, fmt.Errorf("[auth.mtls.rule.%s] email, role and grafana_admin cannot be set for service accounts", name)
+	}
+	return rule, nil
+}
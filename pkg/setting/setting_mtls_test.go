@@ -0,0 +1,84 @@
+package setting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ini.v1"
+)
+
+func TestReadMTLSSettings(t *testing.T) {
+	load := func(t *testing.T, data string) (*Cfg, error) {
+		t.Helper()
+		f, err := ini.Load([]byte(data))
+		require.NoError(t, err)
+		cfg := NewCfg()
+		cfg.Raw = f
+		return cfg, cfg.readMTLSSettings()
+	}
+
+	t.Run("will load settings and rules in order", func(t *testing.T) {
+		cfg, err := load(t, `
+[auth.mtls]
+enabled = true
+client_ca_file = /etc/grafana/ca.pem
+allowed_fingerprints = AB:CD:EF, 0123
+
+[auth.mtls.rule.automation]
+match = spiffe_id
+pattern = spiffe://mesh/sa/(.+)
+identity = service_account
+login = $1
+org_id = 2
+
+[auth.mtls.rule.employees]
+match = email_san
+pattern = (.+)@example\.com
+`)
+		require.NoError(t, err)
+
+		assert.True(t, cfg.MTLSAuth.Enabled)
+		assert.Equal(t, []string{"abcdef", "0123"}, cfg.MTLSAuth.AllowedFingerprints)
+		require.Len(t, cfg.MTLSAuth.Rules, 2)
+
+		automation := cfg.MTLSAuth.Rules[0]
+		assert.Equal(t, "automation", automation.Name)
+		assert.Equal(t, MTLSMatchSPIFFEID, automation.Match)
+		assert.Equal(t, MTLSIdentityServiceAccount, automation.Identity)
+		assert.Equal(t, "$1", automation.Login)
+		assert.Equal(t, int64(2), automation.OrgID)
+		assert.True(t, automation.Pattern.MatchString("spiffe://mesh/sa/deployer"))
+		assert.False(t, automation.Pattern.MatchString("x-spiffe://mesh/sa/deployer"), "pattern must match the whole attribute")
+
+		employees := cfg.MTLSAuth.Rules[1]
+		assert.Equal(t, MTLSIdentityUser, employees.Identity)
+		assert.Equal(t, "$0", employees.Login)
+		assert.False(t, employees.Pattern.MatchString("jane@example.com.evil.com"))
+	})
+
+	t.Run("will fail without client CA file", func(t *testing.T) {
+		_, err := load(t, `
+[auth.mtls]
+enabled = true
+`)
+		require.Error(t, err)
+	})
+
+	t.Run("will fail with invalid pattern", func(t *testing.T) {
+		_, err := load(t, `
+[auth.mtls.rule.broken]
+pattern = (
+`)
+		require.Error(t, err)
+	})
+
+	t.Run("will fail with role for service account", func(t *testing.T) {
+		_, err := load(t, `
+[auth.mtls.rule.automation]
+identity = service_account
+role = Admin
+`)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,63 @@
+package setting
+
+import (
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const (
+	QueryCachingBackendMemory = "memory"
+	QueryCachingBackendRemote = "remote"
+)
+
+type QueryCachingSettings struct {
+	// Enabled turns on the built-in query and resource cache
+	Enabled bool
+	// Backend is either "memory" (per-instance LRU) or "remote" (the configured [remote_cache])
+	Backend string
+	// MaxItems is the maximum number of entries kept by the memory backend
+	MaxItems int
+	// TTL is the default time-to-live of cached query responses
+	TTL time.Duration
+	// ResourceTTL is the time-to-live of cached resource responses
+	ResourceTTL time.Duration
+	// DataSourceTTLs overrides TTL per data source UID. A zero duration disables caching for that data source.
+	DataSourceTTLs map[string]time.Duration
+}
+
+func (cfg *Cfg) readQueryCachingSettings() {
+	section := cfg.Raw.Section("caching")
+
+	settings := QueryCachingSettings{
+		Enabled:        section.Key("enabled").MustBool(false),
+		Backend:        valueAsString(section, "backend", QueryCachingBackendMemory),
+		MaxItems:       section.Key("max_items").MustInt(10000),
+		TTL:            section.Key("ttl").MustDuration(time.Minute),
+		ResourceTTL:    section.Key("resource_ttl").MustDuration(5 * time.Minute),
+		DataSourceTTLs: map[string]time.Duration{},
+	}
+
+	if settings.Backend != QueryCachingBackendMemory && settings.Backend != QueryCachingBackendRemote {
+		cfg.Logger.Warn("Unknown query caching backend, falling back to memory", "backend", settings.Backend)
+		settings.Backend = QueryCachingBackendMemory
+	}
+
+	// Format: "uid1:30s uid2:5m"
+	for _, entry := range util.SplitString(section.Key("datasource_ttls").MustString("")) {
+		uid, rawTTL, found := strings.Cut(entry, ":")
+		if !found || uid == "" {
+			cfg.Logger.Warn("Invalid query caching datasource_ttls entry", "entry", entry)
+			continue
+		}
+		ttl, err := time.ParseDuration(rawTTL)
+		if err != nil || ttl < 0 {
+			cfg.Logger.Warn("Invalid query caching TTL for data source", "uid", uid, "ttl", rawTTL)
+			continue
+		}
+		settings.DataSourceTTLs[uid] = ttl
+	}
+
+	cfg.QueryCaching = settings
+}
@@ -4,12 +4,17 @@ import (
 	"regexp"
 
 	"github.com/grafana/grafana/pkg/services/kmsproviders"
+	"github.com/grafana/grafana/pkg/util"
 )
 
 type SecretsManagerSettings struct {
 	SecretKey          string
 	EncryptionProvider string
 	AvailableProviders []string
+
+	// FileKeeperDirectories are the directories the directory of a file keeper must be in.
+	// File keepers cannot be used when none is configured.
+	FileKeeperDirectories []string
 }
 
 func (cfg *Cfg) readSecretsManagerSettings() {
@@ -19,4 +24,6 @@ func (cfg *Cfg) readSecretsManagerSettings() {
 	// TODO: These are not used yet by the secrets manager because we need to distentagle the dependencies with OSS.
 	cfg.SecretsManagement.SecretKey = secretsMgmt.Key("secret_key").MustString("")
 	cfg.SecretsManagement.AvailableProviders = regexp.MustCompile(`\s*,\s*`).Split(secretsMgmt.Key("available_encryption_providers").MustString(""), -1) // parse comma separated list
+
+	cfg.SecretsManagement.FileKeeperDirectories = util.SplitString(secretsMgmt.Key("file_keeper_allowed_directories").MustString(""))
 }
@@ -0,0 +1,14 @@
+package setting
+
+type TeamSyncSettings struct {
+	// Enabled syncs team memberships from the groups of users that sign in
+	// with an external identity provider.
+	Enabled bool
+}
+
+func (cfg *Cfg) readTeamSyncSettings() {
+	section := cfg.SectionWithEnvOverrides("auth.team_sync")
+	cfg.TeamSync = TeamSyncSettings{
+		Enabled: section.Key("enabled").MustBool(false),
+	}
+}
@@ -0,0 +1,17 @@
+package setting
+
+type TwoFactorSettings struct {
+	// Enabled lets users of form and basic auth sign in with a TOTP code as
+	// second factor, and lets admins require it per organization.
+	Enabled bool
+	// Issuer is the name of the account shown in authenticator apps.
+	Issuer string
+}
+
+func (cfg *Cfg) readTwoFactorSettings() {
+	section := cfg.SectionWithEnvOverrides("auth.two_factor")
+	cfg.TwoFactor = TwoFactorSettings{
+		Enabled: section.Key("enabled").MustBool(false),
+		Issuer:  section.Key("issuer").MustString("Grafana"),
+	}
+}
@@ -68,6 +68,7 @@ const (
 	defaultRecordingRequestTimeout         = 10 * time.Second
 	lokiDefaultMaxQuerySize                = 65536 // 64kb
 	defaultHistorianPrometheusWriteTimeout = 10 * time.Second
+	defaultHistorianSQLRetention           = 30 * 24 * time.Hour
 	defaultHistorianPrometheusMetricName   = "GRAFANA_ALERTS"
 )
 
@@ -122,6 +123,7 @@ type UnifiedAlertingSettings struct {
 	ReservedLabels                UnifiedAlertingReservedLabelSettings
 	SkipClustering                bool
 	StateHistory                  UnifiedAlertingStateHistorySettings
+	NotificationHistory           UnifiedAlertingNotificationHistorySettings
 	RemoteAlertmanager            RemoteAlertmanagerSettings
 	RecordingRules                RecordingRuleSettings
 	PrometheusConversion          UnifiedAlertingPrometheusConversionSettings
@@ -175,6 +177,13 @@ type UnifiedAlertingReservedLabelSettings struct {
 	DisabledLabels map[string]struct{}
 }
 
+// UnifiedAlertingNotificationHistorySettings configures the history of notification attempts of the Grafana Alertmanager.
+type UnifiedAlertingNotificationHistorySettings struct {
+	Enabled bool
+	// Retention is how long notification attempts are kept. 0 keeps them forever.
+	Retention time.Duration
+}
+
 // UnifiedAlertingPrometheusConversionSettings contains configuration for converting Prometheus rules to Grafana format
 type UnifiedAlertingPrometheusConversionSettings struct {
 	// RuleQueryOffset defines a time offset to apply to rule queries during conversion from Prometheus to Grafana format
@@ -197,6 +206,7 @@ type UnifiedAlertingStateHistorySettings struct {
 	PrometheusMetricName          string
 	PrometheusTargetDatasourceUID string
 	PrometheusWriteTimeout        time.Duration
+	SQLRetention                  time.Duration
 	MultiPrimary                  string
 	MultiSecondaries              []string
 	ExternalLabels                map[string]string
@@ -468,10 +478,20 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 		PrometheusMetricName:          stateHistory.Key("prometheus_metric_name").MustString(defaultHistorianPrometheusMetricName),
 		PrometheusTargetDatasourceUID: stateHistory.Key("prometheus_target_datasource_uid").MustString(""),
 		PrometheusWriteTimeout:        stateHistory.Key("prometheus_write_timeout").MustDuration(defaultHistorianPrometheusWriteTimeout),
+		SQLRetention:                  stateHistory.Key("sql_retention").MustDuration(defaultHistorianSQLRetention),
 		ExternalLabels:                stateHistoryLabels.KeysHash(),
 	}
 	uaCfg.StateHistory = uaCfgStateHistory
 
+	notificationHistory := iniFile.Section("unified_alerting.notification_history")
+	uaCfg.NotificationHistory = UnifiedAlertingNotificationHistorySettings{
+		Enabled:   notificationHistory.Key("enabled").MustBool(false),
+		Retention: notificationHistory.Key("retention").MustDuration(30 * 24 * time.Hour),
+	}
+	if uaCfg.NotificationHistory.Retention < 0 {
+		return fmt.Errorf("setting 'retention' in section 'unified_alerting.notification_history' is invalid, only 0 or a positive duration are allowed")
+	}
+
 	prometheusConversion := iniFile.Section("unified_alerting.prometheus_conversion")
 	uaCfg.PrometheusConversion = UnifiedAlertingPrometheusConversionSettings{
 		RuleQueryOffset: prometheusConversion.Key("rule_query_offset").MustDuration(time.Minute),
@@ -0,0 +1,46 @@
+package setting
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/grafana/grafana/pkg/util"
+)
+
+type WebAuthnSettings struct {
+	// Enabled lets users register passkeys and security keys, and sign in
+	// with them.
+	Enabled bool
+	// RPID is the relying party ID credentials are scoped to, the domain of
+	// Grafana or one of its parent domains.
+	RPID string
+	// RPName is the name of the relying party shown by authenticators.
+	RPName string
+	// Origins are the origins browsers may run the ceremonies from.
+	Origins []string
+	// UserVerification is whether authenticators have to verify the user with
+	// a PIN or biometrics: required, preferred or discouraged.
+	UserVerification string
+	// Timeout is how long a registration or sign in can take.
+	Timeout time.Duration
+}
+
+func (cfg *Cfg) readWebAuthnSettings() {
+	section := cfg.SectionWithEnvOverrides("auth.webauthn")
+
+	// by default credentials are scoped to the host and origin of root_url
+	var host, origin string
+	if appURL, err := url.Parse(cfg.AppURL); err == nil {
+		host = appURL.Hostname()
+		origin = appURL.Scheme + "://" + appURL.Host
+	}
+
+	cfg.WebAuthn = WebAuthnSettings{
+		Enabled:          section.Key("enabled").MustBool(false),
+		RPID:             section.Key("rp_id").MustString(host),
+		RPName:           section.Key("rp_name").MustString("Grafana"),
+		Origins:          util.SplitString(section.Key("origins").MustString(origin)),
+		UserVerification: section.Key("user_verification").In("preferred", []string{"required", "preferred", "discouraged"}),
+		Timeout:          section.Key("timeout").MustDuration(5 * time.Minute),
+	}
+}
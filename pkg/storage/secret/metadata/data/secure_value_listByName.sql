@@ -2,7 +2,8 @@
 
 SELECT
   {{ .Ident "name" }},
-  {{ .Ident "keeper" }}
+  {{ .Ident "keeper" }},
+  {{ .Ident "decrypters" }}
 FROM
   {{ .Ident "secret_secure_value" }}
 WHERE  {{ .Ident "namespace" }} = {{ .Arg .Namespace }} AND
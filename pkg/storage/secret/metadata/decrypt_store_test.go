@@ -333,15 +333,15 @@ func setupDecryptTestService(t *testing.T, allowList map[string]struct{}) (*decr
 	)
 	require.NoError(t, err)
 
-	// Initialize the keeper service
-	keeperService, err := secretkeeper.ProvideService(tracer, encValueStore, encryptionManager, nil)
+	// Initialize the secure value storage
+	secureValueMetadataStorage, err := ProvideSecureValueMetadataStorage(database, tracer, features, nil)
 	require.NoError(t, err)
 
-	keeperMetadataStorage, err := ProvideKeeperMetadataStorage(database, tracer, features, nil)
+	// Initialize the keeper service
+	keeperService, err := secretkeeper.ProvideService(tracer, cfg, encValueStore, secureValueMetadataStorage, encryptionManager, nil)
 	require.NoError(t, err)
 
-	// Initialize the secure value storage
-	secureValueMetadataStorage, err := ProvideSecureValueMetadataStorage(database, tracer, features, nil)
+	keeperMetadataStorage, err := ProvideKeeperMetadataStorage(database, tracer, features, nil)
 	require.NoError(t, err)
 
 	decryptAuthorizer := decrypt.ProvideDecryptAuthorizer(tracer, allowList)
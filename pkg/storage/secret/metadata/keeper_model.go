@@ -69,6 +69,8 @@ func (kp *keeperDB) toKubernetes() (*secretv0alpha1.Keeper, error) {
 		resource.Spec.GCP = v
 	case *secretv0alpha1.HashiCorpKeeperConfig:
 		resource.Spec.HashiCorp = v
+	case *secretv0alpha1.FileKeeperConfig:
+		resource.Spec.File = v
 	}
 
 	// Set all meta fields here for consistency.
@@ -209,6 +211,9 @@ func toTypeAndPayload(kp *secretv0alpha1.Keeper) (secretv0alpha1.KeeperType, str
 	} else if kp.Spec.HashiCorp != nil {
 		payload, err := json.Marshal(kp.Spec.HashiCorp)
 		return secretv0alpha1.HashiCorpKeeperType, string(payload), err
+	} else if kp.Spec.File != nil {
+		payload, err := json.Marshal(kp.Spec.File)
+		return secretv0alpha1.FileKeeperType, string(payload), err
 	}
 
 	return "", "", fmt.Errorf("no keeper type found")
@@ -242,6 +247,12 @@ func toProvider(keeperType secretv0alpha1.KeeperType, payload string) secretv0al
 			return nil
 		}
 		return hashicorp
+	case secretv0alpha1.FileKeeperType:
+		file := &secretv0alpha1.FileKeeperConfig{}
+		if err := json.Unmarshal([]byte(payload), file); err != nil {
+			return nil
+		}
+		return file
 	default:
 		return nil
 	}
@@ -276,6 +287,10 @@ func extractSecureValues(kp *secretv0alpha1.Keeper) map[string]struct{} {
 		if kp.Spec.HashiCorp.Token.SecureValueName != "" {
 			return map[string]struct{}{kp.Spec.HashiCorp.Token.SecureValueName: {}}
 		}
+
+	// File does not reference secureValues.
+	case kp.Spec.File != nil:
+		return nil
 	}
 
 	return nil
@@ -2,7 +2,10 @@ package metadata
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	secretv0alpha1 "github.com/grafana/grafana/pkg/apis/secret/v0alpha1"
@@ -396,14 +399,15 @@ func (s *keeperMetadataStorage) validateSecureValueReferences(ctx context.Contex
 
 	// DTO for `sqlSecureValueListByName` query result, only what we need.
 	type listByNameResult struct {
-		Name   string
-		Keeper *string
+		Name       string
+		Keeper     *string
+		Decrypters sql.NullString
 	}
 
 	secureValueRows := make([]listByNameResult, 0)
 	for rows.Next() {
 		var row listByNameResult
-		if err := rows.Scan(&row.Name, &row.Keeper); err != nil {
+		if err := rows.Scan(&row.Name, &row.Keeper, &row.Decrypters); err != nil {
 			return fmt.Errorf("error reading secret value row: %w", err)
 		}
 
@@ -430,6 +434,25 @@ func (s *keeperMetadataStorage) validateSecureValueReferences(ctx context.Contex
 		return contracts.NewErrKeeperInvalidSecureValues(missing)
 	}
 
+	// The credentials are sent to the address of the keeper, so the secure values must be meant to be used as credentials.
+	notDecryptable := make(map[string]struct{}, 0)
+	for _, svRow := range secureValueRows {
+		var decrypters []string
+		if svRow.Decrypters.Valid && svRow.Decrypters.String != "" {
+			if err := json.Unmarshal([]byte(svRow.Decrypters.String), &decrypters); err != nil {
+				return fmt.Errorf("failed to unmarshal decrypters: %w", err)
+			}
+		}
+
+		if !slices.Contains(decrypters, contracts.KeeperCredentialsDecrypter) {
+			notDecryptable[svRow.Name] = struct{}{}
+		}
+	}
+
+	if len(notDecryptable) > 0 {
+		return contracts.NewErrKeeperSecureValuesNotDecryptable(notDecryptable)
+	}
+
 	// If all secure values exist, we need to guarantee that the third-party keeper is not referencing another third-party,
 	// it must reference only the system keeper (when keeper=null) to keep the dependency tree flat (n=1).
 	keeperNames := make([]string, 0, len(secureValueRows))
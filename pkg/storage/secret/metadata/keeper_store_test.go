@@ -333,6 +333,63 @@ func Test_KeeperMetadataStorage_GetKeeperConfig(t *testing.T) {
 	})
 }
 
+func Test_KeeperMetadataStorage_ValidateSecureValueReferences(t *testing.T) {
+	ctx := context.Background()
+	testDB := sqlstore.NewTestStore(t, sqlstore.WithMigrator(migrator.New()))
+	tracer := noop.NewTracerProvider().Tracer("test")
+	db := database.ProvideDatabase(testDB, tracer)
+	features := featuremgmt.WithFeatures(featuremgmt.FlagGrafanaAPIServerWithExperimentalAPIs, featuremgmt.FlagSecretsManagementAppPlatform)
+
+	secureValueStorage, err := ProvideSecureValueMetadataStorage(db, tracer, features, nil)
+	require.NoError(t, err)
+	keeperStorage, err := ProvideKeeperMetadataStorage(db, tracer, features, nil)
+	require.NoError(t, err)
+
+	createSecureValue := func(name string, decrypters []string) {
+		sv := &secretv0alpha1.SecureValue{
+			Spec: secretv0alpha1.SecureValueSpec{
+				Description: "vault token",
+				Value:       "token",
+				Decrypters:  decrypters,
+			},
+		}
+		sv.Name = name
+		sv.Namespace = "ns"
+		_, err := secureValueStorage.Create(ctx, sv, "testuser")
+		require.NoError(t, err)
+	}
+	createSecureValue("keeper-token", []string{contracts.KeeperCredentialsDecrypter})
+	createSecureValue("unrelated-token", []string{"some-app"})
+
+	newVaultKeeper := func(name, secureValueName string) *secretv0alpha1.Keeper {
+		kp := &secretv0alpha1.Keeper{
+			Spec: secretv0alpha1.KeeperSpec{
+				Description: "vault keeper",
+				HashiCorp: &secretv0alpha1.HashiCorpKeeperConfig{
+					HashiCorpCredentials: secretv0alpha1.HashiCorpCredentials{
+						Address: "https://vault.example.com",
+						Token:   secretv0alpha1.CredentialValue{SecureValueName: secureValueName},
+					},
+				},
+			},
+		}
+		kp.Name = name
+		kp.Namespace = "ns"
+		return kp
+	}
+
+	t.Run("allows secure values listing the keeper credentials decrypter", func(t *testing.T) {
+		_, err := keeperStorage.Create(ctx, newVaultKeeper("kp-allowed", "keeper-token"), "testuser")
+		require.NoError(t, err)
+	})
+
+	t.Run("refuses secure values not listing the keeper credentials decrypter", func(t *testing.T) {
+		_, err := keeperStorage.Create(ctx, newVaultKeeper("kp-refused", "unrelated-token"), "testuser")
+		var notDecryptable *contracts.ErrKeeperSecureValuesNotDecryptable
+		require.ErrorAs(t, err, &notDecryptable)
+	})
+}
+
 func initStorage(t *testing.T) contracts.KeeperMetadataStorage {
 	testDB := sqlstore.NewTestStore(t, sqlstore.WithMigrator(migrator.New()))
 	tracer := noop.NewTracerProvider().Tracer("test")
@@ -1,6 +1,7 @@
 SELECT
   `name`,
-  `keeper`
+  `keeper`,
+  `decrypters`
 FROM
   `secret_secure_value`
 WHERE  `namespace` = 'ns' AND
@@ -1,4 +1,4 @@
-package sqleng
+package postgres
 
 import (
 	"errors"
@@ -19,7 +19,7 @@ import (
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
 	"github.com/grafana/grafana/pkg/setting"
-	"github.com/grafana/grafana/pkg/tsdb/grafana-postgresql-datasource/sqleng"
+	"github.com/grafana/grafana/pkg/tsdb/sqleng"
 )
 
 func ProvideService(cfg *setting.Cfg) *Service {
@@ -80,9 +80,10 @@ func newPostgres(ctx context.Context, userFacingDefaultError string, rowLimit in
 	}
 
 	config := sqleng.DataPluginConfiguration{
-		DSInfo:            dsInfo,
-		MetricColumnTypes: []string{"UNKNOWN", "TEXT", "VARCHAR", "CHAR"},
-		RowLimit:          rowLimit,
+		DSInfo:                 dsInfo,
+		MetricColumnTypes:      []string{"UNKNOWN", "TEXT", "VARCHAR", "CHAR"},
+		RowLimit:               rowLimit,
+		ErrToHealthCheckResult: ErrToHealthCheckResult,
 	}
 
 	queryResultTransformer := postgresQueryResultTransformer{}
@@ -188,7 +189,7 @@ func (s *Service) generateConnectionString(dsInfo sqleng.DataSourceInfo) (string
 				port, err = strconv.Atoi(sp[1])
 				if err != nil {
 					logger.Debug("Error parsing the IPv4 address", "address", dsInfo.URL)
-					return "", sqleng.ErrParsingPostgresURL
+					return "", ErrParsingPostgresURL
 				}
 				logger.Debug("Generating IPv4 connection string with network host/port pair", "host", host, "port", port, "address", dsInfo.URL)
 			} else {
@@ -201,7 +202,7 @@ func (s *Service) generateConnectionString(dsInfo sqleng.DataSourceInfo) (string
 				port, err = strconv.Atoi(dsInfo.URL[index+1:])
 				if err != nil {
 					logger.Debug("Error parsing the IPv6 address", "address", dsInfo.URL)
-					return "", sqleng.ErrParsingPostgresURL
+					return "", ErrParsingPostgresURL
 				}
 				logger.Debug("Generating IPv6 connection string with network host/port pair", "host", host, "port", port, "address", dsInfo.URL)
 			} else {
@@ -260,7 +261,7 @@ func (t *postgresQueryResultTransformer) TransformQueryError(_ log.Logger, err e
 func (s *Service) CheckHealth(ctx context.Context, req *backend.CheckHealthRequest) (*backend.CheckHealthResult, error) {
 	dsHandler, err := s.getDSInfo(ctx, req.PluginContext)
 	if err != nil {
-		return sqleng.ErrToHealthCheckResult(err)
+		return ErrToHealthCheckResult(err)
 	}
 	return dsHandler.CheckHealth(ctx, req)
 }
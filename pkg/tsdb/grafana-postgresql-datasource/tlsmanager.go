@@ -10,7 +10,7 @@ import (
 	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
-	"github.com/grafana/grafana/pkg/tsdb/grafana-postgresql-datasource/sqleng"
+	"github.com/grafana/grafana/pkg/tsdb/sqleng"
 )
 
 var validateCertFunc = validateCertFilePaths
@@ -229,7 +229,7 @@ func validateCertFilePaths(rootCert, clientCert, clientKey string) error {
 			return err
 		}
 		if !exists {
-			return sqleng.ErrCertFileNotExist
+			return ErrCertFileNotExist
 		}
 	}
 	return nil
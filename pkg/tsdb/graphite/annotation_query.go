@@ -0,0 +1,200 @@
+package graphite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+)
+
+const (
+	annotationQueryType = "annotation"
+	// Same number of points as the frontend requests for annotations built from a target.
+	annotationMaxDataPoints = 100
+)
+
+// annotationQueryModel is the model of an annotation query. When Target is set, every non-null and non-zero
+// point of the returned series is an annotation. Otherwise, the Graphite events matching Tags are returned.
+type annotationQueryModel struct {
+	Target string   `json:"target"`
+	Tags   []string `json:"tags"`
+}
+
+// GraphiteEvent is an event returned by the /events/get_data endpoint.
+type GraphiteEvent struct {
+	When float64 `json:"when"`
+	What string  `json:"what"`
+	Data string  `json:"data"`
+	// Graphite returns the tags either as a list or as a single string.
+	Tags any `json:"tags"`
+}
+
+type annotationEvent struct {
+	Time  time.Time
+	Title string
+	Tags  []string
+	Text  string
+}
+
+func (s *Service) executeAnnotationQuery(ctx context.Context, logger log.Logger, dsInfo *datasourceInfo, query backend.DataQuery) backend.DataResponse {
+	var model annotationQueryModel
+	if err := json.Unmarshal(query.JSON, &model); err != nil {
+		return backend.ErrDataResponseWithSource(backend.StatusBadRequest, backend.ErrorSourceDownstream, fmt.Sprintf("failed to parse annotation query: %v", err))
+	}
+
+	var events []annotationEvent
+	var err error
+	if model.Target != "" {
+		events, err = s.targetAnnotations(ctx, logger, dsInfo, query)
+	} else {
+		events, err = s.eventAnnotations(ctx, logger, dsInfo, query.TimeRange, model.Tags)
+	}
+	if err != nil {
+		return backend.ErrorResponseWithErrorSource(err)
+	}
+
+	return backend.DataResponse{Frames: data.Frames{annotationsToFrame(query.RefID, events)}}
+}
+
+func (s *Service) targetAnnotations(ctx context.Context, logger log.Logger, dsInfo *datasourceInfo, query backend.DataQuery) ([]annotationEvent, error) {
+	query.MaxDataPoints = annotationMaxDataPoints
+	req, _, _, err := s.createGraphiteRequest(ctx, query, logger, dsInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := dsInfo.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	frames, err := s.toDataFrames(logger, res, query.RefID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]annotationEvent, 0)
+	for _, frame := range frames {
+		title := frame.Fields[1].Config.DisplayNameFromDS
+		for i := 0; i < frame.Rows(); i++ {
+			value, ok := frame.Fields[1].At(i).(*float64)
+			if !ok || value == nil || *value == 0 {
+				continue
+			}
+			events = append(events, annotationEvent{
+				Time:  frame.Fields[0].At(i).(time.Time),
+				Title: title,
+			})
+		}
+	}
+	return events, nil
+}
+
+func (s *Service) eventAnnotations(ctx context.Context, logger log.Logger, dsInfo *datasourceInfo, tr backend.TimeRange, tags []string) ([]annotationEvent, error) {
+	u, err := url.Parse(dsInfo.URL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, "events/get_data")
+	from, until := epochMStoGraphiteTime(tr)
+	params := url.Values{
+		"from":  []string{from},
+		"until": []string{until},
+	}
+	if len(tags) > 0 {
+		params.Set("tags", strings.Join(tags, " "))
+	}
+	u.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	res, err := dsInfo.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			logger.Warn("Failed to close response body", "error", err)
+		}
+	}()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode/100 != 2 {
+		logger.Info("Request failed", "status", res.Status, "body", string(body))
+		return nil, backend.DownstreamError(fmt.Errorf("request failed, status: %s", res.Status))
+	}
+
+	var graphiteEvents []GraphiteEvent
+	if err := json.Unmarshal(body, &graphiteEvents); err != nil {
+		logger.Info("Failed to unmarshal graphite events", "error", err, "status", res.Status, "body", string(body))
+		return nil, err
+	}
+
+	events := make([]annotationEvent, 0, len(graphiteEvents))
+	for _, e := range graphiteEvents {
+		events = append(events, annotationEvent{
+			Time:  time.UnixMilli(int64(e.When * 1000)).UTC(),
+			Title: e.What,
+			Tags:  parseEventTags(e.Tags),
+			Text:  e.Data,
+		})
+	}
+	return events, nil
+}
+
+// parseEventTags returns the tags of an event, splitting them the same way as the frontend when they are
+// returned as a single string.
+func parseEventTags(tags any) []string {
+	switch tags := tags.(type) {
+	case []any:
+		result := make([]string, 0, len(tags))
+		for _, t := range tags {
+			if s, ok := t.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	case string:
+		if tags == "" {
+			return []string{}
+		}
+		if strings.Contains(tags, ",") {
+			return strings.Split(tags, ",")
+		}
+		return strings.Split(tags, " ")
+	default:
+		return []string{}
+	}
+}
+
+func annotationsToFrame(refID string, events []annotationEvent) *data.Frame {
+	frame := data.NewFrame(refID,
+		data.NewField("time", nil, []time.Time{}),
+		data.NewField("title", nil, []string{}),
+		data.NewField("tags", nil, []string{}),
+		data.NewField("text", nil, []string{}),
+	)
+	for _, e := range events {
+		frame.AppendRow(e.Time, e.Title, strings.Join(e.Tags, ","), e.Text)
+	}
+	frame.Meta = &data.FrameMeta{
+		Custom: map[string]any{
+			"rowCount": len(events),
+		},
+	}
+	return frame
+}
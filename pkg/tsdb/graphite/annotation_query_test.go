@@ -0,0 +1,96 @@
+package graphite
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/httpclient"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+)
+
+func TestAnnotationQuery(t *testing.T) {
+	var eventsQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/events/get_data":
+			eventsQuery = r.URL.RawQuery
+			_, _ = w.Write([]byte(`[
+				{"when": 1700000000, "what": "deploy", "data": "v1.2.3", "tags": ["deploy", "prod"]},
+				{"when": 1700000060.5, "what": "restart", "data": "", "tags": "ops,prod"}
+			]`))
+		case "/render":
+			_, _ = w.Write([]byte(`[{"target": "deploys", "datapoints": [[1, 1700000000], [null, 1700000060], [0, 1700000120], [2, 1700000180]]}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	service := ProvideService(httpclient.NewProvider(), tracing.InitializeTracerForTest())
+	timeRange := backend.TimeRange{From: time.Unix(1699990000, 0), To: time.Unix(1700010000, 0)}
+	query := func(t *testing.T, queries ...backend.DataQuery) *backend.QueryDataResponse {
+		t.Helper()
+		resp, err := service.QueryData(context.Background(), &backend.QueryDataRequest{
+			PluginContext: backend.PluginContext{
+				DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{ID: 1, URL: srv.URL},
+			},
+			Queries: queries,
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("returns the events matching the tags", func(t *testing.T) {
+		resp := query(t, backend.DataQuery{RefID: "A", QueryType: annotationQueryType, TimeRange: timeRange, JSON: []byte(`{"tags": ["deploy", "prod"]}`)})
+
+		require.NoError(t, resp.Responses["A"].Error)
+		assert.Equal(t, "from=1699990000&tags=deploy+prod&until=1700010000", eventsQuery)
+		require.Len(t, resp.Responses["A"].Frames, 1)
+		frame := resp.Responses["A"].Frames[0]
+		require.Equal(t, 2, frame.Rows())
+		assert.Equal(t, time.Unix(1700000000, 0).UTC(), frame.Fields[0].At(0))
+		assert.Equal(t, "deploy", frame.Fields[1].At(0))
+		assert.Equal(t, "deploy,prod", frame.Fields[2].At(0))
+		assert.Equal(t, "v1.2.3", frame.Fields[3].At(0))
+		assert.Equal(t, time.UnixMilli(1700000060500).UTC(), frame.Fields[0].At(1))
+		assert.Equal(t, "ops,prod", frame.Fields[2].At(1))
+	})
+
+	t.Run("returns the non-zero points of a target", func(t *testing.T) {
+		resp := query(t, backend.DataQuery{RefID: "A", QueryType: annotationQueryType, TimeRange: timeRange, JSON: []byte(`{"target": "deploys"}`)})
+
+		require.NoError(t, resp.Responses["A"].Error)
+		frame := resp.Responses["A"].Frames[0]
+		require.Equal(t, 2, frame.Rows())
+		assert.Equal(t, time.Unix(1700000000, 0).UTC(), frame.Fields[0].At(0))
+		assert.Equal(t, time.Unix(1700000180, 0).UTC(), frame.Fields[0].At(1))
+		assert.Equal(t, "deploys", frame.Fields[1].At(0))
+	})
+
+	t.Run("can be mixed with render queries", func(t *testing.T) {
+		resp := query(t,
+			backend.DataQuery{RefID: "A", QueryType: annotationQueryType, TimeRange: timeRange, JSON: []byte(`{"tags": ["deploy"]}`)},
+			backend.DataQuery{RefID: "B", TimeRange: timeRange, JSON: []byte(`{"target": "deploys"}`)},
+		)
+
+		require.Contains(t, resp.Responses, "A")
+		require.Contains(t, resp.Responses, "B")
+		assert.Equal(t, 2, resp.Responses["A"].Frames[0].Rows())
+		assert.Equal(t, 4, resp.Responses["B"].Frames[0].Rows())
+	})
+}
+
+func TestParseEventTags(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, parseEventTags([]any{"a", "b"}))
+	assert.Equal(t, []string{"a", "b"}, parseEventTags("a,b"))
+	assert.Equal(t, []string{"a", "b"}, parseEventTags("a b"))
+	assert.Equal(t, []string{}, parseEventTags(""))
+	assert.Equal(t, []string{}, parseEventTags(nil))
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/grafana/grafana-plugin-sdk-go/backend/datasource"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/patrickmn/go-cache"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 
@@ -51,6 +52,8 @@ type datasourceInfo struct {
 	HTTPClient *http.Client
 	URL        string
 	Id         int64
+
+	resourceCache *cache.Cache
 }
 
 func newInstanceSettings(httpClientProvider httpclient.Provider) datasource.InstanceFactoryFunc {
@@ -66,9 +69,10 @@ func newInstanceSettings(httpClientProvider httpclient.Provider) datasource.Inst
 		}
 
 		model := datasourceInfo{
-			HTTPClient: client,
-			URL:        settings.URL,
-			Id:         settings.ID,
+			HTTPClient:    client,
+			URL:           settings.URL,
+			Id:            settings.ID,
+			resourceCache: newResourceCache(),
 		}
 
 		return model, nil
@@ -97,12 +101,25 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 		return nil, err
 	}
 
+	annotationResponses := backend.Responses{}
+	renderQueries := make([]backend.DataQuery, 0, len(req.Queries))
+	for _, query := range req.Queries {
+		if query.QueryType == annotationQueryType {
+			annotationResponses[query.RefID] = s.executeAnnotationQuery(ctx, logger, dsInfo, query)
+			continue
+		}
+		renderQueries = append(renderQueries, query)
+	}
+	if len(renderQueries) == 0 {
+		return &backend.QueryDataResponse{Responses: annotationResponses}, nil
+	}
+
 	emptyQueries := []string{}
 	graphiteQueries := map[string]struct {
 		req      *http.Request
 		formData url.Values
 	}{}
-	for _, query := range req.Queries {
+	for _, query := range renderQueries {
 		graphiteReq, formData, emptyQuery, err := s.createGraphiteRequest(ctx, query, logger, dsInfo)
 		if err != nil {
 			return nil, err
@@ -122,11 +139,11 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 		}
 	}
 
-	var result = backend.QueryDataResponse{}
+	var result = backend.QueryDataResponse{Responses: annotationResponses}
 	if len(emptyQueries) != 0 {
 		logger.Warn("Found query models without targets", "models without targets", strings.Join(emptyQueries, "\n"))
 		// If no queries had a valid target, return an error; otherwise, attempt with the targets we have
-		if len(emptyQueries) == len(req.Queries) {
+		if len(emptyQueries) == len(renderQueries) {
 			if result.Responses == nil {
 				result.Responses = make(map[string]backend.DataResponse)
 			}
@@ -181,7 +198,7 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 	}
 
 	result = backend.QueryDataResponse{
-		Responses: make(backend.Responses),
+		Responses: annotationResponses,
 	}
 
 	for _, f := range frames {
@@ -0,0 +1,335 @@
+package graphite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/resource/httpadapter"
+	"github.com/patrickmn/go-cache"
+)
+
+var (
+	_ backend.CallResourceHandler = (*Service)(nil)
+)
+
+const (
+	// Metric trees and tags change slowly, but we don't want autocomplete to lag behind newly created series for too long.
+	metricsCacheExpiration = time.Minute
+	// The function list only changes when Graphite is upgraded.
+	functionsCacheExpiration = time.Hour
+
+	maxAutoCompleteLimit = 10000
+)
+
+var (
+	errMissingParam = errors.New("missing parameter")
+	errInvalidParam = errors.New("invalid parameter")
+
+	// Graphite 1.1.7 returns Infinity as the default value of some function parameters, which is not valid JSON.
+	// See https://github.com/graphite-project/graphite-web/issues/2609
+	functionsInfinityRegexp = regexp.MustCompile(`"default": ?Infinity`)
+)
+
+// MetricsFindResult is a node of the metric tree returned by /metrics/find.
+type MetricsFindResult struct {
+	Text          string       `json:"text"`
+	ID            string       `json:"id"`
+	Expandable    graphiteBool `json:"expandable"`
+	Leaf          graphiteBool `json:"leaf"`
+	AllowChildren graphiteBool `json:"allowChildren"`
+}
+
+// MetricsExpandResponse is the response of /metrics/expand, with the full names of the matching metrics.
+type MetricsExpandResponse struct {
+	Results []string `json:"results"`
+}
+
+// TagsResult is a tag returned by /tags.
+type TagsResult struct {
+	Tag string `json:"tag"`
+}
+
+// TagValuesResponse is the response of /tags/<tag>.
+type TagValuesResponse struct {
+	Tag    string           `json:"tag"`
+	Values []TagValueResult `json:"values"`
+}
+
+// TagValueResult is a value of a tag returned by /tags/<tag>, with the number of series that have it.
+type TagValueResult struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// graphiteBool decodes the flags of /metrics/find, which graphite-web returns as 0 or 1 and other
+// implementations as booleans.
+type graphiteBool bool
+
+func (b *graphiteBool) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "true", "1":
+		*b = true
+	case "false", "0", "null":
+		*b = false
+	default:
+		return fmt.Errorf("unexpected value %s", data)
+	}
+	return nil
+}
+
+func (s *Service) CallResource(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) error {
+	handler := httpadapter.New(s.registerResourceRoutes())
+	return handler.CallResource(ctx, req, sender)
+}
+
+func (s *Service) registerResourceRoutes() *http.ServeMux {
+	router := http.NewServeMux()
+	router.HandleFunc("GET /metrics/find", s.handleMetricsFind)
+	router.HandleFunc("GET /metrics/expand", s.handleMetricsExpand)
+	router.HandleFunc("GET /tags", s.handleTags)
+	router.HandleFunc("GET /tags/{tag}", s.handleTagValues)
+	router.HandleFunc("GET /tags/autoComplete/tags", s.handleTagsAutoComplete)
+	router.HandleFunc("GET /tags/autoComplete/values", s.handleTagValuesAutoComplete)
+	router.HandleFunc("GET /functions", s.handleFunctions)
+	return router
+}
+
+func (s *Service) handleMetricsFind(rw http.ResponseWriter, r *http.Request) {
+	params, err := copyParams(r.URL.Query(), []string{"query"}, "from", "until")
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, err)
+		return
+	}
+
+	s.handleGraphiteRequest(rw, r, "metrics/find", params, metricsCacheExpiration, func(body []byte) (any, error) {
+		var results []MetricsFindResult
+		if err := json.Unmarshal(body, &results); err != nil {
+			return nil, err
+		}
+		return results, nil
+	})
+}
+
+func (s *Service) handleMetricsExpand(rw http.ResponseWriter, r *http.Request) {
+	params, err := copyParams(r.URL.Query(), []string{"query"}, "from", "until")
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, err)
+		return
+	}
+
+	s.handleGraphiteRequest(rw, r, "metrics/expand", params, metricsCacheExpiration, func(body []byte) (any, error) {
+		var expanded MetricsExpandResponse
+		if err := json.Unmarshal(body, &expanded); err != nil {
+			return nil, err
+		}
+		return expanded, nil
+	})
+}
+
+func (s *Service) handleTags(rw http.ResponseWriter, r *http.Request) {
+	params, err := copyParams(r.URL.Query(), nil, "from", "until")
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, err)
+		return
+	}
+
+	s.handleGraphiteRequest(rw, r, "tags", params, metricsCacheExpiration, func(body []byte) (any, error) {
+		var tags []TagsResult
+		if err := json.Unmarshal(body, &tags); err != nil {
+			return nil, err
+		}
+		return tags, nil
+	})
+}
+
+func (s *Service) handleTagValues(rw http.ResponseWriter, r *http.Request) {
+	params, err := copyParams(r.URL.Query(), nil, "from", "until")
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, err)
+		return
+	}
+
+	// The tag is joined to the data source URL, so it must not leave the tags path.
+	tag := r.PathValue("tag")
+	if tag == "." || tag == ".." || strings.Contains(tag, "/") {
+		writeError(rw, http.StatusBadRequest, fmt.Errorf("%w: tag", errInvalidParam))
+		return
+	}
+
+	s.handleGraphiteRequest(rw, r, "tags/"+tag, params, metricsCacheExpiration, func(body []byte) (any, error) {
+		var values TagValuesResponse
+		if err := json.Unmarshal(body, &values); err != nil {
+			return nil, err
+		}
+		return values, nil
+	})
+}
+
+func (s *Service) handleTagsAutoComplete(rw http.ResponseWriter, r *http.Request) {
+	params, err := copyParams(r.URL.Query(), nil, "tagPrefix", "expr", "limit", "from", "until")
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, err)
+		return
+	}
+
+	s.handleGraphiteRequest(rw, r, "tags/autoComplete/tags", params, metricsCacheExpiration, decodeTags)
+}
+
+func (s *Service) handleTagValuesAutoComplete(rw http.ResponseWriter, r *http.Request) {
+	params, err := copyParams(r.URL.Query(), []string{"tag"}, "valuePrefix", "expr", "limit", "from", "until")
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, err)
+		return
+	}
+
+	s.handleGraphiteRequest(rw, r, "tags/autoComplete/values", params, metricsCacheExpiration, decodeTags)
+}
+
+func (s *Service) handleFunctions(rw http.ResponseWriter, r *http.Request) {
+	s.handleGraphiteRequest(rw, r, "functions", url.Values{}, functionsCacheExpiration, func(body []byte) (any, error) {
+		body = functionsInfinityRegexp.ReplaceAll(body, []byte(`"default": 1e9999`))
+		if !json.Valid(body) {
+			return nil, errors.New("invalid JSON")
+		}
+		return json.RawMessage(body), nil
+	})
+}
+
+// handleGraphiteRequest sends a GET request to the Graphite API and writes the decoded response.
+// Successful responses are cached per data source, keyed by the path and the parameters of the request.
+func (s *Service) handleGraphiteRequest(rw http.ResponseWriter, r *http.Request, apiPath string, params url.Values, expiration time.Duration, decode func([]byte) (any, error)) {
+	ctx := r.Context()
+	logger := logger.FromContext(ctx)
+
+	dsInfo, err := s.getDSInfo(ctx, backend.PluginConfigFromContext(ctx))
+	if err != nil {
+		logger.Error("Failed to get data source info", "error", err)
+		writeError(rw, http.StatusInternalServerError, errors.New("failed to get data source info"))
+		return
+	}
+
+	cacheKey := apiPath + "?" + params.Encode()
+	if dsInfo.resourceCache != nil {
+		if cached, found := dsInfo.resourceCache.Get(cacheKey); found {
+			writeJSON(rw, cached.([]byte))
+			return
+		}
+	}
+
+	u, err := url.Parse(dsInfo.URL)
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, fmt.Errorf("invalid data source URL: %w", err))
+		return
+	}
+	u.Path = path.Join(u.Path, apiPath)
+	u.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, fmt.Errorf("failed to create request: %w", err))
+		return
+	}
+
+	ctx, span := s.tracer.Start(ctx, "graphite resource")
+	defer span.End()
+	s.tracer.Inject(ctx, req.Header, span)
+
+	res, err := dsInfo.HTTPClient.Do(req)
+	if err != nil {
+		logger.Error("Resource request to Graphite failed", "error", err, "path", apiPath)
+		writeError(rw, http.StatusBadGateway, errors.New("request to Graphite failed"))
+		return
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			logger.Warn("Failed to close response body", "error", err)
+		}
+	}()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		writeError(rw, http.StatusBadGateway, fmt.Errorf("failed to read response: %w", err))
+		return
+	}
+	if res.StatusCode/100 != 2 {
+		logger.Info("Resource request failed", "status", res.Status, "path", apiPath, "body", string(body))
+		writeError(rw, res.StatusCode, fmt.Errorf("request failed, status: %s", res.Status))
+		return
+	}
+
+	result, err := decode(body)
+	if err != nil {
+		logger.Info("Failed to decode Graphite response", "error", err, "path", apiPath, "body", string(body))
+		writeError(rw, http.StatusBadGateway, fmt.Errorf("failed to decode response from Graphite: %w", err))
+		return
+	}
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, err)
+		return
+	}
+
+	if dsInfo.resourceCache != nil {
+		dsInfo.resourceCache.Set(cacheKey, encoded, expiration)
+	}
+	writeJSON(rw, encoded)
+}
+
+func decodeTags(body []byte) (any, error) {
+	var tags []string
+	if err := json.Unmarshal(body, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// copyParams returns the required and optional parameters of the query, rejecting empty required parameters
+// and invalid limits. Other parameters are dropped so they are not forwarded to Graphite.
+func copyParams(query url.Values, required []string, optional ...string) (url.Values, error) {
+	params := url.Values{}
+	for _, name := range required {
+		if query.Get(name) == "" {
+			return nil, fmt.Errorf("%w: %s", errMissingParam, name)
+		}
+		params[name] = query[name]
+	}
+	for _, name := range optional {
+		if values, ok := query[name]; ok {
+			params[name] = values
+		}
+	}
+	if limit := params.Get("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l <= 0 || l > maxAutoCompleteLimit {
+			return nil, fmt.Errorf("%w: limit must be between 1 and %d", errInvalidParam, maxAutoCompleteLimit)
+		}
+	}
+	return params, nil
+}
+
+func writeJSON(rw http.ResponseWriter, body []byte) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	_, _ = rw.Write(body)
+}
+
+func writeError(rw http.ResponseWriter, status int, err error) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	_ = json.NewEncoder(rw).Encode(map[string]string{"message": err.Error()})
+}
+
+func newResourceCache() *cache.Cache {
+	return cache.New(metricsCacheExpiration, functionsCacheExpiration)
+}
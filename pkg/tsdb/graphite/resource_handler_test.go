@@ -0,0 +1,141 @@
+package graphite
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/httpclient"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+)
+
+func TestCallResource(t *testing.T) {
+	requests := map[string]int{}
+	var lastQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		lastQuery = r.URL.RawQuery
+		switch r.URL.Path {
+		case "/metrics/find":
+			_, _ = w.Write([]byte(`[{"text":"cpu","id":"servers.cpu","expandable":1,"leaf":0,"allowChildren":1},{"text":"load","id":"servers.load","expandable":false,"leaf":true,"allowChildren":false}]`))
+		case "/metrics/expand":
+			_, _ = w.Write([]byte(`{"results":["servers.a.cpu","servers.b.cpu"]}`))
+		case "/tags":
+			_, _ = w.Write([]byte(`[{"tag":"host"},{"tag":"name"}]`))
+		case "/tags/host":
+			_, _ = w.Write([]byte(`{"tag":"host","values":[{"count":2,"value":"a"},{"count":1,"value":"b"}]}`))
+		case "/tags/autoComplete/tags":
+			_, _ = w.Write([]byte(`["host","name"]`))
+		case "/tags/autoComplete/values":
+			_, _ = w.Write([]byte(`{"invalid":true}`))
+		case "/functions":
+			_, _ = w.Write([]byte(`{"absolute":{"params":[{"name":"n","default": Infinity}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	service := ProvideService(httpclient.NewProvider(), tracing.InitializeTracerForTest())
+	pluginCtx := backend.PluginContext{
+		DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{ID: 1, URL: srv.URL},
+	}
+	call := func(t *testing.T, path string) *backend.CallResourceResponse {
+		t.Helper()
+		var resp *backend.CallResourceResponse
+		err := service.CallResource(context.Background(), &backend.CallResourceRequest{
+			PluginContext: pluginCtx,
+			Method:        http.MethodGet,
+			Path:          strings.SplitN(path, "?", 2)[0],
+			URL:           path,
+		}, backend.CallResourceResponseSenderFunc(func(r *backend.CallResourceResponse) error {
+			resp = r
+			return nil
+		}))
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		return resp
+	}
+
+	t.Run("metrics find returns typed results and forwards only known parameters", func(t *testing.T) {
+		resp := call(t, "metrics/find?query=servers.*&from=-6h&until=now&format=pickle")
+		require.Equal(t, http.StatusOK, resp.Status)
+		assert.Equal(t, "from=-6h&query=servers.%2A&until=now", lastQuery)
+
+		var results []map[string]any
+		require.NoError(t, json.Unmarshal(resp.Body, &results))
+		require.Len(t, results, 2)
+		assert.Equal(t, map[string]any{"text": "cpu", "id": "servers.cpu", "expandable": true, "leaf": false, "allowChildren": true}, results[0])
+		assert.Equal(t, true, results[1]["leaf"])
+	})
+
+	t.Run("responses are cached", func(t *testing.T) {
+		before := requests["/metrics/find"]
+		resp := call(t, "metrics/find?query=servers.*&from=-6h&until=now")
+		require.Equal(t, http.StatusOK, resp.Status)
+		assert.Equal(t, before, requests["/metrics/find"])
+	})
+
+	t.Run("metrics find requires a query", func(t *testing.T) {
+		resp := call(t, "metrics/find")
+		assert.Equal(t, http.StatusBadRequest, resp.Status)
+	})
+
+	t.Run("metrics expand returns the full metric names", func(t *testing.T) {
+		resp := call(t, "metrics/expand?query=servers.*.cpu")
+		require.Equal(t, http.StatusOK, resp.Status)
+		assert.JSONEq(t, `{"results":["servers.a.cpu","servers.b.cpu"]}`, string(resp.Body))
+
+		resp = call(t, "metrics/expand")
+		assert.Equal(t, http.StatusBadRequest, resp.Status)
+	})
+
+	t.Run("tags and tag values return typed results", func(t *testing.T) {
+		resp := call(t, "tags?from=-6h&until=now")
+		require.Equal(t, http.StatusOK, resp.Status)
+		assert.JSONEq(t, `[{"tag":"host"},{"tag":"name"}]`, string(resp.Body))
+
+		resp = call(t, "tags/host")
+		require.Equal(t, http.StatusOK, resp.Status)
+		assert.JSONEq(t, `{"tag":"host","values":[{"value":"a","count":2},{"value":"b","count":1}]}`, string(resp.Body))
+
+		resp = call(t, "tags/..%2Frender")
+		assert.Equal(t, http.StatusBadRequest, resp.Status)
+	})
+
+	t.Run("tags autocomplete validates the limit", func(t *testing.T) {
+		resp := call(t, "tags/autoComplete/tags?limit=abc")
+		assert.Equal(t, http.StatusBadRequest, resp.Status)
+
+		resp = call(t, "tags/autoComplete/tags?tagPrefix=h&limit=100")
+		require.Equal(t, http.StatusOK, resp.Status)
+		assert.JSONEq(t, `["host","name"]`, string(resp.Body))
+	})
+
+	t.Run("tag values autocomplete rejects unexpected responses", func(t *testing.T) {
+		resp := call(t, "tags/autoComplete/values")
+		assert.Equal(t, http.StatusBadRequest, resp.Status)
+
+		resp = call(t, "tags/autoComplete/values?tag=host")
+		assert.Equal(t, http.StatusBadGateway, resp.Status)
+	})
+
+	t.Run("functions fixes the invalid JSON returned by Graphite 1.1.7", func(t *testing.T) {
+		resp := call(t, "functions")
+		require.Equal(t, http.StatusOK, resp.Status)
+		assert.True(t, json.Valid(resp.Body))
+	})
+
+	t.Run("unknown paths are not forwarded", func(t *testing.T) {
+		resp := call(t, "render?target=servers.*")
+		assert.Equal(t, http.StatusNotFound, resp.Status)
+		assert.Zero(t, requests["/render"])
+	})
+}
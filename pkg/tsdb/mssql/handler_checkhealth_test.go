@@ -1,4 +1,4 @@
-package sqleng
+package mssql
 
 import (
 	"errors"
@@ -25,8 +25,8 @@ import (
 	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
 	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/tsdb/mssql/kerberos"
-	"github.com/grafana/grafana/pkg/tsdb/mssql/sqleng"
 	"github.com/grafana/grafana/pkg/tsdb/mssql/utils"
+	"github.com/grafana/grafana/pkg/tsdb/sqleng"
 	"github.com/grafana/grafana/pkg/util"
 )
 
@@ -110,9 +110,10 @@ func newMSSQL(ctx context.Context, driverName string, userFacingDefaultError str
 	}
 
 	config := sqleng.DataPluginConfiguration{
-		DSInfo:            dsInfo,
-		MetricColumnTypes: []string{"VARCHAR", "CHAR", "NVARCHAR", "NCHAR"},
-		RowLimit:          rowLimit,
+		DSInfo:                 dsInfo,
+		MetricColumnTypes:      []string{"VARCHAR", "CHAR", "NVARCHAR", "NCHAR"},
+		RowLimit:               rowLimit,
+		ErrToHealthCheckResult: ErrToHealthCheckResult,
 	}
 
 	queryResultTransformer := mssqlQueryResultTransformer{
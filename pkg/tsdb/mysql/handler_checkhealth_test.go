@@ -1,4 +1,4 @@
-package sqleng
+package mysql
 
 import (
 	"errors"
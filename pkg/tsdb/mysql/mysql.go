@@ -22,7 +22,7 @@ import (
 	"github.com/grafana/grafana-plugin-sdk-go/data/sqlutil"
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
-	"github.com/grafana/grafana/pkg/tsdb/mysql/sqleng"
+	"github.com/grafana/grafana/pkg/tsdb/sqleng"
 )
 
 const (
@@ -132,10 +132,12 @@ func NewInstanceSettings(logger log.Logger) datasource.InstanceFactoryFunc {
 		}
 
 		config := sqleng.DataPluginConfiguration{
-			DSInfo:            dsInfo,
-			TimeColumnNames:   []string{"time", "time_sec"},
-			MetricColumnTypes: []string{"CHAR", "VARCHAR", "TINYTEXT", "TEXT", "MEDIUMTEXT", "LONGTEXT"},
-			RowLimit:          sqlCfg.RowLimit,
+			DSInfo:                 dsInfo,
+			TimeColumnNames:        []string{"time", "time_sec"},
+			MetricColumnTypes:      []string{"CHAR", "VARCHAR", "TINYTEXT", "TEXT", "MEDIUMTEXT", "LONGTEXT"},
+			RowLimit:               sqlCfg.RowLimit,
+			ErrToHealthCheckResult: ErrToHealthCheckResult,
+			DriverError:            driverError,
 		}
 
 		userFacingDefaultError, err := cfg.UserFacingDefaultError()
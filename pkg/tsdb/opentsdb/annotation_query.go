@@ -0,0 +1,103 @@
+package opentsdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+)
+
+const annotationQueryType = "annotation"
+
+// annotationQueryModel is the model of an annotation query. The annotations of the time series of Target are
+// returned, or the global annotations when IsGlobal is set.
+type annotationQueryModel struct {
+	Target   string `json:"target"`
+	IsGlobal bool   `json:"isGlobal"`
+}
+
+func (s *Service) executeAnnotationQuery(ctx context.Context, logger log.Logger, dsInfo *datasourceInfo, query backend.DataQuery) backend.DataResponse {
+	var model annotationQueryModel
+	if err := json.Unmarshal(query.JSON, &model); err != nil {
+		return backend.ErrDataResponseWithSource(backend.StatusBadRequest, backend.ErrorSourceDownstream, fmt.Sprintf("failed to parse annotation query: %v", err))
+	}
+	if model.Target == "" {
+		return backend.ErrDataResponseWithSource(backend.StatusBadRequest, backend.ErrorSourceDownstream, "annotation query has no target")
+	}
+
+	tsdbQuery := OpenTsdbQuery{
+		Start:             query.TimeRange.From.UnixNano() / int64(time.Millisecond),
+		End:               query.TimeRange.To.UnixNano() / int64(time.Millisecond),
+		Queries:           []map[string]any{{"aggregator": "sum", "metric": model.Target}},
+		GlobalAnnotations: model.IsGlobal,
+	}
+	req, err := s.createRequest(ctx, logger, dsInfo, tsdbQuery)
+	if err != nil {
+		return backend.ErrorResponseWithErrorSource(err)
+	}
+
+	res, err := dsInfo.HTTPClient.Do(req)
+	if err != nil {
+		return backend.ErrorResponseWithErrorSource(err)
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			logger.Warn("Failed to close response body", "error", err)
+		}
+	}()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return backend.ErrorResponseWithErrorSource(err)
+	}
+	if res.StatusCode/100 != 2 {
+		logger.Info("Request failed", "status", res.Status, "body", string(body))
+		return backend.ErrorResponseWithErrorSource(backend.DownstreamError(fmt.Errorf("request failed, status: %s", res.Status)))
+	}
+
+	var responseData []OpenTsdbAnnotationResponse
+	if err := json.Unmarshal(body, &responseData); err != nil {
+		logger.Info("Failed to unmarshal opentsdb response", "error", err, "status", res.Status, "body", string(body))
+		return backend.ErrorResponseWithErrorSource(err)
+	}
+
+	// As in the frontend, only the annotations of the first time series are used, because global annotations
+	// are repeated in every series.
+	var annotations []OpenTsdbAnnotation
+	if len(responseData) > 0 {
+		annotations = responseData[0].Annotations
+		if model.IsGlobal {
+			annotations = responseData[0].GlobalAnnotations
+		}
+	}
+
+	return backend.DataResponse{Frames: data.Frames{annotationsToFrame(query.RefID, annotations)}}
+}
+
+func annotationsToFrame(refID string, annotations []OpenTsdbAnnotation) *data.Frame {
+	frame := data.NewFrame(refID,
+		data.NewField("time", nil, []time.Time{}),
+		data.NewField("timeEnd", nil, []*time.Time{}),
+		data.NewField("text", nil, []string{}),
+	)
+	for _, a := range annotations {
+		var end *time.Time
+		if a.EndTime > 0 {
+			t := time.Unix(a.EndTime, 0).UTC()
+			end = &t
+		}
+		frame.AppendRow(time.Unix(a.StartTime, 0).UTC(), end, a.Description)
+	}
+	frame.Meta = &data.FrameMeta{
+		Custom: map[string]any{
+			"rowCount": len(annotations),
+		},
+	}
+	return frame
+}
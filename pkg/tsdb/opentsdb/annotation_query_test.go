@@ -0,0 +1,88 @@
+package opentsdb
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/httpclient"
+)
+
+func TestAnnotationQuery(t *testing.T) {
+	var lastRequest OpenTsdbQuery
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &lastRequest))
+		_, _ = w.Write([]byte(`[{
+			"metric": "deploys",
+			"dps": {"1700000000": 1},
+			"annotations": [{"tsuid": "0001", "description": "deploy v1.2.3", "startTime": 1700000000, "endTime": 1700000600}],
+			"globalAnnotations": [{"description": "maintenance", "startTime": 1700000100}]
+		}]`))
+	}))
+	t.Cleanup(srv.Close)
+
+	service := ProvideService(httpclient.NewProvider())
+	timeRange := backend.TimeRange{From: time.Unix(1699990000, 0), To: time.Unix(1700010000, 0)}
+	query := func(t *testing.T, queries ...backend.DataQuery) *backend.QueryDataResponse {
+		t.Helper()
+		resp, err := service.QueryData(context.Background(), &backend.QueryDataRequest{
+			PluginContext: backend.PluginContext{
+				DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{ID: 1, URL: srv.URL, JSONData: []byte(`{}`)},
+			},
+			Queries: queries,
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("returns the annotations of the time series", func(t *testing.T) {
+		resp := query(t, backend.DataQuery{RefID: "A", QueryType: annotationQueryType, TimeRange: timeRange, JSON: []byte(`{"target": "deploys"}`)})
+
+		require.NoError(t, resp.Responses["A"].Error)
+		assert.Equal(t, "deploys", lastRequest.Queries[0]["metric"])
+		assert.False(t, lastRequest.GlobalAnnotations)
+		frame := resp.Responses["A"].Frames[0]
+		require.Equal(t, 1, frame.Rows())
+		assert.Equal(t, time.Unix(1700000000, 0).UTC(), frame.Fields[0].At(0))
+		end := time.Unix(1700000600, 0).UTC()
+		assert.Equal(t, &end, frame.Fields[1].At(0))
+		assert.Equal(t, "deploy v1.2.3", frame.Fields[2].At(0))
+	})
+
+	t.Run("returns the global annotations", func(t *testing.T) {
+		resp := query(t, backend.DataQuery{RefID: "A", QueryType: annotationQueryType, TimeRange: timeRange, JSON: []byte(`{"target": "deploys", "isGlobal": true}`)})
+
+		require.NoError(t, resp.Responses["A"].Error)
+		assert.True(t, lastRequest.GlobalAnnotations)
+		frame := resp.Responses["A"].Frames[0]
+		require.Equal(t, 1, frame.Rows())
+		assert.Nil(t, frame.Fields[1].At(0))
+		assert.Equal(t, "maintenance", frame.Fields[2].At(0))
+	})
+
+	t.Run("requires a target", func(t *testing.T) {
+		resp := query(t, backend.DataQuery{RefID: "A", QueryType: annotationQueryType, TimeRange: timeRange, JSON: []byte(`{}`)})
+
+		require.Error(t, resp.Responses["A"].Error)
+	})
+
+	t.Run("can be mixed with metric queries", func(t *testing.T) {
+		resp := query(t,
+			backend.DataQuery{RefID: "A", QueryType: annotationQueryType, TimeRange: timeRange, JSON: []byte(`{"target": "deploys"}`)},
+			backend.DataQuery{RefID: "B", TimeRange: timeRange, JSON: []byte(`{"metric": "deploys", "aggregator": "sum"}`)},
+		)
+
+		require.Contains(t, resp.Responses, "A")
+		require.Contains(t, resp.Responses, "B")
+		assert.Equal(t, 1, resp.Responses["A"].Frames[0].Rows())
+	})
+}
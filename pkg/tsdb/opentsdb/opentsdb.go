@@ -17,6 +17,7 @@ import (
 	"github.com/grafana/grafana-plugin-sdk-go/backend/datasource"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/patrickmn/go-cache"
 
 	"github.com/grafana/grafana/pkg/components/simplejson"
 	"github.com/grafana/grafana/pkg/infra/httpclient"
@@ -42,6 +43,8 @@ type datasourceInfo struct {
 	TSDBVersion    float32
 	TSDBResolution int32
 	LookupLimit    int32
+
+	resourceCache *cache.Cache
 }
 
 type DsAccess string
@@ -76,6 +79,7 @@ func newInstanceSettings(httpClientProvider httpclient.Provider) datasource.Inst
 			TSDBVersion:    jsonData.TSDBVersion,
 			TSDBResolution: jsonData.TSDBResolution,
 			LookupLimit:    jsonData.LookupLimit,
+			resourceCache:  newResourceCache(),
 		}
 
 		return model, nil
@@ -87,14 +91,32 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 
 	logger := logger.FromContext(ctx)
 
-	q := req.Queries[0]
+	dsInfo, err := s.getDSInfo(ctx, req.PluginContext)
+	if err != nil {
+		return nil, err
+	}
+
+	annotationResponses := backend.Responses{}
+	metricQueries := make([]backend.DataQuery, 0, len(req.Queries))
+	for _, query := range req.Queries {
+		if query.QueryType == annotationQueryType {
+			annotationResponses[query.RefID] = s.executeAnnotationQuery(ctx, logger, dsInfo, query)
+			continue
+		}
+		metricQueries = append(metricQueries, query)
+	}
+	if len(metricQueries) == 0 {
+		return &backend.QueryDataResponse{Responses: annotationResponses}, nil
+	}
+
+	q := metricQueries[0]
 
 	refID := q.RefID
 
 	tsdbQuery.Start = q.TimeRange.From.UnixNano() / int64(time.Millisecond)
 	tsdbQuery.End = q.TimeRange.To.UnixNano() / int64(time.Millisecond)
 
-	for _, query := range req.Queries {
+	for _, query := range metricQueries {
 		metric := s.buildMetric(query)
 		tsdbQuery.Queries = append(tsdbQuery.Queries, metric)
 	}
@@ -104,11 +126,6 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 		logger.Debug("OpenTsdb request", "params", tsdbQuery)
 	}
 
-	dsInfo, err := s.getDSInfo(ctx, req.PluginContext)
-	if err != nil {
-		return nil, err
-	}
-
 	request, err := s.createRequest(ctx, logger, dsInfo, tsdbQuery)
 	if err != nil {
 		return &backend.QueryDataResponse{}, err
@@ -131,6 +148,9 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 		return &backend.QueryDataResponse{}, err
 	}
 
+	for refID, resp := range annotationResponses {
+		result.Responses[refID] = resp
+	}
 	return result, nil
 }
 
@@ -0,0 +1,221 @@
+package opentsdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/resource/httpadapter"
+	"github.com/patrickmn/go-cache"
+)
+
+var (
+	_ backend.CallResourceHandler = (*Service)(nil)
+)
+
+const (
+	resourceCacheExpiration = time.Minute
+	// Used when the data source doesn't configure a lookup limit, as the frontend does.
+	defaultLookupLimit = 1000
+	maxLookupLimit     = 100000
+)
+
+var suggestTypes = map[string]bool{"metrics": true, "tagk": true, "tagv": true}
+
+// LookupResponse is the response of the /api/search/lookup endpoint.
+type LookupResponse struct {
+	Type         string         `json:"type"`
+	Metric       string         `json:"metric"`
+	Results      []LookupResult `json:"results"`
+	TotalResults int            `json:"totalResults"`
+}
+
+type LookupResult struct {
+	Metric string            `json:"metric"`
+	Tags   map[string]string `json:"tags"`
+	TSUID  string            `json:"tsuid"`
+}
+
+func (s *Service) CallResource(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) error {
+	handler := httpadapter.New(s.registerResourceRoutes())
+	return handler.CallResource(ctx, req, sender)
+}
+
+func (s *Service) registerResourceRoutes() *http.ServeMux {
+	router := http.NewServeMux()
+	router.HandleFunc("GET /api/suggest", s.withDatasourceHandlerFunc(s.handleSuggest))
+	router.HandleFunc("GET /api/search/lookup", s.withDatasourceHandlerFunc(s.handleLookup))
+	return router
+}
+
+func (s *Service) withDatasourceHandlerFunc(handler func(rw http.ResponseWriter, r *http.Request, dsInfo *datasourceInfo)) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		dsInfo, err := s.getDSInfo(r.Context(), backend.PluginConfigFromContext(r.Context()))
+		if err != nil {
+			logger.FromContext(r.Context()).Error("Failed to get data source info", "error", err)
+			writeError(rw, http.StatusInternalServerError, errors.New("failed to get data source info"))
+			return
+		}
+		handler(rw, r, dsInfo)
+	}
+}
+
+func (s *Service) handleSuggest(rw http.ResponseWriter, r *http.Request, dsInfo *datasourceInfo) {
+	query := r.URL.Query()
+	suggestType := query.Get("type")
+	if !suggestTypes[suggestType] {
+		writeError(rw, http.StatusBadRequest, errors.New("type must be one of metrics, tagk or tagv"))
+		return
+	}
+	limit, err := parseLimit(query.Get("max"), dsInfo)
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, err)
+		return
+	}
+
+	params := url.Values{
+		"type": {suggestType},
+		"q":    {query.Get("q")},
+		"max":  {strconv.Itoa(limit)},
+	}
+	s.handleOpenTSDBRequest(rw, r, dsInfo, "api/suggest", params, func(body []byte) (any, error) {
+		var suggestions []string
+		if err := json.Unmarshal(body, &suggestions); err != nil {
+			return nil, err
+		}
+		return suggestions, nil
+	})
+}
+
+func (s *Service) handleLookup(rw http.ResponseWriter, r *http.Request, dsInfo *datasourceInfo) {
+	query := r.URL.Query()
+	metric := query.Get("m")
+	if metric == "" {
+		writeError(rw, http.StatusBadRequest, errors.New("missing parameter: m"))
+		return
+	}
+	limit, err := parseLimit(query.Get("limit"), dsInfo)
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, err)
+		return
+	}
+
+	params := url.Values{
+		"m":     {metric},
+		"limit": {strconv.Itoa(limit)},
+	}
+	s.handleOpenTSDBRequest(rw, r, dsInfo, "api/search/lookup", params, func(body []byte) (any, error) {
+		var lookup LookupResponse
+		if err := json.Unmarshal(body, &lookup); err != nil {
+			return nil, err
+		}
+		return lookup, nil
+	})
+}
+
+// handleOpenTSDBRequest sends a GET request to the OpenTSDB API and writes the decoded response.
+// Successful responses are cached per data source, keyed by the path and the parameters of the request.
+func (s *Service) handleOpenTSDBRequest(rw http.ResponseWriter, r *http.Request, dsInfo *datasourceInfo, apiPath string, params url.Values, decode func([]byte) (any, error)) {
+	logger := logger.FromContext(r.Context())
+
+	cacheKey := apiPath + "?" + params.Encode()
+	if dsInfo.resourceCache != nil {
+		if cached, found := dsInfo.resourceCache.Get(cacheKey); found {
+			writeJSON(rw, cached.([]byte))
+			return
+		}
+	}
+
+	u, err := url.Parse(dsInfo.URL)
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, fmt.Errorf("invalid data source URL: %w", err))
+		return
+	}
+	u.Path = path.Join(u.Path, apiPath)
+	u.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, u.String(), nil)
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, fmt.Errorf("failed to create request: %w", err))
+		return
+	}
+
+	res, err := dsInfo.HTTPClient.Do(req)
+	if err != nil {
+		logger.Error("Resource request to OpenTSDB failed", "error", err, "path", apiPath)
+		writeError(rw, http.StatusBadGateway, errors.New("request to OpenTSDB failed"))
+		return
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			logger.Warn("Failed to close response body", "error", err)
+		}
+	}()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		writeError(rw, http.StatusBadGateway, fmt.Errorf("failed to read response: %w", err))
+		return
+	}
+	if res.StatusCode/100 != 2 {
+		logger.Info("Resource request failed", "status", res.Status, "path", apiPath, "body", string(body))
+		writeError(rw, res.StatusCode, fmt.Errorf("request failed, status: %s", res.Status))
+		return
+	}
+
+	result, err := decode(body)
+	if err != nil {
+		logger.Info("Failed to decode OpenTSDB response", "error", err, "path", apiPath, "body", string(body))
+		writeError(rw, http.StatusBadGateway, fmt.Errorf("failed to decode response from OpenTSDB: %w", err))
+		return
+	}
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, err)
+		return
+	}
+
+	if dsInfo.resourceCache != nil {
+		dsInfo.resourceCache.Set(cacheKey, encoded, cache.DefaultExpiration)
+	}
+	writeJSON(rw, encoded)
+}
+
+// parseLimit returns the requested limit, defaulting to the lookup limit of the data source.
+func parseLimit(value string, dsInfo *datasourceInfo) (int, error) {
+	if value == "" {
+		if dsInfo.LookupLimit > 0 {
+			return int(dsInfo.LookupLimit), nil
+		}
+		return defaultLookupLimit, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 || limit > maxLookupLimit {
+		return 0, fmt.Errorf("invalid parameter: limit must be between 1 and %d", maxLookupLimit)
+	}
+	return limit, nil
+}
+
+func writeJSON(rw http.ResponseWriter, body []byte) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	_, _ = rw.Write(body)
+}
+
+func writeError(rw http.ResponseWriter, status int, err error) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	_ = json.NewEncoder(rw).Encode(map[string]string{"message": err.Error()})
+}
+
+func newResourceCache() *cache.Cache {
+	return cache.New(resourceCacheExpiration, 5*resourceCacheExpiration)
+}
@@ -0,0 +1,100 @@
+package opentsdb
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/httpclient"
+)
+
+func TestCallResource(t *testing.T) {
+	requests := 0
+	var lastQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		lastQuery = r.URL.RawQuery
+		switch r.URL.Path {
+		case "/api/suggest":
+			_, _ = w.Write([]byte(`["cpu.idle","cpu.user"]`))
+		case "/api/search/lookup":
+			_, _ = w.Write([]byte(`{"type":"LOOKUP","metric":"cpu.idle","time":1,"results":[{"metric":"cpu.idle","tags":{"host":"a"},"tsuid":"0001"}],"totalResults":1}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	service := ProvideService(httpclient.NewProvider())
+	pluginCtx := backend.PluginContext{
+		DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{ID: 1, URL: srv.URL, JSONData: []byte(`{"lookupLimit":50}`)},
+	}
+	call := func(t *testing.T, path string) *backend.CallResourceResponse {
+		t.Helper()
+		var resp *backend.CallResourceResponse
+		err := service.CallResource(context.Background(), &backend.CallResourceRequest{
+			PluginContext: pluginCtx,
+			Method:        http.MethodGet,
+			Path:          strings.SplitN(path, "?", 2)[0],
+			URL:           path,
+		}, backend.CallResourceResponseSenderFunc(func(r *backend.CallResourceResponse) error {
+			resp = r
+			return nil
+		}))
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		return resp
+	}
+
+	t.Run("suggest defaults to the lookup limit of the data source", func(t *testing.T) {
+		resp := call(t, "api/suggest?type=metrics&q=cpu")
+		require.Equal(t, http.StatusOK, resp.Status)
+		assert.Equal(t, "max=50&q=cpu&type=metrics", lastQuery)
+		assert.JSONEq(t, `["cpu.idle","cpu.user"]`, string(resp.Body))
+	})
+
+	t.Run("responses are cached", func(t *testing.T) {
+		before := requests
+		resp := call(t, "api/suggest?type=metrics&q=cpu")
+		require.Equal(t, http.StatusOK, resp.Status)
+		assert.Equal(t, before, requests)
+	})
+
+	t.Run("suggest validates the type and limit", func(t *testing.T) {
+		resp := call(t, "api/suggest?type=uid&q=cpu")
+		assert.Equal(t, http.StatusBadRequest, resp.Status)
+
+		resp = call(t, "api/suggest?type=tagk&max=-1")
+		assert.Equal(t, http.StatusBadRequest, resp.Status)
+	})
+
+	t.Run("lookup returns typed results", func(t *testing.T) {
+		resp := call(t, "api/search/lookup?m=cpu.idle{host=*}&limit=10")
+		require.Equal(t, http.StatusOK, resp.Status)
+		assert.Equal(t, "limit=10&m=cpu.idle%7Bhost%3D%2A%7D", lastQuery)
+
+		var lookup LookupResponse
+		require.NoError(t, json.Unmarshal(resp.Body, &lookup))
+		require.Len(t, lookup.Results, 1)
+		assert.Equal(t, map[string]string{"host": "a"}, lookup.Results[0].Tags)
+	})
+
+	t.Run("lookup requires a metric", func(t *testing.T) {
+		resp := call(t, "api/search/lookup")
+		assert.Equal(t, http.StatusBadRequest, resp.Status)
+	})
+
+	t.Run("unknown paths are not forwarded", func(t *testing.T) {
+		before := requests
+		resp := call(t, "api/query")
+		assert.Equal(t, http.StatusNotFound, resp.Status)
+		assert.Equal(t, before, requests)
+	})
+}
@@ -1,9 +1,10 @@
 package opentsdb
 
 type OpenTsdbQuery struct {
-	Start   int64            `json:"start"`
-	End     int64            `json:"end"`
-	Queries []map[string]any `json:"queries"`
+	Start             int64            `json:"start"`
+	End               int64            `json:"end"`
+	Queries           []map[string]any `json:"queries"`
+	GlobalAnnotations bool             `json:"globalAnnotations,omitempty"`
 }
 
 type OpenTsdbCommon struct {
@@ -20,3 +21,18 @@ type OpenTsdbResponse24 struct {
 	OpenTsdbCommon
 	DataPoints [][]float64 `json:"dps"`
 }
+
+type OpenTsdbAnnotation struct {
+	TSUID       string         `json:"tsuid"`
+	Description string         `json:"description"`
+	Notes       string         `json:"notes"`
+	Custom      map[string]any `json:"custom"`
+	StartTime   int64          `json:"startTime"`
+	EndTime     int64          `json:"endTime"`
+}
+
+type OpenTsdbAnnotationResponse struct {
+	Metric            string               `json:"metric"`
+	Annotations       []OpenTsdbAnnotation `json:"annotations"`
+	GlobalAnnotations []OpenTsdbAnnotation `json:"globalAnnotations"`
+}
@@ -13,11 +13,20 @@ import (
 )
 
 func (e *DataSourceHandler) CheckHealth(ctx context.Context, req *backend.CheckHealthRequest) (*backend.CheckHealthResult, error) {
-	if err := e.db.Ping(); err != nil {
+	err := e.Ping()
+	if err != nil {
 		logCheckHealthError(ctx, e.dsInfo, err)
 		if strings.EqualFold(req.PluginContext.User.Role, "Admin") {
+			if e.errToHealthCheckResult != nil {
+				return e.errToHealthCheckResult(err)
+			}
 			return ErrToHealthCheckResult(err)
 		}
+		if e.driverError != nil {
+			if driverErr := e.driverError(err); driverErr != nil {
+				return &backend.CheckHealthResult{Status: backend.HealthStatusError, Message: e.TransformQueryError(e.log, driverErr).Error()}, nil
+			}
+		}
 		return &backend.CheckHealthResult{Status: backend.HealthStatusError, Message: e.TransformQueryError(e.log, err).Error()}, nil
 	}
 	return &backend.CheckHealthResult{Status: backend.HealthStatusOk, Message: "Database Connection OK"}, nil
@@ -25,42 +34,20 @@ func (e *DataSourceHandler) CheckHealth(ctx context.Context, req *backend.CheckH
 
 // ErrToHealthCheckResult converts error into user friendly health check message
 // This should be called with non nil error. If the err parameter is empty, we will send Internal Server Error
+// Data sources override it with DataPluginConfiguration.ErrToHealthCheckResult to describe the errors of their driver.
 func ErrToHealthCheckResult(err error) (*backend.CheckHealthResult, error) {
 	if err == nil {
 		return &backend.CheckHealthResult{Status: backend.HealthStatusError, Message: "Internal Server Error"}, nil
 	}
 	res := &backend.CheckHealthResult{Status: backend.HealthStatusError, Message: err.Error()}
 	details := map[string]string{
-		"verboseMessage":   err.Error(),
-		"errorDetailsLink": "https://grafana.com/docs/grafana/latest/datasources/mssql",
+		"verboseMessage": err.Error(),
 	}
 	var opErr *net.OpError
 	if errors.As(err, &opErr) {
 		res.Message = "Network error: Failed to connect to the server"
 		if opErr != nil && opErr.Err != nil {
-			errMessage := opErr.Err.Error()
-			if strings.HasSuffix(opErr.Err.Error(), "no such host") {
-				errMessage = "no such host"
-			}
-			if strings.HasSuffix(opErr.Err.Error(), "unknown port") {
-				errMessage = "unknown port"
-			}
-			if strings.HasSuffix(opErr.Err.Error(), "invalid port") {
-				errMessage = "invalid port"
-			}
-			if strings.HasSuffix(opErr.Err.Error(), "missing port in address") {
-				errMessage = "missing port in address"
-			}
-			if strings.HasSuffix(opErr.Err.Error(), "invalid syntax") {
-				errMessage = "invalid syntax found in the address"
-			}
-			res.Message += fmt.Sprintf(". Error message: %s", errMessage)
-		}
-	}
-	if strings.HasPrefix(err.Error(), "mssql: ") {
-		res.Message = "Database error: Failed to connect to the mssql server"
-		if unwrappedErr := errors.Unwrap(err); unwrappedErr != nil {
-			details["verboseMessage"] = unwrappedErr.Error()
+			res.Message += fmt.Sprintf(". Error message: %s", opErr.Err.Error())
 		}
 	}
 	detailBytes, marshalErr := json.Marshal(details)
@@ -82,6 +69,7 @@ func logCheckHealthError(ctx context.Context, dsInfo DataSourceInfo, err error)
 		"config_max_idle_conns":             dsInfo.JsonData.MaxIdleConns,
 		"config_conn_max_life_time":         dsInfo.JsonData.ConnMaxLifetime,
 		"config_conn_timeout":               dsInfo.JsonData.ConnectionTimeout,
+		"config_timescaledb":                dsInfo.JsonData.Timescaledb,
 		"config_ssl_mode":                   dsInfo.JsonData.Mode,
 		"config_tls_configuration_method":   dsInfo.JsonData.ConfigurationMethod,
 		"config_tls_skip_verify":            dsInfo.JsonData.TlsSkipVerify,
@@ -76,6 +76,16 @@ type DataPluginConfiguration struct {
 	TimeColumnNames   []string
 	MetricColumnTypes []string
 	RowLimit          int64
+	// ErrToHealthCheckResult converts the error of a failed health check into the result shown to admins.
+	// When not set, ErrToHealthCheckResult of this package is used.
+	ErrToHealthCheckResult func(err error) (*backend.CheckHealthResult, error)
+	// DriverError extracts the error of the driver from the error of a failed health check, which is then
+	// transformed for non-admins instead of the whole error. When not set, or when it returns nil, the whole
+	// error is transformed.
+	DriverError func(err error) error
+	// DynamicColumnTypes infers the type of the columns from their values, for drivers that do not report
+	// the type of computed columns.
+	DynamicColumnTypes bool
 }
 
 type DataSourceHandler struct {
@@ -88,6 +98,9 @@ type DataSourceHandler struct {
 	dsInfo                 DataSourceInfo
 	rowLimit               int64
 	userError              string
+	errToHealthCheckResult func(err error) (*backend.CheckHealthResult, error)
+	driverError            func(err error) error
+	dynamicColumnTypes     bool
 }
 
 type QueryJson struct {
@@ -123,6 +136,9 @@ func NewQueryDataHandler(userFacingDefaultError string, db *sql.DB, config DataP
 		dsInfo:                 config.DSInfo,
 		rowLimit:               config.RowLimit,
 		userError:              userFacingDefaultError,
+		errToHealthCheckResult: config.ErrToHealthCheckResult,
+		driverError:            config.DriverError,
+		dynamicColumnTypes:     config.DynamicColumnTypes,
 	}
 
 	if len(config.TimeColumnNames) > 0 {
@@ -273,11 +289,20 @@ func (e *DataSourceHandler) executeQuery(query backend.DataQuery, wg *sync.WaitG
 
 	// Convert row.Rows to dataframe
 	stringConverters := e.queryResultTransformer.GetConverterList()
-	frame, err := sqlutil.FrameFromRows(rows, e.rowLimit, sqlutil.ToConverters(stringConverters...)...)
+	converters := sqlutil.ToConverters(stringConverters...)
+	if e.dynamicColumnTypes {
+		converters = append(converters, sqlutil.Converter{Dynamic: true})
+	}
+	frame, err := sqlutil.FrameFromRows(rows, e.rowLimit, converters...)
 	if err != nil {
 		errAppendDebug("convert frame from rows error", err, interpolatedQuery, backend.ErrorSourcePlugin)
 		return
 	}
+	// The dynamic conversion stops at the first error without returning it.
+	if err := rows.Err(); err != nil {
+		errAppendDebug("db query error", e.TransformQueryError(logger, err), interpolatedQuery, backend.ErrorSourceDownstream)
+		return
+	}
 
 	if frame.Meta == nil {
 		frame.Meta = &data.FrameMeta{}
@@ -0,0 +1,101 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMacroEngine(t *testing.T) {
+	engine := &sqliteMacroEngine{
+		logger: backend.NewLoggerWith("logger", "test"),
+	}
+	query := &backend.DataQuery{}
+
+	t.Run("Given a time range between 2018-04-12 00:00 and 2018-04-12 00:05", func(t *testing.T) {
+		from := time.Date(2018, 4, 12, 18, 0, 0, 0, time.UTC)
+		to := from.Add(5 * time.Minute)
+		timeRange := backend.TimeRange{From: from, To: to}
+
+		t.Run("interpolate __time function", func(t *testing.T) {
+			sql, err := engine.Interpolate(query, timeRange, "select $__time(time_column)")
+			require.Nil(t, err)
+
+			require.Equal(t, "select unixepoch(time_column) AS time", sql)
+		})
+
+		t.Run("interpolate __timeEpoch function", func(t *testing.T) {
+			sql, err := engine.Interpolate(query, timeRange, "select $__timeEpoch(time_column)")
+			require.Nil(t, err)
+
+			require.Equal(t, "select unixepoch(time_column) AS time_sec", sql)
+		})
+
+		t.Run("interpolate __timeGroup function", func(t *testing.T) {
+			sql, err := engine.Interpolate(query, timeRange, "GROUP BY $__timeGroup(time_column , '5m')")
+			require.Nil(t, err)
+			sql2, err := engine.Interpolate(query, timeRange, "GROUP BY $__timeGroupAlias(time_column,'5m')")
+			require.Nil(t, err)
+
+			require.Equal(t, "GROUP BY unixepoch(time_column) / 300 * 300", sql)
+			require.Equal(t, sql+" AS \"time\"", sql2)
+		})
+
+		t.Run("interpolate __timeGroup function with fill", func(t *testing.T) {
+			fillQuery := &backend.DataQuery{JSON: []byte(`{}`)}
+			_, err := engine.Interpolate(fillQuery, timeRange, "GROUP BY $__timeGroup(time_column,'5m', NULL)")
+			require.Nil(t, err)
+
+			require.JSONEq(t, `{"fill": true, "fillInterval": 300, "fillMode": "null"}`, string(fillQuery.JSON))
+		})
+
+		t.Run("interpolate __timeFilter function", func(t *testing.T) {
+			sql, err := engine.Interpolate(query, timeRange, "WHERE $__timeFilter(time_column)")
+			require.Nil(t, err)
+
+			require.Equal(t, "WHERE unixepoch(time_column) BETWEEN 1523556000 AND 1523556300", sql)
+		})
+
+		t.Run("interpolate __timeFrom and __timeTo functions", func(t *testing.T) {
+			sql, err := engine.Interpolate(query, timeRange, "select $__timeFrom(), $__timeTo()")
+			require.Nil(t, err)
+
+			require.Equal(t, "select datetime(1523556000, 'unixepoch'), datetime(1523556300, 'unixepoch')", sql)
+		})
+
+		t.Run("interpolate __unixEpochFilter function", func(t *testing.T) {
+			sql, err := engine.Interpolate(query, timeRange, "select $__unixEpochFilter(time)")
+			require.Nil(t, err)
+
+			require.Equal(t, "select time >= 1523556000 AND time <= 1523556300", sql)
+		})
+
+		t.Run("interpolate __unixEpochNanoFilter function", func(t *testing.T) {
+			sql, err := engine.Interpolate(query, timeRange, "select $__unixEpochNanoFilter(time)")
+			require.Nil(t, err)
+
+			require.Equal(t, "select time >= 1523556000000000000 AND time <= 1523556300000000000", sql)
+		})
+
+		t.Run("interpolate __unixEpochGroup function", func(t *testing.T) {
+			sql, err := engine.Interpolate(query, timeRange, "SELECT $__unixEpochGroup(time_column,'5m')")
+			require.Nil(t, err)
+			sql2, err := engine.Interpolate(query, timeRange, "SELECT $__unixEpochGroupAlias(time_column,'5m')")
+			require.Nil(t, err)
+
+			require.Equal(t, "SELECT time_column / 300 * 300", sql)
+			require.Equal(t, sql+" AS \"time\"", sql2)
+		})
+
+		t.Run("unknown macros and missing arguments are errors", func(t *testing.T) {
+			_, err := engine.Interpolate(query, timeRange, "select $__unknown(time)")
+			require.Error(t, err)
+
+			_, err = engine.Interpolate(query, timeRange, "GROUP BY $__timeGroup(time)")
+			require.Error(t, err)
+		})
+	})
+}
@@ -0,0 +1,179 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/datasource"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
+	"github.com/grafana/grafana-plugin-sdk-go/data/sqlutil"
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
+	"github.com/grafana/grafana/pkg/tsdb/sqleng"
+)
+
+const (
+	// AllowedDirectoryKey is the key of the plugin configuration holding the directory SQLite databases can be
+	// read from. When it is empty, no database can be read.
+	AllowedDirectoryKey = "GF_SQL_SQLITE_ALLOWED_DIRECTORY"
+
+	driverName = "sqlite3_grafana_datasource"
+)
+
+var (
+	ErrNoAllowedDirectory = errors.New("no directory is allowed for SQLite databases, set sqlite_allowed_directory in the [sql_datasources] section of the configuration")
+	ErrPathNotAllowed     = errors.New("the database file is outside of the directory allowed for SQLite databases")
+	ErrNoDatabasePath     = errors.New("no database file configured")
+)
+
+// jsonDataModel holds the settings of the data source that are specific to SQLite.
+type jsonDataModel struct {
+	// Path is the path of the database file, relative to the allowed directory unless it is absolute.
+	Path string `json:"path"`
+}
+
+var registerDriverOnce sync.Once
+
+// registerDriver registers a driver whose connections cannot attach other databases, so that queries
+// cannot read files outside of the allowed directory.
+func registerDriver() {
+	registerDriverOnce.Do(func() {
+		sql.Register(driverName, &sqlite3.SQLiteDriver{
+			ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+				conn.SetLimit(sqlite3.SQLITE_LIMIT_ATTACHED, 0)
+				return nil
+			},
+		})
+	})
+}
+
+func NewInstanceSettings(logger log.Logger) datasource.InstanceFactoryFunc {
+	return func(ctx context.Context, settings backend.DataSourceInstanceSettings) (instancemgmt.Instance, error) {
+		cfg := backend.GrafanaConfigFromContext(ctx)
+		sqlCfg, err := cfg.SQL()
+		if err != nil {
+			return nil, err
+		}
+		jsonData := sqleng.JsonData{
+			MaxOpenConns:    sqlCfg.DefaultMaxOpenConns,
+			MaxIdleConns:    sqlCfg.DefaultMaxIdleConns,
+			ConnMaxLifetime: sqlCfg.DefaultMaxConnLifetimeSeconds,
+		}
+
+		err = json.Unmarshal(settings.JSONData, &jsonData)
+		if err != nil {
+			return nil, fmt.Errorf("error reading settings: %w", err)
+		}
+
+		var sqliteJSONData jsonDataModel
+		err = json.Unmarshal(settings.JSONData, &sqliteJSONData)
+		if err != nil {
+			return nil, fmt.Errorf("error reading settings: %w", err)
+		}
+
+		path, err := resolveDatabasePath(cfg.Get(AllowedDirectoryKey), sqliteJSONData.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		dsInfo := sqleng.DataSourceInfo{
+			JsonData:                jsonData,
+			Database:                path,
+			ID:                      settings.ID,
+			Updated:                 settings.Updated,
+			UID:                     settings.UID,
+			DecryptedSecureJSONData: settings.DecryptedSecureJSONData,
+		}
+
+		config := sqleng.DataPluginConfiguration{
+			DSInfo:            dsInfo,
+			TimeColumnNames:   []string{"time", "time_sec"},
+			MetricColumnTypes: []string{"TEXT", "CHAR", "VARCHAR", "CLOB"},
+			RowLimit:          sqlCfg.RowLimit,
+			// SQLite columns have no type, only their values have.
+			DynamicColumnTypes: true,
+		}
+
+		userFacingDefaultError, err := cfg.UserFacingDefaultError()
+		if err != nil {
+			return nil, err
+		}
+
+		registerDriver()
+		db, err := sql.Open(driverName, connectionString(path))
+		if err != nil {
+			return nil, err
+		}
+
+		db.SetMaxOpenConns(config.DSInfo.JsonData.MaxOpenConns)
+		db.SetMaxIdleConns(config.DSInfo.JsonData.MaxIdleConns)
+		db.SetConnMaxLifetime(time.Duration(config.DSInfo.JsonData.ConnMaxLifetime) * time.Second)
+
+		return sqleng.NewQueryDataHandler(userFacingDefaultError, db, config, &sqliteQueryResultTransformer{}, newSQLiteMacroEngine(logger), logger)
+	}
+}
+
+// resolveDatabasePath returns the absolute path of the database file, which is relative to the allowed
+// directory unless it is absolute. Symbolic links are resolved, so that they cannot be used to read files
+// outside of the allowed directory.
+func resolveDatabasePath(allowedDirectory string, path string) (string, error) {
+	if allowedDirectory == "" {
+		return "", ErrNoAllowedDirectory
+	}
+	if path == "" {
+		return "", ErrNoDatabasePath
+	}
+
+	allowed, err := filepath.Abs(allowedDirectory)
+	if err != nil {
+		return "", err
+	}
+	allowed, err = filepath.EvalSymlinks(allowed)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the directory allowed for SQLite databases: %w", err)
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(allowed, path)
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("database file %q does not exist", path)
+		}
+		return "", err
+	}
+
+	rel, err := filepath.Rel(allowed, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", ErrPathNotAllowed
+	}
+	return resolved, nil
+}
+
+// connectionString opens the database in read-only mode.
+func connectionString(path string) string {
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path), RawQuery: "mode=ro"}
+	return u.String()
+}
+
+type sqliteQueryResultTransformer struct{}
+
+func (t *sqliteQueryResultTransformer) TransformQueryError(_ log.Logger, err error) error {
+	return err
+}
+
+func (t *sqliteQueryResultTransformer) GetConverterList() []sqlutil.StringConverter {
+	return nil
+}
@@ -0,0 +1,162 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveDatabasePath(t *testing.T) {
+	allowed := t.TempDir()
+	outside := t.TempDir()
+	createDatabase(t, filepath.Join(allowed, "ci.db"))
+	createDatabase(t, filepath.Join(outside, "other.db"))
+	require.NoError(t, os.Symlink(filepath.Join(outside, "other.db"), filepath.Join(allowed, "link.db")))
+
+	allowed, err := filepath.EvalSymlinks(allowed)
+	require.NoError(t, err)
+
+	t.Run("resolves paths relative to the allowed directory", func(t *testing.T) {
+		path, err := resolveDatabasePath(allowed, "ci.db")
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(allowed, "ci.db"), path)
+	})
+
+	t.Run("accepts absolute paths inside the allowed directory", func(t *testing.T) {
+		path, err := resolveDatabasePath(allowed, filepath.Join(allowed, "ci.db"))
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(allowed, "ci.db"), path)
+	})
+
+	t.Run("rejects paths outside of the allowed directory", func(t *testing.T) {
+		_, err := resolveDatabasePath(allowed, filepath.Join(outside, "other.db"))
+		require.ErrorIs(t, err, ErrPathNotAllowed)
+
+		_, err = resolveDatabasePath(allowed, filepath.Join("..", filepath.Base(outside), "other.db"))
+		require.ErrorIs(t, err, ErrPathNotAllowed)
+	})
+
+	t.Run("rejects symbolic links to files outside of the allowed directory", func(t *testing.T) {
+		_, err := resolveDatabasePath(allowed, "link.db")
+		require.ErrorIs(t, err, ErrPathNotAllowed)
+	})
+
+	t.Run("rejects everything when no directory is allowed", func(t *testing.T) {
+		_, err := resolveDatabasePath("", filepath.Join(allowed, "ci.db"))
+		require.ErrorIs(t, err, ErrNoAllowedDirectory)
+	})
+
+	t.Run("rejects missing files", func(t *testing.T) {
+		_, err := resolveDatabasePath(allowed, "missing.db")
+		require.Error(t, err)
+	})
+}
+
+func TestQueryData(t *testing.T) {
+	allowed := t.TempDir()
+	db := createDatabase(t, filepath.Join(allowed, "ci.db"))
+	_, err := db.Exec(`CREATE TABLE builds (time DATETIME, epoch INTEGER, pipeline TEXT, duration REAL)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO builds VALUES
+		('2024-01-01 10:00:00', 1704103200, 'main', 10.5),
+		('2024-01-01 10:01:00', 1704103260, 'main', 12),
+		('2024-01-01 10:06:00', 1704103560, 'release', 30)`)
+	require.NoError(t, err)
+
+	service := ProvideService()
+	ctx := backend.WithGrafanaConfig(context.Background(), backend.NewGrafanaCfg(map[string]string{
+		AllowedDirectoryKey:                      allowed,
+		backend.SQLMaxOpenConnsDefault:           "10",
+		backend.SQLMaxIdleConnsDefault:           "10",
+		backend.SQLMaxConnLifetimeSecondsDefault: "14400",
+		backend.SQLRowLimit:                      "1000000",
+		backend.UserFacingDefaultError:           "inspect Grafana server log for details",
+	}))
+	pluginCtx := backend.PluginContext{
+		DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{ID: 1, JSONData: []byte(`{"path": "ci.db"}`)},
+	}
+	timeRange := backend.TimeRange{
+		From: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+	}
+	query := func(t *testing.T, rawSQL string, format string) backend.DataResponse {
+		t.Helper()
+		resp, err := service.QueryData(ctx, &backend.QueryDataRequest{
+			PluginContext: pluginCtx,
+			Queries: []backend.DataQuery{{
+				RefID:     "A",
+				TimeRange: timeRange,
+				JSON:      []byte(`{"rawSql": "` + rawSQL + `", "format": "` + format + `"}`),
+			}},
+		})
+		require.NoError(t, err)
+		return resp.Responses["A"]
+	}
+
+	t.Run("groups text timestamps into time series", func(t *testing.T) {
+		resp := query(t, "SELECT $__timeGroupAlias(time, '5m'), pipeline AS metric, sum(duration) AS value FROM builds WHERE $__timeFilter(time) GROUP BY 1, 2 ORDER BY 1", "time_series")
+		require.NoError(t, resp.Error)
+		require.Len(t, resp.Frames, 1)
+
+		frame := resp.Frames[0]
+		require.Equal(t, 2, frame.Rows())
+		assert.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), frame.Fields[0].At(0).(time.Time).UTC())
+		assert.Equal(t, time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC), frame.Fields[0].At(1).(time.Time).UTC())
+		assert.Equal(t, "main", frame.Fields[1].Name)
+		assert.Equal(t, 22.5, *frame.Fields[1].At(0).(*float64))
+		assert.Equal(t, "release", frame.Fields[2].Name)
+		assert.Equal(t, 30.0, *frame.Fields[2].At(1).(*float64))
+	})
+
+	t.Run("filters Unix timestamps", func(t *testing.T) {
+		resp := query(t, "SELECT epoch AS time, duration FROM builds WHERE $__unixEpochFilter(epoch) AND pipeline = 'release'", "table")
+		require.NoError(t, resp.Error)
+
+		frame := resp.Frames[0]
+		require.Equal(t, 1, frame.Rows())
+		assert.Equal(t, data.FieldTypeNullableTime, frame.Fields[0].Type())
+		assert.Equal(t, time.Date(2024, 1, 1, 10, 6, 0, 0, time.UTC), frame.Fields[0].At(0).(*time.Time).UTC())
+	})
+
+	t.Run("cannot write to the database", func(t *testing.T) {
+		resp := query(t, "DELETE FROM builds", "table")
+		require.Error(t, resp.Error)
+	})
+
+	t.Run("cannot attach other databases", func(t *testing.T) {
+		resp := query(t, "ATTACH DATABASE 'other.db' AS other", "table")
+		require.Error(t, resp.Error)
+	})
+
+	t.Run("checks the health of the database", func(t *testing.T) {
+		res, err := service.CheckHealth(ctx, &backend.CheckHealthRequest{PluginContext: pluginCtx})
+		require.NoError(t, err)
+		assert.Equal(t, backend.HealthStatusOk, res.Status)
+	})
+
+	t.Run("rejects databases outside of the allowed directory", func(t *testing.T) {
+		res, err := service.CheckHealth(ctx, &backend.CheckHealthRequest{PluginContext: backend.PluginContext{
+			DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{ID: 2, JSONData: []byte(`{"path": "../ci.db"}`)},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, backend.HealthStatusError, res.Status)
+	})
+}
+
+func createDatabase(t *testing.T, path string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, db.Ping())
+	return db
+}
@@ -868,6 +868,22 @@ export type GitHubRepositoryConfig = {
 export type LocalRepositoryConfig = {
   path?: string;
 };
+export type ObjectStorageRepositoryConfig = {
+  /** Access key ID used to sign requests. When empty, requests are sent unsigned (for public buckets). */
+  accessKeyID?: string;
+  /** The bucket holding the Grafana data. */
+  bucket: string;
+  /** Secret access key, but encrypted. This is not possible to read back to a user decrypted. */
+  encryptedSecretAccessKey?: string;
+  /** The endpoint of the S3-compatible service (e.g. `https://s3.us-east-1.amazonaws.com` or `http://minio:9000`). Objects are addressed with path-style URLs: `<endpoint>/<bucket>/<key>`. */
+  endpoint: string;
+  /** Path is the key prefix for the Grafana data. If specified, Grafana will ignore any object outside this prefix. This is usually something like `grafana/`. Trailing and leading slash are not required. They are always added when needed. */
+  path?: string;
+  /** The region used to sign requests. Defaults to `us-east-1`. */
+  region?: string;
+  /** Secret access key used to sign requests. If set, it will be encrypted into encryptedSecretAccessKey, then set to an empty string again. */
+  secretAccessKey?: string;
+};
 export type SyncOptions = {
   /** Enabled must be saved as true before any sync job will run */
   enabled: boolean;
@@ -883,12 +899,14 @@ export type SyncOptions = {
 export type RepositorySpec = {
   /** Repository description */
   description?: string;
-  /** The repository on Git. Mutually exclusive with local | github | git. */
+  /** The repository on Git. Mutually exclusive with local | github | objectStorage. */
   git?: GitRepositoryConfig;
-  /** The repository on GitHub. Mutually exclusive with local | github | git. */
+  /** The repository on GitHub. Mutually exclusive with local | git | objectStorage. */
   github?: GitHubRepositoryConfig;
-  /** The repository on the local file system. Mutually exclusive with local | github. */
+  /** The repository on the local file system. Mutually exclusive with github | git | objectStorage. */
   local?: LocalRepositoryConfig;
+  /** The repository in an S3-compatible object storage bucket. Mutually exclusive with local | github | git. */
+  objectStorage?: ObjectStorageRepositoryConfig;
   /** Sync settings -- how values are pulled from the repository into grafana */
   sync: SyncOptions;
   /** The repository display name (shown in the UI) */
@@ -898,8 +916,9 @@ export type RepositorySpec = {
     Possible enum values:
      - `"git"`
      - `"github"`
-     - `"local"` */
-  type: 'git' | 'github' | 'local';
+     - `"local"`
+     - `"objectstorage"` */
+  type: 'git' | 'github' | 'local' | 'objectstorage';
   /** UI driven Workflow that allow changes to the contends of the repository. The order is relevant for defining the precedence of the workflows. When empty, the repository does not support any edits (eg, readonly) */
   workflows: ('branch' | 'write')[];
 };
@@ -1033,8 +1052,9 @@ export type ResourceRepositoryInfo = {
     Possible enum values:
      - `"git"`
      - `"github"`
-     - `"local"` */
-  type: 'git' | 'github' | 'local';
+     - `"local"`
+     - `"objectstorage"` */
+  type: 'git' | 'github' | 'local' | 'objectstorage';
 };
 export type Unstructured = {
   [key: string]: any;
@@ -1170,8 +1190,9 @@ export type RepositoryView = {
     Possible enum values:
      - `"git"`
      - `"github"`
-     - `"local"` */
-  type: 'git' | 'github' | 'local';
+     - `"local"`
+     - `"objectstorage"` */
+  type: 'git' | 'github' | 'local' | 'objectstorage';
   /** The supported workflows */
   workflows: ('branch' | 'write')[];
 };
@@ -3,8 +3,9 @@ import { PureComponent } from 'react';
 import { t } from '@grafana/i18n';
 import { FetchError, getBackendSrv, isFetchError, locationService } from '@grafana/runtime';
 import config from 'app/core/config';
+import { getCredential, RequestOptionsJSON } from 'app/core/utils/webauthn';
 
-import { LoginDTO, AuthNRedirectDTO } from './types';
+import { LoginDTO, AuthNRedirectDTO, TwoFactorChallenge } from './types';
 
 const isOauthEnabled = () => {
   return !!config.oauth && Object.keys(config.oauth).length > 0;
@@ -19,6 +20,7 @@ export interface FormModel {
   user: string;
   password: string;
   email: string;
+  code?: string;
 }
 
 export interface PasswordlessFormModel {
@@ -44,6 +46,7 @@ interface Props {
     passwordlessStart: (data: PasswordlessFormModel) => void;
     passwordlessConfirm: (data: PasswordlessConfirmationFormModel) => void;
     showPasswordlessConfirmation: boolean;
+    passkeyLogin: () => void;
     disableLoginForm: boolean;
     disableUserSignUp: boolean;
     isOauthEnabled: boolean;
@@ -51,6 +54,7 @@ interface Props {
     passwordHint: string;
     showDefaultPasswordWarning: boolean;
     loginErrorMessage: string | undefined;
+    twoFactor: TwoFactorChallenge | undefined;
   }) => JSX.Element;
 }
 
@@ -59,6 +63,7 @@ interface State {
   isChangingPassword: boolean;
   showDefaultPasswordWarning: boolean;
   loginErrorMessage?: string;
+  twoFactor?: TwoFactorChallenge;
 }
 
 export class LoginCtrl extends PureComponent<Props, State> {
@@ -122,6 +127,13 @@ export class LoginCtrl extends PureComponent<Props, State> {
         }
       })
       .catch((err) => {
+        // users with two-factor authentication sign in again with a code from their authenticator app
+        const twoFactor = isFetchError(err) ? getTwoFactorChallenge(err) : undefined;
+        if (twoFactor) {
+          this.setState({ isLoggingIn: false, twoFactor });
+          return;
+        }
+
         const fetchErrorMessage = isFetchError(err) ? getErrorMessage(err) : undefined;
         this.setState({
           isLoggingIn: false,
@@ -173,6 +185,33 @@ export class LoginCtrl extends PureComponent<Props, State> {
       });
   };
 
+  passkeyLogin = async () => {
+    this.setState({
+      loginErrorMessage: undefined,
+      isLoggingIn: true,
+    });
+
+    try {
+      const options = await getBackendSrv().post<RequestOptionsJSON>(
+        '/api/login/webauthn/begin',
+        {},
+        { showErrorAlert: false }
+      );
+      const credential = await getCredential(options);
+      this.result = await getBackendSrv().post<LoginDTO>('/api/login/webauthn/finish', credential, {
+        showErrorAlert: false,
+      });
+      this.toGrafana();
+    } catch (err) {
+      // the browser rejects with a DOMException when the user cancels
+      const fetchErrorMessage = isFetchError(err) ? getErrorMessage(err) : undefined;
+      this.setState({
+        isLoggingIn: false,
+        loginErrorMessage: fetchErrorMessage || t('login.error.passkey', 'Signing in with a passkey failed'),
+      });
+    }
+  };
+
   changeView = (showDefaultPasswordWarning: boolean) => {
     this.setState({
       isChangingPassword: true,
@@ -199,8 +238,8 @@ export class LoginCtrl extends PureComponent<Props, State> {
 
   render() {
     const { children } = this.props;
-    const { isLoggingIn, isChangingPassword, showDefaultPasswordWarning, loginErrorMessage } = this.state;
-    const { login, toGrafana, changePassword, passwordlessStart, passwordlessConfirm } = this;
+    const { isLoggingIn, isChangingPassword, showDefaultPasswordWarning, loginErrorMessage, twoFactor } = this.state;
+    const { login, toGrafana, changePassword, passwordlessStart, passwordlessConfirm, passkeyLogin } = this;
     const { loginHint, passwordHint, disableLoginForm, disableUserSignUp } = config;
 
     return (
@@ -215,12 +254,14 @@ export class LoginCtrl extends PureComponent<Props, State> {
           passwordlessStart,
           passwordlessConfirm,
           showPasswordlessConfirmation: showPasswordlessConfirmation(),
+          passkeyLogin,
           isLoggingIn,
           changePassword,
           skipPasswordChange: toGrafana,
           isChangingPassword,
           showDefaultPasswordWarning,
           loginErrorMessage,
+          twoFactor,
         })}
       </>
     );
@@ -229,13 +270,33 @@ export class LoginCtrl extends PureComponent<Props, State> {
 
 export default LoginCtrl;
 
-function getErrorMessage(err: FetchError<undefined | { messageId?: string; message?: string }>): string | undefined {
+interface LoginErrorData {
+  messageId?: string;
+  message?: string;
+  extra?: { secret?: string; url?: string };
+}
+
+function getTwoFactorChallenge(err: FetchError<undefined | LoginErrorData>): TwoFactorChallenge | undefined {
+  switch (err.data?.messageId) {
+    case 'two-factor.code-required':
+      return {};
+    case 'two-factor.enrollment-required':
+      return { key: { secret: err.data?.extra?.secret ?? '', url: err.data?.extra?.url ?? '' } };
+    default:
+      return undefined;
+  }
+}
+
+function getErrorMessage(err: FetchError<undefined | LoginErrorData>): string | undefined {
   switch (err.data?.messageId) {
     case 'password-auth.empty':
     case 'password-auth.failed':
     case 'password-auth.invalid':
       return t('login.error.invalid-user-or-password', 'Invalid username or password');
+    case 'two-factor.invalid-code':
+      return t('login.error.invalid-two-factor-code', 'Invalid authentication code');
     case 'login-attempt.blocked':
+    case 'two-factor.blocked':
       return t(
         'login.error.blocked',
         'You have exceeded the number of login attempts for this user. Please try again later.'
@@ -10,6 +10,7 @@ import { Button, Input, Field, useStyles2 } from '@grafana/ui';
 import { PasswordField } from '../PasswordField/PasswordField';
 
 import { FormModel } from './LoginCtrl';
+import { TwoFactorChallenge } from './types';
 
 interface Props {
   children: ReactElement;
@@ -17,12 +18,14 @@ interface Props {
   isLoggingIn: boolean;
   passwordHint: string;
   loginHint: string;
+  twoFactor?: TwoFactorChallenge;
 }
 
-export const LoginForm = ({ children, onSubmit, isLoggingIn, passwordHint, loginHint }: Props) => {
+export const LoginForm = ({ children, onSubmit, isLoggingIn, passwordHint, loginHint, twoFactor }: Props) => {
   const styles = useStyles2(getStyles);
   const usernameId = useId();
   const passwordId = useId();
+  const codeId = useId();
   const {
     handleSubmit,
     register,
@@ -58,6 +61,35 @@ export const LoginForm = ({ children, onSubmit, isLoggingIn, passwordHint, login
             placeholder={passwordHint || t('login.form.password-placeholder', 'password')}
           />
         </Field>
+        {twoFactor && (
+          <Field
+            label={t('login.form.two-factor-code-label', 'Authentication code')}
+            description={
+              twoFactor.key
+                ? t(
+                    'login.form.two-factor-setup-description',
+                    'Your organization requires two-factor authentication. Add the key {{secret}} to your authenticator app, then enter the code it shows.',
+                    { secret: twoFactor.key.secret }
+                  )
+                : t(
+                    'login.form.two-factor-code-description',
+                    'Enter the code from your authenticator app, or one of your recovery codes.'
+                  )
+            }
+            invalid={!!errors.code}
+            error={errors.code?.message}
+          >
+            <Input
+              {...register('code', {
+                required: t('login.form.two-factor-code-required', 'Authentication code is required'),
+              })}
+              id={codeId}
+              autoFocus
+              autoComplete="one-time-code"
+              autoCapitalize="none"
+            />
+          </Field>
+        )}
         <Button
           type="submit"
           data-testid={selectors.pages.Login.submit}
@@ -5,8 +5,9 @@ import { css } from '@emotion/css';
 import { GrafanaTheme2 } from '@grafana/data';
 import { Trans, t } from '@grafana/i18n';
 import { config } from '@grafana/runtime';
-import { Alert, LinkButton, Stack, useStyles2 } from '@grafana/ui';
+import { Alert, Button, LinkButton, Stack, useStyles2 } from '@grafana/ui';
 import { Branding } from 'app/core/components/Branding/Branding';
+import { isWebAuthnSupported } from 'app/core/utils/webauthn';
 
 import { ChangePassword } from '../ForgottenPassword/ChangePassword';
 
@@ -34,12 +35,14 @@ const LoginPage = () => {
         passwordlessStart,
         passwordlessConfirm,
         showPasswordlessConfirmation,
+        passkeyLogin,
         isLoggingIn,
         changePassword,
         skipPasswordChange,
         isChangingPassword,
         showDefaultPasswordWarning,
         loginErrorMessage,
+        twoFactor,
       }) => (
         <LoginLayout isChangingPassword={isChangingPassword}>
           {!isChangingPassword && !showPasswordlessConfirmation && (
@@ -51,7 +54,13 @@ const LoginPage = () => {
               )}
 
               {!disableLoginForm && !config.auth.passwordlessEnabled && (
-                <LoginForm onSubmit={login} loginHint={loginHint} passwordHint={passwordHint} isLoggingIn={isLoggingIn}>
+                <LoginForm
+                  onSubmit={login}
+                  loginHint={loginHint}
+                  passwordHint={passwordHint}
+                  isLoggingIn={isLoggingIn}
+                  twoFactor={twoFactor}
+                >
                   <Stack justifyContent="flex-end">
                     {!config.auth.disableLogin && (
                       <LinkButton
@@ -68,6 +77,18 @@ const LoginPage = () => {
               {config.auth.passwordlessEnabled && (
                 <PasswordlessLoginForm onSubmit={passwordlessStart} isLoggingIn={isLoggingIn}></PasswordlessLoginForm>
               )}
+              {config.auth.webAuthnEnabled && isWebAuthnSupported() && (
+                <Button
+                  className={styles.passkey}
+                  variant="secondary"
+                  icon="key-skeleton-alt"
+                  fullWidth
+                  disabled={isLoggingIn}
+                  onClick={passkeyLogin}
+                >
+                  <Trans i18nKey="login.passkey">Sign in with a passkey</Trans>
+                </Button>
+              )}
               <LoginServiceButtons />
               {!disableUserSignUp && <UserSignup />}
             </InnerBox>
@@ -109,5 +130,9 @@ const getStyles = (theme: GrafanaTheme2) => {
     alert: css({
       width: '100%',
     }),
+
+    passkey: css({
+      marginTop: theme.spacing(2),
+    }),
   };
 };
@@ -6,3 +6,11 @@ export interface LoginDTO {
 export interface AuthNRedirectDTO {
   URL: string;
 }
+
+export interface TwoFactorChallenge {
+  // key is set when the user has to set up two-factor authentication to sign in
+  key?: {
+    secret: string;
+    url: string;
+  };
+}
@@ -0,0 +1,129 @@
+// Helpers for the WebAuthn ceremonies of /api/user/webauthn and /api/login/webauthn. The API uses the JSON
+// serialization of the WebAuthn spec, where binary values are base64url encoded.
+
+export interface CredentialDescriptorJSON {
+  type: 'public-key';
+  id: string;
+  transports?: string[];
+}
+
+export interface CreationOptionsJSON {
+  rp: { id: string; name: string };
+  user: { id: string; name: string; displayName: string };
+  challenge: string;
+  pubKeyCredParams: Array<{ type: 'public-key'; alg: number }>;
+  timeout: number;
+  excludeCredentials: CredentialDescriptorJSON[];
+  authenticatorSelection: { residentKey: ResidentKeyRequirement; userVerification: UserVerificationRequirement };
+  attestation: AttestationConveyancePreference;
+}
+
+export interface RequestOptionsJSON {
+  challenge: string;
+  timeout: number;
+  rpId: string;
+  allowCredentials: CredentialDescriptorJSON[];
+  userVerification: UserVerificationRequirement;
+}
+
+export interface RegistrationResponseJSON {
+  id: string;
+  rawId: string;
+  type: string;
+  response: { clientDataJSON: string; attestationObject: string; transports: string[] };
+}
+
+export interface AuthenticationResponseJSON {
+  id: string;
+  rawId: string;
+  type: string;
+  response: { clientDataJSON: string; authenticatorData: string; signature: string; userHandle?: string };
+}
+
+export function isWebAuthnSupported(): boolean {
+  return typeof window.PublicKeyCredential !== 'undefined' && !!navigator.credentials;
+}
+
+export async function createCredential(options: CreationOptionsJSON): Promise<RegistrationResponseJSON> {
+  const credential = await navigator.credentials.create({
+    publicKey: {
+      rp: options.rp,
+      user: { ...options.user, id: decode(options.user.id) },
+      challenge: decode(options.challenge),
+      pubKeyCredParams: options.pubKeyCredParams,
+      timeout: options.timeout,
+      excludeCredentials: options.excludeCredentials.map(toDescriptor),
+      authenticatorSelection: options.authenticatorSelection,
+      attestation: options.attestation,
+    },
+  });
+  if (!(credential instanceof PublicKeyCredential)) {
+    throw new Error('No passkey was created');
+  }
+
+  const response = credential.response as AuthenticatorAttestationResponse;
+  return {
+    id: credential.id,
+    rawId: encode(credential.rawId),
+    type: credential.type,
+    response: {
+      clientDataJSON: encode(response.clientDataJSON),
+      attestationObject: encode(response.attestationObject),
+      transports: typeof response.getTransports === 'function' ? response.getTransports() : [],
+    },
+  };
+}
+
+export async function getCredential(options: RequestOptionsJSON): Promise<AuthenticationResponseJSON> {
+  const credential = await navigator.credentials.get({
+    publicKey: {
+      challenge: decode(options.challenge),
+      timeout: options.timeout,
+      rpId: options.rpId,
+      allowCredentials: options.allowCredentials.map(toDescriptor),
+      userVerification: options.userVerification,
+    },
+  });
+  if (!(credential instanceof PublicKeyCredential)) {
+    throw new Error('No passkey was selected');
+  }
+
+  const response = credential.response as AuthenticatorAssertionResponse;
+  return {
+    id: credential.id,
+    rawId: encode(credential.rawId),
+    type: credential.type,
+    response: {
+      clientDataJSON: encode(response.clientDataJSON),
+      authenticatorData: encode(response.authenticatorData),
+      signature: encode(response.signature),
+      userHandle: response.userHandle ? encode(response.userHandle) : undefined,
+    },
+  };
+}
+
+function toDescriptor(descriptor: CredentialDescriptorJSON): PublicKeyCredentialDescriptor {
+  return {
+    type: descriptor.type,
+    id: decode(descriptor.id),
+    transports: descriptor.transports as AuthenticatorTransport[] | undefined,
+  };
+}
+
+function decode(value: string): ArrayBuffer {
+  const base64 = value.replace(/-/g, '+').replace(/_/g, '/');
+  const binary = atob(base64.padEnd(Math.ceil(base64.length / 4) * 4, '='));
+  const bytes = new Uint8Array(binary.length);
+  for (let i = 0; i < binary.length; i++) {
+    bytes[i] = binary.charCodeAt(i);
+  }
+  return bytes.buffer;
+}
+
+function encode(buffer: ArrayBuffer): string {
+  let binary = '';
+  new Uint8Array(buffer).forEach((byte) => {
+    binary += String.fromCharCode(byte);
+  });
+  return btoa(binary).replace(/\+/g, '-').replace(/\//g, '_').replace(/=+$/, '');
+}
@@ -147,8 +147,8 @@ const TransformationEditorRow = (props: Props) => {
                 <div>
                   <p>
                     <Trans i18nKey="correlations.transform-row.expression-tooltip">
-                      Required for regular expression. The expression the transformation will use. Logfmt does not use
-                      further specifications.
+                      Required for regular expression, JSONPath, label and template. The expression the transformation
+                      will use. Logfmt does not use further specifications.
                     </Trans>
                   </p>
                 </div>
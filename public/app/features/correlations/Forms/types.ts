@@ -93,6 +93,75 @@ export function getSupportedTransTypeDetails(
           ),
         },
       };
+    case SupportedTransformationType.JSONPath:
+      return {
+        label: t('correlations.trans-details.jsonpath-label', 'JSONPath'),
+        value: SupportedTransformationType.JSONPath,
+        description: t(
+          'correlations.trans-details.jsonpath-description',
+          'Field will be parsed as JSON. The value at the JSONPath expression is added to the variable named in map value, or named after the field.'
+        ),
+        expressionDetails: {
+          show: true,
+          required: true,
+          helpText: t(
+            'correlations.trans-details.jsonpath-expression',
+            'JSONPath expression of the value, for example $.resource.service.'
+          ),
+        },
+        mapValueDetails: {
+          show: true,
+          required: false,
+          helpText: t(
+            'correlations.trans-details.jsonpath-map-values',
+            'Defines the name of the variable. Defaults to the name of the field.'
+          ),
+        },
+      };
+    case SupportedTransformationType.Label:
+      return {
+        label: t('correlations.trans-details.label-label', 'Label'),
+        value: SupportedTransformationType.Label,
+        description: t(
+          'correlations.trans-details.label-description',
+          'The value of a label of the field is added to the variable named in map value, or named after the label.'
+        ),
+        expressionDetails: {
+          show: true,
+          required: true,
+          helpText: t('correlations.trans-details.label-expression', 'Name of the label, for example service.name.'),
+        },
+        mapValueDetails: {
+          show: true,
+          required: false,
+          helpText: t(
+            'correlations.trans-details.label-map-values',
+            'Defines the name of the variable. Defaults to the name of the label.'
+          ),
+        },
+      };
+    case SupportedTransformationType.Template:
+      return {
+        label: t('correlations.trans-details.template-label', 'Template'),
+        value: SupportedTransformationType.Template,
+        description: t(
+          'correlations.trans-details.template-description',
+          'Combines the variables of the preceding transformations into a new variable.'
+        ),
+        expressionDetails: {
+          show: true,
+          required: true,
+          helpText: t(
+            'correlations.trans-details.template-expression',
+            'Template referencing variables as ${name}, for example ${namespace}/${service}.'
+          ),
+        },
+        mapValueDetails: {
+          show: true,
+          required: true,
+          helpText: t('correlations.trans-details.template-map-values', 'Defines the name of the variable.'),
+        },
+      };
     default:
       return {
         label: transType,
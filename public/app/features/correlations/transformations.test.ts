@@ -0,0 +1,117 @@
+import { SupportedTransformationType } from '@grafana/data';
+
+import { getTransformationVars } from './transformations';
+
+describe('getTransformationVars', () => {
+  describe('jsonpath', () => {
+    const body = '{"resource":{"service":"checkout","attributes":{"k8s.namespace":"prod"}},"spans":[{"id":"abc"}]}';
+
+    it('extracts the value at the expression', () => {
+      expect(
+        getTransformationVars(
+          { type: SupportedTransformationType.JSONPath, expression: '$.resource.service', mapValue: 'service' },
+          body,
+          'body'
+        )
+      ).toEqual({ service: { value: 'checkout' } });
+    });
+
+    it('supports bracket notation and array indexes', () => {
+      expect(
+        getTransformationVars(
+          { type: SupportedTransformationType.JSONPath, expression: "$.resource.attributes['k8s.namespace']" },
+          body,
+          'body'
+        )
+      ).toEqual({ body: { value: 'prod' } });
+      expect(
+        getTransformationVars(
+          { type: SupportedTransformationType.JSONPath, expression: '$.spans[0].id', mapValue: 'spanId' },
+          body,
+          'body'
+        )
+      ).toEqual({ spanId: { value: 'abc' } });
+    });
+
+    it('stringifies objects', () => {
+      expect(
+        getTransformationVars(
+          { type: SupportedTransformationType.JSONPath, expression: '$.spans[*]', mapValue: 'span' },
+          body,
+          'body'
+        )
+      ).toEqual({ span: { value: '{"id":"abc"}' } });
+    });
+
+    it('returns no variables when the value is missing or not JSON', () => {
+      expect(
+        getTransformationVars(
+          { type: SupportedTransformationType.JSONPath, expression: '$.resource.missing' },
+          body,
+          'body'
+        )
+      ).toEqual({});
+      expect(
+        getTransformationVars(
+          { type: SupportedTransformationType.JSONPath, expression: '$.resource' },
+          'level=info',
+          'body'
+        )
+      ).toEqual({});
+    });
+  });
+
+  describe('label', () => {
+    it('extracts the value of the label', () => {
+      const labels = { 'service.name': 'checkout' };
+      expect(
+        getTransformationVars(
+          { type: SupportedTransformationType.Label, expression: 'service.name' },
+          'value',
+          'field',
+          labels
+        )
+      ).toEqual({ 'service.name': { value: 'checkout' } });
+      expect(
+        getTransformationVars(
+          { type: SupportedTransformationType.Label, expression: 'service.name', mapValue: 'service' },
+          'value',
+          'field',
+          labels
+        )
+      ).toEqual({ service: { value: 'checkout' } });
+    });
+
+    it('returns no variables when the field has no such label', () => {
+      expect(
+        getTransformationVars({ type: SupportedTransformationType.Label, expression: 'service.name' }, 'value', 'field')
+      ).toEqual({});
+    });
+  });
+
+  describe('template', () => {
+    it('interpolates the variables of the preceding transformations', () => {
+      expect(
+        getTransformationVars(
+          { type: SupportedTransformationType.Template, expression: '${namespace}/${service}', mapValue: 'job' },
+          'value',
+          'field',
+          undefined,
+          { namespace: { value: 'prod' }, service: { value: 'checkout' } }
+        )
+      ).toEqual({ job: { value: 'prod/checkout' } });
+    });
+
+    it('returns no variables when a variable is not defined', () => {
+      expect(
+        getTransformationVars(
+          { type: SupportedTransformationType.Template, expression: '${namespace}/${service}', mapValue: 'job' },
+          'value',
+          'field',
+          undefined,
+          { namespace: { value: 'prod' } }
+        )
+      ).toEqual({});
+    });
+  });
+});
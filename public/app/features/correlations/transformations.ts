@@ -1,15 +1,71 @@
 import logfmt from 'logfmt';
 
-import { ScopedVars, DataLinkTransformationConfig, SupportedTransformationType } from '@grafana/data';
+import { ScopedVars, DataLinkTransformationConfig, SupportedTransformationType, Labels } from '@grafana/data';
 import { safeStringifyValue } from 'app/core/utils/explore';
 
+// matches one step of the JSONPath subset supported by jsonpath transformations: child names (`.name`, `['name']`),
+// array indexes (`[0]`) and wildcards (`.*`, `[*]`)
+const jsonPathStepRegex = /\.([A-Za-z_@][\w@-]*)|\.\*|\[(\d+)\]|\[\*\]|\['([^']+)'\]|\["([^"]+)"\]/y;
+
+// matches a variable reference in a template transformation
+const templateVariableRegex = /\$\{([^${}]*)\}/g;
+
+/**
+ * Returns the first value at the JSONPath expression, or undefined when the expression is invalid or nothing matches.
+ */
+const getJSONPathValue = (value: unknown, expression: string): unknown => {
+  if (!expression.startsWith('$')) {
+    return undefined;
+  }
+
+  let nodes: unknown[] = [value];
+  jsonPathStepRegex.lastIndex = 1;
+  while (jsonPathStepRegex.lastIndex < expression.length) {
+    const match = jsonPathStepRegex.exec(expression);
+    if (!match) {
+      return undefined;
+    }
+    const key = match[1] ?? match[2] ?? match[3] ?? match[4];
+    const next: unknown[] = [];
+    for (const node of nodes) {
+      if (node === null || typeof node !== 'object') {
+        continue;
+      }
+      if (key === undefined) {
+        next.push(...Object.values(node));
+      } else if (Object.prototype.hasOwnProperty.call(node, key)) {
+        next.push(Object.getOwnPropertyDescriptor(node, key)?.value);
+      }
+    }
+    nodes = next;
+  }
+  return nodes[0];
+};
+
+const parseJSON = (fieldValue: unknown): unknown => {
+  if (typeof fieldValue !== 'string') {
+    return fieldValue;
+  }
+  try {
+    return JSON.parse(fieldValue);
+  } catch (e) {
+    return undefined;
+  }
+};
+
+/**
+ * Returns the variables extracted by the transformation. Label transformations read the labels of the field, and
+ * template transformations interpolate the variables extracted by the preceding transformations.
+ */
 export const getTransformationVars = (
   transformation: DataLinkTransformationConfig,
   fieldValue: string,
-  fieldName: string
+  fieldName: string,
+  fieldLabels?: Labels,
+  vars?: ScopedVars
 ): ScopedVars => {
   let transformationScopedVars: ScopedVars = {};
-  let transformVal: { [key: string]: string | boolean | null | undefined } = {};
+  let transformVal: { [key: string]: unknown } = {};
   if (transformation.type === SupportedTransformationType.Regex && transformation.expression) {
     const regexp = new RegExp(transformation.expression, 'gi');
     const stringFieldVal = typeof fieldValue === 'string' ? fieldValue : safeStringifyValue(fieldValue);
@@ -24,6 +80,33 @@ export const getTransformationVars = (
     }
   } else if (transformation.type === SupportedTransformationType.Logfmt && fieldValue !== undefined) {
     transformVal = logfmt.parse(fieldValue);
+  } else if (transformation.type === SupportedTransformationType.JSONPath && transformation.expression) {
+    const value = getJSONPathValue(parseJSON(fieldValue), transformation.expression);
+    if (value !== undefined) {
+      transformVal[transformation.mapValue || fieldName] = value;
+    }
+  } else if (transformation.type === SupportedTransformationType.Label && transformation.expression) {
+    const value = fieldLabels?.[transformation.expression];
+    if (value !== undefined) {
+      transformVal[transformation.mapValue || transformation.expression] = value;
+    }
+  } else if (
+    transformation.type === SupportedTransformationType.Template &&
+    transformation.expression &&
+    transformation.mapValue
+  ) {
+    let allVariablesDefined = true;
+    const value = transformation.expression.replace(templateVariableRegex, (match, name: string) => {
+      const variable = vars?.[name];
+      if (variable?.value === undefined || variable?.value === null) {
+        allVariablesDefined = false;
+        return match;
+      }
+      return typeof variable.value === 'string' ? variable.value : safeStringifyValue(variable.value);
+    });
+    if (allVariablesDefined) {
+      transformVal[transformation.mapValue] = value;
+    }
   }
 
   Object.keys(transformVal).forEach((key) => {
@@ -3,7 +3,7 @@ import { useState, useEffect, useId } from 'react';
 import { useForm } from 'react-hook-form';
 import { useAsync } from 'react-use';
 
-import { DataLinkTransformationConfig, ExploreCorrelationHelperData, GrafanaTheme2 } from '@grafana/data';
+import { DataLinkTransformationConfig, ExploreCorrelationHelperData, GrafanaTheme2, ScopedVars } from '@grafana/data';
 import { Trans, t } from '@grafana/i18n';
 import {
   Collapse,
@@ -103,6 +103,7 @@ export const CorrelationHelper = ({ exploreId, correlations }: Props) => {
       !correlationDetails?.correlationDirty && transformations.length > 0 ? true : correlationDetails?.correlationDirty;
     dispatch(changeCorrelationEditorDetails({ transformations: transformations, correlationDirty: dirty }));
     let transVarRecords: Record<string, string> = {};
+    let transScopedVars: ScopedVars = {};
     transformations.forEach((transformation) => {
       const transformationVars = getTransformationVars(
         {
@@ -111,9 +112,12 @@ export const CorrelationHelper = ({ exploreId, correlations }: Props) => {
           mapValue: transformation.mapValue,
         },
         correlations.vars[transformation.field!],
-        transformation.field!
+        transformation.field!,
+        undefined,
+        transScopedVars
       );
 
+      transScopedVars = { ...transScopedVars, ...transformationVars };
       Object.keys(transformationVars).forEach((key) => {
         transVarRecords[key] = transformationVars[key]?.value;
       });
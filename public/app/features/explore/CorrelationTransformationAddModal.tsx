@@ -3,7 +3,7 @@ import { useId, useState, useMemo, useEffect } from 'react';
 import Highlighter from 'react-highlight-words';
 import { useForm, Controller } from 'react-hook-form';
 
-import { DataLinkTransformationConfig, ScopedVars } from '@grafana/data';
+import { DataLinkTransformationConfig, ScopedVars, SupportedTransformationType } from '@grafana/data';
 import { Trans, t } from '@grafana/i18n';
 import { Button, Field, Icon, Input, Label, Modal, Select, Tooltip, Stack } from '@grafana/ui';
 
@@ -93,10 +93,12 @@ export const CorrelationTransformationAddModal = ({
       let isExpressionValid = false;
       if (expression !== undefined) {
         isExpressionValid = true;
-        try {
-          new RegExp(expression);
-        } catch (e) {
-          isExpressionValid = false;
+        if (formValues.type === SupportedTransformationType.Regex) {
+          try {
+            new RegExp(expression);
+          } catch (e) {
+            isExpressionValid = false;
+          }
         }
       } else {
         isExpressionValid = !formFieldsVis.expressionDetails.show;
@@ -171,7 +173,11 @@ export const CorrelationTransformationAddModal = ({
           <pre>
             <Highlighter
               textToHighlight={exampleValue}
-              searchWords={[isExpValid ? (getValues('expression') ?? '') : '']}
+              searchWords={[
+                isExpValid && getValues('type') === SupportedTransformationType.Regex
+                  ? (getValues('expression') ?? '')
+                  : '',
+              ]}
               autoEscape={false}
             />
           </pre>
@@ -324,6 +324,44 @@ describe('explore links utils', () => {
       );
     });
 
+    it('returns internal links with jsonpath, label and template transformations', () => {
+      const transformationLink: DataLink = {
+        title: '',
+        url: '',
+        origin: DataLinkConfigOrigin.Correlations,
+        internal: {
+          query: { query: 'http_requests{job=${job}}' },
+          datasourceUid: 'uid_1',
+          datasourceName: 'test_ds',
+        },
+        meta: {
+          transformations: [
+            { type: SupportedTransformationType.JSONPath, expression: '$.resource.service', mapValue: 'service' },
+            { type: SupportedTransformationType.Label, expression: 'k8s.namespace', mapValue: 'namespace' },
+            { type: SupportedTransformationType.Template, expression: '${namespace}/${service}', mapValue: 'job' },
+          ],
+        },
+      };
+
+      const { field, range, dataFrame } = setup(transformationLink, true, {
+        name: 'body',
+        type: FieldType.string,
+        values: ['{"resource":{"service":"checkout"}}'],
+        labels: { 'k8s.namespace': 'prod' },
+        config: {
+          links: [transformationLink],
+        },
+      });
+
+      const links = getFieldLinksForExplore({ field, rowIndex: 0, range, dataFrame });
+      expect(links).toHaveLength(1);
+      expect(links[0].href).toBe(
+        `/explore?left=${encodeURIComponent(
+          '{"range":{"from":"now-1h","to":"now"},"datasource":"uid_1","queries":[{"query":"http_requests{job=prod/checkout}"}]}'
+        )}`
+      );
+    });
+
     it('returns internal links with 2 unnamed regex transformations and use the last transformation', () => {
       const transformationLink: DataLink = {
         title: '',
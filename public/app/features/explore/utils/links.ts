@@ -178,16 +178,19 @@ export const getFieldLinksForExplore = (options: {
       if (link.meta?.transformations) {
         link.meta?.transformations.forEach((transformation) => {
           let fieldValue;
+          let fieldLabels;
           if (transformation.field) {
             const transformField = dataFrame?.fields.find((field) => field.name === transformation.field);
             fieldValue = transformField?.values[rowIndex];
+            fieldLabels = transformField?.labels;
           } else {
             fieldValue = field.values[rowIndex];
+            fieldLabels = field.labels;
           }
 
           internalLinkSpecificVars = {
             ...internalLinkSpecificVars,
-            ...getTransformationVars(transformation, fieldValue, field.name),
+            ...getTransformationVars(transformation, fieldValue, field.name, fieldLabels, internalLinkSpecificVars),
           };
         });
       }
@@ -0,0 +1,119 @@
+import { css } from '@emotion/css';
+import { useState } from 'react';
+import { useAsyncFn, useMount } from 'react-use';
+
+import { Trans, t } from '@grafana/i18n';
+import { getBackendSrv } from '@grafana/runtime';
+import { Alert, Button, Field, Icon, Input, LoadingPlaceholder, ScrollContainer, Stack } from '@grafana/ui';
+import { formatDate } from 'app/core/internationalization/dates';
+import { createCredential, CreationOptionsJSON, isWebAuthnSupported } from 'app/core/utils/webauthn';
+
+interface Passkey {
+  uid: string;
+  name: string;
+  created: string;
+  lastUsed?: string;
+}
+
+export function UserPasskeys() {
+  const styles = getStyles();
+  const [name, setName] = useState('');
+
+  const [{ value: passkeys, loading }, loadPasskeys] = useAsyncFn(() =>
+    getBackendSrv().get<Passkey[]>('/api/user/webauthn/credentials')
+  );
+  useMount(() => loadPasskeys());
+
+  const [{ error: registerError, loading: registering }, register] = useAsyncFn(async () => {
+    const options = await getBackendSrv().post<CreationOptionsJSON>('/api/user/webauthn/register/begin');
+    const credential = await createCredential(options);
+    await getBackendSrv().post('/api/user/webauthn/register/finish', { name, credential });
+    setName('');
+    await loadPasskeys();
+  }, [name]);
+
+  const remove = async (uid: string) => {
+    await getBackendSrv().delete(`/api/user/webauthn/credentials/${uid}`);
+    await loadPasskeys();
+  };
+
+  if (loading && !passkeys) {
+    return <LoadingPlaceholder text={<Trans i18nKey="profile.user-passkeys.loading">Loading passkeys...</Trans>} />;
+  }
+
+  return (
+    <div className={styles.wrapper}>
+      <h3 className="page-sub-heading">
+        <Trans i18nKey="profile.user-passkeys.title">Passkeys</Trans>
+      </h3>
+      {passkeys && passkeys.length > 0 && (
+        <ScrollContainer overflowY="visible" overflowX="auto" width="100%">
+          <table className="filter-table form-inline">
+            <thead>
+              <tr>
+                <th>
+                  <Trans i18nKey="profile.user-passkeys.name-column">Name</Trans>
+                </th>
+                <th>
+                  <Trans i18nKey="profile.user-passkeys.created-column">Added</Trans>
+                </th>
+                <th>
+                  <Trans i18nKey="profile.user-passkeys.last-used-column">Last used</Trans>
+                </th>
+                <th></th>
+              </tr>
+            </thead>
+            <tbody>
+              {passkeys.map((passkey) => (
+                <tr key={passkey.uid}>
+                  <td>{passkey.name}</td>
+                  <td>{formatDate(passkey.created, { dateStyle: 'long' })}</td>
+                  <td>{passkey.lastUsed ? formatDate(passkey.lastUsed, { dateStyle: 'long' }) : '-'}</td>
+                  <td>
+                    <Button
+                      size="sm"
+                      variant="destructive"
+                      tooltip={t('profile.user-passkeys.delete', 'Delete passkey')}
+                      onClick={() => remove(passkey.uid)}
+                      aria-label={t('profile.user-passkeys.delete', 'Delete passkey')}
+                    >
+                      <Icon name="trash-alt" />
+                    </Button>
+                  </td>
+                </tr>
+              ))}
+            </tbody>
+          </table>
+        </ScrollContainer>
+      )}
+      {isWebAuthnSupported() ? (
+        <Stack direction="column" gap={1}>
+          {registerError && (
+            <Alert severity="error" title={t('profile.user-passkeys.register-error', 'The passkey was not added')} />
+          )}
+          <Stack alignItems="flex-end">
+            <Field label={t('profile.user-passkeys.name-label', 'Passkey name')} noMargin>
+              <Input
+                value={name}
+                placeholder={t('profile.user-passkeys.name-placeholder', 'For example: work laptop')}
+                onChange={(e) => setName(e.currentTarget.value)}
+                width={30}
+              />
+            </Field>
+            <Button icon="key-skeleton-alt" variant="secondary" disabled={registering} onClick={() => register()}>
+              <Trans i18nKey="profile.user-passkeys.add">Add passkey</Trans>
+            </Button>
+          </Stack>
+        </Stack>
+      ) : (
+        <Trans i18nKey="profile.user-passkeys.unsupported">This browser does not support passkeys.</Trans>
+      )}
+    </div>
+  );
+}
+
+const getStyles = () => ({
+  wrapper: css({
+    maxWidth: '100%',
+  }),
+});
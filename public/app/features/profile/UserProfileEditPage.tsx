@@ -2,13 +2,14 @@ import { connect, ConnectedProps } from 'react-redux';
 import { useMount } from 'react-use';
 
 import { PluginExtensionPoints } from '@grafana/data';
-import { usePluginComponents } from '@grafana/runtime';
+import { config, usePluginComponents } from '@grafana/runtime';
 import { Stack } from '@grafana/ui';
 import { Page } from 'app/core/components/Page/Page';
 import SharedPreferences from 'app/core/components/SharedPreferences/SharedPreferences';
 import { StoreState } from 'app/types';
 
 import UserOrganizations from './UserOrganizations';
+import { UserPasskeys } from './UserPasskeys';
 import UserProfileEditForm from './UserProfileEditForm';
 import { UserProfileEditTabs } from './UserProfileEditTabs';
 import UserSessions from './UserSessions';
@@ -74,6 +75,7 @@ export function UserProfileEditPage({
               <UserTeams isLoading={teamsAreLoading} teams={teams} />
               <UserOrganizations isLoading={orgsAreLoading} setUserOrg={changeUserOrg} orgs={orgs} user={user} />
               <UserSessions isLoading={sessionsAreLoading} revokeUserSession={revokeUserSession} sessions={sessions} />
+              {config.auth.webAuthnEnabled && <UserPasskeys />}
             </Stack>
           </Stack>
         </UserProfileEditTabs>
@@ -41,6 +41,7 @@ describe('graphiteDatasource', () => {
 
     const instanceSettings = {
       url: '/api/datasources/proxy/1',
+      uid: 'graphite-uid',
       name: 'graphiteProd',
       jsonData: {
         rollupIndicatorEnabled: true,
@@ -651,7 +652,7 @@ describe('graphiteDatasource', () => {
         results = data;
       });
 
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/tags/autoComplete/tags');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/tags/autoComplete/tags');
       expect(requestOptions.params?.expr).toEqual([]);
       expect(results).not.toBe(null);
     });
@@ -661,7 +662,7 @@ describe('graphiteDatasource', () => {
         results = data;
       });
 
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/tags/autoComplete/tags');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/tags/autoComplete/tags');
       expect(requestOptions.params?.expr).toEqual(['server=backend_01']);
       expect(results).not.toBe(null);
     });
@@ -671,7 +672,7 @@ describe('graphiteDatasource', () => {
         results = data;
       });
 
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/tags/autoComplete/tags');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/tags/autoComplete/tags');
       expect(requestOptions.params?.expr).toEqual(['server=backend_01']);
       expect(results).not.toBe(null);
     });
@@ -681,7 +682,7 @@ describe('graphiteDatasource', () => {
         results = data;
       });
 
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/tags/autoComplete/values');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/tags/autoComplete/values');
       expect(requestOptions.params?.tag).toBe('server');
       expect(requestOptions.params?.expr).toEqual([]);
       expect(results).not.toBe(null);
@@ -692,7 +693,7 @@ describe('graphiteDatasource', () => {
         results = data;
       });
 
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/tags/autoComplete/values');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/tags/autoComplete/values');
       expect(requestOptions.params?.tag).toBe('server');
       expect(requestOptions.params?.expr).toEqual(['server=~backend*']);
       expect(results).not.toBe(null);
@@ -703,7 +704,7 @@ describe('graphiteDatasource', () => {
         results = data;
       });
 
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/tags/autoComplete/values');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/tags/autoComplete/values');
       expect(requestOptions.params?.tag).toBe('server');
       expect(requestOptions.params?.expr).toEqual([]);
       expect(results).not.toBe(null);
@@ -714,13 +715,13 @@ describe('graphiteDatasource', () => {
         results = data;
       });
 
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/tags/autoComplete/values');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/tags/autoComplete/values');
       expect(requestOptions.params?.tag).toBe('server');
       expect(requestOptions.params?.expr).toEqual(['server=~backend*']);
       expect(results).not.toBe(null);
     });
 
-    it('/metrics/find should be POST', () => {
+    it('/metrics/find should be a resource call', () => {
       ctx.templateSrv.init([
         {
           type: 'query',
@@ -731,11 +732,9 @@ describe('graphiteDatasource', () => {
       ctx.ds.metricFindQuery('[[foo]]').then((data) => {
         results = data;
       });
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/metrics/find');
-      expect(requestOptions.method).toEqual('POST');
-      expect(requestOptions.headers).toHaveProperty('Content-Type', 'application/x-www-form-urlencoded');
-      expect(requestOptions.data).toMatch(`query=bar`);
-      expect(requestOptions).toHaveProperty('params');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/metrics/find');
+      expect(requestOptions.method).toEqual('GET');
+      expect(requestOptions.params).toEqual({ query: 'bar' });
     });
 
     it('should interpolate $__searchFilter with searchFilter', () => {
@@ -743,9 +742,8 @@ describe('graphiteDatasource', () => {
         results = data;
       });
 
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/metrics/find');
-      expect(requestOptions.params).toEqual({});
-      expect(requestOptions.data).toEqual('query=app.backend*');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/metrics/find');
+      expect(requestOptions.params).toEqual({ query: 'app.backend*' });
       expect(results).not.toBe(null);
     });
 
@@ -754,9 +752,8 @@ describe('graphiteDatasource', () => {
         results = data;
       });
 
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/metrics/find');
-      expect(requestOptions.params).toEqual({});
-      expect(requestOptions.data).toEqual('query=app.*');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/metrics/find');
+      expect(requestOptions.params).toEqual({ query: 'app.*' });
       expect(results).not.toBe(null);
     });
 
@@ -765,7 +762,7 @@ describe('graphiteDatasource', () => {
         results = data;
       });
 
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/metrics/expand');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/metrics/expand');
       expect(requestOptions.params?.query).toBe('*.servers.*');
       expect(results).not.toBe(null);
     });
@@ -775,7 +772,7 @@ describe('graphiteDatasource', () => {
       ctx.ds.metricFindQuery(stringQuery).then((data) => {
         results = data;
       });
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/metrics/find');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/metrics/find');
       expect(results).not.toBe(null);
 
       const objectQuery = {
@@ -785,7 +782,7 @@ describe('graphiteDatasource', () => {
         datasource: ctx.ds,
       };
       const data = await ctx.ds.metricFindQuery(objectQuery);
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/metrics/find');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/metrics/find');
       expect(data).toBeTruthy();
     });
 
@@ -681,27 +681,19 @@ export class GraphiteDatasource
     requestId: string,
     range?: { from: any; until: any }
   ): Promise<MetricFindValue[]> {
-    const params: BackendSrvRequest['params'] = {};
+    const params: BackendSrvRequest['params'] = { query };
 
     if (range) {
       params.from = range.from;
       params.until = range.until;
     }
 
-    const httpOptions: BackendSrvRequest = {
-      method: 'POST',
-      url: '/metrics/find',
-      params,
-      data: `query=${query}`,
-      headers: {
-        'Content-Type': 'application/x-www-form-urlencoded',
-      },
-      // for cancellations
-      requestId: requestId,
-    };
-
     return lastValueFrom(
-      this.doGraphiteRequest(httpOptions).pipe(
+      this.doGraphiteResourceRequest('metrics/find', {
+        params,
+        // for cancellations
+        requestId,
+      }).pipe(
         map((results: FetchResponse) => {
           return _map(results.data, (metric) => {
             return {
@@ -730,19 +722,12 @@ export class GraphiteDatasource
       params.until = range.until;
     }
 
-    const httpOptions: BackendSrvRequest = {
-      method: 'GET',
-      url: '/metrics/expand',
-      params,
-      headers: {
-        'Content-Type': 'application/x-www-form-urlencoded',
-      },
-      // for cancellations
-      requestId,
-    };
-
     return lastValueFrom(
-      this.doGraphiteRequest(httpOptions).pipe(
+      this.doGraphiteResourceRequest('metrics/expand', {
+        params,
+        // for cancellations
+        requestId,
+      }).pipe(
         map((results: FetchResponse) => {
           return _map(results.data.results, (metric) => {
             return {
@@ -764,16 +749,12 @@ export class GraphiteDatasource
       params.until = this.translateTime(options.range.to, true, options.timezone);
     }
 
-    const httpOptions: BackendSrvRequest = {
-      method: 'GET',
-      url: '/tags',
-      // for cancellations
-      requestId: options.requestId,
-      params,
-    };
-
     return lastValueFrom(
-      this.doGraphiteRequest(httpOptions).pipe(
+      this.doGraphiteResourceRequest('tags', {
+        // for cancellations
+        requestId: options.requestId,
+        params,
+      }).pipe(
         map((results: FetchResponse) => {
           return _map(results.data, (tag) => {
             return {
@@ -794,16 +775,12 @@ export class GraphiteDatasource
       params.until = this.translateTime(options.range.to, true, options.timezone);
     }
 
-    const httpOptions: BackendSrvRequest = {
-      method: 'GET',
-      url: '/tags/' + this.templateSrv.replace(options.key),
-      // for cancellations
-      requestId: options.requestId,
-      params,
-    };
-
     return lastValueFrom(
-      this.doGraphiteRequest(httpOptions).pipe(
+      this.doGraphiteResourceRequest('tags/' + encodeURIComponent(this.templateSrv.replace(options.key)), {
+        // for cancellations
+        requestId: options.requestId,
+        params,
+      }).pipe(
         map((results: FetchResponse) => {
           if (results.data && results.data.values) {
             return _map(results.data.values, (value) => {
@@ -837,15 +814,13 @@ export class GraphiteDatasource
       params.until = this.translateTime(options.range.to, true, options.timezone);
     }
 
-    const httpOptions: BackendSrvRequest = {
-      method: 'GET',
-      url: '/tags/autoComplete/tags',
-      params,
-      // for cancellations
-      requestId: options.requestId,
-    };
-
-    return lastValueFrom(this.doGraphiteRequest(httpOptions).pipe(mapToTags()));
+    return lastValueFrom(
+      this.doGraphiteResourceRequest('tags/autoComplete/tags', {
+        params,
+        // for cancellations
+        requestId: options.requestId,
+      }).pipe(mapToTags())
+    );
   }
 
   getTagValuesAutoComplete(expressions: string[], tag: string, valuePrefix?: string, optionalOptions?: any) {
@@ -865,15 +840,13 @@ export class GraphiteDatasource
       params.until = this.translateTime(options.range.to, true, options.timezone);
     }
 
-    const httpOptions: BackendSrvRequest = {
-      method: 'GET',
-      url: '/tags/autoComplete/values',
-      params,
-      // for cancellations
-      requestId: options.requestId,
-    };
-
-    return lastValueFrom(this.doGraphiteRequest(httpOptions).pipe(mapToTags()));
+    return lastValueFrom(
+      this.doGraphiteResourceRequest('tags/autoComplete/values', {
+        params,
+        // for cancellations
+        requestId: options.requestId,
+      }).pipe(mapToTags())
+    );
   }
 
   getVersion(optionalOptions: any) {
@@ -924,16 +897,12 @@ export class GraphiteDatasource
       return this.funcDefsPromise;
     }
 
-    const httpOptions = {
-      method: 'GET',
-      url: '/functions',
-      // add responseType because if this is not defined,
-      // backend_srv defaults to json
-      responseType: 'text' as const,
-    };
-
     return lastValueFrom(
-      this.doGraphiteRequest(httpOptions).pipe(
+      this.doGraphiteResourceRequest('functions', {
+        // add responseType because if this is not defined,
+        // backend_srv defaults to json
+        responseType: 'text',
+      }).pipe(
         map((results: FetchResponse) => {
           // Fix for a Graphite bug: https://github.com/graphite-project/graphite-web/issues/2609
           // There is a fix for it https://github.com/graphite-project/graphite-web/pull/2612 but
@@ -992,16 +961,21 @@ export class GraphiteDatasource
     options.url = this.url + options.url;
     options.inspect = { type: 'graphite' };
 
+    return getBackendSrv().fetch(options).pipe(catchGraphiteError());
+  }
+
+  /**
+   * Sends a GET request to the resource handlers of the Graphite backend, which look up metrics,
+   * tags and functions without going through the data proxy.
+   */
+  doGraphiteResourceRequest(path: string, options: Partial<BackendSrvRequest> = {}) {
     return getBackendSrv()
-      .fetch(options)
-      .pipe(
-        catchError((err) => {
-          return throwError(() => {
-            const reduced = reduceError(err);
-            return new Error(`${reduced.data.message}`);
-          });
-        })
-      );
+      .fetch({
+        ...options,
+        method: 'GET',
+        url: `/api/datasources/uid/${this.uid}/resources/${path}`,
+      })
+      .pipe(catchGraphiteError());
   }
 
   buildGraphiteParams(options: any, originalTargetMap: { [key: string]: string }, scopedVars?: ScopedVars): string[] {
@@ -1099,3 +1073,12 @@ function mapToTags(): OperatorFunction<FetchResponse, Array<{ text: string }>> {
     })
   );
 }
+
+function catchGraphiteError<T>(): OperatorFunction<T, T> {
+  return catchError((err) => {
+    return throwError(() => {
+      const reduced = reduceError(err);
+      return new Error(`${reduced.data.message}`);
+    });
+  });
+}
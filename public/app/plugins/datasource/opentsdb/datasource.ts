@@ -261,7 +261,7 @@ export default class OpenTsDatasource extends DataSourceApi<OpenTsdbQuery, OpenT
   }
 
   _performSuggestQuery(query: string, type: string) {
-    return this._get('/api/suggest', { type, q: query, max: this.lookupLimit }).pipe(
+    return this._getResource('api/suggest', { type, q: query, max: this.lookupLimit }).pipe(
       map((result) => {
         return result.data;
       })
@@ -285,7 +285,7 @@ export default class OpenTsDatasource extends DataSourceApi<OpenTsdbQuery, OpenT
 
     const m = metric + '{' + keysQuery + '}';
 
-    return this._get('/api/search/lookup', { m: m, limit: this.lookupLimit }).pipe(
+    return this._getResource('api/search/lookup', { m: m, limit: this.lookupLimit }).pipe(
       map((result) => {
         result = result.data.results;
         const tagvs: any[] = [];
@@ -304,7 +304,7 @@ export default class OpenTsDatasource extends DataSourceApi<OpenTsdbQuery, OpenT
       return of([]);
     }
 
-    return this._get('/api/search/lookup', { m: metric, limit: 1000 }).pipe(
+    return this._getResource('api/search/lookup', { m: metric, limit: 1000 }).pipe(
       map((result) => {
         result = result.data.results;
         const tagks: any[] = [];
@@ -335,6 +335,19 @@ export default class OpenTsDatasource extends DataSourceApi<OpenTsdbQuery, OpenT
     return getBackendSrv().fetch(options);
   }
 
+  // Metric and tag lookups go through the resource handlers of the backend, which cache the
+  // responses of OpenTSDB per data source.
+  _getResource(
+    path: string,
+    params?: { type?: string; q?: string; max?: number; m?: string; limit?: number }
+  ): Observable<FetchResponse> {
+    return getBackendSrv().fetch({
+      method: 'GET',
+      url: `/api/datasources/uid/${this.uid}/resources/${path}`,
+      params: params,
+    });
+  }
+
   _addCredentialOptions(options: Record<string, unknown>) {
     if (this.basicAuth || this.withCredentials) {
       options.withCredentials = true;
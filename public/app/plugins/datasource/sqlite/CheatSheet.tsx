@@ -0,0 +1,89 @@
+import { css } from '@emotion/css';
+
+import { GrafanaTheme2 } from '@grafana/data';
+import { useStyles2 } from '@grafana/ui';
+
+export function CheatSheet() {
+  const styles = useStyles2(getStyles);
+
+  return (
+    <div>
+      <h2>SQLite cheat sheet</h2>
+      Time series:
+      <ul className={styles.ulPadding}>
+        <li>
+          return column named time or time_sec (in UTC), as a unix time stamp or a text time stamp such as
+          2006-01-02 15:04:05. You can use the macros below.
+        </li>
+        <li>return column(s) with numeric values</li>
+      </ul>
+      Optional:
+      <ul className={styles.ulPadding}>
+        <li>
+          return column named <i>metric</i> to represent the series name.
+        </li>
+        <li>If multiple value columns are returned the metric column is used as prefix.</li>
+        <li>If no column named metric is found the column name of the value column is used as series name</li>
+      </ul>
+      <p>Resultsets of time series queries need to be sorted by time.</p>
+      Table:
+      <ul className={styles.ulPadding}>
+        <li>return any set of columns</li>
+      </ul>
+      Macros for text time stamps:
+      <ul className={styles.ulPadding}>
+        <li>$__time(column) -&gt; unixepoch(column) AS time</li>
+        <li>$__timeEpoch(column) -&gt; unixepoch(column) AS time_sec</li>
+        <li>$__timeFilter(column) -&gt; unixepoch(column) BETWEEN 1492750877 AND 1492750877</li>
+        <li>$__timeFrom() -&gt; datetime(1492750877, &apos;unixepoch&apos;)</li>
+        <li>$__timeTo() -&gt; datetime(1492750877, &apos;unixepoch&apos;)</li>
+        <li>
+          $__timeGroup(column,&apos;5m&apos;[, fillvalue]) -&gt; unixepoch(column) / 300 * 300 by setting fillvalue
+          grafana will fill in missing values according to the interval fillvalue can be either a literal value, NULL
+          or previous; previous will fill in the previous seen value or NULL if none has been seen yet
+        </li>
+        <li>$__timeGroupAlias(column,&apos;5m&apos;) -&gt; unixepoch(column) / 300 * 300 AS &quot;time&quot;</li>
+      </ul>
+      Macros for unix time stamps:
+      <ul className={styles.ulPadding}>
+        <li>$__unixEpochFilter(column) -&gt; column &gt;= 1492750877 AND column &lt;= 1492750877</li>
+        <li>
+          $__unixEpochNanoFilter(column) -&gt; column &gt;= 1494410783152415214 AND column &lt;= 1494497183142514872
+        </li>
+        <li>$__unixEpochGroup(column,&apos;5m&apos;) -&gt; column / 300 * 300</li>
+        <li>$__unixEpochGroupAlias(column,&apos;5m&apos;) -&gt; column / 300 * 300 AS &quot;time&quot;</li>
+      </ul>
+      <p>Example of group by and order by with $__timeGroup:</p>
+      <pre>
+        <code>
+          SELECT $__timeGroupAlias(timestamp_col, &apos;1h&apos;), sum(value_double) AS value
+          <br />
+          FROM yourtable
+          <br />
+          WHERE $__timeFilter(timestamp_col)
+          <br />
+          GROUP BY 1
+          <br />
+          ORDER BY 1
+          <br />
+        </code>
+      </pre>
+      Or build your own conditionals using these macros which just return the values:
+      <ul className={styles.ulPadding}>
+        <li>$__unixEpochFrom() -&gt; 1492750877</li>
+        <li>$__unixEpochTo() -&gt; 1492750877</li>
+        <li>$__unixEpochNanoFrom() -&gt; 1494410783152415214</li>
+        <li>$__unixEpochNanoTo() -&gt; 1494497183142514872</li>
+      </ul>
+    </div>
+  );
+}
+
+function getStyles(theme: GrafanaTheme2) {
+  return {
+    ulPadding: css({
+      margin: theme.spacing(1, 0),
+      paddingLeft: theme.spacing(5),
+    }),
+  };
+}
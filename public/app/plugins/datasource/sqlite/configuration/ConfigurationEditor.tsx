@@ -0,0 +1,47 @@
+import { DataSourcePluginOptionsEditorProps, onUpdateDatasourceJsonDataOption } from '@grafana/data';
+import { ConfigSection, DataSourceDescription } from '@grafana/plugin-ui';
+import { ConnectionLimits, Divider } from '@grafana/sql';
+import { Field, Input } from '@grafana/ui';
+
+import { SQLiteOptions } from '../types';
+
+export const ConfigurationEditor = (props: DataSourcePluginOptionsEditorProps<SQLiteOptions>) => {
+  const { options, onOptionsChange } = props;
+
+  const WIDTH_LONG = 40;
+
+  return (
+    <>
+      <DataSourceDescription
+        dataSourceName="SQLite"
+        docsLink="https://grafana.com/docs/grafana/latest/datasources/sqlite/"
+        hasRequiredFields={true}
+      />
+
+      <Divider />
+
+      <ConfigSection title="Database">
+        <Field
+          label="Path"
+          required
+          description="Path of the database file, relative to the directory allowed by the sqlite_allowed_directory setting of the server. The file is opened in read-only mode."
+        >
+          <Input
+            width={WIDTH_LONG}
+            name="path"
+            type="text"
+            value={options.jsonData.path || ''}
+            onChange={onUpdateDatasourceJsonDataOption(props, 'path')}
+            placeholder="ci/results.db"
+          />
+        </Field>
+      </ConfigSection>
+
+      <Divider />
+
+      <ConfigSection title="Additional settings" isCollapsible>
+        <ConnectionLimits options={options} onOptionsChange={onOptionsChange} />
+      </ConfigSection>
+    </>
+  );
+};
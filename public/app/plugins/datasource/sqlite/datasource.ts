@@ -0,0 +1,83 @@
+import { v4 as uuidv4 } from 'uuid';
+
+import { DataSourceInstanceSettings, TimeRange } from '@grafana/data';
+import { LanguageDefinition } from '@grafana/plugin-ui';
+import { COMMON_FNS, DB, FuncParameter, MACRO_FUNCTIONS, SQLQuery, SqlDatasource, formatSQL } from '@grafana/sql';
+
+import { buildColumnQuery, buildTableQuery, quoteIdentifierIfNecessary, quoteLiteral, toRawSql } from './sqlUtil';
+import { SQLiteOptions } from './types';
+
+// SQLite databases are single files, so the only dataset is the main schema.
+const MAIN_SCHEMA = 'main';
+
+export class SQLiteDatasource extends SqlDatasource {
+  sqlLanguageDefinition: LanguageDefinition | undefined;
+
+  constructor(instanceSettings: DataSourceInstanceSettings<SQLiteOptions>) {
+    super(instanceSettings);
+  }
+
+  getQueryModel() {
+    return { quoteLiteral };
+  }
+
+  getSqlLanguageDefinition(): LanguageDefinition {
+    if (this.sqlLanguageDefinition !== undefined) {
+      return this.sqlLanguageDefinition;
+    }
+
+    this.sqlLanguageDefinition = {
+      id: 'sql',
+      formatter: formatSQL,
+    };
+
+    return this.sqlLanguageDefinition;
+  }
+
+  async fetchTables(): Promise<string[]> {
+    const tables = await this.runSql<string[]>(buildTableQuery(), { refId: 'tables' });
+    return tables.map((t) => quoteIdentifierIfNecessary(t[0]));
+  }
+
+  async fetchFields(query: Partial<SQLQuery>) {
+    if (!query.table) {
+      return [];
+    }
+    const frame = await this.runSql<string[]>(buildColumnQuery(query.table), { refId: `fields-${uuidv4()}` });
+    return frame.map((f) => ({
+      name: f[0],
+      text: f[0],
+      value: quoteIdentifierIfNecessary(f[0]),
+      type: f[1],
+      label: f[0],
+    }));
+  }
+
+  getFunctions = (): ReturnType<DB['functions']> => {
+    const columnParam: FuncParameter = {
+      name: 'Column',
+      required: true,
+      options: (query) => this.fetchFields(query),
+    };
+
+    return [...MACRO_FUNCTIONS(columnParam), ...COMMON_FNS.map((fn) => ({ ...fn, parameters: [columnParam] }))];
+  };
+
+  getDB(): DB {
+    if (this.db !== undefined) {
+      return this.db;
+    }
+
+    return {
+      datasets: () => Promise.resolve([MAIN_SCHEMA]),
+      tables: () => this.fetchTables(),
+      fields: (query: SQLQuery) => this.fetchFields(query),
+      validateQuery: (query: SQLQuery, _range?: TimeRange) =>
+        Promise.resolve({ query, error: '', isError: false, isValid: true }),
+      dsID: () => this.id,
+      toRawSql,
+      functions: () => this.getFunctions(),
+      getEditorLanguageDefinition: () => this.getSqlLanguageDefinition(),
+    };
+  }
+}
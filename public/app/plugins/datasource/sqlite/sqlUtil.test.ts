@@ -0,0 +1,24 @@
+import { buildColumnQuery, quoteIdentifierIfNecessary, toRawSql } from './sqlUtil';
+
+describe('sqlUtil', () => {
+  it('quotes identifiers only when necessary', () => {
+    expect(quoteIdentifierIfNecessary('builds')).toBe('builds');
+    expect(quoteIdentifierIfNecessary('ci results')).toBe('"ci results"');
+    expect(quoteIdentifierIfNecessary('a"b')).toBe('"a""b"');
+  });
+
+  it('escapes the table name of column queries', () => {
+    expect(buildColumnQuery("it's")).toBe(`SELECT name, type FROM pragma_table_info('it''s') ORDER BY cid`);
+  });
+
+  it('builds a raw query without dataset', () => {
+    expect(
+      toRawSql({
+        refId: 'A',
+        dataset: 'main',
+        table: 'builds',
+        sql: { columns: [{ type: 'function', parameters: [{ type: 'functionParameter', name: 'duration' }] }] },
+      })
+    ).toBe('SELECT duration FROM builds ');
+  });
+});